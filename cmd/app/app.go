@@ -75,6 +75,19 @@ func RunApp(config *conf.Configuration) {
 		).Start(ctx, constants.ZaloRefreshTokenWorkerInterval)
 	}
 
+	if interval := conf.GetSigningKeyRotationInterval(); interval > 0 {
+		go workers.NewSigningKeyRotationWorker(
+			ucases.TokenUCase,
+			interval,
+		).Start(ctx, constants.SigningKeyRotationWorkerInterval)
+	}
+
+	go workers.NewAccountDeletionWorker(ucases.AccountDeletionUCase).Start(ctx, constants.AccountDeletionWorkerInterval)
+
+	go workers.NewDataExportWorker(ucases.DataExportUCase).Start(ctx, constants.DataExportWorkerInterval)
+
+	go workers.NewUserImportWorker(ucases.UserImportUCase).Start(ctx, constants.UserImportWorkerInterval)
+
 	// Handle shutdown signals
 	waitForShutdownSignal(cancel)
 }
@@ -119,6 +132,7 @@ func initializeLoggerAndMode(config *conf.Configuration) {
 func initializeRouter() *gin.Engine {
 	r := gin.New()
 	r.Use(middleware.RequestTracingMiddleware())
+	r.Use(middleware.ClientInfoMiddleware())
 	r.Use(middleware.DefaultPagination())
 	r.Use(middleware.RequestLoggerMiddleware())
 	r.Use(middleware.RequestDataGuardMiddleware())
@@ -30,21 +30,89 @@ type RootAccountConfiguration struct {
 }
 
 type Configuration struct {
-	Database        DatabaseConfiguration    `mapstructure:",squash"`
-	Redis           RedisConfiguration       `mapstructure:",squash"`
-	RootAccount     RootAccountConfiguration `mapstructure:",squash"`
-	AppName         string                   `mapstructure:"APP_NAME"`
-	AppPort         uint32                   `mapstructure:"APP_PORT"`
-	Env             string                   `mapstructure:"ENV"`
-	LogLevel        string                   `mapstructure:"LOG_LEVEL"`
-	CacheType       string                   `mapstructure:"CACHE_TYPE"`
-	MockWebhookURL  string                   `mapstructure:"MOCK_WEBHOOK_URL"`
-	DbEncryptionKey string                   `mapstructure:"DB_ENCRYPTION_KEY"`
-	COURIER_API_KEY string                   `mapstructure:"COURIER_API_KEY"`
-	KratosConfig    KratosConfiguration      `mapstructure:",squash"`
-	Keto            KetoConfiguration        `mapstructure:",squash"`
-	Sms             SmsConfiguration         `mapstructure:",squash"`
-	DevReviewer     DevReviewerConfiguration `mapstructure:",squash"`
+	Database        DatabaseConfiguration        `mapstructure:",squash"`
+	Redis           RedisConfiguration           `mapstructure:",squash"`
+	RootAccount     RootAccountConfiguration     `mapstructure:",squash"`
+	AppName         string                       `mapstructure:"APP_NAME"`
+	AppPort         uint32                       `mapstructure:"APP_PORT"`
+	Env             string                       `mapstructure:"ENV"`
+	LogLevel        string                       `mapstructure:"LOG_LEVEL"`
+	CacheType       string                       `mapstructure:"CACHE_TYPE"`
+	MockWebhookURL  string                       `mapstructure:"MOCK_WEBHOOK_URL"`
+	DbEncryptionKey string                       `mapstructure:"DB_ENCRYPTION_KEY"`
+	COURIER_API_KEY string                       `mapstructure:"COURIER_API_KEY"`
+	Password        PasswordConfiguration        `mapstructure:",squash"`
+	OIDC            OIDCConfiguration            `mapstructure:",squash"`
+	SIWE            SIWEConfiguration            `mapstructure:",squash"`
+	Passkey         PasskeyConfiguration         `mapstructure:",squash"`
+	SessionCache    SessionCacheConfiguration    `mapstructure:",squash"`
+	JWT             JWTConfiguration             `mapstructure:",squash"`
+	OIDCProvider    OIDCProviderConfiguration    `mapstructure:",squash"`
+	AccountDeletion AccountDeletionConfiguration `mapstructure:",squash"`
+	DataExport      DataExportConfiguration      `mapstructure:",squash"`
+	TrustedDevice   TrustedDeviceConfiguration   `mapstructure:",squash"`
+	GeoIP           GeoIPConfiguration           `mapstructure:",squash"`
+	Notification    NotificationConfiguration    `mapstructure:",squash"`
+	KratosConfig    KratosConfiguration          `mapstructure:",squash"`
+	Keto            KetoConfiguration            `mapstructure:",squash"`
+	Sms             SmsConfiguration             `mapstructure:",squash"`
+	DevReviewer     DevReviewerConfiguration     `mapstructure:",squash"`
+}
+
+type PasswordConfiguration struct {
+	BreachedPasswordListPath string `mapstructure:"BREACHED_PASSWORD_LIST_PATH"`
+}
+
+type OIDCConfiguration struct {
+	GoogleClientIDs string `mapstructure:"OIDC_GOOGLE_CLIENT_IDS"`
+	GoogleJWKSURL   string `mapstructure:"OIDC_GOOGLE_JWKS_URL"`
+	AppleClientIDs  string `mapstructure:"OIDC_APPLE_CLIENT_IDS"`
+	AppleJWKSURL    string `mapstructure:"OIDC_APPLE_JWKS_URL"`
+}
+
+type SIWEConfiguration struct {
+	AllowedDomains   string `mapstructure:"SIWE_ALLOWED_DOMAINS"`
+	CredentialSecret string `mapstructure:"SIWE_CREDENTIAL_SECRET"`
+}
+
+type PasskeyConfiguration struct {
+	CredentialSecret string `mapstructure:"PASSKEY_CREDENTIAL_SECRET"`
+}
+
+type SessionCacheConfiguration struct {
+	SessionCacheTTL string `mapstructure:"SESSION_CACHE_TTL"`
+}
+
+type JWTConfiguration struct {
+	Issuer              string `mapstructure:"JWT_ISSUER"`
+	Audience            string `mapstructure:"JWT_AUDIENCE"`
+	AccessTokenTTL      string `mapstructure:"JWT_ACCESS_TOKEN_TTL"`
+	KeyRotationInterval string `mapstructure:"JWT_KEY_ROTATION_INTERVAL"`
+}
+
+type OIDCProviderConfiguration struct {
+	BaseURL string `mapstructure:"OIDC_PROVIDER_BASE_URL"`
+}
+
+type AccountDeletionConfiguration struct {
+	GracePeriod string `mapstructure:"ACCOUNT_DELETION_GRACE_PERIOD"`
+}
+
+type DataExportConfiguration struct {
+	LinkSecret string `mapstructure:"DATA_EXPORT_LINK_SECRET"`
+}
+
+type TrustedDeviceConfiguration struct {
+	TokenSecret string `mapstructure:"TRUSTED_DEVICE_TOKEN_SECRET"`
+}
+
+type GeoIPConfiguration struct {
+	DatabasePath string `mapstructure:"GEOIP_DATABASE_PATH"`
+}
+
+type NotificationConfiguration struct {
+	EmailWebhookURL string `mapstructure:"NOTIFICATION_EMAIL_WEBHOOK_URL"`
+	ReportURL       string `mapstructure:"SECURITY_REPORT_URL"`
 }
 
 type TwilioConfiguration struct {
@@ -128,6 +196,26 @@ var defaultConfigurations = map[string]any{
 	"LIFE_SPEEDSMS_ACCESS_TOKEN":     "",
 	"SPEEDSMS_BASE_URL":              "https://api.speedsms.vn/index.php",
 	"COURIER_API_KEY":                "",
+	"BREACHED_PASSWORD_LIST_PATH":    "",
+	"OIDC_GOOGLE_CLIENT_IDS":         "",
+	"OIDC_GOOGLE_JWKS_URL":           "https://www.googleapis.com/oauth2/v3/certs",
+	"OIDC_APPLE_CLIENT_IDS":          "",
+	"OIDC_APPLE_JWKS_URL":            "https://appleid.apple.com/auth/keys",
+	"SIWE_ALLOWED_DOMAINS":           "",
+	"SIWE_CREDENTIAL_SECRET":         "",
+	"PASSKEY_CREDENTIAL_SECRET":      "",
+	"SESSION_CACHE_TTL":              "30s",
+	"JWT_ISSUER":                     "",
+	"JWT_AUDIENCE":                   "",
+	"JWT_ACCESS_TOKEN_TTL":           "15m",
+	"JWT_KEY_ROTATION_INTERVAL":      "720h",
+	"OIDC_PROVIDER_BASE_URL":         "",
+	"ACCOUNT_DELETION_GRACE_PERIOD":  "720h",
+	"DATA_EXPORT_LINK_SECRET":        "",
+	"TRUSTED_DEVICE_TOKEN_SECRET":    "",
+	"GEOIP_DATABASE_PATH":            "",
+	"NOTIFICATION_EMAIL_WEBHOOK_URL": "",
+	"SECURITY_REPORT_URL":            "",
 }
 
 // loadDefaultConfigs sets default values for critical configurations
@@ -1,6 +1,11 @@
 package conf
 
-import "github.com/lifenetwork-ai/iam-service/constants"
+import (
+	"strings"
+	"time"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+)
 
 func GetConfiguration() *Configuration {
 	return &configuration
@@ -49,6 +54,128 @@ func GetCourierAPIKey() string {
 	return configuration.COURIER_API_KEY
 }
 
+func GetBreachedPasswordListPath() string {
+	return configuration.Password.BreachedPasswordListPath
+}
+
+func GetOIDCConfiguration() *OIDCConfiguration {
+	return &configuration.OIDC
+}
+
+// GetSIWEAllowedDomains returns the domains wallet sign-in messages may be issued for.
+// Wallet sign-in is refused while the list is empty.
+func GetSIWEAllowedDomains() []string {
+	var domains []string
+	for _, d := range strings.Split(configuration.SIWE.AllowedDomains, ",") {
+		if d = strings.TrimSpace(d); d != "" {
+			domains = append(domains, d)
+		}
+	}
+	return domains
+}
+
+func GetSIWECredentialSecret() string {
+	return configuration.SIWE.CredentialSecret
+}
+
+func GetPasskeyCredentialSecret() string {
+	return configuration.Passkey.CredentialSecret
+}
+
+// GetSessionCacheTTL returns how long a validated session may be served from cache.
+// Zero disables the cache.
+func GetSessionCacheTTL() time.Duration {
+	ttl, err := time.ParseDuration(configuration.SessionCache.SessionCacheTTL)
+	if err != nil || ttl < 0 {
+		return 0
+	}
+	return ttl
+}
+
+// GetJWTIssuer returns the issuer of access tokens, the app name unless configured
+func GetJWTIssuer() string {
+	if configuration.JWT.Issuer != "" {
+		return configuration.JWT.Issuer
+	}
+	return configuration.AppName
+}
+
+// GetJWTAudience returns the audiences access tokens are issued for
+func GetJWTAudience() []string {
+	var audience []string
+	for _, a := range strings.Split(configuration.JWT.Audience, ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			audience = append(audience, a)
+		}
+	}
+	return audience
+}
+
+// GetAccessTokenTTL returns the lifetime of access tokens, 15 minutes unless configured
+func GetAccessTokenTTL() time.Duration {
+	ttl, err := time.ParseDuration(configuration.JWT.AccessTokenTTL)
+	if err != nil || ttl <= 0 {
+		return 15 * time.Minute
+	}
+	return ttl
+}
+
+// GetSigningKeyRotationInterval returns how old the signing key may get before it is rotated.
+// Zero disables automatic rotation.
+func GetSigningKeyRotationInterval() time.Duration {
+	interval, err := time.ParseDuration(configuration.JWT.KeyRotationInterval)
+	if err != nil || interval < 0 {
+		return 0
+	}
+	return interval
+}
+
+// GetOIDCProviderBaseURL returns the public URL tenants' OpenID Connect issuers live under,
+// without a trailing slash. Empty disables the provider.
+func GetOIDCProviderBaseURL() string {
+	return strings.TrimRight(configuration.OIDCProvider.BaseURL, "/")
+}
+
+// GetAccountDeletionGracePeriod returns how long a requested account deletion can be cancelled
+// before the account is erased, 30 days unless configured. Zero erases accounts right away.
+func GetAccountDeletionGracePeriod() time.Duration {
+	period, err := time.ParseDuration(configuration.AccountDeletion.GracePeriod)
+	if err != nil || period < 0 {
+		return 30 * 24 * time.Hour
+	}
+	return period
+}
+
+// GetDataExportLinkSecret returns the secret data export download links are signed with.
+// Empty disables data exports.
+func GetDataExportLinkSecret() string {
+	return configuration.DataExport.LinkSecret
+}
+
+// GetTrustedDeviceTokenSecret returns the secret trusted device tokens are signed with.
+// Empty disables trusted devices.
+func GetTrustedDeviceTokenSecret() string {
+	return configuration.TrustedDevice.TokenSecret
+}
+
+// GetGeoIPDatabasePath returns the local IP-to-country file used to locate logins.
+// Empty leaves every login without a country.
+func GetGeoIPDatabasePath() string {
+	return configuration.GeoIP.DatabasePath
+}
+
+// GetNotificationEmailWebhookURL returns the endpoint security notices for email identifiers are
+// posted to. Empty leaves email identifiers without notices.
+func GetNotificationEmailWebhookURL() string {
+	return configuration.Notification.EmailWebhookURL
+}
+
+// GetSecurityReportURL returns the page security notices link to for reporting a change the
+// user did not make. Empty sends notices without the link.
+func GetSecurityReportURL() string {
+	return configuration.Notification.ReportURL
+}
+
 // SetEnvironmentForTesting sets the environment for testing purposes
 // WARNING: This should only be used in tests!
 func SetEnvironmentForTesting(env string) {
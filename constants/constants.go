@@ -39,6 +39,10 @@ const (
 // DefaultChallengeDuration is the default duration for a challenge session
 const DefaultChallengeDuration = 5 * time.Minute
 
+// ChallengeResendCooldown is how long after a code was sent the user waits before another one
+// can be sent for the same challenge
+const ChallengeResendCooldown = 60 * time.Second
+
 // Challenge Types
 const (
 	ChallengeTypeLogin            = "login"
@@ -46,6 +50,272 @@ const (
 	ChallengeTypeChangeIdentifier = "change_identifier"
 	ChallengeTypeAddIdentifier    = "add_identifier"
 	ChallengeTypeVerifyIdentifier = "verify_identifier"
+	ChallengeTypeRecovery         = "recovery"
+	ChallengeTypeWallet           = "wallet"
+	ChallengeTypeMFA              = "mfa"
+	ChallengeTypePasskeyRegister  = "passkey_register"
+	ChallengeTypePasskeyLogin     = "passkey_login"
+	ChallengeTypeDeleteAccount    = "delete_account"
+	ChallengeTypeLoginVerify      = "login_verify"
+)
+
+// Password policy
+const (
+	DefaultPasswordMinLength = 8
+	MinPasswordMinLength     = 6
+	MaxPasswordLength        = 72 // bcrypt ignores anything beyond 72 bytes
+)
+
+// Session refresh
+// Purposes the database encryption key is derived for; each protects one kind of data
+const (
+	KeyPurposeMFASecret            = "iam-service/mfa-secret"
+	KeyPurposeSigningKey           = "iam-service/signing-key"
+	KeyPurposeDataExport           = "iam-service/data-export"
+	KeyPurposeIdentifierQuarantine = "iam-service/identifier-quarantine"
+)
+
+const (
+	DefaultSessionMaxLifetime = 30 * 24 * time.Hour
+	MinSessionMaxLifetime     = 5 * time.Minute
+	RefreshTokenBytes         = 32
+	SessionActivityInterval   = 1 * time.Minute // granularity of a session's last-seen time
+)
+
+// Trusted devices
+const (
+	TrustedDeviceNonceBytes       = 32
+	MaxTrustedDevicesPerUser      = 20 // the oldest device stops being trusted when another one is remembered
+	TrustedDeviceActivityInterval = 1 * time.Hour
+)
+
+// What a login from a new device or country triggers
+const (
+	LoginRiskActionNone   = "none"   // nothing, the login is not even flagged
+	LoginRiskActionEvent  = "event"  // the login event is flagged
+	LoginRiskActionVerify = "verify" // the login event is flagged and the user must enter a second code
+)
+
+// Quarantine of identifiers taken off an account
+const (
+	IdentifierQuarantineActionBlock     = "block"    // other accounts cannot register the identifier until the quarantine ends
+	IdentifierQuarantineActionApproval  = "approval" // other accounts can register it once an administrator releases it
+	IdentifierQuarantineReasonDeleted   = "deleted"
+	IdentifierQuarantineReasonChanged   = "changed"
+	IdentifierQuarantineReasonErased    = "erased"
+	IdentifierQuarantineDefaultPageSize = 20
+	IdentifierQuarantineMaxPageSize     = 100
+)
+
+// Security notices sent to a user's identifiers
+const (
+	SecurityEventIdentifierAdded   = "identifier_added"
+	SecurityEventIdentifierChanged = "identifier_changed"
+	SecurityEventIdentifierDeleted = "identifier_deleted"
+	SecurityEventNewDeviceLogin    = "new_device_login"
+	SecurityReportTokenBytes       = 32
+	SecurityReportLinkTTL          = 7 * 24 * time.Hour
+	// Who freezes an account reported through a security notice
+	SecurityReportActor = "security_report"
+)
+
+// Login history pages
+const (
+	LoginHistoryDefaultPageSize = 20
+	LoginHistoryMaxPageSize     = 100
+)
+
+// Social sign-in
+const (
+	OIDCJWKSCacheTTL        = 1 * time.Hour
+	OIDCJWKSRefreshInterval = 1 * time.Minute // minimum gap between refetches on an unknown key ID
+	OIDCClockSkew           = 1 * time.Minute
+)
+
+// Access tokens
+const (
+	AccessTokenType    = "Bearer"
+	AccessTokenJWTType = "at+jwt" // RFC 9068 typ header
+	SigningKeyCacheTTL = 1 * time.Minute
+	JWKSMaxAge         = 5 * time.Minute
+	// New signing keys are published this long before they sign anything, so verifiers
+	// caching the key set already hold them; must exceed JWKSMaxAge plus SigningKeyCacheTTL
+	SigningKeyActivationDelay        = 10 * time.Minute
+	SigningKeyRotationWorkerInterval = 1 * time.Hour
+)
+
+// Service clients
+const (
+	OAuthClientIDBytes     = 16
+	OAuthClientSecretBytes = 32
+	// Active introspection results are cached until the session expires, but no longer than
+	// this, so sessions ended outside this service are noticed eventually
+	IntrospectionCacheMaxTTL = 1 * time.Hour
+)
+
+// OpenID Connect provider
+const (
+	OIDCAuthorizationRequestTTL = 10 * time.Minute // time the user has to sign in and consent
+	OIDCAuthorizationCodeTTL    = 1 * time.Minute
+	OIDCAuthorizationCodeBytes  = 32
+	IDTokenJWTType              = "JWT"
+	PKCEMethodS256              = "S256"
+)
+
+// Scopes a client can request from the OpenID Connect provider
+const (
+	OIDCScopeOpenID  = "openid"
+	OIDCScopeProfile = "profile"
+	OIDCScopeEmail   = "email"
+	OIDCScopePhone   = "phone"
+)
+
+// Account deletion
+const (
+	AccountDeletionWorkerInterval = 5 * time.Minute
+	AccountDeletionBatchSize      = 20
+	// A job being processed is pushed back this far, so another instance does not pick it up,
+	// and a failed job is retried after it
+	AccountDeletionRetryDelay = 15 * time.Minute
+)
+
+// Account deletion states
+const (
+	AccountDeletionStatusPending   = "pending"
+	AccountDeletionStatusCompleted = "completed"
+	AccountDeletionStatusCancelled = "cancelled"
+	// AccountDeletionRequestedByUser marks a deletion the user asked for; administrators are
+	// recorded as "admin:<username>"
+	AccountDeletionRequestedByUser = "user"
+)
+
+// Personal data exports
+const (
+	DataExportWorkerInterval = 1 * time.Minute
+	DataExportBatchSize      = 10
+	DataExportRetryDelay     = 5 * time.Minute
+	DataExportMaxAttempts    = 5
+	DataExportRetention      = 24 * time.Hour   // a generated export is deleted after this
+	DataExportLinkTTL        = 15 * time.Minute // lifetime of a signed download link
+)
+
+// Data export states
+const (
+	DataExportStatusPending = "pending"
+	DataExportStatusReady   = "ready"
+	DataExportStatusFailed  = "failed"
+)
+
+// Identity history actions
+const (
+	IdentityChangeActionAdd    = "add"
+	IdentityChangeActionChange = "change"
+	IdentityChangeActionDelete = "delete"
+	IdentityChangeActionLang   = "lang_change"
+)
+
+// Who made an identity change. Changes the service makes on its own, such as cleaning up an
+// orphaned identifier, are recorded as the system.
+const (
+	IdentityChangeActorUser   = "user"
+	IdentityChangeActorAdmin  = "admin"
+	IdentityChangeActorSystem = "system"
+)
+
+// Identity history pages
+const (
+	IdentityHistoryDefaultPageSize = 20
+	IdentityHistoryMaxPageSize     = 100
+)
+
+// Admin user directory
+const (
+	TenantUserDefaultLimit = 20
+	TenantUserMaxLimit     = 100
+	// How many history entries the user detail shows
+	TenantUserRecentChanges = 20
+	// How many logins the user detail shows
+	TenantUserRecentLogins = 20
+	// How many users the tenant user export loads at a time
+	TenantUserExportBatchSize = 500
+)
+
+// Account statuses of a user in a tenant
+const (
+	UserStatusActive    = "active"
+	UserStatusSuspended = "suspended"
+	UserStatusBanned    = "banned"
+)
+
+// Bulk user imports
+const (
+	UserImportWorkerInterval = 10 * time.Second
+	UserImportJobsPerRun     = 5
+	UserImportBatchSize      = 100 // rows processed per job each run, so a large import does not hold up the others
+	UserImportRetryDelay     = 5 * time.Minute
+	UserImportMaxAttempts    = 5
+	UserImportMaxRows        = 10000
+	UserImportMaxBytes       = 5 << 20
+	UserImportDefaultLimit   = 100
+	UserImportMaxLimit       = 1000
+)
+
+// Formats of bulk user imports and exports
+const (
+	BulkFormatCSV    = "csv"
+	BulkFormatNDJSON = "ndjson"
+)
+
+// Bulk user import states
+const (
+	UserImportStatusPending   = "pending"
+	UserImportStatusCompleted = "completed"
+	UserImportStatusFailed    = "failed"
+)
+
+// Outcomes of a row of a bulk user import. A dry run leaves the rows it would import as valid.
+const (
+	UserImportRowPending  = "pending"
+	UserImportRowCreated  = "created"
+	UserImportRowExisting = "existing"
+	UserImportRowValid    = "valid"
+	UserImportRowFailed   = "failed"
+)
+
+// Invitations
+const (
+	InvitationTTL = 7 * 24 * time.Hour
+)
+
+// Invitation states
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusRevoked  = "revoked"
+	InvitationStatusExpired  = "expired"
+)
+
+// Wallet sign-in
+const (
+	SIWEClockSkew = 1 * time.Minute
+)
+
+// Authenticator assurance levels of an issued session
+const (
+	AAL1 = "aal1"
+	AAL2 = "aal2"
+)
+
+// Multi-factor authentication
+const (
+	MFAFactorTOTP         = "totp"
+	MFAMethodRecoveryCode = "recovery_code"
+	MFAMethodLoginCode    = "verification_code" // a code sent to another identifier of a user signing in from a new device or country
+	TOTPSecretBytes       = 20
+	TOTPSkewSteps         = 1 // accept the previous and next 30-second code to absorb clock drift
+	MFARecoveryCodeCount  = 10
+	MFARecoveryCodeLength = 10
+	MFAChallengeDuration  = 5 * time.Minute
 )
 
 // HTTP Headers
@@ -62,6 +332,7 @@ const (
 	ChannelZalo     = "zalo"
 	ChannelSpeedSMS = "speedsms"
 	ChannelWebhook  = "webhook"
+	ChannelEmail    = "email" // codes sent to an email address; not a choice for phone numbers
 
 	// Retry related constants
 	MaxOTPRetryCount   = 5
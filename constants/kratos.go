@@ -13,6 +13,10 @@ const (
 	IdentifierUsername IdentifierType = "username"
 	IdentifierTenant   IdentifierType = "tenant"
 	IdentifierLang     IdentifierType = "lang"
+	IdentifierGoogle   IdentifierType = "google"
+	IdentifierApple    IdentifierType = "apple"
+	IdentifierWallet   IdentifierType = "wallet"
+	IdentifierPasskey  IdentifierType = "passkey"
 )
 
 // MethodType represents the types of login/registration/setting methods
@@ -26,6 +30,7 @@ const (
 	MethodTypePassword MethodType = "password"
 	MethodTypeCode     MethodType = "code"
 	MethodTypeProfile  MethodType = "profile"
+	MethodTypeOIDC     MethodType = "oidc"
 )
 
 // FlowType represents the types of flows in the identity service
@@ -5,6 +5,9 @@ type contextKey string
 const (
 	SessionTokenKey contextKey = "session_token"
 	UserContextKey  contextKey = "user"
+	ClientIPKey     contextKey = "client_ip"
+	UserAgentKey    contextKey = "user_agent"
+	DeviceTokenKey  contextKey = "device_token"
 )
 
 const (
@@ -13,3 +16,6 @@ const (
 )
 
 const TenantHeaderKey = "X-Tenant-ID"
+
+// DeviceTokenHeaderKey carries the token of a trusted device on sign-in requests
+const DeviceTokenHeaderKey = "X-Device-Token"
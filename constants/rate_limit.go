@@ -10,6 +10,23 @@ const (
 
 // Actions for rate limiting
 const (
-	LoginWithPhoneAction = "login_phone"
-	LoginWithEmailAction = "login_email"
+	LoginWithPhoneAction    = "login_phone"
+	LoginWithEmailAction    = "login_email"
+	LoginWithPasswordAction = "login_password"
+	PasswordRecoveryAction  = "password_recovery"
+	SessionRefreshAction    = "session_refresh"
+	LoginWithOIDCAction     = "login_oidc"
+	LoginWithWalletAction   = "login_wallet"
+	MFAVerifyAction         = "mfa_verify"
+	LoginWithPasskeyAction  = "login_passkey"
+	AcceptInvitationAction  = "accept_invitation"
+)
+
+// Wrong OTP codes entered for an identifier across its challenge flows. Reaching the limit locks
+// the identifier out; each lockout in a row lasts twice as long as the one before, up to the maximum.
+const (
+	OTPMaxFailedAttempts   = 5
+	OTPLockoutBaseDuration = 15 * time.Minute
+	OTPLockoutMaxDuration  = 24 * time.Hour
+	OTPFailureResetAfter   = 24 * time.Hour // without a wrong code for this long, past failures and lockouts are forgotten
 )
@@ -33,6 +33,12 @@ func (repo *cachingRepository) SaveItem(key fmt.Stringer, val interface{}, expir
 	return repo.client.Set(repo.ctx, prefixedKey, val, expire)
 }
 
+// SaveItemIfAbsent saves an item to the cache unless the key already holds one
+func (repo *cachingRepository) SaveItemIfAbsent(key fmt.Stringer, val interface{}, expire time.Duration) (bool, error) {
+	prefixedKey := repo.prependAppPrefix(key.String())
+	return repo.client.SetNX(repo.ctx, prefixedKey, val, expire)
+}
+
 // RetrieveItem retrieves an item from the cache
 func (repo *cachingRepository) RetrieveItem(key fmt.Stringer, val interface{}) error {
 	prefixedKey := repo.prependAppPrefix(key.String())
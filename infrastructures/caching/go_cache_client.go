@@ -28,6 +28,14 @@ func (c *goCacheClient) Set(ctx context.Context, key string, value interface{},
 	return nil
 }
 
+// SetNX adds an item to the cache unless an unexpired item already has the key
+func (c *goCacheClient) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	if err := c.cache.Add(key, value, expiration); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
 func (c *goCacheClient) Get(ctx context.Context, key string, dest interface{}) error {
 	cachedValue, found := c.cache.Get(key)
 	if !found {
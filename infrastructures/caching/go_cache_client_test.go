@@ -118,6 +118,25 @@ func TestGoCacheClient_Del(t *testing.T) {
 	})
 }
 
+func TestGoCacheClient_SetNX(t *testing.T) {
+	client := caching.NewGoCacheClient(instances.GoCacheClientInstance())
+	ctx := context.Background()
+	key := "GoCacheClient_SetNX_Key"
+
+	set, err := client.SetNX(ctx, key, "first", 5*time.Minute)
+	require.NoError(t, err)
+	require.True(t, set)
+
+	// The key is taken, so the second value is dropped
+	set, err = client.SetNX(ctx, key, "second", 5*time.Minute)
+	require.NoError(t, err)
+	require.False(t, set)
+
+	dest := ""
+	require.NoError(t, client.Get(ctx, key, &dest))
+	require.Equal(t, "first", dest)
+}
+
 func TestGoCacheClient_CacheMapValue(t *testing.T) {
 	client := caching.NewGoCacheClient(instances.GoCacheClientInstance())
 	ctx := context.Background()
@@ -53,6 +53,25 @@ func (r *redisCacheClient) Set(ctx context.Context, key string, value interface{
 	return err
 }
 
+// SetNX stores a key-value pair in Redis with expiration unless the key already exists
+func (r *redisCacheClient) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	if expiration == 0 {
+		expiration = r.ttl
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		logger.GetLogger().Errorf("Failed to marshal cache value for key: %s", key)
+		return false, err
+	}
+
+	set, err := r.client.SetNX(ctx, key, data, expiration).Result()
+	if err != nil {
+		logger.GetLogger().Errorf("Failed to set cache in Redis for key: %s", key)
+	}
+	return set, err
+}
+
 // Get retrieves a value from Redis and assigns it to the destination
 func (r *redisCacheClient) Get(ctx context.Context, key string, dest interface{}) error {
 	// Validate that dest is a pointer and is not nil
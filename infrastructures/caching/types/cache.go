@@ -27,12 +27,16 @@ func (k *Keyer) String() string {
 
 type CacheClient interface {
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	// SetNX stores the value only if the key is absent, reporting whether it did
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
 	Get(ctx context.Context, key string, dest interface{}) error
 	Del(ctx context.Context, key string) error
 }
 
 type CacheRepository interface {
 	SaveItem(key fmt.Stringer, val interface{}, expire time.Duration) error
+	// SaveItemIfAbsent saves the item only if the key is absent, reporting whether it did
+	SaveItemIfAbsent(key fmt.Stringer, val interface{}, expire time.Duration) (bool, error)
 	RetrieveItem(key fmt.Stringer, val interface{}) error
 	RemoveItem(key fmt.Stringer) error
 }
@@ -84,6 +84,21 @@ func (mr *MockCacheClientMockRecorder) Set(ctx, key, value, expiration any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockCacheClient)(nil).Set), ctx, key, value, expiration)
 }
 
+// SetNX mocks base method.
+func (m *MockCacheClient) SetNX(ctx context.Context, key string, value any, expiration time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNX", ctx, key, value, expiration)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetNX indicates an expected call of SetNX.
+func (mr *MockCacheClientMockRecorder) SetNX(ctx, key, value, expiration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNX", reflect.TypeOf((*MockCacheClient)(nil).SetNX), ctx, key, value, expiration)
+}
+
 // MockCacheRepository is a mock of CacheRepository interface.
 type MockCacheRepository struct {
 	ctrl     *gomock.Controller
@@ -149,3 +164,18 @@ func (mr *MockCacheRepositoryMockRecorder) SaveItem(key, val, expire any) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveItem", reflect.TypeOf((*MockCacheRepository)(nil).SaveItem), key, val, expire)
 }
+
+// SaveItemIfAbsent mocks base method.
+func (m *MockCacheRepository) SaveItemIfAbsent(key fmt.Stringer, val any, expire time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveItemIfAbsent", key, val, expire)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveItemIfAbsent indicates an expected call of SaveItemIfAbsent.
+func (mr *MockCacheRepositoryMockRecorder) SaveItemIfAbsent(key, val, expire any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveItemIfAbsent", reflect.TypeOf((*MockCacheRepository)(nil).SaveItemIfAbsent), key, val, expire)
+}
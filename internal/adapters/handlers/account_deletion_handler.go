@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lifenetwork-ai/iam-service/internal/delivery/dto"
+	"github.com/lifenetwork-ai/iam-service/internal/delivery/http/middleware"
+	interfaces "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	httpresponse "github.com/lifenetwork-ai/iam-service/packages/http/response"
+)
+
+type accountDeletionHandler struct {
+	ucase interfaces.AccountDeletionUseCase
+}
+
+func NewAccountDeletionHandler(ucase interfaces.AccountDeletionUseCase) *accountDeletionHandler {
+	return &accountDeletionHandler{
+		ucase: ucase,
+	}
+}
+
+// ChallengeAccountDeletion sends the code confirming an account deletion.
+// @Summary Start account deletion
+// @Description Send a code to the user's email, or phone when they have none, to confirm deleting the account
+// @Tags users
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Success 200 {object} response.SuccessResponse{data=types.IdentityUserChallengeResponse} "Code sent"
+// @Failure 400 {object} response.ErrorResponse "No email or phone number to send the code to"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 429 {object} response.ErrorResponse "Rate limit exceeded"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/deletion/challenge [post]
+func (h *accountDeletionHandler) ChallengeAccountDeletion(ctx *gin.Context) {
+	tenantID, user, ok := tenantAndUserFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	response, usecaseErr := h.ucase.ChallengeAccountDeletion(ctx.Request.Context(), tenantID, user)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
+
+// DeleteAccount schedules the deletion of the current user's account.
+// @Summary Delete account
+// @Description Confirm the deletion with the code sent by the deletion challenge. The user is signed out everywhere, and the account is erased once the grace period has passed unless the deletion is cancelled.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Param body body dto.IdentityUserDeleteAccountDTO true "Deletion challenge and code"
+// @Success 202 {object} response.SuccessResponse{data=types.AccountDeletionResponse} "Deletion scheduled"
+// @Failure 400 {object} response.ErrorResponse "Invalid payload or code"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Challenge not found"
+// @Failure 409 {object} response.ErrorResponse "Deletion already scheduled"
+// @Failure 429 {object} response.ErrorResponse "Rate limit exceeded"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me [delete]
+func (h *accountDeletionHandler) DeleteAccount(ctx *gin.Context) {
+	tenantID, user, ok := tenantAndUserFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.IdentityUserDeleteAccountDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_PAYLOAD", "Invalid payload", err)
+		return
+	}
+
+	response, usecaseErr := h.ucase.RequestAccountDeletion(ctx.Request.Context(), tenantID, user, req.FlowID, req.Code)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusAccepted, response)
+}
+
+// GetAccountDeletion returns the current user's pending deletion.
+// @Summary Get pending account deletion
+// @Description Return when the account is going to be erased
+// @Tags users
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Success 200 {object} response.SuccessResponse{data=types.AccountDeletionResponse} "Pending deletion"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "No pending deletion"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/deletion [get]
+func (h *accountDeletionHandler) GetAccountDeletion(ctx *gin.Context) {
+	tenantID, user, ok := tenantAndUserFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	response, usecaseErr := h.ucase.GetAccountDeletion(ctx.Request.Context(), tenantID, user.GlobalUserID)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
+
+// CancelAccountDeletion keeps the current user's account.
+// @Summary Cancel account deletion
+// @Description Cancel a pending deletion during its grace period
+// @Tags users
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Success 200 {object} response.SuccessResponse "Deletion cancelled"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "No pending deletion"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/deletion/cancel [post]
+func (h *accountDeletionHandler) CancelAccountDeletion(ctx *gin.Context) {
+	tenantID, user, ok := tenantAndUserFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	if usecaseErr := h.ucase.CancelAccountDeletion(ctx.Request.Context(), tenantID, user.GlobalUserID); usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, nil)
+}
+
+// DeleteUserAdmin schedules the deletion of a tenant's user.
+// @Summary Delete a user
+// @Security BasicAuth
+// @Description Sign the user out everywhere and erase the account after the grace period, or right away with immediate=true
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param global_user_id path string true "Global user ID"
+// @Param immediate query bool false "Skip the grace period"
+// @Success 202 {object} response.SuccessResponse{data=types.AccountDeletionResponse} "Deletion scheduled"
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse "User not found"
+// @Failure 409 {object} response.ErrorResponse "Deletion already scheduled"
+// @Router /api/v1/admin/tenants/{id}/users/{global_user_id} [delete]
+func (h *accountDeletionHandler) DeleteUserAdmin(ctx *gin.Context) {
+	tenantID, ok := adminTenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+	globalUserID, err := uuid.Parse(ctx.Param("global_user_id"))
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_USER_ID", "Invalid user ID", err)
+		return
+	}
+
+	response, usecaseErr := h.ucase.ScheduleAccountDeletion(
+		ctx.Request.Context(),
+		tenantID,
+		globalUserID.String(),
+		"admin:"+middleware.GetAdminUsernameFromContext(ctx),
+		ctx.Query("immediate") == "true",
+	)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusAccepted, response)
+}
+
+// ListAccountDeletions lists a tenant's account deletions.
+// @Summary List account deletions
+// @Security BasicAuth
+// @Description List the tenant's requested account deletions, newest first, with the error of the last failed attempt
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Success 200 {object} response.SuccessResponse{data=[]types.AccountDeletionResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Router /api/v1/admin/tenants/{id}/account-deletions [get]
+func (h *accountDeletionHandler) ListAccountDeletions(ctx *gin.Context) {
+	tenantID, ok := adminTenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	response, usecaseErr := h.ucase.ListAccountDeletions(ctx.Request.Context(), tenantID)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
+
+// adminTenantIDFromPath parses the tenant of an admin tenant route
+func adminTenantIDFromPath(ctx *gin.Context) (uuid.UUID, bool) {
+	tenantID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT_ID_FORMAT", "Invalid tenant ID format", err)
+		return uuid.Nil, false
+	}
+	return tenantID, true
+}
@@ -1,13 +1,16 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/lifenetwork-ai/iam-service/constants"
 	dto "github.com/lifenetwork-ai/iam-service/internal/delivery/dto"
 	"github.com/lifenetwork-ai/iam-service/internal/delivery/http/middleware"
 	interfaces "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
 	httpresponse "github.com/lifenetwork-ai/iam-service/packages/http/response"
 	"github.com/lifenetwork-ai/iam-service/packages/logger"
 )
@@ -245,6 +248,90 @@ func (h *adminHandler) DeleteTenant(ctx *gin.Context) {
 	httpresponse.Success(ctx, http.StatusOK, response)
 }
 
+// GetTenantSetting returns the settings of a tenant
+// @Summary Get tenant settings
+// @Security BasicAuth
+// @Description Get a tenant's authentication settings, including its password policy
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Success 200 {object} response.SuccessResponse{data=domain.TenantSetting}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Router /api/v1/admin/tenants/{id}/settings [get]
+func (h *adminHandler) GetTenantSetting(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		httpresponse.Error(
+			ctx,
+			http.StatusBadRequest,
+			"MSG_INVALID_TENANT_ID",
+			"Invalid tenant ID",
+			nil,
+		)
+		return
+	}
+
+	response, errResponse := h.adminUCase.GetTenantSetting(ctx, id)
+	if errResponse != nil {
+		handleDomainError(ctx, errResponse)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
+
+// UpdateTenantSetting updates the settings of a tenant
+// @Summary Update tenant settings
+// @Security BasicAuth
+// @Description Update a tenant's authentication settings. Omitted fields are left unchanged.
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param settings body dto.UpdateTenantSettingPayloadDTO true "Tenant settings"
+// @Success 200 {object} response.SuccessResponse{data=domain.TenantSetting}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Router /api/v1/admin/tenants/{id}/settings [put]
+func (h *adminHandler) UpdateTenantSetting(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		httpresponse.Error(
+			ctx,
+			http.StatusBadRequest,
+			"MSG_INVALID_TENANT_ID",
+			"Invalid tenant ID",
+			nil,
+		)
+		return
+	}
+
+	var payload dto.UpdateTenantSettingPayloadDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		logger.GetLogger().Errorf("Invalid payload: %v", err)
+		httpresponse.Error(
+			ctx,
+			http.StatusBadRequest,
+			"MSG_INVALID_PAYLOAD",
+			"Invalid request payload",
+			err,
+		)
+		return
+	}
+
+	response, errResponse := h.adminUCase.UpdateTenantSetting(ctx, id, payload)
+	if errResponse != nil {
+		handleDomainError(ctx, errResponse)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
+
 // @Summary Check if an identifier is registered in this tenant
 // @Security BasicAuth
 // @Description Return true if the given identifier (email/phone) already exists in this tenant.
@@ -311,7 +398,7 @@ func (h *adminHandler) AddIdentifierAdmin(ctx *gin.Context) {
 		return
 	}
 
-	resp, derr := h.adminUCase.AddIdentifierAdmin(ctx, tenant.ID, req)
+	resp, derr := h.adminUCase.AddIdentifierAdmin(ctx, tenant.ID, req, middleware.GetAdminUsernameFromContext(ctx))
 	if derr != nil {
 		handleDomainError(ctx, derr)
 		return
@@ -319,3 +406,214 @@ func (h *adminHandler) AddIdentifierAdmin(ctx *gin.Context) {
 
 	httpresponse.Success(ctx, http.StatusCreated, resp)
 }
+
+// CreateOAuthClient registers a client with a tenant
+// @Summary Register an OAuth client
+// @Security BasicAuth
+// @Description Register a backend service that can introspect the tenant's tokens, or an app that signs users in through the tenant's OpenID Connect provider. Public clients get no secret and must use PKCE. The client secret is only returned in this response.
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param client body dto.CreateOAuthClientPayloadDTO true "Client details"
+// @Success 201 {object} response.SuccessResponse{data=dto.OAuthClientCredentialsDTO}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Router /api/v1/admin/tenants/{id}/oauth-clients [post]
+func (h *adminHandler) CreateOAuthClient(ctx *gin.Context) {
+	var payload dto.CreateOAuthClientPayloadDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_PAYLOAD", "Invalid request payload", err)
+		return
+	}
+
+	response, errResponse := h.adminUCase.CreateOAuthClient(ctx, ctx.Param("id"), payload)
+	if errResponse != nil {
+		handleDomainError(ctx, errResponse)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusCreated, response)
+}
+
+// ListOAuthClients lists the OAuth clients of a tenant
+// @Summary List OAuth clients
+// @Security BasicAuth
+// @Description List the clients registered with a tenant, including revoked ones
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Success 200 {object} response.SuccessResponse{data=[]domain.OAuthClient}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Router /api/v1/admin/tenants/{id}/oauth-clients [get]
+func (h *adminHandler) ListOAuthClients(ctx *gin.Context) {
+	response, errResponse := h.adminUCase.ListOAuthClients(ctx, ctx.Param("id"))
+	if errResponse != nil {
+		handleDomainError(ctx, errResponse)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
+
+// RevokeOAuthClient revokes an OAuth client
+// @Summary Revoke an OAuth client
+// @Security BasicAuth
+// @Description Stop a client from authenticating and signing users in. Revoking a revoked client succeeds.
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param client_id path string true "Client ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse
+// @Router /api/v1/admin/tenants/{id}/oauth-clients/{client_id} [delete]
+func (h *adminHandler) RevokeOAuthClient(ctx *gin.Context) {
+	if errResponse := h.adminUCase.RevokeOAuthClient(ctx, ctx.Param("id"), ctx.Param("client_id")); errResponse != nil {
+		handleDomainError(ctx, errResponse)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, nil)
+}
+
+// ListTenantUsers lists a tenant's users
+// @Summary List a tenant's users
+// @Security BasicAuth
+// @Description List the tenant's users, newest first, with their identifiers. Pass next_cursor from a page as cursor to get the next one.
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param q query string false "Part of an email address or phone number"
+// @Param created_from query string false "Only users who joined the tenant at or after this time (RFC 3339)"
+// @Param created_to query string false "Only users who joined the tenant before this time (RFC 3339)"
+// @Param cursor query string false "Cursor from the previous page"
+// @Param limit query int false "Page size (default: 20, max: 100)"
+// @Success 200 {object} response.SuccessResponse{data=types.TenantUserPage}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse "Tenant not found"
+// @Router /api/v1/admin/tenants/{id}/users [get]
+func (h *adminHandler) ListTenantUsers(ctx *gin.Context) {
+	tenantID, ok := adminTenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+	query := types.TenantUserQuery{
+		Identifier: ctx.Query("q"),
+		Cursor:     ctx.Query("cursor"),
+		Limit:      limit,
+	}
+	if query.CreatedFrom, ok = timeQuery(ctx, "created_from"); !ok {
+		return
+	}
+	if query.CreatedTo, ok = timeQuery(ctx, "created_to"); !ok {
+		return
+	}
+
+	response, derr := h.adminUCase.ListTenantUsers(ctx.Request.Context(), tenantID, query)
+	if derr != nil {
+		handleDomainError(ctx, derr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
+
+// GetTenantUser returns one of a tenant's users
+// @Summary Get a tenant's user
+// @Security BasicAuth
+// @Description Return the user's identities with their state in Kratos, the user's language and their latest identity history
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param global_user_id path string true "Global user ID"
+// @Success 200 {object} response.SuccessResponse{data=types.TenantUserDetail}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse "Tenant or user not found"
+// @Router /api/v1/admin/tenants/{id}/users/{global_user_id} [get]
+func (h *adminHandler) GetTenantUser(ctx *gin.Context) {
+	tenantID, ok := adminTenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	response, derr := h.adminUCase.GetTenantUser(ctx.Request.Context(), tenantID, ctx.Param("global_user_id"))
+	if derr != nil {
+		handleDomainError(ctx, derr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
+
+// ExportTenantUsers streams every user of a tenant
+// @Summary Export a tenant's users
+// @Security BasicAuth
+// @Description Download every user of the tenant with their identifiers by type, the Kratos identities behind them, their language and when they joined the tenant. The export is streamed as CSV, with a header row, or as NDJSON, one user per line. Masking redacts email addresses and phone numbers for exports shared outside the admin team. CSV cells other than phone numbers that start with =, +, -, @, a tab or a carriage return are prefixed with a single quote so spreadsheets do not run them as formulas.
+// @Tags tenants
+// @Produce text/csv,application/x-ndjson
+// @Param id path string true "Tenant ID"
+// @Param format query string false "Export format (default: csv)" Enums(csv, ndjson)
+// @Param mask query bool false "Redact email addresses and phone numbers"
+// @Success 200 {array} types.TenantUserExportRecord "One record per user; a CSV export flattens the identifiers into columns"
+// @Failure 400 {object} response.ErrorResponse "Invalid format"
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse "Tenant not found"
+// @Router /api/v1/admin/tenants/{id}/users/export [get]
+func (h *adminHandler) ExportTenantUsers(ctx *gin.Context) {
+	tenantID, ok := adminTenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	query := types.TenantUserExportQuery{
+		Format: ctx.DefaultQuery("format", constants.BulkFormatCSV),
+		Mask:   ctx.Query("mask") == "true",
+	}
+	contentType := "text/csv"
+	if query.Format == constants.BulkFormatNDJSON {
+		contentType = "application/x-ndjson"
+	}
+	w := &exportWriter{
+		ctx:         ctx,
+		contentType: contentType,
+		filename:    fmt.Sprintf("users-%s.%s", tenantID, query.Format),
+	}
+
+	if derr := h.adminUCase.ExportTenantUsers(ctx.Request.Context(), tenantID, query, w); derr != nil {
+		if !w.started {
+			handleDomainError(ctx, derr)
+			return
+		}
+		// The status was sent with the first record; cutting the stream short is all that is left
+		logger.GetLogger().Errorf("Export of the users of tenant %s failed: %v", tenantID, derr)
+		ctx.Abort()
+	}
+}
+
+// exportWriter sends the headers of a download with its first bytes, so an export refused before
+// writing anything can still be answered with an error
+type exportWriter struct {
+	ctx         *gin.Context
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.ctx.Header("Content-Type", w.contentType)
+		w.ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", w.filename))
+		w.ctx.Status(http.StatusOK)
+		w.started = true
+	}
+	return w.ctx.Writer.Write(p)
+}
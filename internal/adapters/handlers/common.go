@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	dto "github.com/lifenetwork-ai/iam-service/internal/delivery/dto"
@@ -25,6 +26,8 @@ func handleDomainError(ctx *gin.Context, err *domainerrors.DomainError) {
 		httpresponse.Error(ctx, http.StatusConflict, err.Code, err.Message, err.Details)
 	case domainerrors.ErrorTypeRateLimit:
 		httpresponse.Error(ctx, http.StatusTooManyRequests, err.Code, err.Message, err.Details)
+	case domainerrors.ErrorTypeForbidden:
+		httpresponse.Error(ctx, http.StatusForbidden, err.Code, err.Message, err.Details)
 	case domainerrors.ErrorTypeInternal:
 		// Log internal errors for debugging
 		logger.GetLogger().Errorf("Internal error: %v", err.Error())
@@ -62,3 +65,17 @@ func ToTenantPaginationDTOResponse(response *domaintypes.PaginatedResponse[*doma
 		NextPage:   response.NextPage,
 	}
 }
+
+// timeQuery parses an optional RFC 3339 query parameter, responding with 400 when it is malformed
+func timeQuery(ctx *gin.Context, param string) (*time.Time, bool) {
+	value := ctx.Query(param)
+	if value == "" {
+		return nil, true
+	}
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TIME_RANGE", "Invalid "+param+" time", err)
+		return nil, false
+	}
+	return &at, true
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	interfaces "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	httpresponse "github.com/lifenetwork-ai/iam-service/packages/http/response"
+)
+
+type dataExportHandler struct {
+	ucase interfaces.DataExportUseCase
+}
+
+func NewDataExportHandler(ucase interfaces.DataExportUseCase) *dataExportHandler {
+	return &dataExportHandler{
+		ucase: ucase,
+	}
+}
+
+// RequestDataExport queues an export of the current user's data.
+// @Summary Request a data export
+// @Description Queue a machine-readable export of everything stored about the user, across all tenants. Poll the export until it is ready, then download it from its link.
+// @Tags users
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Success 202 {object} response.SuccessResponse{data=types.DataExportResponse} "Export queued, or the one already being generated"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 429 {object} response.ErrorResponse "Rate limit exceeded"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/data-exports [post]
+func (h *dataExportHandler) RequestDataExport(ctx *gin.Context) {
+	tenantID, user, ok := tenantAndUserFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	response, usecaseErr := h.ucase.RequestDataExport(ctx.Request.Context(), tenantID, user.GlobalUserID)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusAccepted, response)
+}
+
+// GetDataExport returns one of the current user's data exports.
+// @Summary Get a data export
+// @Description Return the export's status and, once it is ready, a short-lived download link
+// @Tags users
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Param id path string true "Export ID"
+// @Success 200 {object} response.SuccessResponse{data=types.DataExportResponse}
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Export not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/data-exports/{id} [get]
+func (h *dataExportHandler) GetDataExport(ctx *gin.Context) {
+	tenantID, user, ok := tenantAndUserFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	response, usecaseErr := h.ucase.GetDataExport(ctx.Request.Context(), tenantID, user.GlobalUserID, ctx.Param("id"))
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
+
+// DownloadDataExport serves a data export through its signed link.
+// @Summary Download a data export
+// @Description Download the export's JSON document. The link returned with the export is the only credential needed.
+// @Tags users
+// @Produce json
+// @Param id path string true "Export ID"
+// @Param expires query int true "Link expiry, as a Unix timestamp"
+// @Param signature query string true "Link signature"
+// @Success 200 {object} types.UserDataExportDocument
+// @Failure 401 {object} response.ErrorResponse "Invalid or expired link"
+// @Failure 404 {object} response.ErrorResponse "Export not found or expired"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/data-exports/{id}/download [get]
+func (h *dataExportHandler) DownloadDataExport(ctx *gin.Context) {
+	expires, err := strconv.ParseInt(ctx.Query("expires"), 10, 64)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusUnauthorized, "MSG_INVALID_DOWNLOAD_LINK", "Invalid download link", err)
+		return
+	}
+
+	exportID := ctx.Param("id")
+	document, usecaseErr := h.ucase.DownloadDataExport(ctx.Request.Context(), exportID, expires, ctx.Query("signature"))
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	ctx.Header("Content-Disposition", `attachment; filename="data-export-`+exportID+`.json"`)
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Data(http.StatusOK, "application/json", document)
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lifenetwork-ai/iam-service/internal/delivery/dto"
+	"github.com/lifenetwork-ai/iam-service/internal/delivery/http/middleware"
+	interfaces "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	httpresponse "github.com/lifenetwork-ai/iam-service/packages/http/response"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+)
+
+type identifierLockoutHandler struct {
+	ucase interfaces.IdentifierLockoutUseCase
+}
+
+func NewIdentifierLockoutHandler(ucase interfaces.IdentifierLockoutUseCase) *identifierLockoutHandler {
+	return &identifierLockoutHandler{
+		ucase: ucase,
+	}
+}
+
+// ListIdentifierLockouts lists the tenant's locked out identifiers.
+// @Summary List identifier lockouts
+// @Security BasicAuth
+// @Description List the emails and phone numbers that are locked out after too many wrong OTP codes, soonest unlocked first. No codes are sent to a locked out identifier, and its sign-in, registration and verification are refused with MSG_IDENTIFIER_LOCKED until the lockout ends.
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Success 200 {object} response.SuccessResponse{data=[]types.IdentifierLockoutResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/admin/tenants/{id}/identifier-lockouts [get]
+func (h *identifierLockoutHandler) ListIdentifierLockouts(ctx *gin.Context) {
+	tenantID, ok := adminTenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	response, usecaseErr := h.ucase.ListIdentifierLockouts(ctx.Request.Context(), tenantID)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
+
+// UnlockIdentifier lifts the lockout of an email or phone number.
+// @Summary Unlock an identifier
+// @Security BasicAuth
+// @Description Lift the identifier's lockout and forget the wrong codes entered for it, so that a later lockout starts again from the shortest cooldown.
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param body body dto.UnlockIdentifierPayloadDTO true "Identifier to unlock"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid identifier"
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse "No wrong codes on record for the identifier"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/admin/tenants/{id}/identifier-lockouts/unlock [post]
+func (h *identifierLockoutHandler) UnlockIdentifier(ctx *gin.Context) {
+	tenantID, ok := adminTenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	var payload dto.UnlockIdentifierPayloadDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		logger.GetLogger().Errorf("Invalid payload: %v", err)
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_PAYLOAD", "Invalid request payload", err)
+		return
+	}
+
+	usecaseErr := h.ucase.UnlockIdentifier(
+		ctx.Request.Context(),
+		tenantID,
+		payload.Identifier,
+		middleware.GetAdminUsernameFromContext(ctx),
+	)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, nil)
+}
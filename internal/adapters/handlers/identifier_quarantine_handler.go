@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lifenetwork-ai/iam-service/internal/delivery/http/middleware"
+	interfaces "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	httpresponse "github.com/lifenetwork-ai/iam-service/packages/http/response"
+)
+
+type identifierQuarantineHandler struct {
+	ucase interfaces.IdentifierQuarantineUseCase
+}
+
+func NewIdentifierQuarantineHandler(ucase interfaces.IdentifierQuarantineUseCase) *identifierQuarantineHandler {
+	return &identifierQuarantineHandler{
+		ucase: ucase,
+	}
+}
+
+// ListQuarantinedIdentifiers lists the tenant's quarantined identifiers.
+// @Summary List quarantined identifiers
+// @Security BasicAuth
+// @Description List the emails and phone numbers taken off an account, by deleting or changing them, that other accounts cannot register yet, soonest released first. Only the account that released an identifier may claim it back during the quarantine. Depending on the tenant's identifier_quarantine_action, other accounts are refused with MSG_IDENTIFIER_QUARANTINED, or with MSG_IDENTIFIER_APPROVAL_REQUIRED and their request shows as approval_requested_at. Identifiers are stored hashed and shown masked; pass identifier to look one up.
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param identifier query string false "Email or phone number to look up"
+// @Param page query int false "Page number (default: 1)"
+// @Param size query int false "Page size (default: 20, max: 100)"
+// @Success 200 {object} response.SuccessResponse{data=dto.IdentifierQuarantinePaginationDTOResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/admin/tenants/{id}/identifier-quarantines [get]
+func (h *identifierQuarantineHandler) ListQuarantinedIdentifiers(ctx *gin.Context) {
+	tenantID, ok := adminTenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(ctx.DefaultQuery("size", "20"))
+
+	response, usecaseErr := h.ucase.ListQuarantinedIdentifiers(ctx.Request.Context(), tenantID, ctx.Query("identifier"), page, size)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
+
+// ReleaseIdentifier ends an identifier's quarantine early.
+// @Summary Release a quarantined identifier
+// @Security BasicAuth
+// @Description End the quarantine so that any account may register the identifier. This is how a registration waiting for approval is approved; the user then registers again.
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param quarantine_id path string true "Quarantine ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse "No quarantine in effect with this ID"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/admin/tenants/{id}/identifier-quarantines/{quarantine_id}/release [post]
+func (h *identifierQuarantineHandler) ReleaseIdentifier(ctx *gin.Context) {
+	tenantID, ok := adminTenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	usecaseErr := h.ucase.ReleaseIdentifier(
+		ctx.Request.Context(),
+		tenantID,
+		ctx.Param("quarantine_id"),
+		middleware.GetAdminUsernameFromContext(ctx),
+	)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, nil)
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	interfaces "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	httpresponse "github.com/lifenetwork-ai/iam-service/packages/http/response"
+)
+
+type identityHistoryHandler struct {
+	ucase interfaces.IdentityHistoryUseCase
+}
+
+func NewIdentityHistoryHandler(ucase interfaces.IdentityHistoryUseCase) *identityHistoryHandler {
+	return &identityHistoryHandler{
+		ucase: ucase,
+	}
+}
+
+// ListMyIdentityHistory returns the current user's identity history.
+// @Summary List identity history
+// @Description List the changes to the user's identifiers and language in the tenant, newest first
+// @Tags users
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Param action query string false "Only this kind of change" Enums(add, change, delete, lang_change)
+// @Param identity_type query string false "Only changes to this identifier type"
+// @Param actor query string false "Only changes made by" Enums(user, admin, system)
+// @Param from query string false "Only changes at or after this time (RFC 3339)"
+// @Param to query string false "Only changes before this time (RFC 3339)"
+// @Param page query int false "Page number (default: 1)"
+// @Param size query int false "Page size (default: 20, max: 100)"
+// @Success 200 {object} response.SuccessResponse{data=dto.IdentityHistoryPaginationDTOResponse}
+// @Failure 400 {object} response.ErrorResponse "Invalid filter"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/identity-history [get]
+func (h *identityHistoryHandler) ListMyIdentityHistory(ctx *gin.Context) {
+	tenantID, user, ok := tenantAndUserFromContext(ctx)
+	if !ok {
+		return
+	}
+	query, ok := identityHistoryQueryFromRequest(ctx)
+	if !ok {
+		return
+	}
+
+	response, usecaseErr := h.ucase.ListUserIdentityHistory(ctx.Request.Context(), tenantID, user.GlobalUserID, query)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, ToPaginationDTOResponse(response))
+}
+
+// ListTenantIdentityHistory returns the identity history of a tenant's users.
+// @Summary List a tenant's identity history
+// @Security BasicAuth
+// @Description List the changes to the identifiers and language of the tenant's users, newest first
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param global_user_id query string false "Only this user's changes"
+// @Param action query string false "Only this kind of change" Enums(add, change, delete, lang_change)
+// @Param identity_type query string false "Only changes to this identifier type"
+// @Param actor query string false "Only changes made by" Enums(user, admin, system)
+// @Param from query string false "Only changes at or after this time (RFC 3339)"
+// @Param to query string false "Only changes before this time (RFC 3339)"
+// @Param page query int false "Page number (default: 1)"
+// @Param size query int false "Page size (default: 20, max: 100)"
+// @Success 200 {object} response.SuccessResponse{data=dto.IdentityHistoryPaginationDTOResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Router /api/v1/admin/tenants/{id}/identity-history [get]
+func (h *identityHistoryHandler) ListTenantIdentityHistory(ctx *gin.Context) {
+	tenantID, ok := adminTenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+	query, ok := identityHistoryQueryFromRequest(ctx)
+	if !ok {
+		return
+	}
+	query.GlobalUserID = ctx.Query("global_user_id")
+
+	response, usecaseErr := h.ucase.ListTenantIdentityHistory(ctx.Request.Context(), tenantID, query)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, ToPaginationDTOResponse(response))
+}
+
+// identityHistoryQueryFromRequest reads the filters and page shared by both history routes
+func identityHistoryQueryFromRequest(ctx *gin.Context) (types.IdentityHistoryQuery, bool) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(ctx.DefaultQuery("size", "20"))
+	query := types.IdentityHistoryQuery{
+		Action:       ctx.Query("action"),
+		IdentityType: ctx.Query("identity_type"),
+		Actor:        ctx.Query("actor"),
+		Page:         page,
+		Size:         size,
+	}
+
+	var ok bool
+	if query.From, ok = timeQuery(ctx, "from"); !ok {
+		return query, false
+	}
+	if query.To, ok = timeQuery(ctx, "to"); !ok {
+		return query, false
+	}
+	return query, true
+}
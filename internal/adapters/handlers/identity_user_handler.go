@@ -11,6 +11,7 @@ import (
 	"github.com/lifenetwork-ai/iam-service/internal/delivery/http/middleware"
 	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
 	interfaces "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
 	httpresponse "github.com/lifenetwork-ai/iam-service/packages/http/response"
 	"github.com/lifenetwork-ai/iam-service/packages/logger"
 	"github.com/lifenetwork-ai/iam-service/packages/utils"
@@ -173,10 +174,11 @@ func (h *userHandler) ChallengeWithEmail(ctx *gin.Context) {
 // @Param X-Tenant-Id header string true "Tenant ID"
 // Verify a login, registration or verification challenge
 // @Summary Verify login, registration or verification challenge
-// @Description Verify a one-time code sent to user for either login, registration or verification challenge.
+// @Description Verify a one-time code sent to user for either login, registration or verification challenge. A login with `remember_device` returns a `device_token` when the tenant lets users remember devices; sending it back in X-Device-Token on later sign-ins can skip the second factor or allow longer sessions, depending on the tenant's settings. A login from a device or country the user never signed in from can return mfa_required instead of the session when the tenant wants such logins verified.
 // @Tags users
 // @Accept json
 // @Produce json
+// @Param X-Device-Token header string false "Token of a trusted device"
 // @Param challenge body dto.IdentityChallengeVerifyDTO true "Verification payload. `type` must be one of: `register`, `login`, `verify`."
 // @Success 200 {object} response.SuccessResponse "Verification successful"
 // @Failure 400 {object} response.ErrorResponse "Invalid request payload or code"
@@ -217,7 +219,7 @@ func (h *userHandler) ChallengeVerify(ctx *gin.Context) {
 	case constants.FlowTypeRegister.String():
 		auth, usecaseErr = h.ucase.VerifyRegister(ctx.Request.Context(), tenant.ID, reqPayload.FlowID, reqPayload.Code)
 	case constants.FlowTypeLogin.String():
-		auth, usecaseErr = h.ucase.VerifyLogin(ctx.Request.Context(), tenant.ID, reqPayload.FlowID, reqPayload.Code)
+		auth, usecaseErr = h.ucase.VerifyLogin(ctx.Request.Context(), tenant.ID, reqPayload.FlowID, reqPayload.Code, reqPayload.RememberDevice)
 	case constants.FlowTypeVerify.String():
 		auth, usecaseErr = h.ucase.VerifyIdentifier(ctx.Request.Context(), tenant.ID, reqPayload.FlowID, reqPayload.Code)
 	default:
@@ -239,6 +241,44 @@ func (h *userHandler) ChallengeVerify(ctx *gin.Context) {
 	httpresponse.Success(ctx, http.StatusOK, auth)
 }
 
+// ResendChallenge sends a new code for an ongoing challenge.
+// @Summary Resend a challenge code
+// @Description Send a new code for an ongoing login, registration or verification challenge without starting a new one. A flow may ask for a new code once the cooldown after the previous one has passed; until then the request is refused with MSG_RESEND_COOLDOWN and the seconds to wait. A code sent to a phone number can switch to another channel.
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param body body dto.IdentityChallengeResendDTO true "Challenge to resend"
+// @Success 200 {object} response.SuccessResponse{data=types.IdentityUserResendResponse} "Code sent again"
+// @Failure 400 {object} response.ErrorResponse "Invalid payload, channel or challenge"
+// @Failure 404 {object} response.ErrorResponse "Challenge session not found"
+// @Failure 429 {object} response.ErrorResponse "Cooldown not over, identifier locked out or rate limit exceeded"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/challenge/resend [post]
+func (h *userHandler) ResendChallenge(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		logger.GetLogger().Errorf("Failed to get tenant: %v", err)
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	var reqPayload dto.IdentityChallengeResendDTO
+	if err := ctx.ShouldBindJSON(&reqPayload); err != nil {
+		logger.GetLogger().Errorf("Invalid payload: %v", err)
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_PAYLOAD", "Invalid payload", err)
+		return
+	}
+
+	response, usecaseErr := h.ucase.ResendChallenge(ctx.Request.Context(), tenant.ID, reqPayload.FlowID, reqPayload.Channel)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
+
 // Me to get user profile.
 // @Summary Get user profile
 // @Description Get user profile
@@ -330,7 +370,8 @@ func (h *userHandler) Logout(ctx *gin.Context) {
 // @Param register body dto.IdentityUserRegisterDTO true "Only `email` or `phone` must be provided (not both). `lang` is required (`en`|`vi`). Optional `channel` (sms|whatsapp|zalo) can be provided when registering with `phone` to send OTP immediately via that channel."
 // @Success 200 {object} response.SuccessResponse{data=types.IdentityUserAuthResponse} "Successful user registration with verification flow"
 // @Failure 400 {object} response.ErrorResponse "Invalid request payload"
-// @Failure 409 {object} response.ErrorResponse "Email or phone number already exists"
+// @Failure 403 {object} response.ErrorResponse "The tenant only lets invited users register"
+// @Failure 409 {object} response.ErrorResponse "Email or phone number already exists, or has a pending invitation"
 // @Failure 429 {object} response.ErrorResponse "Too many attempts, rate limit exceeded"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /api/v1/users/register [post]
@@ -431,8 +472,8 @@ func validateRegisterPayload(reqPayload dto.IdentityUserRegisterDTO) *dto.ErrorD
 }
 
 // AddIdentifier to bind additional login identifier to current user.
-// @Summary Add new identifier (email or phone)
-// @Description Add a verified identifier (email or phone) to current user
+// @Summary Add new identifier (email, phone or social account)
+// @Description Add a verified identifier (email or phone) to current user, or link a Google/Apple account with its ID token
 // @Tags users
 // @Accept json
 // @Produce json
@@ -440,7 +481,9 @@ func validateRegisterPayload(reqPayload dto.IdentityUserRegisterDTO) *dto.ErrorD
 // @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
 // @Param body body dto.IdentityUserAddIdentifierDTO true "Identifier info"
 // @Success 200 {object} response.SuccessResponse{data=types.IdentityUserChallengeResponse} "OTP sent for verification"
+// @Success 201 {object} response.SuccessResponse{data=types.IdentityLinkedResponse} "Social account linked"
 // @Failure 400 {object} response.ErrorResponse "Invalid request payload"
+// @Failure 401 {object} response.ErrorResponse "Invalid ID token"
 // @Failure 409 {object} response.ErrorResponse "Identifier or type already exists"
 // @Failure 429 {object} response.ErrorResponse "Rate limit exceeded"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
@@ -464,6 +507,17 @@ func (h *userHandler) AddIdentifier(ctx *gin.Context) {
 		return
 	}
 
+	// Social accounts are proven by the ID token, so no OTP challenge is needed
+	if req.Provider != "" {
+		linked, usecaseErr := h.ucase.LinkOIDCIdentifier(ctx, tenant.ID, user.GlobalUserID, req.Provider, req.IDToken, req.Nonce)
+		if usecaseErr != nil {
+			handleDomainError(ctx, usecaseErr)
+			return
+		}
+		httpresponse.Success(ctx, http.StatusCreated, linked)
+		return
+	}
+
 	// Validate identifier type
 	identifierType, err := utils.GetIdentifierType(req.NewIdentifier)
 	if err != nil {
@@ -700,3 +754,819 @@ func (h *userHandler) UpdateLang(ctx *gin.Context) {
 
 	httpresponse.Success(ctx, http.StatusOK, map[string]string{"message": "Language updated"})
 }
+
+// Login to authenticate user with identifier and password.
+// @Summary Login with password
+// @Description Login with email or phone number and password
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param login body dto.IdentityUserLoginDTO true "`user_name` is an email or phone number"
+// @Success 200 {object} response.SuccessResponse{data=types.IdentityUserAuthResponse} "Successful login"
+// @Failure 400 {object} response.ErrorResponse "Invalid request payload"
+// @Failure 401 {object} response.ErrorResponse "Invalid credentials"
+// @Failure 429 {object} response.ErrorResponse "Too many attempts, rate limit exceeded"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/login [post]
+func (h *userHandler) Login(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	var req dto.IdentityUserLoginDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.GetLogger().Errorf("Invalid payload: %v", err)
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_PAYLOAD", "Invalid payload", err)
+		return
+	}
+
+	auth, usecaseErr := h.ucase.Login(ctx.Request.Context(), tenant.ID, req.UserName, req.Password)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, auth)
+}
+
+// SetPassword sets or replaces the authenticated user's password.
+// @Summary Set password
+// @Description Set or replace the current user's password. Requires a recently authenticated session.
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param body body dto.IdentityUserSetPasswordDTO true "New password"
+// @Success 200 {object} response.SuccessResponse{data=map[string]string} "Password updated"
+// @Failure 400 {object} response.ErrorResponse "Invalid request payload, password rejected by policy, or a wallet or passkey session"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/set-password [post]
+func (h *userHandler) SetPassword(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	sessionToken, exists := ctx.Get(string(constants.SessionTokenKey))
+	if !exists {
+		httpresponse.Error(ctx, http.StatusUnauthorized, "MSG_UNAUTHORIZED", "Unauthorized", []interface{}{
+			map[string]string{"field": "session_token", "error": "Session token not found"},
+		})
+		return
+	}
+
+	var req dto.IdentityUserSetPasswordDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_PAYLOAD", "Invalid payload", err)
+		return
+	}
+
+	reqCtx := context.WithValue(ctx.Request.Context(), constants.SessionTokenKey, sessionToken)
+	if usecaseErr := h.ucase.SetPassword(reqCtx, tenant.ID, req.Password); usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, map[string]string{"message": "Password updated"})
+}
+
+// ChallengePasswordRecovery sends an OTP to reset the password of an account.
+// @Summary Start password recovery
+// @Description Send an OTP to a registered email or phone number so the password can be reset
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param body body dto.IdentityPasswordRecoveryChallengeDTO true "Identifier of the account"
+// @Success 200 {object} response.SuccessResponse{data=types.IdentityUserChallengeResponse} "OTP sent"
+// @Failure 400 {object} response.ErrorResponse "Invalid request payload"
+// @Failure 404 {object} response.ErrorResponse "Identifier not found"
+// @Failure 429 {object} response.ErrorResponse "Too many attempts, rate limit exceeded"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/password/recovery [post]
+func (h *userHandler) ChallengePasswordRecovery(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	var req dto.IdentityPasswordRecoveryChallengeDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.GetLogger().Errorf("Invalid payload: %v", err)
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_PAYLOAD", "Invalid payload", err)
+		return
+	}
+
+	// Optional: persist chosen channel when recovering with a phone number
+	if strings.TrimSpace(req.Channel) != "" {
+		identifierType, err := utils.GetIdentifierType(strings.TrimSpace(req.Identifier))
+		if err == nil && identifierType == constants.IdentifierPhone.String() {
+			normalizedPhone, _, nerr := utils.NormalizePhoneE164(req.Identifier, constants.DefaultRegion)
+			if nerr != nil {
+				httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_PHONE_NUMBER", "Invalid phone number", nil)
+				return
+			}
+
+			usecaseErr := h.courierUseCase.ChooseChannel(ctx, tenant.Name, normalizedPhone, strings.ToLower(strings.TrimSpace(req.Channel)))
+			if usecaseErr != nil {
+				handleDomainError(ctx, usecaseErr)
+				return
+			}
+		}
+	}
+
+	challenge, usecaseErr := h.ucase.ChallengePasswordRecovery(ctx.Request.Context(), tenant.ID, req.Identifier)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, challenge)
+}
+
+// VerifyPasswordRecovery verifies the recovery OTP and sets a new password.
+// @Summary Complete password recovery
+// @Description Verify the recovery OTP, set the new password and sign the user in
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param body body dto.IdentityPasswordRecoveryVerifyDTO true "Recovery flow, code and new password"
+// @Success 200 {object} response.SuccessResponse{data=types.IdentityUserAuthResponse} "Password reset and session issued"
+// @Failure 400 {object} response.ErrorResponse "Invalid request payload, code or password"
+// @Failure 404 {object} response.ErrorResponse "Challenge session not found"
+// @Failure 429 {object} response.ErrorResponse "Too many attempts, rate limit exceeded"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/password/recovery/verify [post]
+func (h *userHandler) VerifyPasswordRecovery(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	var req dto.IdentityPasswordRecoveryVerifyDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.GetLogger().Errorf("Invalid payload: %v", err)
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_PAYLOAD", "Invalid payload", err)
+		return
+	}
+
+	auth, usecaseErr := h.ucase.VerifyPasswordRecovery(ctx.Request.Context(), tenant.ID, req.FlowID, req.Code, req.NewPassword)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, auth)
+}
+
+// RefreshSession extends a still-valid session and rotates its refresh token.
+// @Summary Refresh session
+// @Description Extend the current session and get a new refresh token. Each refresh token can be used once; reusing one revokes the session.
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param body body dto.IdentitySessionRefreshDTO true "Session token and refresh token"
+// @Success 200 {object} response.SuccessResponse{data=types.IdentityUserAuthResponse} "Session refreshed"
+// @Failure 400 {object} response.ErrorResponse "Invalid request payload"
+// @Failure 401 {object} response.ErrorResponse "Invalid, reused or expired refresh token"
+// @Failure 429 {object} response.ErrorResponse "Too many attempts, rate limit exceeded"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/session/refresh [post]
+func (h *userHandler) RefreshSession(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	var req dto.IdentitySessionRefreshDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.GetLogger().Errorf("Invalid payload: %v", err)
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_PAYLOAD", "Invalid payload", err)
+		return
+	}
+
+	auth, usecaseErr := h.ucase.RefreshToken(ctx.Request.Context(), tenant.ID, req.SessionToken, req.RefreshToken)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, auth)
+}
+
+// LoginWithOIDC signs a user in with a Google or Apple ID token.
+// @Summary Login with Google or Apple
+// @Description Sign in with an ID token obtained by the app from Google or Apple. The first sign-in creates the account, or joins the account that registered the same verified email.
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param body body dto.IdentityOIDCLoginDTO true "Provider and ID token"
+// @Success 200 {object} response.SuccessResponse{data=types.IdentityUserAuthResponse} "Successful login"
+// @Failure 400 {object} response.ErrorResponse "Invalid request payload"
+// @Failure 401 {object} response.ErrorResponse "Invalid ID token"
+// @Failure 403 {object} response.ErrorResponse "The tenant only lets invited users register"
+// @Failure 409 {object} response.ErrorResponse "Account already linked to another account of this provider"
+// @Failure 429 {object} response.ErrorResponse "Too many attempts, rate limit exceeded"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/oidc/login [post]
+func (h *userHandler) LoginWithOIDC(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	var req dto.IdentityOIDCLoginDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.GetLogger().Errorf("Invalid payload: %v", err)
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_PAYLOAD", "Invalid payload", err)
+		return
+	}
+
+	auth, usecaseErr := h.ucase.LoginWithOIDC(ctx.Request.Context(), tenant.ID, req.Provider, req.IDToken, req.Nonce, req.Lang)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, auth)
+}
+
+// ChallengeWithWallet starts a Sign-In With Ethereum flow.
+// @Summary Challenge with wallet
+// @Description Get a nonce to include in an EIP-4361 sign-in message for the wallet address
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param body body dto.IdentityWalletChallengeDTO true "Wallet address"
+// @Success 200 {object} response.SuccessResponse{data=types.WalletChallengeResponse} "Challenge issued"
+// @Failure 400 {object} response.ErrorResponse "Invalid request payload or address"
+// @Failure 429 {object} response.ErrorResponse "Too many attempts, rate limit exceeded"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/wallet/challenge [post]
+func (h *userHandler) ChallengeWithWallet(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	var req dto.IdentityWalletChallengeDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.GetLogger().Errorf("Invalid payload: %v", err)
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_PAYLOAD", "Invalid payload", err)
+		return
+	}
+
+	challenge, usecaseErr := h.ucase.ChallengeWithWallet(ctx.Request.Context(), tenant.ID, req.Address)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, challenge)
+}
+
+// VerifyWallet completes a Sign-In With Ethereum flow.
+// @Summary Verify wallet signature
+// @Description Sign in with a signed EIP-4361 message. The first sign-in of a wallet creates the account.
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param body body dto.IdentityWalletVerifyDTO true "Signed sign-in message"
+// @Success 200 {object} response.SuccessResponse{data=types.IdentityUserAuthResponse} "Successful login"
+// @Failure 400 {object} response.ErrorResponse "Invalid request payload or message"
+// @Failure 401 {object} response.ErrorResponse "Invalid signature, or message does not match the challenge"
+// @Failure 403 {object} response.ErrorResponse "The tenant only lets invited users register"
+// @Failure 429 {object} response.ErrorResponse "Too many attempts, rate limit exceeded"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/wallet/verify [post]
+func (h *userHandler) VerifyWallet(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	var req dto.IdentityWalletVerifyDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.GetLogger().Errorf("Invalid payload: %v", err)
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_PAYLOAD", "Invalid payload", err)
+		return
+	}
+
+	auth, usecaseErr := h.ucase.VerifyWallet(ctx.Request.Context(), tenant.ID, req.FlowID, req.Message, req.Signature, req.Lang)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, auth)
+}
+
+// VerifyMFA completes a sign-in that is waiting for its second factor.
+// @Summary Verify second factor
+// @Description Complete a sign-in that returned mfa_required with a TOTP code or a recovery code, or, when the methods offered are `verification_code`, with the code sent to the user's other identifier because the login came from a new device or country. A login that asked to remember the device returns its `device_token` here.
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param X-Device-Token header string false "Token of a trusted device"
+// @Param body body dto.IdentityMFAVerifyDTO true "MFA flow and code"
+// @Success 200 {object} response.SuccessResponse{data=types.IdentityUserAuthResponse} "Successful login"
+// @Failure 400 {object} response.ErrorResponse "Invalid request payload or code"
+// @Failure 401 {object} response.ErrorResponse "Session expired"
+// @Failure 429 {object} response.ErrorResponse "Too many attempts, rate limit exceeded"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/mfa/verify [post]
+func (h *userHandler) VerifyMFA(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	var req dto.IdentityMFAVerifyDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.GetLogger().Errorf("Invalid payload: %v", err)
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_PAYLOAD", "Invalid payload", err)
+		return
+	}
+
+	auth, usecaseErr := h.ucase.VerifyMFA(ctx.Request.Context(), tenant.ID, req.FlowID, req.Code)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, auth)
+}
+
+// EnrollTOTP starts TOTP enrollment for the current user.
+// @Summary Enroll TOTP
+// @Description Generate a TOTP secret and provisioning URI. The factor is not active until confirmed with a code.
+// @Tags users
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Success 200 {object} response.SuccessResponse{data=types.TOTPEnrollmentResponse} "TOTP secret and provisioning URI"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 409 {object} response.ErrorResponse "TOTP is already enabled"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/mfa/totp/enroll [post]
+func (h *userHandler) EnrollTOTP(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusUnauthorized, "MSG_UNAUTHORIZED", "Unauthorized", nil)
+		return
+	}
+
+	enrollment, usecaseErr := h.ucase.EnrollTOTP(ctx.Request.Context(), tenant.ID, user.GlobalUserID, accountName(user))
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, enrollment)
+}
+
+// ActivateTOTP confirms a pending TOTP enrollment.
+// @Summary Activate TOTP
+// @Description Enable the pending TOTP factor with a code from the authenticator app. Devices remembered earlier must pass TOTP again. Returns recovery codes, which are shown only once.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Param body body dto.IdentityMFACodeDTO true "TOTP code"
+// @Success 200 {object} response.SuccessResponse{data=types.MFARecoveryCodesResponse} "TOTP enabled"
+// @Failure 400 {object} response.ErrorResponse "Invalid request payload or code"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "No pending enrollment"
+// @Failure 409 {object} response.ErrorResponse "TOTP is already enabled"
+// @Failure 429 {object} response.ErrorResponse "Rate limit exceeded"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/mfa/totp/verify [post]
+func (h *userHandler) ActivateTOTP(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	var req dto.IdentityMFACodeDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_PAYLOAD", "Invalid payload", err)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusUnauthorized, "MSG_UNAUTHORIZED", "Unauthorized", nil)
+		return
+	}
+
+	codes, usecaseErr := h.ucase.ActivateTOTP(ctx.Request.Context(), tenant.ID, user.GlobalUserID, req.Code)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, codes)
+}
+
+// DisableTOTP removes the current user's TOTP factor.
+// @Summary Disable TOTP
+// @Description Remove the TOTP factor and its recovery codes. Not allowed when the tenant requires MFA.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Param body body dto.IdentityMFACodeDTO true "TOTP code or recovery code"
+// @Success 200 {object} response.SuccessResponse "TOTP disabled"
+// @Failure 400 {object} response.ErrorResponse "Invalid code, or MFA is required by the tenant"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "TOTP is not enabled"
+// @Failure 429 {object} response.ErrorResponse "Rate limit exceeded"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/mfa/totp/disable [post]
+func (h *userHandler) DisableTOTP(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	var req dto.IdentityMFACodeDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_PAYLOAD", "Invalid payload", err)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusUnauthorized, "MSG_UNAUTHORIZED", "Unauthorized", nil)
+		return
+	}
+
+	if usecaseErr := h.ucase.DisableTOTP(ctx.Request.Context(), tenant.ID, user.GlobalUserID, req.Code); usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, nil)
+}
+
+// ChallengeWithPasskey starts a passkey sign-in.
+// @Summary Passkey sign-in challenge
+// @Description Get the options for navigator.credentials.get(). Any passkey registered in the tenant can answer it, so no identifier is needed.
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Tags users
+// @Produce json
+// @Success 200 {object} response.SuccessResponse{data=types.PasskeyChallengeResponse} "Challenge issued"
+// @Failure 429 {object} response.ErrorResponse "Too many attempts, rate limit exceeded"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/passkey/challenge [post]
+func (h *userHandler) ChallengeWithPasskey(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	challenge, usecaseErr := h.ucase.ChallengeWithPasskey(ctx.Request.Context(), tenant.ID)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, challenge)
+}
+
+// VerifyPasskey completes a passkey sign-in.
+// @Summary Verify passkey
+// @Description Sign in with the assertion returned by navigator.credentials.get()
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param body body dto.IdentityPasskeyVerifyDTO true "Passkey assertion"
+// @Success 200 {object} response.SuccessResponse{data=types.IdentityUserAuthResponse} "Successful login"
+// @Failure 400 {object} response.ErrorResponse "Invalid request payload"
+// @Failure 401 {object} response.ErrorResponse "Invalid or unknown passkey"
+// @Failure 429 {object} response.ErrorResponse "Too many attempts, rate limit exceeded"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/passkey/verify [post]
+func (h *userHandler) VerifyPasskey(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	var req dto.IdentityPasskeyVerifyDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.GetLogger().Errorf("Invalid payload: %v", err)
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_PAYLOAD", "Invalid payload", err)
+		return
+	}
+
+	auth, usecaseErr := h.ucase.VerifyPasskey(ctx.Request.Context(), tenant.ID, req.FlowID, req.Credential)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, auth)
+}
+
+// BeginPasskeyRegistration starts adding a passkey to the current user.
+// @Summary Begin passkey registration
+// @Description Get the options for navigator.credentials.create(). The passkey is scoped to the host of the tenant's public URL.
+// @Tags users
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Success 200 {object} response.SuccessResponse{data=types.PasskeyChallengeResponse} "Registration options"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 429 {object} response.ErrorResponse "Rate limit exceeded"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/passkeys/register/begin [post]
+func (h *userHandler) BeginPasskeyRegistration(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusUnauthorized, "MSG_UNAUTHORIZED", "Unauthorized", nil)
+		return
+	}
+
+	challenge, usecaseErr := h.ucase.BeginPasskeyRegistration(ctx.Request.Context(), tenant.ID, user.GlobalUserID, accountName(user))
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, challenge)
+}
+
+// FinishPasskeyRegistration stores the passkey created by the browser.
+// @Summary Finish passkey registration
+// @Description Verify the attestation returned by navigator.credentials.create() and save the passkey
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Param body body dto.IdentityPasskeyRegisterDTO true "Passkey attestation"
+// @Success 200 {object} response.SuccessResponse{data=types.PasskeyResponse} "Passkey registered"
+// @Failure 400 {object} response.ErrorResponse "Invalid request payload or attestation"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 409 {object} response.ErrorResponse "Passkey already registered"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/passkeys/register/finish [post]
+func (h *userHandler) FinishPasskeyRegistration(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	var req dto.IdentityPasskeyRegisterDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_PAYLOAD", "Invalid payload", err)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusUnauthorized, "MSG_UNAUTHORIZED", "Unauthorized", nil)
+		return
+	}
+
+	passkey, usecaseErr := h.ucase.FinishPasskeyRegistration(
+		ctx.Request.Context(), tenant.ID, user.GlobalUserID, req.FlowID, req.Name, req.Credential,
+	)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, passkey)
+}
+
+// ListPasskeys returns the current user's passkeys.
+// @Summary List passkeys
+// @Description List the passkeys the current user registered in the tenant
+// @Tags users
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Success 200 {object} response.SuccessResponse{data=[]types.PasskeyResponse} "Passkeys"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/passkeys [get]
+func (h *userHandler) ListPasskeys(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusUnauthorized, "MSG_UNAUTHORIZED", "Unauthorized", nil)
+		return
+	}
+
+	passkeys, usecaseErr := h.ucase.ListPasskeys(ctx.Request.Context(), tenant.ID, user.GlobalUserID)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, passkeys)
+}
+
+// DeletePasskey removes one of the current user's passkeys.
+// @Summary Delete passkey
+// @Description Remove a passkey so it can no longer be used to sign in
+// @Tags users
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Param id path string true "Passkey ID"
+// @Success 200 {object} response.SuccessResponse "Passkey deleted"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Passkey not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/passkeys/{id} [delete]
+func (h *userHandler) DeletePasskey(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusUnauthorized, "MSG_UNAUTHORIZED", "Unauthorized", nil)
+		return
+	}
+
+	if usecaseErr := h.ucase.DeletePasskey(ctx.Request.Context(), tenant.ID, user.GlobalUserID, ctx.Param("id")); usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, nil)
+}
+
+// ListSessions returns the current user's signed-in sessions.
+// @Summary List sessions
+// @Description List the current user's active sessions with the client, IP address, sign-in time and last activity of each
+// @Tags users
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Success 200 {object} response.SuccessResponse{data=[]types.UserSessionResponse} "Sessions, newest first"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/sessions [get]
+func (h *userHandler) ListSessions(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusUnauthorized, "MSG_UNAUTHORIZED", "Unauthorized", nil)
+		return
+	}
+
+	sessions, usecaseErr := h.ucase.ListSessions(ctx.Request.Context(), tenant.ID, user.GlobalUserID)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, sessions)
+}
+
+// RevokeSession signs out one of the current user's sessions.
+// @Summary Revoke session
+// @Description Sign out a session and invalidate its refresh token
+// @Tags users
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Param id path string true "Session ID"
+// @Success 200 {object} response.SuccessResponse "Session revoked"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Session not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/sessions/{id} [delete]
+func (h *userHandler) RevokeSession(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusUnauthorized, "MSG_UNAUTHORIZED", "Unauthorized", nil)
+		return
+	}
+
+	if usecaseErr := h.ucase.RevokeSession(ctx.Request.Context(), tenant.ID, user.GlobalUserID, ctx.Param("id")); usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, nil)
+}
+
+// RevokeOtherSessions signs out all of the current user's other sessions.
+// @Summary Revoke other sessions
+// @Description Sign out every session of the current user except the one making the request
+// @Tags users
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Success 200 {object} response.SuccessResponse{data=types.SessionsRevokedResponse} "Sessions revoked"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/sessions [delete]
+func (h *userHandler) RevokeOtherSessions(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusUnauthorized, "MSG_UNAUTHORIZED", "Unauthorized", nil)
+		return
+	}
+
+	revoked, usecaseErr := h.ucase.RevokeOtherSessions(ctx.Request.Context(), tenant.ID, user.GlobalUserID)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, revoked)
+}
+
+// SessionCacheStats reports how the session validation cache is performing.
+// @Summary Session cache statistics
+// @Security BasicAuth
+// @Description Hit, miss and invalidation counters of the session validation cache of the instance serving the request
+// @Tags admin
+// @Produce json
+// @Success 200 {object} response.SuccessResponse{data=types.SessionCacheStats} "Session cache statistics"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Router /api/v1/admin/session-cache/stats [get]
+func (h *userHandler) SessionCacheStats(ctx *gin.Context) {
+	httpresponse.Success(ctx, http.StatusOK, h.ucase.SessionCacheStats())
+}
+
+// accountName picks the identifier authenticators show for the user's account
+func accountName(user *types.IdentityUserResponse) string {
+	if user.Email != "" {
+		return user.Email
+	}
+	if user.Phone != "" {
+		return user.Phone
+	}
+	return user.UserName
+}
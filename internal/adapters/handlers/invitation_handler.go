@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lifenetwork-ai/iam-service/internal/delivery/dto"
+	"github.com/lifenetwork-ai/iam-service/internal/delivery/http/middleware"
+	interfaces "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	httpresponse "github.com/lifenetwork-ai/iam-service/packages/http/response"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+)
+
+type invitationHandler struct {
+	ucase interfaces.InvitationUseCase
+}
+
+func NewInvitationHandler(ucase interfaces.InvitationUseCase) *invitationHandler {
+	return &invitationHandler{
+		ucase: ucase,
+	}
+}
+
+// CreateInvitation invites an email or phone number to the tenant.
+// @Summary Invite a user
+// @Security BasicAuth
+// @Description Create a pending identity for the email or phone number and send it the code that accepts the invitation. The roles are granted once the invitation is accepted. The invitation expires after 7 days unless resent.
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param body body dto.CreateInvitationPayloadDTO true "Invitation"
+// @Success 201 {object} response.SuccessResponse{data=types.InvitationResponse}
+// @Failure 400 {object} response.ErrorResponse "Invalid identifier or role"
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse "Tenant not found"
+// @Failure 409 {object} response.ErrorResponse "Identifier already registered or already invited"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/admin/tenants/{id}/invitations [post]
+func (h *invitationHandler) CreateInvitation(ctx *gin.Context) {
+	tenantID, ok := adminTenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	var payload dto.CreateInvitationPayloadDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		logger.GetLogger().Errorf("Invalid payload: %v", err)
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_PAYLOAD", "Invalid request payload", err)
+		return
+	}
+
+	response, usecaseErr := h.ucase.CreateInvitation(
+		ctx.Request.Context(),
+		tenantID,
+		payload,
+		middleware.GetAdminUsernameFromContext(ctx),
+	)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusCreated, response)
+}
+
+// ListInvitations lists the tenant's invitations.
+// @Summary List invitations
+// @Security BasicAuth
+// @Description List the tenant's invitations, newest first, with their status.
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Success 200 {object} response.SuccessResponse{data=[]types.InvitationResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/admin/tenants/{id}/invitations [get]
+func (h *invitationHandler) ListInvitations(ctx *gin.Context) {
+	tenantID, ok := adminTenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	response, usecaseErr := h.ucase.ListInvitations(ctx.Request.Context(), tenantID)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
+
+// ResendInvitation sends a new code for an invitation.
+// @Summary Resend an invitation
+// @Security BasicAuth
+// @Description Send a new code to the invited identifier and restart the invitation's expiry. An expired invitation becomes pending again.
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param invitation_id path string true "Invitation ID"
+// @Success 200 {object} response.SuccessResponse{data=types.InvitationResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse "Invitation not found"
+// @Failure 409 {object} response.ErrorResponse "Invitation already accepted or revoked"
+// @Failure 429 {object} response.ErrorResponse "Too many resends"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/admin/tenants/{id}/invitations/{invitation_id}/resend [post]
+func (h *invitationHandler) ResendInvitation(ctx *gin.Context) {
+	tenantID, ok := adminTenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	response, usecaseErr := h.ucase.ResendInvitation(ctx.Request.Context(), tenantID, ctx.Param("invitation_id"))
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
+
+// RevokeInvitation withdraws an invitation.
+// @Summary Revoke an invitation
+// @Security BasicAuth
+// @Description Withdraw an invitation that was not accepted and delete its pending identity.
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param invitation_id path string true "Invitation ID"
+// @Success 200 {object} response.SuccessResponse
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse "Invitation not found"
+// @Failure 409 {object} response.ErrorResponse "Invitation already accepted or revoked"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/admin/tenants/{id}/invitations/{invitation_id} [delete]
+func (h *invitationHandler) RevokeInvitation(ctx *gin.Context) {
+	tenantID, ok := adminTenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	if usecaseErr := h.ucase.RevokeInvitation(ctx.Request.Context(), tenantID, ctx.Param("invitation_id")); usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, nil)
+}
+
+// AcceptInvitation accepts an invitation with the code sent to the invited identifier.
+// @Summary Accept an invitation
+// @Description Verify the code sent with the invitation, create the user and grant the invitation's roles. The user then signs in as usual.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param body body dto.IdentityAcceptInvitationDTO true "Invited identifier and code"
+// @Success 200 {object} response.SuccessResponse{data=types.InvitationResponse}
+// @Failure 400 {object} response.ErrorResponse "Invalid or expired code, or expired invitation"
+// @Failure 404 {object} response.ErrorResponse "No invitation for the identifier"
+// @Failure 409 {object} response.ErrorResponse "Invitation already accepted or revoked"
+// @Failure 429 {object} response.ErrorResponse "Too many attempts, rate limit exceeded"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/users/invitations/accept [post]
+func (h *invitationHandler) AcceptInvitation(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	var payload dto.IdentityAcceptInvitationDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		logger.GetLogger().Errorf("Invalid payload: %v", err)
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_PAYLOAD", "Invalid request payload", err)
+		return
+	}
+
+	response, usecaseErr := h.ucase.AcceptInvitation(ctx.Request.Context(), tenant.ID, payload.Identifier, payload.Code)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
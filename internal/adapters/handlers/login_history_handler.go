@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	interfaces "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	httpresponse "github.com/lifenetwork-ai/iam-service/packages/http/response"
+)
+
+type loginHistoryHandler struct {
+	ucase interfaces.LoginHistoryUseCase
+}
+
+func NewLoginHistoryHandler(ucase interfaces.LoginHistoryUseCase) *loginHistoryHandler {
+	return &loginHistoryHandler{
+		ucase: ucase,
+	}
+}
+
+// ListMyLogins returns the current user's login history.
+// @Summary List login history
+// @Description List the user's successful code logins and registrations in the tenant, newest first, with the client and country they came from and whether they were flagged as coming from a new device or country
+// @Tags users
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Param page query int false "Page number (default: 1)"
+// @Param size query int false "Page size (default: 20, max: 100)"
+// @Success 200 {object} response.SuccessResponse{data=dto.LoginHistoryPaginationDTOResponse}
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/logins [get]
+func (h *loginHistoryHandler) ListMyLogins(ctx *gin.Context) {
+	tenantID, user, ok := tenantAndUserFromContext(ctx)
+	if !ok {
+		return
+	}
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(ctx.DefaultQuery("size", "20"))
+
+	response, usecaseErr := h.ucase.ListUserLogins(ctx.Request.Context(), tenantID, user.GlobalUserID, page, size)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, ToPaginationDTOResponse(response))
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lifenetwork-ai/iam-service/internal/delivery/http/middleware"
+	interfaces "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	httpresponse "github.com/lifenetwork-ai/iam-service/packages/http/response"
+)
+
+type oauthHandler struct {
+	userUCase interfaces.IdentityUserUseCase
+}
+
+func NewOAuthHandler(userUCase interfaces.IdentityUserUseCase) *oauthHandler {
+	return &oauthHandler{
+		userUCase: userUCase,
+	}
+}
+
+// Introspect tells a service client whether a user's session token is active.
+// @Summary Introspect token
+// @Security BasicAuth
+// @Description RFC 7662 token introspection for service clients registered with the token's tenant. Inactive tokens only report "active": false.
+// @Tags oauth2
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param token formData string true "Session token to introspect"
+// @Param token_type_hint formData string false "Ignored, only session tokens are supported"
+// @Success 200 {object} types.IntrospectionResponse "Introspection result"
+// @Failure 400 {object} map[string]string "invalid_request"
+// @Failure 401 {object} map[string]string "invalid_client"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /oauth2/introspect [post]
+func (h *oauthHandler) Introspect(ctx *gin.Context) {
+	client, err := middleware.GetOAuthClientFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusUnauthorized, "MSG_UNAUTHORIZED", "Unauthorized", nil)
+		return
+	}
+	tenantID, err := uuid.Parse(client.TenantID)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusInternalServerError, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	token := ctx.PostForm("token")
+	if token == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":             "invalid_request",
+			"error_description": "token is required",
+		})
+		return
+	}
+
+	result, usecaseErr := h.userUCase.IntrospectToken(ctx.Request.Context(), tenantID, token)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(http.StatusOK, result)
+}
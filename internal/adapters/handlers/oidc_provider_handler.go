@@ -0,0 +1,367 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lifenetwork-ai/iam-service/internal/delivery/http/middleware"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	interfaces "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	httpresponse "github.com/lifenetwork-ai/iam-service/packages/http/response"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+)
+
+// oauthErrorCodes maps token endpoint errors to RFC 6749 section 5.2 error codes
+var oauthErrorCodes = map[string]string{
+	"MSG_INVALID_REQUEST":        "invalid_request",
+	"MSG_INVALID_CLIENT":         "invalid_client",
+	"MSG_INVALID_GRANT":          "invalid_grant",
+	"MSG_UNSUPPORTED_GRANT_TYPE": "unsupported_grant_type",
+}
+
+type oidcProviderHandler struct {
+	ucase interfaces.OIDCProviderUseCase
+}
+
+func NewOIDCProviderHandler(ucase interfaces.OIDCProviderUseCase) *oidcProviderHandler {
+	return &oidcProviderHandler{
+		ucase: ucase,
+	}
+}
+
+// Discovery publishes a tenant's OpenID Connect provider metadata.
+// @Summary OpenID Connect discovery
+// @Description Provider metadata of the tenant's issuer. Only available once the tenant has configured its login page.
+// @Tags oidc
+// @Produce json
+// @Param tenant_id path string true "Tenant ID"
+// @Success 200 {object} types.OIDCDiscoveryDocument "Provider metadata"
+// @Failure 404 {object} response.ErrorResponse "Provider not enabled for the tenant"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /oidc/{tenant_id}/.well-known/openid-configuration [get]
+func (h *oidcProviderHandler) Discovery(ctx *gin.Context) {
+	tenantID, ok := tenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	doc, usecaseErr := h.ucase.Discovery(ctx.Request.Context(), tenantID)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, doc)
+}
+
+// Authorize starts signing a user in to a client.
+// @Summary OpenID Connect authorization endpoint
+// @Description Validates the authorization request and redirects to the tenant's login page. Only the authorization code flow with S256 PKCE is supported. Errors are reported to the client's redirect URI once it is known to be registered.
+// @Tags oidc
+// @Param tenant_id path string true "Tenant ID"
+// @Param client_id query string true "Client ID"
+// @Param redirect_uri query string true "Registered redirect URI"
+// @Param response_type query string true "Must be code"
+// @Param scope query string true "Space separated scopes, including openid"
+// @Param state query string false "Returned to the client unchanged"
+// @Param nonce query string false "Copied into the ID token"
+// @Param code_challenge query string true "S256 PKCE challenge"
+// @Param code_challenge_method query string true "Must be S256"
+// @Param prompt query string false "none is answered with login_required"
+// @Success 302 "Redirect to the login page or back to the client"
+// @Failure 400 {object} response.ErrorResponse "Unknown client or redirect URI"
+// @Failure 404 {object} response.ErrorResponse "Provider not enabled for the tenant"
+// @Router /oidc/{tenant_id}/authorize [get]
+func (h *oidcProviderHandler) Authorize(ctx *gin.Context) {
+	tenantID, ok := tenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	redirectTo, usecaseErr := h.ucase.Authorize(ctx.Request.Context(), tenantID, &types.OIDCAuthorizeRequest{
+		ClientID:            ctx.Query("client_id"),
+		RedirectURI:         ctx.Query("redirect_uri"),
+		ResponseType:        ctx.Query("response_type"),
+		Scope:               ctx.Query("scope"),
+		State:               ctx.Query("state"),
+		Nonce:               ctx.Query("nonce"),
+		CodeChallenge:       ctx.Query("code_challenge"),
+		CodeChallengeMethod: ctx.Query("code_challenge_method"),
+		Prompt:              ctx.Query("prompt"),
+	})
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, redirectTo)
+}
+
+// Token exchanges an authorization code for tokens.
+// @Summary OpenID Connect token endpoint
+// @Description Redeem an authorization code with its PKCE verifier. Confidential clients authenticate with their secret, public clients send only client_id.
+// @Tags oidc
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param tenant_id path string true "Tenant ID"
+// @Param grant_type formData string true "Must be authorization_code"
+// @Param code formData string true "Authorization code"
+// @Param redirect_uri formData string true "Redirect URI of the authorization request"
+// @Param code_verifier formData string true "PKCE code verifier"
+// @Param client_id formData string false "Client ID, unless sent with Basic auth"
+// @Param client_secret formData string false "Client secret of a confidential client, unless sent with Basic auth"
+// @Success 200 {object} types.OIDCTokenResponse "Tokens"
+// @Failure 400 {object} map[string]string "invalid_request, invalid_grant or unsupported_grant_type"
+// @Failure 401 {object} map[string]string "invalid_client"
+// @Failure 500 {object} map[string]string "server_error"
+// @Router /oidc/{tenant_id}/token [post]
+func (h *oidcProviderHandler) Token(ctx *gin.Context) {
+	tenantID, ok := tenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+	clientID, secret, err := middleware.OAuthClientCredentials(ctx)
+	if err != nil {
+		middleware.SendOAuthClientError(ctx, err.Error())
+		return
+	}
+
+	resp, usecaseErr := h.ucase.ExchangeCode(ctx.Request.Context(), tenantID, &types.OIDCTokenRequest{
+		GrantType:    ctx.PostForm("grant_type"),
+		Code:         ctx.PostForm("code"),
+		RedirectURI:  ctx.PostForm("redirect_uri"),
+		CodeVerifier: ctx.PostForm("code_verifier"),
+		ClientID:     clientID,
+		ClientSecret: secret,
+	})
+	ctx.Header("Cache-Control", "no-store")
+	if usecaseErr != nil {
+		sendOAuthTokenError(ctx, usecaseErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UserInfo returns the claims of the user an access token belongs to.
+// @Summary OpenID Connect userinfo endpoint
+// @Description Claims of the user an access token issued by the tenant's provider belongs to. Identifiers are limited to the token's scopes.
+// @Tags oidc
+// @Produce json
+// @Param tenant_id path string true "Tenant ID"
+// @Param Authorization header string true "Bearer access token" default(Bearer <token>)
+// @Success 200 {object} types.OIDCUserInfoResponse "User claims"
+// @Failure 401 {object} map[string]string "invalid_token"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /oidc/{tenant_id}/userinfo [get]
+// @Router /oidc/{tenant_id}/userinfo [post]
+func (h *oidcProviderHandler) UserInfo(ctx *gin.Context) {
+	tenantID, ok := tenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	var accessToken string
+	if header := ctx.GetHeader("Authorization"); strings.HasPrefix(header, "Bearer ") {
+		accessToken = strings.TrimSpace(strings.TrimPrefix(header, "Bearer "))
+	} else if ctx.Request.Method == http.MethodPost {
+		accessToken = ctx.PostForm("access_token")
+	}
+
+	info, usecaseErr := h.ucase.UserInfo(ctx.Request.Context(), tenantID, accessToken)
+	if usecaseErr != nil {
+		if usecaseErr.Type != domainerrors.ErrorTypeUnauthorized {
+			handleDomainError(ctx, usecaseErr)
+			return
+		}
+		ctx.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error":             "invalid_token",
+			"error_description": usecaseErr.Message,
+		})
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(http.StatusOK, info)
+}
+
+// GetAuthorizationRequest describes a pending authorization request to the login page.
+// @Summary Get authorization request
+// @Description Describe a client's pending sign in request, and whether the user still has to consent to it
+// @Tags users
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Param request_id path string true "Authorization request ID"
+// @Success 200 {object} response.SuccessResponse{data=types.OIDCAuthorizationRequestResponse} "Authorization request"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Authorization request not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/authorizations/{request_id} [get]
+func (h *oidcProviderHandler) GetAuthorizationRequest(ctx *gin.Context) {
+	tenant, user, ok := tenantAndUserFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	resp, usecaseErr := h.ucase.GetAuthorizationRequest(ctx.Request.Context(), tenant, user, ctx.Param("request_id"))
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, resp)
+}
+
+// AcceptAuthorizationRequest signs the user in to the client.
+// @Summary Accept authorization request
+// @Description Consent to the requested scopes and get the client redirect carrying the authorization code
+// @Tags users
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Param request_id path string true "Authorization request ID"
+// @Success 200 {object} response.SuccessResponse{data=types.OIDCRedirectResponse} "Where to send the browser"
+// @Failure 400 {object} response.ErrorResponse "Client no longer valid"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Authorization request not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/authorizations/{request_id}/accept [post]
+func (h *oidcProviderHandler) AcceptAuthorizationRequest(ctx *gin.Context) {
+	tenant, user, ok := tenantAndUserFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	resp, usecaseErr := h.ucase.AcceptAuthorizationRequest(ctx.Request.Context(), tenant, user, ctx.Param("request_id"))
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, resp)
+}
+
+// RejectAuthorizationRequest declines to sign the user in to the client.
+// @Summary Reject authorization request
+// @Description Deny the client's request and get the client redirect carrying access_denied
+// @Tags users
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Param request_id path string true "Authorization request ID"
+// @Success 200 {object} response.SuccessResponse{data=types.OIDCRedirectResponse} "Where to send the browser"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Authorization request not found"
+// @Router /api/v1/users/me/authorizations/{request_id}/reject [post]
+func (h *oidcProviderHandler) RejectAuthorizationRequest(ctx *gin.Context) {
+	tenant, user, ok := tenantAndUserFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	resp, usecaseErr := h.ucase.RejectAuthorizationRequest(ctx.Request.Context(), tenant, user, ctx.Param("request_id"))
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, resp)
+}
+
+// ListConsents lists the clients the user allowed to sign them in.
+// @Summary List consents
+// @Description List the clients the current user allowed to sign them in and the scopes they granted
+// @Tags users
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Success 200 {object} response.SuccessResponse{data=[]types.OAuthConsentResponse} "Consents"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/consents [get]
+func (h *oidcProviderHandler) ListConsents(ctx *gin.Context) {
+	tenant, user, ok := tenantAndUserFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	consents, usecaseErr := h.ucase.ListConsents(ctx.Request.Context(), tenant, user)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, consents)
+}
+
+// RevokeConsent revokes the user's consent for a client.
+// @Summary Revoke consent
+// @Description Make the client ask for consent again the next time it signs the user in. Tokens already issued stay valid until they expire.
+// @Tags users
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Param client_id path string true "Client ID"
+// @Success 200 {object} response.SuccessResponse "Consent revoked"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Consent not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/consents/{client_id} [delete]
+func (h *oidcProviderHandler) RevokeConsent(ctx *gin.Context) {
+	tenant, user, ok := tenantAndUserFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	if usecaseErr := h.ucase.RevokeConsent(ctx.Request.Context(), tenant, user, ctx.Param("client_id")); usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, gin.H{"message": "Consent revoked"})
+}
+
+func tenantIDFromPath(ctx *gin.Context) (uuid.UUID, bool) {
+	tenantID, err := uuid.Parse(ctx.Param("tenant_id"))
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusNotFound, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return uuid.Nil, false
+	}
+	return tenantID, true
+}
+
+func tenantAndUserFromContext(ctx *gin.Context) (uuid.UUID, *types.IdentityUserResponse, bool) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return uuid.Nil, nil, false
+	}
+	user, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusUnauthorized, "MSG_UNAUTHORIZED", "Unauthorized", nil)
+		return uuid.Nil, nil, false
+	}
+	return tenant.ID, user, true
+}
+
+// sendOAuthTokenError reports a token endpoint failure in the RFC 6749 format
+func sendOAuthTokenError(ctx *gin.Context, err *domainerrors.DomainError) {
+	code, known := oauthErrorCodes[err.Code]
+	switch {
+	case code == "invalid_client":
+		middleware.SendOAuthClientError(ctx, err.Message)
+		return
+	case known:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": code, "error_description": err.Message})
+	case err.Type == domainerrors.ErrorTypeInternal:
+		logger.GetLogger().Errorf("Internal error: %v", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "server_error", "error_description": err.Message})
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request", "error_description": err.Message})
+	}
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lifenetwork-ai/iam-service/internal/delivery/dto"
+	interfaces "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	httpresponse "github.com/lifenetwork-ai/iam-service/packages/http/response"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+)
+
+type securityReportHandler struct {
+	ucase interfaces.SecurityNotificationUseCase
+}
+
+func NewSecurityReportHandler(ucase interfaces.SecurityNotificationUseCase) *securityReportHandler {
+	return &securityReportHandler{
+		ucase: ucase,
+	}
+}
+
+// ReportUnrecognized freezes the account a security notice was sent about.
+// @Summary Report an unrecognized account change
+// @Description Redeem the token of the "this wasn't me" link sent with a notice about an identifier change or a new-device login. The account is suspended and signed out everywhere until an administrator reviews it. Reporting the same notice again changes nothing.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.SecurityReportDTO true "Report token"
+// @Success 200 {object} response.SuccessResponse{data=types.SecurityReportResponse} "Account frozen"
+// @Failure 400 {object} response.ErrorResponse "Invalid request payload"
+// @Failure 401 {object} response.ErrorResponse "Invalid or expired link"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/security-reports [post]
+func (h *securityReportHandler) ReportUnrecognized(ctx *gin.Context) {
+	var payload dto.SecurityReportDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		logger.GetLogger().Errorf("Invalid payload: %v", err)
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_PAYLOAD", "Invalid request payload", err)
+		return
+	}
+
+	response, usecaseErr := h.ucase.ReportUnrecognized(ctx.Request.Context(), payload.Token)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
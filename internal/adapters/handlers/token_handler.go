@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	"github.com/lifenetwork-ai/iam-service/internal/delivery/http/middleware"
+	interfaces "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	httpresponse "github.com/lifenetwork-ai/iam-service/packages/http/response"
+)
+
+type tokenHandler struct {
+	ucase interfaces.TokenUseCase
+}
+
+func NewTokenHandler(ucase interfaces.TokenUseCase) *tokenHandler {
+	return &tokenHandler{
+		ucase: ucase,
+	}
+}
+
+// IssueAccessToken exchanges the current session for a signed access token.
+// @Summary Issue access token
+// @Description Exchange the session for a short-lived JWT that services can verify offline against /.well-known/jwks.json
+// @Tags users
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Success 200 {object} response.SuccessResponse{data=types.AccessTokenResponse} "Access token issued"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/token [post]
+func (h *tokenHandler) IssueAccessToken(ctx *gin.Context) {
+	tenant, err := middleware.GetTenantFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_TENANT", "Invalid tenant", err)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(ctx)
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusUnauthorized, "MSG_UNAUTHORIZED", "Unauthorized", nil)
+		return
+	}
+
+	token, usecaseErr := h.ucase.IssueAccessToken(ctx.Request.Context(), tenant.ID, user)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, token)
+}
+
+// JWKS publishes the keys access tokens are signed with.
+// @Summary JSON Web Key Set
+// @Description Public keys to verify access tokens with, including keys being rotated in or out
+// @Tags tokens
+// @Produce json
+// @Success 200 {object} types.JSONWebKeySet "Key set"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /.well-known/jwks.json [get]
+func (h *tokenHandler) JWKS(ctx *gin.Context) {
+	set, usecaseErr := h.ucase.JWKS(ctx.Request.Context())
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	ctx.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(constants.JWKSMaxAge.Seconds())))
+	ctx.JSON(http.StatusOK, set)
+}
+
+// ListSigningKeys lists the published signing keys.
+// @Summary List signing keys
+// @Security BasicAuth
+// @Description List the access token signing keys that are published, oldest first
+// @Tags admin
+// @Produce json
+// @Success 200 {object} response.SuccessResponse{data=[]types.SigningKeyResponse} "Signing keys"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/admin/signing-keys [get]
+func (h *tokenHandler) ListSigningKeys(ctx *gin.Context) {
+	keys, usecaseErr := h.ucase.ListSigningKeys(ctx.Request.Context())
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, keys)
+}
+
+// RotateSigningKey schedules a new signing key.
+// @Summary Rotate signing key
+// @Security BasicAuth
+// @Description Publish a new signing key that takes over once verifiers have had time to fetch it. The current key stays published until its tokens expire.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} response.SuccessResponse{data=types.SigningKeyResponse} "New signing key"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 409 {object} response.ErrorResponse "Another rotation is in progress"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/admin/signing-keys/rotate [post]
+func (h *tokenHandler) RotateSigningKey(ctx *gin.Context) {
+	key, usecaseErr := h.ucase.RotateSigningKey(ctx.Request.Context())
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, key)
+}
@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	interfaces "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	httpresponse "github.com/lifenetwork-ai/iam-service/packages/http/response"
+)
+
+type trustedDeviceHandler struct {
+	ucase interfaces.TrustedDeviceUseCase
+}
+
+func NewTrustedDeviceHandler(ucase interfaces.TrustedDeviceUseCase) *trustedDeviceHandler {
+	return &trustedDeviceHandler{
+		ucase: ucase,
+	}
+}
+
+// ListMyTrustedDevices returns the devices the current user chose to remember.
+// @Summary List trusted devices
+// @Description List the devices the current user chose to remember at login, newest first. The device sending its token in X-Device-Token is flagged as current.
+// @Tags users
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Param X-Device-Token header string false "Token of a trusted device"
+// @Success 200 {object} response.SuccessResponse{data=[]types.TrustedDeviceResponse}
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/trusted-devices [get]
+func (h *trustedDeviceHandler) ListMyTrustedDevices(ctx *gin.Context) {
+	tenantID, user, ok := tenantAndUserFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	response, usecaseErr := h.ucase.ListTrustedDevices(ctx.Request.Context(), tenantID, user.GlobalUserID)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
+
+// RevokeMyTrustedDevice forgets one of the current user's trusted devices.
+// @Summary Revoke trusted device
+// @Description Stop trusting a device. Its sessions stay signed in, but its next login is treated like any other device's.
+// @Tags users
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Param device_id path string true "Trusted device ID"
+// @Success 200 {object} response.SuccessResponse "Device no longer trusted"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Trusted device not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/trusted-devices/{device_id} [delete]
+func (h *trustedDeviceHandler) RevokeMyTrustedDevice(ctx *gin.Context) {
+	tenantID, user, ok := tenantAndUserFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	if usecaseErr := h.ucase.RevokeTrustedDevice(ctx.Request.Context(), tenantID, user.GlobalUserID, ctx.Param("device_id")); usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, nil)
+}
+
+// RevokeMyTrustedDevices forgets every trusted device of the current user.
+// @Summary Revoke all trusted devices
+// @Description Stop trusting every device of the current user, including the one making the request
+// @Tags users
+// @Produce json
+// @Param X-Tenant-Id header string true "Tenant ID"
+// @Param Authorization header string true "Bearer Token (Bearer ory...)" default(Bearer <token>)
+// @Success 200 {object} response.SuccessResponse{data=types.TrustedDevicesRevokedResponse}
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/users/me/trusted-devices [delete]
+func (h *trustedDeviceHandler) RevokeMyTrustedDevices(ctx *gin.Context) {
+	tenantID, user, ok := tenantAndUserFromContext(ctx)
+	if !ok {
+		return
+	}
+
+	response, usecaseErr := h.ucase.RevokeTrustedDevices(ctx.Request.Context(), tenantID, user.GlobalUserID)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
+
+// ListTrustedDevices returns the devices a tenant's user chose to remember.
+// @Summary List a user's trusted devices
+// @Security BasicAuth
+// @Description List the devices the user chose to remember at login, newest first
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param global_user_id path string true "Global user ID"
+// @Success 200 {object} response.SuccessResponse{data=[]types.TrustedDeviceResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/admin/tenants/{id}/users/{global_user_id}/trusted-devices [get]
+func (h *trustedDeviceHandler) ListTrustedDevices(ctx *gin.Context) {
+	tenantID, globalUserID, ok := adminTenantAndUserFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	response, usecaseErr := h.ucase.ListTrustedDevices(ctx.Request.Context(), tenantID, globalUserID)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
+
+// RevokeTrustedDevice forgets one of a tenant user's trusted devices.
+// @Summary Revoke a user's trusted device
+// @Security BasicAuth
+// @Description Stop trusting one of the user's devices. Its sessions stay signed in.
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param global_user_id path string true "Global user ID"
+// @Param device_id path string true "Trusted device ID"
+// @Success 200 {object} response.SuccessResponse "Device no longer trusted"
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse "Trusted device not found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/admin/tenants/{id}/users/{global_user_id}/trusted-devices/{device_id} [delete]
+func (h *trustedDeviceHandler) RevokeTrustedDevice(ctx *gin.Context) {
+	tenantID, globalUserID, ok := adminTenantAndUserFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	if usecaseErr := h.ucase.RevokeTrustedDevice(ctx.Request.Context(), tenantID, globalUserID, ctx.Param("device_id")); usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, nil)
+}
+
+// RevokeTrustedDevices forgets every trusted device of a tenant's user.
+// @Summary Revoke all of a user's trusted devices
+// @Security BasicAuth
+// @Description Stop trusting every device of the user, e.g. after they report a lost phone
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param global_user_id path string true "Global user ID"
+// @Success 200 {object} response.SuccessResponse{data=types.TrustedDevicesRevokedResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/admin/tenants/{id}/users/{global_user_id}/trusted-devices [delete]
+func (h *trustedDeviceHandler) RevokeTrustedDevices(ctx *gin.Context) {
+	tenantID, globalUserID, ok := adminTenantAndUserFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	response, usecaseErr := h.ucase.RevokeTrustedDevices(ctx.Request.Context(), tenantID, globalUserID)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
+
+// adminTenantAndUserFromPath parses the tenant and global user IDs of an admin route, responding
+// with 400 when either is malformed
+func adminTenantAndUserFromPath(ctx *gin.Context) (uuid.UUID, string, bool) {
+	tenantID, ok := adminTenantIDFromPath(ctx)
+	if !ok {
+		return uuid.Nil, "", false
+	}
+	globalUserID, err := uuid.Parse(ctx.Param("global_user_id"))
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_USER_ID", "Invalid user ID", err)
+		return uuid.Nil, "", false
+	}
+	return tenantID, globalUserID.String(), true
+}
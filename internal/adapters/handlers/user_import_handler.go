@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	"github.com/lifenetwork-ai/iam-service/internal/delivery/http/middleware"
+	interfaces "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	httpresponse "github.com/lifenetwork-ai/iam-service/packages/http/response"
+)
+
+type userImportHandler struct {
+	ucase interfaces.UserImportUseCase
+}
+
+func NewUserImportHandler(ucase interfaces.UserImportUseCase) *userImportHandler {
+	return &userImportHandler{
+		ucase: ucase,
+	}
+}
+
+// CreateUserImport queues a bulk import of users into the tenant.
+// @Summary Import users
+// @Security BasicAuth
+// @Description Upload a CSV or NDJSON file of email, phone and lang records as the request body. A CSV file starts with a header naming its columns; an NDJSON file has one JSON object per line. Every record is checked on upload and the valid ones are imported in the background, so poll the import for its progress. Identifiers that are already registered to the same user are skipped, which makes re-running an import safe. A dry run reports what would be imported without changing anything.
+// @Tags tenants
+// @Accept plain
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param format query string false "File format, taken from the Content-Type (text/csv or application/x-ndjson) when left out" Enums(csv, ndjson)
+// @Param dry_run query bool false "Only check the records"
+// @Param body body string true "CSV or NDJSON file"
+// @Success 202 {object} response.SuccessResponse{data=types.UserImportResponse} "Import queued"
+// @Failure 400 {object} response.ErrorResponse "Unreadable, empty or too large file"
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse "Tenant not found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/admin/tenants/{id}/user-imports [post]
+func (h *userImportHandler) CreateUserImport(ctx *gin.Context) {
+	tenantID, ok := adminTenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	format := ctx.Query("format")
+	if format == "" {
+		switch ctx.ContentType() {
+		case "text/csv":
+			format = constants.BulkFormatCSV
+		case "application/x-ndjson", "application/ndjson", "application/jsonl":
+			format = constants.BulkFormatNDJSON
+		}
+	}
+
+	response, usecaseErr := h.ucase.CreateUserImport(
+		ctx.Request.Context(),
+		tenantID,
+		format,
+		ctx.Request.Body,
+		ctx.Query("dry_run") == "true",
+		middleware.GetAdminUsernameFromContext(ctx),
+	)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusAccepted, response)
+}
+
+// ListUserImports lists the tenant's bulk user imports.
+// @Summary List user imports
+// @Security BasicAuth
+// @Description List the tenant's user imports, newest first, with their progress.
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Success 200 {object} response.SuccessResponse{data=[]types.UserImportResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/admin/tenants/{id}/user-imports [get]
+func (h *userImportHandler) ListUserImports(ctx *gin.Context) {
+	tenantID, ok := adminTenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	response, usecaseErr := h.ucase.ListUserImports(ctx.Request.Context(), tenantID)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
+
+// GetUserImport returns a bulk user import.
+// @Summary Get a user import
+// @Security BasicAuth
+// @Description Return the import's status and how many of its rows were created, already existed, would be imported by a dry run or failed.
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param import_id path string true "Import ID"
+// @Success 200 {object} response.SuccessResponse{data=types.UserImportResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse "Import not found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/admin/tenants/{id}/user-imports/{import_id} [get]
+func (h *userImportHandler) GetUserImport(ctx *gin.Context) {
+	tenantID, ok := adminTenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	response, usecaseErr := h.ucase.GetUserImport(ctx.Request.Context(), tenantID, ctx.Param("import_id"))
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
+
+// ListUserImportErrors lists the rows of a bulk user import that failed.
+// @Summary List a user import's errors
+// @Security BasicAuth
+// @Description List the failed rows of the import in file order, with the line each record starts on and why it failed. Pass next_after from a page as after to get the next one.
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param import_id path string true "Import ID"
+// @Param after query int false "Only rows after this line"
+// @Param limit query int false "Page size (default: 100, max: 1000)"
+// @Success 200 {object} response.SuccessResponse{data=types.UserImportErrorPage}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse "Import not found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/admin/tenants/{id}/user-imports/{import_id}/errors [get]
+func (h *userImportHandler) ListUserImportErrors(ctx *gin.Context) {
+	tenantID, ok := adminTenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	after, _ := strconv.Atoi(ctx.DefaultQuery("after", "0"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", strconv.Itoa(constants.UserImportDefaultLimit)))
+
+	response, usecaseErr := h.ucase.ListUserImportErrors(ctx.Request.Context(), tenantID, ctx.Param("import_id"), after, limit)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
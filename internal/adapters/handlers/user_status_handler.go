@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lifenetwork-ai/iam-service/internal/delivery/dto"
+	"github.com/lifenetwork-ai/iam-service/internal/delivery/http/middleware"
+	interfaces "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	httpresponse "github.com/lifenetwork-ai/iam-service/packages/http/response"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+)
+
+type userStatusHandler struct {
+	ucase interfaces.UserStatusUseCase
+}
+
+func NewUserStatusHandler(ucase interfaces.UserStatusUseCase) *userStatusHandler {
+	return &userStatusHandler{
+		ucase: ucase,
+	}
+}
+
+// GetUserStatus returns whether a tenant's user is active, suspended or banned.
+// @Summary Get a user's status
+// @Security BasicAuth
+// @Description Get the status in force for the user. An expired suspension is reported as active.
+// @Tags tenants
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param global_user_id path string true "Global user ID"
+// @Success 200 {object} response.SuccessResponse{data=types.UserStatusResponse}
+// @Failure 400 {object} response.ErrorResponse
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse "User not found"
+// @Router /api/v1/admin/tenants/{id}/users/{global_user_id}/status [get]
+func (h *userStatusHandler) GetUserStatus(ctx *gin.Context) {
+	tenantID, ok := adminTenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+
+	response, usecaseErr := h.ucase.GetUserStatus(ctx.Request.Context(), tenantID, ctx.Param("global_user_id"))
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
+
+// UpdateUserStatus suspends, bans or reactivates a tenant's user.
+// @Summary Suspend, ban or reactivate a user
+// @Security BasicAuth
+// @Description Suspended and banned users cannot sign in, register again or use their sessions, and are signed out of every session. A suspension with expires_at ends on its own; a ban lasts until the user is reactivated.
+// @Tags tenants
+// @Accept json
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param global_user_id path string true "Global user ID"
+// @Param body body dto.UpdateUserStatusPayloadDTO true "New status"
+// @Success 200 {object} response.SuccessResponse{data=types.UserStatusResponse}
+// @Failure 400 {object} response.ErrorResponse "Invalid status, missing reason or invalid expiry"
+// @Failure 401 {object} response.ErrorResponse
+// @Failure 404 {object} response.ErrorResponse "User not found"
+// @Failure 500 {object} response.ErrorResponse
+// @Router /api/v1/admin/tenants/{id}/users/{global_user_id}/status [put]
+func (h *userStatusHandler) UpdateUserStatus(ctx *gin.Context) {
+	tenantID, ok := adminTenantIDFromPath(ctx)
+	if !ok {
+		return
+	}
+	globalUserID, err := uuid.Parse(ctx.Param("global_user_id"))
+	if err != nil {
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_USER_ID", "Invalid user ID", err)
+		return
+	}
+
+	var payload dto.UpdateUserStatusPayloadDTO
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		logger.GetLogger().Errorf("Invalid payload: %v", err)
+		httpresponse.Error(ctx, http.StatusBadRequest, "MSG_INVALID_PAYLOAD", "Invalid request payload", err)
+		return
+	}
+
+	response, usecaseErr := h.ucase.UpdateUserStatus(
+		ctx.Request.Context(),
+		tenantID,
+		globalUserID.String(),
+		payload,
+		middleware.GetAdminUsernameFromContext(ctx),
+	)
+	if usecaseErr != nil {
+		handleDomainError(ctx, usecaseErr)
+		return
+	}
+
+	httpresponse.Success(ctx, http.StatusOK, response)
+}
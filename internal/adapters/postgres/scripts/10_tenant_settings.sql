@@ -0,0 +1,26 @@
+-- Table: tenant_settings
+CREATE TABLE IF NOT EXISTS tenant_settings (
+    tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    password_min_length INT NOT NULL DEFAULT 8,
+    password_reject_breached BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Trigger for tenant_settings
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM pg_trigger
+        WHERE tgname = 'trigger_update_tenant_settings_updated_at'
+          AND tgrelid = 'tenant_settings'::regclass
+    ) THEN
+        DROP TRIGGER trigger_update_tenant_settings_updated_at ON tenant_settings;
+    END IF;
+
+    CREATE TRIGGER trigger_update_tenant_settings_updated_at
+    BEFORE UPDATE ON tenant_settings
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+END;
+$$;
@@ -0,0 +1,24 @@
+-- Per-tenant cap on how long a session can be kept alive through refreshes
+ALTER TABLE tenant_settings
+ADD COLUMN IF NOT EXISTS session_max_lifetime_seconds INT NOT NULL DEFAULT 2592000;
+
+-- Table: session_refresh_tokens
+CREATE TABLE IF NOT EXISTS session_refresh_tokens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    family_id UUID NOT NULL,
+    parent_id UUID REFERENCES session_refresh_tokens(id) ON DELETE SET NULL,
+    kratos_session_id UUID NOT NULL,
+    kratos_user_id UUID NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    chain_started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    rotated_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_session_refresh_tokens_family ON session_refresh_tokens (family_id);
+CREATE INDEX IF NOT EXISTS idx_session_refresh_tokens_session ON session_refresh_tokens (tenant_id, kratos_session_id);
+CREATE INDEX IF NOT EXISTS idx_session_refresh_tokens_expires ON session_refresh_tokens (expires_at);
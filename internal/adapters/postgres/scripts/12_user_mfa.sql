@@ -0,0 +1,49 @@
+-- Tenants can require every user to enroll a second factor
+ALTER TABLE tenant_settings
+ADD COLUMN IF NOT EXISTS mfa_required BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Table: user_mfa_factors
+CREATE TABLE IF NOT EXISTS user_mfa_factors (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    global_user_id UUID NOT NULL REFERENCES global_users(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    secret TEXT NOT NULL,
+    enabled_at TIMESTAMP WITH TIME ZONE,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_user_mfa_factors_user_type UNIQUE (tenant_id, global_user_id, type)
+);
+
+-- Trigger for user_mfa_factors
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM pg_trigger
+        WHERE tgname = 'trigger_update_user_mfa_factors_updated_at'
+          AND tgrelid = 'user_mfa_factors'::regclass
+    ) THEN
+        DROP TRIGGER trigger_update_user_mfa_factors_updated_at ON user_mfa_factors;
+    END IF;
+
+    CREATE TRIGGER trigger_update_user_mfa_factors_updated_at
+    BEFORE UPDATE ON user_mfa_factors
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+END;
+$$;
+
+-- Table: user_mfa_recovery_codes
+CREATE TABLE IF NOT EXISTS user_mfa_recovery_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    global_user_id UUID NOT NULL REFERENCES global_users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_user_mfa_recovery_codes_user ON user_mfa_recovery_codes (tenant_id, global_user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_mfa_recovery_codes_hash ON user_mfa_recovery_codes (tenant_id, global_user_id, code_hash);
@@ -0,0 +1,40 @@
+-- Table: user_passkeys
+CREATE TABLE IF NOT EXISTS user_passkeys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    global_user_id UUID NOT NULL REFERENCES global_users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL DEFAULT '',
+    credential_id VARCHAR(1366) NOT NULL,
+    public_key BYTEA NOT NULL,
+    attestation_type VARCHAR(32) NOT NULL DEFAULT '',
+    aaguid BYTEA,
+    transports TEXT NOT NULL DEFAULT '',
+    sign_count BIGINT NOT NULL DEFAULT 0,
+    backup_eligible BOOLEAN NOT NULL DEFAULT FALSE,
+    backup_state BOOLEAN NOT NULL DEFAULT FALSE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_user_passkeys_credential UNIQUE (tenant_id, credential_id)
+);
+
+-- Trigger for user_passkeys
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM pg_trigger
+        WHERE tgname = 'trigger_update_user_passkeys_updated_at'
+          AND tgrelid = 'user_passkeys'::regclass
+    ) THEN
+        DROP TRIGGER trigger_update_user_passkeys_updated_at ON user_passkeys;
+    END IF;
+
+    CREATE TRIGGER trigger_update_user_passkeys_updated_at
+    BEFORE UPDATE ON user_passkeys
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+END;
+$$;
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_user_passkeys_user ON user_passkeys (tenant_id, global_user_id);
@@ -0,0 +1,23 @@
+-- Per-tenant cap on concurrent sessions per user; 0 means unlimited
+ALTER TABLE tenant_settings
+ADD COLUMN IF NOT EXISTS max_concurrent_sessions INT NOT NULL DEFAULT 0;
+
+-- Table: user_sessions
+CREATE TABLE IF NOT EXISTS user_sessions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    global_user_id UUID NOT NULL REFERENCES global_users(id) ON DELETE CASCADE,
+    kratos_session_id UUID NOT NULL,
+    kratos_user_id UUID NOT NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    aal VARCHAR(8) NOT NULL DEFAULT '',
+    expires_at TIMESTAMP WITH TIME ZONE,
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_user_sessions_kratos_session UNIQUE (tenant_id, kratos_session_id)
+);
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_user_sessions_user ON user_sessions (tenant_id, global_user_id, created_at);
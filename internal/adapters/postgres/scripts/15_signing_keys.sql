@@ -0,0 +1,17 @@
+-- Table: signing_keys
+-- Keys that sign access tokens. A key signs from activates_at until a newer key activates,
+-- and stays in the published key set until expires_at so tokens it signed remain verifiable.
+CREATE TABLE IF NOT EXISTS signing_keys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    kid VARCHAR(64) NOT NULL,
+    algorithm VARCHAR(16) NOT NULL,
+    private_key TEXT NOT NULL,
+    public_key JSONB NOT NULL,
+    activates_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_signing_keys_kid UNIQUE (kid)
+);
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_signing_keys_expires_at ON signing_keys (expires_at);
@@ -0,0 +1,16 @@
+-- Table: oauth_clients
+-- Backend services registered with a tenant. A client authenticates with its client_id and a
+-- secret that is only stored hashed.
+CREATE TABLE IF NOT EXISTS oauth_clients (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    client_id VARCHAR(64) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    secret_hash VARCHAR(64) NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_oauth_clients_client_id UNIQUE (client_id)
+);
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_oauth_clients_tenant ON oauth_clients (tenant_id, created_at);
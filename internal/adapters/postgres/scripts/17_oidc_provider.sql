@@ -0,0 +1,49 @@
+-- Clients signing users in through the OpenID Connect provider. Public clients (mobile and
+-- single page apps) have no secret and rely on PKCE alone.
+ALTER TABLE oauth_clients
+ADD COLUMN IF NOT EXISTS redirect_uris TEXT NOT NULL DEFAULT '',
+ADD COLUMN IF NOT EXISTS public BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Page of the tenant's app that signs the user in and accepts authorization requests
+ALTER TABLE tenant_settings
+ADD COLUMN IF NOT EXISTS oidc_login_url TEXT NOT NULL DEFAULT '';
+
+-- Table: oauth_consents
+-- Scopes a user has allowed a client to receive
+CREATE TABLE IF NOT EXISTS oauth_consents (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    client_id VARCHAR(64) NOT NULL REFERENCES oauth_clients(client_id) ON DELETE CASCADE,
+    global_user_id UUID NOT NULL REFERENCES global_users(id) ON DELETE CASCADE,
+    scope TEXT NOT NULL DEFAULT '',
+    granted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_oauth_consents_user_client UNIQUE (tenant_id, global_user_id, client_id)
+);
+
+-- Table: oauth_authorization_codes
+-- Single use codes handed to a client's redirect URI, stored hashed
+CREATE TABLE IF NOT EXISTS oauth_authorization_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    client_id VARCHAR(64) NOT NULL REFERENCES oauth_clients(client_id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    redirect_uri TEXT NOT NULL,
+    scope TEXT NOT NULL DEFAULT '',
+    nonce TEXT NOT NULL DEFAULT '',
+    code_challenge VARCHAR(128) NOT NULL,
+    global_user_id UUID NOT NULL REFERENCES global_users(id) ON DELETE CASCADE,
+    kratos_user_id UUID NOT NULL,
+    kratos_session_id UUID NOT NULL,
+    aal VARCHAR(8) NOT NULL DEFAULT '',
+    auth_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    session_expires_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_oauth_authorization_codes_hash UNIQUE (code_hash)
+);
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_oauth_authorization_codes_expires_at ON oauth_authorization_codes (expires_at);
@@ -0,0 +1,27 @@
+-- Table: account_deletions
+-- Requests to erase a global user. Rows are kept after the user is gone, so global_user_id
+-- deliberately has no foreign key.
+CREATE TABLE IF NOT EXISTS account_deletions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    global_user_id UUID NOT NULL,
+    requested_by VARCHAR(255) NOT NULL,
+    scheduled_for TIMESTAMP WITH TIME ZONE NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    completed_at TIMESTAMP WITH TIME ZONE,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- A user has at most one pending deletion
+CREATE UNIQUE INDEX IF NOT EXISTS uq_account_deletions_pending
+    ON account_deletions (tenant_id, global_user_id)
+    WHERE completed_at IS NULL AND cancelled_at IS NULL;
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_account_deletions_due
+    ON account_deletions (scheduled_for)
+    WHERE completed_at IS NULL AND cancelled_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_account_deletions_tenant_id ON account_deletions (tenant_id, created_at);
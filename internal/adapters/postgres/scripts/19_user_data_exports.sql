@@ -0,0 +1,23 @@
+-- Table: user_data_exports
+-- Personal data exports requested by users, removed with the user or once they expire
+CREATE TABLE IF NOT EXISTS user_data_exports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    global_user_id UUID NOT NULL REFERENCES global_users(id) ON DELETE CASCADE,
+    payload TEXT NOT NULL DEFAULT '',
+    scheduled_for TIMESTAMP WITH TIME ZONE NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    completed_at TIMESTAMP WITH TIME ZONE,
+    failed_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_user_data_exports_user ON user_data_exports (tenant_id, global_user_id);
+CREATE INDEX IF NOT EXISTS idx_user_data_exports_pending
+    ON user_data_exports (scheduled_for)
+    WHERE completed_at IS NULL AND failed_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_user_data_exports_expires_at ON user_data_exports (expires_at);
@@ -0,0 +1,12 @@
+-- Record what kind of change each history entry is and who made it. Entries written before
+-- these columns existed were identifier changes.
+ALTER TABLE user_identity_change_logs
+    ADD COLUMN IF NOT EXISTS action VARCHAR(20) NOT NULL DEFAULT 'change',
+    ADD COLUMN IF NOT EXISTS actor VARCHAR(20) NOT NULL DEFAULT 'system',
+    ADD COLUMN IF NOT EXISTS actor_id VARCHAR(255);
+
+-- A deleted identifier has no new value
+ALTER TABLE user_identity_change_logs ALTER COLUMN new_value DROP NOT NULL;
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_identity_change_tenant_created ON user_identity_change_logs (tenant_id, created_at DESC);
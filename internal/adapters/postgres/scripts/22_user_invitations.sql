@@ -0,0 +1,31 @@
+-- Only invited identifiers may register when set
+ALTER TABLE tenant_settings
+ADD COLUMN IF NOT EXISTS invite_only_registration BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Table: user_invitations
+-- Identifiers an administrator invited to the tenant. The pending Kratos identity is created with
+-- the invitation and bound to a global user once the invitee proves they own the identifier.
+CREATE TABLE IF NOT EXISTS user_invitations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    identifier_type VARCHAR(20) NOT NULL,
+    identifier VARCHAR(320) NOT NULL,
+    lang VARCHAR(10) NOT NULL DEFAULT '',
+    roles TEXT NOT NULL DEFAULT '',
+    kratos_user_id UUID NOT NULL,
+    flow_id VARCHAR(64) NOT NULL,
+    invited_by VARCHAR(255) NOT NULL DEFAULT '',
+    global_user_id UUID REFERENCES global_users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Indexes
+-- An identifier has at most one open invitation per tenant
+CREATE UNIQUE INDEX IF NOT EXISTS uq_user_invitations_open
+    ON user_invitations (tenant_id, identifier_type, identifier)
+    WHERE accepted_at IS NULL AND revoked_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_user_invitations_tenant_created_at ON user_invitations (tenant_id, created_at DESC);
@@ -0,0 +1,46 @@
+-- Table: user_imports
+-- Bulk imports of users into a tenant, processed in the background a batch of rows at a time.
+-- An import is pending until completed_at or failed_at is set.
+CREATE TABLE IF NOT EXISTS user_imports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    format VARCHAR(10) NOT NULL,
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    requested_by VARCHAR(255) NOT NULL DEFAULT '',
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    processed_rows INTEGER NOT NULL DEFAULT 0,
+    created_rows INTEGER NOT NULL DEFAULT 0,
+    existing_rows INTEGER NOT NULL DEFAULT 0,
+    valid_rows INTEGER NOT NULL DEFAULT 0,
+    failed_rows INTEGER NOT NULL DEFAULT 0,
+    scheduled_for TIMESTAMP WITH TIME ZONE NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    completed_at TIMESTAMP WITH TIME ZONE,
+    failed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Table: user_import_rows
+-- The records of an import and the outcome of each
+CREATE TABLE IF NOT EXISTS user_import_rows (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    import_id UUID NOT NULL REFERENCES user_imports(id) ON DELETE CASCADE,
+    line INTEGER NOT NULL,
+    email VARCHAR(320) NOT NULL DEFAULT '',
+    phone VARCHAR(32) NOT NULL DEFAULT '',
+    lang VARCHAR(10) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    error_code VARCHAR(64) NOT NULL DEFAULT '',
+    error_message TEXT NOT NULL DEFAULT '',
+    global_user_id UUID,
+    CONSTRAINT uq_user_import_rows_line UNIQUE (import_id, line)
+);
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_user_imports_tenant_created_at ON user_imports (tenant_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_user_imports_pending
+    ON user_imports (scheduled_for)
+    WHERE completed_at IS NULL AND failed_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_user_import_rows_status ON user_import_rows (import_id, status, line);
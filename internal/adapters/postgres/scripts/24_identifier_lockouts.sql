@@ -0,0 +1,18 @@
+-- Table: identifier_lockouts
+-- Wrong OTP codes entered for an identifier across its challenge flows. After too many the
+-- identifier is locked out until locked_until; lockouts counts the ones in a row so each lasts longer.
+CREATE TABLE IF NOT EXISTS identifier_lockouts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    identifier VARCHAR(320) NOT NULL,
+    failed_attempts INT NOT NULL DEFAULT 0,
+    lockouts INT NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    last_failed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_identifier_lockouts_tenant_identifier UNIQUE (tenant_id, identifier)
+);
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_identifier_lockouts_tenant_locked_until ON identifier_lockouts (tenant_id, locked_until);
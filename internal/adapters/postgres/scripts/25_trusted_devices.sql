@@ -0,0 +1,26 @@
+-- Trusted device policy: how long a remembered device stays trusted (0 disables remembering
+-- devices), whether it skips the second factor and how long its sessions can be refreshed
+-- (0 keeps session_max_lifetime_seconds)
+ALTER TABLE tenant_settings
+ADD COLUMN IF NOT EXISTS trusted_device_lifetime_seconds INT NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS trusted_device_skip_mfa BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN IF NOT EXISTS trusted_device_session_max_lifetime_seconds INT NOT NULL DEFAULT 0;
+
+-- Table: trusted_devices
+-- Devices a user chose to remember after signing in with a code. The device keeps a signed token
+-- whose hash is stored here; the token only vouches for the user and tenant it was issued to.
+CREATE TABLE IF NOT EXISTS trusted_devices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    global_user_id UUID NOT NULL REFERENCES global_users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    last_used_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_trusted_devices_user ON trusted_devices (tenant_id, global_user_id, created_at);
@@ -0,0 +1,26 @@
+-- What a login from a device or country the user never signed in from triggers: nothing ('none'),
+-- a flagged login event ('event') or a verification code the user must enter first ('verify')
+ALTER TABLE tenant_settings
+ADD COLUMN IF NOT EXISTS login_risk_action VARCHAR(16) NOT NULL DEFAULT 'event';
+
+-- Table: login_events
+-- One row per successful code login or registration, with the client it came from and whether it
+-- was flagged as coming from a new device or country.
+CREATE TABLE IF NOT EXISTS login_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    global_user_id UUID NOT NULL REFERENCES global_users(id) ON DELETE CASCADE,
+    flow VARCHAR(32) NOT NULL,
+    method VARCHAR(32) NOT NULL,
+    channel VARCHAR(32) NOT NULL DEFAULT '',
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    country VARCHAR(2) NOT NULL DEFAULT '',
+    new_device BOOLEAN NOT NULL DEFAULT FALSE,
+    new_country BOOLEAN NOT NULL DEFAULT FALSE,
+    risk_action VARCHAR(16) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_login_events_user ON login_events (tenant_id, global_user_id, created_at);
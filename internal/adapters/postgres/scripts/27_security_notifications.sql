@@ -0,0 +1,20 @@
+-- Table: security_notifications
+-- One row per notice sent to a user's identifiers about a sensitive change or a new-device login.
+-- The notice links to a "this wasn't me" report, redeemable once before it expires, that freezes
+-- the account pending review.
+CREATE TABLE IF NOT EXISTS security_notifications (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    global_user_id UUID NOT NULL REFERENCES global_users(id) ON DELETE CASCADE,
+    event VARCHAR(32) NOT NULL,
+    identifier_type VARCHAR(20) NOT NULL DEFAULT '',
+    recipients INTEGER NOT NULL DEFAULT 0,
+    report_token_hash VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    reported_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_security_notifications_token ON security_notifications (report_token_hash);
+CREATE INDEX IF NOT EXISTS idx_security_notifications_user ON security_notifications (tenant_id, global_user_id, created_at);
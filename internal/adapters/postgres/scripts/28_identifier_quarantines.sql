@@ -0,0 +1,33 @@
+-- How long an email or phone number taken off an account stays quarantined (0 disables the
+-- quarantine), and whether another account may then register it at all ('block') or only once an
+-- administrator releases it ('approval')
+ALTER TABLE tenant_settings
+ADD COLUMN IF NOT EXISTS identifier_quarantine_seconds INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS identifier_quarantine_action VARCHAR(16) NOT NULL DEFAULT 'block';
+
+-- Table: identifier_quarantines
+-- Tombstones of identifiers deleted from or changed away from an account. The identifier is kept
+-- hashed; only the account that released it may claim it again before the quarantine ends or an
+-- administrator releases it. The user is not referenced so that the tombstone outlives the account;
+-- global_user_id is cleared when the account is erased.
+CREATE TABLE IF NOT EXISTS identifier_quarantines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    identifier_type VARCHAR(20) NOT NULL,
+    identifier_hash VARCHAR(64) NOT NULL,
+    masked_identifier VARCHAR(64) NOT NULL DEFAULT '',
+    global_user_id UUID,
+    reason VARCHAR(16) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    approval_requested_at TIMESTAMP WITH TIME ZONE,
+    released_at TIMESTAMP WITH TIME ZONE,
+    released_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Tables created before erased accounts were cleared from their quarantines
+ALTER TABLE identifier_quarantines ALTER COLUMN global_user_id DROP NOT NULL;
+
+-- Indexes
+CREATE INDEX IF NOT EXISTS idx_identifier_quarantines_identifier ON identifier_quarantines (tenant_id, identifier_hash, expires_at);
+CREATE INDEX IF NOT EXISTS idx_identifier_quarantines_tenant ON identifier_quarantines (tenant_id, expires_at);
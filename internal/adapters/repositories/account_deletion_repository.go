@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+)
+
+// pendingDeletion matches deletions that were neither completed nor cancelled
+const pendingDeletion = "completed_at IS NULL AND cancelled_at IS NULL"
+
+type accountDeletionRepository struct {
+	db *gorm.DB
+}
+
+func NewAccountDeletionRepository(db *gorm.DB) domainrepo.AccountDeletionRepository {
+	return &accountDeletionRepository{db: db}
+}
+
+func (r *accountDeletionRepository) Create(ctx context.Context, deletion *domain.AccountDeletion) error {
+	return r.db.WithContext(ctx).Create(deletion).Error
+}
+
+func (r *accountDeletionRepository) GetPending(ctx context.Context, tenantID, globalUserID string) (*domain.AccountDeletion, error) {
+	var deletion domain.AccountDeletion
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND global_user_id = ? AND "+pendingDeletion, tenantID, globalUserID).
+		First(&deletion).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &deletion, nil
+}
+
+func (r *accountDeletionRepository) ListByTenant(ctx context.Context, tenantID string) ([]*domain.AccountDeletion, error) {
+	var deletions []*domain.AccountDeletion
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("created_at DESC").
+		Find(&deletions).Error
+	return deletions, err
+}
+
+func (r *accountDeletionRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.AccountDeletion, error) {
+	var deletions []*domain.AccountDeletion
+	err := r.db.WithContext(ctx).
+		Where(pendingDeletion+" AND scheduled_for <= ?", now).
+		Order("scheduled_for ASC").
+		Limit(limit).
+		Find(&deletions).Error
+	return deletions, err
+}
+
+func (r *accountDeletionRepository) Claim(ctx context.Context, id string, now, retryAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.AccountDeletion{}).
+		Where("id = ? AND "+pendingDeletion+" AND scheduled_for <= ?", id, now).
+		Updates(map[string]interface{}{
+			"attempts":      gorm.Expr("attempts + 1"),
+			"scheduled_for": retryAt,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *accountDeletionRepository) RecordFailure(ctx context.Context, id, lastError string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.AccountDeletion{}).
+		Where("id = ?", id).
+		Update("last_error", lastError).Error
+}
+
+func (r *accountDeletionRepository) MarkCompleted(ctx context.Context, id string, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.AccountDeletion{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"completed_at": at,
+			"last_error":   "",
+		}).Error
+}
+
+func (r *accountDeletionRepository) Cancel(ctx context.Context, tenantID, globalUserID string, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.AccountDeletion{}).
+		Where("tenant_id = ? AND global_user_id = ? AND attempts = 0 AND "+pendingDeletion, tenantID, globalUserID).
+		Update("cancelled_at", at)
+	return result.RowsAffected > 0, result.Error
+}
@@ -28,3 +28,10 @@ func (r *globalUserRepository) GetByID(ctx context.Context, id string) (*domain.
 func (r *globalUserRepository) Create(tx *gorm.DB, user *domain.GlobalUser) error {
 	return tx.Create(user).Error
 }
+
+func (r *globalUserRepository) Delete(tx *gorm.DB, id string) error {
+	if tx == nil {
+		tx = r.db
+	}
+	return tx.Delete(&domain.GlobalUser{}, "id = ?", id).Error
+}
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+)
+
+type identifierLockoutRepository struct {
+	db *gorm.DB
+}
+
+func NewIdentifierLockoutRepository(db *gorm.DB) domainrepo.IdentifierLockoutRepository {
+	return &identifierLockoutRepository{db: db}
+}
+
+func (r *identifierLockoutRepository) Get(ctx context.Context, tenantID, identifier string) (*domain.IdentifierLockout, error) {
+	var lockout domain.IdentifierLockout
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND identifier = ?", tenantID, identifier).
+		First(&lockout).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &lockout, nil
+}
+
+func (r *identifierLockoutRepository) RecordFailure(
+	ctx context.Context,
+	tenantID, identifier string,
+	now, resetBefore time.Time,
+) (*domain.IdentifierLockout, error) {
+	var lockout domain.IdentifierLockout
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Make sure the row exists so concurrent failures serialize on its lock below
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&domain.IdentifierLockout{
+			TenantID:     tenantID,
+			Identifier:   identifier,
+			LastFailedAt: now,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("tenant_id = ? AND identifier = ?", tenantID, identifier).
+			First(&lockout).Error; err != nil {
+			return err
+		}
+
+		if lockout.LastFailedAt.Before(resetBefore) && !lockout.IsLocked(now) {
+			lockout.FailedAttempts = 0
+			lockout.Lockouts = 0
+		}
+		lockout.FailedAttempts++
+		lockout.LastFailedAt = now
+		return tx.Model(&domain.IdentifierLockout{}).
+			Where("id = ?", lockout.ID).
+			Updates(map[string]interface{}{
+				"failed_attempts": lockout.FailedAttempts,
+				"lockouts":        lockout.Lockouts,
+				"last_failed_at":  now,
+				"updated_at":      now,
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &lockout, nil
+}
+
+func (r *identifierLockoutRepository) Lock(ctx context.Context, id string, until time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.IdentifierLockout{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"locked_until":    until,
+			"lockouts":        gorm.Expr("lockouts + 1"),
+			"failed_attempts": 0,
+		}).Error
+}
+
+func (r *identifierLockoutRepository) Delete(ctx context.Context, tenantID, identifier string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND identifier = ?", tenantID, identifier).
+		Delete(&domain.IdentifierLockout{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *identifierLockoutRepository) ListLocked(ctx context.Context, tenantID string, now time.Time) ([]*domain.IdentifierLockout, error) {
+	var lockouts []*domain.IdentifierLockout
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND locked_until > ?", tenantID, now).
+		Order("locked_until ASC").
+		Find(&lockouts).Error
+	return lockouts, err
+}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+)
+
+type identifierQuarantineRepository struct {
+	db *gorm.DB
+}
+
+func NewIdentifierQuarantineRepository(db *gorm.DB) domainrepo.IdentifierQuarantineRepository {
+	return &identifierQuarantineRepository{db: db}
+}
+
+func (r *identifierQuarantineRepository) Create(ctx context.Context, quarantine *domain.IdentifierQuarantine) error {
+	return r.db.WithContext(ctx).Create(quarantine).Error
+}
+
+func (r *identifierQuarantineRepository) GetActive(
+	ctx context.Context,
+	tenantID, identifierHash, exceptGlobalUserID string,
+	now time.Time,
+) (*domain.IdentifierQuarantine, error) {
+	query := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND identifier_hash = ? AND released_at IS NULL AND expires_at > ?", tenantID, identifierHash, now)
+	if exceptGlobalUserID != "" {
+		query = query.Where("(global_user_id IS NULL OR global_user_id <> ?)", exceptGlobalUserID)
+	}
+
+	var quarantine domain.IdentifierQuarantine
+	err := query.Order("created_at DESC").First(&quarantine).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &quarantine, nil
+}
+
+func (r *identifierQuarantineRepository) RequestApproval(ctx context.Context, id string, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.IdentifierQuarantine{}).
+		Where("id = ?", id).
+		Update("approval_requested_at", at).Error
+}
+
+func (r *identifierQuarantineRepository) List(
+	ctx context.Context,
+	tenantID, identifierHash string,
+	now time.Time,
+	offset, limit int,
+) ([]*domain.IdentifierQuarantine, int64, error) {
+	query := r.db.WithContext(ctx).
+		Model(&domain.IdentifierQuarantine{}).
+		Where("tenant_id = ? AND released_at IS NULL AND expires_at > ?", tenantID, now)
+	if identifierHash != "" {
+		query = query.Where("identifier_hash = ?", identifierHash)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var quarantines []*domain.IdentifierQuarantine
+	err := query.
+		Order("expires_at ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&quarantines).Error
+	return quarantines, total, err
+}
+
+func (r *identifierQuarantineRepository) Release(ctx context.Context, tenantID, id, releasedBy string, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.IdentifierQuarantine{}).
+		Where("tenant_id = ? AND id = ? AND released_at IS NULL AND expires_at > ?", tenantID, id, at).
+		Updates(map[string]interface{}{
+			"released_at": at,
+			"released_by": releasedBy,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *identifierQuarantineRepository) ForgetGlobalUser(ctx context.Context, tx *gorm.DB, globalUserID string) error {
+	db := r.db
+	if tx != nil {
+		db = tx
+	}
+	return db.WithContext(ctx).
+		Model(&domain.IdentifierQuarantine{}).
+		Where("global_user_id = ?", globalUserID).
+		Update("global_user_id", nil).Error
+}
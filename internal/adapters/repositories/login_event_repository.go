@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+)
+
+type loginEventRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginEventRepository(db *gorm.DB) domainrepo.LoginEventRepository {
+	return &loginEventRepository{db: db}
+}
+
+func (r *loginEventRepository) Create(ctx context.Context, event *domain.LoginEvent) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+func (r *loginEventRepository) List(ctx context.Context, tenantID, globalUserID string, offset, limit int) ([]*domain.LoginEvent, int64, error) {
+	query := r.db.WithContext(ctx).
+		Model(&domain.LoginEvent{}).
+		Where("tenant_id = ? AND global_user_id = ?", tenantID, globalUserID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var events []*domain.LoginEvent
+	err := query.
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&events).Error
+	return events, total, err
+}
+
+func (r *loginEventRepository) ListByGlobalUserID(ctx context.Context, tenantID, globalUserID string) ([]*domain.LoginEvent, error) {
+	var events []*domain.LoginEvent
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND global_user_id = ?", tenantID, globalUserID).
+		Order("created_at DESC").
+		Find(&events).Error
+	return events, err
+}
+
+func (r *loginEventRepository) Count(ctx context.Context, tenantID, globalUserID string) (int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.LoginEvent{}).
+		Where("tenant_id = ? AND global_user_id = ?", tenantID, globalUserID).
+		Count(&total).Error
+	return total, err
+}
+
+func (r *loginEventRepository) HasUserAgent(ctx context.Context, tenantID, globalUserID, userAgent string) (bool, error) {
+	var found int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.LoginEvent{}).
+		Where("tenant_id = ? AND global_user_id = ? AND user_agent = ?", tenantID, globalUserID, userAgent).
+		Limit(1).
+		Count(&found).Error
+	return found > 0, err
+}
+
+func (r *loginEventRepository) ListCountries(ctx context.Context, tenantID, globalUserID string) ([]string, error) {
+	var countries []string
+	err := r.db.WithContext(ctx).
+		Model(&domain.LoginEvent{}).
+		Where("tenant_id = ? AND global_user_id = ? AND country <> ''", tenantID, globalUserID).
+		Distinct().
+		Pluck("country", &countries).Error
+	return countries, err
+}
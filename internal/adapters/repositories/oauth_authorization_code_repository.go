@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+)
+
+type oauthAuthorizationCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewOAuthAuthorizationCodeRepository(db *gorm.DB) domainrepo.OAuthAuthorizationCodeRepository {
+	return &oauthAuthorizationCodeRepository{db: db}
+}
+
+func (r *oauthAuthorizationCodeRepository) Create(ctx context.Context, code *domain.OAuthAuthorizationCode) error {
+	return r.db.WithContext(ctx).Create(code).Error
+}
+
+func (r *oauthAuthorizationCodeRepository) GetByCodeHash(ctx context.Context, codeHash string) (*domain.OAuthAuthorizationCode, error) {
+	var code domain.OAuthAuthorizationCode
+	err := r.db.WithContext(ctx).
+		Where("code_hash = ?", codeHash).
+		First(&code).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &code, nil
+}
+
+func (r *oauthAuthorizationCodeRepository) MarkUsed(ctx context.Context, id string, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.OAuthAuthorizationCode{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", at)
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+)
+
+type oauthClientRepository struct {
+	db *gorm.DB
+}
+
+func NewOAuthClientRepository(db *gorm.DB) domainrepo.OAuthClientRepository {
+	return &oauthClientRepository{db: db}
+}
+
+func (r *oauthClientRepository) Create(ctx context.Context, client *domain.OAuthClient) error {
+	return r.db.WithContext(ctx).Create(client).Error
+}
+
+func (r *oauthClientRepository) GetByClientID(ctx context.Context, clientID string) (*domain.OAuthClient, error) {
+	var client domain.OAuthClient
+	err := r.db.WithContext(ctx).
+		Where("client_id = ?", clientID).
+		First(&client).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &client, nil
+}
+
+func (r *oauthClientRepository) ListByTenant(ctx context.Context, tenantID string) ([]*domain.OAuthClient, error) {
+	var clients []*domain.OAuthClient
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("created_at ASC").
+		Find(&clients).Error
+	return clients, err
+}
+
+func (r *oauthClientRepository) Revoke(ctx context.Context, tenantID, clientID string, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.OAuthClient{}).
+		Where("tenant_id = ? AND client_id = ? AND revoked_at IS NULL", tenantID, clientID).
+		Update("revoked_at", at).Error
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+)
+
+type oauthConsentRepository struct {
+	db *gorm.DB
+}
+
+func NewOAuthConsentRepository(db *gorm.DB) domainrepo.OAuthConsentRepository {
+	return &oauthConsentRepository{db: db}
+}
+
+func (r *oauthConsentRepository) Get(ctx context.Context, tenantID, globalUserID, clientID string) (*domain.OAuthConsent, error) {
+	var consent domain.OAuthConsent
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND global_user_id = ? AND client_id = ?", tenantID, globalUserID, clientID).
+		First(&consent).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &consent, nil
+}
+
+func (r *oauthConsentRepository) Upsert(ctx context.Context, consent *domain.OAuthConsent) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "tenant_id"}, {Name: "global_user_id"}, {Name: "client_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"scope":      consent.Scope,
+			"granted_at": consent.GrantedAt,
+			"revoked_at": nil,
+		}),
+	}).Create(consent).Error
+}
+
+func (r *oauthConsentRepository) ListByUser(ctx context.Context, tenantID, globalUserID string) ([]*domain.OAuthConsent, error) {
+	var consents []*domain.OAuthConsent
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND global_user_id = ? AND revoked_at IS NULL", tenantID, globalUserID).
+		Order("granted_at DESC").
+		Find(&consents).Error
+	return consents, err
+}
+
+func (r *oauthConsentRepository) Revoke(ctx context.Context, tenantID, globalUserID, clientID string, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.OAuthConsent{}).
+		Where("tenant_id = ? AND global_user_id = ? AND client_id = ? AND revoked_at IS NULL", tenantID, globalUserID, clientID).
+		Update("revoked_at", at)
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+)
+
+type securityNotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewSecurityNotificationRepository(db *gorm.DB) domainrepo.SecurityNotificationRepository {
+	return &securityNotificationRepository{db: db}
+}
+
+func (r *securityNotificationRepository) Create(ctx context.Context, notification *domain.SecurityNotification) error {
+	return r.db.WithContext(ctx).Create(notification).Error
+}
+
+func (r *securityNotificationRepository) GetByReportTokenHash(ctx context.Context, tokenHash string) (*domain.SecurityNotification, error) {
+	var notification domain.SecurityNotification
+	err := r.db.WithContext(ctx).
+		Where("report_token_hash = ?", tokenHash).
+		First(&notification).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &notification, nil
+}
+
+func (r *securityNotificationRepository) MarkReported(ctx context.Context, id string, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.SecurityNotification{}).
+		Where("id = ? AND reported_at IS NULL", id).
+		Update("reported_at", at)
+	return result.RowsAffected > 0, result.Error
+}
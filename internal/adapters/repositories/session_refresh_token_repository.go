@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+)
+
+type sessionRefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRefreshTokenRepository(db *gorm.DB) domainrepo.SessionRefreshTokenRepository {
+	return &sessionRefreshTokenRepository{db: db}
+}
+
+func (r *sessionRefreshTokenRepository) Create(ctx context.Context, token *domain.SessionRefreshToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+func (r *sessionRefreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.SessionRefreshToken, error) {
+	var token domain.SessionRefreshToken
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Rotate only succeeds for a live token, so two concurrent refreshes cannot both consume it.
+// The token stays live when the next one cannot be stored.
+func (r *sessionRefreshTokenRepository) Rotate(ctx context.Context, id string, next *domain.SessionRefreshToken, at time.Time) (bool, error) {
+	rotated := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&domain.SessionRefreshToken{}).
+			Where("id = ? AND rotated_at IS NULL AND revoked_at IS NULL", id).
+			Update("rotated_at", at)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != 1 {
+			return nil
+		}
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+		rotated = true
+		return nil
+	})
+	return rotated, err
+}
+
+func (r *sessionRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.SessionRefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", at).Error
+}
+
+func (r *sessionRefreshTokenRepository) RevokeBySession(ctx context.Context, tenantID, kratosSessionID string, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.SessionRefreshToken{}).
+		Where("tenant_id = ? AND kratos_session_id = ? AND revoked_at IS NULL", tenantID, kratosSessionID).
+		Update("revoked_at", at).Error
+}
+
+func (r *sessionRefreshTokenRepository) ListByKratosUserID(ctx context.Context, tenantID, kratosUserID string) ([]*domain.SessionRefreshToken, error) {
+	var tokens []*domain.SessionRefreshToken
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND kratos_user_id = ?", tenantID, kratosUserID).
+		Order("created_at ASC").
+		Find(&tokens).Error
+	return tokens, err
+}
@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+)
+
+type signingKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewSigningKeyRepository(db *gorm.DB) domainrepo.SigningKeyRepository {
+	return &signingKeyRepository{db: db}
+}
+
+func (r *signingKeyRepository) Create(ctx context.Context, key *domain.SigningKey) error {
+	return r.db.WithContext(ctx).Create(key).Error
+}
+
+func (r *signingKeyRepository) ListPublished(ctx context.Context, now time.Time) ([]*domain.SigningKey, error) {
+	var keys []*domain.SigningKey
+	err := r.db.WithContext(ctx).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		Order("activates_at ASC").
+		Find(&keys).Error
+	return keys, err
+}
+
+func (r *signingKeyRepository) ExpireCurrent(ctx context.Context, newestActivatesAt, expiresAt time.Time) (bool, error) {
+	// A concurrent rotation sets expires_at on the same rows first, so the losing update
+	// matches nothing once the row lock is released, and skips the successor it just published
+	result := r.db.WithContext(ctx).
+		Model(&domain.SigningKey{}).
+		Where("expires_at IS NULL AND activates_at <= ?", newestActivatesAt).
+		Update("expires_at", expiresAt)
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/google/uuid"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+)
+
+type tenantSettingRepository struct {
+	db *gorm.DB
+}
+
+func NewTenantSettingRepository(db *gorm.DB) domainrepo.TenantSettingRepository {
+	return &tenantSettingRepository{db: db}
+}
+
+// GetByTenantID retrieves the settings of a tenant, or nil if none were saved
+func (r *tenantSettingRepository) GetByTenantID(ctx context.Context, tenantID uuid.UUID) (*domain.TenantSetting, error) {
+	var setting domain.TenantSetting
+	if err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).First(&setting).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &setting, nil
+}
+
+// Upsert creates or replaces the settings of a tenant
+func (r *tenantSettingRepository) Upsert(ctx context.Context, setting *domain.TenantSetting) error {
+	now := time.Now()
+	if setting.CreatedAt.IsZero() {
+		setting.CreatedAt = now
+	}
+	setting.UpdatedAt = now
+
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "tenant_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"password_min_length":                         setting.PasswordMinLength,
+				"password_reject_breached":                    setting.PasswordRejectBreached,
+				"session_max_lifetime_seconds":                setting.SessionMaxLifetimeSeconds,
+				"mfa_required":                                setting.MFARequired,
+				"max_concurrent_sessions":                     setting.MaxConcurrentSessions,
+				"oidc_login_url":                              setting.OIDCLoginURL,
+				"invite_only_registration":                    setting.InviteOnlyRegistration,
+				"trusted_device_lifetime_seconds":             setting.TrustedDeviceLifetimeSeconds,
+				"trusted_device_skip_mfa":                     setting.TrustedDeviceSkipMFA,
+				"trusted_device_session_max_lifetime_seconds": setting.TrustedDeviceSessionMaxLifetimeSeconds,
+				"login_risk_action":                           setting.LoginRiskAction,
+				"identifier_quarantine_seconds":               setting.IdentifierQuarantineSeconds,
+				"identifier_quarantine_action":                setting.IdentifierQuarantineAction,
+				"updated_at":                                  now,
+			}),
+		}).
+		Create(setting).Error
+}
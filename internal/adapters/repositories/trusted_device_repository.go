@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+)
+
+type trustedDeviceRepository struct {
+	db *gorm.DB
+}
+
+func NewTrustedDeviceRepository(db *gorm.DB) domainrepo.TrustedDeviceRepository {
+	return &trustedDeviceRepository{db: db}
+}
+
+func (r *trustedDeviceRepository) Create(ctx context.Context, device *domain.TrustedDevice) error {
+	return r.db.WithContext(ctx).Create(device).Error
+}
+
+func (r *trustedDeviceRepository) GetByID(ctx context.Context, tenantID, id string) (*domain.TrustedDevice, error) {
+	var device domain.TrustedDevice
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&device).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &device, nil
+}
+
+func (r *trustedDeviceRepository) ListTrusted(ctx context.Context, tenantID, globalUserID string, now time.Time) ([]*domain.TrustedDevice, error) {
+	var devices []*domain.TrustedDevice
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND global_user_id = ? AND revoked_at IS NULL AND expires_at > ?", tenantID, globalUserID, now).
+		Order("created_at DESC").
+		Find(&devices).Error
+	return devices, err
+}
+
+func (r *trustedDeviceRepository) ListByGlobalUserID(ctx context.Context, tenantID, globalUserID string) ([]*domain.TrustedDevice, error) {
+	var devices []*domain.TrustedDevice
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND global_user_id = ?", tenantID, globalUserID).
+		Order("created_at DESC").
+		Find(&devices).Error
+	return devices, err
+}
+
+// Touch skips devices used within the last interval, so sign-ins rarely write
+func (r *trustedDeviceRepository) Touch(ctx context.Context, id string, at time.Time, interval time.Duration) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.TrustedDevice{}).
+		Where("id = ? AND revoked_at IS NULL AND last_used_at < ?", id, at.Add(-interval)).
+		Update("last_used_at", at).Error
+}
+
+func (r *trustedDeviceRepository) Revoke(ctx context.Context, tenantID, globalUserID, id string, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.TrustedDevice{}).
+		Where("tenant_id = ? AND global_user_id = ? AND id = ? AND revoked_at IS NULL AND expires_at > ?", tenantID, globalUserID, id, at).
+		Update("revoked_at", at)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *trustedDeviceRepository) RevokeAll(ctx context.Context, tenantID, globalUserID string, at time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.TrustedDevice{}).
+		Where("tenant_id = ? AND global_user_id = ? AND revoked_at IS NULL AND expires_at > ?", tenantID, globalUserID, at).
+		Update("revoked_at", at)
+	return result.RowsAffected, result.Error
+}
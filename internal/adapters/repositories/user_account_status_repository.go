@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+)
+
+type userAccountStatusRepository struct {
+	db *gorm.DB
+}
+
+func NewUserAccountStatusRepository(db *gorm.DB) domainrepo.UserAccountStatusRepository {
+	return &userAccountStatusRepository{db: db}
+}
+
+func (r *userAccountStatusRepository) Get(ctx context.Context, tenantID, globalUserID string) (*domain.UserAccountStatus, error) {
+	var status domain.UserAccountStatus
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND global_user_id = ?", tenantID, globalUserID).
+		First(&status).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &status, nil
+}
+
+func (r *userAccountStatusRepository) Upsert(ctx context.Context, status *domain.UserAccountStatus) error {
+	now := time.Now()
+	if status.CreatedAt.IsZero() {
+		status.CreatedAt = now
+	}
+	status.UpdatedAt = now
+
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "tenant_id"}, {Name: "global_user_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"status":     status.Status,
+				"reason":     status.Reason,
+				"expires_at": status.ExpiresAt,
+				"updated_by": status.UpdatedBy,
+				"updated_at": now,
+			}),
+		}).
+		Create(status).Error
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+)
+
+// pendingDataExport matches exports that are still being generated
+const pendingDataExport = "completed_at IS NULL AND failed_at IS NULL"
+
+type userDataExportRepository struct {
+	db *gorm.DB
+}
+
+func NewUserDataExportRepository(db *gorm.DB) domainrepo.UserDataExportRepository {
+	return &userDataExportRepository{db: db}
+}
+
+func (r *userDataExportRepository) Create(ctx context.Context, export *domain.UserDataExport) error {
+	return r.db.WithContext(ctx).Create(export).Error
+}
+
+func (r *userDataExportRepository) GetByID(ctx context.Context, id string) (*domain.UserDataExport, error) {
+	var export domain.UserDataExport
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&export).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *userDataExportRepository) GetPending(ctx context.Context, tenantID, globalUserID string) (*domain.UserDataExport, error) {
+	var export domain.UserDataExport
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND global_user_id = ? AND "+pendingDataExport, tenantID, globalUserID).
+		Order("created_at DESC").
+		First(&export).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *userDataExportRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.UserDataExport, error) {
+	var exports []*domain.UserDataExport
+	err := r.db.WithContext(ctx).
+		Where(pendingDataExport+" AND scheduled_for <= ?", now).
+		Order("scheduled_for ASC").
+		Limit(limit).
+		Find(&exports).Error
+	return exports, err
+}
+
+func (r *userDataExportRepository) Claim(ctx context.Context, id string, now, retryAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.UserDataExport{}).
+		Where("id = ? AND "+pendingDataExport+" AND scheduled_for <= ?", id, now).
+		Updates(map[string]interface{}{
+			"attempts":      gorm.Expr("attempts + 1"),
+			"scheduled_for": retryAt,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *userDataExportRepository) Complete(ctx context.Context, id, payload string, at, expiresAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.UserDataExport{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"payload":      payload,
+			"completed_at": at,
+			"expires_at":   expiresAt,
+			"last_error":   "",
+		}).Error
+}
+
+func (r *userDataExportRepository) RecordFailure(ctx context.Context, id, lastError string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.UserDataExport{}).
+		Where("id = ?", id).
+		Update("last_error", lastError).Error
+}
+
+func (r *userDataExportRepository) Fail(ctx context.Context, id, lastError string, at, expiresAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.UserDataExport{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"last_error": lastError,
+			"failed_at":  at,
+			"expires_at": expiresAt,
+		}).Error
+}
+
+func (r *userDataExportRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("expires_at < ?", now).
+		Delete(&domain.UserDataExport{})
+	return result.RowsAffected, result.Error
+}
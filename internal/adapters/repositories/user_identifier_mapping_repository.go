@@ -65,3 +65,25 @@ func (r *userIdentifierMappingRepository) Update(tx *gorm.DB, mapping *domain.Us
 	}
 	return tx.Model(&domain.UserIdentifierMapping{}).Where("id = ?", mapping.ID).Updates(mapping).Error
 }
+
+func (r *userIdentifierMappingRepository) DeleteByGlobalUserID(ctx context.Context, tx *gorm.DB, globalUserID string) error {
+	db := r.db
+	if tx != nil {
+		db = tx
+	}
+	return db.WithContext(ctx).Where("global_user_id = ?", globalUserID).Delete(&domain.UserIdentifierMapping{}).Error
+}
+
+func (r *userIdentifierMappingRepository) ListByGlobalUserIDs(
+	ctx context.Context,
+	globalUserIDs []string,
+) ([]*domain.UserIdentifierMapping, error) {
+	var mappings []*domain.UserIdentifierMapping
+	if len(globalUserIDs) == 0 {
+		return mappings, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("global_user_id IN ?", globalUserIDs).
+		Find(&mappings).Error
+	return mappings, err
+}
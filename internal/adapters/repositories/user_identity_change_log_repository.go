@@ -43,3 +43,45 @@ func (r *userIdentityChangeLogRepository) ListByTenantID(ctx context.Context, te
 		Find(&logs).Error
 	return logs, err
 }
+
+func (r *userIdentityChangeLogRepository) Search(
+	ctx context.Context,
+	filter domainrepo.IdentityChangeLogFilter,
+	offset, limit int,
+) ([]*domain.UserIdentityChangeLog, int64, error) {
+	query := r.db.WithContext(ctx).
+		Model(&domain.UserIdentityChangeLog{}).
+		Where("tenant_id = ?", filter.TenantID)
+	if filter.GlobalUserID != "" {
+		query = query.Where("global_user_id = ?", filter.GlobalUserID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.IdentityType != "" {
+		query = query.Where("identity_type = ?", filter.IdentityType)
+	}
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var logs []*domain.UserIdentityChangeLog
+	err := query.
+		Order("created_at DESC").
+		Order("id").
+		Offset(offset).
+		Limit(limit).
+		Find(&logs).Error
+	return logs, total, err
+}
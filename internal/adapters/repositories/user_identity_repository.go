@@ -2,10 +2,12 @@ package repositories
 
 import (
 	"context"
+	"strings"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/lifenetwork-ai/iam-service/constants"
 	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
 	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
 )
@@ -196,3 +198,110 @@ func (r *userIdentityRepository) Delete(tx *gorm.DB, identityID string) error {
 	}
 	return db.Delete(&domain.UserIdentity{ID: identityID}).Error
 }
+
+func (r *userIdentityRepository) ListByGlobalUserID(ctx context.Context, tx *gorm.DB, globalUserID string) ([]*domain.UserIdentity, error) {
+	db := r.db
+	if tx != nil {
+		db = tx
+	}
+
+	var identities []*domain.UserIdentity
+	err := db.WithContext(ctx).
+		Where("global_user_id = ?", globalUserID).
+		Find(&identities).Error
+	if err != nil {
+		return nil, err
+	}
+	return identities, nil
+}
+
+func (r *userIdentityRepository) DeleteByGlobalUserID(ctx context.Context, tx *gorm.DB, globalUserID string) error {
+	db := r.db
+	if tx != nil {
+		db = tx
+	}
+	return db.WithContext(ctx).Where("global_user_id = ?", globalUserID).Delete(&domain.UserIdentity{}).Error
+}
+
+func (r *userIdentityRepository) SearchTenantUsers(
+	ctx context.Context,
+	filter domainrepo.TenantUserFilter,
+	limit int,
+) ([]*domainrepo.TenantUser, error) {
+	query := r.db.WithContext(ctx).
+		Model(&domain.UserIdentity{}).
+		Select("global_user_id, MIN(created_at) AS created_at").
+		Where("tenant_id = ?", filter.TenantID)
+	if filter.Identifier != "" {
+		matching := r.db.
+			Model(&domain.UserIdentity{}).
+			Select("global_user_id").
+			Where("tenant_id = ?", filter.TenantID).
+			Where("type IN ?", []string{constants.IdentifierEmail.String(), constants.IdentifierPhone.String()}).
+			Where("value LIKE ? ESCAPE '\\'", "%"+escapeLike(filter.Identifier)+"%")
+		query = query.Where("global_user_id IN (?)", matching)
+	}
+	query = query.Group("global_user_id")
+	if filter.CreatedFrom != nil {
+		query = query.Having("MIN(created_at) >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Having("MIN(created_at) < ?", *filter.CreatedTo)
+	}
+	if filter.After != nil {
+		query = query.Having(
+			"MIN(created_at) < ? OR (MIN(created_at) = ? AND global_user_id < ?)",
+			filter.After.CreatedAt, filter.After.CreatedAt, filter.After.GlobalUserID,
+		)
+	}
+
+	var users []*domainrepo.TenantUser
+	err := query.
+		Order("MIN(created_at) DESC").
+		Order("global_user_id DESC").
+		Limit(limit).
+		Scan(&users).Error
+	return users, err
+}
+
+func (r *userIdentityRepository) ListByTenantAndGlobalUserIDs(
+	ctx context.Context,
+	tenantID string,
+	globalUserIDs []string,
+) ([]*domain.UserIdentity, error) {
+	var identities []*domain.UserIdentity
+	if len(globalUserIDs) == 0 {
+		return identities, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND global_user_id IN ?", tenantID, globalUserIDs).
+		Order("created_at").
+		Find(&identities).Error
+	return identities, err
+}
+
+func (r *userIdentityRepository) ListTenantUserIDs(
+	ctx context.Context,
+	tenantID, after string,
+	limit int,
+) ([]string, error) {
+	query := r.db.WithContext(ctx).
+		Model(&domain.UserIdentity{}).
+		Distinct("global_user_id").
+		Where("tenant_id = ?", tenantID)
+	if after != "" {
+		query = query.Where("global_user_id > ?", after)
+	}
+
+	var globalUserIDs []string
+	err := query.
+		Order("global_user_id").
+		Limit(limit).
+		Pluck("global_user_id", &globalUserIDs).Error
+	return globalUserIDs, err
+}
+
+// escapeLike escapes the LIKE wildcards in s
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+)
+
+// pendingImport matches imports that were neither completed nor given up on
+const pendingImport = "completed_at IS NULL AND failed_at IS NULL"
+
+// userImportRowsBatchSize bounds the rows inserted per statement
+const userImportRowsBatchSize = 500
+
+type userImportRepository struct {
+	db *gorm.DB
+}
+
+func NewUserImportRepository(db *gorm.DB) domainrepo.UserImportRepository {
+	return &userImportRepository{db: db}
+}
+
+func (r *userImportRepository) Create(ctx context.Context, userImport *domain.UserImport, rows []*domain.UserImportRow) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(userImport).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		for _, row := range rows {
+			row.ImportID = userImport.ID
+		}
+		return tx.CreateInBatches(rows, userImportRowsBatchSize).Error
+	})
+}
+
+func (r *userImportRepository) GetByID(ctx context.Context, tenantID, id string) (*domain.UserImport, error) {
+	var userImport domain.UserImport
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&userImport).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &userImport, nil
+}
+
+func (r *userImportRepository) ListByTenant(ctx context.Context, tenantID string) ([]*domain.UserImport, error) {
+	var imports []*domain.UserImport
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("created_at DESC").
+		Find(&imports).Error
+	return imports, err
+}
+
+func (r *userImportRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.UserImport, error) {
+	var imports []*domain.UserImport
+	err := r.db.WithContext(ctx).
+		Where(pendingImport+" AND scheduled_for <= ?", now).
+		Order("scheduled_for ASC").
+		Limit(limit).
+		Find(&imports).Error
+	return imports, err
+}
+
+func (r *userImportRepository) Claim(ctx context.Context, id string, now, retryAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.UserImport{}).
+		Where("id = ? AND "+pendingImport+" AND scheduled_for <= ?", id, now).
+		Updates(map[string]interface{}{
+			"attempts":      gorm.Expr("attempts + 1"),
+			"scheduled_for": retryAt,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *userImportRepository) ListPendingRows(ctx context.Context, importID string, limit int) ([]*domain.UserImportRow, error) {
+	var rows []*domain.UserImportRow
+	err := r.db.WithContext(ctx).
+		Where("import_id = ? AND status = ?", importID, constants.UserImportRowPending).
+		Order("line ASC").
+		Limit(limit).
+		Find(&rows).Error
+	return rows, err
+}
+
+func (r *userImportRepository) SaveRows(ctx context.Context, importID string, rows []*domain.UserImportRow) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, row := range rows {
+			if err := tx.Model(&domain.UserImportRow{}).
+				Where("id = ?", row.ID).
+				Updates(map[string]interface{}{
+					"status":         row.Status,
+					"error_code":     row.ErrorCode,
+					"error_message":  row.ErrorMessage,
+					"global_user_id": row.GlobalUserID,
+				}).Error; err != nil {
+				return err
+			}
+		}
+
+		var counts []struct {
+			Status string
+			Count  int
+		}
+		if err := tx.Model(&domain.UserImportRow{}).
+			Select("status, COUNT(*) AS count").
+			Where("import_id = ?", importID).
+			Group("status").
+			Scan(&counts).Error; err != nil {
+			return err
+		}
+		byStatus := make(map[string]int, len(counts))
+		processed := 0
+		for _, c := range counts {
+			byStatus[c.Status] = c.Count
+			if c.Status != constants.UserImportRowPending {
+				processed += c.Count
+			}
+		}
+		return tx.Model(&domain.UserImport{}).
+			Where("id = ?", importID).
+			Updates(map[string]interface{}{
+				"processed_rows": processed,
+				"created_rows":   byStatus[constants.UserImportRowCreated],
+				"existing_rows":  byStatus[constants.UserImportRowExisting],
+				"valid_rows":     byStatus[constants.UserImportRowValid],
+				"failed_rows":    byStatus[constants.UserImportRowFailed],
+			}).Error
+	})
+}
+
+func (r *userImportRepository) ListFailedRows(ctx context.Context, importID string, afterLine, limit int) ([]*domain.UserImportRow, error) {
+	var rows []*domain.UserImportRow
+	err := r.db.WithContext(ctx).
+		Where("import_id = ? AND status = ? AND line > ?", importID, constants.UserImportRowFailed, afterLine).
+		Order("line ASC").
+		Limit(limit).
+		Find(&rows).Error
+	return rows, err
+}
+
+func (r *userImportRepository) Reschedule(ctx context.Context, id string, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.UserImport{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"scheduled_for": at,
+			"attempts":      0,
+			"last_error":    "",
+		}).Error
+}
+
+func (r *userImportRepository) Complete(ctx context.Context, id string, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.UserImport{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"completed_at": at,
+			"last_error":   "",
+		}).Error
+}
+
+func (r *userImportRepository) RecordFailure(ctx context.Context, id, lastError string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.UserImport{}).
+		Where("id = ?", id).
+		Update("last_error", lastError).Error
+}
+
+func (r *userImportRepository) Fail(ctx context.Context, id, lastError string, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.UserImport{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"last_error": lastError,
+			"failed_at":  at,
+		}).Error
+}
@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+)
+
+// openInvitation matches invitations that were neither accepted nor revoked, expired or not
+const openInvitation = "accepted_at IS NULL AND revoked_at IS NULL"
+
+type userInvitationRepository struct {
+	db *gorm.DB
+}
+
+func NewUserInvitationRepository(db *gorm.DB) domainrepo.UserInvitationRepository {
+	return &userInvitationRepository{db: db}
+}
+
+func (r *userInvitationRepository) Create(ctx context.Context, invitation *domain.UserInvitation) error {
+	return r.db.WithContext(ctx).Create(invitation).Error
+}
+
+func (r *userInvitationRepository) GetByID(ctx context.Context, tenantID, id string) (*domain.UserInvitation, error) {
+	var invitation domain.UserInvitation
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&invitation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *userInvitationRepository) GetOpen(ctx context.Context, tenantID, identifierType, identifier string) (*domain.UserInvitation, error) {
+	var invitation domain.UserInvitation
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND identifier_type = ? AND identifier = ? AND "+openInvitation, tenantID, identifierType, identifier).
+		First(&invitation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *userInvitationRepository) ListByTenant(ctx context.Context, tenantID string) ([]*domain.UserInvitation, error) {
+	var invitations []*domain.UserInvitation
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ?", tenantID).
+		Order("created_at DESC").
+		Find(&invitations).Error
+	return invitations, err
+}
+
+func (r *userInvitationRepository) Renew(ctx context.Context, id, flowID string, expiresAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.UserInvitation{}).
+		Where("id = ? AND "+openInvitation, id).
+		Updates(map[string]interface{}{
+			"flow_id":    flowID,
+			"expires_at": expiresAt,
+		}).Error
+}
+
+func (r *userInvitationRepository) MarkAccepted(ctx context.Context, tx *gorm.DB, id, globalUserID string, at time.Time) (bool, error) {
+	db := r.db.WithContext(ctx)
+	if tx != nil {
+		db = tx.WithContext(ctx)
+	}
+	result := db.
+		Model(&domain.UserInvitation{}).
+		Where("id = ? AND "+openInvitation, id).
+		Updates(map[string]interface{}{
+			"global_user_id": globalUserID,
+			"accepted_at":    at,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *userInvitationRepository) Revoke(ctx context.Context, id string, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.UserInvitation{}).
+		Where("id = ? AND "+openInvitation, id).
+		Update("revoked_at", at)
+	return result.RowsAffected > 0, result.Error
+}
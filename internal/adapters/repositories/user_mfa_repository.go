@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+)
+
+type userMFARepository struct {
+	db *gorm.DB
+}
+
+func NewUserMFARepository(db *gorm.DB) domainrepo.UserMFARepository {
+	return &userMFARepository{db: db}
+}
+
+func (r *userMFARepository) GetFactor(ctx context.Context, tenantID, globalUserID, factorType string) (*domain.UserMFAFactor, error) {
+	var factor domain.UserMFAFactor
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND global_user_id = ? AND type = ?", tenantID, globalUserID, factorType).
+		First(&factor).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &factor, nil
+}
+
+// SavePendingFactor restarts a pending enrollment with the new secret, but never overwrites an enabled factor
+func (r *userMFARepository) SavePendingFactor(ctx context.Context, factor *domain.UserMFAFactor) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "tenant_id"}, {Name: "global_user_id"}, {Name: "type"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"secret":         factor.Secret,
+				"last_used_step": 0,
+				"updated_at":     time.Now(),
+			}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{SQL: "user_mfa_factors.enabled_at IS NULL"},
+			}},
+		}).
+		Create(factor).Error
+}
+
+func (r *userMFARepository) EnableFactor(
+	ctx context.Context,
+	factor *domain.UserMFAFactor,
+	step int64,
+	recoveryCodes []*domain.UserMFARecoveryCode,
+) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		res := tx.Model(&domain.UserMFAFactor{}).
+			Where("id = ? AND enabled_at IS NULL", factor.ID).
+			Updates(map[string]interface{}{"enabled_at": now, "last_used_step": step})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != 1 {
+			return errors.New("mfa factor is not pending")
+		}
+
+		if err := tx.Where("tenant_id = ? AND global_user_id = ?", factor.TenantID, factor.GlobalUserID).
+			Delete(&domain.UserMFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+		if len(recoveryCodes) > 0 {
+			if err := tx.Create(&recoveryCodes).Error; err != nil {
+				return err
+			}
+		}
+
+		factor.EnabledAt = &now
+		factor.LastUsedStep = step
+		return nil
+	})
+}
+
+// ConsumeStep only moves forward, so a code cannot be replayed even by concurrent requests
+func (r *userMFARepository) ConsumeStep(ctx context.Context, factorID string, step int64) (bool, error) {
+	res := r.db.WithContext(ctx).
+		Model(&domain.UserMFAFactor{}).
+		Where("id = ? AND last_used_step < ?", factorID, step).
+		Update("last_used_step", step)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+func (r *userMFARepository) UseRecoveryCode(ctx context.Context, tenantID, globalUserID, codeHash string, at time.Time) (bool, error) {
+	res := r.db.WithContext(ctx).
+		Model(&domain.UserMFARecoveryCode{}).
+		Where("tenant_id = ? AND global_user_id = ? AND code_hash = ? AND used_at IS NULL", tenantID, globalUserID, codeHash).
+		Update("used_at", at)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+func (r *userMFARepository) DeleteFactor(ctx context.Context, tenantID, globalUserID, factorType string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tenant_id = ? AND global_user_id = ? AND type = ?", tenantID, globalUserID, factorType).
+			Delete(&domain.UserMFAFactor{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("tenant_id = ? AND global_user_id = ?", tenantID, globalUserID).
+			Delete(&domain.UserMFARecoveryCode{}).Error
+	})
+}
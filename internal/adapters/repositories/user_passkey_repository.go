@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+)
+
+type userPasskeyRepository struct {
+	db *gorm.DB
+}
+
+func NewUserPasskeyRepository(db *gorm.DB) domainrepo.UserPasskeyRepository {
+	return &userPasskeyRepository{db: db}
+}
+
+func (r *userPasskeyRepository) Create(ctx context.Context, passkey *domain.UserPasskey) error {
+	return r.db.WithContext(ctx).Create(passkey).Error
+}
+
+func (r *userPasskeyRepository) ListByGlobalUserID(ctx context.Context, tenantID, globalUserID string) ([]*domain.UserPasskey, error) {
+	var passkeys []*domain.UserPasskey
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND global_user_id = ?", tenantID, globalUserID).
+		Order("created_at ASC").
+		Find(&passkeys).Error
+	return passkeys, err
+}
+
+func (r *userPasskeyRepository) GetByCredentialID(ctx context.Context, tenantID, credentialID string) (*domain.UserPasskey, error) {
+	var passkey domain.UserPasskey
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND credential_id = ?", tenantID, credentialID).
+		First(&passkey).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &passkey, nil
+}
+
+func (r *userPasskeyRepository) RecordUse(ctx context.Context, id string, signCount int64, backupState bool, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.UserPasskey{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"sign_count":   signCount,
+			"backup_state": backupState,
+			"last_used_at": at,
+		}).Error
+}
+
+func (r *userPasskeyRepository) Delete(ctx context.Context, tenantID, globalUserID, id string) (bool, error) {
+	res := r.db.WithContext(ctx).
+		Where("id = ? AND tenant_id = ? AND global_user_id = ?", id, tenantID, globalUserID).
+		Delete(&domain.UserPasskey{})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
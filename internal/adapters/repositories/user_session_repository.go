@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+)
+
+type userSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewUserSessionRepository(db *gorm.DB) domainrepo.UserSessionRepository {
+	return &userSessionRepository{db: db}
+}
+
+func (r *userSessionRepository) Create(ctx context.Context, session *domain.UserSession) error {
+	return r.db.WithContext(ctx).Create(session).Error
+}
+
+func (r *userSessionRepository) ListActive(ctx context.Context, tenantID, globalUserID string, now time.Time) ([]*domain.UserSession, error) {
+	var sessions []*domain.UserSession
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND global_user_id = ? AND revoked_at IS NULL", tenantID, globalUserID).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		Order("created_at ASC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *userSessionRepository) ListByGlobalUserID(ctx context.Context, tenantID, globalUserID string) ([]*domain.UserSession, error) {
+	var sessions []*domain.UserSession
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND global_user_id = ?", tenantID, globalUserID).
+		Order("created_at ASC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *userSessionRepository) GetByID(ctx context.Context, tenantID, id string) (*domain.UserSession, error) {
+	var session domain.UserSession
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND id = ?", tenantID, id).
+		First(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *userSessionRepository) GetByKratosSessionID(ctx context.Context, tenantID, kratosSessionID string) (*domain.UserSession, error) {
+	var session domain.UserSession
+	err := r.db.WithContext(ctx).
+		Where("tenant_id = ? AND kratos_session_id = ?", tenantID, kratosSessionID).
+		First(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Touch skips sessions seen within the last interval, so authenticated requests rarely write
+func (r *userSessionRepository) Touch(ctx context.Context, tenantID, kratosSessionID string, at time.Time, interval time.Duration) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.UserSession{}).
+		Where("tenant_id = ? AND kratos_session_id = ? AND revoked_at IS NULL AND last_seen_at < ?", tenantID, kratosSessionID, at.Add(-interval)).
+		Update("last_seen_at", at).Error
+}
+
+func (r *userSessionRepository) Extend(ctx context.Context, tenantID, kratosSessionID string, expiresAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.UserSession{}).
+		Where("tenant_id = ? AND kratos_session_id = ? AND revoked_at IS NULL", tenantID, kratosSessionID).
+		Update("expires_at", expiresAt).Error
+}
+
+func (r *userSessionRepository) Revoke(ctx context.Context, tenantID, kratosSessionID string, at time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.UserSession{}).
+		Where("tenant_id = ? AND kratos_session_id = ? AND revoked_at IS NULL", tenantID, kratosSessionID).
+		Update("revoked_at", at).Error
+}
@@ -0,0 +1,232 @@
+package accesstoken
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+)
+
+const rsaKeyBits = 2048
+
+type rsaSigner struct{}
+
+// NewRSASigner returns a TokenSigner using RS256, which every JWT library can verify
+func NewRSASigner() domainservice.TokenSigner {
+	return &rsaSigner{}
+}
+
+// privateClaims are the IAM specific claims on top of the registered ones
+type privateClaims struct {
+	GlobalUserID string `json:"global_user_id"`
+	TenantID     string `json:"tenant_id"`
+	Tenant       string `json:"tenant,omitempty"`
+	KratosUserID string `json:"kratos_user_id"`
+	SessionID    string `json:"sid,omitempty"`
+	AAL          string `json:"aal,omitempty"`
+	Email        string `json:"email,omitempty"`
+	Phone        string `json:"phone_number,omitempty"`
+	Lang         string `json:"lang,omitempty"`
+	ClientID     string `json:"client_id,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+// idTokenClaims are the OpenID Connect claims on top of the registered ones
+type idTokenClaims struct {
+	AuthTime     *jwt.NumericDate `json:"auth_time,omitempty"`
+	Nonce        string           `json:"nonce,omitempty"`
+	GlobalUserID string           `json:"global_user_id"`
+	Tenant       string           `json:"tenant,omitempty"`
+	SessionID    string           `json:"sid,omitempty"`
+	AAL          string           `json:"acr,omitempty"`
+	Email        string           `json:"email,omitempty"`
+	Phone        string           `json:"phone_number,omitempty"`
+	Locale       string           `json:"locale,omitempty"`
+}
+
+// GenerateKey creates an RSA key pair identified by its RFC 7638 thumbprint
+func (s *rsaSigner) GenerateKey() (*types.SigningKeyMaterial, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		return nil, fmt.Errorf("generate rsa key: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("marshal private key: %w", err)
+	}
+
+	public := jose.JSONWebKey{Key: &privateKey.PublicKey, Algorithm: string(jose.RS256), Use: "sig"}
+	thumbprint, err := public.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("compute key thumbprint: %w", err)
+	}
+	public.KeyID = base64.RawURLEncoding.EncodeToString(thumbprint)
+	jwk, err := json.Marshal(public)
+	if err != nil {
+		return nil, fmt.Errorf("marshal public key: %w", err)
+	}
+
+	return &types.SigningKeyMaterial{
+		KeyID:      public.KeyID,
+		Algorithm:  string(jose.RS256),
+		PrivateKey: der,
+		PublicJWK:  jwk,
+	}, nil
+}
+
+// Sign issues a compact JWT naming keyID in its header
+func (s *rsaSigner) Sign(keyID string, privateKey []byte, claims *types.AccessTokenClaims) (string, error) {
+	signer, err := newJoseSigner(keyID, privateKey, constants.AccessTokenJWTType)
+	if err != nil {
+		return "", err
+	}
+
+	registered := jwt.Claims{
+		ID:        claims.ID,
+		Issuer:    claims.Issuer,
+		Audience:  jwt.Audience(claims.Audience),
+		Subject:   claims.Subject,
+		IssuedAt:  jwt.NewNumericDate(claims.IssuedAt),
+		NotBefore: jwt.NewNumericDate(claims.IssuedAt),
+		Expiry:    jwt.NewNumericDate(claims.ExpiresAt),
+	}
+	private := privateClaims{
+		GlobalUserID: claims.GlobalUserID,
+		TenantID:     claims.TenantID,
+		Tenant:       claims.Tenant,
+		KratosUserID: claims.KratosUserID,
+		SessionID:    claims.SessionID,
+		AAL:          claims.AAL,
+		Email:        claims.Email,
+		Phone:        claims.Phone,
+		Lang:         claims.Lang,
+		ClientID:     claims.ClientID,
+		Scope:        claims.Scope,
+	}
+	token, err := jwt.Signed(signer).Claims(registered).Claims(private).Serialize()
+	if err != nil {
+		return "", fmt.Errorf("sign access token: %w", err)
+	}
+	return token, nil
+}
+
+// SignIDToken issues an OpenID Connect ID token with the same keys as access tokens
+func (s *rsaSigner) SignIDToken(keyID string, privateKey []byte, claims *types.IDTokenClaims) (string, error) {
+	signer, err := newJoseSigner(keyID, privateKey, constants.IDTokenJWTType)
+	if err != nil {
+		return "", err
+	}
+
+	registered := jwt.Claims{
+		Issuer:   claims.Issuer,
+		Audience: jwt.Audience{claims.Audience},
+		Subject:  claims.Subject,
+		IssuedAt: jwt.NewNumericDate(claims.IssuedAt),
+		Expiry:   jwt.NewNumericDate(claims.ExpiresAt),
+	}
+	oidc := idTokenClaims{
+		Nonce:        claims.Nonce,
+		GlobalUserID: claims.GlobalUserID,
+		Tenant:       claims.Tenant,
+		SessionID:    claims.SessionID,
+		AAL:          claims.AAL,
+		Email:        claims.Email,
+		Phone:        claims.Phone,
+		Locale:       claims.Locale,
+	}
+	if !claims.AuthTime.IsZero() {
+		oidc.AuthTime = jwt.NewNumericDate(claims.AuthTime)
+	}
+	token, err := jwt.Signed(signer).Claims(registered).Claims(oidc).Serialize()
+	if err != nil {
+		return "", fmt.Errorf("sign id token: %w", err)
+	}
+	return token, nil
+}
+
+// Verify checks an access token against the public key its header names
+func (s *rsaSigner) Verify(token string, publicKeys []json.RawMessage) (*types.AccessTokenClaims, error) {
+	parsed, err := jwt.ParseSigned(token, []jose.SignatureAlgorithm{jose.RS256})
+	if err != nil {
+		return nil, fmt.Errorf("parse token: %w", err)
+	}
+	if len(parsed.Headers) != 1 || parsed.Headers[0].ExtraHeaders[jose.HeaderType] != constants.AccessTokenJWTType {
+		return nil, errors.New("not an access token")
+	}
+
+	var key *jose.JSONWebKey
+	for _, raw := range publicKeys {
+		var candidate jose.JSONWebKey
+		if err := json.Unmarshal(raw, &candidate); err != nil {
+			continue
+		}
+		if candidate.KeyID == parsed.Headers[0].KeyID {
+			key = &candidate
+			break
+		}
+	}
+	if key == nil {
+		return nil, errors.New("unknown signing key")
+	}
+
+	var registered jwt.Claims
+	var private privateClaims
+	if err := parsed.Claims(key.Key, &registered, &private); err != nil {
+		return nil, fmt.Errorf("verify token: %w", err)
+	}
+	if err := registered.ValidateWithLeeway(jwt.Expected{Time: time.Now()}, 0); err != nil {
+		return nil, fmt.Errorf("validate token: %w", err)
+	}
+
+	return &types.AccessTokenClaims{
+		ID:           registered.ID,
+		Issuer:       registered.Issuer,
+		Audience:     []string(registered.Audience),
+		Subject:      registered.Subject,
+		IssuedAt:     registered.IssuedAt.Time(),
+		ExpiresAt:    registered.Expiry.Time(),
+		GlobalUserID: private.GlobalUserID,
+		TenantID:     private.TenantID,
+		Tenant:       private.Tenant,
+		KratosUserID: private.KratosUserID,
+		SessionID:    private.SessionID,
+		AAL:          private.AAL,
+		Email:        private.Email,
+		Phone:        private.Phone,
+		Lang:         private.Lang,
+		ClientID:     private.ClientID,
+		Scope:        private.Scope,
+	}, nil
+}
+
+func newJoseSigner(keyID string, privateKey []byte, typ string) (jose.Signer, error) {
+	parsed, err := x509.ParsePKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("parse private key: %w", err)
+	}
+	rsaKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("signing key is not an rsa key")
+	}
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: rsaKey, KeyID: keyID}},
+		(&jose.SignerOptions{}).WithType(jose.ContentType(typ)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create signer: %w", err)
+	}
+	return signer, nil
+}
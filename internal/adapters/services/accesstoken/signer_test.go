@@ -0,0 +1,141 @@
+package accesstoken
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+)
+
+func TestRSASigner_SignVerifiesAgainstPublishedKey(t *testing.T) {
+	signer := NewRSASigner()
+	material, err := signer.GenerateKey()
+	require.NoError(t, err)
+	assert.Equal(t, "RS256", material.Algorithm)
+
+	var published jose.JSONWebKey
+	require.NoError(t, json.Unmarshal(material.PublicJWK, &published))
+	assert.Equal(t, material.KeyID, published.KeyID)
+	assert.True(t, published.IsPublic())
+
+	now := time.Now().Truncate(time.Second)
+	raw, err := signer.Sign(material.KeyID, material.PrivateKey, &types.AccessTokenClaims{
+		ID:           "token-1",
+		Issuer:       "iam",
+		Audience:     []string{"orders"},
+		Subject:      "global-1",
+		IssuedAt:     now,
+		ExpiresAt:    now.Add(15 * time.Minute),
+		GlobalUserID: "global-1",
+		TenantID:     "tenant-1",
+		KratosUserID: "kratos-1",
+		SessionID:    "session-1",
+		Email:        "alice@example.com",
+		Lang:         "en",
+	})
+	require.NoError(t, err)
+
+	token, err := jwt.ParseSigned(raw, []jose.SignatureAlgorithm{jose.RS256})
+	require.NoError(t, err)
+	require.Len(t, token.Headers, 1)
+	assert.Equal(t, material.KeyID, token.Headers[0].KeyID)
+	assert.Equal(t, "at+jwt", token.Headers[0].ExtraHeaders[jose.HeaderType])
+
+	var registered jwt.Claims
+	var private privateClaims
+	require.NoError(t, token.Claims(published, &registered, &private))
+	require.NoError(t, registered.Validate(jwt.Expected{Issuer: "iam", AnyAudience: jwt.Audience{"orders"}, Time: now}))
+	assert.Equal(t, "global-1", registered.Subject)
+	assert.Equal(t, privateClaims{
+		GlobalUserID: "global-1",
+		TenantID:     "tenant-1",
+		KratosUserID: "kratos-1",
+		SessionID:    "session-1",
+		Email:        "alice@example.com",
+		Lang:         "en",
+	}, private)
+
+	other, err := signer.GenerateKey()
+	require.NoError(t, err)
+	assert.NotEqual(t, material.KeyID, other.KeyID)
+	var otherKey jose.JSONWebKey
+	require.NoError(t, json.Unmarshal(other.PublicJWK, &otherKey))
+	assert.Error(t, token.Claims(otherKey, &registered))
+}
+
+func TestRSASigner_VerifyAcceptsOnlyPublishedAccessTokens(t *testing.T) {
+	signer := NewRSASigner()
+	material, err := signer.GenerateKey()
+	require.NoError(t, err)
+	published := []json.RawMessage{material.PublicJWK}
+
+	now := time.Now().Truncate(time.Second)
+	claims := &types.AccessTokenClaims{
+		ID:           "token-1",
+		Issuer:       "iam",
+		Audience:     []string{"partner-app"},
+		Subject:      "global-1",
+		IssuedAt:     now,
+		ExpiresAt:    now.Add(15 * time.Minute),
+		GlobalUserID: "global-1",
+		TenantID:     "tenant-1",
+		ClientID:     "partner-app",
+		Scope:        "openid email",
+	}
+	raw, err := signer.Sign(material.KeyID, material.PrivateKey, claims)
+	require.NoError(t, err)
+
+	verified, err := signer.Verify(raw, published)
+	require.NoError(t, err)
+	assert.Equal(t, "global-1", verified.Subject)
+	assert.Equal(t, "partner-app", verified.ClientID)
+	assert.Equal(t, "openid email", verified.Scope)
+	assert.True(t, claims.ExpiresAt.Equal(verified.ExpiresAt))
+
+	// Signed by a key that is not published
+	other, err := signer.GenerateKey()
+	require.NoError(t, err)
+	_, err = signer.Verify(raw, []json.RawMessage{other.PublicJWK})
+	assert.Error(t, err)
+
+	// Expired
+	claims.ExpiresAt = now.Add(-time.Minute)
+	expired, err := signer.Sign(material.KeyID, material.PrivateKey, claims)
+	require.NoError(t, err)
+	_, err = signer.Verify(expired, published)
+	assert.Error(t, err)
+
+	// ID tokens are not access tokens
+	idToken, err := signer.SignIDToken(material.KeyID, material.PrivateKey, &types.IDTokenClaims{
+		Issuer:       "https://iam.example.com/oidc/tenant-1",
+		Audience:     "partner-app",
+		Subject:      "global-1",
+		IssuedAt:     now,
+		ExpiresAt:    now.Add(15 * time.Minute),
+		AuthTime:     now.Add(-time.Minute),
+		Nonce:        "nonce-1",
+		GlobalUserID: "global-1",
+	})
+	require.NoError(t, err)
+	_, err = signer.Verify(idToken, published)
+	assert.Error(t, err)
+
+	token, err := jwt.ParseSigned(idToken, []jose.SignatureAlgorithm{jose.RS256})
+	require.NoError(t, err)
+	assert.Equal(t, "JWT", token.Headers[0].ExtraHeaders[jose.HeaderType])
+	var registered jwt.Claims
+	var oidc idTokenClaims
+	var key jose.JSONWebKey
+	require.NoError(t, json.Unmarshal(material.PublicJWK, &key))
+	require.NoError(t, token.Claims(key, &registered, &oidc))
+	assert.Equal(t, jwt.Audience{"partner-app"}, registered.Audience)
+	assert.Equal(t, "nonce-1", oidc.Nonce)
+	assert.Equal(t, "global-1", oidc.GlobalUserID)
+	assert.Equal(t, now.Add(-time.Minute), oidc.AuthTime.Time())
+}
@@ -0,0 +1,64 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+)
+
+// webhookSender hands emails to a mail relay through a webhook
+type webhookSender struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSender posts each email to url. Without a url every send fails, so callers can tell
+// emails are not configured.
+func NewWebhookSender(url string) domainservice.EmailSender {
+	return &webhookSender{
+		url:    url,
+		client: &http.Client{Timeout: constants.WebhookTimeout},
+	}
+}
+
+type emailPayload struct {
+	Tenant  string `json:"tenant"`
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+func (s *webhookSender) SendEmail(ctx context.Context, tenantName, to, subject, body string) error {
+	if s.url == "" {
+		return errors.New("NOTIFICATION_EMAIL_WEBHOOK_URL is not set")
+	}
+	logger.GetLogger().Infof("Sending email to %s via webhook", to)
+
+	bodyBytes, err := json.Marshal(emailPayload{Tenant: tenantName, To: to, Subject: subject, Body: body})
+	if err != nil {
+		return fmt.Errorf("failed to marshal email payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("failed to create email request: %w", err)
+	}
+	req.Header.Set(constants.HeaderKeyContentType, constants.HeaderContentTypeJson)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send email request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("email webhook returned non-2xx status: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSender_PostsEmail(t *testing.T) {
+	var received emailPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	err := NewWebhookSender(server.URL).SendEmail(context.Background(), "life_ai", "user@example.com", "Subject", "Body")
+	require.NoError(t, err)
+	assert.Equal(t, emailPayload{Tenant: "life_ai", To: "user@example.com", Subject: "Subject", Body: "Body"}, received)
+}
+
+func TestWebhookSender_Failures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	assert.Error(t, NewWebhookSender(server.URL).SendEmail(context.Background(), "life_ai", "user@example.com", "s", "b"))
+	assert.Error(t, NewWebhookSender("").SendEmail(context.Background(), "life_ai", "user@example.com", "s", "b"),
+		"an unconfigured sender does not pretend to deliver")
+}
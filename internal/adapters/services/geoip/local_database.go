@@ -0,0 +1,133 @@
+package geoip
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+)
+
+type ipRange struct {
+	start   netip.Addr
+	end     netip.Addr
+	country string
+}
+
+type localDatabase struct {
+	ranges []ipRange
+}
+
+// NewLocalDatabase builds a locator from a local CSV file. Each line is either
+// "<start ip>,<end ip>,<country>" as in the DB-IP lite downloads or
+// "<cidr>,<country>". An empty path gives a locator that knows no country.
+func NewLocalDatabase(path string) (domainservice.GeoIPLocator, error) {
+	db := &localDatabase{}
+	if path == "" {
+		return db, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open geoip database: %w", err)
+	}
+	defer f.Close()
+
+	if err := db.load(f); err != nil {
+		return nil, fmt.Errorf("load geoip database %s: %w", path, err)
+	}
+	return db, nil
+}
+
+// Country returns the ISO 3166 alpha-2 code of the country the IP is located in,
+// or an empty string when it is unknown
+func (db *localDatabase) Country(ip string) string {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return ""
+	}
+	addr = addr.Unmap()
+
+	// The first range ending at or after addr is the only one that can contain it
+	i := sort.Search(len(db.ranges), func(i int) bool {
+		return db.ranges[i].end.Compare(addr) >= 0
+	})
+	if i == len(db.ranges) || db.ranges[i].start.Compare(addr) > 0 {
+		return ""
+	}
+	return db.ranges[i].country
+}
+
+func (db *localDatabase) load(r io.Reader) error {
+	reader := csv.NewReader(bufio.NewReader(r))
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		rng, err := parseRange(record)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		db.ranges = append(db.ranges, rng)
+	}
+
+	sort.Slice(db.ranges, func(i, j int) bool {
+		return db.ranges[i].start.Less(db.ranges[j].start)
+	})
+	return nil
+}
+
+func parseRange(record []string) (ipRange, error) {
+	switch len(record) {
+	case 2:
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(record[0]))
+		if err != nil {
+			return ipRange{}, err
+		}
+		prefix = prefix.Masked()
+		return ipRange{start: prefix.Addr(), end: lastAddr(prefix), country: normalizeCountry(record[1])}, nil
+	case 3:
+		start, err := netip.ParseAddr(strings.TrimSpace(record[0]))
+		if err != nil {
+			return ipRange{}, err
+		}
+		end, err := netip.ParseAddr(strings.TrimSpace(record[1]))
+		if err != nil {
+			return ipRange{}, err
+		}
+		if start.Is4() != end.Is4() || end.Less(start) {
+			return ipRange{}, fmt.Errorf("invalid range %s-%s", start, end)
+		}
+		return ipRange{start: start, end: end, country: normalizeCountry(record[2])}, nil
+	default:
+		return ipRange{}, fmt.Errorf("expected 2 or 3 fields, got %d", len(record))
+	}
+}
+
+// lastAddr returns the highest address of the prefix
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	bytes := prefix.Addr().AsSlice()
+	for bit := prefix.Bits(); bit < len(bytes)*8; bit++ {
+		bytes[bit/8] |= 1 << (7 - bit%8)
+	}
+	addr, _ := netip.AddrFromSlice(bytes)
+	return addr
+}
+
+func normalizeCountry(country string) string {
+	return strings.ToUpper(strings.TrimSpace(country))
+}
@@ -0,0 +1,43 @@
+package geoip
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalDatabase(t *testing.T) {
+	content := "# start,end,country\n" +
+		"1.52.0.0,1.55.255.255,vn\n" +
+		"8.8.8.0/24,US\n" +
+		"2001:ee0::,2001:ee0:ffff:ffff:ffff:ffff:ffff:ffff,VN\n"
+	path := filepath.Join(t.TempDir(), "geoip.csv")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	locator, err := NewLocalDatabase(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "VN", locator.Country("1.53.10.20"))
+	assert.Equal(t, "VN", locator.Country("::ffff:1.55.255.255"), "IPv4-mapped addresses are unmapped")
+	assert.Equal(t, "US", locator.Country("8.8.8.8"))
+	assert.Equal(t, "VN", locator.Country("2001:ee0:1::1"))
+	assert.Empty(t, locator.Country("8.8.9.1"))
+	assert.Empty(t, locator.Country("not-an-ip"))
+}
+
+func TestLocalDatabase_EmptyPathKnowsNothing(t *testing.T) {
+	locator, err := NewLocalDatabase("")
+	require.NoError(t, err)
+	assert.Empty(t, locator.Country("8.8.8.8"))
+}
+
+func TestLocalDatabase_InvalidLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "geoip.csv")
+	require.NoError(t, os.WriteFile(path, []byte("8.8.8.8,1.1.1.1,US\n"), 0o600))
+
+	_, err := NewLocalDatabase(path)
+	assert.Error(t, err)
+}
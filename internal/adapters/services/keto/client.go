@@ -184,6 +184,63 @@ func (c *Client) CreateRelationTuple(ctx context.Context, request ucasetypes.Cre
 	return nil
 }
 
+// ListRelationTuplesBySubject returns the relation tuples granted to the user in any namespace,
+// following Keto's pagination to the last page
+func (c *Client) ListRelationTuplesBySubject(ctx context.Context, globalUserID string) ([]ucasetypes.RelationTuple, *domainerrors.DomainError) {
+	var tuples []ucasetypes.RelationTuple
+	pageToken := ""
+	for {
+		req := c.client.RelationshipApi.GetRelationships(ctx).SubjectId(globalUserID)
+		if pageToken != "" {
+			req = req.PageToken(pageToken)
+		}
+		page, _, err := req.Execute()
+		if err != nil {
+			logger.GetLogger().Errorf("failed to list relation tuples of subject %s: %v", globalUserID, err)
+			return nil, domainerrors.NewInternalError(
+				"MSG_FAILED_TO_LIST_RELATION_TUPLES",
+				"Failed to list relation tuples",
+			)
+		}
+
+		for _, tuple := range page.RelationTuples {
+			tuples = append(tuples, ucasetypes.RelationTuple{
+				Namespace: tuple.Namespace,
+				Object:    tuple.Object,
+				Relation:  tuple.Relation,
+			})
+		}
+
+		pageToken = page.GetNextPageToken()
+		if pageToken == "" {
+			return tuples, nil
+		}
+	}
+}
+
+// DeleteRelationTuplesBySubject removes every relation tuple granted to the user, in any namespace.
+// Deleting a subject that has no tuples is not an error.
+func (c *Client) DeleteRelationTuplesBySubject(ctx context.Context, globalUserID string) *domainerrors.DomainError {
+	httpResp, err := c.client.RelationshipApi.DeleteRelationships(ctx).SubjectId(globalUserID).Execute()
+	if err != nil {
+		logger.GetLogger().Errorf("failed to delete relation tuples of subject %s: %v", globalUserID, err)
+		return domainerrors.NewInternalError(
+			"MSG_FAILED_TO_DELETE_RELATION_TUPLES",
+			"Failed to delete relation tuples",
+		)
+	}
+
+	// Keto returns 204 No Content on success
+	if httpResp.StatusCode != http.StatusNoContent {
+		logger.GetLogger().Errorf("failed to delete relation tuples: unexpected status code %d", httpResp.StatusCode)
+		return domainerrors.NewInternalError(
+			"MSG_FAILED_TO_DELETE_RELATION_TUPLES",
+			"Failed to delete relation tuples",
+		)
+	}
+	return nil
+}
+
 func (c *Client) toKetoCheckPermissionBody(req ucasetypes.CheckPermissionRequest) keto.PostCheckPermissionBody {
 	return keto.PostCheckPermissionBody{
 		Namespace: &req.Namespace,
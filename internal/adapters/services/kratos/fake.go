@@ -35,6 +35,7 @@ type FakeKratosService struct {
 	flows      map[string]*flowRecord
 	faults     Faults
 	langs      map[uuid.UUID]string
+	passwords  map[string]string // identity ID -> password
 }
 
 type flowRecord struct {
@@ -49,6 +50,7 @@ func NewFakeKratosService() *FakeKratosService {
 		sessions:   make(map[string]*kratos.Session),
 		flows:      make(map[string]*flowRecord),
 		langs:      make(map[uuid.UUID]string),
+		passwords:  make(map[string]string),
 	}
 }
 
@@ -90,13 +92,23 @@ func (f *FakeKratosService) SubmitRegistrationFlow(
 	defer f.mu.Unlock()
 
 	id := uuid.NewString()
-	identity := &kratos.Identity{Id: id, Traits: traits}
+	identityTraits := make(map[string]interface{}, len(traits))
+	for k, v := range traits {
+		if k == "password" {
+			f.passwords[id], _ = v.(string)
+			continue
+		}
+		identityTraits[k] = v
+	}
+	identity := &kratos.Identity{Id: id, Traits: identityTraits}
 
 	if f.identities[tenantID] == nil {
 		f.identities[tenantID] = make(map[string]*kratos.Identity)
 	}
-	for k, v := range traits {
-		if k == constants.IdentifierEmail.String() || k == constants.IdentifierPhone.String() {
+	for k, v := range identityTraits {
+		switch k {
+		case constants.IdentifierEmail.String(), constants.IdentifierPhone.String(),
+			constants.IdentifierWallet.String(), constants.IdentifierPasskey.String():
 			val := v.(string)
 			f.identities[tenantID][val] = identity
 		}
@@ -112,10 +124,10 @@ func (f *FakeKratosService) SubmitRegistrationFlow(
 	token := uuid.NewString()
 	f.sessions[token] = session
 	if r, ok := f.flows[flow.Id]; ok {
-		r.traits = traits
+		r.traits = identityTraits
 	}
 
-	return &kratos.SuccessfulNativeRegistration{Session: session, SessionToken: &token}, nil
+	return &kratos.SuccessfulNativeRegistration{Identity: *identity, Session: session, SessionToken: &token}, nil
 }
 
 // --- Login flow ---
@@ -160,6 +172,11 @@ func (f *FakeKratosService) SubmitLoginFlow(
 		}
 		return nil, fmt.Errorf("phone number not found")
 	}
+	if method == constants.MethodTypePassword.String() {
+		if password == nil || f.passwords[identity.Id] == "" || f.passwords[identity.Id] != *password {
+			return nil, fmt.Errorf("the provided credentials are invalid")
+		}
+	}
 
 	session := &kratos.Session{
 		Id:              uuid.NewString(),
@@ -174,6 +191,30 @@ func (f *FakeKratosService) SubmitLoginFlow(
 	return &kratos.SuccessfulNativeLogin{Session: *session, SessionToken: &token}, nil
 }
 
+// --- Resending codes ---
+
+func (f *FakeKratosService) ResendLoginCode(ctx context.Context, tenantID uuid.UUID, flowID, identifier string) error {
+	return f.resendCode(flowID)
+}
+
+func (f *FakeKratosService) ResendRegistrationCode(ctx context.Context, tenantID uuid.UUID, flowID string) error {
+	return f.resendCode(flowID)
+}
+
+func (f *FakeKratosService) resendCode(flowID string) error {
+	if f.faults.NetworkError {
+		return errors.New("network error")
+	}
+	rec, ok := f.flows[flowID]
+	if !ok {
+		return errors.New("flow not found")
+	}
+	if f.faults.ExpireFlowsAfter > 0 && time.Since(rec.createdAt) > f.faults.ExpireFlowsAfter {
+		return errors.New("flow expired")
+	}
+	return nil
+}
+
 // --- Verification flow ---
 
 func (f *FakeKratosService) SubmitVerificationFlow(
@@ -446,6 +487,20 @@ func (f *FakeKratosService) SubmitSettingsFlow(ctx context.Context, tenantID uui
 	if f.faults.NetworkError {
 		return nil, errors.New("network error")
 	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if method == constants.MethodTypePassword.String() {
+		session, ok := f.sessions[sessionToken]
+		if !ok {
+			return nil, fmt.Errorf("session not found")
+		}
+		password, _ := traits["password"].(string)
+		if password == "" {
+			return nil, fmt.Errorf("password is required for the password settings method")
+		}
+		f.passwords[session.Identity.Id] = password
+		return &kratos.SettingsFlow{Id: flow.Id}, nil
+	}
 	if rec, ok := f.flows[flow.Id]; ok {
 		rec.traits = traits
 	}
@@ -482,6 +537,15 @@ func (f *FakeKratosService) GetIdentity(ctx context.Context, tenantID, identityI
 	return nil, fmt.Errorf("identity not found")
 }
 
+func (f *FakeKratosService) GetIdentityByIdentifierAdmin(ctx context.Context, tenantID uuid.UUID, identifier string) (*kratos.Identity, error) {
+	if f.faults.NetworkError {
+		return nil, errors.New("network error")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.identities[tenantID][identifier], nil
+}
+
 func (f *FakeKratosService) UpdateLangAdmin(ctx context.Context, tenantID, identityID uuid.UUID, newLang string) error {
 	if f.faults.NetworkError || f.faults.FailUpdate {
 		return errors.New("network error")
@@ -490,5 +554,105 @@ func (f *FakeKratosService) UpdateLangAdmin(ctx context.Context, tenantID, ident
 	return nil
 }
 
+func (f *FakeKratosService) ExtendSession(ctx context.Context, tenantID uuid.UUID, sessionID string) (*kratos.Session, error) {
+	if f.faults.NetworkError {
+		return nil, errors.New("network error")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, s := range f.sessions {
+		if s.Id == sessionID {
+			s.ExpiresAt = ptr(time.Now().Add(30 * time.Minute))
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("session not found")
+}
+
+func (f *FakeKratosService) DisableSessionAdmin(ctx context.Context, tenantID uuid.UUID, sessionID string) error {
+	if f.faults.NetworkError {
+		return errors.New("network error")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for token, s := range f.sessions {
+		if s.Id == sessionID {
+			delete(f.sessions, token)
+		}
+	}
+	return nil
+}
+
+// The fake does not decode ID tokens; the raw token stands in for the provider subject.
+func oidcCredentialKey(provider, idToken string) string {
+	return "oidc:" + provider + ":" + idToken
+}
+
+func (f *FakeKratosService) SubmitRegistrationFlowWithIDToken(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	flow *kratos.RegistrationFlow,
+	provider, idToken, nonce string,
+	traits map[string]interface{},
+) (*kratos.SuccessfulNativeRegistration, error) {
+	if f.faults.NetworkError || f.faults.FailRegistration {
+		return nil, errors.New("registration failed")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.identities[tenantID] == nil {
+		f.identities[tenantID] = make(map[string]*kratos.Identity)
+	}
+	key := oidcCredentialKey(provider, idToken)
+	if _, exists := f.identities[tenantID][key]; exists {
+		return nil, fmt.Errorf("an account with the same identifier exists already")
+	}
+	identity := &kratos.Identity{Id: uuid.NewString(), Traits: traits}
+	f.identities[tenantID][key] = identity
+
+	session := &kratos.Session{
+		Id:              uuid.NewString(),
+		Active:          ptr(true),
+		Identity:        identity,
+		AuthenticatedAt: ptr(time.Now()),
+		ExpiresAt:       ptr(time.Now().Add(30 * time.Minute)),
+	}
+	token := uuid.NewString()
+	f.sessions[token] = session
+	return &kratos.SuccessfulNativeRegistration{Session: session, SessionToken: &token}, nil
+}
+
+func (f *FakeKratosService) SubmitLoginFlowWithIDToken(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	flow *kratos.LoginFlow,
+	provider, idToken, nonce string,
+) (*kratos.SuccessfulNativeLogin, error) {
+	if f.faults.NetworkError || f.faults.FailLogin {
+		return nil, errors.New("login failed")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	identity, ok := f.identities[tenantID][oidcCredentialKey(provider, idToken)]
+	if !ok {
+		return nil, fmt.Errorf("the provided credentials are invalid")
+	}
+
+	session := &kratos.Session{
+		Id:              uuid.NewString(),
+		Active:          ptr(true),
+		Identity:        identity,
+		AuthenticatedAt: ptr(time.Now()),
+		ExpiresAt:       ptr(time.Now().Add(30 * time.Minute)),
+	}
+	token := uuid.NewString()
+	f.sessions[token] = session
+	return &kratos.SuccessfulNativeLogin{Session: *session, SessionToken: &token}, nil
+}
+
 // helpers
 func ptr[T any](v T) *T { return &v }
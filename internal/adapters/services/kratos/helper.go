@@ -1,6 +1,7 @@
 package kratos
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"maps"
@@ -9,6 +10,8 @@ import (
 
 	"github.com/lifenetwork-ai/iam-service/constants"
 	kratos_types "github.com/lifenetwork-ai/iam-service/internal/adapters/services/kratos/types"
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+	kratos "github.com/ory/kratos-client-go"
 	"github.com/pkg/errors"
 )
 
@@ -49,6 +52,52 @@ func normalizeTraitsIdentifiers(in map[string]interface{}) (map[string]interface
 	return traits, nil
 }
 
+// registrationFlowTraits returns the traits submitted with a registration flow, which Kratos
+// expects again with every later submission of the flow
+func registrationFlowTraits(ctx context.Context, publicAPI *kratos.APIClient, flowID string) (map[string]interface{}, error) {
+	_, resp, err := publicAPI.FrontendAPI.GetRegistrationFlow(ctx).Id(flowID).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registration flow: %w", err)
+	}
+	if resp == nil || resp.Body == nil {
+		return nil, fmt.Errorf("empty response from registration flow")
+	}
+	defer resp.Body.Close()
+
+	flowData, err := kratos_types.FromHttpResp(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse flow data: %w", err)
+	}
+
+	traits := flowData.GetTraits()
+	if len(traits) == 0 {
+		return nil, fmt.Errorf("no traits found in registration flow")
+	}
+	return traits, nil
+}
+
+// rejectedFlowError keeps the message of a submission Kratos answered with 400 while letting
+// callers match it with ErrFlowRejected
+type rejectedFlowError struct {
+	err error
+}
+
+func (e *rejectedFlowError) Error() string {
+	return e.err.Error()
+}
+
+func (e *rejectedFlowError) Unwrap() []error {
+	return []error{e.err, domainservice.ErrFlowRejected}
+}
+
+// rejectFlow marks a non-nil error from parseKratosErrorResponse as a rejected submission
+func rejectFlow(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &rejectedFlowError{err: err}
+}
+
 // parseKratosErrorResponse parses error response from Kratos and returns appropriate error
 func parseKratosErrorResponse(resp *http.Response, defaultErr error) error {
 	if resp == nil {
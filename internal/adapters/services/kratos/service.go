@@ -8,7 +8,6 @@ import (
 	"github.com/google/uuid"
 	"github.com/lifenetwork-ai/iam-service/conf"
 	"github.com/lifenetwork-ai/iam-service/constants"
-	kratos_types "github.com/lifenetwork-ai/iam-service/internal/adapters/services/kratos/types"
 	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
 	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
 	kratos "github.com/ory/kratos-client-go"
@@ -79,9 +78,20 @@ func (k *kratosServiceImpl) SubmitRegistrationFlow(
 		return result, nil
 
 	case constants.MethodTypePassword.String():
+		password, _ := traits["password"].(string)
+		if password == "" {
+			return nil, fmt.Errorf("password is required for the password registration method")
+		}
+		identityTraits := make(map[string]interface{}, len(traits))
+		for k, v := range traits {
+			if k != "password" {
+				identityTraits[k] = v
+			}
+		}
 		body.UpdateRegistrationFlowWithPasswordMethod = &kratos.UpdateRegistrationFlowWithPasswordMethod{
-			Method: constants.MethodTypePassword.String(),
-			Traits: traits,
+			Method:   constants.MethodTypePassword.String(),
+			Password: password,
+			Traits:   identityTraits,
 		}
 		result, resp, err := submitFlow.UpdateRegistrationFlowBody(body).Execute()
 		if err != nil {
@@ -121,44 +131,103 @@ func (k *kratosServiceImpl) SubmitRegistrationFlowWithCode(ctx context.Context,
 	}
 
 	// Get the flow again to extract traits
-	_, resp, err := publicAPI.FrontendAPI.GetRegistrationFlow(ctx).Id(flow.Id).Execute()
+	traits, err := registrationFlowTraits(ctx, publicAPI, flow.Id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get registration flow: %w", err)
+		return nil, err
 	}
-	if resp == nil || resp.Body == nil {
-		return nil, fmt.Errorf("empty response from registration flow")
+
+	// Submit the flow with code
+	submitFlow := publicAPI.FrontendAPI.UpdateRegistrationFlow(ctx).Flow(flow.Id)
+	body := kratos.UpdateRegistrationFlowBody{
+		UpdateRegistrationFlowWithCodeMethod: &kratos.UpdateRegistrationFlowWithCodeMethod{
+			Method: constants.MethodTypeCode.String(),
+			Code:   &code,
+			Traits: traits,
+		},
 	}
-	defer resp.Body.Close()
 
-	flowData, err := kratos_types.FromHttpResp(resp)
+	result, resp, err := submitFlow.UpdateRegistrationFlowBody(body).Execute()
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse flow data: %w", err)
+		if resp != nil && resp.StatusCode == 400 {
+			if err := rejectFlow(parseKratosErrorResponse(resp, fmt.Errorf("registration failed: %w", err))); err != nil {
+				return nil, err
+			}
+			return &kratos.SuccessfulNativeRegistration{}, nil
+		}
+		return nil, fmt.Errorf("failed to submit registration flow with code: %w", err)
+	}
+
+	return result, nil
+}
+
+// ResendRegistrationCode sends a new code for an ongoing registration flow, invalidating the previous one
+func (k *kratosServiceImpl) ResendRegistrationCode(ctx context.Context, tenantID uuid.UUID, flowID string) error {
+	publicAPI, err := k.client.PublicAPI(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get public API client: %w", err)
 	}
 
-	traits := flowData.GetTraits()
-	if len(traits) == 0 {
-		return nil, fmt.Errorf("no traits found in registration flow")
+	traits, err := registrationFlowTraits(ctx, publicAPI, flowID)
+	if err != nil {
+		return err
 	}
 
-	// Submit the flow with code
-	submitFlow := publicAPI.FrontendAPI.UpdateRegistrationFlow(ctx).Flow(flow.Id)
+	resend := constants.MethodTypeCode.String()
 	body := kratos.UpdateRegistrationFlowBody{
 		UpdateRegistrationFlowWithCodeMethod: &kratos.UpdateRegistrationFlowWithCodeMethod{
 			Method: constants.MethodTypeCode.String(),
-			Code:   &code,
 			Traits: traits,
+			Resend: &resend,
 		},
 	}
 
-	result, resp, err := submitFlow.UpdateRegistrationFlowBody(body).Execute()
+	_, resp, err := publicAPI.FrontendAPI.UpdateRegistrationFlow(ctx).Flow(flowID).UpdateRegistrationFlowBody(body).Execute()
+	if err != nil {
+		// Like the first submission, a resent code is answered with 400 and the sent_email state
+		if resp != nil && resp.StatusCode == 400 {
+			return parseKratosErrorResponse(resp, fmt.Errorf("resend registration code failed: %w", err))
+		}
+		return fmt.Errorf("failed to resend registration code: %w", err)
+	}
+	return nil
+}
+
+// SubmitRegistrationFlowWithIDToken registers an identity from a social provider ID token.
+// Kratos verifies the token again and stores it as the identity's OIDC credential.
+func (k *kratosServiceImpl) SubmitRegistrationFlowWithIDToken(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	flow *kratos.RegistrationFlow,
+	provider, idToken, nonce string,
+	traits map[string]interface{},
+) (*kratos.SuccessfulNativeRegistration, error) {
+	publicAPI, err := k.client.PublicAPI(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public API client: %w", err)
+	}
+
+	method := &kratos.UpdateRegistrationFlowWithOidcMethod{
+		Method:   constants.MethodTypeOIDC.String(),
+		Provider: provider,
+		IdToken:  &idToken,
+		Traits:   traits,
+	}
+	if nonce != "" {
+		method.IdTokenNonce = &nonce
+	}
+
+	result, resp, err := publicAPI.FrontendAPI.UpdateRegistrationFlow(ctx).
+		Flow(flow.Id).
+		UpdateRegistrationFlowBody(kratos.UpdateRegistrationFlowBody{UpdateRegistrationFlowWithOidcMethod: method}).
+		Execute()
 	if err != nil {
 		if resp != nil && resp.StatusCode == 400 {
 			if err := parseKratosErrorResponse(resp, fmt.Errorf("registration failed: %w", err)); err != nil {
 				return nil, err
 			}
-			return &kratos.SuccessfulNativeRegistration{}, nil
+			return nil, fmt.Errorf("registration with id token did not complete")
 		}
-		return nil, fmt.Errorf("failed to submit registration flow with code: %w", err)
+		return nil, fmt.Errorf("failed to submit registration flow with id token: %w", err)
 	}
 
 	return result, nil
@@ -220,7 +289,7 @@ func (k *kratosServiceImpl) SubmitLoginFlow(ctx context.Context, tenantID uuid.U
 	result, resp, err := submitFlow.UpdateLoginFlowBody(body).Execute()
 	if err != nil {
 		if resp != nil && resp.StatusCode == 400 {
-			if err := parseKratosErrorResponse(resp, fmt.Errorf("login failed: %w", err)); err != nil {
+			if err := rejectFlow(parseKratosErrorResponse(resp, fmt.Errorf("login failed: %w", err))); err != nil {
 				return nil, err
 			}
 			return &kratos.SuccessfulNativeLogin{}, nil
@@ -231,6 +300,70 @@ func (k *kratosServiceImpl) SubmitLoginFlow(ctx context.Context, tenantID uuid.U
 	return result, nil
 }
 
+// ResendLoginCode sends a new code for an ongoing login flow, invalidating the previous one
+func (k *kratosServiceImpl) ResendLoginCode(ctx context.Context, tenantID uuid.UUID, flowID, identifier string) error {
+	publicAPI, err := k.client.PublicAPI(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get public API client: %w", err)
+	}
+
+	resend := constants.MethodTypeCode.String()
+	body := kratos.UpdateLoginFlowBody{
+		UpdateLoginFlowWithCodeMethod: &kratos.UpdateLoginFlowWithCodeMethod{
+			Method:     constants.MethodTypeCode.String(),
+			Identifier: &identifier,
+			Resend:     &resend,
+		},
+	}
+
+	_, resp, err := publicAPI.FrontendAPI.UpdateLoginFlow(ctx).Flow(flowID).UpdateLoginFlowBody(body).Execute()
+	if err != nil {
+		if resp != nil && resp.StatusCode == 400 {
+			return parseKratosErrorResponse(resp, fmt.Errorf("resend login code failed: %w", err))
+		}
+		return fmt.Errorf("failed to resend login code: %w", err)
+	}
+	return nil
+}
+
+// SubmitLoginFlowWithIDToken signs in the identity that holds the provider credential of the ID token
+func (k *kratosServiceImpl) SubmitLoginFlowWithIDToken(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	flow *kratos.LoginFlow,
+	provider, idToken, nonce string,
+) (*kratos.SuccessfulNativeLogin, error) {
+	publicAPI, err := k.client.PublicAPI(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public API client: %w", err)
+	}
+
+	method := &kratos.UpdateLoginFlowWithOidcMethod{
+		Method:   constants.MethodTypeOIDC.String(),
+		Provider: provider,
+		IdToken:  &idToken,
+	}
+	if nonce != "" {
+		method.IdTokenNonce = &nonce
+	}
+
+	result, resp, err := publicAPI.FrontendAPI.UpdateLoginFlow(ctx).
+		Flow(flow.Id).
+		UpdateLoginFlowBody(kratos.UpdateLoginFlowBody{UpdateLoginFlowWithOidcMethod: method}).
+		Execute()
+	if err != nil {
+		if resp != nil && resp.StatusCode == 400 {
+			if err := parseKratosErrorResponse(resp, fmt.Errorf("login failed: %w", err)); err != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("login with id token did not complete")
+		}
+		return nil, fmt.Errorf("failed to submit login flow with id token: %w", err)
+	}
+
+	return result, nil
+}
+
 // InitializeVerificationFlow initiates a new verification flow
 func (k *kratosServiceImpl) InitializeVerificationFlow(ctx context.Context, tenantID uuid.UUID) (string, error) {
 	publicAPI, err := k.client.PublicAPI(tenantID)
@@ -290,7 +423,7 @@ func (k *kratosServiceImpl) SubmitVerificationFlow(ctx context.Context, tenantID
 		Execute()
 	if err != nil {
 		if resp != nil && resp.StatusCode == 400 {
-			if err := parseKratosErrorResponse(resp, fmt.Errorf("verification failed: %w", err)); err != nil {
+			if err := rejectFlow(parseKratosErrorResponse(resp, fmt.Errorf("verification failed: %w", err))); err != nil {
 				return nil, err
 			}
 			return nil, nil
@@ -437,6 +570,7 @@ func (k *kratosServiceImpl) GetSettingsFlow(
 	return flow, nil
 }
 
+// SubmitSettingsFlow submits a settings flow using the profile or password method
 func (k *kratosServiceImpl) SubmitSettingsFlow(
 	ctx context.Context,
 	tenantID uuid.UUID,
@@ -459,6 +593,15 @@ func (k *kratosServiceImpl) SubmitSettingsFlow(
 			Method: method,
 			Traits: traits,
 		}
+	case constants.MethodTypePassword.String():
+		password, _ := traits["password"].(string)
+		if password == "" {
+			return nil, fmt.Errorf("password is required for the password settings method")
+		}
+		body.UpdateSettingsFlowWithPasswordMethod = &kratos.UpdateSettingsFlowWithPasswordMethod{
+			Method:   method,
+			Password: password,
+		}
 	default:
 		return nil, fmt.Errorf("unsupported settings method: %s", method)
 	}
@@ -509,14 +652,36 @@ func (k *kratosServiceImpl) GetIdentity(ctx context.Context, tenantID, identityI
 	return identity, nil
 }
 
+// GetIdentityByIdentifierAdmin looks an identity up by the exact identifier of one of its
+// credentials, returning nil when there is none
+func (k *kratosServiceImpl) GetIdentityByIdentifierAdmin(ctx context.Context, tenantID uuid.UUID, identifier string) (*kratos.Identity, error) {
+	adminAPI, err := k.client.AdminAPI(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("get admin API failed: %w", err)
+	}
+
+	identities, _, err := adminAPI.IdentityAPI.ListIdentities(ctx).CredentialsIdentifier(identifier).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("list identities failed: %w", err)
+	}
+	if len(identities) == 0 {
+		return nil, nil
+	}
+	return &identities[0], nil
+}
+
 func (k *kratosServiceImpl) DeleteIdentifierAdmin(ctx context.Context, tenantID, identityID uuid.UUID) error {
 	adminAPI, err := k.client.AdminAPI(tenantID)
 	if err != nil {
 		return fmt.Errorf("get admin API failed: %w", err)
 	}
 
-	_, err = adminAPI.IdentityAPI.DeleteIdentity(ctx, identityID.String()).Execute()
+	resp, err := adminAPI.IdentityAPI.DeleteIdentity(ctx, identityID.String()).Execute()
 	if err != nil {
+		// The identity is already gone, which is what the caller wanted
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil
+		}
 		return fmt.Errorf("delete identity failed: %w", err)
 	}
 
@@ -581,3 +746,31 @@ func (k *kratosServiceImpl) CreateIdentityAdmin(
 	}
 	return identity, http.StatusCreated, nil
 }
+
+// ExtendSession pushes the expiry of a session forward by the lifespan configured in Kratos
+func (k *kratosServiceImpl) ExtendSession(ctx context.Context, tenantID uuid.UUID, sessionID string) (*kratos.Session, error) {
+	adminAPI, err := k.client.AdminAPI(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("get admin API failed: %w", err)
+	}
+
+	session, _, err := adminAPI.IdentityAPI.ExtendSession(ctx, sessionID).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("extend session failed: %w", err)
+	}
+	return session, nil
+}
+
+// DisableSessionAdmin revokes a session by ID, without needing its token
+func (k *kratosServiceImpl) DisableSessionAdmin(ctx context.Context, tenantID uuid.UUID, sessionID string) error {
+	adminAPI, err := k.client.AdminAPI(tenantID)
+	if err != nil {
+		return fmt.Errorf("get admin API failed: %w", err)
+	}
+
+	_, err = adminAPI.IdentityAPI.DisableSession(ctx, sessionID).Execute()
+	if err != nil {
+		return fmt.Errorf("disable session failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+)
+
+// Well-known issuers of the supported providers
+var (
+	GoogleIssuers = []string{"https://accounts.google.com", "accounts.google.com"}
+	AppleIssuers  = []string{"https://appleid.apple.com"}
+)
+
+var supportedAlgorithms = []jose.SignatureAlgorithm{jose.RS256, jose.ES256}
+
+// Provider describes how ID tokens of one provider are verified
+type Provider struct {
+	Issuers   []string
+	JWKSURL   string
+	ClientIDs []string
+}
+
+type cachedKeySet struct {
+	keys      jose.JSONWebKeySet
+	fetchedAt time.Time
+}
+
+type jwksVerifier struct {
+	providers  map[string]Provider
+	httpClient *http.Client
+
+	mu    sync.Mutex
+	cache map[string]*cachedKeySet // keyed by JWKS URL
+}
+
+// NewJWKSVerifier verifies ID tokens against the providers' published key sets.
+// Providers without client IDs are treated as disabled.
+func NewJWKSVerifier(providers map[string]Provider, httpClient *http.Client) domainservice.OIDCTokenVerifier {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &jwksVerifier{
+		providers:  providers,
+		httpClient: httpClient,
+		cache:      make(map[string]*cachedKeySet),
+	}
+}
+
+// idTokenClaims are the provider specific claims on top of the registered ones
+type idTokenClaims struct {
+	Nonce         string       `json:"nonce"`
+	Email         string       `json:"email"`
+	EmailVerified flexibleBool `json:"email_verified"`
+}
+
+// flexibleBool accepts both JSON booleans and "true"/"false" strings, as Apple sends the latter
+type flexibleBool bool
+
+func (b *flexibleBool) UnmarshalJSON(data []byte) error {
+	switch strings.Trim(string(data), `"`) {
+	case "true":
+		*b = true
+	case "false", "null", "":
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean value %s", data)
+	}
+	return nil
+}
+
+// Verify checks the signature, issuer, audience, expiry and, when given, the nonce of an ID token
+func (v *jwksVerifier) Verify(ctx context.Context, provider, rawIDToken, nonce string) (*types.OIDCClaims, error) {
+	p, ok := v.providers[provider]
+	if !ok || len(p.ClientIDs) == 0 {
+		return nil, fmt.Errorf("provider %s is not configured", provider)
+	}
+
+	token, err := jwt.ParseSigned(rawIDToken, supportedAlgorithms)
+	if err != nil {
+		return nil, fmt.Errorf("parse id token: %w", err)
+	}
+	if len(token.Headers) != 1 {
+		return nil, errors.New("id token must carry exactly one signature")
+	}
+
+	key, err := v.key(ctx, p.JWKSURL, token.Headers[0].KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var registered jwt.Claims
+	var extra idTokenClaims
+	if err := token.Claims(key, &registered, &extra); err != nil {
+		return nil, fmt.Errorf("verify id token: %w", err)
+	}
+
+	if registered.Expiry == nil {
+		return nil, errors.New("id token has no expiry")
+	}
+	expected := jwt.Expected{AnyAudience: jwt.Audience(p.ClientIDs), Time: time.Now()}
+	if err := registered.ValidateWithLeeway(expected, constants.OIDCClockSkew); err != nil {
+		return nil, fmt.Errorf("validate id token: %w", err)
+	}
+	if !slices.Contains(p.Issuers, registered.Issuer) {
+		return nil, fmt.Errorf("unexpected issuer %q", registered.Issuer)
+	}
+	if registered.Subject == "" {
+		return nil, errors.New("id token has no subject")
+	}
+	if nonce != "" && extra.Nonce != nonce {
+		return nil, errors.New("id token nonce mismatch")
+	}
+
+	return &types.OIDCClaims{
+		Provider:      provider,
+		Subject:       registered.Subject,
+		Email:         strings.ToLower(strings.TrimSpace(extra.Email)),
+		EmailVerified: bool(extra.EmailVerified),
+	}, nil
+}
+
+// key returns the signing key for kid, refetching the key set when it is stale
+// or does not know kid yet (providers rotate keys without notice).
+func (v *jwksVerifier) key(ctx context.Context, jwksURL, kid string) (*jose.JSONWebKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	cached := v.cache[jwksURL]
+	if cached != nil && time.Since(cached.fetchedAt) < constants.OIDCJWKSCacheTTL {
+		if key := lookupKey(cached.keys, kid); key != nil {
+			return key, nil
+		}
+		if time.Since(cached.fetchedAt) < constants.OIDCJWKSRefreshInterval {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+	}
+
+	keys, err := v.fetch(ctx, jwksURL)
+	if err != nil {
+		return nil, err
+	}
+	v.cache[jwksURL] = &cachedKeySet{keys: *keys, fetchedAt: time.Now()}
+
+	if key := lookupKey(*keys, kid); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+func (v *jwksVerifier) fetch(ctx context.Context, jwksURL string) (*jose.JSONWebKeySet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build jwks request: %w", err)
+	}
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch jwks: unexpected status %d", resp.StatusCode)
+	}
+	var keys jose.JSONWebKeySet
+	if err := json.NewDecoder(resp.Body).Decode(&keys); err != nil {
+		return nil, fmt.Errorf("decode jwks: %w", err)
+	}
+	return &keys, nil
+}
+
+// lookupKey finds the key by ID; a token without kid is only accepted for single-key sets
+func lookupKey(set jose.JSONWebKeySet, kid string) *jose.JSONWebKey {
+	if kid == "" {
+		if len(set.Keys) == 1 {
+			return &set.Keys[0]
+		}
+		return nil
+	}
+	if keys := set.Key(kid); len(keys) > 0 {
+		return &keys[0]
+	}
+	return nil
+}
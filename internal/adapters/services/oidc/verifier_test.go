@@ -0,0 +1,135 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testClientID = "test-client.apps.googleusercontent.com"
+
+type jwksStandIn struct {
+	key     *rsa.PrivateKey
+	kid     string
+	server  *httptest.Server
+	fetches atomic.Int32
+}
+
+func newJWKSStandIn(t *testing.T) *jwksStandIn {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	s := &jwksStandIn{key: key, kid: "key-1"}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.fetches.Add(1)
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &s.key.PublicKey, KeyID: s.kid, Algorithm: string(jose.RS256), Use: "sig"},
+		}})
+	}))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+func (s *jwksStandIn) verifier() *jwksVerifier {
+	v := NewJWKSVerifier(map[string]Provider{
+		"google": {Issuers: GoogleIssuers, JWKSURL: s.server.URL, ClientIDs: []string{testClientID}},
+	}, s.server.Client())
+	return v.(*jwksVerifier)
+}
+
+func (s *jwksStandIn) sign(t *testing.T, key *rsa.PrivateKey, claims jwt.Claims, extra map[string]interface{}) string {
+	t.Helper()
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", s.kid),
+	)
+	require.NoError(t, err)
+	raw, err := jwt.Signed(signer).Claims(claims).Claims(extra).Serialize()
+	require.NoError(t, err)
+	return raw
+}
+
+func validClaims() jwt.Claims {
+	now := time.Now()
+	return jwt.Claims{
+		Issuer:   "https://accounts.google.com",
+		Subject:  "1234567890",
+		Audience: jwt.Audience{testClientID},
+		IssuedAt: jwt.NewNumericDate(now),
+		Expiry:   jwt.NewNumericDate(now.Add(time.Hour)),
+	}
+}
+
+func TestVerify_ValidToken(t *testing.T) {
+	s := newJWKSStandIn(t)
+	raw := s.sign(t, s.key, validClaims(), map[string]interface{}{
+		"email":          "User@Example.com",
+		"email_verified": "true",
+		"nonce":          "n-1",
+	})
+
+	claims, err := s.verifier().Verify(context.Background(), "google", raw, "n-1")
+	require.NoError(t, err)
+	assert.Equal(t, "google", claims.Provider)
+	assert.Equal(t, "1234567890", claims.Subject)
+	assert.Equal(t, "user@example.com", claims.Email)
+	assert.True(t, claims.EmailVerified)
+}
+
+func TestVerify_CachesKeySet(t *testing.T) {
+	s := newJWKSStandIn(t)
+	v := s.verifier()
+	raw := s.sign(t, s.key, validClaims(), nil)
+
+	for i := 0; i < 3; i++ {
+		_, err := v.Verify(context.Background(), "google", raw, "")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), s.fetches.Load())
+}
+
+func TestVerify_Rejections(t *testing.T) {
+	s := newJWKSStandIn(t)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	wrongAudience := validClaims()
+	wrongAudience.Audience = jwt.Audience{"someone-else"}
+	expired := validClaims()
+	expired.Expiry = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+	wrongIssuer := validClaims()
+	wrongIssuer.Issuer = "https://evil.example.com"
+
+	tests := []struct {
+		name     string
+		provider string
+		raw      string
+		nonce    string
+	}{
+		{"wrong audience", "google", s.sign(t, s.key, wrongAudience, nil), ""},
+		{"expired", "google", s.sign(t, s.key, expired, nil), ""},
+		{"wrong issuer", "google", s.sign(t, s.key, wrongIssuer, nil), ""},
+		{"bad signature", "google", s.sign(t, otherKey, validClaims(), nil), ""},
+		{"nonce mismatch", "google", s.sign(t, s.key, validClaims(), map[string]interface{}{"nonce": "a"}), "b"},
+		{"unconfigured provider", "apple", s.sign(t, s.key, validClaims(), nil), ""},
+		{"malformed", "google", "not-a-jwt", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.verifier().Verify(context.Background(), tt.provider, tt.raw, tt.nonce)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package passkey
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+)
+
+// ErrPossibleClone is returned when an authenticator reports a signature counter that did not increase
+var ErrPossibleClone = errors.New("passkey signature counter did not increase, the authenticator may be cloned")
+
+type webAuthnService struct{}
+
+// NewWebAuthnService returns a PasskeyService that requires discoverable, user-verified credentials
+func NewWebAuthnService() domainservice.PasskeyService {
+	return &webAuthnService{}
+}
+
+func (s *webAuthnService) BeginRegistration(
+	rp types.PasskeyRelyingParty,
+	user types.PasskeyUser,
+	existing []*types.PasskeyCredential,
+) (*types.PasskeyCeremony, error) {
+	wa, err := newWebAuthn(rp)
+	if err != nil {
+		return nil, err
+	}
+
+	exclusions := make([]protocol.CredentialDescriptor, 0, len(existing))
+	for _, c := range existing {
+		exclusions = append(exclusions, toCredential(c).Descriptor())
+	}
+	creation, session, err := wa.BeginRegistration(
+		&passkeyUser{user: user},
+		webauthn.WithExclusions(exclusions),
+		webauthn.WithAuthenticatorSelection(protocol.AuthenticatorSelection{
+			ResidentKey:        protocol.ResidentKeyRequirementRequired,
+			RequireResidentKey: protocol.ResidentKeyRequired(),
+			UserVerification:   protocol.VerificationRequired,
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return newCeremony(creation, session)
+}
+
+func (s *webAuthnService) FinishRegistration(
+	rp types.PasskeyRelyingParty,
+	user types.PasskeyUser,
+	state string,
+	response []byte,
+) (*types.PasskeyCredential, error) {
+	wa, err := newWebAuthn(rp)
+	if err != nil {
+		return nil, err
+	}
+	var session webauthn.SessionData
+	if err := json.Unmarshal([]byte(state), &session); err != nil {
+		return nil, fmt.Errorf("decode passkey session: %w", err)
+	}
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(response)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := wa.CreateCredential(&passkeyUser{user: user}, session, parsed)
+	if err != nil {
+		return nil, err
+	}
+	result := fromCredential(credential)
+	result.UserHandle = user.Handle
+	return result, nil
+}
+
+func (s *webAuthnService) BeginLogin(rp types.PasskeyRelyingParty) (*types.PasskeyCeremony, error) {
+	wa, err := newWebAuthn(rp)
+	if err != nil {
+		return nil, err
+	}
+	assertion, session, err := wa.BeginDiscoverableLogin(webauthn.WithUserVerification(protocol.VerificationRequired))
+	if err != nil {
+		return nil, err
+	}
+	return newCeremony(assertion, session)
+}
+
+func (s *webAuthnService) FinishLogin(
+	rp types.PasskeyRelyingParty,
+	state string,
+	response []byte,
+	lookup func(credentialID, userHandle []byte) (*types.PasskeyCredential, error),
+) (*types.PasskeyCredential, error) {
+	wa, err := newWebAuthn(rp)
+	if err != nil {
+		return nil, err
+	}
+	var session webauthn.SessionData
+	if err := json.Unmarshal([]byte(state), &session); err != nil {
+		return nil, fmt.Errorf("decode passkey session: %w", err)
+	}
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(response)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored *types.PasskeyCredential
+	handler := func(rawID, userHandle []byte) (webauthn.User, error) {
+		c, err := lookup(rawID, userHandle)
+		if err != nil {
+			return nil, err
+		}
+		stored = c
+		return &passkeyUser{user: types.PasskeyUser{Handle: c.UserHandle}, credentials: []webauthn.Credential{toCredential(c)}}, nil
+	}
+	_, credential, err := wa.ValidatePasskeyLogin(handler, session, parsed)
+	if err != nil {
+		return nil, err
+	}
+	if credential.Authenticator.CloneWarning {
+		return nil, ErrPossibleClone
+	}
+
+	result := fromCredential(credential)
+	result.UserHandle = stored.UserHandle
+	return result, nil
+}
+
+func newWebAuthn(rp types.PasskeyRelyingParty) (*webauthn.WebAuthn, error) {
+	if rp.ID == "" || rp.Origin == "" {
+		return nil, errors.New("passkey relying party is not configured")
+	}
+	return webauthn.New(&webauthn.Config{
+		RPID:          rp.ID,
+		RPDisplayName: rp.Name,
+		RPOrigins:     []string{rp.Origin},
+	})
+}
+
+func newCeremony(options interface{}, session *webauthn.SessionData) (*types.PasskeyCeremony, error) {
+	rawOptions, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+	state, err := json.Marshal(session)
+	if err != nil {
+		return nil, err
+	}
+	return &types.PasskeyCeremony{Options: rawOptions, State: string(state)}, nil
+}
+
+// passkeyUser adapts a user and their credentials to the webauthn.User interface
+type passkeyUser struct {
+	user        types.PasskeyUser
+	credentials []webauthn.Credential
+}
+
+func (u *passkeyUser) WebAuthnID() []byte                         { return u.user.Handle }
+func (u *passkeyUser) WebAuthnName() string                       { return u.user.Name }
+func (u *passkeyUser) WebAuthnDisplayName() string                { return u.user.DisplayName }
+func (u *passkeyUser) WebAuthnCredentials() []webauthn.Credential { return u.credentials }
+
+func toCredential(c *types.PasskeyCredential) webauthn.Credential {
+	transports := make([]protocol.AuthenticatorTransport, 0, len(c.Transports))
+	for _, t := range c.Transports {
+		transports = append(transports, protocol.AuthenticatorTransport(t))
+	}
+	return webauthn.Credential{
+		ID:              c.ID,
+		PublicKey:       c.PublicKey,
+		AttestationType: c.AttestationType,
+		Transport:       transports,
+		Flags: webauthn.CredentialFlags{
+			BackupEligible: c.BackupEligible,
+			BackupState:    c.BackupState,
+		},
+		Authenticator: webauthn.Authenticator{
+			AAGUID:    c.AAGUID,
+			SignCount: c.SignCount,
+		},
+	}
+}
+
+func fromCredential(c *webauthn.Credential) *types.PasskeyCredential {
+	transports := make([]string, 0, len(c.Transport))
+	for _, t := range c.Transport {
+		transports = append(transports, string(t))
+	}
+	return &types.PasskeyCredential{
+		ID:              c.ID,
+		PublicKey:       c.PublicKey,
+		AttestationType: c.AttestationType,
+		AAGUID:          c.Authenticator.AAGUID,
+		Transports:      transports,
+		SignCount:       c.Authenticator.SignCount,
+		BackupEligible:  c.Flags.BackupEligible,
+		BackupState:     c.Flags.BackupState,
+	}
+}
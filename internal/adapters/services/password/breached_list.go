@@ -0,0 +1,86 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1" //nolint:gosec // SHA-1 is the format breach corpora are published in
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+)
+
+//go:embed common_passwords.txt
+var commonPasswords string
+
+type localBreachedList struct {
+	hashes map[string]struct{}
+}
+
+// NewLocalBreachedList builds a checker from the bundled common-password list
+// plus, when path is not empty, a local file. Each line of the file is either a
+// plaintext password or an uppercase/lowercase SHA-1 hex digest, optionally
+// followed by ":<count>" as in the Have I Been Pwned downloads.
+func NewLocalBreachedList(path string) (domainservice.BreachedPasswordChecker, error) {
+	l := &localBreachedList{hashes: make(map[string]struct{})}
+	if err := l.load(strings.NewReader(commonPasswords)); err != nil {
+		return nil, fmt.Errorf("load bundled password list: %w", err)
+	}
+
+	if path == "" {
+		return l, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open breached password list: %w", err)
+	}
+	defer f.Close()
+
+	if err := l.load(f); err != nil {
+		return nil, fmt.Errorf("load breached password list %s: %w", path, err)
+	}
+	return l, nil
+}
+
+// IsBreached reports whether the password is present in the list
+func (l *localBreachedList) IsBreached(password string) bool {
+	_, ok := l.hashes[sha1Hex(password)]
+	return ok
+}
+
+func (l *localBreachedList) load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if digest, ok := parseSHA1Line(line); ok {
+			l.hashes[digest] = struct{}{}
+			continue
+		}
+		l.hashes[sha1Hex(line)] = struct{}{}
+	}
+	return scanner.Err()
+}
+
+// parseSHA1Line accepts "<40 hex chars>" or "<40 hex chars>:<count>"
+func parseSHA1Line(line string) (string, bool) {
+	digest, _, _ := strings.Cut(line, ":")
+	if len(digest) != sha1.Size*2 {
+		return "", false
+	}
+	if _, err := hex.DecodeString(digest); err != nil {
+		return "", false
+	}
+	return strings.ToUpper(digest), true
+}
+
+func sha1Hex(s string) string {
+	sum := sha1.Sum([]byte(s)) //nolint:gosec // lookup key only, not used for storage
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
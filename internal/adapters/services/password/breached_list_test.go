@@ -0,0 +1,30 @@
+package password
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalBreachedList(t *testing.T) {
+	// "hunter2" as SHA-1, in the HIBP "<hash>:<count>" format
+	content := "# custom list\nlet-me-in-2024\nF3BBBD66A63D4BF1747940578EC3D0103530E21D:17\n"
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	checker, err := NewLocalBreachedList(path)
+	require.NoError(t, err)
+
+	assert.True(t, checker.IsBreached("123456"), "bundled list is always loaded")
+	assert.True(t, checker.IsBreached("let-me-in-2024"))
+	assert.True(t, checker.IsBreached("hunter2"))
+	assert.False(t, checker.IsBreached("correct horse battery staple"))
+}
+
+func TestLocalBreachedList_MissingFile(t *testing.T) {
+	_, err := NewLocalBreachedList(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+}
@@ -8,6 +8,8 @@ import (
 // SMSProvider defines the interface that all SMS providers must implement
 type SMSProvider interface {
 	SendOTP(ctx context.Context, tenantName, receiver, otp string, ttl time.Duration) error
+	// SendMessage delivers a message as written, for notices that are not a one time password
+	SendMessage(ctx context.Context, tenantName, receiver, message string) error
 	RefreshToken(ctx context.Context, refreshToken string) error
 	GetChannelType() string
 	HealthCheck(ctx context.Context) error
@@ -24,18 +24,7 @@ func NewSpeedSMSProvider(conf conf.SpeedSMSConfiguration) SMSProvider {
 }
 
 func (s *SpeedSMSProvider) SendOTP(ctx context.Context, tenantName, receiver, otp string, ttl time.Duration) error {
-	var brandname client.SpeedSMSBrandname
-	var smsClient *client.SpeedSMSClient
-
-	// Select client and brandname based on tenant
-	if tenantName == constants.TenantLifeAI {
-		brandname = constants.LifeBrandname
-		smsClient = s.lifeClient
-	} else {
-		brandname = constants.GeneticaBrandname
-		smsClient = s.geneticaClient
-	}
-
+	smsClient, brandname := s.clientFor(tenantName)
 	resp, err := smsClient.SendOTP(receiver, otp, brandname)
 	if err != nil {
 		return fmt.Errorf("failed to send SMS via SpeedSMS for tenant %s: %w", tenantName, err)
@@ -44,6 +33,24 @@ func (s *SpeedSMSProvider) SendOTP(ctx context.Context, tenantName, receiver, ot
 	return nil
 }
 
+func (s *SpeedSMSProvider) SendMessage(ctx context.Context, tenantName, receiver, message string) error {
+	smsClient, brandname := s.clientFor(tenantName)
+	resp, err := smsClient.SendSingleSMS(receiver, message, client.SMSTypeBrandname, brandname)
+	if err != nil {
+		return fmt.Errorf("failed to send SMS via SpeedSMS for tenant %s: %w", tenantName, err)
+	}
+	logger.GetLogger().Infof("Message sent successfully via SpeedSMS for tenant %s: %+v", tenantName, resp)
+	return nil
+}
+
+// clientFor selects the client and brandname of the tenant
+func (s *SpeedSMSProvider) clientFor(tenantName string) (*client.SpeedSMSClient, client.SpeedSMSBrandname) {
+	if tenantName == constants.TenantLifeAI {
+		return s.lifeClient, constants.LifeBrandname
+	}
+	return s.geneticaClient, constants.GeneticaBrandname
+}
+
 func (s *SpeedSMSProvider) GetChannelType() string {
 	return constants.ChannelSpeedSMS
 }
@@ -38,6 +38,18 @@ func (t *TwilioProvider) SendOTP(ctx context.Context, tenantName, receiver, otp
 	return nil
 }
 
+func (t *TwilioProvider) SendMessage(ctx context.Context, tenantName, receiver, message string) error {
+	logger.GetLogger().Infof("Sending message to %s via Twilio", receiver)
+
+	resp, err := t.client.SendSMS(tenantName, t.config.TwilioFrom, receiver, message)
+	if err != nil {
+		return fmt.Errorf("failed to send SMS via Twilio: %w", err)
+	}
+
+	logger.GetLogger().Infof("Message sent successfully via Twilio: %+v", resp)
+	return nil
+}
+
 func (t *TwilioProvider) RefreshToken(ctx context.Context, refreshToken string) error {
 	// Twilio doesn't require token refresh - using API key authentication
 	return nil
@@ -22,29 +22,40 @@ func NewWebhookProvider() SMSProvider {
 	return &WebhookProvider{}
 }
 
+type webhookPayload struct {
+	Tenant  string `json:"tenant"`
+	To      string `json:"to"`
+	Message string `json:"message"`
+	OTP     string `json:"otp,omitempty"`
+	TTL     int64  `json:"ttl_seconds,omitempty"`
+}
+
 func (w *WebhookProvider) SendOTP(ctx context.Context, tenantName, receiver, otp string, ttl time.Duration) error {
 	logger.GetLogger().Infof("Sending OTP to %s via webhook", receiver)
 
-	url := conf.GetMockWebhookURL()
-	if url == "" {
-		return errors.New("MOCK_WEBHOOK_URL is not set")
-	}
+	return w.post(ctx, webhookPayload{
+		Tenant:  tenantName,
+		To:      receiver,
+		Message: common.GetOTPMessage(tenantName, otp, ttl),
+		OTP:     otp,
+		TTL:     int64(ttl.Seconds()),
+	})
+}
 
-	type webhookPayload struct {
-		Tenant  string `json:"tenant"`
-		To      string `json:"to"`
-		Message string `json:"message"`
-		OTP     string `json:"otp"`
-		TTL     int64  `json:"ttl_seconds"`
-	}
+func (w *WebhookProvider) SendMessage(ctx context.Context, tenantName, receiver, message string) error {
+	logger.GetLogger().Infof("Sending message to %s via webhook", receiver)
 
-	message := common.GetOTPMessage(tenantName, otp, ttl)
-	payload := webhookPayload{
+	return w.post(ctx, webhookPayload{
 		Tenant:  tenantName,
 		To:      receiver,
 		Message: message,
-		OTP:     otp,
-		TTL:     int64(ttl.Seconds()),
+	})
+}
+
+func (w *WebhookProvider) post(ctx context.Context, payload webhookPayload) error {
+	url := conf.GetMockWebhookURL()
+	if url == "" {
+		return errors.New("MOCK_WEBHOOK_URL is not set")
 	}
 
 	bodyBytes, err := json.Marshal(payload)
@@ -69,7 +80,7 @@ func (w *WebhookProvider) SendOTP(ctx context.Context, tenantName, receiver, otp
 		return fmt.Errorf("webhook returned non-2xx status: %s", resp.Status)
 	}
 
-	logger.GetLogger().Infof("Webhook sent successfully to %s", receiver)
+	logger.GetLogger().Infof("Webhook sent successfully to %s", payload.To)
 	return nil
 }
 
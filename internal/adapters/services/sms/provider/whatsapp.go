@@ -38,6 +38,18 @@ func (w *WhatsAppProvider) SendOTP(ctx context.Context, tenantName, receiver, ot
 	return nil
 }
 
+func (w *WhatsAppProvider) SendMessage(ctx context.Context, tenantName, receiver, message string) error {
+	logger.GetLogger().Infof("Sending message to %s via WhatsApp", receiver)
+
+	resp, err := w.client.SendMessage(tenantName, receiver, message)
+	if err != nil {
+		return fmt.Errorf("failed to send message via WhatsApp: %w", err)
+	}
+
+	logger.GetLogger().Infof("WhatsApp message sent successfully: %+v", resp)
+	return nil
+}
+
 func (w *WhatsAppProvider) RefreshToken(ctx context.Context, refreshToken string) error {
 	// TODO: Implement WhatsApp token refresh when needed
 	// For now, WhatsApp uses long-lived tokens
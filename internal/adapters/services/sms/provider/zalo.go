@@ -141,6 +141,11 @@ func (z *ZaloProvider) SendOTP(ctx context.Context, tenantName, receiver, otp st
 	return nil
 }
 
+// SendMessage is not supported: Zalo only delivers the tenant's approved templates
+func (z *ZaloProvider) SendMessage(ctx context.Context, tenantName, receiver, message string) error {
+	return fmt.Errorf("zalo only delivers approved templates")
+}
+
 // HealthCheck is deprecated in multi-tenant mode
 // Use the sms_token use case ZaloHealthCheck method instead
 func (z *ZaloProvider) HealthCheck(ctx context.Context) error {
@@ -99,6 +99,17 @@ func (s *SMSService) SendOTP(ctx context.Context, tenantName, receiver, channel,
 	return provider.SendOTP(ctx, tenantName, receiver, otp, ttl)
 }
 
+// SendMessage sends a message as written through the specified channel
+func (s *SMSService) SendMessage(ctx context.Context, tenantName, receiver, channel, message string) error {
+	logger.GetLogger().Infof("Sending message to %s via channel %s", receiver, channel)
+
+	provider, err := s.factory.GetProvider(channel)
+	if err != nil {
+		return fmt.Errorf("failed to get provider for channel %s: %w", channel, err)
+	}
+	return provider.SendMessage(ctx, tenantName, receiver, message)
+}
+
 // GetSupportedChannels returns all supported channels
 func (s *SMSService) GetSupportedChannels() []string {
 	return s.factory.GetSupportedChannels()
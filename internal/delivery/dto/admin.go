@@ -68,3 +68,24 @@ type AdminAddIdentifierPayloadDTO struct {
 type AdminCheckIdentifierPayloadDTO struct {
 	Identifier string `json:"identifier" binding:"required"`
 }
+
+// UpdateUserStatusPayloadDTO represents the payload for suspending, banning or reactivating a user.
+type UpdateUserStatusPayloadDTO struct {
+	Status string `json:"status" binding:"required,oneof=active suspended banned"`
+	Reason string `json:"reason" binding:"max=1024"`
+	// ExpiresAt ends a suspension on its own; leave it out to suspend until reactivated
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// UnlockIdentifierPayloadDTO represents the payload for lifting the lockout of an email or phone number.
+type UnlockIdentifierPayloadDTO struct {
+	Identifier string `json:"identifier" binding:"required"`
+}
+
+// CreateInvitationPayloadDTO represents the payload for inviting an email or phone number to a tenant.
+type CreateInvitationPayloadDTO struct {
+	Identifier string `json:"identifier" binding:"required"`
+	Lang       string `json:"lang" binding:"omitempty,oneof=en vi"`
+	// Roles are granted in the tenant once the invitation is accepted
+	Roles []string `json:"roles" binding:"omitempty,max=20,dive,required,max=64"`
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "encoding/json"
+
 // IdentityChallengeWithPhoneDTO represents the request for a phone challenge.
 type IdentityChallengeWithPhoneDTO struct {
 	Phone   string `json:"phone"`
@@ -12,9 +14,16 @@ type IdentityChallengeWithEmailDTO struct {
 }
 
 type IdentityChallengeVerifyDTO struct {
-	FlowID string `json:"flow_id" binding:"required" description:"The flow ID of the challenge"`
-	Code   string `json:"code" binding:"required" description:"The code of the challenge"`
-	Type   string `json:"type" binding:"required,oneof=register login verify" description:"The type of the challenge, can be register, login or verify"`
+	FlowID         string `json:"flow_id" binding:"required" description:"The flow ID of the challenge"`
+	Code           string `json:"code" binding:"required" description:"The code of the challenge"`
+	Type           string `json:"type" binding:"required,oneof=register login verify" description:"The type of the challenge, can be register, login or verify"`
+	RememberDevice bool   `json:"remember_device" description:"Trust this device for later logins; only used by login"`
+}
+
+// IdentityChallengeResendDTO represents the request for sending a new code for an ongoing challenge.
+type IdentityChallengeResendDTO struct {
+	FlowID  string `json:"flow_id" binding:"required" description:"The flow ID of the challenge"`
+	Channel string `json:"channel" binding:"omitempty,oneof=sms whatsapp zalo" description:"Optional channel to switch a phone number to; one of sms, whatsapp, zalo"`
 }
 
 type IdentityUserRegisterDTO struct {
@@ -26,13 +35,86 @@ type IdentityUserRegisterDTO struct {
 
 // IdentityUserLoginDTO represents the request for a user login.
 type IdentityUserLoginDTO struct {
-	UserName string `json:"user_name"`
-	Password string `json:"password"`
+	UserName string `json:"user_name" binding:"required" description:"Email or phone number"`
+	Password string `json:"password" binding:"required"`
+}
+
+// IdentityOIDCLoginDTO represents the request for signing in with a social provider ID token.
+type IdentityOIDCLoginDTO struct {
+	Provider string `json:"provider" binding:"required,oneof=google apple" description:"The identity provider, can be google or apple"`
+	IDToken  string `json:"id_token" binding:"required" description:"The ID token issued to the app by the provider"`
+	Nonce    string `json:"nonce" description:"The nonce the ID token was requested with, if any"`
+	Lang     string `json:"lang" binding:"omitempty,oneof=en vi" description:"The language for a first-time sign-in"`
+}
+
+// IdentityWalletChallengeDTO represents the request for a wallet sign-in challenge.
+type IdentityWalletChallengeDTO struct {
+	Address string `json:"address" binding:"required" description:"The 0x-prefixed Ethereum address"`
+}
+
+// IdentityWalletVerifyDTO represents the request for completing a wallet sign-in.
+type IdentityWalletVerifyDTO struct {
+	FlowID    string `json:"flow_id" binding:"required" description:"The flow ID returned by the wallet challenge"`
+	Message   string `json:"message" binding:"required" description:"The EIP-4361 message that was signed, containing the challenge nonce"`
+	Signature string `json:"signature" binding:"required" description:"The 0x-prefixed personal_sign signature of the message"`
+	Lang      string `json:"lang" binding:"omitempty,oneof=en vi" description:"The language for a first-time sign-in"`
+}
+
+// IdentityPasskeyVerifyDTO represents the request for completing a passkey sign-in.
+type IdentityPasskeyVerifyDTO struct {
+	FlowID     string          `json:"flow_id" binding:"required" description:"The flow ID returned by the passkey challenge"`
+	Credential json.RawMessage `json:"credential" binding:"required" swaggertype:"object" description:"The PublicKeyCredential returned by navigator.credentials.get(), serialized to JSON"`
+}
+
+// IdentityPasskeyRegisterDTO represents the request for completing a passkey registration.
+type IdentityPasskeyRegisterDTO struct {
+	FlowID     string          `json:"flow_id" binding:"required" description:"The flow ID returned by the registration start"`
+	Name       string          `json:"name" binding:"max=100" description:"A label for the passkey, such as the device it lives on"`
+	Credential json.RawMessage `json:"credential" binding:"required" swaggertype:"object" description:"The PublicKeyCredential returned by navigator.credentials.create(), serialized to JSON"`
+}
+
+// IdentityMFAVerifyDTO represents the request for completing a sign-in with a second factor.
+type IdentityMFAVerifyDTO struct {
+	FlowID string `json:"flow_id" binding:"required" description:"The MFA flow ID returned by the sign-in"`
+	Code   string `json:"code" binding:"required" description:"A TOTP code or an unused recovery code"`
+}
+
+// IdentityMFACodeDTO represents a request confirmed with a second factor code.
+type IdentityMFACodeDTO struct {
+	Code string `json:"code" binding:"required" description:"A TOTP code, or a recovery code where accepted"`
+}
+
+// IdentitySessionRefreshDTO represents the request for refreshing a session.
+type IdentitySessionRefreshDTO struct {
+	SessionToken string `json:"session_token" binding:"required" description:"The current, still valid session token"`
+	RefreshToken string `json:"refresh_token" binding:"required" description:"The refresh token issued with the session or by the previous refresh"`
+}
+
+// IdentityUserSetPasswordDTO represents the request for setting the current user's password.
+type IdentityUserSetPasswordDTO struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// IdentityPasswordRecoveryChallengeDTO represents the request for starting a password recovery.
+type IdentityPasswordRecoveryChallengeDTO struct {
+	Identifier string `json:"identifier" binding:"required" description:"Email or phone number of the account"`
+	Channel    string `json:"channel" binding:"omitempty,oneof=sms whatsapp zalo" description:"Optional delivery channel for OTP when identifier is a phone; one of sms, whatsapp, zalo"`
+}
+
+// IdentityPasswordRecoveryVerifyDTO represents the request for completing a password recovery.
+type IdentityPasswordRecoveryVerifyDTO struct {
+	FlowID      string `json:"flow_id" binding:"required" description:"The flow ID returned by the recovery challenge"`
+	Code        string `json:"code" binding:"required" description:"The code sent to the identifier"`
+	NewPassword string `json:"new_password" binding:"required"`
 }
 
 // IdentityUserAddIdentifierDTO represents the request for adding a new identifier.
+// Either new_identifier, or provider and id_token to link a social account, must be set.
 type IdentityUserAddIdentifierDTO struct {
-	NewIdentifier string `json:"new_identifier" binding:"required"` // email address or phone number
+	NewIdentifier string `json:"new_identifier" binding:"required_without=Provider"` // email address or phone number
+	Provider      string `json:"provider" binding:"omitempty,oneof=google apple" description:"Social provider to link, can be google or apple"`
+	IDToken       string `json:"id_token" binding:"required_with=Provider" description:"The ID token issued by the provider"`
+	Nonce         string `json:"nonce" description:"The nonce the ID token was requested with, if any"`
 }
 
 // IdentityUserChangeIdentifierDTO represents the request for changing an identifier.
@@ -45,6 +127,23 @@ type IdentityUserDeleteIdentifierDTO struct {
 	IdentifierType string `json:"identifier_type" binding:"required,oneof=email phone_number" description:"The type of the identifier, can be email or phone_number"`
 }
 
+// IdentityUserDeleteAccountDTO confirms an account deletion with the code sent by its challenge.
+type IdentityUserDeleteAccountDTO struct {
+	FlowID string `json:"flow_id" binding:"required" description:"The flow ID returned by the deletion challenge"`
+	Code   string `json:"code" binding:"required" description:"The code sent to the identifier"`
+}
+
+// SecurityReportDTO reports the change a security notice was about as not made by the user.
+type SecurityReportDTO struct {
+	Token string `json:"token" binding:"required" description:"The token of the notice's report link"`
+}
+
+// IdentityAcceptInvitationDTO accepts an invitation with the code sent to the invited identifier.
+type IdentityAcceptInvitationDTO struct {
+	Identifier string `json:"identifier" binding:"required" description:"The invited email or phone number"`
+	Code       string `json:"code" binding:"required" description:"The code sent with the invitation"`
+}
+
 // IdentityVerificationChallengeDTO represents the request for initiating a verification challenge.
 type IdentityVerificationChallengeDTO struct {
 	Identifier string `json:"identifier" binding:"required" description:"Email or phone number to verify"`
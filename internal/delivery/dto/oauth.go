@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"time"
+)
+
+// CreateOAuthClientPayloadDTO represents the payload for registering a client.
+// Clients signing users in through the OpenID Connect provider need redirect URIs; public
+// clients such as mobile apps get no secret.
+type CreateOAuthClientPayloadDTO struct {
+	Name         string   `json:"name" binding:"required,max=100"`
+	RedirectURIs []string `json:"redirect_uris" binding:"omitempty,max=10,dive,max=2048"`
+	Public       bool     `json:"public"`
+}
+
+// OAuthClientCredentialsDTO is a newly registered client. The secret is not stored and
+// cannot be retrieved again.
+type OAuthClientCredentialsDTO struct {
+	ClientID     string    `json:"client_id"`
+	ClientSecret string    `json:"client_secret,omitempty"`
+	Name         string    `json:"name"`
+	TenantID     string    `json:"tenant_id"`
+	RedirectURIs []string  `json:"redirect_uris"`
+	Public       bool      `json:"public"`
+	CreatedAt    time.Time `json:"created_at"`
+}
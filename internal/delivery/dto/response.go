@@ -1,6 +1,10 @@
 package dto
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+)
 
 // PaginationDTOResponse is a generic response for pagination
 // It is used to return a paginated list of items
@@ -22,6 +26,36 @@ type TenantPaginationDTOResponse struct {
 	Items      []TenantDTO `json:"items"`
 }
 
+// IdentityHistoryPaginationDTOResponse is a concrete response for identity history pagination
+// This is used specifically for swagger documentation compatibility
+type IdentityHistoryPaginationDTOResponse struct {
+	NextPage   int                          `json:"next_page"`
+	Page       int                          `json:"page"`
+	PageSize   int                          `json:"page_size"`
+	TotalCount int64                        `json:"total_count"`
+	Items      []types.IdentityHistoryEntry `json:"items"`
+}
+
+// LoginHistoryPaginationDTOResponse is a concrete response for login history pagination
+// This is used specifically for swagger documentation compatibility
+type LoginHistoryPaginationDTOResponse struct {
+	NextPage   int                        `json:"next_page"`
+	Page       int                        `json:"page"`
+	PageSize   int                        `json:"page_size"`
+	TotalCount int64                      `json:"total_count"`
+	Items      []types.LoginEventResponse `json:"items"`
+}
+
+// IdentifierQuarantinePaginationDTOResponse is a concrete response for quarantined identifier pagination
+// This is used specifically for swagger documentation compatibility
+type IdentifierQuarantinePaginationDTOResponse struct {
+	NextPage   int                                  `json:"next_page"`
+	Page       int                                  `json:"page"`
+	PageSize   int                                  `json:"page_size"`
+	TotalCount int64                                `json:"total_count"`
+	Items      []types.IdentifierQuarantineResponse `json:"items"`
+}
+
 type SuccessDTOResponse struct {
 	Status  int         `json:"status,omitempty"`
 	Code    string      `json:"code"`
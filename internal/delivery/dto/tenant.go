@@ -31,6 +31,24 @@ type UpdateTenantPayloadDTO struct {
 	AdminURL  string `json:"admin_url" validate:"omitempty,url"`
 }
 
+// UpdateTenantSettingPayloadDTO represents the payload for updating a tenant's settings.
+// Omitted fields keep their current value.
+type UpdateTenantSettingPayloadDTO struct {
+	PasswordMinLength                      *int    `json:"password_min_length" binding:"omitempty,min=6,max=72"`
+	PasswordRejectBreached                 *bool   `json:"password_reject_breached"`
+	SessionMaxLifetimeSeconds              *int    `json:"session_max_lifetime_seconds" binding:"omitempty,min=300"`
+	MFARequired                            *bool   `json:"mfa_required"`
+	MaxConcurrentSessions                  *int    `json:"max_concurrent_sessions" binding:"omitempty,min=0,max=100"`
+	OIDCLoginURL                           *string `json:"oidc_login_url" binding:"omitempty,max=2048"`
+	InviteOnlyRegistration                 *bool   `json:"invite_only_registration"`
+	TrustedDeviceLifetimeSeconds           *int    `json:"trusted_device_lifetime_seconds" binding:"omitempty,min=3600,max=31536000"` // 0 disables remembering devices
+	TrustedDeviceSkipMFA                   *bool   `json:"trusted_device_skip_mfa"`
+	TrustedDeviceSessionMaxLifetimeSeconds *int    `json:"trusted_device_session_max_lifetime_seconds" binding:"omitempty,min=300"`
+	LoginRiskAction                        *string `json:"login_risk_action" binding:"omitempty,oneof=none event verify"`
+	IdentifierQuarantineSeconds            *int    `json:"identifier_quarantine_seconds" binding:"omitempty,min=3600,max=63072000"` // 0 disables the quarantine
+	IdentifierQuarantineAction             *string `json:"identifier_quarantine_action" binding:"omitempty,oneof=block approval"`
+}
+
 func ToTenantDTO(t domain.Tenant) TenantDTO {
 	return TenantDTO{
 		ID:        t.ID.String(),
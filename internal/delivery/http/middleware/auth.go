@@ -77,6 +77,14 @@ func setAdminContext(c *gin.Context, account *domain.AdminAccount) {
 	c.Set(ContextKeyRole, account.Role)
 }
 
+// GetAdminUsernameFromContext returns the username of the authenticated admin or root account
+func GetAdminUsernameFromContext(c *gin.Context) string {
+	if username := c.GetString(ContextKeyAdminUsername); username != "" {
+		return username
+	}
+	return c.GetString(ContextKeyRootUsername)
+}
+
 // sendAuthError sends authentication error response
 func sendAuthError(c *gin.Context, realm, message string, statusCode int) {
 	c.Header("WWW-Authenticate", fmt.Sprintf(`Basic realm="%s"`, realm))
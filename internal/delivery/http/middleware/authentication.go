@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/lifenetwork-ai/iam-service/constants"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
 	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
 	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
 	httpresponse "github.com/lifenetwork-ai/iam-service/packages/http/response"
@@ -61,8 +62,19 @@ func (am *AuthMiddleware) validateAuthorizationHeader(ctx *gin.Context) (bool, s
 	return true, strings.TrimSpace(tokenParts[1])
 }
 
-// RequireAuth is a complete authentication middleware
+// RequireAuth is a complete authentication middleware. Sessions of users who still have to enroll
+// in the MFA their tenant requires are refused.
 func (am *AuthMiddleware) RequireAuth() gin.HandlerFunc {
+	return am.requireAuth(false)
+}
+
+// RequireAuthForMFAEnrollment authenticates like RequireAuth but also admits sessions of users who
+// still have to enroll in MFA, for the endpoints they need to do so
+func (am *AuthMiddleware) RequireAuthForMFAEnrollment() gin.HandlerFunc {
+	return am.requireAuth(true)
+}
+
+func (am *AuthMiddleware) requireAuth(allowMFAEnrollment bool) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		// Extract token from header
 		isValid, token := am.validateAuthorizationHeader(ctx)
@@ -92,6 +104,12 @@ func (am *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 
 		// Validate token and get user
 		user, ucaseErr := am.identityUseCase.Profile(ctx.Request.Context(), tenant.ID)
+		if ucaseErr != nil && ucaseErr.Type == domainerrors.ErrorTypeForbidden {
+			// A valid session of a suspended or banned user
+			httpresponse.Error(ctx, http.StatusForbidden, ucaseErr.Code, ucaseErr.Message, ucaseErr.Details)
+			ctx.Abort()
+			return
+		}
 		if ucaseErr != nil {
 			logger.GetLogger().Errorf("Token validation failed: %v", ucaseErr)
 			httpresponse.Error(
@@ -105,6 +123,19 @@ func (am *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 			return
 		}
 
+		// The tenant requires MFA and the user has not enrolled yet
+		if user.MFAEnrollmentRequired && !allowMFAEnrollment {
+			httpresponse.Error(
+				ctx,
+				http.StatusForbidden,
+				"MSG_MFA_ENROLLMENT_REQUIRED",
+				"Multi-factor authentication must be enrolled first",
+				nil,
+			)
+			ctx.Abort()
+			return
+		}
+
 		// Set user and token in context for downstream handlers
 		ctx.Set(string(constants.SessionTokenKey), token)
 		ctx.Set(string(constants.UserContextKey), user)
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+)
+
+// ClientInfoMiddleware puts the client IP, user agent and trusted device token into the request
+// context, so use cases can record which client a session was issued to.
+func ClientInfoMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reqCtx := context.WithValue(c.Request.Context(), constants.ClientIPKey, c.ClientIP())
+		reqCtx = context.WithValue(reqCtx, constants.UserAgentKey, c.Request.UserAgent())
+		if token := c.GetHeader(constants.DeviceTokenHeaderKey); token != "" {
+			reqCtx = context.WithValue(reqCtx, constants.DeviceTokenKey, token)
+		}
+		c.Request = c.Request.WithContext(reqCtx)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	ucases "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+	"github.com/lifenetwork-ai/iam-service/packages/utils"
+)
+
+// ContextKeyOAuthClient holds the authenticated service client
+var ContextKeyOAuthClient = "oauthClient"
+
+// OAuthClientAuthMiddleware authenticates a confidential client registered with a tenant.
+// Failures are reported in the OAuth error format.
+func OAuthClientAuthMiddleware(clientRepo ucases.OAuthClientRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clientID, secret, err := OAuthClientCredentials(c)
+		if err == nil && secret == "" {
+			err = errors.New("client authentication is required")
+		}
+		if err != nil {
+			SendOAuthClientError(c, err.Error())
+			return
+		}
+
+		client, err := clientRepo.GetByClientID(c.Request.Context(), clientID)
+		if err != nil || client == nil || client.RevokedAt != nil || !client.MatchesSecretHash(utils.HashToken(secret)) {
+			SendOAuthClientError(c, "Invalid client credentials")
+			return
+		}
+
+		c.Set(ContextKeyOAuthClient, client)
+		c.Next()
+	}
+}
+
+// GetOAuthClientFromContext returns the client authenticated by OAuthClientAuthMiddleware
+func GetOAuthClientFromContext(c *gin.Context) (*domain.OAuthClient, error) {
+	value, ok := c.Get(ContextKeyOAuthClient)
+	if !ok {
+		return nil, errors.New("oauth client not found in context")
+	}
+	client, ok := value.(*domain.OAuthClient)
+	if !ok {
+		return nil, errors.New("invalid oauth client type in context")
+	}
+	return client, nil
+}
+
+// OAuthClientCredentials reads client credentials from HTTP Basic auth, which carries them
+// form-encoded, or from the client_id and client_secret form parameters (RFC 6749 section 2.3.1).
+// Public clients only send client_id, so the secret may be empty.
+func OAuthClientCredentials(c *gin.Context) (string, string, error) {
+	if header := c.GetHeader("Authorization"); header != "" {
+		clientID, secret, err := validateBasicAuth(header)
+		if err != nil {
+			return "", "", err
+		}
+		if clientID, err = url.QueryUnescape(clientID); err != nil {
+			return "", "", errors.New("invalid authorization header format")
+		}
+		if secret, err = url.QueryUnescape(secret); err != nil {
+			return "", "", errors.New("invalid authorization header format")
+		}
+		return clientID, secret, nil
+	}
+
+	clientID := c.PostForm("client_id")
+	if clientID == "" {
+		return "", "", errors.New("client authentication is required")
+	}
+	return clientID, c.PostForm("client_secret"), nil
+}
+
+// SendOAuthClientError rejects the client with an RFC 6749 invalid_client error
+func SendOAuthClientError(c *gin.Context, description string) {
+	c.Header("WWW-Authenticate", `Basic realm="OAuth Clients"`)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+		"error":             "invalid_client",
+		"error_description": description,
+	})
+}
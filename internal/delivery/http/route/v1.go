@@ -63,6 +63,15 @@ func RegisterRoutes(
 	}
 
 	// Admin Tenant Management subgroup
+	accountDeletionHandler := handlers.NewAccountDeletionHandler(ucases.AccountDeletionUCase)
+	userStatusHandler := handlers.NewUserStatusHandler(ucases.UserStatusUCase)
+	identityHistoryHandler := handlers.NewIdentityHistoryHandler(ucases.IdentityHistoryUCase)
+	invitationHandler := handlers.NewInvitationHandler(ucases.InvitationUCase)
+	userImportHandler := handlers.NewUserImportHandler(ucases.UserImportUCase)
+	identifierLockoutHandler := handlers.NewIdentifierLockoutHandler(ucases.IdentifierLockoutUCase)
+	trustedDeviceHandler := handlers.NewTrustedDeviceHandler(ucases.TrustedDeviceUCase)
+	loginHistoryHandler := handlers.NewLoginHistoryHandler(ucases.LoginHistoryUCase)
+	identifierQuarantineHandler := handlers.NewIdentifierQuarantineHandler(ucases.IdentifierQuarantineUCase)
 	tenantRouter := adminRouter.Group("tenants")
 	{
 		tenantRouter.Use(middleware.AdminAuthMiddleware(repos.AdminAccountRepo))
@@ -71,6 +80,43 @@ func RegisterRoutes(
 		tenantRouter.POST("/", adminHandler.CreateTenant)
 		tenantRouter.PUT("/:id", adminHandler.UpdateTenant)
 		tenantRouter.DELETE("/:id", adminHandler.DeleteTenant)
+		tenantRouter.GET("/:id/settings", adminHandler.GetTenantSetting)
+		tenantRouter.PUT("/:id/settings", adminHandler.UpdateTenantSetting)
+		tenantRouter.GET("/:id/oauth-clients", adminHandler.ListOAuthClients)
+		tenantRouter.POST("/:id/oauth-clients", adminHandler.CreateOAuthClient)
+		tenantRouter.DELETE("/:id/oauth-clients/:client_id", adminHandler.RevokeOAuthClient)
+		tenantRouter.GET("/:id/users", adminHandler.ListTenantUsers)
+		tenantRouter.GET("/:id/users/export", adminHandler.ExportTenantUsers)
+		tenantRouter.GET("/:id/users/:global_user_id", adminHandler.GetTenantUser)
+		tenantRouter.DELETE("/:id/users/:global_user_id", accountDeletionHandler.DeleteUserAdmin)
+		tenantRouter.GET("/:id/users/:global_user_id/status", userStatusHandler.GetUserStatus)
+		tenantRouter.PUT("/:id/users/:global_user_id/status", userStatusHandler.UpdateUserStatus)
+		tenantRouter.GET("/:id/users/:global_user_id/trusted-devices", trustedDeviceHandler.ListTrustedDevices)
+		tenantRouter.DELETE("/:id/users/:global_user_id/trusted-devices", trustedDeviceHandler.RevokeTrustedDevices)
+		tenantRouter.DELETE("/:id/users/:global_user_id/trusted-devices/:device_id", trustedDeviceHandler.RevokeTrustedDevice)
+		tenantRouter.GET("/:id/account-deletions", accountDeletionHandler.ListAccountDeletions)
+		tenantRouter.GET("/:id/identity-history", identityHistoryHandler.ListTenantIdentityHistory)
+		tenantRouter.GET("/:id/invitations", invitationHandler.ListInvitations)
+		tenantRouter.POST("/:id/invitations", invitationHandler.CreateInvitation)
+		tenantRouter.POST("/:id/invitations/:invitation_id/resend", invitationHandler.ResendInvitation)
+		tenantRouter.DELETE("/:id/invitations/:invitation_id", invitationHandler.RevokeInvitation)
+		tenantRouter.GET("/:id/user-imports", userImportHandler.ListUserImports)
+		tenantRouter.POST("/:id/user-imports", userImportHandler.CreateUserImport)
+		tenantRouter.GET("/:id/user-imports/:import_id", userImportHandler.GetUserImport)
+		tenantRouter.GET("/:id/user-imports/:import_id/errors", userImportHandler.ListUserImportErrors)
+		tenantRouter.GET("/:id/identifier-lockouts", identifierLockoutHandler.ListIdentifierLockouts)
+		tenantRouter.POST("/:id/identifier-lockouts/unlock", identifierLockoutHandler.UnlockIdentifier)
+		tenantRouter.GET("/:id/identifier-quarantines", identifierQuarantineHandler.ListQuarantinedIdentifiers)
+		tenantRouter.POST("/:id/identifier-quarantines/:quarantine_id/release", identifierQuarantineHandler.ReleaseIdentifier)
+	}
+
+	// Admin access token signing keys
+	tokenHandler := handlers.NewTokenHandler(ucases.TokenUCase)
+	signingKeyRouter := adminRouter.Group("signing-keys")
+	{
+		signingKeyRouter.Use(middleware.AdminAuthMiddleware(repos.AdminAccountRepo))
+		signingKeyRouter.GET("/", tokenHandler.ListSigningKeys)
+		signingKeyRouter.POST("/rotate", tokenHandler.RotateSigningKey)
 	}
 
 	// SECTION: Permission routes
@@ -117,20 +163,128 @@ func RegisterRoutes(
 		userHandler.ChallengeVerify,
 	)
 
+	userRouter.POST(
+		"/challenge/resend",
+		userHandler.ResendChallenge,
+	)
+
 	userRouter.POST(
 		"/register",
 		userHandler.Register,
 	)
 
+	userRouter.POST(
+		"/invitations/accept",
+		middleware.IPRateLimitMiddleware(middleware.RateLimitConfig{
+			RateLimiter: instances.RateLimiterInstance(),
+			Action:      constants.AcceptInvitationAction,
+			Limit:       constants.MaxAttemptsPerWindow,
+			Window:      constants.RateLimitWindow,
+		}),
+		invitationHandler.AcceptInvitation,
+	)
+
+	userRouter.POST(
+		"/login",
+		middleware.IPRateLimitMiddleware(middleware.RateLimitConfig{
+			RateLimiter: instances.RateLimiterInstance(),
+			Action:      constants.LoginWithPasswordAction,
+			Limit:       constants.MaxAttemptsPerWindow,
+			Window:      constants.RateLimitWindow,
+		}),
+		userHandler.Login,
+	)
+
+	userRouter.POST(
+		"/oidc/login",
+		middleware.IPRateLimitMiddleware(middleware.RateLimitConfig{
+			RateLimiter: instances.RateLimiterInstance(),
+			Action:      constants.LoginWithOIDCAction,
+			Limit:       constants.MaxAttemptsPerWindow,
+			Window:      constants.RateLimitWindow,
+		}),
+		userHandler.LoginWithOIDC,
+	)
+
+	userRouter.POST(
+		"/wallet/challenge",
+		middleware.IPRateLimitMiddleware(middleware.RateLimitConfig{
+			RateLimiter: instances.RateLimiterInstance(),
+			Action:      constants.LoginWithWalletAction,
+			Limit:       constants.MaxAttemptsPerWindow,
+			Window:      constants.RateLimitWindow,
+		}),
+		userHandler.ChallengeWithWallet,
+	)
+
+	userRouter.POST(
+		"/wallet/verify",
+		userHandler.VerifyWallet,
+	)
+
+	userRouter.POST(
+		"/passkey/challenge",
+		middleware.IPRateLimitMiddleware(middleware.RateLimitConfig{
+			RateLimiter: instances.RateLimiterInstance(),
+			Action:      constants.LoginWithPasskeyAction,
+			Limit:       constants.MaxAttemptsPerWindow,
+			Window:      constants.RateLimitWindow,
+		}),
+		userHandler.ChallengeWithPasskey,
+	)
+
+	userRouter.POST(
+		"/passkey/verify",
+		userHandler.VerifyPasskey,
+	)
+
+	userRouter.POST(
+		"/mfa/verify",
+		middleware.IPRateLimitMiddleware(middleware.RateLimitConfig{
+			RateLimiter: instances.RateLimiterInstance(),
+			Action:      constants.MFAVerifyAction,
+			Limit:       constants.MaxAttemptsPerWindow,
+			Window:      constants.RateLimitWindow,
+		}),
+		userHandler.VerifyMFA,
+	)
+
+	userRouter.POST(
+		"/password/recovery",
+		middleware.IPRateLimitMiddleware(middleware.RateLimitConfig{
+			RateLimiter: instances.RateLimiterInstance(),
+			Action:      constants.PasswordRecoveryAction,
+			Limit:       constants.MaxAttemptsPerWindow,
+			Window:      constants.RateLimitWindow,
+		}),
+		userHandler.ChallengePasswordRecovery,
+	)
+
+	userRouter.POST(
+		"/password/recovery/verify",
+		userHandler.VerifyPasswordRecovery,
+	)
+
+	userRouter.POST(
+		"/session/refresh",
+		middleware.IPRateLimitMiddleware(middleware.RateLimitConfig{
+			RateLimiter: instances.RateLimiterInstance(),
+			Action:      constants.SessionRefreshAction,
+			Limit:       constants.MaxAttemptsPerWindow,
+			Window:      constants.RateLimitWindow,
+		}),
+		userHandler.RefreshSession,
+	)
+
 	userRouter.POST(
 		"/logout",
-		authMiddleware.RequireAuth(),
+		authMiddleware.RequireAuthForMFAEnrollment(),
 		userHandler.Logout,
 	)
 
 	userRouter.GET(
 		"/me",
-		authMiddleware.RequireAuth(),
+		authMiddleware.RequireAuthForMFAEnrollment(),
 		userHandler.Me,
 	)
 
@@ -152,18 +306,227 @@ func RegisterRoutes(
 		userHandler.DeleteIdentifier,
 	)
 
+	userRouter.POST(
+		"/me/set-password",
+		authMiddleware.RequireAuth(),
+		userHandler.SetPassword,
+	)
+
 	userRouter.PATCH(
 		"/me/update-lang",
 		authMiddleware.RequireAuth(),
 		userHandler.UpdateLang,
 	)
 
+	userRouter.POST(
+		"/me/mfa/totp/enroll",
+		authMiddleware.RequireAuthForMFAEnrollment(),
+		userHandler.EnrollTOTP,
+	)
+
+	userRouter.POST(
+		"/me/mfa/totp/verify",
+		authMiddleware.RequireAuthForMFAEnrollment(),
+		userHandler.ActivateTOTP,
+	)
+
+	userRouter.POST(
+		"/me/mfa/totp/disable",
+		authMiddleware.RequireAuth(),
+		userHandler.DisableTOTP,
+	)
+
+	userRouter.POST(
+		"/me/passkeys/register/begin",
+		authMiddleware.RequireAuth(),
+		userHandler.BeginPasskeyRegistration,
+	)
+
+	userRouter.POST(
+		"/me/passkeys/register/finish",
+		authMiddleware.RequireAuth(),
+		userHandler.FinishPasskeyRegistration,
+	)
+
+	userRouter.GET(
+		"/me/passkeys",
+		authMiddleware.RequireAuth(),
+		userHandler.ListPasskeys,
+	)
+
+	userRouter.DELETE(
+		"/me/passkeys/:id",
+		authMiddleware.RequireAuth(),
+		userHandler.DeletePasskey,
+	)
+
+	userRouter.GET(
+		"/me/sessions",
+		authMiddleware.RequireAuth(),
+		userHandler.ListSessions,
+	)
+
+	userRouter.DELETE(
+		"/me/sessions",
+		authMiddleware.RequireAuth(),
+		userHandler.RevokeOtherSessions,
+	)
+
+	userRouter.DELETE(
+		"/me/sessions/:id",
+		authMiddleware.RequireAuth(),
+		userHandler.RevokeSession,
+	)
+
+	userRouter.GET(
+		"/me/trusted-devices",
+		authMiddleware.RequireAuth(),
+		trustedDeviceHandler.ListMyTrustedDevices,
+	)
+
+	userRouter.DELETE(
+		"/me/trusted-devices",
+		authMiddleware.RequireAuth(),
+		trustedDeviceHandler.RevokeMyTrustedDevices,
+	)
+
+	userRouter.DELETE(
+		"/me/trusted-devices/:device_id",
+		authMiddleware.RequireAuth(),
+		trustedDeviceHandler.RevokeMyTrustedDevice,
+	)
+
+	userRouter.GET(
+		"/me/logins",
+		authMiddleware.RequireAuth(),
+		loginHistoryHandler.ListMyLogins,
+	)
+
+	userRouter.POST(
+		"/me/token",
+		authMiddleware.RequireAuth(),
+		tokenHandler.IssueAccessToken,
+	)
+
+	// OpenID Connect sign in to partner clients, completed by the tenant's login page
+	oidcProviderHandler := handlers.NewOIDCProviderHandler(ucases.OIDCProviderUCase)
+	userRouter.GET(
+		"/me/authorizations/:request_id",
+		authMiddleware.RequireAuth(),
+		oidcProviderHandler.GetAuthorizationRequest,
+	)
+
+	userRouter.POST(
+		"/me/authorizations/:request_id/accept",
+		authMiddleware.RequireAuth(),
+		oidcProviderHandler.AcceptAuthorizationRequest,
+	)
+
+	userRouter.POST(
+		"/me/authorizations/:request_id/reject",
+		authMiddleware.RequireAuth(),
+		oidcProviderHandler.RejectAuthorizationRequest,
+	)
+
+	userRouter.GET(
+		"/me/consents",
+		authMiddleware.RequireAuth(),
+		oidcProviderHandler.ListConsents,
+	)
+
+	userRouter.DELETE(
+		"/me/consents/:client_id",
+		authMiddleware.RequireAuth(),
+		oidcProviderHandler.RevokeConsent,
+	)
+
+	// Account deletion, confirmed with a code and erased after a grace period
+	userRouter.GET(
+		"/me/deletion",
+		authMiddleware.RequireAuth(),
+		accountDeletionHandler.GetAccountDeletion,
+	)
+
+	userRouter.POST(
+		"/me/deletion/challenge",
+		authMiddleware.RequireAuth(),
+		accountDeletionHandler.ChallengeAccountDeletion,
+	)
+
+	userRouter.POST(
+		"/me/deletion/cancel",
+		authMiddleware.RequireAuth(),
+		accountDeletionHandler.CancelAccountDeletion,
+	)
+
+	userRouter.DELETE(
+		"/me",
+		authMiddleware.RequireAuth(),
+		accountDeletionHandler.DeleteAccount,
+	)
+
+	// Personal data exports, generated in the background
+	dataExportHandler := handlers.NewDataExportHandler(ucases.DataExportUCase)
+	userRouter.POST(
+		"/me/data-exports",
+		authMiddleware.RequireAuth(),
+		dataExportHandler.RequestDataExport,
+	)
+
+	userRouter.GET(
+		"/me/data-exports/:id",
+		authMiddleware.RequireAuth(),
+		dataExportHandler.GetDataExport,
+	)
+
+	userRouter.GET(
+		"/me/identity-history",
+		authMiddleware.RequireAuth(),
+		identityHistoryHandler.ListMyIdentityHistory,
+	)
+
 	userRouter.POST(
 		"/verification/challenge",
 		authMiddleware.RequireAuth(),
 		userHandler.ChallengeVerification,
 	)
 
+	// Admin session cache metrics
+	sessionCacheRouter := adminRouter.Group("session-cache")
+	{
+		sessionCacheRouter.Use(middleware.AdminAuthMiddleware(repos.AdminAccountRepo))
+		sessionCacheRouter.GET("/stats", userHandler.SessionCacheStats)
+	}
+
+	// SECTION: Data export downloads, authorized by the link's signature
+	v1.GET("/data-exports/:id/download", dataExportHandler.DownloadDataExport)
+
+	// SECTION: "This wasn't me" reports, authorized by the token of a security notice's link
+	securityReportHandler := handlers.NewSecurityReportHandler(ucases.SecurityNoticeUCase)
+	v1.POST("/security-reports", securityReportHandler.ReportUnrecognized)
+
+	// SECTION: Well-known documents
+	r.GET("/.well-known/jwks.json", tokenHandler.JWKS)
+
+	// SECTION: OAuth 2.0 endpoints for service clients
+	oauthHandler := handlers.NewOAuthHandler(ucases.IdentityUserUCase)
+	oauthRouter := r.Group("/oauth2")
+	oauthRouter.POST(
+		"/introspect",
+		middleware.OAuthClientAuthMiddleware(repos.OAuthClientRepo),
+		oauthHandler.Introspect,
+	)
+
+	// SECTION: OpenID Connect provider, one issuer per tenant
+	oidcRouter := r.Group("/oidc/:tenant_id")
+	{
+		oidcRouter.GET("/.well-known/openid-configuration", oidcProviderHandler.Discovery)
+		oidcRouter.GET("/authorize", oidcProviderHandler.Authorize)
+		oidcRouter.POST("/token", oidcProviderHandler.Token)
+		oidcRouter.GET("/userinfo", oidcProviderHandler.UserInfo)
+		oidcRouter.POST("/userinfo", oidcProviderHandler.UserInfo)
+	}
+
 	// SECTION: Courier (OTP delivery) routes
 	courierHandler := handlers.NewCourierHandler(ucases.CourierUCase)
 	courierRouter := v1.Group("courier")
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+)
+
+// AccountDeletion is a request to erase a global user and everything linked to it.
+// It is pending until either CompletedAt or CancelledAt is set, and outlives the user
+// so the erasure can be audited.
+type AccountDeletion struct {
+	ID           string     `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TenantID     string     `json:"tenant_id" gorm:"type:uuid;not null"`
+	GlobalUserID string     `json:"global_user_id" gorm:"type:uuid;not null"`
+	RequestedBy  string     `json:"requested_by" gorm:"type:varchar(255);not null"` // "user" or "admin:<username>"
+	ScheduledFor time.Time  `json:"scheduled_for" gorm:"not null"`
+	Attempts     int        `json:"attempts" gorm:"not null;default:0"`
+	LastError    string     `json:"last_error" gorm:"type:text;not null;default:''"`
+	CompletedAt  *time.Time `json:"completed_at"`
+	CancelledAt  *time.Time `json:"cancelled_at"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName overrides the default table name for GORM.
+func (AccountDeletion) TableName() string {
+	return "account_deletions"
+}
@@ -1,10 +1,14 @@
 package domain
 
 type ChallengeSession struct {
-	GlobalUserID   string `json:"global_user_id"`
-	KratosUserID   string `json:"kratos_user_id"`
-	IdentifierType string `json:"identifier_type"`
-	Identifier     string `json:"identifier"`
-	ChallengeType  string `json:"challenge_type"`
-	OTP            string `json:"otp"`
+	GlobalUserID   string      `json:"global_user_id"`
+	KratosUserID   string      `json:"kratos_user_id"`
+	IdentifierType string      `json:"identifier_type"`
+	Identifier     string      `json:"identifier"`
+	ChallengeType  string      `json:"challenge_type"`
+	OTP            string      `json:"otp"`
+	SessionToken   string      `json:"session_token,omitempty"`   // session withheld until the second factor is verified
+	PasskeyState   string      `json:"passkey_state,omitempty"`   // WebAuthn ceremony state
+	Login          *LoginEvent `json:"login,omitempty"`           // login recorded once the second factor is verified
+	RememberDevice bool        `json:"remember_device,omitempty"` // trust the device once the second factor is verified
 }
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// IdentifierLockout counts the wrong OTP codes entered for an email or phone number of a tenant,
+// whichever challenge flow they were entered in. Lockouts counts the lockouts in a row so that
+// each one lasts longer than the last.
+type IdentifierLockout struct {
+	ID             string     `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TenantID       string     `json:"tenant_id" gorm:"type:uuid;not null"`
+	Identifier     string     `json:"identifier" gorm:"type:varchar(320);not null"`
+	FailedAttempts int        `json:"failed_attempts" gorm:"not null;default:0"` // since the last lockout
+	Lockouts       int        `json:"lockouts" gorm:"not null;default:0"`
+	LockedUntil    *time.Time `json:"locked_until"`
+	LastFailedAt   time.Time  `json:"last_failed_at" gorm:"not null"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName overrides the default table name for GORM.
+func (IdentifierLockout) TableName() string {
+	return "identifier_lockouts"
+}
+
+// IsLocked reports whether the identifier is locked out at now
+func (l *IdentifierLockout) IsLocked(now time.Time) bool {
+	return l != nil && l.LockedUntil != nil && now.Before(*l.LockedUntil)
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// IdentifierQuarantine is the tombstone of an email or phone number deleted from or changed away
+// from an account. Until it expires or is released, no other account may register the identifier,
+// which keeps a recycled phone number from being used to take over whoever gets it next.
+type IdentifierQuarantine struct {
+	ID                  string     `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TenantID            string     `json:"tenant_id" gorm:"type:uuid;not null"`
+	IdentifierType      string     `json:"identifier_type" gorm:"type:varchar(20);not null"`
+	IdentifierHash      string     `json:"-" gorm:"type:varchar(64);not null"`
+	MaskedIdentifier    string     `json:"masked_identifier" gorm:"type:varchar(64);not null"`
+	GlobalUserID        string     `json:"global_user_id" gorm:"type:uuid"` // the account that released the identifier, empty once it is erased
+	Reason              string     `json:"reason" gorm:"type:varchar(16);not null"`
+	ExpiresAt           time.Time  `json:"expires_at" gorm:"not null"`
+	ApprovalRequestedAt *time.Time `json:"approval_requested_at"` // last time another account asked for the identifier
+	ReleasedAt          *time.Time `json:"released_at"`
+	ReleasedBy          string     `json:"released_by" gorm:"type:varchar(255);not null"`
+	CreatedAt           time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName overrides the default table name for GORM.
+func (IdentifierQuarantine) TableName() string {
+	return "identifier_quarantines"
+}
+
+// IsActive reports whether the quarantine is still in effect at now
+func (q *IdentifierQuarantine) IsActive(now time.Time) bool {
+	return q != nil && q.ReleasedAt == nil && now.Before(q.ExpiresAt)
+}
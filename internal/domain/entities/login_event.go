@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// LoginEvent is a successful code login or registration of a user, with the client it came from
+type LoginEvent struct {
+	ID           string `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TenantID     string `json:"tenant_id" gorm:"type:uuid;not null"`
+	GlobalUserID string `json:"global_user_id" gorm:"type:uuid;not null"`
+	Flow         string `json:"flow" gorm:"type:varchar(32);not null"`   // the challenge type the code completed, e.g. login or register
+	Method       string `json:"method" gorm:"type:varchar(32);not null"` // the Kratos method, e.g. code
+	Channel      string `json:"channel" gorm:"type:varchar(32);not null"`
+	IPAddress    string `json:"ip_address" gorm:"type:varchar(45);not null"`
+	UserAgent    string `json:"user_agent" gorm:"type:text;not null"`
+	Country      string `json:"country" gorm:"type:varchar(2);not null"` // empty when the IP could not be located
+	NewDevice    bool   `json:"new_device" gorm:"not null"`
+	NewCountry   bool   `json:"new_country" gorm:"not null"`
+	// RiskAction is the tenant's login risk action applied to a flagged login, empty otherwise
+	RiskAction string    `json:"risk_action" gorm:"type:varchar(16);not null"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName overrides the default table name for GORM.
+func (LoginEvent) TableName() string {
+	return "login_events"
+}
+
+// Risky reports whether the login came from a device or country the user never signed in from
+func (e *LoginEvent) Risky() bool {
+	return e.NewDevice || e.NewCountry
+}
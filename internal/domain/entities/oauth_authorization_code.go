@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"time"
+)
+
+// OAuthAuthorizationCode is a single use code a client exchanges for tokens. It carries
+// everything the tokens are issued from, so the exchange does not depend on the session.
+type OAuthAuthorizationCode struct {
+	ID               string     `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TenantID         string     `json:"tenant_id" gorm:"type:uuid;not null"`
+	ClientID         string     `json:"client_id" gorm:"type:varchar(64);not null"`
+	CodeHash         string     `json:"-" gorm:"type:varchar(64);not null"`
+	RedirectURI      string     `json:"redirect_uri" gorm:"type:text;not null"`
+	Scope            string     `json:"scope" gorm:"type:text;not null"`
+	Nonce            string     `json:"-" gorm:"type:text;not null"`
+	CodeChallenge    string     `json:"-" gorm:"type:varchar(128);not null"` // S256
+	GlobalUserID     string     `json:"global_user_id" gorm:"type:uuid;not null"`
+	KratosUserID     string     `json:"kratos_user_id" gorm:"type:uuid;not null"`
+	KratosSessionID  string     `json:"kratos_session_id" gorm:"type:uuid;not null"`
+	AAL              string     `json:"aal" gorm:"column:aal;type:varchar(8);not null"`
+	AuthTime         time.Time  `json:"auth_time" gorm:"not null"`
+	SessionExpiresAt *time.Time `json:"session_expires_at"`
+	ExpiresAt        time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt           *time.Time `json:"used_at"`
+	CreatedAt        time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName overrides the default table name for GORM.
+func (OAuthAuthorizationCode) TableName() string {
+	return "oauth_authorization_codes"
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"crypto/subtle"
+	"slices"
+	"strings"
+	"time"
+)
+
+// OAuthClient is an application registered with a tenant. Confidential clients authenticate
+// with a secret of which only the hash is stored; public clients have none.
+type OAuthClient struct {
+	ID           string     `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TenantID     string     `json:"tenant_id" gorm:"type:uuid;not null"`
+	ClientID     string     `json:"client_id" gorm:"type:varchar(64);not null"`
+	Name         string     `json:"name" gorm:"type:varchar(100);not null"`
+	SecretHash   string     `json:"-" gorm:"type:varchar(64);not null"`
+	RedirectURIs string     `json:"redirect_uris" gorm:"type:text;not null"` // space separated
+	Public       bool       `json:"public" gorm:"not null"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName overrides the default table name for GORM.
+func (OAuthClient) TableName() string {
+	return "oauth_clients"
+}
+
+// MatchesSecretHash reports, in constant time, whether secretHash is the hash of the client's
+// secret. Public clients have no secret.
+func (c *OAuthClient) MatchesSecretHash(secretHash string) bool {
+	if c.Public || c.SecretHash == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(secretHash), []byte(c.SecretHash)) == 1
+}
+
+// AllowsRedirectURI reports whether uri exactly matches one of the registered redirect URIs
+func (c *OAuthClient) AllowsRedirectURI(uri string) bool {
+	return uri != "" && slices.Contains(strings.Fields(c.RedirectURIs), uri)
+}
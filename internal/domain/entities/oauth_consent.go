@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"time"
+)
+
+// OAuthConsent records the scopes a user allowed a client to receive
+type OAuthConsent struct {
+	ID           string     `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TenantID     string     `json:"tenant_id" gorm:"type:uuid;not null"`
+	ClientID     string     `json:"client_id" gorm:"type:varchar(64);not null"`
+	GlobalUserID string     `json:"global_user_id" gorm:"type:uuid;not null"`
+	Scope        string     `json:"scope" gorm:"type:text;not null"` // space separated
+	GrantedAt    time.Time  `json:"granted_at" gorm:"not null"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName overrides the default table name for GORM.
+func (OAuthConsent) TableName() string {
+	return "oauth_consents"
+}
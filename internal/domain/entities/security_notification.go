@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// SecurityNotification is a notice sent to a user's identifiers about a sensitive change to the
+// account or a login from a new device. Only the hash of its report token is kept.
+type SecurityNotification struct {
+	ID              string     `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TenantID        string     `json:"tenant_id" gorm:"type:uuid;not null"`
+	GlobalUserID    string     `json:"global_user_id" gorm:"type:uuid;not null"`
+	Event           string     `json:"event" gorm:"type:varchar(32);not null"`
+	IdentifierType  string     `json:"identifier_type" gorm:"type:varchar(20);not null"` // the identifier the change was about, empty for logins
+	Recipients      int        `json:"recipients" gorm:"not null"`                       // how many identifiers the notice reached
+	ReportTokenHash string     `json:"-" gorm:"type:varchar(64);not null"`
+	ExpiresAt       time.Time  `json:"expires_at" gorm:"not null"` // when the report link stops working
+	ReportedAt      *time.Time `json:"reported_at"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName overrides the default table name for GORM.
+func (SecurityNotification) TableName() string {
+	return "security_notifications"
+}
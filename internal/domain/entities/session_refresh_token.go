@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+)
+
+// SessionRefreshToken is one link in a session's refresh chain.
+// Every refresh consumes the current token and issues a child in the same family;
+// presenting a consumed token again revokes the whole family.
+type SessionRefreshToken struct {
+	ID              string     `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TenantID        string     `json:"tenant_id" gorm:"type:uuid;not null"`
+	FamilyID        string     `json:"family_id" gorm:"type:uuid;not null"`
+	ParentID        *string    `json:"parent_id" gorm:"type:uuid"`
+	KratosSessionID string     `json:"kratos_session_id" gorm:"type:uuid;not null"`
+	KratosUserID    string     `json:"kratos_user_id" gorm:"type:uuid;not null"`
+	TokenHash       string     `json:"-" gorm:"type:varchar(64);not null;uniqueIndex"`
+	ChainStartedAt  time.Time  `json:"chain_started_at" gorm:"not null"`
+	ExpiresAt       time.Time  `json:"expires_at" gorm:"not null"`
+	RotatedAt       *time.Time `json:"rotated_at"`
+	RevokedAt       *time.Time `json:"revoked_at"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName overrides the default table name for GORM.
+func (SessionRefreshToken) TableName() string {
+	return "session_refresh_tokens"
+}
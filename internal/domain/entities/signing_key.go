@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"time"
+)
+
+// SigningKey is a key pair that signs access tokens. The private key is stored encrypted.
+type SigningKey struct {
+	ID          string     `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	KeyID       string     `json:"kid" gorm:"column:kid;type:varchar(64);not null"`
+	Algorithm   string     `json:"algorithm" gorm:"type:varchar(16);not null"`
+	PrivateKey  string     `json:"-" gorm:"type:text;not null"`
+	PublicKey   string     `json:"public_key" gorm:"type:jsonb;not null"` // JWK
+	ActivatesAt time.Time  `json:"activates_at" gorm:"not null"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName overrides the default table name for GORM.
+func (SigningKey) TableName() string {
+	return "signing_keys"
+}
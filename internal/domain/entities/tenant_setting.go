@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+)
+
+// TenantSetting holds the per-tenant authentication policy.
+// A tenant without a row falls back to DefaultTenantSetting.
+type TenantSetting struct {
+	TenantID                               uuid.UUID `json:"tenant_id" gorm:"type:uuid;primaryKey"`
+	PasswordMinLength                      int       `json:"password_min_length" gorm:"not null"`
+	PasswordRejectBreached                 bool      `json:"password_reject_breached" gorm:"not null"`
+	SessionMaxLifetimeSeconds              int       `json:"session_max_lifetime_seconds" gorm:"not null"`
+	MFARequired                            bool      `json:"mfa_required" gorm:"not null"`
+	MaxConcurrentSessions                  int       `json:"max_concurrent_sessions" gorm:"not null"`                        // 0 means unlimited
+	OIDCLoginURL                           string    `json:"oidc_login_url" gorm:"column:oidc_login_url;type:text;not null"` // empty disables the OIDC provider
+	InviteOnlyRegistration                 bool      `json:"invite_only_registration" gorm:"not null"`                       // only invited identifiers may register
+	TrustedDeviceLifetimeSeconds           int       `json:"trusted_device_lifetime_seconds" gorm:"not null"`                // 0 disables remembering devices
+	TrustedDeviceSkipMFA                   bool      `json:"trusted_device_skip_mfa" gorm:"column:trusted_device_skip_mfa;not null"`
+	TrustedDeviceSessionMaxLifetimeSeconds int       `json:"trusted_device_session_max_lifetime_seconds" gorm:"not null"` // 0 keeps SessionMaxLifetimeSeconds
+	LoginRiskAction                        string    `json:"login_risk_action" gorm:"type:varchar(16);not null"`          // what a login from a new device or country triggers
+	IdentifierQuarantineSeconds            int       `json:"identifier_quarantine_seconds" gorm:"not null"`               // 0 disables quarantining released identifiers
+	IdentifierQuarantineAction             string    `json:"identifier_quarantine_action" gorm:"type:varchar(16);not null"`
+	CreatedAt                              time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt                              time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName overrides the default table name for GORM.
+func (TenantSetting) TableName() string {
+	return "tenant_settings"
+}
+
+// DefaultTenantSetting returns the policy applied to tenants that have not configured one.
+func DefaultTenantSetting(tenantID uuid.UUID) *TenantSetting {
+	return &TenantSetting{
+		TenantID:                   tenantID,
+		PasswordMinLength:          constants.DefaultPasswordMinLength,
+		PasswordRejectBreached:     true,
+		SessionMaxLifetimeSeconds:  int(constants.DefaultSessionMaxLifetime / time.Second),
+		LoginRiskAction:            constants.LoginRiskActionEvent,
+		IdentifierQuarantineAction: constants.IdentifierQuarantineActionBlock,
+	}
+}
+
+// SessionMaxLifetime is how long after the original sign-in a session can still be refreshed.
+// It is never shorter than constants.MinSessionMaxLifetime.
+func (s *TenantSetting) SessionMaxLifetime() time.Duration {
+	lifetime := time.Duration(s.SessionMaxLifetimeSeconds) * time.Second
+	if lifetime < constants.MinSessionMaxLifetime {
+		return constants.MinSessionMaxLifetime
+	}
+	return lifetime
+}
+
+// TrustedDeviceLifetime is how long a remembered device stays trusted; zero when the tenant does
+// not let users remember devices
+func (s *TenantSetting) TrustedDeviceLifetime() time.Duration {
+	if s.TrustedDeviceLifetimeSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(s.TrustedDeviceLifetimeSeconds) * time.Second
+}
+
+// TrustedDeviceSessionMaxLifetime is the SessionMaxLifetime of sessions signed in from a trusted
+// device. It is never shorter than that of other sessions.
+func (s *TenantSetting) TrustedDeviceSessionMaxLifetime() time.Duration {
+	lifetime := time.Duration(s.TrustedDeviceSessionMaxLifetimeSeconds) * time.Second
+	if lifetime < s.SessionMaxLifetime() {
+		return s.SessionMaxLifetime()
+	}
+	return lifetime
+}
+
+// IdentifierQuarantine is how long an identifier taken off an account is kept from other accounts;
+// zero when the tenant does not quarantine identifiers
+func (s *TenantSetting) IdentifierQuarantine() time.Duration {
+	if s.IdentifierQuarantineSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(s.IdentifierQuarantineSeconds) * time.Second
+}
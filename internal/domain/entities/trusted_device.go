@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// TrustedDevice is a device a user chose to remember when signing in. Only the hash of the
+// device's token is kept.
+type TrustedDevice struct {
+	ID           string     `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TenantID     string     `json:"tenant_id" gorm:"type:uuid;not null"`
+	GlobalUserID string     `json:"global_user_id" gorm:"type:uuid;not null"`
+	TokenHash    string     `json:"-" gorm:"type:varchar(64);not null"`
+	IPAddress    string     `json:"ip_address" gorm:"type:varchar(45);not null"`
+	UserAgent    string     `json:"user_agent" gorm:"type:text;not null"`
+	LastUsedAt   time.Time  `json:"last_used_at" gorm:"not null"`
+	ExpiresAt    time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName overrides the default table name for GORM.
+func (TrustedDevice) TableName() string {
+	return "trusted_devices"
+}
+
+// IsTrusted reports whether the device is still trusted at now
+func (d *TrustedDevice) IsTrusted(now time.Time) bool {
+	return d != nil && d.RevokedAt == nil && now.Before(d.ExpiresAt)
+}
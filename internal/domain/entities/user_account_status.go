@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+)
+
+// UserAccountStatus records whether a user may sign in to a tenant.
+// A suspension ends on its own at ExpiresAt; a ban lasts until an administrator lifts it.
+type UserAccountStatus struct {
+	ID           string     `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TenantID     string     `json:"tenant_id" gorm:"type:uuid;not null"`
+	GlobalUserID string     `json:"global_user_id" gorm:"type:uuid;not null"`
+	Status       string     `json:"status" gorm:"type:varchar(20);not null;default:'active'"`
+	Reason       string     `json:"reason" gorm:"type:text;not null;default:''"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+	UpdatedBy    string     `json:"updated_by" gorm:"type:varchar(255);not null;default:''"` // admin username
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName overrides the default table name for GORM.
+func (UserAccountStatus) TableName() string {
+	return "user_account_statuses"
+}
+
+// EffectiveStatus is the status in force at now; an expired suspension no longer blocks the user
+func (s *UserAccountStatus) EffectiveStatus(now time.Time) string {
+	if s == nil {
+		return constants.UserStatusActive
+	}
+	if s.Status == constants.UserStatusSuspended && s.ExpiresAt != nil && !now.Before(*s.ExpiresAt) {
+		return constants.UserStatusActive
+	}
+	return s.Status
+}
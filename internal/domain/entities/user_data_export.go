@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+)
+
+// UserDataExport is a copy of a global user's personal data, generated in the background.
+// It is pending until CompletedAt or FailedAt is set, and a completed export is deleted once
+// it expires.
+type UserDataExport struct {
+	ID           string     `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TenantID     string     `json:"tenant_id" gorm:"type:uuid;not null"`
+	GlobalUserID string     `json:"global_user_id" gorm:"type:uuid;not null"`
+	Payload      string     `json:"-" gorm:"type:text;not null;default:''"` // JSON document, encrypted with the database encryption key
+	ScheduledFor time.Time  `json:"scheduled_for" gorm:"not null"`
+	Attempts     int        `json:"attempts" gorm:"not null;default:0"`
+	LastError    string     `json:"last_error" gorm:"type:text;not null;default:''"`
+	CompletedAt  *time.Time `json:"completed_at"`
+	FailedAt     *time.Time `json:"failed_at"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName overrides the default table name for GORM.
+func (UserDataExport) TableName() string {
+	return "user_data_exports"
+}
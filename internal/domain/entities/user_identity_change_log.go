@@ -9,9 +9,12 @@ type UserIdentityChangeLog struct {
 	ID           string    `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
 	GlobalUserID string    `json:"global_user_id" gorm:"type:uuid;not null"`
 	TenantID     string    `json:"tenant_id" gorm:"type:uuid;not null"`
+	Action       string    `json:"action" gorm:"type:varchar(20);not null;default:change"`
 	IdentityType string    `json:"identity_type" gorm:"type:varchar(20);not null"`
 	OldValue     string    `json:"old_value" gorm:"type:varchar(255)"`
-	NewValue     string    `json:"new_value" gorm:"type:varchar(255);not null"`
+	NewValue     string    `json:"new_value" gorm:"type:varchar(255)"`
+	Actor        string    `json:"actor" gorm:"type:varchar(20);not null;default:system"`
+	ActorID      string    `json:"actor_id" gorm:"type:varchar(255)"` // the administrator's username
 	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
 }
 
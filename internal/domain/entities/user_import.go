@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+)
+
+// UserImport is a bulk import of users into a tenant. Its rows are processed in the background a
+// batch at a time; the counters follow the outcome of the rows processed so far.
+type UserImport struct {
+	ID            string     `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TenantID      string     `json:"tenant_id" gorm:"type:uuid;not null"`
+	Format        string     `json:"format" gorm:"type:varchar(10);not null"`
+	DryRun        bool       `json:"dry_run" gorm:"not null;default:false"`
+	RequestedBy   string     `json:"requested_by" gorm:"type:varchar(255);not null;default:''"` // admin username
+	TotalRows     int        `json:"total_rows" gorm:"not null;default:0"`
+	ProcessedRows int        `json:"processed_rows" gorm:"not null;default:0"`
+	CreatedRows   int        `json:"created_rows" gorm:"not null;default:0"`
+	ExistingRows  int        `json:"existing_rows" gorm:"not null;default:0"`
+	ValidRows     int        `json:"valid_rows" gorm:"not null;default:0"`
+	FailedRows    int        `json:"failed_rows" gorm:"not null;default:0"`
+	ScheduledFor  time.Time  `json:"scheduled_for" gorm:"not null"`
+	Attempts      int        `json:"attempts" gorm:"not null;default:0"`
+	LastError     string     `json:"last_error" gorm:"type:text;not null;default:''"`
+	CompletedAt   *time.Time `json:"completed_at"`
+	FailedAt      *time.Time `json:"failed_at"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName overrides the default table name for GORM.
+func (UserImport) TableName() string {
+	return "user_imports"
+}
+
+// Status reports whether the import is still running
+func (i *UserImport) Status() string {
+	switch {
+	case i.CompletedAt != nil:
+		return constants.UserImportStatusCompleted
+	case i.FailedAt != nil:
+		return constants.UserImportStatusFailed
+	}
+	return constants.UserImportStatusPending
+}
+
+// UserImportRow is one record of an import. Line is where the record starts in the uploaded file.
+type UserImportRow struct {
+	ID           string  `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	ImportID     string  `json:"import_id" gorm:"type:uuid;not null"`
+	Line         int     `json:"line" gorm:"not null"`
+	Email        string  `json:"email" gorm:"type:varchar(320);not null;default:''"`
+	Phone        string  `json:"phone" gorm:"type:varchar(32);not null;default:''"`
+	Lang         string  `json:"lang" gorm:"type:varchar(10);not null;default:''"`
+	Status       string  `json:"status" gorm:"type:varchar(20);not null;default:'pending'"`
+	ErrorCode    string  `json:"error_code" gorm:"type:varchar(64);not null;default:''"`
+	ErrorMessage string  `json:"error_message" gorm:"type:text;not null;default:''"`
+	GlobalUserID *string `json:"global_user_id" gorm:"type:uuid"`
+}
+
+// TableName overrides the default table name for GORM.
+func (UserImportRow) TableName() string {
+	return "user_import_rows"
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+)
+
+// UserInvitation is an identifier an administrator invited to a tenant. KratosUserID is the
+// identity created for it up front; GlobalUserID is set once the invitation is accepted.
+type UserInvitation struct {
+	ID             string     `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TenantID       string     `json:"tenant_id" gorm:"type:uuid;not null"`
+	IdentifierType string     `json:"identifier_type" gorm:"type:varchar(20);not null"`
+	Identifier     string     `json:"identifier" gorm:"type:varchar(320);not null"`
+	Lang           string     `json:"lang" gorm:"type:varchar(10);not null;default:''"`
+	Roles          string     `json:"roles" gorm:"type:text;not null;default:''"` // space separated
+	KratosUserID   string     `json:"kratos_user_id" gorm:"type:uuid;not null"`
+	FlowID         string     `json:"-" gorm:"type:varchar(64);not null"` // verification flow carrying the code
+	InvitedBy      string     `json:"invited_by" gorm:"type:varchar(255);not null;default:''"`
+	GlobalUserID   *string    `json:"global_user_id" gorm:"type:uuid"`
+	ExpiresAt      time.Time  `json:"expires_at" gorm:"not null"`
+	AcceptedAt     *time.Time `json:"accepted_at"`
+	RevokedAt      *time.Time `json:"revoked_at"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName overrides the default table name for GORM.
+func (UserInvitation) TableName() string {
+	return "user_invitations"
+}
+
+// Status is the state of the invitation at now
+func (i *UserInvitation) Status(now time.Time) string {
+	switch {
+	case i.AcceptedAt != nil:
+		return constants.InvitationStatusAccepted
+	case i.RevokedAt != nil:
+		return constants.InvitationStatusRevoked
+	case !now.Before(i.ExpiresAt):
+		return constants.InvitationStatusExpired
+	}
+	return constants.InvitationStatusPending
+}
+
+// RoleList returns the roles granted when the invitation is accepted
+func (i *UserInvitation) RoleList() []string {
+	return strings.Fields(i.Roles)
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"time"
+)
+
+// UserMFAFactor is a second factor enrolled by a global user within a tenant.
+// It only guards sign-in once EnabledAt is set; until then the enrollment is pending.
+type UserMFAFactor struct {
+	ID           string     `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TenantID     string     `json:"tenant_id" gorm:"type:uuid;not null"`
+	GlobalUserID string     `json:"global_user_id" gorm:"type:uuid;not null"`
+	Type         string     `json:"type" gorm:"type:varchar(20);not null"`
+	Secret       string     `json:"-" gorm:"type:text;not null"` // encrypted with the database encryption key
+	EnabledAt    *time.Time `json:"enabled_at"`
+	LastUsedStep int64      `json:"-" gorm:"not null;default:0"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName overrides the default table name for GORM.
+func (UserMFAFactor) TableName() string {
+	return "user_mfa_factors"
+}
+
+// Enabled reports whether the factor has been confirmed and now guards sign-in
+func (f *UserMFAFactor) Enabled() bool {
+	return f != nil && f.EnabledAt != nil
+}
+
+// UserMFARecoveryCode is a single-use backup code, stored as a hash
+type UserMFARecoveryCode struct {
+	ID           string     `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TenantID     string     `json:"tenant_id" gorm:"type:uuid;not null"`
+	GlobalUserID string     `json:"global_user_id" gorm:"type:uuid;not null"`
+	CodeHash     string     `json:"-" gorm:"type:varchar(64);not null"`
+	UsedAt       *time.Time `json:"used_at"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName overrides the default table name for GORM.
+func (UserMFARecoveryCode) TableName() string {
+	return "user_mfa_recovery_codes"
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+)
+
+// UserPasskey is a WebAuthn credential registered by a global user within a tenant
+type UserPasskey struct {
+	ID              string     `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TenantID        string     `json:"tenant_id" gorm:"type:uuid;not null"`
+	GlobalUserID    string     `json:"global_user_id" gorm:"type:uuid;not null"`
+	Name            string     `json:"name" gorm:"type:varchar(100);not null"`
+	CredentialID    string     `json:"credential_id" gorm:"type:varchar(1366);not null"` // base64url, as sent by the browser
+	PublicKey       []byte     `json:"-" gorm:"type:bytea;not null"`                     // COSE encoded
+	AttestationType string     `json:"attestation_type" gorm:"type:varchar(32);not null"`
+	AAGUID          []byte     `json:"-" gorm:"column:aaguid;type:bytea"`
+	Transports      string     `json:"transports" gorm:"type:text;not null"` // comma separated
+	SignCount       int64      `json:"-" gorm:"not null;default:0"`
+	BackupEligible  bool       `json:"backup_eligible" gorm:"not null"`
+	BackupState     bool       `json:"backup_state" gorm:"not null"`
+	LastUsedAt      *time.Time `json:"last_used_at"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName overrides the default table name for GORM.
+func (UserPasskey) TableName() string {
+	return "user_passkeys"
+}
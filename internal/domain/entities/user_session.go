@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+)
+
+// UserSession tracks a Kratos session issued to a global user, with the client it was issued to
+type UserSession struct {
+	ID              string     `json:"id" gorm:"type:uuid;default:uuid_generate_v4();primaryKey"`
+	TenantID        string     `json:"tenant_id" gorm:"type:uuid;not null"`
+	GlobalUserID    string     `json:"global_user_id" gorm:"type:uuid;not null"`
+	KratosSessionID string     `json:"kratos_session_id" gorm:"type:uuid;not null"`
+	KratosUserID    string     `json:"kratos_user_id" gorm:"type:uuid;not null"`
+	IPAddress       string     `json:"ip_address" gorm:"type:varchar(45);not null"`
+	UserAgent       string     `json:"user_agent" gorm:"type:text;not null"`
+	AAL             string     `json:"aal" gorm:"column:aal;type:varchar(8);not null"`
+	ExpiresAt       *time.Time `json:"expires_at"`
+	LastSeenAt      time.Time  `json:"last_seen_at" gorm:"not null"`
+	RevokedAt       *time.Time `json:"revoked_at"`
+	CreatedAt       time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName overrides the default table name for GORM.
+func (UserSession) TableName() string {
+	return "user_sessions"
+}
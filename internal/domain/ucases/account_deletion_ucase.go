@@ -0,0 +1,451 @@
+package ucases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	ratelimiters "github.com/lifenetwork-ai/iam-service/infrastructures/rate_limiter/types"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+	"github.com/lifenetwork-ai/iam-service/packages/utils"
+)
+
+type accountDeletionUseCase struct {
+	db                        *gorm.DB
+	rateLimiter               ratelimiters.RateLimiter
+	challengeSessionRepo      domainrepo.ChallengeSessionRepository
+	identifierLockoutRepo     domainrepo.IdentifierLockoutRepository
+	identifierQuarantineRepo  domainrepo.IdentifierQuarantineRepository
+	tenantSettingRepo         domainrepo.TenantSettingRepository
+	accountDeletionRepo       domainrepo.AccountDeletionRepository
+	globalUserRepo            domainrepo.GlobalUserRepository
+	userIdentityRepo          domainrepo.UserIdentityRepository
+	userIdentifierMappingRepo domainrepo.UserIdentifierMappingRepository
+	userSessionRepo           domainrepo.UserSessionRepository
+	sessionRefreshTokenRepo   domainrepo.SessionRefreshTokenRepository
+	kratosService             domainservice.KratosService
+	ketoService               domainservice.KetoService
+	sessionCache              *SessionCache
+}
+
+func NewAccountDeletionUseCase(
+	db *gorm.DB,
+	sessionCache *SessionCache,
+	rateLimiter ratelimiters.RateLimiter,
+	challengeSessionRepo domainrepo.ChallengeSessionRepository,
+	identifierLockoutRepo domainrepo.IdentifierLockoutRepository,
+	identifierQuarantineRepo domainrepo.IdentifierQuarantineRepository,
+	tenantSettingRepo domainrepo.TenantSettingRepository,
+	accountDeletionRepo domainrepo.AccountDeletionRepository,
+	globalUserRepo domainrepo.GlobalUserRepository,
+	userIdentityRepo domainrepo.UserIdentityRepository,
+	userIdentifierMappingRepo domainrepo.UserIdentifierMappingRepository,
+	userSessionRepo domainrepo.UserSessionRepository,
+	sessionRefreshTokenRepo domainrepo.SessionRefreshTokenRepository,
+	kratosService domainservice.KratosService,
+	ketoService domainservice.KetoService,
+) interfaces.AccountDeletionUseCase {
+	return &accountDeletionUseCase{
+		db:                        db,
+		rateLimiter:               rateLimiter,
+		challengeSessionRepo:      challengeSessionRepo,
+		identifierLockoutRepo:     identifierLockoutRepo,
+		identifierQuarantineRepo:  identifierQuarantineRepo,
+		tenantSettingRepo:         tenantSettingRepo,
+		accountDeletionRepo:       accountDeletionRepo,
+		globalUserRepo:            globalUserRepo,
+		userIdentityRepo:          userIdentityRepo,
+		userIdentifierMappingRepo: userIdentifierMappingRepo,
+		userSessionRepo:           userSessionRepo,
+		sessionRefreshTokenRepo:   sessionRefreshTokenRepo,
+		kratosService:             kratosService,
+		ketoService:               ketoService,
+		sessionCache:              sessionCache,
+	}
+}
+
+// ChallengeAccountDeletion sends the confirmation code to the user's email, or their phone when
+// they have no email
+func (u *accountDeletionUseCase) ChallengeAccountDeletion(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	user *types.IdentityUserResponse,
+) (*types.IdentityUserChallengeResponse, *domainerrors.DomainError) {
+	identities, err := u.userIdentityRepo.GetByGlobalUserIDAndTenantID(ctx, nil, user.GlobalUserID, tenantID.String())
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_IDENTITIES_FAILED", "Failed to get user identities")
+	}
+	receiver := verifiableIdentity(identities)
+	if receiver == nil {
+		return nil, domainerrors.NewValidationError(
+			"MSG_NO_VERIFIABLE_IDENTIFIER",
+			"The account has no email or phone number to confirm the deletion with",
+			nil,
+		)
+	}
+
+	key := fmt.Sprintf("challenge:delete_account:%s:%s", user.GlobalUserID, tenantID.String())
+	if err := utils.CheckRateLimitDomain(u.rateLimiter, key, constants.MaxAttemptsPerWindow, constants.RateLimitWindow); err != nil {
+		return nil, domainerrors.NewRateLimitError("MSG_RATE_LIMIT_EXCEEDED", "Rate limit exceeded", err)
+	}
+	if derr := identifierLockout(ctx, u.identifierLockoutRepo, tenantID, receiver.Value); derr != nil {
+		return nil, derr
+	}
+
+	flowID, err := u.kratosService.InitializeVerificationFlow(ctx, tenantID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_VERIFICATION_FLOW_FAILED", "Failed to initialize verification flow")
+	}
+	identifier := receiver.Value
+	if _, err := u.kratosService.SubmitVerificationFlow(
+		ctx, tenantID, flowID, &identifier, constants.IdentifierType(receiver.Type), nil,
+	); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_SEND_VERIFICATION_FAILED", "Failed to send verification code")
+	}
+
+	session := &domain.ChallengeSession{
+		ChallengeType:  constants.ChallengeTypeDeleteAccount,
+		Identifier:     receiver.Value,
+		IdentifierType: receiver.Type,
+		GlobalUserID:   user.GlobalUserID,
+		KratosUserID:   receiver.KratosUserID,
+	}
+	if err := u.challengeSessionRepo.SaveChallenge(ctx, flowID, session, constants.DefaultChallengeDuration); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_SAVING_SESSION_FAILED", "Saving challenge session failed")
+	}
+
+	return &types.IdentityUserChallengeResponse{
+		FlowID:      flowID,
+		Receiver:    receiver.Value,
+		ChallengeAt: time.Now().Unix(),
+	}, nil
+}
+
+// verifiableIdentity picks the identity a confirmation code can be sent to
+func verifiableIdentity(identities []*domain.UserIdentity) *domain.UserIdentity {
+	for _, idType := range []constants.IdentifierType{constants.IdentifierEmail, constants.IdentifierPhone} {
+		for _, identity := range identities {
+			if identity.Type == idType.String() {
+				return identity
+			}
+		}
+	}
+	return nil
+}
+
+// RequestAccountDeletion confirms the deletion with the code sent by ChallengeAccountDeletion
+func (u *accountDeletionUseCase) RequestAccountDeletion(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	user *types.IdentityUserResponse,
+	flowID, code string,
+) (*types.AccountDeletionResponse, *domainerrors.DomainError) {
+	key := "verify:delete_account:" + flowID
+	if err := utils.CheckRateLimitDomain(u.rateLimiter, key, constants.MaxAttemptsPerWindow, constants.RateLimitWindow); err != nil {
+		return nil, domainerrors.NewRateLimitError("MSG_RATE_LIMIT_EXCEEDED", "Rate limit exceeded", err)
+	}
+
+	// A code sent for another user, or for another purpose, must not delete this account
+	session, err := u.challengeSessionRepo.GetChallenge(ctx, flowID)
+	if err != nil || session == nil ||
+		session.ChallengeType != constants.ChallengeTypeDeleteAccount ||
+		session.GlobalUserID != user.GlobalUserID {
+		return nil, domainerrors.NewNotFoundError("MSG_CHALLENGE_SESSION_NOT_FOUND", "Challenge session")
+	}
+	if derr := identifierLockout(ctx, u.identifierLockoutRepo, tenantID, session.Identifier); derr != nil {
+		return nil, derr
+	}
+
+	identifier := session.Identifier
+	result, err := u.kratosService.SubmitVerificationFlow(
+		ctx, tenantID, flowID, &identifier, constants.IdentifierType(session.IdentifierType), &code,
+	)
+	if err != nil {
+		if errors.Is(err, domainservice.ErrFlowRejected) {
+			if derr := u.recordWrongCode(ctx, tenantID, flowID, identifier); derr != nil {
+				return nil, derr
+			}
+		}
+		return nil, domainerrors.NewValidationError("MSG_VERIFICATION_FAILED", "Verification failed", []interface{}{err.Error()})
+	}
+	verified := false
+	if result != nil {
+		if state, ok := result.State.(string); ok && strings.EqualFold(state, constants.StatePassedChallenge) {
+			verified = true
+		}
+	}
+	if !verified {
+		// Keep the session so the user can retry until the identifier is locked out
+		if derr := u.recordWrongCode(ctx, tenantID, flowID, identifier); derr != nil {
+			return nil, derr
+		}
+		return nil, domainerrors.NewValidationError("MSG_VERIFICATION_FAILED", "Invalid or expired verification code", nil)
+	}
+	forgetWrongCodes(ctx, u.identifierLockoutRepo, tenantID, identifier)
+	_ = u.challengeSessionRepo.DeleteChallenge(ctx, flowID)
+
+	return u.schedule(ctx, tenantID, user.GlobalUserID, constants.AccountDeletionRequestedByUser,
+		time.Now().Add(conf.GetAccountDeletionGracePeriod()))
+}
+
+// recordWrongCode counts a wrong confirmation code, dropping the flow once the identifier is
+// locked out
+func (u *accountDeletionUseCase) recordWrongCode(ctx context.Context, tenantID uuid.UUID, flowID, identifier string) *domainerrors.DomainError {
+	derr := countWrongCode(ctx, u.identifierLockoutRepo, tenantID, flowID, identifier)
+	if derr != nil {
+		_ = u.challengeSessionRepo.DeleteChallenge(ctx, flowID)
+	}
+	return derr
+}
+
+// ScheduleAccountDeletion deletes a user of the tenant on an administrator's behalf
+func (u *accountDeletionUseCase) ScheduleAccountDeletion(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID, requestedBy string,
+	immediate bool,
+) (*types.AccountDeletionResponse, *domainerrors.DomainError) {
+	scheduledFor := time.Now()
+	if !immediate {
+		scheduledFor = scheduledFor.Add(conf.GetAccountDeletionGracePeriod())
+	}
+	return u.schedule(ctx, tenantID, globalUserID, requestedBy, scheduledFor)
+}
+
+// schedule records the deletion and signs the user out everywhere. Sessions the user opens
+// during the grace period are signed out again when the account is erased.
+func (u *accountDeletionUseCase) schedule(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID, requestedBy string,
+	scheduledFor time.Time,
+) (*types.AccountDeletionResponse, *domainerrors.DomainError) {
+	identities, err := u.userIdentityRepo.GetByGlobalUserIDAndTenantID(ctx, nil, globalUserID, tenantID.String())
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_IDENTITIES_FAILED", "Failed to get user identities")
+	}
+	if len(identities) == 0 {
+		return nil, domainerrors.NewNotFoundError("MSG_USER_NOT_FOUND", "User")
+	}
+
+	pending, err := u.accountDeletionRepo.GetPending(ctx, tenantID.String(), globalUserID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_ACCOUNT_DELETION_FAILED", "Failed to get account deletion")
+	}
+	if pending != nil {
+		return nil, domainerrors.NewConflictError("MSG_ACCOUNT_DELETION_PENDING", "The account is already scheduled for deletion", nil)
+	}
+
+	deletion := &domain.AccountDeletion{
+		TenantID:     tenantID.String(),
+		GlobalUserID: globalUserID,
+		RequestedBy:  requestedBy,
+		ScheduledFor: scheduledFor,
+	}
+	if err := u.accountDeletionRepo.Create(ctx, deletion); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_CREATE_ACCOUNT_DELETION_FAILED", "Failed to schedule account deletion")
+	}
+
+	if err := u.revokeSessions(ctx, tenantID, globalUserID); err != nil {
+		logger.GetLogger().Errorf("Failed to sign out user %s scheduled for deletion: %v", globalUserID, err)
+	}
+
+	return toAccountDeletionResponse(deletion), nil
+}
+
+// GetAccountDeletion returns the user's pending deletion
+func (u *accountDeletionUseCase) GetAccountDeletion(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+) (*types.AccountDeletionResponse, *domainerrors.DomainError) {
+	deletion, err := u.accountDeletionRepo.GetPending(ctx, tenantID.String(), globalUserID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_ACCOUNT_DELETION_FAILED", "Failed to get account deletion")
+	}
+	if deletion == nil {
+		return nil, domainerrors.NewNotFoundError("MSG_ACCOUNT_DELETION_NOT_FOUND", "Account deletion")
+	}
+	return toAccountDeletionResponse(deletion), nil
+}
+
+// CancelAccountDeletion keeps the account. A deletion that has already started cannot be cancelled.
+func (u *accountDeletionUseCase) CancelAccountDeletion(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+) *domainerrors.DomainError {
+	cancelled, err := u.accountDeletionRepo.Cancel(ctx, tenantID.String(), globalUserID, time.Now())
+	if err != nil {
+		return domainerrors.WrapInternal(err, "MSG_CANCEL_ACCOUNT_DELETION_FAILED", "Failed to cancel account deletion")
+	}
+	if !cancelled {
+		return domainerrors.NewNotFoundError("MSG_ACCOUNT_DELETION_NOT_FOUND", "Account deletion")
+	}
+	return nil
+}
+
+// ListAccountDeletions returns the tenant's deletions, newest first
+func (u *accountDeletionUseCase) ListAccountDeletions(
+	ctx context.Context,
+	tenantID uuid.UUID,
+) ([]*types.AccountDeletionResponse, *domainerrors.DomainError) {
+	deletions, err := u.accountDeletionRepo.ListByTenant(ctx, tenantID.String())
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_LIST_ACCOUNT_DELETIONS_FAILED", "Failed to list account deletions")
+	}
+	resp := make([]*types.AccountDeletionResponse, 0, len(deletions))
+	for _, deletion := range deletions {
+		resp = append(resp, toAccountDeletionResponse(deletion))
+	}
+	return resp, nil
+}
+
+// ProcessDueAccountDeletions erases the accounts whose deletion is due. Each deletion is claimed
+// first, so instances running the worker side by side do not erase the same account twice.
+func (u *accountDeletionUseCase) ProcessDueAccountDeletions(ctx context.Context) (int, *domainerrors.DomainError) {
+	now := time.Now()
+	due, err := u.accountDeletionRepo.ListDue(ctx, now, constants.AccountDeletionBatchSize)
+	if err != nil {
+		return 0, domainerrors.WrapInternal(err, "MSG_LIST_ACCOUNT_DELETIONS_FAILED", "Failed to list due account deletions")
+	}
+
+	erased := 0
+	for _, deletion := range due {
+		if !claimJob("account deletion", deletion.ID, func() (bool, error) {
+			return u.accountDeletionRepo.Claim(ctx, deletion.ID, now, now.Add(constants.AccountDeletionRetryDelay))
+		}) {
+			continue
+		}
+
+		if err := u.erase(ctx, deletion); err != nil {
+			logger.GetLogger().Errorf("Failed to erase user %s, retrying later: %v", deletion.GlobalUserID, err)
+			if err := u.accountDeletionRepo.RecordFailure(ctx, deletion.ID, err.Error()); err != nil {
+				logger.GetLogger().Errorf("Failed to record failure of account deletion %s: %v", deletion.ID, err)
+			}
+			continue
+		}
+		if err := u.accountDeletionRepo.MarkCompleted(ctx, deletion.ID, time.Now()); err != nil {
+			// The account is gone; the next attempt finds nothing left to erase and completes
+			logger.GetLogger().Errorf("Failed to complete account deletion %s: %v", deletion.ID, err)
+			continue
+		}
+		erased++
+	}
+	return erased, nil
+}
+
+// erase removes the user from Kratos, Keto and the database. Every step can be repeated, so a
+// deletion that failed part way is finished by running it again. The database rows go last:
+// they are how the remaining Kratos identities are found.
+func (u *accountDeletionUseCase) erase(ctx context.Context, deletion *domain.AccountDeletion) error {
+	tenantID, err := uuid.Parse(deletion.TenantID)
+	if err != nil {
+		return fmt.Errorf("invalid tenant id: %w", err)
+	}
+	if err := u.revokeSessions(ctx, tenantID, deletion.GlobalUserID); err != nil {
+		return err
+	}
+
+	// Global users are not shared between tenants, but any identity left elsewhere would lose its
+	// user along with this one, so every identity is deleted
+	identities, err := u.userIdentityRepo.ListByGlobalUserID(ctx, nil, deletion.GlobalUserID)
+	if err != nil {
+		return fmt.Errorf("list identities: %w", err)
+	}
+	deleted := make(map[string]bool, len(identities))
+	for _, identity := range identities {
+		key := identity.TenantID + "/" + identity.KratosUserID
+		if deleted[key] {
+			continue
+		}
+		identityTenantID, err := uuid.Parse(identity.TenantID)
+		if err != nil {
+			return fmt.Errorf("invalid tenant id of identity %s: %w", identity.ID, err)
+		}
+		kratosUserID, err := uuid.Parse(identity.KratosUserID)
+		if err != nil {
+			return fmt.Errorf("invalid kratos id of identity %s: %w", identity.ID, err)
+		}
+		if err := u.kratosService.DeleteIdentifierAdmin(ctx, identityTenantID, kratosUserID); err != nil {
+			return fmt.Errorf("delete kratos identity %s: %w", identity.KratosUserID, err)
+		}
+		deleted[key] = true
+	}
+
+	if derr := u.ketoService.DeleteRelationTuplesBySubject(ctx, deletion.GlobalUserID); derr != nil {
+		return fmt.Errorf("delete relation tuples: %s", derr.Message)
+	}
+
+	// The email addresses and phone numbers stay out of other accounts' reach for the tenant's
+	// quarantine period, as when they are deleted or changed away
+	for _, identity := range identities {
+		if identity.Type == constants.IdentifierEmail.String() || identity.Type == constants.IdentifierPhone.String() {
+			quarantineIdentity(ctx, u.tenantSettingRepo, u.identifierQuarantineRepo, identity, constants.IdentifierQuarantineReasonErased)
+		}
+	}
+
+	// Sessions, second factors, passkeys, consents and the identity history go with the global
+	// user through their foreign keys. The quarantines outlive it without pointing back at it.
+	err = u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := u.identifierQuarantineRepo.ForgetGlobalUser(ctx, tx, deletion.GlobalUserID); err != nil {
+			return fmt.Errorf("forget user in identifier quarantines: %w", err)
+		}
+		if err := u.userIdentityRepo.DeleteByGlobalUserID(ctx, tx, deletion.GlobalUserID); err != nil {
+			return fmt.Errorf("delete identities: %w", err)
+		}
+		if err := u.userIdentifierMappingRepo.DeleteByGlobalUserID(ctx, tx, deletion.GlobalUserID); err != nil {
+			return fmt.Errorf("delete identifier mapping: %w", err)
+		}
+		if err := u.globalUserRepo.Delete(tx, deletion.GlobalUserID); err != nil {
+			return fmt.Errorf("delete global user: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	u.sessionCache.invalidateUser(deletion.GlobalUserID)
+	return nil
+}
+
+// revokeSessions signs the user out of every session in the tenant and revokes their refresh tokens
+func (u *accountDeletionUseCase) revokeSessions(ctx context.Context, tenantID uuid.UUID, globalUserID string) error {
+	return revokeUserSessions(ctx, tenantID, globalUserID, u.kratosService, u.userSessionRepo, u.sessionRefreshTokenRepo, u.sessionCache)
+}
+
+func toAccountDeletionResponse(deletion *domain.AccountDeletion) *types.AccountDeletionResponse {
+	status := constants.AccountDeletionStatusPending
+	switch {
+	case deletion.CompletedAt != nil:
+		status = constants.AccountDeletionStatusCompleted
+	case deletion.CancelledAt != nil:
+		status = constants.AccountDeletionStatusCancelled
+	}
+	return &types.AccountDeletionResponse{
+		ID:           deletion.ID,
+		GlobalUserID: deletion.GlobalUserID,
+		RequestedBy:  deletion.RequestedBy,
+		Status:       status,
+		ScheduledFor: deletion.ScheduledFor,
+		Attempts:     deletion.Attempts,
+		LastError:    deletion.LastError,
+		CompletedAt:  deletion.CompletedAt,
+		CancelledAt:  deletion.CancelledAt,
+		CreatedAt:    deletion.CreatedAt,
+	}
+}
@@ -0,0 +1,266 @@
+package ucases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	client "github.com/ory/kratos-client-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	mock_repositories "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/repositories"
+	mock_services "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/services"
+	mock_rate_limiter "github.com/lifenetwork-ai/iam-service/mocks/infrastructures/rate_limiter/types"
+)
+
+func TestRequestAccountDeletion_NeedsTheUsersOwnDeletionCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	user := &types.IdentityUserResponse{GlobalUserID: "global-1"}
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	deletionRepo := mock_repositories.NewMockAccountDeletionRepository(ctrl)
+	sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	tokenRepo := mock_repositories.NewMockSessionRefreshTokenRepository(ctrl)
+	lockoutRepo := mock_repositories.NewMockIdentifierLockoutRepository(ctrl)
+	kratos := mock_services.NewMockKratosService(ctrl)
+
+	u := &accountDeletionUseCase{
+		rateLimiter:             rateLimiter,
+		challengeSessionRepo:    challengeRepo,
+		identifierLockoutRepo:   lockoutRepo,
+		accountDeletionRepo:     deletionRepo,
+		userIdentityRepo:        identityRepo,
+		userSessionRepo:         sessionRepo,
+		sessionRefreshTokenRepo: tokenRepo,
+		kratosService:           kratos,
+	}
+
+	// A code confirming something else, or sent to someone else, is refused before Kratos sees it
+	for flowID, session := range map[string]*domain.ChallengeSession{
+		"flow-other-purpose": {ChallengeType: constants.ChallengeTypeVerifyIdentifier, GlobalUserID: "global-1"},
+		"flow-other-user":    {ChallengeType: constants.ChallengeTypeDeleteAccount, GlobalUserID: "global-2"},
+	} {
+		challengeRepo.EXPECT().GetChallenge(ctx, flowID).Return(session, nil)
+		resp, derr := u.RequestAccountDeletion(ctx, tenantID, user, flowID, "123456")
+		assert.Nil(t, resp)
+		require.NotNil(t, derr, flowID)
+		assert.Equal(t, "MSG_CHALLENGE_SESSION_NOT_FOUND", derr.Code)
+	}
+
+	session := &domain.ChallengeSession{
+		ChallengeType:  constants.ChallengeTypeDeleteAccount,
+		GlobalUserID:   "global-1",
+		Identifier:     "user@example.com",
+		IdentifierType: constants.IdentifierEmail.String(),
+	}
+	challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").Return(session, nil)
+	lockoutRepo.EXPECT().Get(ctx, tenantID.String(), "user@example.com").Return(nil, nil)
+	kratos.EXPECT().SubmitVerificationFlow(ctx, tenantID, "flow-1", gomock.Any(), constants.IdentifierEmail, gomock.Any()).
+		Return(&client.VerificationFlow{State: constants.StatePassedChallenge}, nil)
+	lockoutRepo.EXPECT().Delete(ctx, tenantID.String(), "user@example.com").Return(true, nil)
+	challengeRepo.EXPECT().DeleteChallenge(ctx, "flow-1").Return(nil)
+	identityRepo.EXPECT().GetByGlobalUserIDAndTenantID(ctx, nil, "global-1", tenantID.String()).
+		Return([]*domain.UserIdentity{{GlobalUserID: "global-1"}}, nil)
+	deletionRepo.EXPECT().GetPending(ctx, tenantID.String(), "global-1").Return(nil, nil)
+	deletionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	// The user is signed out right away
+	sessionRepo.EXPECT().ListActive(ctx, tenantID.String(), "global-1", gomock.Any()).
+		Return([]*domain.UserSession{{KratosSessionID: "session-1"}}, nil)
+	kratos.EXPECT().DisableSessionAdmin(ctx, tenantID, "session-1").Return(nil)
+	tokenRepo.EXPECT().RevokeBySession(ctx, tenantID.String(), "session-1", gomock.Any()).Return(nil)
+	sessionRepo.EXPECT().Revoke(ctx, tenantID.String(), "session-1", gomock.Any()).Return(nil)
+
+	resp, derr := u.RequestAccountDeletion(ctx, tenantID, user, "flow-1", "123456")
+	require.Nil(t, derr)
+	assert.Equal(t, constants.AccountDeletionStatusPending, resp.Status)
+	assert.Equal(t, constants.AccountDeletionRequestedByUser, resp.RequestedBy)
+	// The configured grace period defaults to 30 days
+	assert.WithinDuration(t, time.Now().Add(30*24*time.Hour), resp.ScheduledFor, time.Minute)
+}
+
+func TestRequestAccountDeletion_WrongCodeLocksOutIdentifierAtLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	user := &types.IdentityUserResponse{GlobalUserID: "global-1"}
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	// The flow is dropped along with the lockout, so its code cannot be guessed at any longer
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").Return(&domain.ChallengeSession{
+		ChallengeType:  constants.ChallengeTypeDeleteAccount,
+		GlobalUserID:   "global-1",
+		Identifier:     "user@example.com",
+		IdentifierType: constants.IdentifierEmail.String(),
+	}, nil)
+	challengeRepo.EXPECT().DeleteChallenge(ctx, "flow-1").Return(nil)
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().SubmitVerificationFlow(ctx, tenantID, "flow-1", gomock.Any(), constants.IdentifierEmail, gomock.Any()).
+		Return(&client.VerificationFlow{State: "sent_email"}, nil)
+
+	lockoutRepo := mock_repositories.NewMockIdentifierLockoutRepository(ctrl)
+	lockoutRepo.EXPECT().Get(ctx, tenantID.String(), "user@example.com").Return(nil, nil)
+	lockoutRepo.EXPECT().RecordFailure(ctx, tenantID.String(), "user@example.com", gomock.Any(), gomock.Any()).
+		Return(&domain.IdentifierLockout{ID: "lockout-1", FailedAttempts: constants.OTPMaxFailedAttempts}, nil)
+	lockoutRepo.EXPECT().Lock(ctx, "lockout-1", gomock.Any()).Return(nil)
+
+	u := &accountDeletionUseCase{
+		rateLimiter:           rateLimiter,
+		challengeSessionRepo:  challengeRepo,
+		identifierLockoutRepo: lockoutRepo,
+		kratosService:         kratos,
+	}
+
+	resp, derr := u.RequestAccountDeletion(ctx, tenantID, user, "flow-1", "000000")
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_IDENTIFIER_LOCKED", derr.Code)
+}
+
+func TestProcessDueAccountDeletions_ResumesAfterPartialFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	deletion := &domain.AccountDeletion{ID: "deletion-1", TenantID: tenantID.String(), GlobalUserID: "global-1"}
+	kratosIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	identities := []*domain.UserIdentity{
+		{
+			ID: "identity-email", TenantID: tenantID.String(), GlobalUserID: "global-1", KratosUserID: kratosIDs[0].String(),
+			Type: constants.IdentifierEmail.String(), Value: "user@example.com",
+		},
+		{
+			ID: "identity-phone", TenantID: tenantID.String(), GlobalUserID: "global-1", KratosUserID: kratosIDs[1].String(),
+			Type: constants.IdentifierPhone.String(), Value: "+84901234567",
+		},
+		{
+			ID: "identity-wallet", TenantID: tenantID.String(), GlobalUserID: "global-1", KratosUserID: kratosIDs[1].String(),
+			Type: constants.IdentifierWallet.String(), Value: "0xabc",
+		},
+	}
+
+	deletionRepo := mock_repositories.NewMockAccountDeletionRepository(ctrl)
+	quarantineRepo := mock_repositories.NewMockIdentifierQuarantineRepository(ctrl)
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	mappingRepo := mock_repositories.NewMockUserIdentifierMappingRepository(ctrl)
+	globalRepo := mock_repositories.NewMockGlobalUserRepository(ctrl)
+	kratos := mock_services.NewMockKratosService(ctrl)
+	keto := mock_services.NewMockKetoService(ctrl)
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	u := &accountDeletionUseCase{
+		db:                        db,
+		identifierQuarantineRepo:  quarantineRepo,
+		tenantSettingRepo:         settingRepo,
+		accountDeletionRepo:       deletionRepo,
+		globalUserRepo:            globalRepo,
+		userIdentityRepo:          identityRepo,
+		userIdentifierMappingRepo: mappingRepo,
+		userSessionRepo:           sessionRepo,
+		kratosService:             kratos,
+		ketoService:               keto,
+	}
+
+	deletionRepo.EXPECT().ListDue(ctx, gomock.Any(), constants.AccountDeletionBatchSize).
+		Return([]*domain.AccountDeletion{deletion}, nil).Times(2)
+	deletionRepo.EXPECT().Claim(ctx, deletion.ID, gomock.Any(), gomock.Any()).Return(true, nil).Times(2)
+	sessionRepo.EXPECT().ListActive(ctx, tenantID.String(), "global-1", gomock.Any()).Return(nil, nil).Times(2)
+	identityRepo.EXPECT().ListByGlobalUserID(ctx, nil, "global-1").Return(identities, nil).Times(2)
+
+	// First run: Kratos fails on the second identity, so nothing else is touched
+	gomock.InOrder(
+		kratos.EXPECT().DeleteIdentifierAdmin(ctx, tenantID, kratosIDs[0]).Return(nil),
+		kratos.EXPECT().DeleteIdentifierAdmin(ctx, tenantID, kratosIDs[1]).Return(errors.New("kratos unavailable")),
+	)
+	deletionRepo.EXPECT().RecordFailure(ctx, deletion.ID, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, lastError string) error {
+			assert.Contains(t, lastError, "kratos unavailable")
+			return nil
+		})
+
+	erased, derr := u.ProcessDueAccountDeletions(ctx)
+	require.Nil(t, derr)
+	assert.Equal(t, 0, erased)
+
+	// Second run: the identity deleted before is already gone, which Kratos reports as success
+	gomock.InOrder(
+		kratos.EXPECT().DeleteIdentifierAdmin(ctx, tenantID, kratosIDs[0]).Return(nil),
+		kratos.EXPECT().DeleteIdentifierAdmin(ctx, tenantID, kratosIDs[1]).Return(nil),
+	)
+	keto.EXPECT().DeleteRelationTuplesBySubject(ctx, "global-1").Return(nil)
+
+	// The email and phone stay quarantined, and the quarantines no longer point at the user
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(&domain.TenantSetting{
+		TenantID:                    tenantID,
+		IdentifierQuarantineSeconds: int((30 * 24 * time.Hour) / time.Second),
+	}, nil).Times(2)
+	var quarantined []string
+	quarantineRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, q *domain.IdentifierQuarantine) error {
+		assert.Equal(t, constants.IdentifierQuarantineReasonErased, q.Reason)
+		quarantined = append(quarantined, q.IdentifierType)
+		return nil
+	}).Times(2)
+	quarantineRepo.EXPECT().ForgetGlobalUser(ctx, gomock.Any(), "global-1").Return(nil)
+	identityRepo.EXPECT().DeleteByGlobalUserID(ctx, gomock.Any(), "global-1").Return(nil)
+	mappingRepo.EXPECT().DeleteByGlobalUserID(ctx, gomock.Any(), "global-1").Return(nil)
+	globalRepo.EXPECT().Delete(gomock.Any(), "global-1").Return(nil)
+	deletionRepo.EXPECT().MarkCompleted(ctx, deletion.ID, gomock.Any()).Return(nil)
+
+	erased, derr = u.ProcessDueAccountDeletions(ctx)
+	require.Nil(t, derr)
+	assert.Equal(t, 1, erased)
+	assert.Equal(t, []string{constants.IdentifierEmail.String(), constants.IdentifierPhone.String()}, quarantined)
+}
+
+func TestProcessDueAccountDeletions_SkipsDeletionClaimedElsewhere(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	deletion := &domain.AccountDeletion{ID: "deletion-1", TenantID: uuid.NewString(), GlobalUserID: "global-1"}
+
+	deletionRepo := mock_repositories.NewMockAccountDeletionRepository(ctrl)
+	deletionRepo.EXPECT().ListDue(ctx, gomock.Any(), constants.AccountDeletionBatchSize).
+		Return([]*domain.AccountDeletion{deletion}, nil)
+	deletionRepo.EXPECT().Claim(ctx, deletion.ID, gomock.Any(), gomock.Any()).Return(false, nil)
+
+	// Another worker owns the deletion, so no identity is touched
+	u := &accountDeletionUseCase{
+		accountDeletionRepo: deletionRepo,
+		kratosService:       mock_services.NewMockKratosService(ctrl),
+	}
+
+	erased, derr := u.ProcessDueAccountDeletions(ctx)
+	require.Nil(t, derr)
+	assert.Equal(t, 0, erased)
+}
@@ -7,9 +7,11 @@ import (
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 
 	"github.com/google/uuid"
 	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/constants"
 	"github.com/lifenetwork-ai/iam-service/internal/delivery/dto"
 	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
 	domaintypes "github.com/lifenetwork-ai/iam-service/internal/domain/types"
@@ -17,30 +19,56 @@ import (
 	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
 	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
 	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
 	"github.com/lifenetwork-ai/iam-service/packages/logger"
+	"github.com/lifenetwork-ai/iam-service/packages/utils"
 )
 
 type adminUseCase struct {
+	db                        *gorm.DB
 	tenantRepo                domainrepo.TenantRepository
 	adminAccountRepo          domainrepo.AdminAccountRepository
 	userIdentityRepo          domainrepo.UserIdentityRepository
 	userIdentifierMappingRepo domainrepo.UserIdentifierMappingRepository
+	tenantSettingRepo         domainrepo.TenantSettingRepository
+	oauthClientRepo           domainrepo.OAuthClientRepository
+	changeLogRepo             domainrepo.UserIdentityChangeLogRepository
+	loginEventRepo            domainrepo.LoginEventRepository
+	identifierQuarantineRepo  domainrepo.IdentifierQuarantineRepository
 	kratosService             domainservice.KratosService
+	securityNotifier          interfaces.SecurityNotificationUseCase
+	sessionCache              *SessionCache
 }
 
 func NewAdminUseCase(
+	db *gorm.DB,
 	tenantRepo domainrepo.TenantRepository,
 	adminAccountRepo domainrepo.AdminAccountRepository,
 	userIdentityRepo domainrepo.UserIdentityRepository,
 	userIdentifierMappingRepo domainrepo.UserIdentifierMappingRepository,
+	tenantSettingRepo domainrepo.TenantSettingRepository,
+	oauthClientRepo domainrepo.OAuthClientRepository,
+	changeLogRepo domainrepo.UserIdentityChangeLogRepository,
+	loginEventRepo domainrepo.LoginEventRepository,
+	identifierQuarantineRepo domainrepo.IdentifierQuarantineRepository,
 	kratosService domainservice.KratosService,
+	securityNotifier interfaces.SecurityNotificationUseCase,
+	sessionCache *SessionCache,
 ) interfaces.AdminUseCase {
 	return &adminUseCase{
+		db:                        db,
 		tenantRepo:                tenantRepo,
 		adminAccountRepo:          adminAccountRepo,
 		userIdentityRepo:          userIdentityRepo,
 		userIdentifierMappingRepo: userIdentifierMappingRepo,
+		tenantSettingRepo:         tenantSettingRepo,
+		oauthClientRepo:           oauthClientRepo,
+		changeLogRepo:             changeLogRepo,
+		loginEventRepo:            loginEventRepo,
+		identifierQuarantineRepo:  identifierQuarantineRepo,
 		kratosService:             kratosService,
+		securityNotifier:          securityNotifier,
+		sessionCache:              sessionCache,
 	}
 }
 
@@ -363,10 +391,242 @@ func (u *adminUseCase) DeleteTenant(ctx context.Context, id string) (*domain.Ten
 			"Failed to delete tenant",
 		)
 	}
+	u.sessionCache.invalidateTenant(tenantID)
 
 	return &domainTenant, nil
 }
 
+// GetTenantSetting returns the tenant's settings, or the defaults if none have been saved
+func (u *adminUseCase) GetTenantSetting(ctx context.Context, id string) (*domain.TenantSetting, *domainerrors.DomainError) {
+	tenant, derr := u.getExistingTenant(id)
+	if derr != nil {
+		return nil, derr
+	}
+
+	return getTenantSetting(ctx, u.tenantSettingRepo, tenant.ID)
+}
+
+// UpdateTenantSetting applies the provided fields on top of the tenant's current settings
+func (u *adminUseCase) UpdateTenantSetting(
+	ctx context.Context,
+	id string,
+	req dto.UpdateTenantSettingPayloadDTO,
+) (*domain.TenantSetting, *domainerrors.DomainError) {
+	tenant, derr := u.getExistingTenant(id)
+	if derr != nil {
+		return nil, derr
+	}
+
+	setting, derr := getTenantSetting(ctx, u.tenantSettingRepo, tenant.ID)
+	if derr != nil {
+		return nil, derr
+	}
+
+	if req.PasswordMinLength != nil {
+		setting.PasswordMinLength = *req.PasswordMinLength
+	}
+	if req.PasswordRejectBreached != nil {
+		setting.PasswordRejectBreached = *req.PasswordRejectBreached
+	}
+	if req.SessionMaxLifetimeSeconds != nil {
+		setting.SessionMaxLifetimeSeconds = *req.SessionMaxLifetimeSeconds
+	}
+	if req.MFARequired != nil {
+		setting.MFARequired = *req.MFARequired
+	}
+	if req.MaxConcurrentSessions != nil {
+		setting.MaxConcurrentSessions = *req.MaxConcurrentSessions
+	}
+	if req.OIDCLoginURL != nil {
+		loginURL := strings.TrimSpace(*req.OIDCLoginURL)
+		if loginURL != "" && !isAbsoluteURL(loginURL) {
+			return nil, domainerrors.NewValidationError(
+				"MSG_INVALID_OIDC_LOGIN_URL",
+				"OIDC login URL must be an absolute URL",
+				map[string]string{
+					"field": "oidc_login_url",
+					"error": "Must be an absolute http(s) URL",
+				},
+			)
+		}
+		setting.OIDCLoginURL = loginURL
+	}
+	if req.InviteOnlyRegistration != nil {
+		setting.InviteOnlyRegistration = *req.InviteOnlyRegistration
+	}
+	if req.TrustedDeviceLifetimeSeconds != nil {
+		setting.TrustedDeviceLifetimeSeconds = *req.TrustedDeviceLifetimeSeconds
+	}
+	if req.TrustedDeviceSkipMFA != nil {
+		setting.TrustedDeviceSkipMFA = *req.TrustedDeviceSkipMFA
+	}
+	if req.TrustedDeviceSessionMaxLifetimeSeconds != nil {
+		setting.TrustedDeviceSessionMaxLifetimeSeconds = *req.TrustedDeviceSessionMaxLifetimeSeconds
+	}
+	if req.LoginRiskAction != nil {
+		setting.LoginRiskAction = *req.LoginRiskAction
+	}
+	if req.IdentifierQuarantineSeconds != nil {
+		setting.IdentifierQuarantineSeconds = *req.IdentifierQuarantineSeconds
+	}
+	if req.IdentifierQuarantineAction != nil {
+		setting.IdentifierQuarantineAction = *req.IdentifierQuarantineAction
+	}
+
+	if err := u.tenantSettingRepo.Upsert(ctx, setting); err != nil {
+		logger.GetLogger().Errorf("Failed to update tenant settings: %v", err)
+		return nil, domainerrors.NewInternalError(
+			"MSG_UPDATE_TENANT_SETTING_FAILED",
+			"Failed to update tenant settings",
+		)
+	}
+	// Cached sessions carry state derived from the settings, such as a pending MFA enrollment
+	u.sessionCache.invalidateTenant(tenant.ID)
+
+	return setting, nil
+}
+
+// CreateOAuthClient registers a client with the tenant. The secret of a confidential client is
+// only returned here.
+func (u *adminUseCase) CreateOAuthClient(
+	ctx context.Context,
+	id string,
+	req dto.CreateOAuthClientPayloadDTO,
+) (*dto.OAuthClientCredentialsDTO, *domainerrors.DomainError) {
+	tenant, derr := u.getExistingTenant(id)
+	if derr != nil {
+		return nil, derr
+	}
+
+	redirectURIs := make([]string, 0, len(req.RedirectURIs))
+	for _, uri := range req.RedirectURIs {
+		if !isValidRedirectURI(uri) {
+			return nil, domainerrors.NewValidationError(
+				"MSG_INVALID_REDIRECT_URI",
+				"Redirect URIs must be absolute URIs without a fragment",
+				map[string]string{
+					"field": "redirect_uris",
+					"error": uri,
+				},
+			)
+		}
+		redirectURIs = append(redirectURIs, uri)
+	}
+	if req.Public && len(redirectURIs) == 0 {
+		return nil, domainerrors.NewValidationError(
+			"MSG_REDIRECT_URI_REQUIRED",
+			"Public clients need at least one redirect URI",
+			map[string]string{
+				"field": "redirect_uris",
+				"error": "At least one redirect URI is required",
+			},
+		)
+	}
+
+	clientID, err := utils.RandomToken(constants.OAuthClientIDBytes)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_CREATE_OAUTH_CLIENT_FAILED", "Failed to generate client ID")
+	}
+	var secret, secretHash string
+	if !req.Public {
+		if secret, err = utils.RandomToken(constants.OAuthClientSecretBytes); err != nil {
+			return nil, domainerrors.WrapInternal(err, "MSG_CREATE_OAUTH_CLIENT_FAILED", "Failed to generate client secret")
+		}
+		secretHash = utils.HashToken(secret)
+	}
+
+	client := &domain.OAuthClient{
+		TenantID:     tenant.ID.String(),
+		ClientID:     clientID,
+		Name:         strings.TrimSpace(req.Name),
+		SecretHash:   secretHash,
+		RedirectURIs: strings.Join(redirectURIs, " "),
+		Public:       req.Public,
+	}
+	if err := u.oauthClientRepo.Create(ctx, client); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_CREATE_OAUTH_CLIENT_FAILED", "Failed to save client")
+	}
+
+	return &dto.OAuthClientCredentialsDTO{
+		ClientID:     client.ClientID,
+		ClientSecret: secret,
+		Name:         client.Name,
+		TenantID:     client.TenantID,
+		RedirectURIs: redirectURIs,
+		Public:       client.Public,
+		CreatedAt:    client.CreatedAt,
+	}, nil
+}
+
+// ListOAuthClients returns the tenant's clients, including revoked ones
+func (u *adminUseCase) ListOAuthClients(ctx context.Context, id string) ([]*domain.OAuthClient, *domainerrors.DomainError) {
+	tenant, derr := u.getExistingTenant(id)
+	if derr != nil {
+		return nil, derr
+	}
+
+	clients, err := u.oauthClientRepo.ListByTenant(ctx, tenant.ID.String())
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_LIST_OAUTH_CLIENTS_FAILED", "Failed to list clients")
+	}
+	return clients, nil
+}
+
+// RevokeOAuthClient stops a client from authenticating and signing users in
+func (u *adminUseCase) RevokeOAuthClient(ctx context.Context, id, clientID string) *domainerrors.DomainError {
+	tenant, derr := u.getExistingTenant(id)
+	if derr != nil {
+		return derr
+	}
+
+	client, err := u.oauthClientRepo.GetByClientID(ctx, clientID)
+	if err != nil {
+		return domainerrors.WrapInternal(err, "MSG_GET_OAUTH_CLIENT_FAILED", "Failed to get client")
+	}
+	if client == nil || client.TenantID != tenant.ID.String() {
+		return domainerrors.NewNotFoundError("MSG_OAUTH_CLIENT_NOT_FOUND", "Client")
+	}
+	if client.RevokedAt != nil {
+		return nil
+	}
+
+	if err := u.oauthClientRepo.Revoke(ctx, client.TenantID, client.ClientID, time.Now()); err != nil {
+		return domainerrors.WrapInternal(err, "MSG_REVOKE_OAUTH_CLIENT_FAILED", "Failed to revoke client")
+	}
+	return nil
+}
+
+// getExistingTenant parses the tenant ID and loads the tenant, failing if it does not exist
+func (u *adminUseCase) getExistingTenant(id string) (*domain.Tenant, *domainerrors.DomainError) {
+	tenantID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, domainerrors.NewValidationError(
+			"MSG_INVALID_TENANT_ID_FORMAT",
+			"Invalid tenant ID format",
+			map[string]string{
+				"field": "id",
+				"error": "Invalid UUID format",
+			},
+		)
+	}
+
+	tenant, err := u.tenantRepo.GetByID(tenantID)
+	if err != nil {
+		return nil, domainerrors.NewInternalError(
+			"MSG_GET_TENANT_FAILED",
+			"Failed to get tenant",
+		)
+	}
+	if tenant == nil {
+		return nil, domainerrors.NewNotFoundError(
+			"MSG_TENANT_NOT_FOUND",
+			"Tenant not found",
+		)
+	}
+
+	return tenant, nil
+}
+
 func (u *adminUseCase) CheckIdentifierAdmin(
 	ctx context.Context,
 	tenantID uuid.UUID,
@@ -396,6 +656,7 @@ func (u *adminUseCase) AddIdentifierAdmin(
 	ctx context.Context,
 	tenantID uuid.UUID,
 	req dto.AdminAddIdentifierPayloadDTO,
+	adminUsername string,
 ) (*dto.AdminAddIdentifierResponse, *domainerrors.DomainError) {
 	// 1. Infer & normalize both identifiers
 	exType, exIdentifier, derr := inferAndNormalizeIdentifier(req.ExistingIdentifier)
@@ -439,6 +700,14 @@ func (u *adminUseCase) AddIdentifierAdmin(
 	if exists {
 		return nil, domainerrors.NewConflictError("MSG_IDENTIFIER_ALREADY_EXISTS", "Identifier has already been registered", nil)
 	}
+	// An administrator releases a quarantined identifier explicitly rather than by adding it
+	quarantine, derr := activeIdentifierQuarantine(ctx, u.identifierQuarantineRepo, tenantID, newType, newIdentifier, globalUserID)
+	if derr != nil {
+		return nil, derr
+	}
+	if quarantine != nil {
+		return nil, identifierQuarantinedError(quarantine)
+	}
 
 	// 6. User must not already have identifier of this type
 	hasType, err := u.userIdentityRepo.ExistsByTenantGlobalUserIDAndType(ctx, tenantID.String(), globalUserID, newType)
@@ -463,20 +732,48 @@ func (u *adminUseCase) AddIdentifierAdmin(
 	}
 	newKratosUserID := newKratos.Id
 
-	// 8. Persist DB: attach identifier to same global_user_id
-	if ok, err := u.userIdentityRepo.InsertOnceByKratosUserAndType(
-		ctx, nil,
-		tenantID.String(), newKratosUserID, globalUserID,
-		newType, newIdentifier,
-	); err != nil {
+	// 8. Persist DB: attach identifier to same global_user_id and record who added it
+	var ok bool
+	if err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		ok, err = u.userIdentityRepo.InsertOnceByKratosUserAndType(
+			ctx, tx,
+			tenantID.String(), newKratosUserID, globalUserID,
+			newType, newIdentifier,
+		)
+		if err != nil || !ok {
+			return err
+		}
+		return u.changeLogRepo.Create(ctx, tx, &domain.UserIdentityChangeLog{
+			GlobalUserID: globalUserID,
+			TenantID:     tenantID.String(),
+			Action:       constants.IdentityChangeActionAdd,
+			IdentityType: newType,
+			NewValue:     newIdentifier,
+			Actor:        constants.IdentityChangeActorAdmin,
+			ActorID:      adminUsername,
+		})
+	}); err != nil {
 		return nil, domainerrors.WrapInternal(err, "MSG_SAVE_IDENTITY_FAILED", "Failed to save identifier")
 	} else if !ok {
 		// race-safe no-op (unique on (tenant_id, global_user_id, type))
 		return nil, domainerrors.NewConflictError("MSG_IDENTIFIER_TYPE_EXISTS",
 			fmt.Sprintf("User already has an identifier of type %s", newType), nil)
 	}
+	u.sessionCache.invalidateUser(globalUserID)
+
+	// 9. Tell the user, who did not ask for the change
+	if u.securityNotifier != nil {
+		u.securityNotifier.Notify(ctx, tenantID, types.SecurityNotice{
+			Event:          constants.SecurityEventIdentifierAdded,
+			GlobalUserID:   globalUserID,
+			IdentifierType: newType,
+			Identifier:     newIdentifier,
+			ByAdmin:        true,
+		})
+	}
 
-	// 9. Return response
+	// 10. Return response
 	return &dto.AdminAddIdentifierResponse{
 		GlobalUserID: globalUserID,
 		KratosUserID: newKratosUserID,
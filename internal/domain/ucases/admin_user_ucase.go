@@ -0,0 +1,450 @@
+package ucases
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+)
+
+// ListTenantUsers returns a page of the tenant's users, newest first
+func (u *adminUseCase) ListTenantUsers(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	query types.TenantUserQuery,
+) (*types.TenantUserPage, *domainerrors.DomainError) {
+	if _, derr := u.getExistingTenant(tenantID.String()); derr != nil {
+		return nil, derr
+	}
+
+	filter := domainrepo.TenantUserFilter{
+		TenantID:    tenantID.String(),
+		Identifier:  strings.ToLower(strings.TrimSpace(query.Identifier)),
+		CreatedFrom: query.CreatedFrom,
+		CreatedTo:   query.CreatedTo,
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		return nil, domainerrors.NewValidationError("MSG_INVALID_TIME_RANGE", "created_from must be before created_to", nil)
+	}
+	if query.Cursor != "" {
+		after, err := decodeTenantUserCursor(query.Cursor)
+		if err != nil {
+			return nil, domainerrors.NewValidationError("MSG_INVALID_CURSOR", "Invalid cursor", nil)
+		}
+		filter.After = after
+	}
+	limit := query.Limit
+	if limit < 1 {
+		limit = constants.TenantUserDefaultLimit
+	}
+	if limit > constants.TenantUserMaxLimit {
+		limit = constants.TenantUserMaxLimit
+	}
+
+	// One extra row tells whether there is a next page
+	users, err := u.userIdentityRepo.SearchTenantUsers(ctx, filter, limit+1)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_LIST_USERS_FAILED", "Failed to list users")
+	}
+	page := &types.TenantUserPage{Items: make([]*types.TenantUserSummary, 0, len(users))}
+	if len(users) > limit {
+		users = users[:limit]
+		page.NextCursor = encodeTenantUserCursor(users[limit-1])
+	}
+
+	globalUserIDs := make([]string, len(users))
+	for i, user := range users {
+		globalUserIDs[i] = user.GlobalUserID
+	}
+	identities, err := u.userIdentityRepo.ListByTenantAndGlobalUserIDs(ctx, tenantID.String(), globalUserIDs)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_LIST_USERS_FAILED", "Failed to list users")
+	}
+	identitiesByUser := make(map[string][]types.TenantUserIdentity, len(users))
+	for _, identity := range identities {
+		identitiesByUser[identity.GlobalUserID] = append(identitiesByUser[identity.GlobalUserID], toTenantUserIdentity(identity))
+	}
+
+	for _, user := range users {
+		page.Items = append(page.Items, &types.TenantUserSummary{
+			GlobalUserID: user.GlobalUserID,
+			Identities:   identitiesByUser[user.GlobalUserID],
+			CreatedAt:    user.CreatedAt,
+		})
+	}
+	return page, nil
+}
+
+// GetTenantUser returns one of the tenant's users with their language, the state of their
+// Kratos identities, their latest identity history and their latest logins
+func (u *adminUseCase) GetTenantUser(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+) (*types.TenantUserDetail, *domainerrors.DomainError) {
+	if _, derr := u.getExistingTenant(tenantID.String()); derr != nil {
+		return nil, derr
+	}
+	if _, err := uuid.Parse(globalUserID); err != nil {
+		return nil, domainerrors.NewNotFoundError("MSG_USER_NOT_FOUND", "User")
+	}
+
+	identities, err := u.userIdentityRepo.GetByGlobalUserIDAndTenantID(ctx, nil, globalUserID, tenantID.String())
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_IDENTIFIERS_FAILED", "Failed to get user identifiers")
+	}
+	if len(identities) == 0 {
+		return nil, domainerrors.NewNotFoundError("MSG_USER_NOT_FOUND", "User")
+	}
+
+	detail := &types.TenantUserDetail{
+		GlobalUserID:  globalUserID,
+		CreatedAt:     identities[0].CreatedAt,
+		Identities:    make([]types.TenantUserIdentity, 0, len(identities)),
+		RecentChanges: []*types.IdentityHistoryEntry{},
+	}
+	for _, identity := range identities {
+		if identity.CreatedAt.Before(detail.CreatedAt) {
+			detail.CreatedAt = identity.CreatedAt
+		}
+		item := toTenantUserIdentity(identity)
+		item.KratosState = u.kratosIdentityState(ctx, tenantID, identity)
+		detail.Identities = append(detail.Identities, item)
+	}
+
+	mapping, err := u.userIdentifierMappingRepo.GetByGlobalUserID(ctx, globalUserID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainerrors.WrapInternal(err, "MSG_MAPPING_NOT_FOUND", "Failed to load user mapping")
+	}
+	if mapping != nil {
+		detail.Lang = mapping.Lang
+	}
+
+	changes, _, err := u.changeLogRepo.Search(ctx, domainrepo.IdentityChangeLogFilter{
+		TenantID:     tenantID.String(),
+		GlobalUserID: globalUserID,
+	}, 0, constants.TenantUserRecentChanges)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_LIST_IDENTITY_HISTORY_FAILED", "Failed to list identity history")
+	}
+	for _, change := range changes {
+		detail.RecentChanges = append(detail.RecentChanges, toIdentityHistoryEntry(change))
+	}
+
+	logins, _, err := u.loginEventRepo.List(ctx, tenantID.String(), globalUserID, 0, constants.TenantUserRecentLogins)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_LIST_LOGIN_HISTORY_FAILED", "Failed to list login history")
+	}
+	detail.RecentLogins = toLoginEventResponses(logins)
+	return detail, nil
+}
+
+// ExportTenantUsers streams the tenant's users in ID order, loading one batch of users at a time
+// so that a large tenant is never held in memory
+func (u *adminUseCase) ExportTenantUsers(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	query types.TenantUserExportQuery,
+	w io.Writer,
+) *domainerrors.DomainError {
+	if _, derr := u.getExistingTenant(tenantID.String()); derr != nil {
+		return derr
+	}
+	var encoder tenantUserEncoder
+	switch query.Format {
+	case "", constants.BulkFormatCSV:
+		encoder = newTenantUserCSVEncoder(w)
+	case constants.BulkFormatNDJSON:
+		encoder = &tenantUserNDJSONEncoder{encoder: json.NewEncoder(w)}
+	default:
+		return domainerrors.NewValidationError("MSG_INVALID_EXPORT_FORMAT", "The export format must be csv or ndjson", nil)
+	}
+
+	after := ""
+	for {
+		globalUserIDs, err := u.userIdentityRepo.ListTenantUserIDs(ctx, tenantID.String(), after, constants.TenantUserExportBatchSize)
+		if err != nil {
+			return domainerrors.WrapInternal(err, "MSG_EXPORT_USERS_FAILED", "Failed to export users")
+		}
+		if len(globalUserIDs) == 0 {
+			break
+		}
+
+		records, err := u.tenantUserExportRecords(ctx, tenantID, globalUserIDs, query.Mask)
+		if err != nil {
+			return domainerrors.WrapInternal(err, "MSG_EXPORT_USERS_FAILED", "Failed to export users")
+		}
+		for _, record := range records {
+			if err := encoder.Encode(record); err != nil {
+				return domainerrors.WrapInternal(err, "MSG_WRITE_EXPORT_FAILED", "Failed to write the export")
+			}
+		}
+		if err := encoder.Flush(); err != nil {
+			return domainerrors.WrapInternal(err, "MSG_WRITE_EXPORT_FAILED", "Failed to write the export")
+		}
+
+		if len(globalUserIDs) < constants.TenantUserExportBatchSize {
+			break
+		}
+		after = globalUserIDs[len(globalUserIDs)-1]
+	}
+	return encoder.Flush()
+}
+
+// tenantUserExportRecords builds the export records of the users, in the order given
+func (u *adminUseCase) tenantUserExportRecords(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserIDs []string,
+	mask bool,
+) ([]*types.TenantUserExportRecord, error) {
+	identities, err := u.userIdentityRepo.ListByTenantAndGlobalUserIDs(ctx, tenantID.String(), globalUserIDs)
+	if err != nil {
+		return nil, err
+	}
+	mappings, err := u.userIdentifierMappingRepo.ListByGlobalUserIDs(ctx, globalUserIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make(map[string]*types.TenantUserExportRecord, len(globalUserIDs))
+	for _, globalUserID := range globalUserIDs {
+		records[globalUserID] = &types.TenantUserExportRecord{
+			GlobalUserID:  globalUserID,
+			Identifiers:   map[string]string{},
+			KratosUserIDs: map[string]string{},
+		}
+	}
+	for _, identity := range identities {
+		record := records[identity.GlobalUserID]
+		if record == nil {
+			continue
+		}
+		value := identity.Value
+		if mask {
+			value = maskIdentifier(identity.Type, value)
+		}
+		record.Identifiers[identity.Type] = value
+		record.KratosUserIDs[identity.Type] = identity.KratosUserID
+		if record.CreatedAt.IsZero() || identity.CreatedAt.Before(record.CreatedAt) {
+			record.CreatedAt = identity.CreatedAt
+		}
+	}
+	for _, mapping := range mappings {
+		if record := records[mapping.GlobalUserID]; record != nil {
+			record.Lang = mapping.Lang
+		}
+	}
+
+	ordered := make([]*types.TenantUserExportRecord, 0, len(globalUserIDs))
+	for _, globalUserID := range globalUserIDs {
+		ordered = append(ordered, records[globalUserID])
+	}
+	return ordered, nil
+}
+
+// kratosIdentityState returns the identity's state in Kratos, or "" when Kratos cannot return it
+func (u *adminUseCase) kratosIdentityState(ctx context.Context, tenantID uuid.UUID, identity *domain.UserIdentity) string {
+	kratosUserID, err := uuid.Parse(identity.KratosUserID)
+	if err != nil {
+		return ""
+	}
+	kratosIdentity, err := u.kratosService.GetIdentity(ctx, tenantID, kratosUserID)
+	if err != nil {
+		logger.GetLogger().Warnf("Failed to get Kratos identity %s: %v", identity.KratosUserID, err)
+		return ""
+	}
+	return string(kratosIdentity.GetState())
+}
+
+func toTenantUserIdentity(identity *domain.UserIdentity) types.TenantUserIdentity {
+	return types.TenantUserIdentity{
+		ID:           identity.ID,
+		Type:         identity.Type,
+		Value:        identity.Value,
+		KratosUserID: identity.KratosUserID,
+		CreatedAt:    identity.CreatedAt,
+		UpdatedAt:    identity.UpdatedAt,
+	}
+}
+
+// encodeTenantUserCursor points a listing at the users after user
+func encodeTenantUserCursor(user *domainrepo.TenantUser) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(user.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + user.GlobalUserID))
+}
+
+func decodeTenantUserCursor(cursor string) (*domainrepo.TenantUser, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+	createdAt, globalUserID, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, errors.New("malformed cursor")
+	}
+	at, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(globalUserID); err != nil {
+		return nil, err
+	}
+	return &domainrepo.TenantUser{GlobalUserID: globalUserID, CreatedAt: at}, nil
+}
+
+// tenantUserEncoder writes tenant user export records in one of the export formats
+type tenantUserEncoder interface {
+	Encode(record *types.TenantUserExportRecord) error
+	// Flush writes out anything the encoder buffered
+	Flush() *domainerrors.DomainError
+}
+
+// tenantUserCSVColumns are the columns of a CSV export. Identifiers other than email and phone
+// number go together in other_identifiers as type:value pairs, and kratos_user_ids lists the
+// Kratos identities in the same order as the identifiers.
+var tenantUserCSVColumns = []string{
+	"global_user_id", "email", "phone_number", "other_identifiers", "kratos_user_ids", "lang", "created_at",
+}
+
+type tenantUserCSVEncoder struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func newTenantUserCSVEncoder(w io.Writer) *tenantUserCSVEncoder {
+	return &tenantUserCSVEncoder{writer: csv.NewWriter(w)}
+}
+
+func (e *tenantUserCSVEncoder) Encode(record *types.TenantUserExportRecord) error {
+	if !e.headerWritten {
+		if err := e.writer.Write(tenantUserCSVColumns); err != nil {
+			return err
+		}
+		e.headerWritten = true
+	}
+
+	email := constants.IdentifierEmail.String()
+	phone := constants.IdentifierPhone.String()
+	otherTypes := make([]string, 0, len(record.Identifiers))
+	for idType := range record.Identifiers {
+		if idType != email && idType != phone {
+			otherTypes = append(otherTypes, idType)
+		}
+	}
+	slices.Sort(otherTypes)
+
+	others := make([]string, 0, len(otherTypes))
+	for _, idType := range otherTypes {
+		others = append(others, idType+":"+record.Identifiers[idType])
+	}
+	kratosUserIDs := make([]string, 0, len(record.KratosUserIDs))
+	for _, idType := range append([]string{email, phone}, otherTypes...) {
+		if id, ok := record.KratosUserIDs[idType]; ok {
+			kratosUserIDs = append(kratosUserIDs, id)
+		}
+	}
+
+	return e.writer.Write([]string{
+		record.GlobalUserID,
+		csvSafeCell(record.Identifiers[email]),
+		csvSafePhoneCell(record.Identifiers[phone]),
+		csvSafeCell(strings.Join(others, " ")),
+		strings.Join(kratosUserIDs, " "),
+		csvSafeCell(record.Lang),
+		record.CreatedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+// csvSafeCell quotes a cell a spreadsheet would otherwise run as a formula. Identifiers and
+// languages are user-supplied, so an export opened in a spreadsheet must not evaluate them.
+func csvSafeCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// csvSafePhoneCell leaves phone numbers as they are: an E.164 number, masked or not, is a plus
+// followed by digits and cannot run as a formula. Anything else in the column is quoted.
+func csvSafePhoneCell(value string) string {
+	if idType, _, derr := inferAndNormalizeIdentifier(value); derr == nil && idType == constants.IdentifierPhone.String() {
+		return value
+	}
+	if digits, ok := strings.CutPrefix(value, "+"); ok && digits != "" && strings.Trim(digits, "0123456789*") == "" {
+		return value
+	}
+	return csvSafeCell(value)
+}
+
+// Flush writes the header of an empty export too, so the file always names its columns
+func (e *tenantUserCSVEncoder) Flush() *domainerrors.DomainError {
+	if !e.headerWritten {
+		if err := e.writer.Write(tenantUserCSVColumns); err != nil {
+			return domainerrors.WrapInternal(err, "MSG_WRITE_EXPORT_FAILED", "Failed to write the export")
+		}
+		e.headerWritten = true
+	}
+	e.writer.Flush()
+	if err := e.writer.Error(); err != nil {
+		return domainerrors.WrapInternal(err, "MSG_WRITE_EXPORT_FAILED", "Failed to write the export")
+	}
+	return nil
+}
+
+type tenantUserNDJSONEncoder struct {
+	encoder *json.Encoder
+}
+
+func (e *tenantUserNDJSONEncoder) Encode(record *types.TenantUserExportRecord) error {
+	return e.encoder.Encode(record)
+}
+
+func (e *tenantUserNDJSONEncoder) Flush() *domainerrors.DomainError {
+	return nil
+}
+
+// maskIdentifier redacts email addresses and phone numbers, keeping just enough to tell them
+// apart: the first character and domain of an email address and the last digits of a number
+func maskIdentifier(idType, value string) string {
+	switch idType {
+	case constants.IdentifierEmail.String():
+		local, domainPart, ok := strings.Cut(value, "@")
+		if !ok || local == "" {
+			return "***"
+		}
+		first, _ := utf8.DecodeRuneInString(local)
+		return string(first) + "***@" + domainPart
+	case constants.IdentifierPhone.String():
+		const visibleDigits = 3
+		masked := []byte(value)
+		visible := 0
+		for i := len(masked) - 1; i >= 0; i-- {
+			if masked[i] < '0' || masked[i] > '9' {
+				continue
+			}
+			if visible < visibleDigits {
+				visible++
+				continue
+			}
+			masked[i] = '*'
+		}
+		return string(masked)
+	}
+	return value
+}
@@ -0,0 +1,261 @@
+package ucases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	mock_repositories "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/repositories"
+	mock_services "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/services"
+)
+
+func TestListTenantUsers_FollowsTheCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	now := time.Now().UTC()
+	first := &domainrepo.TenantUser{GlobalUserID: uuid.NewString(), CreatedAt: now}
+	second := &domainrepo.TenantUser{GlobalUserID: uuid.NewString(), CreatedAt: now.Add(-time.Hour)}
+	third := &domainrepo.TenantUser{GlobalUserID: uuid.NewString(), CreatedAt: now.Add(-2 * time.Hour)}
+
+	tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+	tenantRepo.EXPECT().GetByID(tenantID).Return(&domain.Tenant{ID: tenantID}, nil).Times(2)
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	u := &adminUseCase{tenantRepo: tenantRepo, userIdentityRepo: identityRepo}
+
+	identityRepo.EXPECT().SearchTenantUsers(ctx, domainrepo.TenantUserFilter{
+		TenantID:   tenantID.String(),
+		Identifier: "alice",
+	}, 3).Return([]*domainrepo.TenantUser{first, second, third}, nil)
+	identityRepo.EXPECT().ListByTenantAndGlobalUserIDs(ctx, tenantID.String(), []string{first.GlobalUserID, second.GlobalUserID}).
+		Return([]*domain.UserIdentity{
+			{GlobalUserID: first.GlobalUserID, Type: constants.IdentifierEmail.String(), Value: "alice@example.com"},
+			{GlobalUserID: first.GlobalUserID, Type: constants.IdentifierPhone.String(), Value: "+84901234567"},
+			{GlobalUserID: second.GlobalUserID, Type: constants.IdentifierEmail.String(), Value: "alice.b@example.com"},
+		}, nil)
+
+	page, derr := u.ListTenantUsers(ctx, tenantID, types.TenantUserQuery{Identifier: "  Alice ", Limit: 2})
+	require.Nil(t, derr)
+	require.Len(t, page.Items, 2)
+	assert.Equal(t, first.GlobalUserID, page.Items[0].GlobalUserID)
+	assert.Len(t, page.Items[0].Identities, 2)
+	assert.Len(t, page.Items[1].Identities, 1)
+	require.NotEmpty(t, page.NextCursor)
+
+	// The cursor resumes after the last user of the previous page
+	identityRepo.EXPECT().SearchTenantUsers(ctx, gomock.Any(), 3).
+		DoAndReturn(func(_ context.Context, filter domainrepo.TenantUserFilter, _ int) ([]*domainrepo.TenantUser, error) {
+			require.NotNil(t, filter.After)
+			assert.Equal(t, second.GlobalUserID, filter.After.GlobalUserID)
+			assert.True(t, second.CreatedAt.Equal(filter.After.CreatedAt))
+			return []*domainrepo.TenantUser{third}, nil
+		})
+	identityRepo.EXPECT().ListByTenantAndGlobalUserIDs(ctx, tenantID.String(), []string{third.GlobalUserID}).Return(nil, nil)
+
+	page, derr = u.ListTenantUsers(ctx, tenantID, types.TenantUserQuery{Identifier: "alice", Cursor: page.NextCursor, Limit: 2})
+	require.Nil(t, derr)
+	require.Len(t, page.Items, 1)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestListTenantUsers_RejectsInvalidQueries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := uuid.New()
+	tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+	tenantRepo.EXPECT().GetByID(tenantID).Return(&domain.Tenant{ID: tenantID}, nil).AnyTimes()
+	u := &adminUseCase{tenantRepo: tenantRepo}
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	for code, query := range map[string]types.TenantUserQuery{
+		"MSG_INVALID_TIME_RANGE": {CreatedFrom: &now, CreatedTo: &earlier},
+		"MSG_INVALID_CURSOR":     {Cursor: "not-a-cursor"},
+	} {
+		page, derr := u.ListTenantUsers(context.Background(), tenantID, query)
+		assert.Nil(t, page)
+		require.NotNil(t, derr, code)
+		assert.Equal(t, code, derr.Code)
+	}
+}
+
+func TestGetTenantUser_JoinsIdentitiesLanguageAndHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	globalUserID := uuid.NewString()
+	now := time.Now()
+
+	tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+	tenantRepo.EXPECT().GetByID(tenantID).Return(&domain.Tenant{ID: tenantID}, nil).Times(2)
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	mappingRepo := mock_repositories.NewMockUserIdentifierMappingRepository(ctrl)
+	changeLogRepo := mock_repositories.NewMockUserIdentityChangeLogRepository(ctrl)
+	loginEventRepo := mock_repositories.NewMockLoginEventRepository(ctrl)
+	kratos := mock_services.NewMockKratosService(ctrl)
+	u := &adminUseCase{
+		tenantRepo:                tenantRepo,
+		userIdentityRepo:          identityRepo,
+		userIdentifierMappingRepo: mappingRepo,
+		changeLogRepo:             changeLogRepo,
+		loginEventRepo:            loginEventRepo,
+		kratosService:             kratos,
+	}
+
+	identityRepo.EXPECT().GetByGlobalUserIDAndTenantID(ctx, nil, globalUserID, tenantID.String()).Return([]*domain.UserIdentity{
+		{ID: "identity-1", GlobalUserID: globalUserID, KratosUserID: uuid.NewString(), Type: constants.IdentifierPhone.String(), CreatedAt: now},
+		{ID: "identity-2", GlobalUserID: globalUserID, KratosUserID: uuid.NewString(), Type: constants.IdentifierEmail.String(), CreatedAt: now.Add(-time.Hour)},
+	}, nil)
+	kratos.EXPECT().GetIdentity(ctx, tenantID, gomock.Any()).Return(nil, errors.New("kratos unavailable")).Times(2)
+	mappingRepo.EXPECT().GetByGlobalUserID(ctx, globalUserID).Return(&domain.UserIdentifierMapping{Lang: "vi"}, nil)
+	changeLogRepo.EXPECT().Search(ctx, domainrepo.IdentityChangeLogFilter{TenantID: tenantID.String(), GlobalUserID: globalUserID}, 0, constants.TenantUserRecentChanges).
+		Return([]*domain.UserIdentityChangeLog{{ID: "log-1", Action: constants.IdentityChangeActionAdd}}, int64(1), nil)
+	loginEventRepo.EXPECT().List(ctx, tenantID.String(), globalUserID, 0, constants.TenantUserRecentLogins).
+		Return([]*domain.LoginEvent{{ID: "login-1", Flow: constants.ChallengeTypeLogin, Country: "VN"}}, int64(1), nil)
+
+	detail, derr := u.GetTenantUser(ctx, tenantID, globalUserID)
+	require.Nil(t, derr)
+	assert.Equal(t, "vi", detail.Lang)
+	assert.True(t, detail.CreatedAt.Equal(now.Add(-time.Hour)), "a user joined with their first identity")
+	require.Len(t, detail.Identities, 2)
+	assert.Empty(t, detail.Identities[0].KratosState, "an unreachable Kratos does not hide the user")
+	require.Len(t, detail.RecentChanges, 1)
+	require.Len(t, detail.RecentLogins, 1)
+	assert.Equal(t, "VN", detail.RecentLogins[0].Country)
+
+	identityRepo.EXPECT().GetByGlobalUserIDAndTenantID(ctx, nil, gomock.Any(), tenantID.String()).Return(nil, nil)
+	detail, derr = u.GetTenantUser(ctx, tenantID, uuid.NewString())
+	assert.Nil(t, detail)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_USER_NOT_FOUND", derr.Code)
+}
+
+func TestExportTenantUsers_StreamsMaskedCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	joined := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	alice, bob := "11111111-1111-1111-1111-111111111111", "22222222-2222-2222-2222-222222222222"
+
+	tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+	tenantRepo.EXPECT().GetByID(tenantID).Return(&domain.Tenant{ID: tenantID}, nil)
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	mappingRepo := mock_repositories.NewMockUserIdentifierMappingRepository(ctrl)
+	u := &adminUseCase{tenantRepo: tenantRepo, userIdentityRepo: identityRepo, userIdentifierMappingRepo: mappingRepo}
+
+	identityRepo.EXPECT().ListTenantUserIDs(ctx, tenantID.String(), "", constants.TenantUserExportBatchSize).
+		Return([]string{alice, bob}, nil)
+	identityRepo.EXPECT().ListByTenantAndGlobalUserIDs(ctx, tenantID.String(), []string{alice, bob}).
+		Return([]*domain.UserIdentity{
+			{GlobalUserID: alice, Type: constants.IdentifierEmail.String(), Value: "alice@example.com", KratosUserID: "k-1", CreatedAt: joined},
+			{GlobalUserID: alice, Type: constants.IdentifierPhone.String(), Value: "+84901234567", KratosUserID: "k-2", CreatedAt: joined.Add(time.Hour)},
+			{GlobalUserID: bob, Type: constants.IdentifierWallet.String(), Value: "0xabc", KratosUserID: "k-3", CreatedAt: joined},
+		}, nil)
+	mappingRepo.EXPECT().ListByGlobalUserIDs(ctx, []string{alice, bob}).
+		Return([]*domain.UserIdentifierMapping{{GlobalUserID: alice, Lang: "vi"}}, nil)
+
+	var out strings.Builder
+	derr := u.ExportTenantUsers(ctx, tenantID, types.TenantUserExportQuery{Format: constants.BulkFormatCSV, Mask: true}, &out)
+	require.Nil(t, derr)
+	assert.Equal(t, strings.Join([]string{
+		"global_user_id,email,phone_number,other_identifiers,kratos_user_ids,lang,created_at",
+		alice + ",a***@example.com,+********567,,k-1 k-2,vi,2025-03-01T08:00:00Z",
+		bob + ",,,wallet:0xabc,k-3,,2025-03-01T08:00:00Z",
+		"",
+	}, "\n"), out.String())
+}
+
+func TestExportTenantUsers_QuotesFormulaCells(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	joined := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	alice, bob := "11111111-1111-1111-1111-111111111111", "22222222-2222-2222-2222-222222222222"
+
+	tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+	tenantRepo.EXPECT().GetByID(tenantID).Return(&domain.Tenant{ID: tenantID}, nil)
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	mappingRepo := mock_repositories.NewMockUserIdentifierMappingRepository(ctrl)
+	u := &adminUseCase{tenantRepo: tenantRepo, userIdentityRepo: identityRepo, userIdentifierMappingRepo: mappingRepo}
+
+	identityRepo.EXPECT().ListTenantUserIDs(ctx, tenantID.String(), "", constants.TenantUserExportBatchSize).
+		Return([]string{alice, bob}, nil)
+	identityRepo.EXPECT().ListByTenantAndGlobalUserIDs(ctx, tenantID.String(), []string{alice, bob}).
+		Return([]*domain.UserIdentity{
+			{GlobalUserID: alice, Type: constants.IdentifierEmail.String(), Value: "=cmd@example.com", KratosUserID: "k-1", CreatedAt: joined},
+			{GlobalUserID: alice, Type: constants.IdentifierPhone.String(), Value: "+84901234567", KratosUserID: "k-2", CreatedAt: joined},
+			{GlobalUserID: bob, Type: constants.IdentifierPhone.String(), Value: "+SUM(1,2)", KratosUserID: "k-3", CreatedAt: joined},
+			{GlobalUserID: bob, Type: constants.IdentifierWallet.String(), Value: "0xabc", KratosUserID: "k-4", CreatedAt: joined},
+		}, nil)
+	mappingRepo.EXPECT().ListByGlobalUserIDs(ctx, []string{alice, bob}).
+		Return([]*domain.UserIdentifierMapping{{GlobalUserID: alice, Lang: "@vi"}, {GlobalUserID: bob, Lang: "\rvi"}}, nil)
+
+	var out strings.Builder
+	derr := u.ExportTenantUsers(ctx, tenantID, types.TenantUserExportQuery{Format: constants.BulkFormatCSV}, &out)
+	require.Nil(t, derr)
+	// A valid phone number is left as it is; a malformed one is quoted like any free text
+	assert.Equal(t, strings.Join([]string{
+		"global_user_id,email,phone_number,other_identifiers,kratos_user_ids,lang,created_at",
+		alice + ",'=cmd@example.com,+84901234567,,k-1 k-2,'@vi,2025-03-01T08:00:00Z",
+		bob + ",,\"'+SUM(1,2)\",wallet:0xabc,k-3 k-4,\"'\rvi\",2025-03-01T08:00:00Z",
+		"",
+	}, "\n"), out.String())
+}
+
+func TestExportTenantUsers_PagesThroughNDJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+	tenantRepo.EXPECT().GetByID(tenantID).Return(&domain.Tenant{ID: tenantID}, nil).Times(2)
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	mappingRepo := mock_repositories.NewMockUserIdentifierMappingRepository(ctrl)
+	u := &adminUseCase{tenantRepo: tenantRepo, userIdentityRepo: identityRepo, userIdentifierMappingRepo: mappingRepo}
+
+	firstBatch := make([]string, constants.TenantUserExportBatchSize)
+	for i := range firstBatch {
+		firstBatch[i] = uuid.NewString()
+	}
+	last := uuid.NewString()
+	identityRepo.EXPECT().ListTenantUserIDs(ctx, tenantID.String(), "", constants.TenantUserExportBatchSize).Return(firstBatch, nil)
+	identityRepo.EXPECT().ListTenantUserIDs(ctx, tenantID.String(), firstBatch[len(firstBatch)-1], constants.TenantUserExportBatchSize).
+		Return([]string{last}, nil)
+	identityRepo.EXPECT().ListByTenantAndGlobalUserIDs(ctx, tenantID.String(), gomock.Any()).Return(nil, nil).Times(2)
+	mappingRepo.EXPECT().ListByGlobalUserIDs(ctx, gomock.Any()).Return(nil, nil).Times(2)
+
+	var out strings.Builder
+	derr := u.ExportTenantUsers(ctx, tenantID, types.TenantUserExportQuery{Format: constants.BulkFormatNDJSON}, &out)
+	require.Nil(t, derr)
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, constants.TenantUserExportBatchSize+1)
+	assert.Contains(t, lines[len(lines)-1], `"global_user_id":"`+last+`"`)
+
+	out.Reset()
+	derr = u.ExportTenantUsers(ctx, tenantID, types.TenantUserExportQuery{Format: "xlsx"}, &out)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_INVALID_EXPORT_FORMAT", derr.Code)
+	assert.Empty(t, out.String())
+}
@@ -125,6 +125,18 @@ func shouldDefaultToWebhook() bool {
 	return env == "DEV" || env == "DEVELOPMENT" || env == constants.NightlyEnvironment
 }
 
+// defaultSMSChannel is the channel of a receiver who did not choose one
+func defaultSMSChannel(receiver string) string {
+	// In DEV/NIGHTLY, keep using webhook by default
+	if shouldDefaultToWebhook() {
+		return constants.DefaultSMSChannel
+	}
+	if isVietnamesePhone(receiver) && shouldRouteToSpeedSMS() {
+		return constants.ChannelSpeedSMS
+	}
+	return constants.ChannelSMS
+}
+
 func (u *courierUseCase) GetChannel(ctx context.Context, tenantName, receiver string) (types.ChooseChannelResponse, *domainerrors.DomainError) {
 	key := &cachingtypes.Keyer{
 		Raw: fmt.Sprintf("channel:%s:%s", tenantName, receiver),
@@ -137,16 +149,7 @@ func (u *courierUseCase) GetChannel(ctx context.Context, tenantName, receiver st
 	if err != nil {
 		// fallback to SMS routing if cache miss
 		if errors.Is(err, cachingtypes.ErrCacheMiss) {
-			// In DEV/NIGHTLY, keep using webhook by default
-			if shouldDefaultToWebhook() {
-				return types.ChooseChannelResponse{Channel: constants.DefaultSMSChannel}, nil
-			}
-
-			defaultChannel := constants.ChannelSMS
-			if isVietnamesePhone(receiver) && shouldRouteToSpeedSMS() {
-				defaultChannel = constants.ChannelSpeedSMS
-			}
-			return types.ChooseChannelResponse{Channel: defaultChannel}, nil
+			return types.ChooseChannelResponse{Channel: defaultSMSChannel(receiver)}, nil
 		}
 		return types.ChooseChannelResponse{}, domainerrors.NewInternalError("MSG_GET_CHANNEL_FAILED", "Failed to get channel from cache").WithCause(err)
 	}
@@ -261,6 +264,25 @@ func (u *courierUseCase) DeliverOTP(ctx context.Context, tenantName, receiver st
 	return nil // delivery success
 }
 
+// DeliverMessage sends a message as written to a phone through the channel the receiver chose.
+// Zalo only delivers approved templates, so its receivers get the message by SMS. Nothing is
+// queued for retry: a notice that arrives late is of little use.
+func (u *courierUseCase) DeliverMessage(ctx context.Context, tenantName, receiver, message string) *domainerrors.DomainError {
+	channel, usecaseErr := u.GetChannel(ctx, tenantName, receiver)
+	if usecaseErr != nil {
+		return usecaseErr
+	}
+	if channel.Channel == "" || channel.Channel == constants.ChannelZalo {
+		channel.Channel = defaultSMSChannel(receiver)
+	}
+
+	if err := u.smsProvider.SendMessage(ctx, tenantName, receiver, channel.Channel, message); err != nil {
+		logger.GetLogger().Errorf("Failed to deliver message to %s via %s: %v", receiver, channel.Channel, err)
+		return domainerrors.NewInternalError("MSG_DELIVER_FAILED", "Failed to deliver message").WithCause(err)
+	}
+	return nil
+}
+
 func (u *courierUseCase) RetryFailedOTPs(ctx context.Context, now time.Time) (int, *domainerrors.DomainError) {
 	tasks, err := u.queue.GetDueRetryTasks(ctx, now)
 	if err != nil {
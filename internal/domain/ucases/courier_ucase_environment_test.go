@@ -2,6 +2,7 @@ package ucases
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -327,3 +328,36 @@ func TestCourierUseCase_ChooseChannel_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+// TestCourierUseCase_DeliverMessage_ZaloFallsBackToSMS ensures messages that are not OTPs reach
+// Zalo receivers through the SMS routing, since Zalo only delivers approved templates
+func TestCourierUseCase_DeliverMessage_ZaloFallsBackToSMS(t *testing.T) {
+	originalEnv := conf.GetEnvironment()
+	defer conf.SetEnvironmentForTesting(originalEnv)
+	conf.SetEnvironmentForTesting(constants.StagingEnvironment)
+
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSMSProvider := mock_services.NewMockSMSProvider(ctrl)
+	inMemCache := caching.NewCachingRepository(
+		context.Background(),
+		caching.NewGoCacheClient(cache.New(5*time.Minute, 10*time.Minute)),
+	)
+	courierUseCase := NewCourierUseCase(mock_otpqueue.NewMockOTPQueueRepository(ctrl), mockSMSProvider, inMemCache)
+
+	require.Nil(t, courierUseCase.ChooseChannel(ctx, constants.TenantGenetica, "+84987654321", constants.ChannelZalo))
+	mockSMSProvider.EXPECT().
+		SendMessage(ctx, constants.TenantGenetica, "+84987654321", constants.ChannelSpeedSMS, "notice").
+		Return(nil)
+	require.Nil(t, courierUseCase.DeliverMessage(ctx, constants.TenantGenetica, "+84987654321", "notice"))
+
+	require.Nil(t, courierUseCase.ChooseChannel(ctx, constants.TenantLifeAI, "+12025551234", constants.ChannelWhatsApp))
+	mockSMSProvider.EXPECT().
+		SendMessage(ctx, constants.TenantLifeAI, "+12025551234", constants.ChannelWhatsApp, "notice").
+		Return(errors.New("whatsapp is down"))
+	derr := courierUseCase.DeliverMessage(ctx, constants.TenantLifeAI, "+12025551234", "notice")
+	require.NotNil(t, derr)
+	require.Equal(t, "MSG_DELIVER_FAILED", derr.Code)
+}
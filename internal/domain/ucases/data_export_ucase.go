@@ -0,0 +1,533 @@
+package ucases
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	ratelimiters "github.com/lifenetwork-ai/iam-service/infrastructures/rate_limiter/types"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+	"github.com/lifenetwork-ai/iam-service/packages/utils"
+)
+
+type dataExportUseCase struct {
+	rateLimiter               ratelimiters.RateLimiter
+	dataExportRepo            domainrepo.UserDataExportRepository
+	userIdentityRepo          domainrepo.UserIdentityRepository
+	userIdentifierMappingRepo domainrepo.UserIdentifierMappingRepository
+	changeLogRepo             domainrepo.UserIdentityChangeLogRepository
+	loginEventRepo            domainrepo.LoginEventRepository
+	userSessionRepo           domainrepo.UserSessionRepository
+	sessionRefreshTokenRepo   domainrepo.SessionRefreshTokenRepository
+	trustedDeviceRepo         domainrepo.TrustedDeviceRepository
+	userPasskeyRepo           domainrepo.UserPasskeyRepository
+	userMFARepo               domainrepo.UserMFARepository
+	kratosService             domainservice.KratosService
+	ketoService               domainservice.KetoService
+}
+
+func NewDataExportUseCase(
+	rateLimiter ratelimiters.RateLimiter,
+	dataExportRepo domainrepo.UserDataExportRepository,
+	userIdentityRepo domainrepo.UserIdentityRepository,
+	userIdentifierMappingRepo domainrepo.UserIdentifierMappingRepository,
+	changeLogRepo domainrepo.UserIdentityChangeLogRepository,
+	loginEventRepo domainrepo.LoginEventRepository,
+	userSessionRepo domainrepo.UserSessionRepository,
+	sessionRefreshTokenRepo domainrepo.SessionRefreshTokenRepository,
+	trustedDeviceRepo domainrepo.TrustedDeviceRepository,
+	userPasskeyRepo domainrepo.UserPasskeyRepository,
+	userMFARepo domainrepo.UserMFARepository,
+	kratosService domainservice.KratosService,
+	ketoService domainservice.KetoService,
+) interfaces.DataExportUseCase {
+	return &dataExportUseCase{
+		rateLimiter:               rateLimiter,
+		dataExportRepo:            dataExportRepo,
+		userIdentityRepo:          userIdentityRepo,
+		userIdentifierMappingRepo: userIdentifierMappingRepo,
+		changeLogRepo:             changeLogRepo,
+		loginEventRepo:            loginEventRepo,
+		userSessionRepo:           userSessionRepo,
+		sessionRefreshTokenRepo:   sessionRefreshTokenRepo,
+		trustedDeviceRepo:         trustedDeviceRepo,
+		userPasskeyRepo:           userPasskeyRepo,
+		userMFARepo:               userMFARepo,
+		kratosService:             kratosService,
+		ketoService:               ketoService,
+	}
+}
+
+// RequestDataExport queues an export of the user's data. While one is being generated, it is
+// returned instead of queueing another.
+func (u *dataExportUseCase) RequestDataExport(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+) (*types.DataExportResponse, *domainerrors.DomainError) {
+	if conf.GetDataExportLinkSecret() == "" {
+		return nil, domainerrors.WrapInternal(errors.New("data export link secret is empty"), "MSG_DATA_EXPORT_NOT_CONFIGURED", "Data exports are not configured")
+	}
+
+	key := fmt.Sprintf("data_export:%s:%s", globalUserID, tenantID.String())
+	if err := utils.CheckRateLimitDomain(u.rateLimiter, key, constants.MaxAttemptsPerWindow, constants.RateLimitWindow); err != nil {
+		return nil, domainerrors.NewRateLimitError("MSG_RATE_LIMIT_EXCEEDED", "Rate limit exceeded", err)
+	}
+
+	pending, err := u.dataExportRepo.GetPending(ctx, tenantID.String(), globalUserID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_DATA_EXPORT_FAILED", "Failed to get data export")
+	}
+	if pending != nil {
+		return toDataExportResponse(pending, time.Now()), nil
+	}
+
+	export := &domain.UserDataExport{
+		TenantID:     tenantID.String(),
+		GlobalUserID: globalUserID,
+		ScheduledFor: time.Now(),
+	}
+	if err := u.dataExportRepo.Create(ctx, export); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_CREATE_DATA_EXPORT_FAILED", "Failed to request data export")
+	}
+	return toDataExportResponse(export, time.Now()), nil
+}
+
+// GetDataExport returns one of the user's exports, with a fresh download link once it is ready
+func (u *dataExportUseCase) GetDataExport(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID, exportID string,
+) (*types.DataExportResponse, *domainerrors.DomainError) {
+	export, derr := u.getExport(ctx, exportID)
+	if derr != nil {
+		return nil, derr
+	}
+	if export.TenantID != tenantID.String() || export.GlobalUserID != globalUserID {
+		return nil, domainerrors.NewNotFoundError("MSG_DATA_EXPORT_NOT_FOUND", "Data export")
+	}
+	return toDataExportResponse(export, time.Now()), nil
+}
+
+// DownloadDataExport returns the export a signed download link points to. The link is the only
+// credential, so it is checked before the export is looked up.
+func (u *dataExportUseCase) DownloadDataExport(
+	ctx context.Context,
+	exportID string,
+	expires int64,
+	signature string,
+) ([]byte, *domainerrors.DomainError) {
+	secret := conf.GetDataExportLinkSecret()
+	if secret == "" {
+		return nil, domainerrors.WrapInternal(errors.New("data export link secret is empty"), "MSG_DATA_EXPORT_NOT_CONFIGURED", "Data exports are not configured")
+	}
+	expected := dataExportLinkSignature(secret, exportID, expires)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return nil, domainerrors.NewUnauthorizedError("MSG_INVALID_DOWNLOAD_LINK", "Invalid download link")
+	}
+	if time.Now().Unix() > expires {
+		return nil, domainerrors.NewUnauthorizedError("MSG_DOWNLOAD_LINK_EXPIRED", "Download link has expired")
+	}
+
+	export, derr := u.getExport(ctx, exportID)
+	if derr != nil {
+		return nil, derr
+	}
+	if export.CompletedAt == nil || export.ExpiresAt == nil || !time.Now().Before(*export.ExpiresAt) {
+		return nil, domainerrors.NewNotFoundError("MSG_DATA_EXPORT_NOT_FOUND", "Data export")
+	}
+
+	key, derr := dataExportEncryptionKey()
+	if derr != nil {
+		return nil, derr
+	}
+	payload, err := utils.Decrypt(key, export.Payload)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_DECRYPT_DATA_EXPORT_FAILED", "Failed to read data export")
+	}
+	return []byte(payload), nil
+}
+
+func (u *dataExportUseCase) getExport(ctx context.Context, exportID string) (*domain.UserDataExport, *domainerrors.DomainError) {
+	if _, err := uuid.Parse(exportID); err != nil {
+		return nil, domainerrors.NewNotFoundError("MSG_DATA_EXPORT_NOT_FOUND", "Data export")
+	}
+	export, err := u.dataExportRepo.GetByID(ctx, exportID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_DATA_EXPORT_FAILED", "Failed to get data export")
+	}
+	if export == nil {
+		return nil, domainerrors.NewNotFoundError("MSG_DATA_EXPORT_NOT_FOUND", "Data export")
+	}
+	return export, nil
+}
+
+// ProcessPendingDataExports deletes expired exports and generates the pending ones, returning
+// how many were generated
+func (u *dataExportUseCase) ProcessPendingDataExports(ctx context.Context) (int, *domainerrors.DomainError) {
+	now := time.Now()
+	if deleted, err := u.dataExportRepo.DeleteExpired(ctx, now); err != nil {
+		logger.GetLogger().Errorf("Failed to delete expired data exports: %v", err)
+	} else if deleted > 0 {
+		logger.GetLogger().Infof("Deleted %d expired data exports", deleted)
+	}
+
+	due, err := u.dataExportRepo.ListDue(ctx, now, constants.DataExportBatchSize)
+	if err != nil {
+		return 0, domainerrors.WrapInternal(err, "MSG_LIST_DATA_EXPORTS_FAILED", "Failed to list pending data exports")
+	}
+
+	generated := 0
+	for _, export := range due {
+		if !claimJob("data export", export.ID, func() (bool, error) {
+			return u.dataExportRepo.Claim(ctx, export.ID, now, now.Add(constants.DataExportRetryDelay))
+		}) {
+			continue
+		}
+
+		if err := u.generate(ctx, export); err != nil {
+			u.recordFailure(ctx, export, err)
+			continue
+		}
+		generated++
+	}
+	return generated, nil
+}
+
+func (u *dataExportUseCase) generate(ctx context.Context, export *domain.UserDataExport) error {
+	document, err := u.buildDocument(ctx, export.GlobalUserID)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(document)
+	if err != nil {
+		return fmt.Errorf("encode export: %w", err)
+	}
+
+	key, derr := dataExportEncryptionKey()
+	if derr != nil {
+		return derr
+	}
+	encrypted, err := utils.Encrypt(key, string(payload))
+	if err != nil {
+		return fmt.Errorf("encrypt export: %w", err)
+	}
+
+	now := time.Now()
+	if err := u.dataExportRepo.Complete(ctx, export.ID, encrypted, now, now.Add(constants.DataExportRetention)); err != nil {
+		return fmt.Errorf("store export: %w", err)
+	}
+	return nil
+}
+
+// recordFailure schedules the export for another attempt, giving up after DataExportMaxAttempts
+func (u *dataExportUseCase) recordFailure(ctx context.Context, export *domain.UserDataExport, cause error) {
+	recordJobFailure("data export", export.ID, export.Attempts, constants.DataExportMaxAttempts, cause,
+		func(lastError string) error {
+			return u.dataExportRepo.RecordFailure(ctx, export.ID, lastError)
+		},
+		func(lastError string) error {
+			now := time.Now()
+			return u.dataExportRepo.Fail(ctx, export.ID, lastError, now, now.Add(constants.DataExportRetention))
+		},
+	)
+}
+
+// buildDocument gathers what this service, Kratos and Keto store about the user, in every tenant
+func (u *dataExportUseCase) buildDocument(ctx context.Context, globalUserID string) (*types.UserDataExportDocument, error) {
+	gid, err := uuid.Parse(globalUserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid global user id: %w", err)
+	}
+	document := &types.UserDataExportDocument{
+		GlobalUserID:     globalUserID,
+		GeneratedAt:      time.Now().UTC(),
+		Identities:       []types.ExportedIdentity{},
+		IdentityChanges:  []types.ExportedIdentityChange{},
+		KratosIdentities: []types.ExportedKratosIdentity{},
+		RelationTuples:   []types.RelationTuple{},
+		LoginHistory:     []types.ExportedLoginEvent{},
+		Sessions:         []types.ExportedSession{},
+		RefreshTokens:    []types.ExportedRefreshToken{},
+		TrustedDevices:   []types.ExportedTrustedDevice{},
+		Passkeys:         []types.ExportedPasskey{},
+		MFAFactors:       []types.ExportedMFAFactor{},
+	}
+
+	mapping, err := u.userIdentifierMappingRepo.GetByGlobalUserID(ctx, globalUserID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("get identifier mapping: %w", err)
+	}
+	if mapping != nil {
+		document.Lang = mapping.Lang
+	}
+
+	identities, err := u.userIdentityRepo.ListByGlobalUserID(ctx, nil, globalUserID)
+	if err != nil {
+		return nil, fmt.Errorf("list identities: %w", err)
+	}
+	exported := make(map[string]bool, len(identities))
+	var tenantIDs []string
+	for _, identity := range identities {
+		document.Identities = append(document.Identities, types.ExportedIdentity{
+			TenantID:     identity.TenantID,
+			Type:         identity.Type,
+			Value:        identity.Value,
+			KratosUserID: identity.KratosUserID,
+			CreatedAt:    identity.CreatedAt,
+			UpdatedAt:    identity.UpdatedAt,
+		})
+
+		key := identity.TenantID + "/" + identity.KratosUserID
+		if exported[key] {
+			continue
+		}
+		exported[key] = true
+		kratosIdentity, err := u.kratosIdentity(ctx, identity)
+		if err != nil {
+			return nil, err
+		}
+		document.KratosIdentities = append(document.KratosIdentities, *kratosIdentity)
+		if err := u.addRefreshTokens(ctx, document, identity); err != nil {
+			return nil, err
+		}
+		if !slices.Contains(tenantIDs, identity.TenantID) {
+			tenantIDs = append(tenantIDs, identity.TenantID)
+		}
+	}
+
+	for _, tenantID := range tenantIDs {
+		if err := u.addTenantRecords(ctx, document, tenantID, globalUserID); err != nil {
+			return nil, err
+		}
+	}
+
+	changes, err := u.changeLogRepo.ListByGlobalUserID(ctx, gid)
+	if err != nil {
+		return nil, fmt.Errorf("list identity changes: %w", err)
+	}
+	for _, change := range changes {
+		document.IdentityChanges = append(document.IdentityChanges, types.ExportedIdentityChange{
+			TenantID:     change.TenantID,
+			Action:       change.Action,
+			IdentityType: change.IdentityType,
+			OldValue:     change.OldValue,
+			NewValue:     change.NewValue,
+			Actor:        change.Actor,
+			CreatedAt:    change.CreatedAt,
+		})
+	}
+
+	tuples, derr := u.ketoService.ListRelationTuplesBySubject(ctx, globalUserID)
+	if derr != nil {
+		return nil, fmt.Errorf("list relation tuples: %s", derr.Message)
+	}
+	document.RelationTuples = append(document.RelationTuples, tuples...)
+
+	return document, nil
+}
+
+// addRefreshTokens adds the refresh-token chains issued to one of the user's Kratos identities
+func (u *dataExportUseCase) addRefreshTokens(ctx context.Context, document *types.UserDataExportDocument, identity *domain.UserIdentity) error {
+	tokens, err := u.sessionRefreshTokenRepo.ListByKratosUserID(ctx, identity.TenantID, identity.KratosUserID)
+	if err != nil {
+		return fmt.Errorf("list refresh tokens of %s: %w", identity.KratosUserID, err)
+	}
+	for _, token := range tokens {
+		document.RefreshTokens = append(document.RefreshTokens, types.ExportedRefreshToken{
+			TenantID:        token.TenantID,
+			ID:              token.ID,
+			FamilyID:        token.FamilyID,
+			ParentID:        token.ParentID,
+			KratosSessionID: token.KratosSessionID,
+			ChainStartedAt:  token.ChainStartedAt,
+			ExpiresAt:       token.ExpiresAt,
+			RotatedAt:       token.RotatedAt,
+			RevokedAt:       token.RevokedAt,
+			CreatedAt:       token.CreatedAt,
+		})
+	}
+	return nil
+}
+
+// addTenantRecords adds the sign-in records the user has in one tenant. Secrets, token hashes
+// and public keys are left out.
+func (u *dataExportUseCase) addTenantRecords(ctx context.Context, document *types.UserDataExportDocument, tenantID, globalUserID string) error {
+	events, err := u.loginEventRepo.ListByGlobalUserID(ctx, tenantID, globalUserID)
+	if err != nil {
+		return fmt.Errorf("list login events: %w", err)
+	}
+	for _, event := range events {
+		document.LoginHistory = append(document.LoginHistory, types.ExportedLoginEvent{
+			TenantID:   event.TenantID,
+			Flow:       event.Flow,
+			Method:     event.Method,
+			Channel:    event.Channel,
+			IPAddress:  event.IPAddress,
+			UserAgent:  event.UserAgent,
+			Country:    event.Country,
+			NewDevice:  event.NewDevice,
+			NewCountry: event.NewCountry,
+			RiskAction: event.RiskAction,
+			CreatedAt:  event.CreatedAt,
+		})
+	}
+
+	sessions, err := u.userSessionRepo.ListByGlobalUserID(ctx, tenantID, globalUserID)
+	if err != nil {
+		return fmt.Errorf("list sessions: %w", err)
+	}
+	for _, session := range sessions {
+		document.Sessions = append(document.Sessions, types.ExportedSession{
+			TenantID:        session.TenantID,
+			ID:              session.ID,
+			KratosSessionID: session.KratosSessionID,
+			IPAddress:       session.IPAddress,
+			UserAgent:       session.UserAgent,
+			AAL:             session.AAL,
+			ExpiresAt:       session.ExpiresAt,
+			LastSeenAt:      session.LastSeenAt,
+			RevokedAt:       session.RevokedAt,
+			CreatedAt:       session.CreatedAt,
+		})
+	}
+
+	devices, err := u.trustedDeviceRepo.ListByGlobalUserID(ctx, tenantID, globalUserID)
+	if err != nil {
+		return fmt.Errorf("list trusted devices: %w", err)
+	}
+	for _, device := range devices {
+		document.TrustedDevices = append(document.TrustedDevices, types.ExportedTrustedDevice{
+			TenantID:   device.TenantID,
+			ID:         device.ID,
+			IPAddress:  device.IPAddress,
+			UserAgent:  device.UserAgent,
+			LastUsedAt: device.LastUsedAt,
+			ExpiresAt:  device.ExpiresAt,
+			RevokedAt:  device.RevokedAt,
+			CreatedAt:  device.CreatedAt,
+		})
+	}
+
+	passkeys, err := u.userPasskeyRepo.ListByGlobalUserID(ctx, tenantID, globalUserID)
+	if err != nil {
+		return fmt.Errorf("list passkeys: %w", err)
+	}
+	for _, passkey := range passkeys {
+		document.Passkeys = append(document.Passkeys, types.ExportedPasskey{
+			TenantID:        passkey.TenantID,
+			ID:              passkey.ID,
+			Name:            passkey.Name,
+			CredentialID:    passkey.CredentialID,
+			AttestationType: passkey.AttestationType,
+			Transports:      passkey.Transports,
+			BackupEligible:  passkey.BackupEligible,
+			BackupState:     passkey.BackupState,
+			LastUsedAt:      passkey.LastUsedAt,
+			CreatedAt:       passkey.CreatedAt,
+		})
+	}
+
+	factor, err := u.userMFARepo.GetFactor(ctx, tenantID, globalUserID, constants.MFAFactorTOTP)
+	if err != nil {
+		return fmt.Errorf("get mfa factor: %w", err)
+	}
+	if factor != nil {
+		document.MFAFactors = append(document.MFAFactors, types.ExportedMFAFactor{
+			TenantID:  factor.TenantID,
+			Type:      factor.Type,
+			EnabledAt: factor.EnabledAt,
+			CreatedAt: factor.CreatedAt,
+		})
+	}
+	return nil
+}
+
+func (u *dataExportUseCase) kratosIdentity(ctx context.Context, identity *domain.UserIdentity) (*types.ExportedKratosIdentity, error) {
+	tenantID, err := uuid.Parse(identity.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenant id of identity %s: %w", identity.ID, err)
+	}
+	kratosUserID, err := uuid.Parse(identity.KratosUserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kratos id of identity %s: %w", identity.ID, err)
+	}
+	kratosIdentity, err := u.kratosService.GetIdentity(ctx, tenantID, kratosUserID)
+	if err != nil {
+		return nil, fmt.Errorf("get kratos identity %s: %w", identity.KratosUserID, err)
+	}
+	return &types.ExportedKratosIdentity{
+		TenantID:  identity.TenantID,
+		ID:        kratosIdentity.Id,
+		State:     string(kratosIdentity.GetState()),
+		Traits:    kratosIdentity.Traits,
+		CreatedAt: kratosIdentity.CreatedAt,
+	}, nil
+}
+
+func toDataExportResponse(export *domain.UserDataExport, now time.Time) *types.DataExportResponse {
+	resp := &types.DataExportResponse{
+		ID:          export.ID,
+		Status:      constants.DataExportStatusPending,
+		CreatedAt:   export.CreatedAt,
+		CompletedAt: export.CompletedAt,
+		ExpiresAt:   export.ExpiresAt,
+	}
+	switch {
+	case export.FailedAt != nil:
+		resp.Status = constants.DataExportStatusFailed
+	case export.CompletedAt != nil:
+		resp.Status = constants.DataExportStatusReady
+		if export.ExpiresAt != nil && now.Before(*export.ExpiresAt) {
+			resp.DownloadURL, resp.DownloadURLExpiresAt = dataExportDownloadURL(export, now)
+		}
+	}
+	return resp
+}
+
+// dataExportDownloadURL signs a link to the export that works until the link or the export expires
+func dataExportDownloadURL(export *domain.UserDataExport, now time.Time) (string, *time.Time) {
+	expiresAt := now.Add(constants.DataExportLinkTTL)
+	if export.ExpiresAt.Before(expiresAt) {
+		expiresAt = *export.ExpiresAt
+	}
+	expires := expiresAt.Unix()
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", dataExportLinkSignature(conf.GetDataExportLinkSecret(), export.ID, expires))
+	return "/api/v1/data-exports/" + export.ID + "/download?" + query.Encode(), &expiresAt
+}
+
+func dataExportLinkSignature(secret, exportID string, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(exportID + ":" + strconv.FormatInt(expires, 10)))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// dataExportEncryptionKey derives the AES key for stored exports from the database encryption key
+func dataExportEncryptionKey() ([32]byte, *domainerrors.DomainError) {
+	dbKey := conf.GetConfiguration().DbEncryptionKey
+	if dbKey == "" {
+		return [32]byte{}, domainerrors.WrapInternal(errors.New("db encryption key is empty"), "MSG_DATA_EXPORT_NOT_CONFIGURED", "Data exports are not configured")
+	}
+	return utils.DeriveKey(dbKey, constants.KeyPurposeDataExport), nil
+}
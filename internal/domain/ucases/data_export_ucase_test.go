@@ -0,0 +1,230 @@
+package ucases
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	client "github.com/ory/kratos-client-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	mock_repositories "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/repositories"
+	mock_services "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/services"
+)
+
+// useDataExportSecrets sets the secrets export links are signed and payloads sealed with
+func useDataExportSecrets(t *testing.T) {
+	cfg := conf.GetConfiguration()
+	linkSecret, dbKey := cfg.DataExport.LinkSecret, cfg.DbEncryptionKey
+	cfg.DataExport.LinkSecret, cfg.DbEncryptionKey = "link-secret", "db-key"
+	t.Cleanup(func() { cfg.DataExport.LinkSecret, cfg.DbEncryptionKey = linkSecret, dbKey })
+}
+
+func TestProcessPendingDataExports_CollectsTheUsersDataAcrossTenants(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useDataExportSecrets(t)
+	ctx := context.Background()
+	globalUserID := uuid.NewString()
+	tenants := []string{uuid.NewString(), uuid.NewString()}
+	kratosIDs := []string{uuid.NewString(), uuid.NewString()}
+	export := &domain.UserDataExport{ID: uuid.NewString(), TenantID: tenants[0], GlobalUserID: globalUserID}
+
+	exportRepo := mock_repositories.NewMockUserDataExportRepository(ctrl)
+	mappingRepo := mock_repositories.NewMockUserIdentifierMappingRepository(ctrl)
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	changeLogRepo := mock_repositories.NewMockUserIdentityChangeLogRepository(ctrl)
+	loginEventRepo := mock_repositories.NewMockLoginEventRepository(ctrl)
+	sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	refreshTokenRepo := mock_repositories.NewMockSessionRefreshTokenRepository(ctrl)
+	deviceRepo := mock_repositories.NewMockTrustedDeviceRepository(ctrl)
+	passkeyRepo := mock_repositories.NewMockUserPasskeyRepository(ctrl)
+	mfaRepo := mock_repositories.NewMockUserMFARepository(ctrl)
+	kratos := mock_services.NewMockKratosService(ctrl)
+	keto := mock_services.NewMockKetoService(ctrl)
+
+	u := &dataExportUseCase{
+		dataExportRepo:            exportRepo,
+		userIdentityRepo:          identityRepo,
+		userIdentifierMappingRepo: mappingRepo,
+		changeLogRepo:             changeLogRepo,
+		loginEventRepo:            loginEventRepo,
+		userSessionRepo:           sessionRepo,
+		sessionRefreshTokenRepo:   refreshTokenRepo,
+		trustedDeviceRepo:         deviceRepo,
+		userPasskeyRepo:           passkeyRepo,
+		userMFARepo:               mfaRepo,
+		kratosService:             kratos,
+		ketoService:               keto,
+	}
+
+	exportRepo.EXPECT().DeleteExpired(ctx, gomock.Any()).Return(int64(0), nil)
+	exportRepo.EXPECT().ListDue(ctx, gomock.Any(), constants.DataExportBatchSize).Return([]*domain.UserDataExport{export}, nil)
+	exportRepo.EXPECT().Claim(ctx, export.ID, gomock.Any(), gomock.Any()).Return(true, nil)
+
+	mappingRepo.EXPECT().GetByGlobalUserID(ctx, globalUserID).Return(&domain.UserIdentifierMapping{Lang: "vi"}, nil)
+	identityRepo.EXPECT().ListByGlobalUserID(ctx, nil, globalUserID).Return([]*domain.UserIdentity{
+		{ID: "identity-email", TenantID: tenants[0], Type: "email", Value: "user@example.com", KratosUserID: kratosIDs[0]},
+		{ID: "identity-phone", TenantID: tenants[1], Type: "phone_number", Value: "+84123456789", KratosUserID: kratosIDs[1]},
+	}, nil)
+	for i := range tenants {
+		kratos.EXPECT().GetIdentity(ctx, uuid.MustParse(tenants[i]), uuid.MustParse(kratosIDs[i])).
+			Return(&client.Identity{Id: kratosIDs[i], Traits: map[string]interface{}{"tenant": tenants[i]}}, nil)
+	}
+
+	// The sign-in records live in the first tenant; the second has none
+	kratosSessionID, familyID, parentID := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	enabledAt := time.Now()
+	refreshTokenRepo.EXPECT().ListByKratosUserID(ctx, tenants[0], kratosIDs[0]).Return([]*domain.SessionRefreshToken{
+		{ID: parentID, TenantID: tenants[0], FamilyID: familyID, KratosSessionID: kratosSessionID, TokenHash: "refresh-token-hash"},
+		{ID: uuid.NewString(), TenantID: tenants[0], FamilyID: familyID, ParentID: &parentID, KratosSessionID: kratosSessionID, TokenHash: "next-token-hash"},
+	}, nil)
+	refreshTokenRepo.EXPECT().ListByKratosUserID(ctx, tenants[1], kratosIDs[1]).Return(nil, nil)
+	loginEventRepo.EXPECT().ListByGlobalUserID(ctx, tenants[0], globalUserID).Return([]*domain.LoginEvent{
+		{TenantID: tenants[0], Flow: "login", Method: "code", IPAddress: "203.0.113.7", UserAgent: "Mozilla/5.0", Country: "VN"},
+	}, nil)
+	loginEventRepo.EXPECT().ListByGlobalUserID(ctx, tenants[1], globalUserID).Return(nil, nil)
+	sessionRepo.EXPECT().ListByGlobalUserID(ctx, tenants[0], globalUserID).Return([]*domain.UserSession{
+		{ID: uuid.NewString(), TenantID: tenants[0], KratosSessionID: kratosSessionID, IPAddress: "203.0.113.7", AAL: "aal1"},
+	}, nil)
+	sessionRepo.EXPECT().ListByGlobalUserID(ctx, tenants[1], globalUserID).Return(nil, nil)
+	deviceRepo.EXPECT().ListByGlobalUserID(ctx, tenants[0], globalUserID).Return([]*domain.TrustedDevice{
+		{ID: uuid.NewString(), TenantID: tenants[0], TokenHash: "device-token-hash", UserAgent: "Mozilla/5.0"},
+	}, nil)
+	deviceRepo.EXPECT().ListByGlobalUserID(ctx, tenants[1], globalUserID).Return(nil, nil)
+	passkeyRepo.EXPECT().ListByGlobalUserID(ctx, tenants[0], globalUserID).Return([]*domain.UserPasskey{
+		{ID: uuid.NewString(), TenantID: tenants[0], Name: "Laptop", CredentialID: "credential-1", PublicKey: []byte("public-key")},
+	}, nil)
+	passkeyRepo.EXPECT().ListByGlobalUserID(ctx, tenants[1], globalUserID).Return(nil, nil)
+	mfaRepo.EXPECT().GetFactor(ctx, tenants[0], globalUserID, constants.MFAFactorTOTP).
+		Return(&domain.UserMFAFactor{TenantID: tenants[0], Type: constants.MFAFactorTOTP, Secret: "totp-secret", EnabledAt: &enabledAt}, nil)
+	mfaRepo.EXPECT().GetFactor(ctx, tenants[1], globalUserID, constants.MFAFactorTOTP).Return(nil, nil)
+
+	changeLogRepo.EXPECT().ListByGlobalUserID(ctx, uuid.MustParse(globalUserID)).Return([]*domain.UserIdentityChangeLog{
+		{TenantID: tenants[0], IdentityType: "email", OldValue: "old@example.com", NewValue: "user@example.com"},
+	}, nil)
+	keto.EXPECT().ListRelationTuplesBySubject(ctx, globalUserID).
+		Return([]types.RelationTuple{{Namespace: "documents", Object: "doc-1", Relation: "owner"}}, nil)
+
+	var stored string
+	exportRepo.EXPECT().Complete(ctx, export.ID, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, payload string, completedAt, expiresAt time.Time) error {
+			stored = payload
+			assert.Equal(t, constants.DataExportRetention, expiresAt.Sub(completedAt))
+			return nil
+		})
+
+	generated, derr := u.ProcessPendingDataExports(ctx)
+	require.Nil(t, derr)
+	assert.Equal(t, 1, generated)
+	assert.NotContains(t, stored, "user@example.com", "the export is stored encrypted")
+
+	// Once ready, the user gets a link that downloads the document
+	now := time.Now()
+	completedAt, expiresAt := now, now.Add(constants.DataExportRetention)
+	export.Payload, export.CompletedAt, export.ExpiresAt = stored, &completedAt, &expiresAt
+	exportRepo.EXPECT().GetByID(ctx, export.ID).Return(export, nil).Times(2)
+
+	resp, derr := u.GetDataExport(ctx, uuid.MustParse(tenants[0]), globalUserID, export.ID)
+	require.Nil(t, derr)
+	assert.Equal(t, constants.DataExportStatusReady, resp.Status)
+	expires, signature := parseDownloadURL(t, resp.DownloadURL, export.ID)
+
+	payload, derr := u.DownloadDataExport(ctx, export.ID, expires, signature)
+	require.Nil(t, derr)
+	var document types.UserDataExportDocument
+	require.NoError(t, json.Unmarshal(payload, &document))
+	assert.Equal(t, globalUserID, document.GlobalUserID)
+	assert.Equal(t, "vi", document.Lang)
+	assert.Len(t, document.Identities, 2)
+	assert.Len(t, document.KratosIdentities, 2)
+	assert.Len(t, document.IdentityChanges, 1)
+	assert.Equal(t, "owner", document.RelationTuples[0].Relation)
+
+	require.Len(t, document.LoginHistory, 1)
+	assert.Equal(t, "203.0.113.7", document.LoginHistory[0].IPAddress)
+	assert.Equal(t, "Mozilla/5.0", document.LoginHistory[0].UserAgent)
+	assert.Equal(t, "VN", document.LoginHistory[0].Country)
+	require.Len(t, document.Sessions, 1)
+	assert.Equal(t, kratosSessionID, document.Sessions[0].KratosSessionID)
+	require.Len(t, document.RefreshTokens, 2)
+	assert.Equal(t, familyID, document.RefreshTokens[1].FamilyID)
+	assert.Equal(t, &parentID, document.RefreshTokens[1].ParentID)
+	assert.Len(t, document.TrustedDevices, 1)
+	require.Len(t, document.Passkeys, 1)
+	assert.Equal(t, "credential-1", document.Passkeys[0].CredentialID)
+	require.Len(t, document.MFAFactors, 1)
+	assert.Equal(t, constants.MFAFactorTOTP, document.MFAFactors[0].Type)
+	assert.NotNil(t, document.MFAFactors[0].EnabledAt)
+	for _, secret := range []string{"refresh-token-hash", "next-token-hash", "device-token-hash", "totp-secret"} {
+		assert.NotContains(t, string(payload), secret)
+	}
+}
+
+func TestDownloadDataExport_RejectsTamperedAndExpiredLinks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useDataExportSecrets(t)
+	ctx := context.Background()
+	exportID := uuid.NewString()
+	expires := time.Now().Add(time.Minute).Unix()
+	signature := dataExportLinkSignature("link-secret", exportID, expires)
+
+	// Neither reaches the database
+	u := &dataExportUseCase{dataExportRepo: mock_repositories.NewMockUserDataExportRepository(ctrl)}
+
+	_, derr := u.DownloadDataExport(ctx, exportID, expires+3600, signature)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_INVALID_DOWNLOAD_LINK", derr.Code)
+
+	_, derr = u.DownloadDataExport(ctx, uuid.NewString(), expires, signature)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_INVALID_DOWNLOAD_LINK", derr.Code)
+
+	expired := time.Now().Add(-time.Minute).Unix()
+	_, derr = u.DownloadDataExport(ctx, exportID, expired, dataExportLinkSignature("link-secret", exportID, expired))
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_DOWNLOAD_LINK_EXPIRED", derr.Code)
+}
+
+func TestGetDataExport_HidesOtherUsersExports(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	export := &domain.UserDataExport{ID: uuid.NewString(), TenantID: tenantID.String(), GlobalUserID: "global-1"}
+
+	exportRepo := mock_repositories.NewMockUserDataExportRepository(ctrl)
+	exportRepo.EXPECT().GetByID(ctx, export.ID).Return(export, nil)
+
+	u := &dataExportUseCase{dataExportRepo: exportRepo}
+
+	resp, derr := u.GetDataExport(ctx, tenantID, "global-2", export.ID)
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_DATA_EXPORT_NOT_FOUND", derr.Code)
+}
+
+func parseDownloadURL(t *testing.T, downloadURL, exportID string) (int64, string) {
+	t.Helper()
+	parsed, err := url.Parse(downloadURL)
+	require.NoError(t, err)
+	require.True(t, strings.HasSuffix(parsed.Path, "/data-exports/"+exportID+"/download"), downloadURL)
+	expires, err := strconv.ParseInt(parsed.Query().Get("expires"), 10, 64)
+	require.NoError(t, err)
+	return expires, parsed.Query().Get("signature")
+}
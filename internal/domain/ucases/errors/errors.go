@@ -19,6 +19,7 @@ const (
 	ErrorTypeConflict
 	ErrorTypeInternal
 	ErrorTypeRateLimit
+	ErrorTypeForbidden
 )
 
 // Error implements the error interface
@@ -103,6 +104,16 @@ func NewRateLimitError(code, message string, details interface{}) *DomainError {
 	}
 }
 
+// NewForbiddenError creates an error for a caller that is known but not allowed to proceed
+func NewForbiddenError(code, message string, details interface{}) *DomainError {
+	return &DomainError{
+		Type:    ErrorTypeForbidden,
+		Code:    code,
+		Message: message,
+		Details: details,
+	}
+}
+
 // Wrap wraps an error with a domain error
 func Wrap(err error, errorType ErrorType, code, message string) *DomainError {
 	return &DomainError{
@@ -4,16 +4,32 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"regexp"
 	"strings"
 
 	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
 	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
 	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
 	"github.com/lifenetwork-ai/iam-service/packages/logger"
 	"github.com/lifenetwork-ai/iam-service/packages/utils"
+	client "github.com/ory/kratos-client-go"
 )
 
+// clientInfo returns the IP address and user agent of the client making the request, when known
+func clientInfo(ctx context.Context) (string, string) {
+	ip, _ := ctx.Value(constants.ClientIPKey).(string)
+	userAgent, _ := ctx.Value(constants.UserAgentKey).(string)
+	return ip, userAgent
+}
+
+// deviceToken returns the trusted device token the client sent, if any
+func deviceToken(ctx context.Context) string {
+	token, _ := ctx.Value(constants.DeviceTokenKey).(string)
+	return token
+}
+
 // extractSessionToken extracts and validates the session token from context
 func extractSessionToken(ctx context.Context) (string, *domainerrors.DomainError) {
 	sessionTokenVal := ctx.Value(constants.SessionTokenKey)
@@ -77,6 +93,61 @@ func extractUserFromTraits(traits interface{}, fallbackEmail, fallbackPhone stri
 	}, nil
 }
 
+// isAbsoluteURL reports whether raw is an absolute http(s) URL
+func isAbsoluteURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "https" || u.Scheme == "http") && u.Host != ""
+}
+
+// isValidRedirectURI reports whether raw can be registered as an OAuth redirect URI: absolute and
+// without a fragment (RFC 6749 section 3.1.2). Custom schemes are allowed for native apps.
+func isValidRedirectURI(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && u.Scheme != "" && u.Fragment == "" && !strings.ContainsAny(raw, " #")
+}
+
+// identifiersFromIdentities returns the first email and phone number among a user's identities
+func identifiersFromIdentities(identities []*domain.UserIdentity) (email, phone string) {
+	for _, id := range identities {
+		switch id.Type {
+		case constants.IdentifierEmail.String():
+			if email == "" {
+				email = id.Value
+			}
+		case constants.IdentifierPhone.String():
+			if phone == "" {
+				phone = id.Value
+			}
+		}
+	}
+	return email, phone
+}
+
+// newAuthResponse builds the authentication response for a freshly issued Kratos session
+func newAuthResponse(session *client.Session, sessionToken string) *types.IdentityUserAuthResponse {
+	traits, _ := safeExtractTraits(session.GetIdentity().Traits)
+
+	return &types.IdentityUserAuthResponse{
+		SessionID:       session.Id,
+		SessionToken:    sessionToken,
+		Active:          session.GetActive(),
+		ExpiresAt:       session.ExpiresAt,
+		IssuedAt:        session.IssuedAt,
+		AuthenticatedAt: session.AuthenticatedAt,
+		User: &types.IdentityUserResponse{
+			ID:       session.GetIdentity().Id,
+			UserName: extractStringFromTraits(traits, constants.IdentifierUsername.String(), ""),
+			Email:    extractStringFromTraits(traits, constants.IdentifierEmail.String(), ""),
+			Phone:    extractStringFromTraits(traits, constants.IdentifierPhone.String(), ""),
+			Tenant:   extractStringFromTraits(traits, constants.IdentifierTenant.String(), ""),
+			Lang:     extractStringFromTraits(traits, constants.IdentifierLang.String(), ""),
+		},
+		AuthenticationMethods: utils.Map(session.AuthenticationMethods, func(method client.SessionAuthenticationMethod) string {
+			return method.GetMethod()
+		}),
+	}
+}
+
 // extractStringFromTraits extracts a string value from traits map
 // If the value is a pointer to a string, it dereferences it
 // If the value is nil, it returns the default value
@@ -162,3 +233,37 @@ func inferAndNormalizeIdentifier(identifier string) (string, string, *domainerro
 		return "", "", domainerrors.NewValidationError("MSG_INVALID_IDENTIFIER_TYPE", "Invalid identifier type", nil)
 	}
 }
+
+// claimJob reports whether this worker won the claim on a background job. A claim that
+// cannot be made is logged and left to the next run.
+func claimJob(kind, id string, claim func() (bool, error)) bool {
+	claimed, err := claim()
+	if err != nil {
+		logger.GetLogger().Errorf("Failed to claim %s %s: %v", kind, id, err)
+		return false
+	}
+	return claimed
+}
+
+// recordJobFailure schedules a claimed background job for another attempt with retry, or gives
+// up on it with fail once maxAttempts is reached. attempts is the count loaded before the job
+// was claimed.
+func recordJobFailure(
+	kind, id string,
+	attempts, maxAttempts int,
+	cause error,
+	retry func(lastError string) error,
+	fail func(lastError string) error,
+) {
+	// Claim counted this attempt after the job was loaded
+	record := retry
+	if attempts+1 < maxAttempts {
+		logger.GetLogger().Errorf("Failed to process %s %s, retrying later: %v", kind, id, cause)
+	} else {
+		logger.GetLogger().Errorf("Giving up on %s %s: %v", kind, id, cause)
+		record = fail
+	}
+	if err := record(cause.Error()); err != nil {
+		logger.GetLogger().Errorf("Failed to record failure of %s %s: %v", kind, id, err)
+	}
+}
@@ -0,0 +1,174 @@
+package ucases
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+)
+
+type identifierLockoutUseCase struct {
+	identifierLockoutRepo domainrepo.IdentifierLockoutRepository
+}
+
+func NewIdentifierLockoutUseCase(identifierLockoutRepo domainrepo.IdentifierLockoutRepository) interfaces.IdentifierLockoutUseCase {
+	return &identifierLockoutUseCase{
+		identifierLockoutRepo: identifierLockoutRepo,
+	}
+}
+
+// ListIdentifierLockouts returns the identifiers that are locked out now, soonest unlocked first
+func (u *identifierLockoutUseCase) ListIdentifierLockouts(
+	ctx context.Context,
+	tenantID uuid.UUID,
+) ([]*types.IdentifierLockoutResponse, *domainerrors.DomainError) {
+	lockouts, err := u.identifierLockoutRepo.ListLocked(ctx, tenantID.String(), time.Now())
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_LIST_IDENTIFIER_LOCKOUTS_FAILED", "Failed to list identifier lockouts")
+	}
+
+	resp := make([]*types.IdentifierLockoutResponse, 0, len(lockouts))
+	for _, lockout := range lockouts {
+		resp = append(resp, &types.IdentifierLockoutResponse{
+			Identifier:   lockout.Identifier,
+			Lockouts:     lockout.Lockouts,
+			LockedUntil:  *lockout.LockedUntil,
+			LastFailedAt: lockout.LastFailedAt,
+		})
+	}
+	return resp, nil
+}
+
+// UnlockIdentifier lifts the lockout of an identifier and forgets the wrong codes entered for it,
+// so its next lockout is as short as the first
+func (u *identifierLockoutUseCase) UnlockIdentifier(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	identifier string,
+	unlockedBy string,
+) *domainerrors.DomainError {
+	_, identifier, derr := inferAndNormalizeIdentifier(identifier)
+	if derr != nil {
+		return derr
+	}
+
+	deleted, err := u.identifierLockoutRepo.Delete(ctx, tenantID.String(), identifier)
+	if err != nil {
+		return domainerrors.WrapInternal(err, "MSG_UNLOCK_IDENTIFIER_FAILED", "Failed to unlock identifier")
+	}
+	if !deleted {
+		return domainerrors.NewNotFoundError("MSG_IDENTIFIER_LOCKOUT_NOT_FOUND", "Identifier lockout")
+	}
+	logger.GetLogger().Infof("Identifier lockout lifted in tenant %s by %s", tenantID, unlockedBy)
+	return nil
+}
+
+// identifierLockedError refuses an identifier that is locked out at now. It is a rate limit
+// error with its own code so clients can tell the user how long to wait.
+func identifierLockedError(lockout *domain.IdentifierLockout, now time.Time) *domainerrors.DomainError {
+	if !lockout.IsLocked(now) {
+		return nil
+	}
+	return domainerrors.NewRateLimitError(
+		"MSG_IDENTIFIER_LOCKED",
+		"Too many wrong codes were entered; try again later",
+		map[string]interface{}{
+			"locked_until": lockout.LockedUntil.UTC().Format(time.RFC3339),
+			"retry_after":  int(math.Ceil(lockout.LockedUntil.Sub(now).Seconds())),
+		},
+	)
+}
+
+// otpLockoutDuration doubles the base lockout for each earlier lockout in a row, up to the maximum
+func otpLockoutDuration(previousLockouts int) time.Duration {
+	d := constants.OTPLockoutBaseDuration
+	for i := 0; i < previousLockouts && d < constants.OTPLockoutMaxDuration; i++ {
+		d *= 2
+	}
+	return min(d, constants.OTPLockoutMaxDuration)
+}
+
+// checkIdentifierLockout refuses an identifier that is locked out after too many wrong codes
+func (u *userUseCase) checkIdentifierLockout(ctx context.Context, tenantID uuid.UUID, identifier string) *domainerrors.DomainError {
+	return identifierLockout(ctx, u.identifierLockoutRepo, tenantID, identifier)
+}
+
+// recordWrongCode counts a wrong code entered for the identifier in the given flow. The flow is
+// dropped once the identifier is locked out, so the code it sent cannot be guessed at any longer.
+func (u *userUseCase) recordWrongCode(ctx context.Context, tenantID uuid.UUID, flowID, identifier string) *domainerrors.DomainError {
+	derr := countWrongCode(ctx, u.identifierLockoutRepo, tenantID, flowID, identifier)
+	if derr != nil {
+		_ = u.challengeSessionRepo.DeleteChallenge(ctx, flowID)
+	}
+	return derr
+}
+
+// clearWrongCodes forgets the wrong codes entered for an identifier once a right one is entered
+func (u *userUseCase) clearWrongCodes(ctx context.Context, tenantID uuid.UUID, identifier string) {
+	forgetWrongCodes(ctx, u.identifierLockoutRepo, tenantID, identifier)
+}
+
+// identifierLockout refuses an identifier that is locked out after too many wrong codes
+func identifierLockout(
+	ctx context.Context,
+	identifierLockoutRepo domainrepo.IdentifierLockoutRepository,
+	tenantID uuid.UUID,
+	identifier string,
+) *domainerrors.DomainError {
+	lockout, err := identifierLockoutRepo.Get(ctx, tenantID.String(), identifier)
+	if err != nil {
+		return domainerrors.WrapInternal(err, "MSG_GET_IDENTIFIER_LOCKOUT_FAILED", "Failed to check identifier lockout")
+	}
+	return identifierLockedError(lockout, time.Now())
+}
+
+// countWrongCode counts a wrong code entered for the identifier in the given flow. It returns the
+// lockout error when this code locked the identifier out, and nil otherwise; failing to count the
+// code is logged rather than hiding the wrong-code error from the user.
+func countWrongCode(
+	ctx context.Context,
+	identifierLockoutRepo domainrepo.IdentifierLockoutRepository,
+	tenantID uuid.UUID,
+	flowID, identifier string,
+) *domainerrors.DomainError {
+	now := time.Now()
+	lockout, err := identifierLockoutRepo.RecordFailure(ctx, tenantID.String(), identifier, now, now.Add(-constants.OTPFailureResetAfter))
+	if err != nil {
+		logger.GetLogger().Errorf("Failed to record wrong code for flow %s: %v", flowID, err)
+		return nil
+	}
+	if lockout.FailedAttempts < constants.OTPMaxFailedAttempts {
+		return nil
+	}
+
+	until := now.Add(otpLockoutDuration(lockout.Lockouts))
+	if err := identifierLockoutRepo.Lock(ctx, lockout.ID, until); err != nil {
+		logger.GetLogger().Errorf("Failed to lock out identifier for flow %s: %v", flowID, err)
+		return nil
+	}
+	logger.GetLogger().Warnf("Identifier of flow %s in tenant %s locked out until %s", flowID, tenantID, until.UTC().Format(time.RFC3339))
+
+	lockout.LockedUntil = &until
+	return identifierLockedError(lockout, now)
+}
+
+// forgetWrongCodes clears the wrong codes counted for an identifier
+func forgetWrongCodes(
+	ctx context.Context,
+	identifierLockoutRepo domainrepo.IdentifierLockoutRepository,
+	tenantID uuid.UUID,
+	identifier string,
+) {
+	if _, err := identifierLockoutRepo.Delete(ctx, tenantID.String(), identifier); err != nil {
+		logger.GetLogger().Errorf("Failed to clear wrong codes in tenant %s: %v", tenantID, err)
+	}
+}
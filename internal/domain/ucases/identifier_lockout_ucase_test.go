@@ -0,0 +1,179 @@
+package ucases
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	kratos "github.com/ory/kratos-client-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+	mock_repositories "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/repositories"
+	mock_services "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/services"
+	mock_rate_limiter "github.com/lifenetwork-ai/iam-service/mocks/infrastructures/rate_limiter/types"
+)
+
+func TestVerifyLogin_WrongCodeLocksOutIdentifierAtLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	flow := &kratos.LoginFlow{Id: "flow-1"}
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").
+		Return(&domain.ChallengeSession{Identifier: "user@example.com", IdentifierType: constants.IdentifierEmail.String()}, nil)
+	challengeRepo.EXPECT().DeleteChallenge(ctx, "flow-1").Return(nil)
+
+	kratosService := mock_services.NewMockKratosService(ctrl)
+	kratosService.EXPECT().GetLoginFlow(ctx, tenantID, "flow-1").Return(flow, nil)
+	kratosService.EXPECT().SubmitLoginFlow(ctx, tenantID, flow, constants.MethodTypeCode.String(), gomock.Any(), nil, gomock.Any()).
+		Return(nil, fmt.Errorf("login failed: %w", domainservice.ErrFlowRejected))
+
+	// Fifth wrong code after one earlier lockout: the identifier is locked for twice the base duration
+	var lockedUntil time.Time
+	lockoutRepo := mock_repositories.NewMockIdentifierLockoutRepository(ctrl)
+	lockoutRepo.EXPECT().Get(ctx, tenantID.String(), "user@example.com").Return(nil, nil)
+	lockoutRepo.EXPECT().RecordFailure(ctx, tenantID.String(), "user@example.com", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, now, resetBefore time.Time) (*domain.IdentifierLockout, error) {
+			assert.Equal(t, constants.OTPFailureResetAfter, now.Sub(resetBefore))
+			return &domain.IdentifierLockout{ID: "lockout-1", FailedAttempts: constants.OTPMaxFailedAttempts, Lockouts: 1}, nil
+		})
+	lockoutRepo.EXPECT().Lock(ctx, "lockout-1", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, until time.Time) error {
+		lockedUntil = until
+		return nil
+	})
+
+	u := &userUseCase{
+		rateLimiter:           rateLimiter,
+		challengeSessionRepo:  challengeRepo,
+		identifierLockoutRepo: lockoutRepo,
+		kratosService:         kratosService,
+	}
+
+	start := time.Now()
+	resp, derr := u.VerifyLogin(ctx, tenantID, "flow-1", "000000", false)
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, domainerrors.ErrorTypeRateLimit, derr.Type)
+	assert.Equal(t, "MSG_IDENTIFIER_LOCKED", derr.Code)
+	assert.WithinDuration(t, start.Add(2*constants.OTPLockoutBaseDuration), lockedUntil, 5*time.Second)
+}
+
+func TestVerifyLogin_WrongCodeBelowLimitKeepsFlow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	flow := &kratos.LoginFlow{Id: "flow-1"}
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	// DeleteChallenge is not expected: the user may retry with the right code
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").
+		Return(&domain.ChallengeSession{Identifier: "+84901234567", IdentifierType: constants.IdentifierPhone.String()}, nil)
+
+	kratosService := mock_services.NewMockKratosService(ctrl)
+	kratosService.EXPECT().GetLoginFlow(ctx, tenantID, "flow-1").Return(flow, nil)
+	kratosService.EXPECT().SubmitLoginFlow(ctx, tenantID, flow, constants.MethodTypeCode.String(), gomock.Any(), nil, gomock.Any()).
+		Return(nil, fmt.Errorf("login failed: %w", domainservice.ErrFlowRejected))
+
+	lockoutRepo := mock_repositories.NewMockIdentifierLockoutRepository(ctrl)
+	lockoutRepo.EXPECT().Get(ctx, tenantID.String(), "+84901234567").Return(nil, nil)
+	lockoutRepo.EXPECT().RecordFailure(ctx, tenantID.String(), "+84901234567", gomock.Any(), gomock.Any()).
+		Return(&domain.IdentifierLockout{ID: "lockout-1", FailedAttempts: 2}, nil)
+
+	u := &userUseCase{
+		rateLimiter:           rateLimiter,
+		challengeSessionRepo:  challengeRepo,
+		identifierLockoutRepo: lockoutRepo,
+		kratosService:         kratosService,
+	}
+
+	resp, derr := u.VerifyLogin(ctx, tenantID, "flow-1", "000000", false)
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_LOGIN_FAILED", derr.Code)
+}
+
+func TestVerifyLogin_RefusesLockedIdentifier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	lockedUntil := time.Now().Add(10 * time.Minute)
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").
+		Return(&domain.ChallengeSession{Identifier: "user@example.com", IdentifierType: constants.IdentifierEmail.String()}, nil)
+
+	kratosService := mock_services.NewMockKratosService(ctrl)
+	kratosService.EXPECT().GetLoginFlow(ctx, tenantID, "flow-1").Return(&kratos.LoginFlow{Id: "flow-1"}, nil)
+
+	lockoutRepo := mock_repositories.NewMockIdentifierLockoutRepository(ctrl)
+	lockoutRepo.EXPECT().Get(ctx, tenantID.String(), "user@example.com").
+		Return(&domain.IdentifierLockout{Lockouts: 1, LockedUntil: &lockedUntil}, nil)
+
+	u := &userUseCase{
+		rateLimiter:           rateLimiter,
+		challengeSessionRepo:  challengeRepo,
+		identifierLockoutRepo: lockoutRepo,
+		kratosService:         kratosService,
+	}
+
+	// The code is not even submitted to Kratos while the identifier is locked out
+	resp, derr := u.VerifyLogin(ctx, tenantID, "flow-1", "123456", false)
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_IDENTIFIER_LOCKED", derr.Code)
+	details, ok := derr.Details.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, lockedUntil.UTC().Format(time.RFC3339), details["locked_until"])
+}
+
+func TestOTPLockoutDuration_DoublesUpToMaximum(t *testing.T) {
+	assert.Equal(t, constants.OTPLockoutBaseDuration, otpLockoutDuration(0))
+	assert.Equal(t, 2*constants.OTPLockoutBaseDuration, otpLockoutDuration(1))
+	assert.Equal(t, 4*constants.OTPLockoutBaseDuration, otpLockoutDuration(2))
+	assert.Equal(t, constants.OTPLockoutMaxDuration, otpLockoutDuration(20))
+}
+
+func TestUnlockIdentifier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	lockoutRepo := mock_repositories.NewMockIdentifierLockoutRepository(ctrl)
+	u := NewIdentifierLockoutUseCase(lockoutRepo)
+
+	lockoutRepo.EXPECT().Delete(ctx, tenantID.String(), "user@example.com").Return(true, nil)
+	assert.Nil(t, u.UnlockIdentifier(ctx, tenantID, " User@Example.com ", "support-admin"))
+
+	lockoutRepo.EXPECT().Delete(ctx, tenantID.String(), "other@example.com").Return(false, nil)
+	derr := u.UnlockIdentifier(ctx, tenantID, "other@example.com", "support-admin")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_IDENTIFIER_LOCKOUT_NOT_FOUND", derr.Code)
+}
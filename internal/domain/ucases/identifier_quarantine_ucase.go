@@ -0,0 +1,226 @@
+package ucases
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domaintypes "github.com/lifenetwork-ai/iam-service/internal/domain/types"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+	"github.com/lifenetwork-ai/iam-service/packages/utils"
+)
+
+type identifierQuarantineUseCase struct {
+	identifierQuarantineRepo domainrepo.IdentifierQuarantineRepository
+}
+
+func NewIdentifierQuarantineUseCase(
+	identifierQuarantineRepo domainrepo.IdentifierQuarantineRepository,
+) interfaces.IdentifierQuarantineUseCase {
+	return &identifierQuarantineUseCase{
+		identifierQuarantineRepo: identifierQuarantineRepo,
+	}
+}
+
+// ListQuarantinedIdentifiers returns the quarantines in effect, soonest ending first. Identifiers
+// are only stored hashed, so a single one can be looked up but the list shows them masked.
+func (u *identifierQuarantineUseCase) ListQuarantinedIdentifiers(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	identifier string,
+	page, size int,
+) (*domaintypes.PaginatedResponse[*types.IdentifierQuarantineResponse], *domainerrors.DomainError) {
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = constants.IdentifierQuarantineDefaultPageSize
+	}
+	if size > constants.IdentifierQuarantineMaxPageSize {
+		size = constants.IdentifierQuarantineMaxPageSize
+	}
+
+	var identifierHash string
+	if identifier != "" {
+		idType, normalized, derr := inferAndNormalizeIdentifier(identifier)
+		if derr != nil {
+			return nil, derr
+		}
+		identifierHash = identifierQuarantineHash(tenantID, idType, normalized)
+	}
+
+	quarantines, total, err := u.identifierQuarantineRepo.List(ctx, tenantID.String(), identifierHash, time.Now(), (page-1)*size, size)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_LIST_IDENTIFIER_QUARANTINES_FAILED", "Failed to list quarantined identifiers")
+	}
+
+	items := make([]*types.IdentifierQuarantineResponse, 0, len(quarantines))
+	for _, quarantine := range quarantines {
+		items = append(items, &types.IdentifierQuarantineResponse{
+			ID:                  quarantine.ID,
+			IdentifierType:      quarantine.IdentifierType,
+			MaskedIdentifier:    quarantine.MaskedIdentifier,
+			GlobalUserID:        quarantine.GlobalUserID,
+			Reason:              quarantine.Reason,
+			ExpiresAt:           quarantine.ExpiresAt,
+			ApprovalRequestedAt: quarantine.ApprovalRequestedAt,
+			CreatedAt:           quarantine.CreatedAt,
+		})
+	}
+	nextPage := page
+	if int64(page*size) < total {
+		nextPage++
+	}
+	return &domaintypes.PaginatedResponse[*types.IdentifierQuarantineResponse]{
+		Items:      items,
+		TotalCount: total,
+		Page:       page,
+		PageSize:   size,
+		NextPage:   nextPage,
+	}, nil
+}
+
+// ReleaseIdentifier ends the quarantine before it expires
+func (u *identifierQuarantineUseCase) ReleaseIdentifier(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	quarantineID string,
+	releasedBy string,
+) *domainerrors.DomainError {
+	if _, err := uuid.Parse(quarantineID); err != nil {
+		return domainerrors.NewNotFoundError("MSG_IDENTIFIER_QUARANTINE_NOT_FOUND", "Identifier quarantine")
+	}
+
+	released, err := u.identifierQuarantineRepo.Release(ctx, tenantID.String(), quarantineID, releasedBy, time.Now())
+	if err != nil {
+		return domainerrors.WrapInternal(err, "MSG_RELEASE_IDENTIFIER_FAILED", "Failed to release identifier")
+	}
+	if !released {
+		return domainerrors.NewNotFoundError("MSG_IDENTIFIER_QUARANTINE_NOT_FOUND", "Identifier quarantine")
+	}
+	logger.GetLogger().Infof("Identifier quarantine %s of tenant %s released by %s", quarantineID, tenantID, releasedBy)
+	return nil
+}
+
+// identifierQuarantineHash is keyed with a key derived from the database encryption key, so that
+// the stored hashes cannot be matched against a list of every phone number without it
+func identifierQuarantineHash(tenantID uuid.UUID, idType, identifier string) string {
+	key := utils.DeriveKey(conf.GetConfiguration().DbEncryptionKey, constants.KeyPurposeIdentifierQuarantine)
+	mac := hmac.New(sha256.New, key[:])
+	mac.Write([]byte(tenantID.String() + ":" + idType + ":" + identifier))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// quarantineIdentity keeps the identifier an identity held from other accounts for the tenant's
+// quarantine period. The identifier is already off the account, so failing to record the
+// quarantine is logged rather than undoing that.
+func quarantineIdentity(
+	ctx context.Context,
+	settingRepo domainrepo.TenantSettingRepository,
+	quarantineRepo domainrepo.IdentifierQuarantineRepository,
+	identity *domain.UserIdentity,
+	reason string,
+) {
+	if quarantineRepo == nil {
+		return
+	}
+	tenantID, err := uuid.Parse(identity.TenantID)
+	if err != nil {
+		logger.GetLogger().Errorf("Failed to quarantine identifier of identity %s: %v", identity.ID, err)
+		return
+	}
+	setting, derr := getTenantSetting(ctx, settingRepo, tenantID)
+	if derr != nil {
+		logger.GetLogger().Errorf("Failed to quarantine identifier of identity %s: %v", identity.ID, derr)
+		return
+	}
+	period := setting.IdentifierQuarantine()
+	if period == 0 {
+		return
+	}
+
+	if err := quarantineRepo.Create(ctx, &domain.IdentifierQuarantine{
+		TenantID:         identity.TenantID,
+		IdentifierType:   identity.Type,
+		IdentifierHash:   identifierQuarantineHash(tenantID, identity.Type, identity.Value),
+		MaskedIdentifier: maskIdentifier(identity.Type, identity.Value),
+		GlobalUserID:     identity.GlobalUserID,
+		Reason:           reason,
+		ExpiresAt:        time.Now().Add(period),
+	}); err != nil {
+		logger.GetLogger().Errorf("Failed to quarantine identifier of identity %s: %v", identity.ID, err)
+	}
+}
+
+// activeIdentifierQuarantine returns the quarantine keeping the claimant from the identifier, or
+// nil. The account that released the identifier may always claim it back; claimantGlobalUserID is
+// empty for someone registering a new account.
+func activeIdentifierQuarantine(
+	ctx context.Context,
+	quarantineRepo domainrepo.IdentifierQuarantineRepository,
+	tenantID uuid.UUID,
+	idType, identifier, claimantGlobalUserID string,
+) (*domain.IdentifierQuarantine, *domainerrors.DomainError) {
+	if quarantineRepo == nil {
+		return nil, nil
+	}
+	quarantine, err := quarantineRepo.GetActive(
+		ctx, tenantID.String(), identifierQuarantineHash(tenantID, idType, identifier), claimantGlobalUserID, time.Now(),
+	)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_IDENTIFIER_QUARANTINE_FAILED", "Failed to check identifier quarantine")
+	}
+	return quarantine, nil
+}
+
+// identifierQuarantinedError refuses an identifier another account released recently
+func identifierQuarantinedError(quarantine *domain.IdentifierQuarantine) *domainerrors.DomainError {
+	return domainerrors.NewConflictError(
+		"MSG_IDENTIFIER_QUARANTINED",
+		"The identifier was recently removed from another account and cannot be registered yet",
+		map[string]interface{}{
+			"quarantined_until": quarantine.ExpiresAt.UTC().Format(time.RFC3339),
+		},
+	)
+}
+
+// checkIdentifierQuarantine refuses an identifier that is quarantined after another account
+// released it. Where the tenant lets administrators approve such identifiers, the request is
+// recorded for them to see in the list of quarantines.
+func (u *userUseCase) checkIdentifierQuarantine(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	idType, identifier, claimantGlobalUserID string,
+) *domainerrors.DomainError {
+	quarantine, derr := activeIdentifierQuarantine(ctx, u.identifierQuarantineRepo, tenantID, idType, identifier, claimantGlobalUserID)
+	if derr != nil || quarantine == nil {
+		return derr
+	}
+	setting, derr := getTenantSetting(ctx, u.tenantSettingRepo, tenantID)
+	if derr != nil {
+		return derr
+	}
+	if setting.IdentifierQuarantineAction != constants.IdentifierQuarantineActionApproval {
+		return identifierQuarantinedError(quarantine)
+	}
+
+	if err := u.identifierQuarantineRepo.RequestApproval(ctx, quarantine.ID, time.Now()); err != nil {
+		logger.GetLogger().Errorf("Failed to record approval request for identifier quarantine %s: %v", quarantine.ID, err)
+	}
+	return domainerrors.NewForbiddenError(
+		"MSG_IDENTIFIER_APPROVAL_REQUIRED",
+		"The identifier was recently removed from another account; an administrator must approve its registration",
+		nil,
+	)
+}
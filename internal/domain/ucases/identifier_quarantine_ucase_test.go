@@ -0,0 +1,125 @@
+package ucases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	mock_repositories "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/repositories"
+)
+
+func TestQuarantineIdentity_KeepsHashedIdentifierForTenantPeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	quarantineRepo := mock_repositories.NewMockIdentifierQuarantineRepository(ctrl)
+	identity := &domain.UserIdentity{
+		ID:           "identity-1",
+		TenantID:     tenantID.String(),
+		GlobalUserID: uuid.NewString(),
+		Type:         constants.IdentifierPhone.String(),
+		Value:        "+84901234567",
+	}
+
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(&domain.TenantSetting{
+		TenantID:                    tenantID,
+		IdentifierQuarantineSeconds: int((90 * 24 * time.Hour) / time.Second),
+	}, nil)
+	quarantineRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, q *domain.IdentifierQuarantine) error {
+		assert.Equal(t, identifierQuarantineHash(tenantID, identity.Type, identity.Value), q.IdentifierHash)
+		assert.NotContains(t, q.IdentifierHash, "901234567", "the identifier is not stored in clear")
+		assert.Equal(t, "+********567", q.MaskedIdentifier)
+		assert.Equal(t, identity.GlobalUserID, q.GlobalUserID)
+		assert.Equal(t, constants.IdentifierQuarantineReasonDeleted, q.Reason)
+		assert.WithinDuration(t, time.Now().Add(90*24*time.Hour), q.ExpiresAt, time.Minute)
+		return nil
+	})
+	quarantineIdentity(ctx, settingRepo, quarantineRepo, identity, constants.IdentifierQuarantineReasonDeleted)
+
+	// Tenants that did not set a period do not quarantine identifiers
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(nil, nil)
+	quarantineIdentity(ctx, settingRepo, quarantineRepo, identity, constants.IdentifierQuarantineReasonChanged)
+}
+
+func TestIdentifierQuarantineHash_IsPerTenant(t *testing.T) {
+	tenantA, tenantB := uuid.New(), uuid.New()
+	phone := constants.IdentifierPhone.String()
+
+	assert.Equal(t, identifierQuarantineHash(tenantA, phone, "+84901234567"), identifierQuarantineHash(tenantA, phone, "+84901234567"))
+	assert.NotEqual(t, identifierQuarantineHash(tenantA, phone, "+84901234567"), identifierQuarantineHash(tenantB, phone, "+84901234567"))
+	assert.Len(t, identifierQuarantineHash(tenantA, phone, "+84901234567"), 64)
+}
+
+func TestCheckIdentifierQuarantine_BlocksOrAwaitsApproval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	quarantineRepo := mock_repositories.NewMockIdentifierQuarantineRepository(ctrl)
+	u := &userUseCase{tenantSettingRepo: settingRepo, identifierQuarantineRepo: quarantineRepo}
+	phone := constants.IdentifierPhone.String()
+	hash := identifierQuarantineHash(tenantID, phone, "+84901234567")
+	quarantine := &domain.IdentifierQuarantine{ID: "quarantine-1", ExpiresAt: time.Now().Add(time.Hour)}
+
+	// Someone registering a new account
+	quarantineRepo.EXPECT().GetActive(ctx, tenantID.String(), hash, "", gomock.Any()).Return(quarantine, nil)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(nil, nil)
+	derr := u.checkIdentifierQuarantine(ctx, tenantID, phone, "+84901234567", "")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_IDENTIFIER_QUARANTINED", derr.Code)
+
+	// Another account adding it, where administrators approve such identifiers
+	quarantineRepo.EXPECT().GetActive(ctx, tenantID.String(), hash, "user-2", gomock.Any()).Return(quarantine, nil)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(&domain.TenantSetting{
+		TenantID:                   tenantID,
+		IdentifierQuarantineAction: constants.IdentifierQuarantineActionApproval,
+	}, nil)
+	quarantineRepo.EXPECT().RequestApproval(ctx, "quarantine-1", gomock.Any()).Return(nil)
+	derr = u.checkIdentifierQuarantine(ctx, tenantID, phone, "+84901234567", "user-2")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_IDENTIFIER_APPROVAL_REQUIRED", derr.Code)
+
+	// The account that released it, or no quarantine in effect
+	quarantineRepo.EXPECT().GetActive(ctx, tenantID.String(), hash, "user-1", gomock.Any()).Return(nil, nil)
+	assert.Nil(t, u.checkIdentifierQuarantine(ctx, tenantID, phone, "+84901234567", "user-1"))
+}
+
+func TestListQuarantinedIdentifiers_LooksUpOneIdentifierByHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	quarantineRepo := mock_repositories.NewMockIdentifierQuarantineRepository(ctrl)
+	u := NewIdentifierQuarantineUseCase(quarantineRepo)
+	hash := identifierQuarantineHash(tenantID, constants.IdentifierEmail.String(), "user@example.com")
+
+	quarantineRepo.EXPECT().List(ctx, tenantID.String(), hash, gomock.Any(), 0, constants.IdentifierQuarantineMaxPageSize).
+		Return([]*domain.IdentifierQuarantine{{ID: "quarantine-1", MaskedIdentifier: "u***@example.com"}}, int64(1), nil)
+
+	resp, derr := u.ListQuarantinedIdentifiers(ctx, tenantID, " User@Example.com ", 0, 1000)
+	require.Nil(t, derr)
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, "u***@example.com", resp.Items[0].MaskedIdentifier)
+	assert.Equal(t, 1, resp.NextPage)
+
+	derr = u.ReleaseIdentifier(ctx, tenantID, "not-a-uuid", "admin")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_IDENTIFIER_QUARANTINE_NOT_FOUND", derr.Code)
+
+	quarantineID := uuid.NewString()
+	quarantineRepo.EXPECT().Release(ctx, tenantID.String(), quarantineID, "admin", gomock.Any()).Return(true, nil)
+	assert.Nil(t, u.ReleaseIdentifier(ctx, tenantID, quarantineID, "admin"))
+}
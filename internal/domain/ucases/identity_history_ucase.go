@@ -0,0 +1,140 @@
+package ucases
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domaintypes "github.com/lifenetwork-ai/iam-service/internal/domain/types"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+)
+
+type identityHistoryUseCase struct {
+	changeLogRepo domainrepo.UserIdentityChangeLogRepository
+}
+
+func NewIdentityHistoryUseCase(changeLogRepo domainrepo.UserIdentityChangeLogRepository) interfaces.IdentityHistoryUseCase {
+	return &identityHistoryUseCase{
+		changeLogRepo: changeLogRepo,
+	}
+}
+
+// ListUserIdentityHistory returns the user's own history. Administrators are not named to the user.
+func (u *identityHistoryUseCase) ListUserIdentityHistory(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+	query types.IdentityHistoryQuery,
+) (*domaintypes.PaginatedResponse[*types.IdentityHistoryEntry], *domainerrors.DomainError) {
+	query.GlobalUserID = globalUserID
+	page, derr := u.search(ctx, tenantID, query)
+	if derr != nil {
+		return nil, derr
+	}
+	for _, entry := range page.Items {
+		entry.ActorID = ""
+	}
+	return page, nil
+}
+
+// ListTenantIdentityHistory returns the history of the tenant's users
+func (u *identityHistoryUseCase) ListTenantIdentityHistory(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	query types.IdentityHistoryQuery,
+) (*domaintypes.PaginatedResponse[*types.IdentityHistoryEntry], *domainerrors.DomainError) {
+	if query.GlobalUserID != "" {
+		if _, err := uuid.Parse(query.GlobalUserID); err != nil {
+			return nil, domainerrors.NewValidationError("MSG_INVALID_USER_ID", "Invalid user ID", nil)
+		}
+	}
+	return u.search(ctx, tenantID, query)
+}
+
+func (u *identityHistoryUseCase) search(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	query types.IdentityHistoryQuery,
+) (*domaintypes.PaginatedResponse[*types.IdentityHistoryEntry], *domainerrors.DomainError) {
+	if derr := validateIdentityHistoryQuery(&query); derr != nil {
+		return nil, derr
+	}
+
+	logs, total, err := u.changeLogRepo.Search(ctx, domainrepo.IdentityChangeLogFilter{
+		TenantID:     tenantID.String(),
+		GlobalUserID: query.GlobalUserID,
+		Action:       query.Action,
+		IdentityType: query.IdentityType,
+		Actor:        query.Actor,
+		From:         query.From,
+		To:           query.To,
+	}, (query.Page-1)*query.Size, query.Size)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_LIST_IDENTITY_HISTORY_FAILED", "Failed to list identity history")
+	}
+
+	items := make([]*types.IdentityHistoryEntry, len(logs))
+	for i, log := range logs {
+		items[i] = toIdentityHistoryEntry(log)
+	}
+	nextPage := query.Page
+	if int64(query.Page*query.Size) < total {
+		nextPage++
+	}
+	return &domaintypes.PaginatedResponse[*types.IdentityHistoryEntry]{
+		Items:      items,
+		TotalCount: total,
+		Page:       query.Page,
+		PageSize:   query.Size,
+		NextPage:   nextPage,
+	}, nil
+}
+
+// validateIdentityHistoryQuery checks the filters and fills in the default page
+func validateIdentityHistoryQuery(query *types.IdentityHistoryQuery) *domainerrors.DomainError {
+	switch query.Action {
+	case "", constants.IdentityChangeActionAdd, constants.IdentityChangeActionChange,
+		constants.IdentityChangeActionDelete, constants.IdentityChangeActionLang:
+	default:
+		return domainerrors.NewValidationError("MSG_INVALID_ACTION", "Invalid action", nil)
+	}
+	switch query.Actor {
+	case "", constants.IdentityChangeActorUser, constants.IdentityChangeActorAdmin, constants.IdentityChangeActorSystem:
+	default:
+		return domainerrors.NewValidationError("MSG_INVALID_ACTOR", "Invalid actor", nil)
+	}
+	if query.From != nil && query.To != nil && !query.From.Before(*query.To) {
+		return domainerrors.NewValidationError("MSG_INVALID_TIME_RANGE", "from must be before to", nil)
+	}
+
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.Size < 1 {
+		query.Size = constants.IdentityHistoryDefaultPageSize
+	}
+	if query.Size > constants.IdentityHistoryMaxPageSize {
+		query.Size = constants.IdentityHistoryMaxPageSize
+	}
+	return nil
+}
+
+func toIdentityHistoryEntry(log *domain.UserIdentityChangeLog) *types.IdentityHistoryEntry {
+	return &types.IdentityHistoryEntry{
+		ID:           log.ID,
+		GlobalUserID: log.GlobalUserID,
+		TenantID:     log.TenantID,
+		Action:       log.Action,
+		IdentityType: log.IdentityType,
+		OldValue:     log.OldValue,
+		NewValue:     log.NewValue,
+		Actor:        log.Actor,
+		ActorID:      log.ActorID,
+		CreatedAt:    log.CreatedAt,
+	}
+}
@@ -0,0 +1,75 @@
+package ucases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	mock_repositories "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/repositories"
+)
+
+func TestListUserIdentityHistory_OnlyReturnsTheUsersOwnEntries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	repo := mock_repositories.NewMockUserIdentityChangeLogRepository(ctrl)
+	ucase := NewIdentityHistoryUseCase(repo)
+
+	repo.EXPECT().Search(ctx, domainrepo.IdentityChangeLogFilter{
+		TenantID:     tenantID.String(),
+		GlobalUserID: "global-1",
+		Actor:        constants.IdentityChangeActorAdmin,
+	}, 20, 20).Return([]*domain.UserIdentityChangeLog{{
+		ID:           "log-1",
+		GlobalUserID: "global-1",
+		Action:       constants.IdentityChangeActionAdd,
+		Actor:        constants.IdentityChangeActorAdmin,
+		ActorID:      "support-admin",
+	}}, int64(41), nil)
+
+	// A user cannot widen the query to someone else
+	page, derr := ucase.ListUserIdentityHistory(ctx, tenantID, "global-1", types.IdentityHistoryQuery{
+		GlobalUserID: "global-2",
+		Actor:        constants.IdentityChangeActorAdmin,
+		Page:         2,
+	})
+	require.Nil(t, derr)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, constants.IdentityChangeActorAdmin, page.Items[0].Actor)
+	assert.Empty(t, page.Items[0].ActorID, "administrators are not named to users")
+	assert.Equal(t, int64(41), page.TotalCount)
+	assert.Equal(t, 3, page.NextPage)
+}
+
+func TestListTenantIdentityHistory_RejectsInvalidFilters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	ucase := NewIdentityHistoryUseCase(mock_repositories.NewMockUserIdentityChangeLogRepository(ctrl))
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	for code, query := range map[string]types.IdentityHistoryQuery{
+		"MSG_INVALID_USER_ID":    {GlobalUserID: "not-a-uuid"},
+		"MSG_INVALID_ACTION":     {Action: "rename"},
+		"MSG_INVALID_ACTOR":      {Actor: "robot"},
+		"MSG_INVALID_TIME_RANGE": {From: &now, To: &earlier},
+	} {
+		page, derr := ucase.ListTenantIdentityHistory(ctx, uuid.New(), query)
+		assert.Nil(t, page)
+		require.NotNil(t, derr, code)
+		assert.Equal(t, code, derr.Code)
+	}
+}
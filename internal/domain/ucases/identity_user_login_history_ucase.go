@@ -0,0 +1,230 @@
+package ucases
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+)
+
+// signInOptions is what a first-factor sign-in asks of completeSignIn beyond issuing the session
+type signInOptions struct {
+	// rememberDevice trusts the client once the sign-in is complete
+	rememberDevice bool
+	// login is recorded in the user's login history once the session is handed over; nil for
+	// sign-ins that are not recorded
+	login *domain.LoginEvent
+	// assessRisk flags the login when it comes from a device or country the user never signed in from
+	assessRisk bool
+}
+
+// newLoginEvent describes a code sign-in from the client making the request
+func newLoginEvent(ctx context.Context, flow, channel string) *domain.LoginEvent {
+	ip, userAgent := clientInfo(ctx)
+	return &domain.LoginEvent{
+		Flow:      flow,
+		Method:    constants.MethodTypeCode.String(),
+		Channel:   channel,
+		IPAddress: ip,
+		UserAgent: userAgent,
+	}
+}
+
+// codeChannel returns the channel the code for identifier was delivered through, or an empty
+// string when it cannot be told
+func (u *userUseCase) codeChannel(ctx context.Context, tenantID uuid.UUID, identifierType, identifier string) string {
+	if identifierType != constants.IdentifierPhone.String() {
+		return constants.ChannelEmail
+	}
+	if u.courierUseCase == nil {
+		return ""
+	}
+	tenant, err := u.tenantRepo.GetByID(tenantID)
+	if err != nil || tenant == nil {
+		logger.GetLogger().Errorf("Failed to get tenant for login channel: %v", err)
+		return ""
+	}
+	channel, derr := u.courierUseCase.GetChannel(ctx, tenant.Name, identifier)
+	if derr != nil {
+		logger.GetLogger().Errorf("Failed to get login channel: %v", derr)
+		return ""
+	}
+	return channel.Channel
+}
+
+// assessLogin fills in where the login came from and reports whether the tenant wants a login
+// flagged as new verified before the session is handed over
+func (u *userUseCase) assessLogin(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+	event *domain.LoginEvent,
+	assessRisk bool,
+) bool {
+	event.TenantID = tenantID.String()
+	event.GlobalUserID = globalUserID
+	if u.geoIPLocator != nil {
+		event.Country = u.geoIPLocator.Country(event.IPAddress)
+	}
+
+	action := constants.LoginRiskActionNone
+	if assessRisk {
+		if setting, derr := getTenantSetting(ctx, u.tenantSettingRepo, tenantID); derr != nil {
+			logger.GetLogger().Errorf("Failed to get tenant settings for login risk: %v", derr)
+		} else {
+			action = setting.LoginRiskAction
+		}
+	}
+	if action != constants.LoginRiskActionNone {
+		u.flagNewLogin(ctx, event)
+		if event.Risky() {
+			event.RiskAction = action
+			logger.GetLogger().Warnf("Login of user %s in tenant %s from new device=%t, new country=%t (%s)",
+				globalUserID, tenantID, event.NewDevice, event.NewCountry, event.Country)
+		}
+	}
+	return event.Risky() && action == constants.LoginRiskActionVerify
+}
+
+// recordLogin stores an assessed login in the user's history once its session is handed over.
+// Until then the device and country stay new, so retrying a challenged login is challenged again.
+// The history is an audit trail, so failing to write it does not fail the sign-in.
+func (u *userUseCase) recordLogin(ctx context.Context, tenantID uuid.UUID, event *domain.LoginEvent) {
+	if event == nil {
+		return
+	}
+	if err := u.loginEventRepo.Create(ctx, event); err != nil {
+		logger.GetLogger().Errorf("Failed to record login event: %v", err)
+	}
+	if event.NewDevice {
+		u.notifySecurityEvent(ctx, tenantID, types.SecurityNotice{
+			Event:        constants.SecurityEventNewDeviceLogin,
+			GlobalUserID: event.GlobalUserID,
+			Device:       event.UserAgent,
+			Country:      event.Country,
+		})
+	}
+}
+
+// flagNewLogin compares the login with the user's earlier ones. A user's first login, or their
+// first located one, has nothing to be compared with and is never flagged.
+func (u *userUseCase) flagNewLogin(ctx context.Context, event *domain.LoginEvent) {
+	logins, err := u.loginEventRepo.Count(ctx, event.TenantID, event.GlobalUserID)
+	if err != nil {
+		logger.GetLogger().Errorf("Failed to count login events: %v", err)
+		return
+	}
+	if logins == 0 {
+		return
+	}
+
+	if event.UserAgent != "" {
+		seen, err := u.loginEventRepo.HasUserAgent(ctx, event.TenantID, event.GlobalUserID, event.UserAgent)
+		if err != nil {
+			logger.GetLogger().Errorf("Failed to look up login device: %v", err)
+		} else {
+			event.NewDevice = !seen
+		}
+	}
+	if event.Country != "" {
+		countries, err := u.loginEventRepo.ListCountries(ctx, event.TenantID, event.GlobalUserID)
+		if err != nil {
+			logger.GetLogger().Errorf("Failed to list login countries: %v", err)
+		} else {
+			event.NewCountry = len(countries) > 0 && !slices.Contains(countries, event.Country)
+		}
+	}
+}
+
+// challengeLogin holds back the session of a login flagged as new and sends a code to another of
+// the user's identifiers, which VerifyMFA accepts in place of a second factor. It returns nil when
+// the user has no other identifier to send the code to.
+func (u *userUseCase) challengeLogin(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	resp *types.IdentityUserAuthResponse,
+	login *domain.LoginEvent,
+	rememberDevice bool,
+) (*types.IdentityUserAuthResponse, *domainerrors.DomainError) {
+	identities, err := u.userIdentityRepo.GetByGlobalUserIDAndTenantID(ctx, nil, resp.User.GlobalUserID, tenantID.String())
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_IDENTITIES_FAILED", "Failed to get user identities")
+	}
+	others := make([]*domain.UserIdentity, 0, len(identities))
+	for _, identity := range identities {
+		if identity.KratosUserID != resp.User.ID {
+			others = append(others, identity)
+		}
+	}
+	receiver := verifiableIdentity(others)
+	if receiver == nil {
+		return nil, nil
+	}
+
+	flowID, err := u.kratosService.InitializeVerificationFlow(ctx, tenantID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_VERIFICATION_FLOW_FAILED", "Failed to initialize verification flow")
+	}
+	identifier := receiver.Value
+	if _, err := u.kratosService.SubmitVerificationFlow(
+		ctx, tenantID, flowID, &identifier, constants.IdentifierType(receiver.Type), nil,
+	); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_SEND_VERIFICATION_FAILED", "Failed to send verification code")
+	}
+
+	challenge := &domain.ChallengeSession{
+		GlobalUserID:   resp.User.GlobalUserID,
+		KratosUserID:   resp.User.ID,
+		IdentifierType: receiver.Type,
+		Identifier:     receiver.Value,
+		ChallengeType:  constants.ChallengeTypeLoginVerify,
+		SessionToken:   resp.SessionToken,
+		Login:          login,
+		RememberDevice: rememberDevice,
+	}
+	if err := u.challengeSessionRepo.SaveChallenge(ctx, flowID, challenge, constants.MFAChallengeDuration); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_SAVE_CHALLENGE_FAILED", "Failed to save challenge session")
+	}
+
+	// Whoever signs in may not own the account, so the other identifier is not given away
+	return &types.IdentityUserAuthResponse{
+		AAL:         constants.AAL1,
+		MFARequired: true,
+		MFAFlow: &types.MFAChallengeResponse{
+			FlowID:      flowID,
+			Methods:     []string{constants.MFAMethodLoginCode},
+			ChallengeAt: time.Now().Unix(),
+			Receiver:    maskIdentifier(receiver.Type, receiver.Value),
+		},
+	}, nil
+}
+
+// checkLoginCode accepts the code challengeLogin sent. A wrong code keeps the challenge so the
+// user can try again.
+func (u *userUseCase) checkLoginCode(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	flowID string,
+	challenge *domain.ChallengeSession,
+	code string,
+) *domainerrors.DomainError {
+	identifier := challenge.Identifier
+	code = strings.TrimSpace(code)
+	result, err := u.kratosService.SubmitVerificationFlow(
+		ctx, tenantID, flowID, &identifier, constants.IdentifierType(challenge.IdentifierType), &code,
+	)
+	if err == nil && result != nil {
+		if state, ok := result.State.(string); ok && strings.EqualFold(state, constants.StatePassedChallenge) {
+			return nil
+		}
+	}
+	return domainerrors.NewValidationError("MSG_INVALID_MFA_CODE", "Invalid verification code", nil)
+}
@@ -0,0 +1,333 @@
+package ucases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	client "github.com/ory/kratos-client-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	mock_interfaces "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/interfaces"
+	mock_repositories "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/repositories"
+	mock_services "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/services"
+	mock_rate_limiter "github.com/lifenetwork-ai/iam-service/mocks/infrastructures/rate_limiter/types"
+)
+
+func loginContext() context.Context {
+	ctx := context.WithValue(context.Background(), constants.ClientIPKey, "203.0.113.7")
+	return context.WithValue(ctx, constants.UserAgentKey, "Mozilla/5.0")
+}
+
+func loginRiskSetting(tenantID uuid.UUID, action string) *domain.TenantSetting {
+	setting := domain.DefaultTenantSetting(tenantID)
+	setting.LoginRiskAction = action
+	return setting
+}
+
+func TestCompleteSignIn_FlagsLoginFromNewCountry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := loginContext()
+	tenantID := uuid.New()
+
+	locator := mock_services.NewMockGeoIPLocator(ctrl)
+	locator.EXPECT().Country("203.0.113.7").Return("US")
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(loginRiskSetting(tenantID, constants.LoginRiskActionEvent), nil).Times(3)
+
+	var recorded *domain.LoginEvent
+	loginEventRepo := mock_repositories.NewMockLoginEventRepository(ctrl)
+	loginEventRepo.EXPECT().Count(ctx, tenantID.String(), "global-1").Return(int64(4), nil)
+	loginEventRepo.EXPECT().HasUserAgent(ctx, tenantID.String(), "global-1", "Mozilla/5.0").Return(true, nil)
+	loginEventRepo.EXPECT().ListCountries(ctx, tenantID.String(), "global-1").Return([]string{"VN"}, nil)
+	loginEventRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, event *domain.LoginEvent) error {
+		recorded = event
+		return nil
+	})
+
+	mfaRepo := mock_repositories.NewMockUserMFARepository(ctrl)
+	mfaRepo.EXPECT().GetFactor(ctx, tenantID.String(), "global-1", constants.MFAFactorTOTP).Return(nil, nil)
+
+	tokenRepo := mock_repositories.NewMockSessionRefreshTokenRepository(ctrl)
+	tokenRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	sessionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	u := &userUseCase{
+		userAccountStatusRepo:   activeAccountStatusRepo(ctrl),
+		tenantSettingRepo:       settingRepo,
+		loginEventRepo:          loginEventRepo,
+		userMFARepo:             mfaRepo,
+		sessionRefreshTokenRepo: tokenRepo,
+		userSessionRepo:         sessionRepo,
+		geoIPLocator:            locator,
+	}
+
+	// The tenant only wants new logins flagged, so the session is handed over
+	resp, derr := u.completeSignIn(ctx, tenantID, firstFactorResponse(), signInOptions{
+		login:      newLoginEvent(ctx, constants.ChallengeTypeLogin, constants.ChannelSMS),
+		assessRisk: true,
+	})
+	require.Nil(t, derr)
+	assert.Equal(t, "token-1", resp.SessionToken)
+	assert.False(t, resp.MFARequired)
+
+	require.NotNil(t, recorded)
+	assert.Equal(t, tenantID.String(), recorded.TenantID)
+	assert.Equal(t, "global-1", recorded.GlobalUserID)
+	assert.Equal(t, constants.MethodTypeCode.String(), recorded.Method)
+	assert.Equal(t, constants.ChannelSMS, recorded.Channel)
+	assert.Equal(t, "203.0.113.7", recorded.IPAddress)
+	assert.Equal(t, "US", recorded.Country)
+	assert.False(t, recorded.NewDevice)
+	assert.True(t, recorded.NewCountry)
+	assert.Equal(t, constants.LoginRiskActionEvent, recorded.RiskAction)
+}
+
+func TestCompleteSignIn_FirstLoginIsNotFlagged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := loginContext()
+	tenantID := uuid.New()
+
+	locator := mock_services.NewMockGeoIPLocator(ctrl)
+	locator.EXPECT().Country("203.0.113.7").Return("US")
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(loginRiskSetting(tenantID, constants.LoginRiskActionVerify), nil).Times(3)
+
+	loginEventRepo := mock_repositories.NewMockLoginEventRepository(ctrl)
+	loginEventRepo.EXPECT().Count(ctx, tenantID.String(), "global-1").Return(int64(0), nil)
+	loginEventRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, event *domain.LoginEvent) error {
+		assert.False(t, event.Risky())
+		assert.Empty(t, event.RiskAction)
+		return nil
+	})
+
+	mfaRepo := mock_repositories.NewMockUserMFARepository(ctrl)
+	mfaRepo.EXPECT().GetFactor(ctx, tenantID.String(), "global-1", constants.MFAFactorTOTP).Return(nil, nil)
+
+	tokenRepo := mock_repositories.NewMockSessionRefreshTokenRepository(ctrl)
+	tokenRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	sessionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	u := &userUseCase{
+		userAccountStatusRepo:   activeAccountStatusRepo(ctrl),
+		tenantSettingRepo:       settingRepo,
+		loginEventRepo:          loginEventRepo,
+		userMFARepo:             mfaRepo,
+		sessionRefreshTokenRepo: tokenRepo,
+		userSessionRepo:         sessionRepo,
+		geoIPLocator:            locator,
+	}
+
+	resp, derr := u.completeSignIn(ctx, tenantID, firstFactorResponse(), signInOptions{
+		login:      newLoginEvent(ctx, constants.ChallengeTypeLogin, constants.ChannelEmail),
+		assessRisk: true,
+	})
+	require.Nil(t, derr)
+	assert.Equal(t, "token-1", resp.SessionToken)
+}
+
+func TestCompleteSignIn_VerifiesNewDeviceWithCodeToOtherIdentifier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := loginContext()
+	tenantID := uuid.New()
+
+	locator := mock_services.NewMockGeoIPLocator(ctrl)
+	locator.EXPECT().Country("203.0.113.7").Return("")
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(loginRiskSetting(tenantID, constants.LoginRiskActionVerify), nil)
+
+	// Neither recorded nor notified until the code is verified
+	loginEventRepo := mock_repositories.NewMockLoginEventRepository(ctrl)
+	loginEventRepo.EXPECT().Count(ctx, tenantID.String(), "global-1").Return(int64(2), nil)
+	loginEventRepo.EXPECT().HasUserAgent(ctx, tenantID.String(), "global-1", "Mozilla/5.0").Return(false, nil)
+
+	mfaRepo := mock_repositories.NewMockUserMFARepository(ctrl)
+	mfaRepo.EXPECT().GetFactor(ctx, tenantID.String(), "global-1", constants.MFAFactorTOTP).Return(nil, nil)
+
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	identityRepo.EXPECT().GetByGlobalUserIDAndTenantID(ctx, nil, "global-1", tenantID.String()).Return([]*domain.UserIdentity{
+		{KratosUserID: "kratos-1", Type: constants.IdentifierPhone.String(), Value: "+84901234567"},
+		{KratosUserID: "kratos-2", Type: constants.IdentifierEmail.String(), Value: "user@example.com"},
+	}, nil)
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().InitializeVerificationFlow(ctx, tenantID).Return("verify-flow-1", nil)
+	kratos.EXPECT().SubmitVerificationFlow(ctx, tenantID, "verify-flow-1", gomock.Any(), constants.IdentifierEmail, nil).Return(nil, nil)
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().SaveChallenge(ctx, "verify-flow-1", gomock.Any(), constants.MFAChallengeDuration).
+		DoAndReturn(func(_ context.Context, _ string, c *domain.ChallengeSession, _ time.Duration) error {
+			assert.Equal(t, constants.ChallengeTypeLoginVerify, c.ChallengeType)
+			assert.Equal(t, "user@example.com", c.Identifier)
+			assert.Equal(t, "token-1", c.SessionToken)
+			assert.True(t, c.RememberDevice)
+			require.NotNil(t, c.Login)
+			assert.True(t, c.Login.NewDevice)
+			assert.Equal(t, constants.LoginRiskActionVerify, c.Login.RiskAction)
+			return nil
+		})
+
+	u := &userUseCase{
+		userAccountStatusRepo: activeAccountStatusRepo(ctrl),
+		tenantSettingRepo:     settingRepo,
+		loginEventRepo:        loginEventRepo,
+		userMFARepo:           mfaRepo,
+		userIdentityRepo:      identityRepo,
+		challengeSessionRepo:  challengeRepo,
+		kratosService:         kratos,
+		geoIPLocator:          locator,
+		securityNotifier:      mock_interfaces.NewMockSecurityNotificationUseCase(ctrl),
+	}
+
+	resp, derr := u.completeSignIn(ctx, tenantID, firstFactorResponse(), signInOptions{
+		rememberDevice: true,
+		login:          newLoginEvent(ctx, constants.ChallengeTypeLogin, constants.ChannelSMS),
+		assessRisk:     true,
+	})
+	require.Nil(t, derr)
+	assert.True(t, resp.MFARequired)
+	assert.Empty(t, resp.SessionToken)
+	require.NotNil(t, resp.MFAFlow)
+	assert.Equal(t, "verify-flow-1", resp.MFAFlow.FlowID)
+	assert.Equal(t, []string{constants.MFAMethodLoginCode}, resp.MFAFlow.Methods)
+	assert.Equal(t, "u***@example.com", resp.MFAFlow.Receiver)
+}
+
+func TestCompleteSignIn_RetriedFlaggedLoginIsChallengedAgain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := loginContext()
+	tenantID := uuid.New()
+
+	locator := mock_services.NewMockGeoIPLocator(ctrl)
+	locator.EXPECT().Country("203.0.113.7").Return("").Times(2)
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(loginRiskSetting(tenantID, constants.LoginRiskActionVerify), nil).Times(2)
+
+	// The abandoned challenge leaves no history behind, so the device is still new on the retry
+	loginEventRepo := mock_repositories.NewMockLoginEventRepository(ctrl)
+	loginEventRepo.EXPECT().Count(ctx, tenantID.String(), "global-1").Return(int64(2), nil).Times(2)
+	loginEventRepo.EXPECT().HasUserAgent(ctx, tenantID.String(), "global-1", "Mozilla/5.0").Return(false, nil).Times(2)
+	loginEventRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+
+	mfaRepo := mock_repositories.NewMockUserMFARepository(ctrl)
+	mfaRepo.EXPECT().GetFactor(ctx, tenantID.String(), "global-1", constants.MFAFactorTOTP).
+		Return(enabledTOTPFactor(t, tenantID, "JBSWY3DPEHPK3PXP"), nil).Times(2)
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().SaveChallenge(ctx, gomock.Any(), gomock.Any(), constants.MFAChallengeDuration).Return(nil).Times(2)
+
+	u := &userUseCase{
+		userAccountStatusRepo: activeAccountStatusRepo(ctrl),
+		tenantSettingRepo:     settingRepo,
+		loginEventRepo:        loginEventRepo,
+		userMFARepo:           mfaRepo,
+		challengeSessionRepo:  challengeRepo,
+		geoIPLocator:          locator,
+	}
+
+	for i := 0; i < 2; i++ {
+		resp, derr := u.completeSignIn(ctx, tenantID, firstFactorResponse(), signInOptions{
+			login:      newLoginEvent(ctx, constants.ChallengeTypeLogin, constants.ChannelSMS),
+			assessRisk: true,
+		})
+		require.Nil(t, derr)
+		assert.True(t, resp.MFARequired)
+		assert.Empty(t, resp.SessionToken)
+	}
+}
+
+func TestVerifyMFA_AcceptsLoginVerificationCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	session := &client.Session{Id: "session-1", Active: client.PtrBool(true), Identity: &client.Identity{Id: "kratos-1"}}
+	login := &domain.LoginEvent{
+		TenantID:     tenantID.String(),
+		GlobalUserID: "global-1",
+		UserAgent:    "Mozilla/5.0",
+		NewDevice:    true,
+		RiskAction:   constants.LoginRiskActionVerify,
+	}
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().GetChallenge(ctx, "verify-flow-1").Return(&domain.ChallengeSession{
+		GlobalUserID:   "global-1",
+		KratosUserID:   "kratos-1",
+		IdentifierType: constants.IdentifierEmail.String(),
+		Identifier:     "user@example.com",
+		ChallengeType:  constants.ChallengeTypeLoginVerify,
+		SessionToken:   "token-1",
+		Login:          login,
+	}, nil)
+	challengeRepo.EXPECT().DeleteChallenge(ctx, "verify-flow-1").Return(nil)
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().SubmitVerificationFlow(ctx, tenantID, "verify-flow-1", gomock.Any(), constants.IdentifierEmail, gomock.Any()).
+		Return(&client.VerificationFlow{State: constants.StatePassedChallenge}, nil)
+	kratos.EXPECT().GetSession(ctx, tenantID, "token-1").Return(session, nil)
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(nil, nil).Times(2)
+
+	tokenRepo := mock_repositories.NewMockSessionRefreshTokenRepository(ctrl)
+	tokenRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	sessionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	// The verified login joins the history, so the device is known from now on
+	loginEventRepo := mock_repositories.NewMockLoginEventRepository(ctrl)
+	loginEventRepo.EXPECT().Create(ctx, login).Return(nil)
+
+	notifier := mock_interfaces.NewMockSecurityNotificationUseCase(ctrl)
+	notifier.EXPECT().Notify(ctx, tenantID, types.SecurityNotice{
+		Event:        constants.SecurityEventNewDeviceLogin,
+		GlobalUserID: "global-1",
+		Device:       "Mozilla/5.0",
+	})
+
+	u := &userUseCase{
+		rateLimiter:             rateLimiter,
+		challengeSessionRepo:    challengeRepo,
+		tenantSettingRepo:       settingRepo,
+		sessionRefreshTokenRepo: tokenRepo,
+		userSessionRepo:         sessionRepo,
+		loginEventRepo:          loginEventRepo,
+		kratosService:           kratos,
+		securityNotifier:        notifier,
+	}
+
+	resp, derr := u.VerifyMFA(ctx, tenantID, "verify-flow-1", "123456")
+	require.Nil(t, derr)
+	assert.Equal(t, "token-1", resp.SessionToken)
+	assert.Equal(t, constants.AAL1, resp.AAL, "a code sent to another identifier is not a second factor")
+	assert.NotEmpty(t, resp.RefreshToken)
+}
@@ -0,0 +1,451 @@
+package ucases
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+	"github.com/lifenetwork-ai/iam-service/packages/totp"
+	"github.com/lifenetwork-ai/iam-service/packages/utils"
+)
+
+// recoveryCodeAlphabet has 32 symbols without look-alikes, so a random byte maps onto it uniformly
+const recoveryCodeAlphabet = "abcdefghijkmnpqrstuvwxyz23456789"
+
+// EnrollTOTP starts a TOTP enrollment and returns the secret to load into an authenticator app.
+// The factor stays pending until ActivateTOTP confirms a code generated from it.
+func (u *userUseCase) EnrollTOTP(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+	accountName string,
+) (*types.TOTPEnrollmentResponse, *domainerrors.DomainError) {
+	// 1. Refuse to replace an active factor
+	factor, err := u.userMFARepo.GetFactor(ctx, tenantID.String(), globalUserID, constants.MFAFactorTOTP)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_MFA_FACTOR_FAILED", "Failed to get MFA factor")
+	}
+	if factor.Enabled() {
+		return nil, domainerrors.NewConflictError("MSG_MFA_ALREADY_ENABLED", "TOTP is already enabled", nil)
+	}
+
+	tenant, err := u.tenantRepo.GetByID(tenantID)
+	if err != nil || tenant == nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_TENANT_FAILED", "Failed to get tenant")
+	}
+
+	// 2. Generate and store the encrypted secret
+	secret, err := totp.GenerateSecret(constants.TOTPSecretBytes)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GENERATE_MFA_SECRET_FAILED", "Failed to generate TOTP secret")
+	}
+	encrypted, derr := encryptMFASecret(secret)
+	if derr != nil {
+		return nil, derr
+	}
+	if err := u.userMFARepo.SavePendingFactor(ctx, &domain.UserMFAFactor{
+		TenantID:     tenantID.String(),
+		GlobalUserID: globalUserID,
+		Type:         constants.MFAFactorTOTP,
+		Secret:       encrypted,
+	}); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_SAVE_MFA_FACTOR_FAILED", "Failed to save MFA factor")
+	}
+
+	// 3. Return the provisioning data
+	return &types.TOTPEnrollmentResponse{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(secret, tenant.Name, accountName),
+	}, nil
+}
+
+// ActivateTOTP enables a pending TOTP factor once the user proves their authenticator works.
+// The recovery codes are only ever returned here.
+func (u *userUseCase) ActivateTOTP(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+	code string,
+) (*types.MFARecoveryCodesResponse, *domainerrors.DomainError) {
+	// 1. Rate limit
+	key := fmt.Sprintf("mfa:activate:%s:tenant:%s", globalUserID, tenantID.String())
+	if err := utils.CheckRateLimitDomain(u.rateLimiter, key, constants.MaxAttemptsPerWindow, constants.RateLimitWindow); err != nil {
+		return nil, domainerrors.NewRateLimitError("MSG_RATE_LIMIT_EXCEEDED", "Rate limit exceeded", err)
+	}
+
+	// 2. Load pending factor
+	factor, err := u.userMFARepo.GetFactor(ctx, tenantID.String(), globalUserID, constants.MFAFactorTOTP)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_MFA_FACTOR_FAILED", "Failed to get MFA factor")
+	}
+	if factor == nil {
+		return nil, domainerrors.NewNotFoundError("MSG_MFA_NOT_ENROLLED", "TOTP enrollment")
+	}
+	if factor.Enabled() {
+		return nil, domainerrors.NewConflictError("MSG_MFA_ALREADY_ENABLED", "TOTP is already enabled", nil)
+	}
+
+	// 3. Check the code against the pending secret
+	secret, derr := decryptMFASecret(factor.Secret)
+	if derr != nil {
+		return nil, derr
+	}
+	step, ok := totp.Validate(secret, code, time.Now(), constants.TOTPSkewSteps)
+	if !ok {
+		return nil, domainerrors.NewValidationError("MSG_INVALID_MFA_CODE", "Invalid verification code", nil)
+	}
+
+	// 4. Devices remembered so far never passed this factor, so they stop skipping it
+	if _, err := u.trustedDeviceRepo.RevokeAll(ctx, tenantID.String(), globalUserID, time.Now()); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_REVOKE_TRUSTED_DEVICE_FAILED", "Failed to revoke trusted devices")
+	}
+
+	// 5. Enable the factor with a fresh set of recovery codes
+	codes, records, err := newRecoveryCodes(tenantID, globalUserID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GENERATE_RECOVERY_CODES_FAILED", "Failed to generate recovery codes")
+	}
+	if err := u.userMFARepo.EnableFactor(ctx, factor, step, records); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_ENABLE_MFA_FAILED", "Failed to enable TOTP")
+	}
+	// Lifts the enrollment restriction from the user's cached sessions
+	u.sessionCache.invalidateUser(globalUserID)
+
+	return &types.MFARecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// DisableTOTP removes the TOTP factor and its recovery codes after checking a current code
+func (u *userUseCase) DisableTOTP(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+	code string,
+) *domainerrors.DomainError {
+	// 1. Rate limit
+	key := fmt.Sprintf("mfa:disable:%s:tenant:%s", globalUserID, tenantID.String())
+	if err := utils.CheckRateLimitDomain(u.rateLimiter, key, constants.MaxAttemptsPerWindow, constants.RateLimitWindow); err != nil {
+		return domainerrors.NewRateLimitError("MSG_RATE_LIMIT_EXCEEDED", "Rate limit exceeded", err)
+	}
+
+	// 2. Tenants that mandate MFA do not let users opt out
+	setting, derr := getTenantSetting(ctx, u.tenantSettingRepo, tenantID)
+	if derr != nil {
+		return derr
+	}
+	if setting.MFARequired {
+		return domainerrors.NewValidationError("MSG_MFA_REQUIRED_BY_TENANT", "Multi-factor authentication is required by the tenant", nil)
+	}
+
+	// 3. Check the code
+	factor, err := u.userMFARepo.GetFactor(ctx, tenantID.String(), globalUserID, constants.MFAFactorTOTP)
+	if err != nil {
+		return domainerrors.WrapInternal(err, "MSG_GET_MFA_FACTOR_FAILED", "Failed to get MFA factor")
+	}
+	if !factor.Enabled() {
+		return domainerrors.NewNotFoundError("MSG_MFA_NOT_ENABLED", "TOTP factor")
+	}
+	if derr := u.checkSecondFactor(ctx, factor, code); derr != nil {
+		return derr
+	}
+
+	// 4. Remove the factor
+	if err := u.userMFARepo.DeleteFactor(ctx, tenantID.String(), globalUserID, constants.MFAFactorTOTP); err != nil {
+		return domainerrors.WrapInternal(err, "MSG_DISABLE_MFA_FAILED", "Failed to disable TOTP")
+	}
+	u.sessionCache.invalidateUser(globalUserID)
+	return nil
+}
+
+// VerifyMFA completes a sign-in held back by completeSignIn with a TOTP or recovery code, or with
+// the verification code sent for a login flagged as new
+func (u *userUseCase) VerifyMFA(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	flowID string,
+	code string,
+) (*types.IdentityUserAuthResponse, *domainerrors.DomainError) {
+	// 1. Rate limit verification attempts
+	key := "verify:mfa:" + flowID
+	if err := utils.CheckRateLimitDomain(u.rateLimiter, key, constants.MaxAttemptsPerWindow, constants.RateLimitWindow); err != nil {
+		return nil, domainerrors.NewRateLimitError("MSG_RATE_LIMIT_EXCEEDED", "Rate limit exceeded", err)
+	}
+
+	// 2. Load challenge
+	challenge, err := u.challengeSessionRepo.GetChallenge(ctx, flowID)
+	if err != nil || challenge == nil {
+		return nil, domainerrors.NewNotFoundError("MSG_CHALLENGE_SESSION_NOT_FOUND", "Challenge session")
+	}
+	if challenge.SessionToken == "" {
+		return nil, domainerrors.NewValidationError("MSG_INVALID_CHALLENGE_TYPE", "Invalid challenge type", nil)
+	}
+
+	// 3. Check the second factor, or the code sent for a login flagged as new
+	aal := constants.AAL2
+	switch challenge.ChallengeType {
+	case constants.ChallengeTypeMFA:
+		factor, err := u.userMFARepo.GetFactor(ctx, tenantID.String(), challenge.GlobalUserID, constants.MFAFactorTOTP)
+		if err != nil {
+			return nil, domainerrors.WrapInternal(err, "MSG_GET_MFA_FACTOR_FAILED", "Failed to get MFA factor")
+		}
+		if !factor.Enabled() {
+			return nil, domainerrors.NewUnauthorizedError("MSG_MFA_NOT_ENABLED", "Multi-factor authentication is not enabled")
+		}
+		if derr := u.checkSecondFactor(ctx, factor, code); derr != nil {
+			return nil, derr
+		}
+	case constants.ChallengeTypeLoginVerify:
+		if derr := u.checkLoginCode(ctx, tenantID, flowID, challenge, code); derr != nil {
+			return nil, derr
+		}
+		// A code sent to another identifier is not an enrolled factor
+		aal = constants.AAL1
+	default:
+		return nil, domainerrors.NewValidationError("MSG_INVALID_CHALLENGE_TYPE", "Invalid challenge type", nil)
+	}
+
+	// 4. The challenge is single use
+	_ = u.challengeSessionRepo.DeleteChallenge(ctx, flowID)
+
+	// 5. Release the session held for this challenge
+	session, err := u.kratosService.GetSession(ctx, tenantID, challenge.SessionToken)
+	if err != nil || session == nil {
+		return nil, domainerrors.NewUnauthorizedError("MSG_INVALID_SESSION", "Session expired, please sign in again")
+	}
+	resp := newAuthResponse(session, challenge.SessionToken)
+	resp.User.GlobalUserID = challenge.GlobalUserID
+	resp.AAL = aal
+	if aal == constants.AAL1 {
+		resp.MFAEnrollmentRequired = u.mfaEnrollmentRequired(ctx, tenantID, false)
+	}
+	u.startSession(ctx, tenantID, resp, challenge.RememberDevice)
+	u.recordLogin(ctx, tenantID, challenge.Login)
+
+	return resp, nil
+}
+
+// completeSignIn finishes every first-factor sign-in. Users with an enabled factor get an MFA
+// challenge instead of the session, unless the tenant lets their trusted device skip it; everyone
+// else gets an aal1 session with a refresh token. A login the tenant wants verified because it
+// comes from a new device or country always gets a challenge: the second factor when the user has
+// one, otherwise a code sent to another of their identifiers.
+func (u *userUseCase) completeSignIn(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	resp *types.IdentityUserAuthResponse,
+	opts signInOptions,
+) (*types.IdentityUserAuthResponse, *domainerrors.DomainError) {
+	if resp.User != nil && resp.User.GlobalUserID == "" {
+		if identity, err := u.userIdentityRepo.GetByTenantAndKratosUserID(ctx, nil, tenantID.String(), resp.User.ID); err == nil && identity != nil {
+			resp.User.GlobalUserID = identity.GlobalUserID
+		}
+	}
+
+	// 1. Refuse suspended and banned users, ending the session Kratos has just issued
+	if resp.User != nil && resp.User.GlobalUserID != "" {
+		if derr := u.checkAccountStatus(ctx, tenantID, resp.User.GlobalUserID); derr != nil {
+			if resp.SessionID != "" {
+				if err := u.kratosService.DisableSessionAdmin(ctx, tenantID, resp.SessionID); err != nil {
+					logger.GetLogger().Errorf("Failed to disable session %s of blocked user: %v", resp.SessionID, err)
+				}
+			}
+			return nil, derr
+		}
+	}
+
+	// 2. Assess the login, which may call for verifying it. It is recorded with the session, so a
+	// challenged login that is retried instead of verified is challenged again.
+	var login *domain.LoginEvent
+	verifyLogin := false
+	if opts.login != nil && resp.User != nil && resp.User.GlobalUserID != "" {
+		login = opts.login
+		verifyLogin = u.assessLogin(ctx, tenantID, resp.User.GlobalUserID, login, opts.assessRisk)
+	}
+
+	// 3. Hold the session back while the second factor is outstanding
+	mfaEnabled := false
+	if resp.User != nil && resp.User.GlobalUserID != "" {
+		factor, err := u.userMFARepo.GetFactor(ctx, tenantID.String(), resp.User.GlobalUserID, constants.MFAFactorTOTP)
+		if err != nil {
+			return nil, domainerrors.WrapInternal(err, "MSG_GET_MFA_FACTOR_FAILED", "Failed to get MFA factor")
+		}
+		mfaEnabled = factor.Enabled()
+		if mfaEnabled && (verifyLogin || !u.skipsSecondFactor(ctx, tenantID, resp.User.GlobalUserID)) {
+			flowID := uuid.NewString()
+			challenge := &domain.ChallengeSession{
+				GlobalUserID:   resp.User.GlobalUserID,
+				KratosUserID:   resp.User.ID,
+				ChallengeType:  constants.ChallengeTypeMFA,
+				SessionToken:   resp.SessionToken,
+				Login:          login,
+				RememberDevice: opts.rememberDevice,
+			}
+			if err := u.challengeSessionRepo.SaveChallenge(ctx, flowID, challenge, constants.MFAChallengeDuration); err != nil {
+				return nil, domainerrors.WrapInternal(err, "MSG_SAVE_CHALLENGE_FAILED", "Failed to save challenge session")
+			}
+			return &types.IdentityUserAuthResponse{
+				AAL:         constants.AAL1,
+				MFARequired: true,
+				MFAFlow: &types.MFAChallengeResponse{
+					FlowID:      flowID,
+					Methods:     []string{constants.MFAFactorTOTP, constants.MFAMethodRecoveryCode},
+					ChallengeAt: time.Now().Unix(),
+				},
+			}, nil
+		}
+	}
+
+	// 4. Users without a second factor verify a flagged login with a code sent elsewhere. Those
+	// with nowhere else to send it to are let in; their login stays flagged in the history.
+	if verifyLogin && !mfaEnabled {
+		challenged, derr := u.challengeLogin(ctx, tenantID, resp, login, opts.rememberDevice)
+		if derr != nil {
+			return nil, derr
+		}
+		if challenged != nil {
+			return challenged, nil
+		}
+	}
+
+	// 5. Flag users of tenants that mandate MFA so the client can send them through enrollment;
+	// until they enroll, RequireAuth only lets the session reach the enrollment endpoints
+	resp.AAL = constants.AAL1
+	resp.MFAEnrollmentRequired = u.mfaEnrollmentRequired(ctx, tenantID, mfaEnabled)
+	u.startSession(ctx, tenantID, resp, opts.rememberDevice)
+	u.recordLogin(ctx, tenantID, login)
+
+	return resp, nil
+}
+
+// mfaEnrollmentRequired reports whether the tenant mandates MFA and the user has yet to enroll
+func (u *userUseCase) mfaEnrollmentRequired(ctx context.Context, tenantID uuid.UUID, mfaEnabled bool) bool {
+	setting, derr := getTenantSetting(ctx, u.tenantSettingRepo, tenantID)
+	if derr != nil {
+		logger.GetLogger().Errorf("Failed to get tenant settings for MFA policy: %v", derr)
+		return false
+	}
+	return setting.MFARequired && !mfaEnabled
+}
+
+// mfaEnrollmentPending reports whether the tenant mandates MFA and the user has yet to enable
+// TOTP. Unlike mfaEnrollmentRequired it gates access, so it fails closed.
+func (u *userUseCase) mfaEnrollmentPending(ctx context.Context, tenantID uuid.UUID, globalUserID string) (bool, *domainerrors.DomainError) {
+	setting, derr := getTenantSetting(ctx, u.tenantSettingRepo, tenantID)
+	if derr != nil {
+		return false, derr
+	}
+	if !setting.MFARequired {
+		return false, nil
+	}
+	factor, err := u.userMFARepo.GetFactor(ctx, tenantID.String(), globalUserID, constants.MFAFactorTOTP)
+	if err != nil {
+		return false, domainerrors.WrapInternal(err, "MSG_GET_MFA_FACTOR_FAILED", "Failed to get MFA factor")
+	}
+	return !factor.Enabled(), nil
+}
+
+// checkSecondFactor accepts a current TOTP code or an unused recovery code, each at most once
+func (u *userUseCase) checkSecondFactor(ctx context.Context, factor *domain.UserMFAFactor, code string) *domainerrors.DomainError {
+	code = strings.TrimSpace(code)
+	invalid := domainerrors.NewValidationError("MSG_INVALID_MFA_CODE", "Invalid verification code", nil)
+
+	if len(code) == totp.Digits {
+		secret, derr := decryptMFASecret(factor.Secret)
+		if derr != nil {
+			return derr
+		}
+		step, ok := totp.Validate(secret, code, time.Now(), constants.TOTPSkewSteps)
+		if !ok {
+			return invalid
+		}
+		consumed, err := u.userMFARepo.ConsumeStep(ctx, factor.ID, step)
+		if err != nil {
+			return domainerrors.WrapInternal(err, "MSG_CONSUME_MFA_CODE_FAILED", "Failed to record MFA code")
+		}
+		if !consumed {
+			return domainerrors.NewValidationError("MSG_MFA_CODE_ALREADY_USED", "Verification code has already been used", nil)
+		}
+		return nil
+	}
+
+	used, err := u.userMFARepo.UseRecoveryCode(ctx, factor.TenantID, factor.GlobalUserID, utils.HashToken(normalizeRecoveryCode(code)), time.Now())
+	if err != nil {
+		return domainerrors.WrapInternal(err, "MSG_CONSUME_MFA_CODE_FAILED", "Failed to record MFA code")
+	}
+	if !used {
+		return invalid
+	}
+	return nil
+}
+
+// newRecoveryCodes returns the clear codes to show the user once and the hashed records to store
+func newRecoveryCodes(tenantID uuid.UUID, globalUserID string) ([]string, []*domain.UserMFARecoveryCode, error) {
+	codes := make([]string, 0, constants.MFARecoveryCodeCount)
+	records := make([]*domain.UserMFARecoveryCode, 0, constants.MFARecoveryCodeCount)
+	buf := make([]byte, constants.MFARecoveryCodeLength)
+	for range constants.MFARecoveryCodeCount {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		raw := make([]byte, len(buf))
+		for i, b := range buf {
+			raw[i] = recoveryCodeAlphabet[b&31]
+		}
+		half := len(raw) / 2
+		codes = append(codes, string(raw[:half])+"-"+string(raw[half:]))
+		records = append(records, &domain.UserMFARecoveryCode{
+			TenantID:     tenantID.String(),
+			GlobalUserID: globalUserID,
+			CodeHash:     utils.HashToken(string(raw)),
+		})
+	}
+	return codes, records, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// mfaSecretKey derives the AES key for TOTP secrets from the database encryption key
+func mfaSecretKey() ([32]byte, *domainerrors.DomainError) {
+	dbKey := conf.GetConfiguration().DbEncryptionKey
+	if dbKey == "" {
+		return [32]byte{}, domainerrors.WrapInternal(errors.New("db encryption key is empty"), "MSG_MFA_NOT_CONFIGURED", "Multi-factor authentication is not configured")
+	}
+	return utils.DeriveKey(dbKey, constants.KeyPurposeMFASecret), nil
+}
+
+func encryptMFASecret(secret string) (string, *domainerrors.DomainError) {
+	key, derr := mfaSecretKey()
+	if derr != nil {
+		return "", derr
+	}
+	encrypted, err := utils.Encrypt(key, secret)
+	if err != nil {
+		return "", domainerrors.WrapInternal(err, "MSG_ENCRYPT_MFA_SECRET_FAILED", "Failed to encrypt TOTP secret")
+	}
+	return encrypted, nil
+}
+
+func decryptMFASecret(encrypted string) (string, *domainerrors.DomainError) {
+	key, derr := mfaSecretKey()
+	if derr != nil {
+		return "", derr
+	}
+	secret, err := utils.Decrypt(key, encrypted)
+	if err != nil {
+		return "", domainerrors.WrapInternal(err, "MSG_DECRYPT_MFA_SECRET_FAILED", "Failed to decrypt TOTP secret")
+	}
+	return secret, nil
+}
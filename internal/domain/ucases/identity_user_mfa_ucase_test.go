@@ -0,0 +1,488 @@
+package ucases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	client "github.com/ory/kratos-client-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	mock_repositories "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/repositories"
+	mock_services "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/services"
+	mock_rate_limiter "github.com/lifenetwork-ai/iam-service/mocks/infrastructures/rate_limiter/types"
+	"github.com/lifenetwork-ai/iam-service/packages/totp"
+	"github.com/lifenetwork-ai/iam-service/packages/utils"
+)
+
+// enabledTOTPFactor also sets the encryption key the factor's secret is sealed with for the test
+func enabledTOTPFactor(t *testing.T, tenantID uuid.UUID, secret string) *domain.UserMFAFactor {
+	t.Helper()
+	previousKey := conf.GetConfiguration().DbEncryptionKey
+	conf.GetConfiguration().DbEncryptionKey = "test-encryption-key"
+	t.Cleanup(func() { conf.GetConfiguration().DbEncryptionKey = previousKey })
+
+	encrypted, derr := encryptMFASecret(secret)
+	require.Nil(t, derr)
+	enabledAt := time.Now().Add(-time.Hour)
+	return &domain.UserMFAFactor{
+		ID:           uuid.NewString(),
+		TenantID:     tenantID.String(),
+		GlobalUserID: "global-1",
+		Type:         constants.MFAFactorTOTP,
+		Secret:       encrypted,
+		EnabledAt:    &enabledAt,
+	}
+}
+
+func firstFactorResponse() *types.IdentityUserAuthResponse {
+	return &types.IdentityUserAuthResponse{
+		SessionID:    "session-1",
+		SessionToken: "token-1",
+		User:         &types.IdentityUserResponse{ID: "kratos-1", GlobalUserID: "global-1"},
+	}
+}
+
+func TestCompleteSignIn_WithholdsSessionWhenFactorEnabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	mfaRepo := mock_repositories.NewMockUserMFARepository(ctrl)
+	mfaRepo.EXPECT().GetFactor(ctx, tenantID.String(), "global-1", constants.MFAFactorTOTP).
+		Return(enabledTOTPFactor(t, tenantID, "JBSWY3DPEHPK3PXP"), nil)
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().SaveChallenge(ctx, gomock.Any(), gomock.Any(), constants.MFAChallengeDuration).
+		DoAndReturn(func(_ context.Context, _ string, c *domain.ChallengeSession, _ time.Duration) error {
+			assert.Equal(t, constants.ChallengeTypeMFA, c.ChallengeType)
+			assert.Equal(t, "token-1", c.SessionToken)
+			assert.Equal(t, "global-1", c.GlobalUserID)
+			return nil
+		})
+
+	// No device token was sent, so the factor cannot be skipped
+	u := &userUseCase{
+		userAccountStatusRepo: activeAccountStatusRepo(ctrl),
+		userMFARepo:           mfaRepo,
+		challengeSessionRepo:  challengeRepo,
+	}
+
+	resp, derr := u.completeSignIn(ctx, tenantID, firstFactorResponse(), signInOptions{})
+	require.Nil(t, derr)
+	assert.True(t, resp.MFARequired)
+	assert.Equal(t, constants.AAL1, resp.AAL)
+	assert.Empty(t, resp.SessionToken)
+	assert.Empty(t, resp.RefreshToken)
+	require.NotNil(t, resp.MFAFlow)
+	assert.NotEmpty(t, resp.MFAFlow.FlowID)
+}
+
+func TestCompleteSignIn_FlagsMandatoryEnrollment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	setting := domain.DefaultTenantSetting(tenantID)
+	setting.MFARequired = true
+
+	mfaRepo := mock_repositories.NewMockUserMFARepository(ctrl)
+	mfaRepo.EXPECT().GetFactor(ctx, tenantID.String(), "global-1", constants.MFAFactorTOTP).Return(nil, nil)
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(setting, nil).Times(2)
+
+	tokenRepo := mock_repositories.NewMockSessionRefreshTokenRepository(ctrl)
+	tokenRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	sessionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	u := &userUseCase{
+		userAccountStatusRepo:   activeAccountStatusRepo(ctrl),
+		userMFARepo:             mfaRepo,
+		tenantSettingRepo:       settingRepo,
+		sessionRefreshTokenRepo: tokenRepo,
+		userSessionRepo:         sessionRepo,
+	}
+
+	resp, derr := u.completeSignIn(ctx, tenantID, firstFactorResponse(), signInOptions{})
+	require.Nil(t, derr)
+	assert.Equal(t, "token-1", resp.SessionToken)
+	assert.NotEmpty(t, resp.RefreshToken)
+	assert.Equal(t, constants.AAL1, resp.AAL)
+	assert.True(t, resp.MFAEnrollmentRequired)
+	assert.False(t, resp.MFARequired)
+}
+
+func TestProfile_RestrictsSessionUntilMandatoryEnrollment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := uuid.New()
+	ctx := context.WithValue(context.Background(), constants.SessionTokenKey, "token-1")
+	setting := domain.DefaultTenantSetting(tenantID)
+	setting.MFARequired = true
+	session := &client.Session{
+		Id:       "session-1",
+		Identity: &client.Identity{Id: "kratos-1", Traits: map[string]interface{}{"tenant": "genetica"}},
+	}
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().WhoAmI(ctx, tenantID, "token-1").Return(session, nil).Times(2)
+
+	sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	sessionRepo.EXPECT().Touch(ctx, tenantID.String(), "session-1", gomock.Any(), constants.SessionActivityInterval).Return(nil).Times(2)
+
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	identityRepo.EXPECT().ListByTenantAndKratosUserID(ctx, nil, tenantID.String(), "kratos-1").Return([]*domain.UserIdentity{
+		{GlobalUserID: "global-1", Type: constants.IdentifierEmail.String(), Value: "alice@example.com"},
+	}, nil).Times(2)
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(setting, nil).Times(2)
+
+	mfaRepo := mock_repositories.NewMockUserMFARepository(ctrl)
+	gomock.InOrder(
+		mfaRepo.EXPECT().GetFactor(ctx, tenantID.String(), "global-1", constants.MFAFactorTOTP).Return(nil, nil),
+		mfaRepo.EXPECT().GetFactor(ctx, tenantID.String(), "global-1", constants.MFAFactorTOTP).
+			Return(enabledTOTPFactor(t, tenantID, "JBSWY3DPEHPK3PXP"), nil),
+	)
+
+	u := &userUseCase{
+		userAccountStatusRepo: activeAccountStatusRepo(ctrl),
+		userIdentityRepo:      identityRepo,
+		userSessionRepo:       sessionRepo,
+		userMFARepo:           mfaRepo,
+		tenantSettingRepo:     settingRepo,
+		kratosService:         kratos,
+		sessionCache:          newTestSessionCache(time.Minute),
+	}
+
+	user, derr := u.Profile(ctx, tenantID)
+	require.Nil(t, derr)
+	assert.True(t, user.MFAEnrollmentRequired)
+
+	// Enabling TOTP drops the cached session, so the restriction is lifted on the next request
+	u.sessionCache.invalidateUser("global-1")
+	user, derr = u.Profile(ctx, tenantID)
+	require.Nil(t, derr)
+	assert.False(t, user.MFAEnrollmentRequired)
+}
+
+func TestIntrospectToken_InactiveUntilMandatoryEnrollment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	setting := domain.DefaultTenantSetting(tenantID)
+	setting.MFARequired = true
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().WhoAmI(ctx, tenantID, "token-1").Return(&client.Session{
+		Id:       "session-1",
+		Active:   client.PtrBool(true),
+		Identity: &client.Identity{Id: "kratos-1"},
+	}, nil)
+
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	identityRepo.EXPECT().ListByTenantAndKratosUserID(ctx, nil, tenantID.String(), "kratos-1").Return([]*domain.UserIdentity{
+		{GlobalUserID: "global-1", Type: constants.IdentifierEmail.String(), Value: "alice@example.com"},
+	}, nil)
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(setting, nil)
+
+	mfaRepo := mock_repositories.NewMockUserMFARepository(ctrl)
+	mfaRepo.EXPECT().GetFactor(ctx, tenantID.String(), "global-1", constants.MFAFactorTOTP).Return(nil, nil)
+
+	u := &userUseCase{
+		userAccountStatusRepo: activeAccountStatusRepo(ctrl),
+		userIdentityRepo:      identityRepo,
+		userMFARepo:           mfaRepo,
+		tenantSettingRepo:     settingRepo,
+		kratosService:         kratos,
+	}
+
+	resp, derr := u.IntrospectToken(ctx, tenantID, "token-1")
+	require.Nil(t, derr)
+	assert.False(t, resp.Active)
+}
+
+func TestVerifyMFA_ExpiredChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").Return(nil, nil)
+
+	u := &userUseCase{
+		rateLimiter:          rateLimiter,
+		challengeSessionRepo: challengeRepo,
+		kratosService:        mock_services.NewMockKratosService(ctrl),
+	}
+
+	resp, derr := u.VerifyMFA(ctx, uuid.New(), "flow-1", "123456")
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_CHALLENGE_SESSION_NOT_FOUND", derr.Code)
+	assert.Equal(t, domainerrors.ErrorTypeNotFound, derr.Type)
+}
+
+func TestVerifyMFA_TOTPCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	secret, err := totp.GenerateSecret(constants.TOTPSecretBytes)
+	require.NoError(t, err)
+	factor := enabledTOTPFactor(t, tenantID, secret)
+	step := totp.Step(time.Now())
+	code, err := totp.Code(secret, step)
+	require.NoError(t, err)
+	session := &client.Session{Id: "session-1", Active: client.PtrBool(true), Identity: &client.Identity{Id: "kratos-1"}}
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").Return(&domain.ChallengeSession{
+		GlobalUserID:  "global-1",
+		KratosUserID:  "kratos-1",
+		ChallengeType: constants.ChallengeTypeMFA,
+		SessionToken:  "token-1",
+	}, nil)
+	challengeRepo.EXPECT().DeleteChallenge(ctx, "flow-1").Return(nil)
+
+	mfaRepo := mock_repositories.NewMockUserMFARepository(ctrl)
+	mfaRepo.EXPECT().GetFactor(ctx, tenantID.String(), "global-1", constants.MFAFactorTOTP).Return(factor, nil)
+	mfaRepo.EXPECT().ConsumeStep(ctx, factor.ID, step).Return(true, nil)
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().GetSession(ctx, tenantID, "token-1").Return(session, nil)
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(nil, nil)
+
+	tokenRepo := mock_repositories.NewMockSessionRefreshTokenRepository(ctrl)
+	tokenRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	sessionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	u := &userUseCase{
+		rateLimiter:             rateLimiter,
+		challengeSessionRepo:    challengeRepo,
+		userMFARepo:             mfaRepo,
+		tenantSettingRepo:       settingRepo,
+		sessionRefreshTokenRepo: tokenRepo,
+		userSessionRepo:         sessionRepo,
+		kratosService:           kratos,
+	}
+
+	resp, derr := u.VerifyMFA(ctx, tenantID, "flow-1", code)
+	require.Nil(t, derr)
+	assert.Equal(t, "token-1", resp.SessionToken)
+	assert.Equal(t, constants.AAL2, resp.AAL)
+	assert.Equal(t, "global-1", resp.User.GlobalUserID)
+	assert.NotEmpty(t, resp.RefreshToken)
+}
+
+func TestVerifyMFA_ReplayedTOTPCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	secret, err := totp.GenerateSecret(constants.TOTPSecretBytes)
+	require.NoError(t, err)
+	factor := enabledTOTPFactor(t, tenantID, secret)
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	require.NoError(t, err)
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").Return(&domain.ChallengeSession{
+		GlobalUserID:  "global-1",
+		ChallengeType: constants.ChallengeTypeMFA,
+		SessionToken:  "token-1",
+	}, nil)
+
+	mfaRepo := mock_repositories.NewMockUserMFARepository(ctrl)
+	mfaRepo.EXPECT().GetFactor(ctx, tenantID.String(), "global-1", constants.MFAFactorTOTP).Return(factor, nil)
+	mfaRepo.EXPECT().ConsumeStep(ctx, factor.ID, gomock.Any()).Return(false, nil)
+
+	u := &userUseCase{
+		rateLimiter:          rateLimiter,
+		challengeSessionRepo: challengeRepo,
+		userMFARepo:          mfaRepo,
+		kratosService:        mock_services.NewMockKratosService(ctrl),
+	}
+
+	_, derr := u.VerifyMFA(ctx, tenantID, "flow-1", code)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_MFA_CODE_ALREADY_USED", derr.Code)
+}
+
+func TestVerifyMFA_RecoveryCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		used     bool
+		wantCode string
+	}{
+		{"unused code", true, ""},
+		{"unknown or used code", false, "MSG_INVALID_MFA_CODE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			tenantID := uuid.New()
+			factor := enabledTOTPFactor(t, tenantID, "JBSWY3DPEHPK3PXP")
+
+			rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+			rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+			rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+			challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").Return(&domain.ChallengeSession{
+				GlobalUserID:  "global-1",
+				ChallengeType: constants.ChallengeTypeMFA,
+				SessionToken:  "token-1",
+			}, nil)
+
+			// Recovery codes are matched case-insensitively and without the separator
+			mfaRepo := mock_repositories.NewMockUserMFARepository(ctrl)
+			mfaRepo.EXPECT().GetFactor(ctx, tenantID.String(), "global-1", constants.MFAFactorTOTP).Return(factor, nil)
+			mfaRepo.EXPECT().UseRecoveryCode(ctx, tenantID.String(), "global-1", utils.HashToken("abcde23456"), gomock.Any()).Return(tt.used, nil)
+
+			kratos := mock_services.NewMockKratosService(ctrl)
+			settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+			tokenRepo := mock_repositories.NewMockSessionRefreshTokenRepository(ctrl)
+			sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+			if tt.used {
+				challengeRepo.EXPECT().DeleteChallenge(ctx, "flow-1").Return(nil)
+				kratos.EXPECT().GetSession(ctx, tenantID, "token-1").
+					Return(&client.Session{Id: "session-1", Identity: &client.Identity{Id: "kratos-1"}}, nil)
+				settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(nil, nil)
+				tokenRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+				sessionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+			}
+
+			u := &userUseCase{
+				rateLimiter:             rateLimiter,
+				challengeSessionRepo:    challengeRepo,
+				userMFARepo:             mfaRepo,
+				tenantSettingRepo:       settingRepo,
+				sessionRefreshTokenRepo: tokenRepo,
+				userSessionRepo:         sessionRepo,
+				kratosService:           kratos,
+			}
+
+			resp, derr := u.VerifyMFA(ctx, tenantID, "flow-1", "ABCDE-23456")
+			if tt.wantCode != "" {
+				require.NotNil(t, derr)
+				assert.Equal(t, tt.wantCode, derr.Code)
+				return
+			}
+			require.Nil(t, derr)
+			assert.Equal(t, constants.AAL2, resp.AAL)
+		})
+	}
+}
+
+func TestActivateTOTP_ReturnsRecoveryCodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	secret, err := totp.GenerateSecret(constants.TOTPSecretBytes)
+	require.NoError(t, err)
+	factor := enabledTOTPFactor(t, tenantID, secret)
+	factor.EnabledAt = nil
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	require.NoError(t, err)
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	mfaRepo := mock_repositories.NewMockUserMFARepository(ctrl)
+	mfaRepo.EXPECT().GetFactor(ctx, tenantID.String(), "global-1", constants.MFAFactorTOTP).Return(factor, nil)
+	mfaRepo.EXPECT().EnableFactor(ctx, factor, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *domain.UserMFAFactor, _ int64, codes []*domain.UserMFARecoveryCode) error {
+			assert.Len(t, codes, constants.MFARecoveryCodeCount)
+			return nil
+		})
+
+	deviceRepo := mock_repositories.NewMockTrustedDeviceRepository(ctrl)
+	deviceRepo.EXPECT().RevokeAll(ctx, tenantID.String(), "global-1", gomock.Any()).Return(int64(0), nil)
+
+	u := &userUseCase{
+		rateLimiter:       rateLimiter,
+		userMFARepo:       mfaRepo,
+		trustedDeviceRepo: deviceRepo,
+	}
+
+	resp, derr := u.ActivateTOTP(ctx, tenantID, "global-1", code)
+	require.Nil(t, derr)
+	require.Len(t, resp.RecoveryCodes, constants.MFARecoveryCodeCount)
+	assert.Len(t, resp.RecoveryCodes[0], constants.MFARecoveryCodeLength+1)
+}
+
+func TestDisableTOTP_RefusedWhenTenantRequiresMFA(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	setting := domain.DefaultTenantSetting(tenantID)
+	setting.MFARequired = true
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(setting, nil)
+
+	// The factor is kept without looking at the code
+	u := &userUseCase{
+		rateLimiter:       rateLimiter,
+		tenantSettingRepo: settingRepo,
+		userMFARepo:       mock_repositories.NewMockUserMFARepository(ctrl),
+	}
+
+	derr := u.DisableTOTP(ctx, tenantID, "global-1", "123456")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_MFA_REQUIRED_BY_TENANT", derr.Code)
+}
@@ -0,0 +1,334 @@
+package ucases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+	"github.com/lifenetwork-ai/iam-service/packages/utils"
+)
+
+// errOIDCTypeTaken signals that the GlobalUser already holds another account of the same provider
+var errOIDCTypeTaken = errors.New("user already has an identity of this provider")
+
+// LoginWithOIDC signs a user in with a Google or Apple ID token.
+// The first sign-in registers a Kratos identity for the provider subject; it joins the
+// GlobalUser that owns the same email when the provider vouches for that email,
+// otherwise a new GlobalUser is created.
+func (u *userUseCase) LoginWithOIDC(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	provider string,
+	idToken string,
+	nonce string,
+	lang string,
+) (*types.IdentityUserAuthResponse, *domainerrors.DomainError) {
+	// 1. Verify the ID token
+	claims, derr := u.verifyOIDCToken(ctx, provider, idToken, nonce)
+	if derr != nil {
+		return nil, derr
+	}
+
+	// 2. Rate limit per provider subject
+	key := fmt.Sprintf("login:oidc:%s:tenant:%s:%s", provider, claims.Subject, tenantID.String())
+	if err := utils.CheckRateLimitDomain(u.rateLimiter, key, constants.MaxAttemptsPerWindow, constants.RateLimitWindow); err != nil {
+		return nil, domainerrors.NewRateLimitError("MSG_RATE_LIMIT_EXCEEDED", "Rate limit exceeded", err)
+	}
+
+	// 3. Look up the social identity
+	identity, err := u.userIdentityRepo.GetByTypeAndValue(ctx, nil, tenantID.String(), provider, claims.Subject)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainerrors.WrapInternal(err, "MSG_IAM_LOOKUP_FAILED", "Failed to look up identity")
+	}
+
+	var resp *types.IdentityUserAuthResponse
+	if identity != nil {
+		// 4a. Known subject: sign in to its Kratos identity
+		flow, err := u.kratosService.InitializeLoginFlow(ctx, tenantID)
+		if err != nil {
+			logger.GetLogger().Errorf("Failed to initialize login flow: %v", err)
+			return nil, domainerrors.WrapInternal(err, "MSG_INITIALIZE_LOGIN_FAILED", "Failed to initialize login flow")
+		}
+		loginResult, err := u.kratosService.SubmitLoginFlowWithIDToken(ctx, tenantID, flow, provider, idToken, nonce)
+		if err != nil || loginResult.SessionToken == nil {
+			logger.GetLogger().Errorf("Failed to submit login flow with id token: %v", err)
+			return nil, domainerrors.NewUnauthorizedError("MSG_LOGIN_FAILED", "Login failed").WithCause(err)
+		}
+		resp = newAuthResponse(&loginResult.Session, *loginResult.SessionToken)
+		resp.User.GlobalUserID = identity.GlobalUserID
+	} else {
+		// 4b. New subject: register it and bind it to a GlobalUser
+		resp, derr = u.registerOIDCIdentity(ctx, tenantID, claims, idToken, nonce, lang)
+		if derr != nil {
+			return nil, derr
+		}
+	}
+
+	// 5. Return authentication response
+	return u.completeSignIn(ctx, tenantID, resp, signInOptions{})
+}
+
+// registerOIDCIdentity creates the Kratos identity for a first-time social sign-in and its IAM records
+func (u *userUseCase) registerOIDCIdentity(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	claims *types.OIDCClaims,
+	idToken string,
+	nonce string,
+	lang string,
+) (*types.IdentityUserAuthResponse, *domainerrors.DomainError) {
+	tenant, err := u.tenantRepo.GetByID(tenantID)
+	if err != nil || tenant == nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_TENANT_FAILED", "Failed to get tenant")
+	}
+
+	// A verified email lets the social account join the user who registered that email
+	var linkedGlobalUserID string
+	if claims.EmailVerified && claims.Email != "" {
+		owner, err := u.userIdentityRepo.GetByTypeAndValue(ctx, nil, tenantID.String(), constants.IdentifierEmail.String(), claims.Email)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.WrapInternal(err, "MSG_IAM_LOOKUP_FAILED", "Failed to look up identity")
+		}
+		if owner != nil {
+			linkedGlobalUserID = owner.GlobalUserID
+			if mapping, err := u.userIdentifierMappingRepo.GetByGlobalUserID(ctx, owner.GlobalUserID); err == nil && mapping != nil && mapping.Lang != "" {
+				lang = mapping.Lang
+			}
+		}
+	}
+	if lang == "" {
+		lang = constants.LangEN
+	}
+	// Joining an existing user is not a registration
+	if linkedGlobalUserID == "" {
+		if derr := u.checkRegistrationAllowed(ctx, tenantID, claims.Provider, claims.Subject); derr != nil {
+			return nil, derr
+		}
+	}
+
+	flow, err := u.kratosService.InitializeRegistrationFlow(ctx, tenantID)
+	if err != nil {
+		logger.GetLogger().Errorf("Failed to initialize registration flow: %v", err)
+		return nil, domainerrors.WrapInternal(err, "MSG_INITIALIZE_REGISTRATION_FAILED", "Failed to initialize registration flow")
+	}
+	traits := map[string]interface{}{
+		constants.IdentifierTenant.String(): tenant.Name,
+		constants.IdentifierLang.String():   lang,
+	}
+	result, err := u.kratosService.SubmitRegistrationFlowWithIDToken(ctx, tenantID, flow, claims.Provider, idToken, nonce, traits)
+	if err != nil || result.Session == nil || result.SessionToken == nil {
+		logger.GetLogger().Errorf("Failed to submit registration flow with id token: %v", err)
+		return nil, domainerrors.NewValidationError("MSG_REGISTRATION_FAILED", "Registration failed", nil).WithCause(err)
+	}
+	newKratosUserID := result.Session.GetIdentity().Id
+
+	var globalUserID string
+	err = u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if linkedGlobalUserID == "" {
+			if err := u.bindIAMToRegistration(ctx, tx, tenant, newKratosUserID, claims.Subject, claims.Provider, lang); err != nil {
+				return err
+			}
+			identity, err := u.userIdentityRepo.GetByTenantAndKratosUserID(ctx, tx, tenantID.String(), newKratosUserID)
+			if err != nil {
+				return fmt.Errorf("get identity: %w", err)
+			}
+			globalUserID = identity.GlobalUserID
+			return nil
+		}
+
+		inserted, err := u.userIdentityRepo.InsertOnceByKratosUserAndType(
+			ctx, tx, tenantID.String(), newKratosUserID, linkedGlobalUserID, claims.Provider, claims.Subject,
+		)
+		if err != nil {
+			return fmt.Errorf("create identity: %w", err)
+		}
+		if !inserted {
+			return errOIDCTypeTaken
+		}
+		globalUserID = linkedGlobalUserID
+		// The provider's verified email matched an existing user, so the service linked it
+		return u.changeLogRepo.Create(ctx, tx, &domain.UserIdentityChangeLog{
+			GlobalUserID: linkedGlobalUserID,
+			TenantID:     tenantID.String(),
+			Action:       constants.IdentityChangeActionAdd,
+			IdentityType: claims.Provider,
+			NewValue:     claims.Subject,
+			Actor:        constants.IdentityChangeActorSystem,
+		})
+	})
+	if err != nil {
+		// Do not leave a Kratos identity behind that IAM knows nothing about
+		if cleanUpErr := u.kratosService.DeleteIdentifierAdmin(ctx, tenantID, uuid.MustParse(newKratosUserID)); cleanUpErr != nil {
+			logger.GetLogger().Errorf("Failed to clean up Kratos identity %s: %v", newKratosUserID, cleanUpErr)
+		}
+		if errors.Is(err, errOIDCTypeTaken) {
+			return nil, domainerrors.NewConflictError(
+				"MSG_IDENTIFIER_TYPE_EXISTS",
+				fmt.Sprintf("User already has a linked %s account", claims.Provider),
+				nil,
+			)
+		}
+		return nil, domainerrors.WrapInternal(err, "MSG_IAM_REGISTRATION_FAILED", "Failed to bind IAM to registration")
+	}
+
+	resp := newAuthResponse(result.Session, *result.SessionToken)
+	resp.User.GlobalUserID = globalUserID
+	return resp, nil
+}
+
+// LinkOIDCIdentifier links a Google or Apple account to an existing user
+func (u *userUseCase) LinkOIDCIdentifier(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+	provider string,
+	idToken string,
+	nonce string,
+) (*types.IdentityLinkedResponse, *domainerrors.DomainError) {
+	// 1. Verify the ID token
+	claims, derr := u.verifyOIDCToken(ctx, provider, idToken, nonce)
+	if derr != nil {
+		return nil, derr
+	}
+
+	// 2. The provider account must not belong to anyone in the tenant yet
+	exists, err := u.userIdentityRepo.ExistsWithinTenant(ctx, tenantID.String(), provider, claims.Subject)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_IAM_LOOKUP_FAILED", "Failed to check existing identifier")
+	}
+	if exists {
+		return nil, domainerrors.NewConflictError("MSG_IDENTIFIER_ALREADY_EXISTS", "Identifier has already been registered", nil)
+	}
+
+	// 3. Check if user already has this identifier type
+	hasType, err := u.userIdentityRepo.ExistsByTenantGlobalUserIDAndType(ctx, tenantID.String(), globalUserID, provider)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_CHECK_TYPE_EXIST_FAILED", "Failed to check user identity type")
+	}
+	if hasType {
+		return nil, domainerrors.NewConflictError("MSG_IDENTIFIER_TYPE_EXISTS", fmt.Sprintf("User already has an identifier of type %s", provider), nil)
+	}
+
+	// 4. Rate limit
+	key := fmt.Sprintf("link:oidc:%s:tenant:%s:%s", provider, globalUserID, tenantID.String())
+	if err := utils.CheckRateLimitDomain(u.rateLimiter, key, constants.MaxAttemptsPerWindow, constants.RateLimitWindow); err != nil {
+		return nil, domainerrors.NewRateLimitError("MSG_RATE_LIMIT_EXCEEDED", "Rate limit exceeded", err)
+	}
+
+	// 5. Register the Kratos identity holding the provider credential
+	tenant, err := u.tenantRepo.GetByID(tenantID)
+	if err != nil || tenant == nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_TENANT_FAILED", "Failed to get tenant")
+	}
+	traits := map[string]interface{}{
+		constants.IdentifierTenant.String(): tenant.Name,
+	}
+	if mapping, err := u.userIdentifierMappingRepo.GetByGlobalUserID(ctx, globalUserID); err == nil && mapping != nil {
+		if lang := strings.TrimSpace(mapping.Lang); lang != "" {
+			traits[constants.IdentifierLang.String()] = lang
+		}
+	}
+
+	flow, err := u.kratosService.InitializeRegistrationFlow(ctx, tenantID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_INIT_REG_FLOW_FAILED", "Failed to initialize registration flow")
+	}
+	result, err := u.kratosService.SubmitRegistrationFlowWithIDToken(ctx, tenantID, flow, provider, idToken, nonce, traits)
+	if err != nil || result.Session == nil {
+		logger.GetLogger().Errorf("Failed to submit registration flow with id token: %v", err)
+		return nil, domainerrors.NewValidationError("MSG_REGISTRATION_FAILED", "Registration failed", nil).WithCause(err)
+	}
+	newKratosUserID := result.Session.GetIdentity().Id
+
+	// Registration signs the new identity in; the caller keeps using their current session
+	if err := u.kratosService.DisableSessionAdmin(ctx, tenantID, result.Session.Id); err != nil {
+		logger.GetLogger().Errorf("Failed to disable session of linked identity: %v", err)
+	}
+
+	// 6. Bind the identity to the user
+	var inserted bool
+	err = u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		inserted, err = u.userIdentityRepo.InsertOnceByKratosUserAndType(
+			ctx, tx, tenantID.String(), newKratosUserID, globalUserID, provider, claims.Subject,
+		)
+		if err != nil || !inserted {
+			return err
+		}
+		return u.changeLogRepo.Create(ctx, tx, &domain.UserIdentityChangeLog{
+			GlobalUserID: globalUserID,
+			TenantID:     tenantID.String(),
+			Action:       constants.IdentityChangeActionAdd,
+			IdentityType: provider,
+			NewValue:     claims.Subject,
+			Actor:        constants.IdentityChangeActorUser,
+		})
+	})
+	if err != nil || !inserted {
+		if cleanUpErr := u.kratosService.DeleteIdentifierAdmin(ctx, tenantID, uuid.MustParse(newKratosUserID)); cleanUpErr != nil {
+			logger.GetLogger().Errorf("Failed to clean up Kratos identity %s: %v", newKratosUserID, cleanUpErr)
+		}
+		if err != nil {
+			return nil, domainerrors.WrapInternal(err, "MSG_ADD_IDENTIFIER_FAILED", "Failed to add identifier")
+		}
+		return nil, domainerrors.NewConflictError("MSG_IDENTIFIER_TYPE_EXISTS", "Identifier of this type already added", nil)
+	}
+	u.sessionCache.invalidateUser(globalUserID)
+	u.notifySecurityEvent(ctx, tenantID, types.SecurityNotice{
+		Event:          constants.SecurityEventIdentifierAdded,
+		GlobalUserID:   globalUserID,
+		IdentifierType: provider,
+		Identifier:     claims.Subject,
+	})
+
+	// 7. Return response
+	return &types.IdentityLinkedResponse{
+		IdentifierType: provider,
+		Subject:        claims.Subject,
+		Email:          claims.Email,
+		LinkedAt:       time.Now().Unix(),
+	}, nil
+}
+
+// verifyOIDCToken validates the provider name and the ID token
+func (u *userUseCase) verifyOIDCToken(
+	ctx context.Context,
+	provider string,
+	idToken string,
+	nonce string,
+) (*types.OIDCClaims, *domainerrors.DomainError) {
+	if !isSocialIdentifierType(provider) {
+		return nil, domainerrors.NewValidationError("MSG_UNSUPPORTED_PROVIDER", "Unsupported identity provider", []interface{}{
+			map[string]string{"field": "provider", "error": "Provider must be google or apple"},
+		})
+	}
+	if idToken == "" {
+		return nil, domainerrors.NewValidationError("MSG_ID_TOKEN_REQUIRED", "ID token is required", []interface{}{
+			map[string]string{"field": "id_token", "error": "ID token is required"},
+		})
+	}
+	if u.oidcVerifier == nil {
+		return nil, domainerrors.NewInternalError("MSG_OIDC_NOT_CONFIGURED", "Social sign-in is not configured")
+	}
+
+	claims, err := u.oidcVerifier.Verify(ctx, provider, idToken, nonce)
+	if err != nil {
+		logger.GetLogger().Warnf("Rejected %s ID token: %v", provider, err)
+		return nil, domainerrors.NewUnauthorizedError("MSG_INVALID_ID_TOKEN", "Invalid ID token").WithCause(err)
+	}
+	return claims, nil
+}
+
+func isSocialIdentifierType(identifierType string) bool {
+	return identifierType == constants.IdentifierGoogle.String() || identifierType == constants.IdentifierApple.String()
+}
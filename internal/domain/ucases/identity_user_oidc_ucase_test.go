@@ -0,0 +1,325 @@
+package ucases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	client "github.com/ory/kratos-client-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	mock_interfaces "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/interfaces"
+	mock_repositories "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/repositories"
+	mock_services "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/services"
+	mock_rate_limiter "github.com/lifenetwork-ai/iam-service/mocks/infrastructures/rate_limiter/types"
+)
+
+func TestLoginWithOIDC_KnownSubject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	claims := &types.OIDCClaims{Provider: "google", Subject: "sub-1", Email: "a@example.com", EmailVerified: true}
+	flow := &client.LoginFlow{Id: "flow-1"}
+	session := client.Session{Id: "session-1", Active: client.PtrBool(true), Identity: &client.Identity{Id: "kratos-1"}}
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	verifier := mock_services.NewMockOIDCTokenVerifier(ctrl)
+	verifier.EXPECT().Verify(ctx, "google", "id-token", "nonce").Return(claims, nil)
+
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	identityRepo.EXPECT().GetByTypeAndValue(ctx, nil, tenantID.String(), "google", "sub-1").
+		Return(&domain.UserIdentity{GlobalUserID: "global-1", KratosUserID: "kratos-1"}, nil)
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().InitializeLoginFlow(ctx, tenantID).Return(flow, nil)
+	kratos.EXPECT().SubmitLoginFlowWithIDToken(ctx, tenantID, flow, "google", "id-token", "nonce").
+		Return(&client.SuccessfulNativeLogin{Session: session, SessionToken: client.PtrString("token-1")}, nil)
+
+	mfaRepo := mock_repositories.NewMockUserMFARepository(ctrl)
+	mfaRepo.EXPECT().GetFactor(ctx, tenantID.String(), "global-1", gomock.Any()).Return(nil, nil).AnyTimes()
+
+	// The refresh token is best effort; failing to issue it must not fail the sign-in
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(gomock.Any(), tenantID).Return(nil, errors.New("unavailable")).AnyTimes()
+
+	u := &userUseCase{
+		rateLimiter:           rateLimiter,
+		userAccountStatusRepo: activeAccountStatusRepo(ctrl),
+		userIdentityRepo:      identityRepo,
+		tenantSettingRepo:     settingRepo,
+		userMFARepo:           mfaRepo,
+		kratosService:         kratos,
+		oidcVerifier:          verifier,
+	}
+
+	resp, derr := u.LoginWithOIDC(ctx, tenantID, "google", "id-token", "nonce", "en")
+	require.Nil(t, derr)
+	assert.Equal(t, "token-1", resp.SessionToken)
+	assert.Equal(t, "kratos-1", resp.User.ID)
+	assert.Equal(t, "global-1", resp.User.GlobalUserID)
+}
+
+func TestLoginWithOIDC_InvalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	verifier := mock_services.NewMockOIDCTokenVerifier(ctrl)
+	verifier.EXPECT().Verify(ctx, "apple", "bad-token", "").Return(nil, errors.New("signature mismatch"))
+
+	u := &userUseCase{
+		oidcVerifier:  verifier,
+		kratosService: mock_services.NewMockKratosService(ctrl),
+	}
+
+	resp, derr := u.LoginWithOIDC(ctx, uuid.New(), "apple", "bad-token", "", "")
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_INVALID_ID_TOKEN", derr.Code)
+}
+
+func TestLoginWithOIDC_UnsupportedProvider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	u := &userUseCase{
+		oidcVerifier:  mock_services.NewMockOIDCTokenVerifier(ctrl),
+		kratosService: mock_services.NewMockKratosService(ctrl),
+	}
+
+	_, derr := u.LoginWithOIDC(context.Background(), uuid.New(), "facebook", "id-token", "", "")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_UNSUPPORTED_PROVIDER", derr.Code)
+}
+
+func TestLoginWithOIDC_LookupFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	verifier := mock_services.NewMockOIDCTokenVerifier(ctrl)
+	verifier.EXPECT().Verify(ctx, "google", "id-token", "").Return(&types.OIDCClaims{Provider: "google", Subject: "sub-1"}, nil)
+
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	identityRepo.EXPECT().GetByTypeAndValue(ctx, nil, tenantID.String(), "google", "sub-1").Return(nil, gorm.ErrInvalidDB)
+
+	u := &userUseCase{
+		rateLimiter:      rateLimiter,
+		userIdentityRepo: identityRepo,
+		oidcVerifier:     verifier,
+		kratosService:    mock_services.NewMockKratosService(ctrl),
+	}
+
+	_, derr := u.LoginWithOIDC(ctx, tenantID, "google", "id-token", "", "")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_IAM_LOOKUP_FAILED", derr.Code)
+}
+
+func TestLoginWithOIDC_InviteOnlyTenantRefusesNewSubject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	// The email is not verified, so the subject cannot join an existing user
+	verifier := mock_services.NewMockOIDCTokenVerifier(ctrl)
+	verifier.EXPECT().Verify(ctx, "google", "id-token", "").
+		Return(&types.OIDCClaims{Provider: "google", Subject: "sub-1", Email: "a@example.com"}, nil)
+
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	identityRepo.EXPECT().GetByTypeAndValue(ctx, nil, tenantID.String(), "google", "sub-1").Return(nil, gorm.ErrRecordNotFound)
+
+	tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+	tenantRepo.EXPECT().GetByID(tenantID).Return(&domain.Tenant{ID: tenantID, Name: "acme"}, nil)
+
+	invitationRepo := mock_repositories.NewMockUserInvitationRepository(ctrl)
+	invitationRepo.EXPECT().GetOpen(ctx, tenantID.String(), "google", "sub-1").Return(nil, nil)
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(&domain.TenantSetting{InviteOnlyRegistration: true}, nil)
+
+	// No Kratos identity is created
+	u := &userUseCase{
+		rateLimiter:        rateLimiter,
+		tenantRepo:         tenantRepo,
+		tenantSettingRepo:  settingRepo,
+		userIdentityRepo:   identityRepo,
+		userInvitationRepo: invitationRepo,
+		kratosService:      mock_services.NewMockKratosService(ctrl),
+		oidcVerifier:       verifier,
+	}
+
+	resp, derr := u.LoginWithOIDC(ctx, tenantID, "google", "id-token", "", "")
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_REGISTRATION_INVITE_ONLY", derr.Code)
+}
+
+func TestLinkOIDCIdentifier_SubjectTaken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	verifier := mock_services.NewMockOIDCTokenVerifier(ctrl)
+	verifier.EXPECT().Verify(ctx, "google", "id-token", "").Return(&types.OIDCClaims{Provider: "google", Subject: "sub-1"}, nil)
+
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	identityRepo.EXPECT().ExistsWithinTenant(ctx, tenantID.String(), "google", "sub-1").Return(true, nil)
+
+	u := &userUseCase{
+		userIdentityRepo: identityRepo,
+		oidcVerifier:     verifier,
+		kratosService:    mock_services.NewMockKratosService(ctrl),
+	}
+
+	_, derr := u.LinkOIDCIdentifier(ctx, tenantID, "global-1", "google", "id-token", "")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_IDENTIFIER_ALREADY_EXISTS", derr.Code)
+}
+
+func TestLinkOIDCIdentifier_AlreadyHasProvider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	verifier := mock_services.NewMockOIDCTokenVerifier(ctrl)
+	verifier.EXPECT().Verify(ctx, "apple", "id-token", "").Return(&types.OIDCClaims{Provider: "apple", Subject: "sub-2"}, nil)
+
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	identityRepo.EXPECT().ExistsWithinTenant(ctx, tenantID.String(), "apple", "sub-2").Return(false, nil)
+	identityRepo.EXPECT().ExistsByTenantGlobalUserIDAndType(ctx, tenantID.String(), "global-1", "apple").Return(true, nil)
+
+	u := &userUseCase{
+		userIdentityRepo: identityRepo,
+		oidcVerifier:     verifier,
+		kratosService:    mock_services.NewMockKratosService(ctrl),
+	}
+
+	_, derr := u.LinkOIDCIdentifier(ctx, tenantID, "global-1", "apple", "id-token", "")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_IDENTIFIER_TYPE_EXISTS", derr.Code)
+}
+
+func TestLinkOIDCIdentifier_RecordsAndNotifiesLink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	kratosUserID := uuid.New()
+	flow := &client.RegistrationFlow{Id: "flow-1"}
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	verifier := mock_services.NewMockOIDCTokenVerifier(ctrl)
+	verifier.EXPECT().Verify(ctx, "google", "id-token", "").
+		Return(&types.OIDCClaims{Provider: "google", Subject: "sub-1", Email: "a@example.com"}, nil)
+
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	identityRepo.EXPECT().ExistsWithinTenant(ctx, tenantID.String(), "google", "sub-1").Return(false, nil)
+	identityRepo.EXPECT().ExistsByTenantGlobalUserIDAndType(ctx, tenantID.String(), "global-1", "google").Return(false, nil)
+	identityRepo.EXPECT().InsertOnceByKratosUserAndType(
+		ctx, gomock.Any(), tenantID.String(), kratosUserID.String(), "global-1", "google", "sub-1",
+	).Return(true, nil)
+
+	tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+	tenantRepo.EXPECT().GetByID(tenantID).Return(&domain.Tenant{ID: tenantID, Name: "acme"}, nil)
+
+	mappingRepo := mock_repositories.NewMockUserIdentifierMappingRepository(ctrl)
+	mappingRepo.EXPECT().GetByGlobalUserID(ctx, "global-1").Return(nil, nil)
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().InitializeRegistrationFlow(ctx, tenantID).Return(flow, nil)
+	kratos.EXPECT().SubmitRegistrationFlowWithIDToken(ctx, tenantID, flow, "google", "id-token", "", gomock.Any()).
+		Return(&client.SuccessfulNativeRegistration{Session: &client.Session{
+			Id:       "session-1",
+			Identity: &client.Identity{Id: kratosUserID.String()},
+		}}, nil)
+	kratos.EXPECT().DisableSessionAdmin(ctx, tenantID, "session-1").Return(nil)
+
+	// The link joins the identity history, as an identifier added with a code does
+	changeLogRepo := mock_repositories.NewMockUserIdentityChangeLogRepository(ctrl)
+	changeLogRepo.EXPECT().Create(ctx, gomock.Any(), &domain.UserIdentityChangeLog{
+		GlobalUserID: "global-1",
+		TenantID:     tenantID.String(),
+		Action:       constants.IdentityChangeActionAdd,
+		IdentityType: "google",
+		NewValue:     "sub-1",
+		Actor:        constants.IdentityChangeActorUser,
+	}).Return(nil)
+
+	notifier := mock_interfaces.NewMockSecurityNotificationUseCase(ctrl)
+	notifier.EXPECT().Notify(ctx, tenantID, types.SecurityNotice{
+		Event:          constants.SecurityEventIdentifierAdded,
+		GlobalUserID:   "global-1",
+		IdentifierType: "google",
+		Identifier:     "sub-1",
+	})
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	// Sessions cached before the link are validated again
+	cache := newTestSessionCache(time.Minute)
+	cache.put(tenantID, "token-1", &types.IdentityUserResponse{GlobalUserID: "global-1"}, "current-session", nil, time.Now())
+
+	u := &userUseCase{
+		db:                        db,
+		rateLimiter:               rateLimiter,
+		tenantRepo:                tenantRepo,
+		userIdentityRepo:          identityRepo,
+		userIdentifierMappingRepo: mappingRepo,
+		changeLogRepo:             changeLogRepo,
+		kratosService:             kratos,
+		oidcVerifier:              verifier,
+		securityNotifier:          notifier,
+		sessionCache:              cache,
+	}
+
+	resp, derr := u.LinkOIDCIdentifier(ctx, tenantID, "global-1", "google", "id-token", "")
+	require.Nil(t, derr)
+	assert.Equal(t, "sub-1", resp.Subject)
+	assert.Nil(t, cache.get(tenantID, "token-1"))
+
+	// The notice names the provider rather than its opaque subject
+	message, err := securityNoticeMessage("acme", types.SecurityNotice{
+		Event:          constants.SecurityEventIdentifierAdded,
+		IdentifierType: "google",
+		Identifier:     "sub-1",
+	}, time.Now(), "")
+	require.NoError(t, err)
+	assert.Contains(t, message, "A google sign-in was linked to your account")
+	assert.NotContains(t, message, "sub-1")
+}
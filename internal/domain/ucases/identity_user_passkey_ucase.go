@@ -0,0 +1,458 @@
+package ucases
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+	"github.com/lifenetwork-ai/iam-service/packages/utils"
+)
+
+const (
+	defaultPasskeyName   = "Passkey"
+	maxPasskeyNameLength = 100
+)
+
+var errPasskeyNotFound = errors.New("passkey not found")
+
+// BeginPasskeyRegistration returns the options for navigator.credentials.create() to add a passkey
+// to a signed-in user
+func (u *userUseCase) BeginPasskeyRegistration(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+	accountName string,
+) (*types.PasskeyChallengeResponse, *domainerrors.DomainError) {
+	// 1. Check passkeys are available for the tenant
+	if conf.GetPasskeyCredentialSecret() == "" || u.passkeyService == nil {
+		return nil, domainerrors.NewInternalError("MSG_PASSKEY_NOT_CONFIGURED", "Passkey sign-in is not configured")
+	}
+	rp, derr := u.passkeyRelyingParty(tenantID)
+	if derr != nil {
+		return nil, derr
+	}
+
+	// 2. Rate limit
+	key := fmt.Sprintf("passkey:register:%s:tenant:%s", globalUserID, tenantID.String())
+	if err := utils.CheckRateLimitDomain(u.rateLimiter, key, constants.MaxAttemptsPerWindow, constants.RateLimitWindow); err != nil {
+		return nil, domainerrors.NewRateLimitError("MSG_RATE_LIMIT_EXCEEDED", "Rate limit exceeded", err)
+	}
+
+	// 3. Start the ceremony, excluding authenticators that already hold one of the user's passkeys
+	passkeys, err := u.userPasskeyRepo.ListByGlobalUserID(ctx, tenantID.String(), globalUserID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_LIST_PASSKEYS_FAILED", "Failed to list passkeys")
+	}
+	existing := make([]*types.PasskeyCredential, 0, len(passkeys))
+	for _, p := range passkeys {
+		c, err := passkeyCredentialOf(p)
+		if err != nil {
+			return nil, domainerrors.WrapInternal(err, "MSG_LIST_PASSKEYS_FAILED", "Failed to decode stored passkey")
+		}
+		existing = append(existing, c)
+	}
+	if accountName == "" {
+		accountName = globalUserID
+	}
+	ceremony, err := u.passkeyService.BeginRegistration(*rp, types.PasskeyUser{
+		Handle:      []byte(globalUserID),
+		Name:        accountName,
+		DisplayName: accountName,
+	}, existing)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_BEGIN_PASSKEY_REGISTRATION_FAILED", "Failed to start passkey registration")
+	}
+
+	// 4. Save challenge session
+	flowID := uuid.NewString()
+	session := &domain.ChallengeSession{
+		GlobalUserID:  globalUserID,
+		Identifier:    accountName,
+		ChallengeType: constants.ChallengeTypePasskeyRegister,
+		PasskeyState:  ceremony.State,
+	}
+	if err := u.challengeSessionRepo.SaveChallenge(ctx, flowID, session, constants.DefaultChallengeDuration); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_SAVE_CHALLENGE_FAILED", "Failed to save challenge session")
+	}
+
+	// 5. Return response
+	return &types.PasskeyChallengeResponse{
+		FlowID:      flowID,
+		Options:     ceremony.Options,
+		ChallengeAt: time.Now().Unix(),
+	}, nil
+}
+
+// FinishPasskeyRegistration verifies the attestation returned by the browser and stores the new passkey
+func (u *userUseCase) FinishPasskeyRegistration(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+	flowID string,
+	name string,
+	credential []byte,
+) (*types.PasskeyResponse, *domainerrors.DomainError) {
+	// 1. Validate name
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = defaultPasskeyName
+	}
+	if len(name) > maxPasskeyNameLength {
+		return nil, domainerrors.NewValidationError("MSG_INVALID_PASSKEY_NAME", "Invalid passkey name", []interface{}{
+			map[string]string{"field": "name", "error": fmt.Sprintf("Name must be at most %d characters", maxPasskeyNameLength)},
+		})
+	}
+
+	// 2. Load challenge; only the user who started the ceremony may finish it
+	challenge, err := u.challengeSessionRepo.GetChallenge(ctx, flowID)
+	if err != nil || challenge == nil {
+		return nil, domainerrors.NewNotFoundError("MSG_CHALLENGE_SESSION_NOT_FOUND", "Challenge session")
+	}
+	if challenge.ChallengeType != constants.ChallengeTypePasskeyRegister || challenge.GlobalUserID != globalUserID {
+		return nil, domainerrors.NewValidationError("MSG_INVALID_CHALLENGE_TYPE", "Invalid challenge type", nil)
+	}
+	secret := conf.GetPasskeyCredentialSecret()
+	if secret == "" || u.passkeyService == nil {
+		return nil, domainerrors.NewInternalError("MSG_PASSKEY_NOT_CONFIGURED", "Passkey sign-in is not configured")
+	}
+	rp, derr := u.passkeyRelyingParty(tenantID)
+	if derr != nil {
+		return nil, derr
+	}
+
+	// 3. Verify the attestation
+	created, err := u.passkeyService.FinishRegistration(*rp, types.PasskeyUser{
+		Handle:      []byte(globalUserID),
+		Name:        challenge.Identifier,
+		DisplayName: challenge.Identifier,
+	}, challenge.PasskeyState, credential)
+	if err != nil {
+		logger.GetLogger().Warnf("Rejected passkey registration: %v", err)
+		return nil, domainerrors.NewValidationError("MSG_INVALID_PASSKEY", "Invalid passkey", nil).WithCause(err)
+	}
+	_ = u.challengeSessionRepo.DeleteChallenge(ctx, flowID)
+
+	// 4. Make sure the user has a Kratos identity to sign in with
+	if derr := u.ensurePasskeyIdentity(ctx, tenantID, globalUserID, secret); derr != nil {
+		return nil, derr
+	}
+
+	// 5. Store the passkey
+	credentialID := base64.RawURLEncoding.EncodeToString(created.ID)
+	existing, err := u.userPasskeyRepo.GetByCredentialID(ctx, tenantID.String(), credentialID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_PASSKEY_FAILED", "Failed to get passkey")
+	}
+	if existing != nil {
+		return nil, domainerrors.NewConflictError("MSG_PASSKEY_ALREADY_REGISTERED", "Passkey has already been registered", nil)
+	}
+	passkey := &domain.UserPasskey{
+		TenantID:        tenantID.String(),
+		GlobalUserID:    globalUserID,
+		Name:            name,
+		CredentialID:    credentialID,
+		PublicKey:       created.PublicKey,
+		AttestationType: created.AttestationType,
+		AAGUID:          created.AAGUID,
+		Transports:      strings.Join(created.Transports, ","),
+		SignCount:       int64(created.SignCount),
+		BackupEligible:  created.BackupEligible,
+		BackupState:     created.BackupState,
+	}
+	if err := u.userPasskeyRepo.Create(ctx, passkey); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_SAVE_PASSKEY_FAILED", "Failed to save passkey")
+	}
+
+	// 6. Return response
+	return newPasskeyResponse(passkey), nil
+}
+
+// ListPasskeys returns the passkeys a user registered in the tenant
+func (u *userUseCase) ListPasskeys(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+) ([]*types.PasskeyResponse, *domainerrors.DomainError) {
+	passkeys, err := u.userPasskeyRepo.ListByGlobalUserID(ctx, tenantID.String(), globalUserID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_LIST_PASSKEYS_FAILED", "Failed to list passkeys")
+	}
+	resp := make([]*types.PasskeyResponse, 0, len(passkeys))
+	for _, p := range passkeys {
+		resp = append(resp, newPasskeyResponse(p))
+	}
+	return resp, nil
+}
+
+// DeletePasskey removes one of the user's passkeys
+func (u *userUseCase) DeletePasskey(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+	passkeyID string,
+) *domainerrors.DomainError {
+	if _, err := uuid.Parse(passkeyID); err != nil {
+		return domainerrors.NewNotFoundError("MSG_PASSKEY_NOT_FOUND", "Passkey")
+	}
+	deleted, err := u.userPasskeyRepo.Delete(ctx, tenantID.String(), globalUserID, passkeyID)
+	if err != nil {
+		return domainerrors.WrapInternal(err, "MSG_DELETE_PASSKEY_FAILED", "Failed to delete passkey")
+	}
+	if !deleted {
+		return domainerrors.NewNotFoundError("MSG_PASSKEY_NOT_FOUND", "Passkey")
+	}
+	return nil
+}
+
+// ChallengeWithPasskey returns the options for navigator.credentials.get() to sign in with a
+// discoverable passkey, so no identifier is needed up front
+func (u *userUseCase) ChallengeWithPasskey(
+	ctx context.Context,
+	tenantID uuid.UUID,
+) (*types.PasskeyChallengeResponse, *domainerrors.DomainError) {
+	// 1. Start the ceremony
+	if u.passkeyService == nil {
+		return nil, domainerrors.NewInternalError("MSG_PASSKEY_NOT_CONFIGURED", "Passkey sign-in is not configured")
+	}
+	rp, derr := u.passkeyRelyingParty(tenantID)
+	if derr != nil {
+		return nil, derr
+	}
+	ceremony, err := u.passkeyService.BeginLogin(*rp)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_BEGIN_PASSKEY_LOGIN_FAILED", "Failed to start passkey sign-in")
+	}
+
+	// 2. Save challenge session
+	flowID := uuid.NewString()
+	session := &domain.ChallengeSession{
+		IdentifierType: constants.IdentifierPasskey.String(),
+		ChallengeType:  constants.ChallengeTypePasskeyLogin,
+		PasskeyState:   ceremony.State,
+	}
+	if err := u.challengeSessionRepo.SaveChallenge(ctx, flowID, session, constants.DefaultChallengeDuration); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_SAVE_CHALLENGE_FAILED", "Failed to save challenge session")
+	}
+
+	// 3. Return response
+	return &types.PasskeyChallengeResponse{
+		FlowID:      flowID,
+		Options:     ceremony.Options,
+		ChallengeAt: time.Now().Unix(),
+	}, nil
+}
+
+// VerifyPasskey checks a passkey assertion and signs its owner in
+func (u *userUseCase) VerifyPasskey(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	flowID string,
+	credential []byte,
+) (*types.IdentityUserAuthResponse, *domainerrors.DomainError) {
+	// 1. Rate limit verification attempts
+	key := "verify:passkey:" + flowID
+	if err := utils.CheckRateLimitDomain(u.rateLimiter, key, constants.MaxAttemptsPerWindow, constants.RateLimitWindow); err != nil {
+		return nil, domainerrors.NewRateLimitError("MSG_RATE_LIMIT_EXCEEDED", "Rate limit exceeded", err)
+	}
+
+	// 2. Load challenge
+	challenge, err := u.challengeSessionRepo.GetChallenge(ctx, flowID)
+	if err != nil || challenge == nil {
+		return nil, domainerrors.NewNotFoundError("MSG_CHALLENGE_SESSION_NOT_FOUND", "Challenge session")
+	}
+	if challenge.ChallengeType != constants.ChallengeTypePasskeyLogin {
+		return nil, domainerrors.NewValidationError("MSG_INVALID_CHALLENGE_TYPE", "Invalid challenge type", nil)
+	}
+	if u.passkeyService == nil {
+		return nil, domainerrors.NewInternalError("MSG_PASSKEY_NOT_CONFIGURED", "Passkey sign-in is not configured")
+	}
+	rp, derr := u.passkeyRelyingParty(tenantID)
+	if derr != nil {
+		return nil, derr
+	}
+
+	// 3. Verify the assertion against the stored passkey
+	var passkey *domain.UserPasskey
+	lookup := func(credentialID, userHandle []byte) (*types.PasskeyCredential, error) {
+		p, err := u.userPasskeyRepo.GetByCredentialID(ctx, tenantID.String(), base64.RawURLEncoding.EncodeToString(credentialID))
+		if err != nil {
+			return nil, err
+		}
+		if p == nil || p.GlobalUserID != string(userHandle) {
+			return nil, errPasskeyNotFound
+		}
+		passkey = p
+		return passkeyCredentialOf(p)
+	}
+	asserted, err := u.passkeyService.FinishLogin(*rp, challenge.PasskeyState, credential, lookup)
+	if err != nil || passkey == nil {
+		logger.GetLogger().Warnf("Rejected passkey assertion: %v", err)
+		return nil, domainerrors.NewUnauthorizedError("MSG_INVALID_PASSKEY", "Invalid passkey").WithCause(err)
+	}
+
+	// 4. The challenge is single use
+	_ = u.challengeSessionRepo.DeleteChallenge(ctx, flowID)
+	if err := u.userPasskeyRepo.RecordUse(ctx, passkey.ID, int64(asserted.SignCount), asserted.BackupState, time.Now()); err != nil {
+		logger.GetLogger().Errorf("Failed to record passkey use: %v", err)
+	}
+
+	// 5. Sign in through the user's passkey identity
+	secret := conf.GetPasskeyCredentialSecret()
+	if secret == "" {
+		return nil, domainerrors.NewInternalError("MSG_PASSKEY_NOT_CONFIGURED", "Passkey sign-in is not configured")
+	}
+	resp, derr := u.loginWithDerivedCredential(ctx, tenantID, passkey.GlobalUserID, derivedCredential(secret, tenantID, passkey.GlobalUserID))
+	if derr != nil {
+		return nil, derr
+	}
+	resp.User.GlobalUserID = passkey.GlobalUserID
+
+	// 6. Return authentication response
+	return u.completeSignIn(ctx, tenantID, resp, signInOptions{})
+}
+
+// passkeyRelyingParty scopes passkeys to the host of the tenant's public URL
+func (u *userUseCase) passkeyRelyingParty(tenantID uuid.UUID) (*types.PasskeyRelyingParty, *domainerrors.DomainError) {
+	tenant, err := u.tenantRepo.GetByID(tenantID)
+	if err != nil || tenant == nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_TENANT_FAILED", "Failed to get tenant")
+	}
+	publicURL, err := url.Parse(tenant.PublicURL)
+	if err != nil || publicURL.Hostname() == "" || (publicURL.Scheme != "https" && publicURL.Scheme != "http") {
+		return nil, domainerrors.NewInternalError("MSG_PASSKEY_RP_NOT_CONFIGURED", "Tenant public URL is not a valid passkey origin")
+	}
+	return &types.PasskeyRelyingParty{
+		ID:     publicURL.Hostname(),
+		Name:   tenant.Name,
+		Origin: publicURL.Scheme + "://" + publicURL.Host,
+	}, nil
+}
+
+// ensurePasskeyIdentity creates the hidden Kratos identity passkey sign-ins are issued from,
+// the first time the user registers a passkey
+func (u *userUseCase) ensurePasskeyIdentity(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+	secret string,
+) *domainerrors.DomainError {
+	hasType, err := u.userIdentityRepo.ExistsByTenantGlobalUserIDAndType(ctx, tenantID.String(), globalUserID, constants.IdentifierPasskey.String())
+	if err != nil {
+		return domainerrors.WrapInternal(err, "MSG_CHECK_TYPE_EXIST_FAILED", "Failed to check user identity type")
+	}
+	if hasType {
+		return nil
+	}
+
+	tenant, err := u.tenantRepo.GetByID(tenantID)
+	if err != nil || tenant == nil {
+		return domainerrors.WrapInternal(err, "MSG_GET_TENANT_FAILED", "Failed to get tenant")
+	}
+	traits := map[string]interface{}{
+		constants.IdentifierTenant.String():  tenant.Name,
+		constants.IdentifierPasskey.String(): globalUserID,
+		"password":                           derivedCredential(secret, tenantID, globalUserID),
+	}
+	if mapping, err := u.userIdentifierMappingRepo.GetByGlobalUserID(ctx, globalUserID); err == nil && mapping != nil {
+		if lang := strings.TrimSpace(mapping.Lang); lang != "" {
+			traits[constants.IdentifierLang.String()] = lang
+		}
+	}
+
+	flow, err := u.kratosService.InitializeRegistrationFlow(ctx, tenantID)
+	if err != nil {
+		return domainerrors.WrapInternal(err, "MSG_INIT_REG_FLOW_FAILED", "Failed to initialize registration flow")
+	}
+	result, err := u.kratosService.SubmitRegistrationFlow(ctx, tenantID, flow, constants.MethodTypePassword.String(), traits)
+	if err != nil || result.Identity.Id == "" {
+		logger.GetLogger().Errorf("Failed to submit passkey registration flow: %v", err)
+		return domainerrors.NewValidationError("MSG_REGISTRATION_FAILED", "Registration failed", nil).WithCause(err)
+	}
+	newKratosUserID := result.Identity.Id
+
+	// Registration may sign the new identity in; the caller keeps using their current session
+	if result.Session != nil {
+		if err := u.kratosService.DisableSessionAdmin(ctx, tenantID, result.Session.Id); err != nil {
+			logger.GetLogger().Errorf("Failed to disable session of passkey identity: %v", err)
+		}
+	}
+
+	var inserted bool
+	err = u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		inserted, err = u.userIdentityRepo.InsertOnceByKratosUserAndType(
+			ctx, tx, tenantID.String(), newKratosUserID, globalUserID, constants.IdentifierPasskey.String(), globalUserID,
+		)
+		if err != nil || !inserted {
+			return err
+		}
+		// The service creates this identity on its own when the first passkey is registered
+		return u.changeLogRepo.Create(ctx, tx, &domain.UserIdentityChangeLog{
+			GlobalUserID: globalUserID,
+			TenantID:     tenantID.String(),
+			Action:       constants.IdentityChangeActionAdd,
+			IdentityType: constants.IdentifierPasskey.String(),
+			NewValue:     globalUserID,
+			Actor:        constants.IdentityChangeActorSystem,
+		})
+	})
+	if err != nil || !inserted {
+		if cleanUpErr := u.kratosService.DeleteIdentifierAdmin(ctx, tenantID, uuid.MustParse(newKratosUserID)); cleanUpErr != nil {
+			logger.GetLogger().Errorf("Failed to clean up Kratos identity %s: %v", newKratosUserID, cleanUpErr)
+		}
+		if err != nil {
+			return domainerrors.WrapInternal(err, "MSG_ADD_IDENTIFIER_FAILED", "Failed to add identifier")
+		}
+	}
+	return nil
+}
+
+func passkeyCredentialOf(p *domain.UserPasskey) (*types.PasskeyCredential, error) {
+	id, err := base64.RawURLEncoding.DecodeString(p.CredentialID)
+	if err != nil {
+		return nil, err
+	}
+	var transports []string
+	if p.Transports != "" {
+		transports = strings.Split(p.Transports, ",")
+	}
+	return &types.PasskeyCredential{
+		ID:              id,
+		PublicKey:       p.PublicKey,
+		AttestationType: p.AttestationType,
+		AAGUID:          p.AAGUID,
+		Transports:      transports,
+		SignCount:       uint32(p.SignCount), //nolint:gosec // stored from a uint32
+		BackupEligible:  p.BackupEligible,
+		BackupState:     p.BackupState,
+		UserHandle:      []byte(p.GlobalUserID),
+	}, nil
+}
+
+func newPasskeyResponse(p *domain.UserPasskey) *types.PasskeyResponse {
+	var transports []string
+	if p.Transports != "" {
+		transports = strings.Split(p.Transports, ",")
+	}
+	return &types.PasskeyResponse{
+		ID:             p.ID,
+		Name:           p.Name,
+		Transports:     transports,
+		BackupEligible: p.BackupEligible,
+		CreatedAt:      p.CreatedAt,
+		LastUsedAt:     p.LastUsedAt,
+	}
+}
@@ -0,0 +1,272 @@
+package ucases
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	client "github.com/ory/kratos-client-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	mock_repositories "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/repositories"
+	mock_services "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/services"
+	mock_rate_limiter "github.com/lifenetwork-ai/iam-service/mocks/infrastructures/rate_limiter/types"
+)
+
+func usePasskeySecret(t *testing.T, secret string) {
+	previousSecret := conf.GetConfiguration().Passkey.CredentialSecret
+	conf.GetConfiguration().Passkey.CredentialSecret = secret
+	t.Cleanup(func() { conf.GetConfiguration().Passkey.CredentialSecret = previousSecret })
+}
+
+func TestPasskeyRelyingParty(t *testing.T) {
+	tests := []struct {
+		name      string
+		publicURL string
+		want      *types.PasskeyRelyingParty
+	}{
+		{"host only", "https://app.example.com", &types.PasskeyRelyingParty{ID: "app.example.com", Name: "genetica", Origin: "https://app.example.com"}},
+		{"port and path", "https://app.example.com:8443/login", &types.PasskeyRelyingParty{ID: "app.example.com", Name: "genetica", Origin: "https://app.example.com:8443"}},
+		{"empty", "", nil},
+		{"no scheme", "app.example.com", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tenantID := uuid.New()
+			tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+			tenantRepo.EXPECT().GetByID(tenantID).Return(&domain.Tenant{ID: tenantID, Name: "genetica", PublicURL: tt.publicURL}, nil)
+
+			u := &userUseCase{tenantRepo: tenantRepo}
+
+			rp, derr := u.passkeyRelyingParty(tenantID)
+			if tt.want == nil {
+				require.NotNil(t, derr)
+				assert.Equal(t, "MSG_PASSKEY_RP_NOT_CONFIGURED", derr.Code)
+				return
+			}
+			require.Nil(t, derr)
+			assert.Equal(t, tt.want, rp)
+		})
+	}
+}
+
+func TestBeginPasskeyRegistration_NotConfigured(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usePasskeySecret(t, "")
+
+	u := &userUseCase{
+		passkeyService: mock_services.NewMockPasskeyService(ctrl),
+		kratosService:  mock_services.NewMockKratosService(ctrl),
+	}
+
+	_, derr := u.BeginPasskeyRegistration(context.Background(), uuid.New(), "global-1", "alice@example.com")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_PASSKEY_NOT_CONFIGURED", derr.Code)
+}
+
+func TestDeletePasskey_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	passkeyID := uuid.NewString()
+
+	// An ID that is not a UUID is not looked up
+	passkeyRepo := mock_repositories.NewMockUserPasskeyRepository(ctrl)
+	passkeyRepo.EXPECT().Delete(ctx, tenantID.String(), "global-1", passkeyID).Return(false, nil)
+
+	u := &userUseCase{userPasskeyRepo: passkeyRepo}
+
+	derr := u.DeletePasskey(ctx, tenantID, "global-1", passkeyID)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_PASSKEY_NOT_FOUND", derr.Code)
+
+	derr = u.DeletePasskey(ctx, tenantID, "global-1", "not-a-uuid")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_PASSKEY_NOT_FOUND", derr.Code)
+}
+
+func TestVerifyPasskey(t *testing.T) {
+	credentialID := []byte("credential-1")
+	stored := &domain.UserPasskey{
+		ID:           uuid.NewString(),
+		GlobalUserID: "global-1",
+		CredentialID: base64.RawURLEncoding.EncodeToString(credentialID),
+		PublicKey:    []byte("public-key"),
+		Transports:   "internal,hybrid",
+		SignCount:    4,
+	}
+
+	tests := []struct {
+		name       string
+		userHandle string
+		wantCode   string
+	}{
+		{"signs the owner in", "global-1", ""},
+		{"rejects a passkey presented for another user", "global-2", "MSG_INVALID_PASSKEY"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			tenantID := uuid.New()
+			usePasskeySecret(t, "test-passkey-secret")
+
+			rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+			rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+			rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+			challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").Return(&domain.ChallengeSession{
+				ChallengeType: constants.ChallengeTypePasskeyLogin,
+				PasskeyState:  "state",
+			}, nil)
+
+			tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+			tenantRepo.EXPECT().GetByID(tenantID).Return(&domain.Tenant{ID: tenantID, Name: "genetica", PublicURL: "https://app.example.com"}, nil)
+
+			passkeyRepo := mock_repositories.NewMockUserPasskeyRepository(ctrl)
+			passkeyRepo.EXPECT().GetByCredentialID(ctx, tenantID.String(), stored.CredentialID).Return(stored, nil)
+
+			passkeys := mock_services.NewMockPasskeyService(ctrl)
+			passkeys.EXPECT().FinishLogin(gomock.Any(), "state", []byte(`{}`), gomock.Any()).
+				DoAndReturn(func(
+					_ types.PasskeyRelyingParty,
+					_ string,
+					_ []byte,
+					lookup func(credentialID, userHandle []byte) (*types.PasskeyCredential, error),
+				) (*types.PasskeyCredential, error) {
+					c, err := lookup(credentialID, []byte(tt.userHandle))
+					if err != nil {
+						return nil, err
+					}
+					assert.Equal(t, []string{"internal", "hybrid"}, c.Transports)
+					assert.Equal(t, uint32(4), c.SignCount)
+					c.SignCount = 5
+					return c, nil
+				})
+
+			kratos := mock_services.NewMockKratosService(ctrl)
+			mfaRepo := mock_repositories.NewMockUserMFARepository(ctrl)
+			settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+			tokenRepo := mock_repositories.NewMockSessionRefreshTokenRepository(ctrl)
+			sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+			if tt.wantCode == "" {
+				token := "token-1"
+				session := client.Session{Id: "session-1", Identity: &client.Identity{Id: "kratos-1"}}
+				challengeRepo.EXPECT().DeleteChallenge(ctx, "flow-1").Return(nil)
+				passkeyRepo.EXPECT().RecordUse(ctx, stored.ID, int64(5), false, gomock.Any()).Return(nil)
+				kratos.EXPECT().InitializeLoginFlow(ctx, tenantID).Return(&client.LoginFlow{}, nil)
+				kratos.EXPECT().SubmitLoginFlow(ctx, tenantID, gomock.Any(), constants.MethodTypePassword.String(), gomock.Any(), gomock.Any(), nil).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, _ *client.LoginFlow, _ string, identifier, password, _ *string) (*client.SuccessfulNativeLogin, error) {
+						assert.Equal(t, "global-1", *identifier)
+						assert.Equal(t, derivedCredential("test-passkey-secret", tenantID, "global-1"), *password)
+						return &client.SuccessfulNativeLogin{Session: session, SessionToken: &token}, nil
+					})
+				mfaRepo.EXPECT().GetFactor(ctx, tenantID.String(), "global-1", constants.MFAFactorTOTP).Return(nil, nil)
+				settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(nil, nil).Times(2)
+				tokenRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+				sessionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+			}
+
+			u := &userUseCase{
+				rateLimiter:             rateLimiter,
+				userAccountStatusRepo:   activeAccountStatusRepo(ctrl),
+				userPasskeyRepo:         passkeyRepo,
+				challengeSessionRepo:    challengeRepo,
+				tenantRepo:              tenantRepo,
+				userMFARepo:             mfaRepo,
+				tenantSettingRepo:       settingRepo,
+				sessionRefreshTokenRepo: tokenRepo,
+				userSessionRepo:         sessionRepo,
+				kratosService:           kratos,
+				passkeyService:          passkeys,
+			}
+
+			resp, derr := u.VerifyPasskey(ctx, tenantID, "flow-1", []byte(`{}`))
+			if tt.wantCode != "" {
+				require.NotNil(t, derr)
+				assert.Equal(t, tt.wantCode, derr.Code)
+				assert.True(t, errors.Is(derr, errPasskeyNotFound))
+				return
+			}
+			require.Nil(t, derr)
+			assert.Equal(t, "global-1", resp.User.GlobalUserID)
+			assert.Equal(t, "token-1", resp.SessionToken)
+			assert.Equal(t, constants.AAL1, resp.AAL)
+			assert.NotEmpty(t, resp.RefreshToken)
+		})
+	}
+}
+
+func TestFinishPasskeyRegistration_RejectsForeignFlow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	usePasskeySecret(t, "test-passkey-secret")
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").Return(&domain.ChallengeSession{
+		ChallengeType: constants.ChallengeTypePasskeyRegister,
+		GlobalUserID:  "global-2",
+		PasskeyState:  "state",
+	}, nil)
+
+	u := &userUseCase{
+		challengeSessionRepo: challengeRepo,
+		passkeyService:       mock_services.NewMockPasskeyService(ctrl),
+	}
+
+	_, derr := u.FinishPasskeyRegistration(ctx, uuid.New(), "global-1", "flow-1", "Laptop", []byte(`{}`))
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_INVALID_CHALLENGE_TYPE", derr.Code)
+}
+
+func TestPasskeyCeremonies_ExpiredChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	usePasskeySecret(t, "test-passkey-secret")
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").Return(nil, nil).Times(2)
+
+	u := &userUseCase{
+		rateLimiter:          rateLimiter,
+		challengeSessionRepo: challengeRepo,
+		passkeyService:       mock_services.NewMockPasskeyService(ctrl),
+	}
+
+	_, derr := u.FinishPasskeyRegistration(ctx, uuid.New(), "global-1", "flow-1", "Laptop", []byte(`{}`))
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_CHALLENGE_SESSION_NOT_FOUND", derr.Code)
+	assert.Equal(t, domainerrors.ErrorTypeNotFound, derr.Type)
+
+	_, derr = u.VerifyPasskey(ctx, uuid.New(), "flow-1", []byte(`{}`))
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_CHALLENGE_SESSION_NOT_FOUND", derr.Code)
+	assert.Equal(t, domainerrors.ErrorTypeNotFound, derr.Type)
+}
@@ -0,0 +1,204 @@
+package ucases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+	"github.com/lifenetwork-ai/iam-service/packages/utils"
+)
+
+// SetPassword sets or replaces the password of the identity behind the current session.
+// Kratos only accepts this on a privileged (recently authenticated) session.
+func (u *userUseCase) SetPassword(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	password string,
+) *domainerrors.DomainError {
+	// 1. Get session token from context
+	sessionToken, derr := extractSessionToken(ctx)
+	if derr != nil {
+		return derr
+	}
+
+	// 2. Wallet and passkey identities sign in with a password derived by IAM; replacing it would
+	// lock the user out of that sign-in method
+	session, err := u.kratosService.WhoAmI(ctx, tenantID, sessionToken)
+	if err != nil || session == nil || session.Identity == nil {
+		return domainerrors.NewUnauthorizedError("MSG_INVALID_SESSION", "Invalid session").WithCause(err)
+	}
+	identities, err := u.userIdentityRepo.ListByTenantAndKratosUserID(ctx, nil, tenantID.String(), session.Identity.Id)
+	if err != nil {
+		return domainerrors.WrapInternal(err, "MSG_GET_IDENTIFIERS_FAILED", "Failed to fetch user identifiers")
+	}
+	for _, identity := range identities {
+		if identity.Type == constants.IdentifierWallet.String() || identity.Type == constants.IdentifierPasskey.String() {
+			return domainerrors.NewValidationError("MSG_PASSWORD_NOT_SUPPORTED", "This sign-in method does not use a password", []any{
+				map[string]string{"type": identity.Type},
+			})
+		}
+	}
+
+	// 3. Validate password against tenant policy
+	setting, derr := getTenantSetting(ctx, u.tenantSettingRepo, tenantID)
+	if derr != nil {
+		return derr
+	}
+	if derr := validatePassword(password, setting, u.breachedPasswordChecker); derr != nil {
+		return derr
+	}
+
+	// 4. Submit password through the settings flow
+	return u.submitPasswordSettings(ctx, tenantID, sessionToken, password)
+}
+
+// ChallengePasswordRecovery sends an OTP to a registered identifier so its password can be reset
+func (u *userUseCase) ChallengePasswordRecovery(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	identifier string,
+) (*types.IdentityUserChallengeResponse, *domainerrors.DomainError) {
+	// 1. Detect & normalize
+	idType, identifier, derr := inferAndNormalizeIdentifier(identifier)
+	if derr != nil {
+		return nil, derr
+	}
+
+	// 2. Rate limit
+	key := fmt.Sprintf("challenge:recovery:%s:%s:%s", idType, identifier, tenantID.String())
+	if err := utils.CheckRateLimitDomain(u.rateLimiter, key, constants.MaxAttemptsPerWindow, constants.RateLimitWindow); err != nil {
+		return nil, domainerrors.NewRateLimitError("MSG_RATE_LIMIT_EXCEEDED", "Rate limit exceeded", err)
+	}
+	if derr := u.checkIdentifierLockout(ctx, tenantID, identifier); derr != nil {
+		return nil, derr
+	}
+
+	// 3. Only registered identifiers can be recovered
+	if _, err := u.userIdentityRepo.GetByTypeAndValue(ctx, nil, tenantID.String(), idType, identifier); err != nil {
+		return nil, domainerrors.NewNotFoundError("MSG_IDENTITY_NOT_FOUND", "Identifier not registered in the system").WithDetails([]interface{}{
+			map[string]string{"field": idType, "error": "Identifier not registered in the system"},
+		})
+	}
+
+	// 4. Send OTP through a code login flow
+	flow, err := u.kratosService.InitializeLoginFlow(ctx, tenantID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_INITIALIZE_LOGIN_FAILED", "Failed to initialize login flow")
+	}
+	if _, err := u.kratosService.SubmitLoginFlow(ctx, tenantID, flow, constants.MethodTypeCode.String(), &identifier, nil, nil); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_SEND_RECOVERY_CODE_FAILED", "Failed to send recovery code")
+	}
+
+	// 5. Save challenge session
+	if err := u.challengeSessionRepo.SaveChallenge(ctx, flow.Id, &domain.ChallengeSession{
+		ChallengeType:  constants.ChallengeTypeRecovery,
+		IdentifierType: idType,
+		Identifier:     identifier,
+	}, constants.DefaultChallengeDuration); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_SAVING_SESSION_FAILED", "Saving challenge session failed")
+	}
+
+	return &types.IdentityUserChallengeResponse{
+		FlowID:      flow.Id,
+		Receiver:    identifier,
+		ChallengeAt: time.Now().Unix(),
+	}, nil
+}
+
+// VerifyPasswordRecovery checks the recovery OTP, sets the new password and returns the resulting session
+func (u *userUseCase) VerifyPasswordRecovery(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	flowID string,
+	code string,
+	newPassword string,
+) (*types.IdentityUserAuthResponse, *domainerrors.DomainError) {
+	// 1. Rate limit
+	key := "verify:recovery:" + flowID
+	if err := utils.CheckRateLimitDomain(u.rateLimiter, key, constants.MaxAttemptsPerWindow, constants.RateLimitWindow); err != nil {
+		return nil, domainerrors.NewRateLimitError("MSG_RATE_LIMIT_EXCEEDED", "Rate limit exceeded", err)
+	}
+
+	// 2. Load session
+	sessionValue, err := u.challengeSessionRepo.GetChallenge(ctx, flowID)
+	if err != nil || sessionValue == nil || sessionValue.ChallengeType != constants.ChallengeTypeRecovery {
+		return nil, domainerrors.NewNotFoundError("MSG_CHALLENGE_SESSION_NOT_FOUND", "Challenge session")
+	}
+	if derr := u.checkIdentifierLockout(ctx, tenantID, sessionValue.Identifier); derr != nil {
+		return nil, derr
+	}
+
+	// 3. Validate the new password before the code is consumed
+	setting, derr := getTenantSetting(ctx, u.tenantSettingRepo, tenantID)
+	if derr != nil {
+		return nil, derr
+	}
+	if derr := validatePassword(newPassword, setting, u.breachedPasswordChecker); derr != nil {
+		return nil, derr
+	}
+
+	// 4. Complete the code login flow to obtain a privileged session
+	flow, err := u.kratosService.GetLoginFlow(ctx, tenantID, flowID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_FLOW_FAILED", "Failed to get login flow")
+	}
+	identifier := sessionValue.Identifier
+	loginResult, err := u.kratosService.SubmitLoginFlow(ctx, tenantID, flow, constants.MethodTypeCode.String(), &identifier, nil, &code)
+	if err != nil {
+		if errors.Is(err, domainservice.ErrFlowRejected) {
+			if derr := u.recordWrongCode(ctx, tenantID, flowID, identifier); derr != nil {
+				return nil, derr
+			}
+		}
+		return nil, domainerrors.NewValidationError("MSG_RECOVERY_FAILED", "Invalid or expired recovery code", []interface{}{err.Error()})
+	}
+	if loginResult.SessionToken == nil {
+		return nil, domainerrors.NewValidationError("MSG_RECOVERY_FAILED", "Invalid or expired recovery code", nil)
+	}
+	u.clearWrongCodes(ctx, tenantID, identifier)
+
+	// 5. Set the new password
+	if derr := u.submitPasswordSettings(ctx, tenantID, *loginResult.SessionToken, newPassword); derr != nil {
+		return nil, derr
+	}
+
+	// 6. Cleanup session
+	_ = u.challengeSessionRepo.DeleteChallenge(ctx, flowID)
+
+	resp := newAuthResponse(&loginResult.Session, *loginResult.SessionToken)
+	if identity, err := u.userIdentityRepo.GetByTenantAndKratosUserID(ctx, nil, tenantID.String(), resp.User.ID); err == nil && identity != nil {
+		resp.User.GlobalUserID = identity.GlobalUserID
+	}
+	return u.completeSignIn(ctx, tenantID, resp, signInOptions{})
+}
+
+// submitPasswordSettings runs a Kratos settings flow with the password method
+func (u *userUseCase) submitPasswordSettings(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	sessionToken string,
+	password string,
+) *domainerrors.DomainError {
+	flow, err := u.kratosService.InitializeSettingsFlow(ctx, tenantID, sessionToken)
+	if err != nil {
+		return domainerrors.NewUnauthorizedError("MSG_INITIALIZE_SETTINGS_FAILED", "Failed to initialize settings flow").WithCause(err)
+	}
+
+	if _, err := u.kratosService.SubmitSettingsFlow(
+		ctx, tenantID, flow, sessionToken, constants.MethodTypePassword.String(),
+		map[string]interface{}{"password": password},
+	); err != nil {
+		logger.GetLogger().Errorf("Failed to submit password settings flow: %v", err)
+		return domainerrors.NewValidationError("MSG_SET_PASSWORD_FAILED", "Failed to set password", []interface{}{err.Error()})
+	}
+
+	return nil
+}
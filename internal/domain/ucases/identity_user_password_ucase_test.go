@@ -0,0 +1,323 @@
+package ucases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	client "github.com/ory/kratos-client-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+	mock_repositories "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/repositories"
+	mock_services "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/services"
+	mock_rate_limiter "github.com/lifenetwork-ai/iam-service/mocks/infrastructures/rate_limiter/types"
+)
+
+func TestSetPassword_RejectsBreachedPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.WithValue(context.Background(), constants.SessionTokenKey, "token-1")
+	tenantID := uuid.New()
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(&domain.TenantSetting{PasswordMinLength: 8, PasswordRejectBreached: true}, nil)
+
+	checker := mock_services.NewMockBreachedPasswordChecker(ctrl)
+	checker.EXPECT().IsBreached("password123").Return(true)
+
+	// The settings flow is never started for a password the policy refuses
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().WhoAmI(ctx, tenantID, "token-1").Return(&client.Session{Identity: &client.Identity{Id: "kratos-1"}}, nil)
+
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	identityRepo.EXPECT().ListByTenantAndKratosUserID(ctx, nil, tenantID.String(), "kratos-1").
+		Return([]*domain.UserIdentity{{Type: constants.IdentifierEmail.String()}}, nil)
+
+	u := &userUseCase{
+		tenantSettingRepo:       settingRepo,
+		breachedPasswordChecker: checker,
+		kratosService:           kratos,
+		userIdentityRepo:        identityRepo,
+	}
+
+	derr := u.SetPassword(ctx, tenantID, "password123")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_PASSWORD_BREACHED", derr.Code)
+}
+
+func TestSetPassword_SubmitsSettingsFlow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.WithValue(context.Background(), constants.SessionTokenKey, "token-1")
+	tenantID := uuid.New()
+	flow := &client.SettingsFlow{Id: "settings-flow-1"}
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(nil, nil)
+
+	checker := mock_services.NewMockBreachedPasswordChecker(ctrl)
+	checker.EXPECT().IsBreached("correct horse battery").Return(false).AnyTimes()
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().WhoAmI(ctx, tenantID, "token-1").Return(&client.Session{Identity: &client.Identity{Id: "kratos-1"}}, nil)
+	kratos.EXPECT().InitializeSettingsFlow(ctx, tenantID, "token-1").Return(flow, nil)
+	kratos.EXPECT().SubmitSettingsFlow(ctx, tenantID, flow, "token-1", constants.MethodTypePassword.String(),
+		map[string]interface{}{"password": "correct horse battery"}).Return(nil, nil)
+
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	identityRepo.EXPECT().ListByTenantAndKratosUserID(ctx, nil, tenantID.String(), "kratos-1").
+		Return([]*domain.UserIdentity{{Type: constants.IdentifierEmail.String()}}, nil)
+
+	u := &userUseCase{
+		tenantSettingRepo:       settingRepo,
+		breachedPasswordChecker: checker,
+		kratosService:           kratos,
+		userIdentityRepo:        identityRepo,
+	}
+
+	assert.Nil(t, u.SetPassword(ctx, tenantID, "correct horse battery"))
+}
+
+func TestSetPassword_RefusesDerivedCredentialIdentities(t *testing.T) {
+	for _, idType := range []constants.IdentifierType{constants.IdentifierWallet, constants.IdentifierPasskey} {
+		t.Run(idType.String(), func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.WithValue(context.Background(), constants.SessionTokenKey, "token-1")
+			tenantID := uuid.New()
+
+			// The settings flow is never started, so the derived password stays in place
+			kratos := mock_services.NewMockKratosService(ctrl)
+			kratos.EXPECT().WhoAmI(ctx, tenantID, "token-1").Return(&client.Session{Identity: &client.Identity{Id: "kratos-1"}}, nil)
+
+			identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+			identityRepo.EXPECT().ListByTenantAndKratosUserID(ctx, nil, tenantID.String(), "kratos-1").
+				Return([]*domain.UserIdentity{{Type: idType.String()}}, nil)
+
+			u := &userUseCase{
+				kratosService:    kratos,
+				userIdentityRepo: identityRepo,
+			}
+
+			derr := u.SetPassword(ctx, tenantID, "correct horse battery")
+			require.NotNil(t, derr)
+			assert.Equal(t, "MSG_PASSWORD_NOT_SUPPORTED", derr.Code)
+		})
+	}
+}
+
+func TestVerifyPasswordRecovery_ExpiredFlow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	// The challenge has outlived its TTL
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").Return(nil, nil)
+
+	u := &userUseCase{
+		rateLimiter:          rateLimiter,
+		challengeSessionRepo: challengeRepo,
+		kratosService:        mock_services.NewMockKratosService(ctrl),
+	}
+
+	resp, derr := u.VerifyPasswordRecovery(ctx, tenantID, "flow-1", "123456", "correct horse battery")
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_CHALLENGE_SESSION_NOT_FOUND", derr.Code)
+}
+
+func TestVerifyPasswordRecovery_WrongCodeKeepsChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	flow := &client.LoginFlow{Id: "flow-1"}
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	// DeleteChallenge is not expected: the user may retry with the right code
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").Return(&domain.ChallengeSession{
+		ChallengeType:  constants.ChallengeTypeRecovery,
+		IdentifierType: constants.IdentifierEmail.String(),
+		Identifier:     "user@example.com",
+	}, nil)
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(nil, nil)
+
+	checker := mock_services.NewMockBreachedPasswordChecker(ctrl)
+	checker.EXPECT().IsBreached(gomock.Any()).Return(false).AnyTimes()
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().GetLoginFlow(ctx, tenantID, "flow-1").Return(flow, nil)
+	kratos.EXPECT().SubmitLoginFlow(ctx, tenantID, flow, constants.MethodTypeCode.String(), gomock.Any(), nil, gomock.Any()).
+		Return(nil, fmt.Errorf("the code is invalid: %w", domainservice.ErrFlowRejected))
+
+	lockoutRepo := mock_repositories.NewMockIdentifierLockoutRepository(ctrl)
+	lockoutRepo.EXPECT().Get(ctx, tenantID.String(), "user@example.com").Return(nil, nil)
+	lockoutRepo.EXPECT().RecordFailure(ctx, tenantID.String(), "user@example.com", gomock.Any(), gomock.Any()).
+		Return(&domain.IdentifierLockout{ID: "lockout-1", FailedAttempts: 1}, nil)
+
+	u := &userUseCase{
+		rateLimiter:             rateLimiter,
+		challengeSessionRepo:    challengeRepo,
+		identifierLockoutRepo:   lockoutRepo,
+		tenantSettingRepo:       settingRepo,
+		breachedPasswordChecker: checker,
+		kratosService:           kratos,
+	}
+
+	resp, derr := u.VerifyPasswordRecovery(ctx, tenantID, "flow-1", "000000", "correct horse battery")
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_RECOVERY_FAILED", derr.Code)
+}
+
+func TestVerifyPasswordRecovery_WrongCodeLocksOutIdentifierAtLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	flow := &client.LoginFlow{Id: "flow-1"}
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").Return(&domain.ChallengeSession{
+		ChallengeType:  constants.ChallengeTypeRecovery,
+		IdentifierType: constants.IdentifierEmail.String(),
+		Identifier:     "user@example.com",
+	}, nil)
+	challengeRepo.EXPECT().DeleteChallenge(ctx, "flow-1").Return(nil)
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(nil, nil)
+
+	checker := mock_services.NewMockBreachedPasswordChecker(ctrl)
+	checker.EXPECT().IsBreached(gomock.Any()).Return(false).AnyTimes()
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().GetLoginFlow(ctx, tenantID, "flow-1").Return(flow, nil)
+	kratos.EXPECT().SubmitLoginFlow(ctx, tenantID, flow, constants.MethodTypeCode.String(), gomock.Any(), nil, gomock.Any()).
+		Return(nil, fmt.Errorf("the code is invalid: %w", domainservice.ErrFlowRejected))
+
+	lockoutRepo := mock_repositories.NewMockIdentifierLockoutRepository(ctrl)
+	lockoutRepo.EXPECT().Get(ctx, tenantID.String(), "user@example.com").Return(nil, nil)
+	lockoutRepo.EXPECT().RecordFailure(ctx, tenantID.String(), "user@example.com", gomock.Any(), gomock.Any()).
+		Return(&domain.IdentifierLockout{ID: "lockout-1", FailedAttempts: constants.OTPMaxFailedAttempts}, nil)
+	lockoutRepo.EXPECT().Lock(ctx, "lockout-1", gomock.Any()).Return(nil)
+
+	u := &userUseCase{
+		rateLimiter:             rateLimiter,
+		challengeSessionRepo:    challengeRepo,
+		identifierLockoutRepo:   lockoutRepo,
+		tenantSettingRepo:       settingRepo,
+		breachedPasswordChecker: checker,
+		kratosService:           kratos,
+	}
+
+	resp, derr := u.VerifyPasswordRecovery(ctx, tenantID, "flow-1", "000000", "correct horse battery")
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_IDENTIFIER_LOCKED", derr.Code)
+}
+
+func TestVerifyPasswordRecovery_BreachedPasswordKeepsCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").Return(&domain.ChallengeSession{
+		ChallengeType:  constants.ChallengeTypeRecovery,
+		IdentifierType: constants.IdentifierEmail.String(),
+		Identifier:     "user@example.com",
+	}, nil)
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(&domain.TenantSetting{PasswordMinLength: 8, PasswordRejectBreached: true}, nil)
+
+	checker := mock_services.NewMockBreachedPasswordChecker(ctrl)
+	checker.EXPECT().IsBreached("password123").Return(true)
+
+	// The code is not submitted, so it stays usable with a better password
+	kratos := mock_services.NewMockKratosService(ctrl)
+
+	lockoutRepo := mock_repositories.NewMockIdentifierLockoutRepository(ctrl)
+	lockoutRepo.EXPECT().Get(ctx, tenantID.String(), "user@example.com").Return(nil, nil)
+
+	u := &userUseCase{
+		rateLimiter:             rateLimiter,
+		challengeSessionRepo:    challengeRepo,
+		identifierLockoutRepo:   lockoutRepo,
+		tenantSettingRepo:       settingRepo,
+		breachedPasswordChecker: checker,
+		kratosService:           kratos,
+	}
+
+	resp, derr := u.VerifyPasswordRecovery(ctx, tenantID, "flow-1", "123456", "password123")
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_PASSWORD_BREACHED", derr.Code)
+}
+
+func TestChallengePasswordRecovery_UnknownIdentifier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	identityRepo.EXPECT().GetByTypeAndValue(ctx, nil, tenantID.String(), constants.IdentifierEmail.String(), "user@example.com").
+		Return(nil, errors.New("record not found"))
+
+	lockoutRepo := mock_repositories.NewMockIdentifierLockoutRepository(ctrl)
+	lockoutRepo.EXPECT().Get(ctx, tenantID.String(), "user@example.com").Return(nil, nil)
+
+	u := &userUseCase{
+		rateLimiter:           rateLimiter,
+		userIdentityRepo:      identityRepo,
+		identifierLockoutRepo: lockoutRepo,
+		kratosService:         mock_services.NewMockKratosService(ctrl),
+	}
+
+	resp, derr := u.ChallengePasswordRecovery(ctx, tenantID, "user@example.com")
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_IDENTITY_NOT_FOUND", derr.Code)
+}
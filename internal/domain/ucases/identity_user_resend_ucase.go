@@ -0,0 +1,181 @@
+package ucases
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	cachetypes "github.com/lifenetwork-ai/iam-service/infrastructures/caching/types"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+	"github.com/lifenetwork-ai/iam-service/packages/utils"
+)
+
+// ResendChallenge sends a new code for the challenge of an ongoing flow. Unlike starting a new
+// challenge it keeps the flow, so it does not count against the challenge rate limit; instead each
+// flow may only ask for a code once per cooldown. A phone number can switch to another channel.
+func (u *userUseCase) ResendChallenge(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	flowID string,
+	channel string,
+) (*types.IdentityUserResendResponse, *domainerrors.DomainError) {
+	key := "challenge:resend:" + flowID
+	if err := utils.CheckRateLimitDomain(u.rateLimiter, key, constants.MaxAttemptsPerWindow, constants.RateLimitWindow); err != nil {
+		return nil, domainerrors.NewRateLimitError("MSG_RATE_LIMIT_EXCEEDED", "Rate limit exceeded", err)
+	}
+
+	session, err := u.challengeSessionRepo.GetChallenge(ctx, flowID)
+	if err != nil || session == nil {
+		return nil, domainerrors.NewNotFoundError("MSG_CHALLENGE_SESSION_NOT_FOUND", "Challenge session")
+	}
+
+	if derr := u.checkIdentifierLockout(ctx, tenantID, session.Identifier); derr != nil {
+		return nil, derr
+	}
+
+	var resend func() error
+	switch session.ChallengeType {
+	case "", constants.ChallengeTypeLogin:
+		resend = func() error {
+			return u.kratosService.ResendLoginCode(ctx, tenantID, flowID, session.Identifier)
+		}
+	case constants.ChallengeTypeRegister, constants.ChallengeTypeAddIdentifier, constants.ChallengeTypeChangeIdentifier:
+		resend = func() error {
+			return u.kratosService.ResendRegistrationCode(ctx, tenantID, flowID)
+		}
+	case constants.ChallengeTypeVerifyIdentifier:
+		resend = func() error {
+			identifier := session.Identifier
+			_, err := u.kratosService.SubmitVerificationFlow(
+				ctx, tenantID, flowID, &identifier, constants.IdentifierType(session.IdentifierType), nil,
+			)
+			return err
+		}
+	default:
+		return nil, domainerrors.NewValidationError("MSG_CHALLENGE_NOT_RESENDABLE", "No new code can be sent for this challenge", nil)
+	}
+
+	tenantName, derr := u.resendChannelTenant(ctx, tenantID, session.IdentifierType, session.Identifier, channel)
+	if derr != nil {
+		return nil, derr
+	}
+
+	now := time.Now()
+	if derr := u.startResendCooldown(flowID, now); derr != nil {
+		return nil, derr
+	}
+
+	if err := resend(); err != nil {
+		logger.GetLogger().Errorf("Failed to resend code for flow %s: %v", flowID, err)
+		// No code went out, so the user may try again straight away
+		if err := u.cacheRepo.RemoveItem(resendCooldownKey(flowID)); err != nil {
+			logger.GetLogger().Warnf("Failed to clear resend cooldown for flow %s: %v", flowID, err)
+		}
+		return nil, domainerrors.WrapInternal(err, "MSG_RESEND_CODE_FAILED", "Failed to resend code")
+	}
+
+	// The new code gets as long as the first one did
+	if err := u.challengeSessionRepo.SaveChallenge(ctx, flowID, session, constants.DefaultChallengeDuration); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_SAVING_SESSION_FAILED", "Saving challenge session failed")
+	}
+
+	return &types.IdentityUserResendResponse{
+		FlowID:       flowID,
+		Receiver:     session.Identifier,
+		Channel:      u.switchResendChannel(ctx, tenantName, session.IdentifierType, session.Identifier, channel),
+		ChallengeAt:  now.Unix(),
+		NextResendAt: now.Add(constants.ChallengeResendCooldown).Unix(),
+	}, nil
+}
+
+// resendChannelTenant checks that the requested channel, if any, can deliver to the identifier and
+// returns the name of the tenant whose channels a phone number uses
+func (u *userUseCase) resendChannelTenant(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	identifierType, identifier, channel string,
+) (string, *domainerrors.DomainError) {
+	if identifierType != constants.IdentifierPhone.String() {
+		if channel != "" {
+			return "", domainerrors.NewValidationError("MSG_CHANNEL_NOT_SUPPORTED", "Only codes sent to a phone number can switch channel", []any{
+				map[string]string{"channel": channel},
+			})
+		}
+		return "", nil
+	}
+
+	tenant, err := u.tenantRepo.GetByID(tenantID)
+	if err != nil {
+		return "", domainerrors.WrapInternal(err, "MSG_GET_TENANT_FAILED", "Failed to get tenant")
+	}
+	if channel != "" {
+		supportedChannels := u.courierUseCase.GetAvailableChannels(ctx, tenant.Name, identifier)
+		if !slices.Contains(supportedChannels, channel) {
+			return "", domainerrors.NewValidationError("MSG_CHANNEL_NOT_SUPPORTED", "Channel not supported", []any{
+				map[string]string{"channel": channel, "supported_channels": strings.Join(supportedChannels, ", ")},
+			})
+		}
+	}
+	return tenant.Name, nil
+}
+
+// switchResendChannel moves a phone number to the requested channel once its new code is on the way,
+// so a failed resend leaves the channel unchanged, and returns the channel the code is delivered
+// through. Kratos queues the code and only hands it to the courier after the resend returns, so
+// the switch still applies to it. The code is sent either way, so failing to switch is logged.
+func (u *userUseCase) switchResendChannel(ctx context.Context, tenantName, identifierType, identifier, channel string) string {
+	if identifierType != constants.IdentifierPhone.String() {
+		return constants.ChannelEmail
+	}
+
+	if channel != "" {
+		if derr := u.courierUseCase.ChooseChannel(ctx, tenantName, identifier, channel); derr != nil {
+			logger.GetLogger().Warnf("Failed to switch %s to channel %s: %v", identifier, channel, derr)
+		}
+	}
+	current, derr := u.courierUseCase.GetChannel(ctx, tenantName, identifier)
+	if derr != nil {
+		logger.GetLogger().Warnf("Failed to get channel of %s: %v", identifier, derr)
+		return channel
+	}
+	return current.Channel
+}
+
+// startResendCooldown claims the cooldown after sentAt for the flow. The claim is atomic, so of two
+// resends racing for the same flow only one sends a code. The cooldown is only a courtesy to the
+// user's phone, so failing to store it is logged.
+func (u *userUseCase) startResendCooldown(flowID string, sentAt time.Time) *domainerrors.DomainError {
+	resendAt := sentAt.Add(constants.ChallengeResendCooldown).Unix()
+	claimed, err := u.cacheRepo.SaveItemIfAbsent(resendCooldownKey(flowID), resendAt, constants.ChallengeResendCooldown)
+	if err != nil {
+		logger.GetLogger().Warnf("Failed to start resend cooldown for flow %s: %v", flowID, err)
+		return nil
+	}
+	if claimed {
+		return nil
+	}
+
+	// Report when the cooldown that holds the flow ends; it was claimed no earlier than now
+	if err := u.cacheRepo.RetrieveItem(resendCooldownKey(flowID), &resendAt); err != nil {
+		logger.GetLogger().Warnf("Failed to get resend cooldown for flow %s: %v", flowID, err)
+	}
+	return domainerrors.NewRateLimitError(
+		"MSG_RESEND_COOLDOWN",
+		"Please wait before asking for another code",
+		map[string]interface{}{
+			"next_resend_at": resendAt,
+			"retry_after":    int(math.Ceil(time.Unix(resendAt, 0).Sub(sentAt).Seconds())),
+		},
+	)
+}
+
+func resendCooldownKey(flowID string) *cachetypes.Keyer {
+	return &cachetypes.Keyer{Raw: "challenge:resend-after:" + flowID}
+}
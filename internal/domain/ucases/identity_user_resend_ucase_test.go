@@ -0,0 +1,277 @@
+package ucases
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	gocache "github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	"github.com/lifenetwork-ai/iam-service/infrastructures/caching"
+	cachetypes "github.com/lifenetwork-ai/iam-service/infrastructures/caching/types"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	mock_interfaces "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/interfaces"
+	mock_repositories "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/repositories"
+	mock_services "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/services"
+	mock_rate_limiter "github.com/lifenetwork-ai/iam-service/mocks/infrastructures/rate_limiter/types"
+)
+
+func newResendCache() cachetypes.CacheRepository {
+	return caching.NewCachingRepository(context.Background(), caching.NewGoCacheClient(gocache.New(time.Minute, time.Minute)))
+}
+
+func TestResendChallenge_SwitchesChannelThenWaitsForCooldown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	session := &domain.ChallengeSession{IdentifierType: constants.IdentifierPhone.String(), Identifier: "+84901234567"}
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil).Times(2)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").Return(session, nil).Times(2)
+	challengeRepo.EXPECT().SaveChallenge(ctx, "flow-1", session, constants.DefaultChallengeDuration).Return(nil)
+
+	lockoutRepo := mock_repositories.NewMockIdentifierLockoutRepository(ctrl)
+	lockoutRepo.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+
+	tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+	tenantRepo.EXPECT().GetByID(tenantID).Return(&domain.Tenant{ID: tenantID, Name: "genetica"}, nil).Times(2)
+
+	// The channel switches once the code is on its way
+	kratos := mock_services.NewMockKratosService(ctrl)
+	courier := mock_interfaces.NewMockCourierUseCase(ctrl)
+	gomock.InOrder(
+		courier.EXPECT().GetAvailableChannels(ctx, "genetica", "+84901234567").Return([]string{constants.ChannelSMS, constants.ChannelZalo}),
+		kratos.EXPECT().ResendLoginCode(ctx, tenantID, "flow-1", "+84901234567").Return(nil),
+		courier.EXPECT().ChooseChannel(ctx, "genetica", "+84901234567", constants.ChannelZalo).Return(nil),
+		courier.EXPECT().GetChannel(ctx, "genetica", "+84901234567").Return(types.ChooseChannelResponse{Channel: constants.ChannelZalo}, nil),
+	)
+
+	u := &userUseCase{
+		cacheRepo:             newResendCache(),
+		rateLimiter:           rateLimiter,
+		challengeSessionRepo:  challengeRepo,
+		identifierLockoutRepo: lockoutRepo,
+		tenantRepo:            tenantRepo,
+		kratosService:         kratos,
+		courierUseCase:        courier,
+	}
+
+	resp, derr := u.ResendChallenge(ctx, tenantID, "flow-1", constants.ChannelZalo)
+	require.Nil(t, derr)
+	assert.Equal(t, constants.ChannelZalo, resp.Channel)
+	assert.Equal(t, "+84901234567", resp.Receiver)
+	assert.Equal(t, resp.ChallengeAt+int64(constants.ChallengeResendCooldown.Seconds()), resp.NextResendAt)
+
+	// A second resend right away is refused without asking Kratos for another code
+	resp, derr = u.ResendChallenge(ctx, tenantID, "flow-1", "")
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_RESEND_COOLDOWN", derr.Code)
+	details, ok := derr.Details.(map[string]interface{})
+	require.True(t, ok)
+	assert.InDelta(t, constants.ChallengeResendCooldown.Seconds(), details["retry_after"], 1)
+}
+
+func TestResendChallenge_FailedResendKeepsChannel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	session := &domain.ChallengeSession{IdentifierType: constants.IdentifierPhone.String(), Identifier: "+84901234567"}
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil).Times(2)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").Return(session, nil).Times(2)
+	challengeRepo.EXPECT().SaveChallenge(ctx, "flow-1", session, constants.DefaultChallengeDuration).Return(nil)
+
+	lockoutRepo := mock_repositories.NewMockIdentifierLockoutRepository(ctrl)
+	lockoutRepo.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+
+	tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+	tenantRepo.EXPECT().GetByID(tenantID).Return(&domain.Tenant{ID: tenantID, Name: "genetica"}, nil).Times(2)
+
+	// ChooseChannel only follows the resend that went through
+	kratos := mock_services.NewMockKratosService(ctrl)
+	courier := mock_interfaces.NewMockCourierUseCase(ctrl)
+	courier.EXPECT().GetAvailableChannels(ctx, "genetica", "+84901234567").Return([]string{constants.ChannelSMS, constants.ChannelZalo}).Times(2)
+	gomock.InOrder(
+		kratos.EXPECT().ResendLoginCode(ctx, tenantID, "flow-1", "+84901234567").Return(errors.New("kratos unavailable")),
+		kratos.EXPECT().ResendLoginCode(ctx, tenantID, "flow-1", "+84901234567").Return(nil),
+		courier.EXPECT().ChooseChannel(ctx, "genetica", "+84901234567", constants.ChannelZalo).Return(nil),
+		courier.EXPECT().GetChannel(ctx, "genetica", "+84901234567").Return(types.ChooseChannelResponse{Channel: constants.ChannelZalo}, nil),
+	)
+
+	u := &userUseCase{
+		cacheRepo:             newResendCache(),
+		rateLimiter:           rateLimiter,
+		challengeSessionRepo:  challengeRepo,
+		identifierLockoutRepo: lockoutRepo,
+		tenantRepo:            tenantRepo,
+		kratosService:         kratos,
+		courierUseCase:        courier,
+	}
+
+	resp, derr := u.ResendChallenge(ctx, tenantID, "flow-1", constants.ChannelZalo)
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_RESEND_CODE_FAILED", derr.Code)
+
+	// No code went out, so the retry is not held back by the cooldown
+	resp, derr = u.ResendChallenge(ctx, tenantID, "flow-1", constants.ChannelZalo)
+	require.Nil(t, derr)
+	assert.Equal(t, constants.ChannelZalo, resp.Channel)
+}
+
+func TestResendChallenge_ConcurrentResendsSendOneCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	session := &domain.ChallengeSession{
+		ChallengeType:  constants.ChallengeTypeRegister,
+		IdentifierType: constants.IdentifierEmail.String(),
+		Identifier:     "user@example.com",
+	}
+	const resends = 8
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil).Times(resends)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").Return(session, nil).Times(resends)
+	challengeRepo.EXPECT().SaveChallenge(ctx, "flow-1", session, constants.DefaultChallengeDuration).Return(nil)
+
+	lockoutRepo := mock_repositories.NewMockIdentifierLockoutRepository(ctrl)
+	lockoutRepo.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(resends)
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().ResendRegistrationCode(ctx, tenantID, "flow-1").Return(nil)
+
+	u := &userUseCase{
+		cacheRepo:             newResendCache(),
+		rateLimiter:           rateLimiter,
+		challengeSessionRepo:  challengeRepo,
+		identifierLockoutRepo: lockoutRepo,
+		kratosService:         kratos,
+	}
+
+	var wg sync.WaitGroup
+	var sent, cooledDown atomic.Int32
+	for i := 0; i < resends; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, derr := u.ResendChallenge(ctx, tenantID, "flow-1", "")
+			switch {
+			case derr == nil:
+				sent.Add(1)
+			case derr.Code == "MSG_RESEND_COOLDOWN":
+				cooledDown.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), sent.Load())
+	assert.Equal(t, int32(resends-1), cooledDown.Load())
+}
+
+func TestResendChallenge_ResendsRegistrationCodeByEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	session := &domain.ChallengeSession{
+		ChallengeType:  constants.ChallengeTypeRegister,
+		IdentifierType: constants.IdentifierEmail.String(),
+		Identifier:     "user@example.com",
+	}
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil).Times(2)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").Return(session, nil).Times(2)
+	challengeRepo.EXPECT().SaveChallenge(ctx, "flow-1", session, constants.DefaultChallengeDuration).Return(nil)
+
+	lockoutRepo := mock_repositories.NewMockIdentifierLockoutRepository(ctrl)
+	lockoutRepo.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().ResendRegistrationCode(ctx, tenantID, "flow-1").Return(nil)
+
+	u := &userUseCase{
+		cacheRepo:             newResendCache(),
+		rateLimiter:           rateLimiter,
+		challengeSessionRepo:  challengeRepo,
+		identifierLockoutRepo: lockoutRepo,
+		kratosService:         kratos,
+	}
+
+	// Emails have no channel to switch to
+	_, derr := u.ResendChallenge(ctx, tenantID, "flow-1", constants.ChannelSMS)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_CHANNEL_NOT_SUPPORTED", derr.Code)
+
+	resp, derr := u.ResendChallenge(ctx, tenantID, "flow-1", "")
+	require.Nil(t, derr)
+	assert.Equal(t, constants.ChannelEmail, resp.Channel)
+}
+
+func TestResendChallenge_RefusesChallengesWithoutCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil).Times(2)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").
+		Return(&domain.ChallengeSession{ChallengeType: constants.ChallengeTypePasskeyLogin}, nil)
+	challengeRepo.EXPECT().GetChallenge(ctx, "flow-2").Return(nil, nil)
+
+	lockoutRepo := mock_repositories.NewMockIdentifierLockoutRepository(ctrl)
+	lockoutRepo.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	u := &userUseCase{
+		cacheRepo:             newResendCache(),
+		rateLimiter:           rateLimiter,
+		challengeSessionRepo:  challengeRepo,
+		identifierLockoutRepo: lockoutRepo,
+		kratosService:         mock_services.NewMockKratosService(ctrl),
+	}
+
+	_, derr := u.ResendChallenge(ctx, uuid.New(), "flow-1", "")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_CHALLENGE_NOT_RESENDABLE", derr.Code)
+
+	_, derr = u.ResendChallenge(ctx, uuid.New(), "flow-2", "")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_CHALLENGE_SESSION_NOT_FOUND", derr.Code)
+}
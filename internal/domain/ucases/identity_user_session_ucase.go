@@ -0,0 +1,502 @@
+package ucases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+	"github.com/lifenetwork-ai/iam-service/packages/utils"
+)
+
+// RefreshToken extends a still-valid session and rotates its refresh token.
+// Presenting a refresh token that was already used revokes the whole chain.
+func (u *userUseCase) RefreshToken(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	accessToken string,
+	refreshToken string,
+) (*types.IdentityUserAuthResponse, *domainerrors.DomainError) {
+	// 1. Validate input
+	if accessToken == "" || refreshToken == "" {
+		return nil, domainerrors.NewValidationError("MSG_INVALID_PAYLOAD", "Session token and refresh token are required", nil)
+	}
+	tokenHash := utils.HashToken(refreshToken)
+
+	// 2. Rate limit
+	key := "refresh:session:" + tokenHash
+	if err := utils.CheckRateLimitDomain(u.rateLimiter, key, constants.MaxAttemptsPerWindow, constants.RateLimitWindow); err != nil {
+		return nil, domainerrors.NewRateLimitError("MSG_RATE_LIMIT_EXCEEDED", "Rate limit exceeded", err)
+	}
+
+	// 3. Load refresh token
+	record, err := u.sessionRefreshTokenRepo.GetByTokenHash(ctx, tokenHash)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_REFRESH_TOKEN_FAILED", "Failed to get refresh token")
+	}
+	if record == nil || record.TenantID != tenantID.String() {
+		return nil, domainerrors.NewUnauthorizedError("MSG_INVALID_REFRESH_TOKEN", "Invalid refresh token")
+	}
+
+	// 4. Reject revoked chains and replayed tokens
+	if record.RevokedAt != nil {
+		return nil, domainerrors.NewUnauthorizedError("MSG_SESSION_REVOKED", "Session has been revoked")
+	}
+	if record.RotatedAt != nil {
+		u.revokeSessionChain(ctx, tenantID, record)
+		return nil, domainerrors.NewUnauthorizedError("MSG_REFRESH_TOKEN_REUSED", "Refresh token has already been used")
+	}
+
+	// 5. Enforce the tenant's maximum session lifetime
+	now := time.Now()
+	if !now.Before(record.ExpiresAt) {
+		u.revokeSessionChain(ctx, tenantID, record)
+		return nil, domainerrors.NewUnauthorizedError("MSG_SESSION_MAX_LIFETIME_EXCEEDED", "Session has reached its maximum lifetime")
+	}
+
+	// 6. The session token must still be valid and belong to the same chain
+	session, err := u.kratosService.GetSession(ctx, tenantID, accessToken)
+	if err != nil || session == nil || session.Id != record.KratosSessionID {
+		return nil, domainerrors.NewUnauthorizedError("MSG_INVALID_SESSION", "Invalid session")
+	}
+
+	// 7. Suspended and banned users cannot keep their session alive
+	identity, err := u.userIdentityRepo.GetByTenantAndKratosUserID(ctx, nil, tenantID.String(), record.KratosUserID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_IDENTIFIERS_FAILED", "Failed to fetch user identifiers")
+	}
+	globalUserID := ""
+	if identity != nil {
+		globalUserID = identity.GlobalUserID
+		if derr := u.checkAccountStatus(ctx, tenantID, globalUserID); derr != nil {
+			return nil, derr
+		}
+	}
+
+	// 8. Extend the Kratos session. The refresh token is still live, so the client can retry
+	// when this fails.
+	extended, err := u.kratosService.ExtendSession(ctx, tenantID, session.Id)
+	if err != nil {
+		logger.GetLogger().Errorf("Failed to extend session: %v", err)
+		return nil, domainerrors.WrapInternal(err, "MSG_EXTEND_SESSION_FAILED", "Failed to extend session")
+	}
+
+	// 9. Swap the current refresh token for the next one in the chain; losing the race means it
+	// was replayed
+	next, err := utils.RandomToken(constants.RefreshTokenBytes)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_ISSUE_REFRESH_TOKEN_FAILED", "Failed to issue refresh token")
+	}
+	rotated, err := u.sessionRefreshTokenRepo.Rotate(ctx, record.ID, &domain.SessionRefreshToken{
+		TenantID:        record.TenantID,
+		FamilyID:        record.FamilyID,
+		ParentID:        &record.ID,
+		KratosSessionID: record.KratosSessionID,
+		KratosUserID:    record.KratosUserID,
+		TokenHash:       utils.HashToken(next),
+		ChainStartedAt:  record.ChainStartedAt,
+		ExpiresAt:       record.ExpiresAt,
+	}, now)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_ROTATE_REFRESH_TOKEN_FAILED", "Failed to rotate refresh token")
+	}
+	if !rotated {
+		u.revokeSessionChain(ctx, tenantID, record)
+		return nil, domainerrors.NewUnauthorizedError("MSG_REFRESH_TOKEN_REUSED", "Refresh token has already been used")
+	}
+
+	resp := newAuthResponse(session, accessToken)
+	resp.RefreshToken = next
+	if extended != nil && extended.ExpiresAt != nil {
+		resp.ExpiresAt = extended.ExpiresAt
+	}
+	// Kratos extends by its own lifespan, which may outlast the tenant's cap
+	if resp.ExpiresAt == nil || resp.ExpiresAt.After(record.ExpiresAt) {
+		chainExpiresAt := record.ExpiresAt
+		resp.ExpiresAt = &chainExpiresAt
+	}
+	if err := u.userSessionRepo.Extend(ctx, tenantID.String(), session.Id, *resp.ExpiresAt); err != nil {
+		logger.GetLogger().Errorf("Failed to extend tracked session: %v", err)
+	}
+	if globalUserID != "" {
+		resp.User.GlobalUserID = globalUserID
+	}
+
+	return resp, nil
+}
+
+// startSession attaches a refresh token to a freshly issued session, records the client it was
+// issued to and enforces the tenant's concurrent session limit. With rememberDevice the client
+// becomes a trusted device unless it already is one; sessions of trusted devices can be refreshed
+// for longer.
+func (u *userUseCase) startSession(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	resp *types.IdentityUserAuthResponse,
+	rememberDevice bool,
+) {
+	if resp == nil || resp.SessionID == "" || resp.User == nil {
+		return
+	}
+
+	setting, derr := getTenantSetting(ctx, u.tenantSettingRepo, tenantID)
+	if derr != nil {
+		logger.GetLogger().Errorf("Failed to get tenant settings for new session: %v", derr)
+		return
+	}
+
+	now := time.Now()
+	trusted := u.recognizeDevice(ctx, tenantID, resp.User.GlobalUserID, now) != nil
+	if rememberDevice && !trusted {
+		token, err := u.rememberDevice(ctx, tenantID, resp.User.GlobalUserID, setting, now)
+		if err != nil {
+			logger.GetLogger().Errorf("Failed to remember device: %v", err)
+		}
+		resp.DeviceToken = token
+		trusted = token != ""
+	}
+
+	maxLifetime := setting.SessionMaxLifetime()
+	if trusted {
+		maxLifetime = setting.TrustedDeviceSessionMaxLifetime()
+	}
+	u.attachRefreshToken(ctx, tenantID, resp, maxLifetime)
+	u.trackSession(ctx, tenantID, resp, setting)
+}
+
+// attachRefreshToken starts a new refresh chain for a freshly issued session, which can be
+// refreshed for maxLifetime. Failing to do so only costs the client the ability to refresh,
+// so sign-in still succeeds.
+func (u *userUseCase) attachRefreshToken(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	resp *types.IdentityUserAuthResponse,
+	maxLifetime time.Duration,
+) {
+	now := time.Now()
+	token, err := u.createRefreshToken(ctx, &domain.SessionRefreshToken{
+		TenantID:        tenantID.String(),
+		FamilyID:        uuid.NewString(),
+		KratosSessionID: resp.SessionID,
+		KratosUserID:    resp.User.ID,
+		ChainStartedAt:  now,
+		ExpiresAt:       now.Add(maxLifetime),
+	})
+	if err != nil {
+		logger.GetLogger().Errorf("Failed to issue refresh token: %v", err)
+		return
+	}
+	resp.RefreshToken = token
+}
+
+// trackSession records a new session for the session list, then signs out the user's oldest
+// sessions beyond the tenant's limit
+func (u *userUseCase) trackSession(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	resp *types.IdentityUserAuthResponse,
+	setting *domain.TenantSetting,
+) {
+	globalUserID := resp.User.GlobalUserID
+	if globalUserID == "" {
+		return
+	}
+
+	ip, userAgent := clientInfo(ctx)
+	now := time.Now()
+	if err := u.userSessionRepo.Create(ctx, &domain.UserSession{
+		TenantID:        tenantID.String(),
+		GlobalUserID:    globalUserID,
+		KratosSessionID: resp.SessionID,
+		KratosUserID:    resp.User.ID,
+		IPAddress:       ip,
+		UserAgent:       userAgent,
+		AAL:             resp.AAL,
+		ExpiresAt:       resp.ExpiresAt,
+		LastSeenAt:      now,
+	}); err != nil {
+		logger.GetLogger().Errorf("Failed to record session: %v", err)
+		return
+	}
+
+	if setting.MaxConcurrentSessions <= 0 {
+		return
+	}
+	sessions, err := u.userSessionRepo.ListActive(ctx, tenantID.String(), globalUserID, now)
+	if err != nil {
+		logger.GetLogger().Errorf("Failed to list sessions for concurrent session limit: %v", err)
+		return
+	}
+	for i := 0; i < len(sessions)-setting.MaxConcurrentSessions; i++ {
+		if sessions[i].KratosSessionID == resp.SessionID {
+			continue
+		}
+		if err := u.revokeSession(ctx, tenantID, sessions[i].KratosSessionID); err != nil {
+			logger.GetLogger().Errorf("Failed to evict session %s: %v", sessions[i].KratosSessionID, err)
+		}
+	}
+}
+
+// createRefreshToken generates a token, stores its hash on the record and returns the clear value
+func (u *userUseCase) createRefreshToken(ctx context.Context, record *domain.SessionRefreshToken) (string, error) {
+	token, err := utils.RandomToken(constants.RefreshTokenBytes)
+	if err != nil {
+		return "", err
+	}
+	record.TokenHash = utils.HashToken(token)
+	if err := u.sessionRefreshTokenRepo.Create(ctx, record); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// revokeSessionChain revokes every refresh token of the chain and the Kratos session behind it
+func (u *userUseCase) revokeSessionChain(ctx context.Context, tenantID uuid.UUID, record *domain.SessionRefreshToken) {
+	if err := u.sessionRefreshTokenRepo.RevokeFamily(ctx, record.FamilyID, time.Now()); err != nil {
+		logger.GetLogger().Errorf("Failed to revoke refresh token family %s: %v", record.FamilyID, err)
+	}
+	if err := u.kratosService.DisableSessionAdmin(ctx, tenantID, record.KratosSessionID); err != nil {
+		logger.GetLogger().Errorf("Failed to disable session %s: %v", record.KratosSessionID, err)
+	}
+	u.sessionCache.invalidateSession(record.KratosSessionID)
+	if err := u.userSessionRepo.Revoke(ctx, tenantID.String(), record.KratosSessionID, time.Now()); err != nil {
+		logger.GetLogger().Errorf("Failed to mark session %s revoked: %v", record.KratosSessionID, err)
+	}
+}
+
+// revokeSession signs a session out in Kratos, drops it from the session cache and revokes its refresh tokens
+func (u *userUseCase) revokeSession(ctx context.Context, tenantID uuid.UUID, kratosSessionID string) error {
+	if err := u.kratosService.DisableSessionAdmin(ctx, tenantID, kratosSessionID); err != nil {
+		return err
+	}
+	u.sessionCache.invalidateSession(kratosSessionID)
+	now := time.Now()
+	if err := u.sessionRefreshTokenRepo.RevokeBySession(ctx, tenantID.String(), kratosSessionID, now); err != nil {
+		return err
+	}
+	return u.userSessionRepo.Revoke(ctx, tenantID.String(), kratosSessionID, now)
+}
+
+// revokeUserSessions signs a user out of every session in the tenant and revokes their refresh
+// tokens. A Kratos session that cannot be disabled is only logged: callers use this when the
+// user is no longer allowed in, and that is enforced whenever the session is used.
+func revokeUserSessions(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+	kratosService domainservice.KratosService,
+	userSessionRepo domainrepo.UserSessionRepository,
+	sessionRefreshTokenRepo domainrepo.SessionRefreshTokenRepository,
+	cache *SessionCache,
+) error {
+	now := time.Now()
+	sessions, err := userSessionRepo.ListActive(ctx, tenantID.String(), globalUserID, now)
+	if err != nil {
+		return fmt.Errorf("list sessions: %w", err)
+	}
+	for _, session := range sessions {
+		if err := kratosService.DisableSessionAdmin(ctx, tenantID, session.KratosSessionID); err != nil {
+			logger.GetLogger().Errorf("Failed to disable session %s: %v", session.KratosSessionID, err)
+		}
+		if err := sessionRefreshTokenRepo.RevokeBySession(ctx, tenantID.String(), session.KratosSessionID, now); err != nil {
+			return fmt.Errorf("revoke refresh tokens of session %s: %w", session.KratosSessionID, err)
+		}
+		if err := userSessionRepo.Revoke(ctx, tenantID.String(), session.KratosSessionID, now); err != nil {
+			return fmt.Errorf("revoke session %s: %w", session.KratosSessionID, err)
+		}
+	}
+	cache.invalidateUser(globalUserID)
+	return nil
+}
+
+// ListSessions returns the user's active sessions, newest first, flagging the one making the request
+func (u *userUseCase) ListSessions(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+) ([]*types.UserSessionResponse, *domainerrors.DomainError) {
+	currentID, derr := u.currentSessionID(ctx, tenantID)
+	if derr != nil {
+		return nil, derr
+	}
+
+	sessions, err := u.userSessionRepo.ListActive(ctx, tenantID.String(), globalUserID, time.Now())
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_LIST_SESSIONS_FAILED", "Failed to list sessions")
+	}
+	resp := make([]*types.UserSessionResponse, 0, len(sessions))
+	for i := len(sessions) - 1; i >= 0; i-- {
+		s := sessions[i]
+		resp = append(resp, &types.UserSessionResponse{
+			ID:         s.ID,
+			Device:     s.UserAgent,
+			IPAddress:  s.IPAddress,
+			AAL:        s.AAL,
+			Current:    s.KratosSessionID == currentID,
+			CreatedAt:  s.CreatedAt,
+			LastSeenAt: s.LastSeenAt,
+			ExpiresAt:  s.ExpiresAt,
+		})
+	}
+	return resp, nil
+}
+
+// RevokeSession signs out one of the user's sessions
+func (u *userUseCase) RevokeSession(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+	sessionID string,
+) *domainerrors.DomainError {
+	if _, err := uuid.Parse(sessionID); err != nil {
+		return domainerrors.NewNotFoundError("MSG_SESSION_NOT_FOUND", "Session")
+	}
+	session, err := u.userSessionRepo.GetByID(ctx, tenantID.String(), sessionID)
+	if err != nil {
+		return domainerrors.WrapInternal(err, "MSG_GET_SESSION_FAILED", "Failed to get session")
+	}
+	if session == nil || session.GlobalUserID != globalUserID || session.RevokedAt != nil {
+		return domainerrors.NewNotFoundError("MSG_SESSION_NOT_FOUND", "Session")
+	}
+
+	if err := u.revokeSession(ctx, tenantID, session.KratosSessionID); err != nil {
+		logger.GetLogger().Errorf("Failed to revoke session %s: %v", session.KratosSessionID, err)
+		return domainerrors.WrapInternal(err, "MSG_REVOKE_SESSION_FAILED", "Failed to revoke session")
+	}
+	return nil
+}
+
+// RevokeOtherSessions signs out every session of the user except the one making the request
+func (u *userUseCase) RevokeOtherSessions(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+) (*types.SessionsRevokedResponse, *domainerrors.DomainError) {
+	currentID, derr := u.currentSessionID(ctx, tenantID)
+	if derr != nil {
+		return nil, derr
+	}
+
+	sessions, err := u.userSessionRepo.ListActive(ctx, tenantID.String(), globalUserID, time.Now())
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_LIST_SESSIONS_FAILED", "Failed to list sessions")
+	}
+	revoked := 0
+	for _, s := range sessions {
+		if s.KratosSessionID == currentID {
+			continue
+		}
+		if err := u.revokeSession(ctx, tenantID, s.KratosSessionID); err != nil {
+			logger.GetLogger().Errorf("Failed to revoke session %s: %v", s.KratosSessionID, err)
+			return nil, domainerrors.WrapInternal(err, "MSG_REVOKE_SESSION_FAILED", "Failed to revoke session")
+		}
+		revoked++
+	}
+	return &types.SessionsRevokedResponse{Revoked: revoked}, nil
+}
+
+// currentSessionID resolves the Kratos session of the token the request was authenticated with
+func (u *userUseCase) currentSessionID(ctx context.Context, tenantID uuid.UUID) (string, *domainerrors.DomainError) {
+	sessionToken, derr := extractSessionToken(ctx)
+	if derr != nil {
+		return "", derr
+	}
+	session, err := u.kratosService.GetSession(ctx, tenantID, sessionToken)
+	if err != nil || session == nil {
+		return "", domainerrors.NewUnauthorizedError("MSG_INVALID_SESSION", "Invalid session").WithCause(err)
+	}
+	return session.Id, nil
+}
+
+// IntrospectToken reports whether a session token is active and whose it is, for services that
+// received it from a user (RFC 7662). Any token Kratos does not accept is reported inactive.
+func (u *userUseCase) IntrospectToken(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	token string,
+) (*types.IntrospectionResponse, *domainerrors.DomainError) {
+	inactive := &types.IntrospectionResponse{Active: false}
+	if token == "" {
+		return inactive, nil
+	}
+
+	if resp := u.sessionCache.getIntrospection(tenantID, token); resp != nil {
+		return resp, nil
+	}
+	validatedAt := time.Now()
+
+	session, err := u.kratosService.WhoAmI(ctx, tenantID, token)
+	if err != nil || session == nil || session.Identity == nil || (session.Active != nil && !*session.Active) {
+		if err != nil {
+			logger.GetLogger().Debugf("Introspected token is not active: %v", err)
+		}
+		return inactive, nil
+	}
+
+	identities, err := u.userIdentityRepo.ListByTenantAndKratosUserID(ctx, nil, tenantID.String(), session.Identity.Id)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_IDENTIFIERS_FAILED", "Failed to fetch user identifiers")
+	}
+	if len(identities) == 0 {
+		return inactive, nil
+	}
+
+	// A session held back to enrollment by the tenant's MFA policy is of no use to other services
+	pending, derr := u.mfaEnrollmentPending(ctx, tenantID, identities[0].GlobalUserID)
+	if derr != nil {
+		return nil, derr
+	}
+	if pending {
+		return inactive, nil
+	}
+
+	// Nor is the session of a suspended or banned user. Only active users are cached; a status
+	// change invalidates the user's cached results.
+	status, err := u.userAccountStatusRepo.Get(ctx, tenantID.String(), identities[0].GlobalUserID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_USER_STATUS_FAILED", "Failed to get user status")
+	}
+	if accountStatusError(status, time.Now()) != nil {
+		return inactive, nil
+	}
+
+	traits, _ := safeExtractTraits(session.Identity.Traits)
+	email, phone := identifiersFromIdentities(identities)
+	resp := &types.IntrospectionResponse{
+		Active:       true,
+		Subject:      identities[0].GlobalUserID,
+		Tenant:       extractStringFromTraits(traits, constants.IdentifierTenant.String(), ""),
+		TenantID:     tenantID.String(),
+		KratosUserID: session.Identity.Id,
+		SessionID:    session.Id,
+		Email:        email,
+		Phone:        phone,
+	}
+	if session.ExpiresAt != nil {
+		resp.ExpiresAt = session.ExpiresAt.Unix()
+	}
+	if session.IssuedAt != nil {
+		resp.IssuedAt = session.IssuedAt.Unix()
+	}
+	if session.AuthenticatorAssuranceLevel != nil {
+		resp.AAL = string(*session.AuthenticatorAssuranceLevel)
+	}
+
+	u.sessionCache.putIntrospection(tenantID, token, resp, session.Id, validatedAt)
+	return resp, nil
+}
+
+// SessionCacheStats reports the session validation cache counters of this instance
+func (u *userUseCase) SessionCacheStats() *types.SessionCacheStats {
+	return u.sessionCache.stats()
+}
@@ -0,0 +1,571 @@
+package ucases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	client "github.com/ory/kratos-client-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	"github.com/lifenetwork-ai/iam-service/internal/delivery/dto"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	mock_repositories "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/repositories"
+	mock_services "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/services"
+	mock_rate_limiter "github.com/lifenetwork-ai/iam-service/mocks/infrastructures/rate_limiter/types"
+	"github.com/lifenetwork-ai/iam-service/packages/utils"
+)
+
+func newRefreshRecord(tenantID uuid.UUID, sessionID string) *domain.SessionRefreshToken {
+	return &domain.SessionRefreshToken{
+		ID:              uuid.NewString(),
+		TenantID:        tenantID.String(),
+		FamilyID:        uuid.NewString(),
+		KratosSessionID: sessionID,
+		KratosUserID:    uuid.NewString(),
+		ChainStartedAt:  time.Now().Add(-time.Hour),
+		ExpiresAt:       time.Now().Add(time.Hour),
+	}
+}
+
+func TestRefreshToken_RotatesToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	sessionID := uuid.NewString()
+	record := newRefreshRecord(tenantID, sessionID)
+	session := &client.Session{Id: sessionID, Active: client.PtrBool(true), Identity: &client.Identity{Id: record.KratosUserID}}
+	farExpiry := time.Now().Add(48 * time.Hour)
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	tokenRepo := mock_repositories.NewMockSessionRefreshTokenRepository(ctrl)
+	tokenRepo.EXPECT().GetByTokenHash(ctx, utils.HashToken("refresh-1")).Return(record, nil)
+
+	// The token is only consumed once Kratos has extended the session
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().GetSession(ctx, tenantID, "session-token").Return(session, nil)
+	extend := kratos.EXPECT().ExtendSession(ctx, tenantID, sessionID).Return(&client.Session{Id: sessionID, ExpiresAt: &farExpiry}, nil)
+	var nextHash string
+	tokenRepo.EXPECT().Rotate(ctx, record.ID, gomock.Any(), gomock.Any()).After(extend).
+		DoAndReturn(func(_ context.Context, _ string, next *domain.SessionRefreshToken, _ time.Time) (bool, error) {
+			assert.Equal(t, record.FamilyID, next.FamilyID)
+			assert.Equal(t, record.ID, *next.ParentID)
+			assert.Equal(t, record.ExpiresAt, next.ExpiresAt)
+			nextHash = next.TokenHash
+			return true, nil
+		})
+
+	sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	sessionRepo.EXPECT().Extend(ctx, tenantID.String(), sessionID, record.ExpiresAt).Return(nil)
+
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	identityRepo.EXPECT().GetByTenantAndKratosUserID(ctx, nil, tenantID.String(), record.KratosUserID).Return(&domain.UserIdentity{GlobalUserID: "global-1"}, nil)
+
+	u := &userUseCase{
+		rateLimiter:             rateLimiter,
+		sessionRefreshTokenRepo: tokenRepo,
+		userSessionRepo:         sessionRepo,
+		userIdentityRepo:        identityRepo,
+		userAccountStatusRepo:   activeAccountStatusRepo(ctrl),
+		kratosService:           kratos,
+	}
+
+	resp, derr := u.RefreshToken(ctx, tenantID, "session-token", "refresh-1")
+	require.Nil(t, derr)
+	assert.NotEmpty(t, resp.RefreshToken)
+	assert.NotEqual(t, "refresh-1", resp.RefreshToken)
+	assert.Equal(t, utils.HashToken(resp.RefreshToken), nextHash)
+	assert.Equal(t, "global-1", resp.User.GlobalUserID)
+	// Expiry is capped by the chain's maximum lifetime
+	assert.True(t, resp.ExpiresAt.Equal(record.ExpiresAt))
+}
+
+func TestRefreshToken_RefusesSuspendedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	sessionID := uuid.NewString()
+	record := newRefreshRecord(tenantID, sessionID)
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	// Rotate is not expected: the refresh token is left as it was
+	tokenRepo := mock_repositories.NewMockSessionRefreshTokenRepository(ctrl)
+	tokenRepo.EXPECT().GetByTokenHash(ctx, utils.HashToken("refresh-1")).Return(record, nil)
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().GetSession(ctx, tenantID, "session-token").
+		Return(&client.Session{Id: sessionID, Identity: &client.Identity{Id: record.KratosUserID}}, nil)
+
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	identityRepo.EXPECT().GetByTenantAndKratosUserID(ctx, nil, tenantID.String(), record.KratosUserID).
+		Return(&domain.UserIdentity{GlobalUserID: "global-1"}, nil)
+
+	statusRepo := mock_repositories.NewMockUserAccountStatusRepository(ctrl)
+	statusRepo.EXPECT().Get(ctx, tenantID.String(), "global-1").
+		Return(&domain.UserAccountStatus{Status: constants.UserStatusSuspended}, nil)
+
+	u := &userUseCase{
+		rateLimiter:             rateLimiter,
+		sessionRefreshTokenRepo: tokenRepo,
+		userIdentityRepo:        identityRepo,
+		userAccountStatusRepo:   statusRepo,
+		kratosService:           kratos,
+	}
+
+	resp, derr := u.RefreshToken(ctx, tenantID, "session-token", "refresh-1")
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_ACCOUNT_SUSPENDED", derr.Code)
+}
+
+func TestRefreshToken_FailedExtensionKeepsToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	sessionID := uuid.NewString()
+	record := newRefreshRecord(tenantID, sessionID)
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	// Rotate is not expected: the client can retry with the same refresh token
+	tokenRepo := mock_repositories.NewMockSessionRefreshTokenRepository(ctrl)
+	tokenRepo.EXPECT().GetByTokenHash(ctx, utils.HashToken("refresh-1")).Return(record, nil)
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().GetSession(ctx, tenantID, "session-token").
+		Return(&client.Session{Id: sessionID, Identity: &client.Identity{Id: record.KratosUserID}}, nil)
+	kratos.EXPECT().ExtendSession(ctx, tenantID, sessionID).Return(nil, errors.New("kratos unavailable"))
+
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	identityRepo.EXPECT().GetByTenantAndKratosUserID(ctx, nil, tenantID.String(), record.KratosUserID).
+		Return(&domain.UserIdentity{GlobalUserID: "global-1"}, nil)
+
+	u := &userUseCase{
+		rateLimiter:             rateLimiter,
+		sessionRefreshTokenRepo: tokenRepo,
+		userIdentityRepo:        identityRepo,
+		userAccountStatusRepo:   activeAccountStatusRepo(ctrl),
+		kratosService:           kratos,
+	}
+
+	resp, derr := u.RefreshToken(ctx, tenantID, "session-token", "refresh-1")
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_EXTEND_SESSION_FAILED", derr.Code)
+}
+
+func TestRefreshToken_UnknownSessionRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	record := newRefreshRecord(tenantID, uuid.NewString())
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	tokenRepo := mock_repositories.NewMockSessionRefreshTokenRepository(ctrl)
+	tokenRepo.EXPECT().GetByTokenHash(ctx, utils.HashToken("refresh-1")).Return(record, nil)
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().GetSession(ctx, tenantID, "session-token").Return(nil, nil)
+
+	u := &userUseCase{
+		rateLimiter:             rateLimiter,
+		sessionRefreshTokenRepo: tokenRepo,
+		kratosService:           kratos,
+	}
+
+	resp, derr := u.RefreshToken(ctx, tenantID, "session-token", "refresh-1")
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_INVALID_SESSION", derr.Code)
+}
+
+func TestRefreshToken_ReplayRevokesChain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	record := newRefreshRecord(tenantID, uuid.NewString())
+	rotatedAt := time.Now().Add(-time.Minute)
+	record.RotatedAt = &rotatedAt
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	tokenRepo := mock_repositories.NewMockSessionRefreshTokenRepository(ctrl)
+	tokenRepo.EXPECT().GetByTokenHash(ctx, gomock.Any()).Return(record, nil)
+	tokenRepo.EXPECT().RevokeFamily(ctx, record.FamilyID, gomock.Any()).Return(nil)
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().DisableSessionAdmin(ctx, tenantID, record.KratosSessionID).Return(nil)
+
+	sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	sessionRepo.EXPECT().Revoke(ctx, tenantID.String(), record.KratosSessionID, gomock.Any()).Return(nil)
+
+	u := &userUseCase{
+		rateLimiter:             rateLimiter,
+		sessionRefreshTokenRepo: tokenRepo,
+		userSessionRepo:         sessionRepo,
+		kratosService:           kratos,
+	}
+
+	resp, derr := u.RefreshToken(ctx, tenantID, "session-token", "refresh-old")
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_REFRESH_TOKEN_REUSED", derr.Code)
+}
+
+func TestRefreshToken_MaxLifetimeExceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	record := newRefreshRecord(tenantID, uuid.NewString())
+	record.ExpiresAt = time.Now().Add(-time.Second)
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	tokenRepo := mock_repositories.NewMockSessionRefreshTokenRepository(ctrl)
+	tokenRepo.EXPECT().GetByTokenHash(ctx, gomock.Any()).Return(record, nil)
+	tokenRepo.EXPECT().RevokeFamily(ctx, record.FamilyID, gomock.Any()).Return(nil)
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().DisableSessionAdmin(ctx, tenantID, record.KratosSessionID).Return(nil)
+
+	sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	sessionRepo.EXPECT().Revoke(ctx, tenantID.String(), record.KratosSessionID, gomock.Any()).Return(nil)
+
+	u := &userUseCase{
+		rateLimiter:             rateLimiter,
+		sessionRefreshTokenRepo: tokenRepo,
+		userSessionRepo:         sessionRepo,
+		kratosService:           kratos,
+	}
+
+	_, derr := u.RefreshToken(ctx, tenantID, "session-token", "refresh-1")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_SESSION_MAX_LIFETIME_EXCEEDED", derr.Code)
+}
+
+func TestRefreshToken_OtherTenantRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	record := newRefreshRecord(uuid.New(), uuid.NewString())
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	tokenRepo := mock_repositories.NewMockSessionRefreshTokenRepository(ctrl)
+	tokenRepo.EXPECT().GetByTokenHash(ctx, gomock.Any()).Return(record, nil)
+
+	u := &userUseCase{
+		rateLimiter:             rateLimiter,
+		sessionRefreshTokenRepo: tokenRepo,
+	}
+
+	_, derr := u.RefreshToken(ctx, uuid.New(), "session-token", "refresh-1")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_INVALID_REFRESH_TOKEN", derr.Code)
+}
+
+func TestStartSession_EvictsOldestBeyondLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := uuid.New()
+	ctx := context.WithValue(context.Background(), constants.ClientIPKey, "203.0.113.7")
+	ctx = context.WithValue(ctx, constants.UserAgentKey, "Mozilla/5.0")
+
+	setting := domain.DefaultTenantSetting(tenantID)
+	setting.MaxConcurrentSessions = 2
+	sessions := []*domain.UserSession{
+		{KratosSessionID: "oldest"},
+		{KratosSessionID: "older"},
+		{KratosSessionID: "session-new"},
+	}
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(setting, nil)
+
+	tokenRepo := mock_repositories.NewMockSessionRefreshTokenRepository(ctrl)
+	tokenRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+	tokenRepo.EXPECT().RevokeBySession(ctx, tenantID.String(), "oldest", gomock.Any()).Return(nil)
+
+	sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	sessionRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, s *domain.UserSession) error {
+		assert.Equal(t, "global-1", s.GlobalUserID)
+		assert.Equal(t, "session-new", s.KratosSessionID)
+		assert.Equal(t, "203.0.113.7", s.IPAddress)
+		assert.Equal(t, "Mozilla/5.0", s.UserAgent)
+		return nil
+	})
+	sessionRepo.EXPECT().ListActive(ctx, tenantID.String(), "global-1", gomock.Any()).Return(sessions, nil)
+	sessionRepo.EXPECT().Revoke(ctx, tenantID.String(), "oldest", gomock.Any()).Return(nil)
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().DisableSessionAdmin(ctx, tenantID, "oldest").Return(nil)
+
+	u := &userUseCase{
+		tenantSettingRepo:       settingRepo,
+		sessionRefreshTokenRepo: tokenRepo,
+		userSessionRepo:         sessionRepo,
+		kratosService:           kratos,
+	}
+
+	resp := &types.IdentityUserAuthResponse{
+		SessionID: "session-new",
+		User:      &types.IdentityUserResponse{ID: "kratos-1", GlobalUserID: "global-1"},
+	}
+	u.startSession(ctx, tenantID, resp, false)
+	assert.NotEmpty(t, resp.RefreshToken)
+}
+
+func TestRevokeSession_OtherUsersSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	sessionID := uuid.NewString()
+
+	// Sessions of other users are reported missing rather than revoked
+	sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	sessionRepo.EXPECT().GetByID(ctx, tenantID.String(), sessionID).
+		Return(&domain.UserSession{ID: sessionID, GlobalUserID: "global-2", KratosSessionID: "kratos-session"}, nil)
+
+	u := &userUseCase{
+		userSessionRepo: sessionRepo,
+		kratosService:   mock_services.NewMockKratosService(ctrl),
+	}
+
+	derr := u.RevokeSession(ctx, tenantID, "global-1", sessionID)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_SESSION_NOT_FOUND", derr.Code)
+}
+
+func TestRevokeOtherSessions_KeepsCurrent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.WithValue(context.Background(), constants.SessionTokenKey, "session-token")
+	tenantID := uuid.New()
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().GetSession(ctx, tenantID, "session-token").Return(&client.Session{Id: "current"}, nil)
+	kratos.EXPECT().DisableSessionAdmin(ctx, tenantID, "other").Return(nil)
+
+	sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	sessionRepo.EXPECT().ListActive(ctx, tenantID.String(), "global-1", gomock.Any()).Return([]*domain.UserSession{
+		{KratosSessionID: "other"},
+		{KratosSessionID: "current"},
+	}, nil)
+	sessionRepo.EXPECT().Revoke(ctx, tenantID.String(), "other", gomock.Any()).Return(nil)
+
+	tokenRepo := mock_repositories.NewMockSessionRefreshTokenRepository(ctrl)
+	tokenRepo.EXPECT().RevokeBySession(ctx, tenantID.String(), "other", gomock.Any()).Return(nil)
+
+	u := &userUseCase{
+		sessionRefreshTokenRepo: tokenRepo,
+		userSessionRepo:         sessionRepo,
+		kratosService:           kratos,
+	}
+
+	resp, derr := u.RevokeOtherSessions(ctx, tenantID, "global-1")
+	require.Nil(t, derr)
+	assert.Equal(t, 1, resp.Revoked)
+}
+
+func TestIntrospectToken_CachedUntilRevoked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	issuedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	aal := client.AUTHENTICATORASSURANCELEVEL_AAL1
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().WhoAmI(ctx, tenantID, "session-token").Return(&client.Session{
+		Id:                          "session-1",
+		Active:                      client.PtrBool(true),
+		ExpiresAt:                   &expiresAt,
+		IssuedAt:                    &issuedAt,
+		AuthenticatorAssuranceLevel: &aal,
+		Identity:                    &client.Identity{Id: "kratos-1", Traits: map[string]interface{}{"tenant": "genetica"}},
+	}, nil)
+
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	identityRepo.EXPECT().ListByTenantAndKratosUserID(ctx, nil, tenantID.String(), "kratos-1").Return([]*domain.UserIdentity{
+		{GlobalUserID: "global-1", Type: constants.IdentifierPhone.String(), Value: "+84987654321"},
+		{GlobalUserID: "global-1", Type: constants.IdentifierEmail.String(), Value: "alice@example.com"},
+	}, nil)
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(nil, nil)
+
+	u := &userUseCase{
+		tenantSettingRepo:     settingRepo,
+		userIdentityRepo:      identityRepo,
+		userAccountStatusRepo: activeAccountStatusRepo(ctrl),
+		kratosService:         kratos,
+		sessionCache:          newTestSessionCache(time.Minute),
+	}
+
+	want := &types.IntrospectionResponse{
+		Active:       true,
+		Subject:      "global-1",
+		Tenant:       "genetica",
+		TenantID:     tenantID.String(),
+		ExpiresAt:    expiresAt.Unix(),
+		IssuedAt:     issuedAt.Unix(),
+		KratosUserID: "kratos-1",
+		SessionID:    "session-1",
+		AAL:          constants.AAL1,
+		Email:        "alice@example.com",
+		Phone:        "+84987654321",
+	}
+	for i := 0; i < 2; i++ {
+		resp, derr := u.IntrospectToken(ctx, tenantID, "session-token")
+		require.Nil(t, derr)
+		assert.Equal(t, want, resp)
+	}
+
+	// Revoking the session must not leave it active in the cache
+	u.sessionCache.invalidateSession("session-1")
+	kratos.EXPECT().WhoAmI(ctx, tenantID, "session-token").Return(nil, assert.AnError)
+
+	resp, derr := u.IntrospectToken(ctx, tenantID, "session-token")
+	require.Nil(t, derr)
+	assert.Equal(t, &types.IntrospectionResponse{Active: false}, resp)
+}
+
+func TestIntrospectToken_BanDropsCachedResult(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	globalUserID := uuid.NewString()
+	expiresAt := time.Now().Add(time.Hour)
+	session := &client.Session{
+		Id:        "session-1",
+		Active:    client.PtrBool(true),
+		ExpiresAt: &expiresAt,
+		Identity:  &client.Identity{Id: "kratos-1"},
+	}
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().WhoAmI(ctx, tenantID, "session-token").Return(session, nil).Times(2)
+
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	identityRepo.EXPECT().ListByTenantAndKratosUserID(ctx, nil, tenantID.String(), "kratos-1").
+		Return([]*domain.UserIdentity{{GlobalUserID: globalUserID}}, nil).Times(2)
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(nil, nil).Times(2)
+
+	banned := &domain.UserAccountStatus{Status: constants.UserStatusBanned}
+	statusRepo := mock_repositories.NewMockUserAccountStatusRepository(ctrl)
+	gomock.InOrder(
+		statusRepo.EXPECT().Get(ctx, tenantID.String(), globalUserID).Return(nil, nil),
+		statusRepo.EXPECT().Get(ctx, tenantID.String(), globalUserID).Return(banned, nil),
+	)
+
+	// The session is still being signed out, yet the cached result is already dropped
+	sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	sessionRepo.EXPECT().ListActive(ctx, tenantID.String(), globalUserID, gomock.Any()).Return(nil, assert.AnError)
+
+	identityRepo.EXPECT().GetByGlobalUserIDAndTenantID(ctx, nil, globalUserID, tenantID.String()).
+		Return([]*domain.UserIdentity{{GlobalUserID: globalUserID}}, nil)
+	statusRepo.EXPECT().Upsert(ctx, gomock.Any()).Return(nil)
+
+	cache := newTestSessionCache(time.Minute)
+	u := &userUseCase{
+		tenantSettingRepo:     settingRepo,
+		userIdentityRepo:      identityRepo,
+		userAccountStatusRepo: statusRepo,
+		kratosService:         kratos,
+		sessionCache:          cache,
+	}
+	status := &userStatusUseCase{
+		userAccountStatusRepo: statusRepo,
+		userIdentityRepo:      identityRepo,
+		userSessionRepo:       sessionRepo,
+		kratosService:         kratos,
+		sessionCache:          cache,
+	}
+
+	resp, derr := u.IntrospectToken(ctx, tenantID, "session-token")
+	require.Nil(t, derr)
+	require.True(t, resp.Active)
+
+	_, derr = status.UpdateUserStatus(ctx, tenantID, globalUserID, dto.UpdateUserStatusPayloadDTO{
+		Status: constants.UserStatusBanned,
+		Reason: "fraud",
+	}, "support-admin")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_REVOKE_SESSION_FAILED", derr.Code)
+
+	resp, derr = u.IntrospectToken(ctx, tenantID, "session-token")
+	require.Nil(t, derr)
+	assert.Equal(t, &types.IntrospectionResponse{Active: false}, resp)
+}
+
+func TestIntrospectToken_InactiveNotCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().WhoAmI(ctx, tenantID, "session-token").Return(nil, assert.AnError).Times(2)
+
+	u := &userUseCase{
+		kratosService: kratos,
+		sessionCache:  newTestSessionCache(time.Minute),
+	}
+
+	for i := 0; i < 2; i++ {
+		resp, derr := u.IntrospectToken(ctx, tenantID, "session-token")
+		require.Nil(t, derr)
+		assert.False(t, resp.Active)
+	}
+
+	resp, derr := u.IntrospectToken(ctx, tenantID, "")
+	require.Nil(t, derr)
+	assert.False(t, resp.Active)
+}
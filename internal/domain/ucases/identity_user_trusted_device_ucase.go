@@ -0,0 +1,117 @@
+package ucases
+
+import (
+	"context"
+	"crypto/hmac"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+	"github.com/lifenetwork-ai/iam-service/packages/utils"
+)
+
+// recognizeDevice returns the trusted device the client signs in from, or nil when the client sent
+// no device token or one that is not trusted for this user. A device that is not recognized only
+// loses its privileges, so lookup failures are logged rather than returned.
+func (u *userUseCase) recognizeDevice(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+	now time.Time,
+) *domain.TrustedDevice {
+	token := deviceToken(ctx)
+	secret := conf.GetTrustedDeviceTokenSecret()
+	if token == "" || secret == "" || globalUserID == "" {
+		return nil
+	}
+
+	deviceID, nonce, signature, ok := splitDeviceToken(token)
+	if !ok {
+		return nil
+	}
+	expected := deviceTokenSignature(secret, tenantID, globalUserID, deviceID, nonce)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return nil
+	}
+
+	device, err := u.trustedDeviceRepo.GetByID(ctx, tenantID.String(), deviceID)
+	if err != nil {
+		logger.GetLogger().Errorf("Failed to get trusted device %s: %v", deviceID, err)
+		return nil
+	}
+	if device == nil || device.GlobalUserID != globalUserID || !device.IsTrusted(now) ||
+		!hmac.Equal([]byte(device.TokenHash), []byte(utils.HashToken(token))) {
+		return nil
+	}
+
+	if err := u.trustedDeviceRepo.Touch(ctx, device.ID, now, constants.TrustedDeviceActivityInterval); err != nil {
+		logger.GetLogger().Errorf("Failed to record use of trusted device %s: %v", device.ID, err)
+	}
+	return device
+}
+
+// skipsSecondFactor reports whether the tenant lets the device the user signs in from skip the second factor
+func (u *userUseCase) skipsSecondFactor(ctx context.Context, tenantID uuid.UUID, globalUserID string) bool {
+	if deviceToken(ctx) == "" {
+		return false
+	}
+	setting, derr := getTenantSetting(ctx, u.tenantSettingRepo, tenantID)
+	if derr != nil {
+		logger.GetLogger().Errorf("Failed to get tenant settings for trusted devices: %v", derr)
+		return false
+	}
+	return setting.TrustedDeviceSkipMFA && u.recognizeDevice(ctx, tenantID, globalUserID, time.Now()) != nil
+}
+
+// rememberDevice trusts the client the user signed in from and returns the token it must present
+// on later sign-ins. It returns an empty token when trusted devices are disabled, for the tenant
+// or for the whole service. Beyond constants.MaxTrustedDevicesPerUser the oldest device is forgotten.
+func (u *userUseCase) rememberDevice(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+	setting *domain.TenantSetting,
+	now time.Time,
+) (string, error) {
+	secret := conf.GetTrustedDeviceTokenSecret()
+	lifetime := setting.TrustedDeviceLifetime()
+	if secret == "" || lifetime == 0 || globalUserID == "" {
+		return "", nil
+	}
+
+	nonce, err := utils.RandomToken(constants.TrustedDeviceNonceBytes)
+	if err != nil {
+		return "", err
+	}
+	deviceID := uuid.NewString()
+	token := newDeviceToken(secret, tenantID, globalUserID, deviceID, nonce)
+	ip, userAgent := clientInfo(ctx)
+	if err := u.trustedDeviceRepo.Create(ctx, &domain.TrustedDevice{
+		ID:           deviceID,
+		TenantID:     tenantID.String(),
+		GlobalUserID: globalUserID,
+		TokenHash:    utils.HashToken(token),
+		IPAddress:    ip,
+		UserAgent:    userAgent,
+		LastUsedAt:   now,
+		ExpiresAt:    now.Add(lifetime),
+	}); err != nil {
+		return "", err
+	}
+
+	devices, err := u.trustedDeviceRepo.ListTrusted(ctx, tenantID.String(), globalUserID, now)
+	if err != nil {
+		logger.GetLogger().Errorf("Failed to list trusted devices for device limit: %v", err)
+		return token, nil
+	}
+	for i := constants.MaxTrustedDevicesPerUser; i < len(devices); i++ {
+		if _, err := u.trustedDeviceRepo.Revoke(ctx, tenantID.String(), globalUserID, devices[i].ID, now); err != nil {
+			logger.GetLogger().Errorf("Failed to forget trusted device %s: %v", devices[i].ID, err)
+		}
+	}
+	return token, nil
+}
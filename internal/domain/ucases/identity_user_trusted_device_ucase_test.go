@@ -0,0 +1,274 @@
+package ucases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	mock_repositories "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/repositories"
+	mock_rate_limiter "github.com/lifenetwork-ai/iam-service/mocks/infrastructures/rate_limiter/types"
+	"github.com/lifenetwork-ai/iam-service/packages/totp"
+	"github.com/lifenetwork-ai/iam-service/packages/utils"
+)
+
+const testDeviceSecret = "device-secret"
+
+func useTestDeviceSecret(t *testing.T) {
+	previousSecret := conf.GetConfiguration().TrustedDevice.TokenSecret
+	conf.GetConfiguration().TrustedDevice.TokenSecret = testDeviceSecret
+	t.Cleanup(func() { conf.GetConfiguration().TrustedDevice.TokenSecret = previousSecret })
+}
+
+func trustedDeviceSetting(tenantID uuid.UUID) *domain.TenantSetting {
+	setting := domain.DefaultTenantSetting(tenantID)
+	setting.TrustedDeviceLifetimeSeconds = int(30 * 24 * time.Hour / time.Second)
+	setting.TrustedDeviceSkipMFA = true
+	setting.TrustedDeviceSessionMaxLifetimeSeconds = int(90 * 24 * time.Hour / time.Second)
+	return setting
+}
+
+func TestCompleteSignIn_RemembersDeviceWithLongerSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.WithValue(context.Background(), constants.UserAgentKey, "Mozilla/5.0")
+	tenantID := uuid.New()
+	useTestDeviceSecret(t)
+
+	mfaRepo := mock_repositories.NewMockUserMFARepository(ctrl)
+	mfaRepo.EXPECT().GetFactor(ctx, tenantID.String(), "global-1", constants.MFAFactorTOTP).Return(nil, nil)
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(trustedDeviceSetting(tenantID), nil).Times(2)
+
+	var device *domain.TrustedDevice
+	deviceRepo := mock_repositories.NewMockTrustedDeviceRepository(ctrl)
+	deviceRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, created *domain.TrustedDevice) error {
+		device = created
+		return nil
+	})
+	deviceRepo.EXPECT().ListTrusted(ctx, tenantID.String(), "global-1", gomock.Any()).Return(nil, nil)
+
+	tokenRepo := mock_repositories.NewMockSessionRefreshTokenRepository(ctrl)
+	tokenRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, record *domain.SessionRefreshToken) error {
+		assert.WithinDuration(t, time.Now().Add(90*24*time.Hour), record.ExpiresAt, time.Minute)
+		return nil
+	})
+
+	sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	sessionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	u := &userUseCase{
+		userAccountStatusRepo:   activeAccountStatusRepo(ctrl),
+		userMFARepo:             mfaRepo,
+		tenantSettingRepo:       settingRepo,
+		trustedDeviceRepo:       deviceRepo,
+		sessionRefreshTokenRepo: tokenRepo,
+		userSessionRepo:         sessionRepo,
+	}
+
+	resp, derr := u.completeSignIn(ctx, tenantID, firstFactorResponse(), signInOptions{rememberDevice: true})
+	require.Nil(t, derr)
+	require.NotEmpty(t, resp.DeviceToken)
+	require.NotNil(t, device)
+	assert.Equal(t, "global-1", device.GlobalUserID)
+	assert.Equal(t, "Mozilla/5.0", device.UserAgent)
+	assert.Equal(t, utils.HashToken(resp.DeviceToken), device.TokenHash)
+	assert.WithinDuration(t, time.Now().Add(30*24*time.Hour), device.ExpiresAt, time.Minute)
+
+	deviceID, _, _, ok := splitDeviceToken(resp.DeviceToken)
+	require.True(t, ok)
+	assert.Equal(t, device.ID, deviceID)
+}
+
+func TestCompleteSignIn_TrustedDeviceSkipsSecondFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := uuid.New()
+	deviceID := uuid.NewString()
+	token := newDeviceToken(testDeviceSecret, tenantID, "global-1", deviceID, "nonce")
+	ctx := context.WithValue(context.Background(), constants.DeviceTokenKey, token)
+	useTestDeviceSecret(t)
+
+	device := &domain.TrustedDevice{
+		ID:           deviceID,
+		TenantID:     tenantID.String(),
+		GlobalUserID: "global-1",
+		TokenHash:    utils.HashToken(token),
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}
+
+	mfaRepo := mock_repositories.NewMockUserMFARepository(ctrl)
+	mfaRepo.EXPECT().GetFactor(ctx, tenantID.String(), "global-1", constants.MFAFactorTOTP).
+		Return(enabledTOTPFactor(t, tenantID, "JBSWY3DPEHPK3PXP"), nil)
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(trustedDeviceSetting(tenantID), nil).Times(3)
+
+	deviceRepo := mock_repositories.NewMockTrustedDeviceRepository(ctrl)
+	deviceRepo.EXPECT().GetByID(ctx, tenantID.String(), deviceID).Return(device, nil).Times(2)
+	deviceRepo.EXPECT().Touch(ctx, deviceID, gomock.Any(), constants.TrustedDeviceActivityInterval).Return(nil).Times(2)
+
+	tokenRepo := mock_repositories.NewMockSessionRefreshTokenRepository(ctrl)
+	tokenRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	sessionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	u := &userUseCase{
+		userAccountStatusRepo:   activeAccountStatusRepo(ctrl),
+		userMFARepo:             mfaRepo,
+		tenantSettingRepo:       settingRepo,
+		trustedDeviceRepo:       deviceRepo,
+		sessionRefreshTokenRepo: tokenRepo,
+		userSessionRepo:         sessionRepo,
+	}
+
+	// Asking to remember a device that is already trusted does not issue another token
+	resp, derr := u.completeSignIn(ctx, tenantID, firstFactorResponse(), signInOptions{rememberDevice: true})
+	require.Nil(t, derr)
+	assert.False(t, resp.MFARequired)
+	assert.False(t, resp.MFAEnrollmentRequired)
+	assert.Equal(t, "token-1", resp.SessionToken)
+	assert.Empty(t, resp.DeviceToken)
+}
+
+func TestCompleteSignIn_IgnoresDeviceTokenOfAnotherUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := uuid.New()
+	token := newDeviceToken(testDeviceSecret, tenantID, "global-2", uuid.NewString(), "nonce")
+	ctx := context.WithValue(context.Background(), constants.DeviceTokenKey, token)
+	useTestDeviceSecret(t)
+
+	mfaRepo := mock_repositories.NewMockUserMFARepository(ctrl)
+	mfaRepo.EXPECT().GetFactor(ctx, tenantID.String(), "global-1", constants.MFAFactorTOTP).
+		Return(enabledTOTPFactor(t, tenantID, "JBSWY3DPEHPK3PXP"), nil)
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(trustedDeviceSetting(tenantID), nil)
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().SaveChallenge(ctx, gomock.Any(), gomock.Any(), constants.MFAChallengeDuration).
+		DoAndReturn(func(_ context.Context, _ string, c *domain.ChallengeSession, _ time.Duration) error {
+			assert.True(t, c.RememberDevice)
+			return nil
+		})
+
+	// The token is not looked up: its signature binds it to another user
+	u := &userUseCase{
+		userAccountStatusRepo: activeAccountStatusRepo(ctrl),
+		userMFARepo:           mfaRepo,
+		tenantSettingRepo:     settingRepo,
+		challengeSessionRepo:  challengeRepo,
+		trustedDeviceRepo:     mock_repositories.NewMockTrustedDeviceRepository(ctrl),
+	}
+
+	resp, derr := u.completeSignIn(ctx, tenantID, firstFactorResponse(), signInOptions{rememberDevice: true})
+	require.Nil(t, derr)
+	assert.True(t, resp.MFARequired)
+	assert.Empty(t, resp.SessionToken)
+}
+
+func TestActivateTOTP_StopsTrustingRememberedDevices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	secret, err := totp.GenerateSecret(constants.TOTPSecretBytes)
+	require.NoError(t, err)
+	factor := enabledTOTPFactor(t, tenantID, secret)
+	factor.EnabledAt = nil
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	require.NoError(t, err)
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	mfaRepo := mock_repositories.NewMockUserMFARepository(ctrl)
+	mfaRepo.EXPECT().GetFactor(ctx, tenantID.String(), "global-1", constants.MFAFactorTOTP).Return(factor, nil)
+
+	// A device remembered before enrollment never passed TOTP, so it must not skip it afterwards
+	deviceRepo := mock_repositories.NewMockTrustedDeviceRepository(ctrl)
+	revoke := deviceRepo.EXPECT().RevokeAll(ctx, tenantID.String(), "global-1", gomock.Any()).Return(int64(2), nil)
+	mfaRepo.EXPECT().EnableFactor(ctx, factor, gomock.Any(), gomock.Any()).Return(nil).After(revoke)
+
+	u := &userUseCase{
+		rateLimiter:       rateLimiter,
+		userMFARepo:       mfaRepo,
+		trustedDeviceRepo: deviceRepo,
+	}
+
+	_, derr := u.ActivateTOTP(ctx, tenantID, "global-1", code)
+	require.Nil(t, derr)
+}
+
+func TestActivateTOTP_RevokingDevicesFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	secret, err := totp.GenerateSecret(constants.TOTPSecretBytes)
+	require.NoError(t, err)
+	factor := enabledTOTPFactor(t, tenantID, secret)
+	factor.EnabledAt = nil
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	require.NoError(t, err)
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	// EnableFactor is not expected: the factor stays pending until the devices are revoked
+	mfaRepo := mock_repositories.NewMockUserMFARepository(ctrl)
+	mfaRepo.EXPECT().GetFactor(ctx, tenantID.String(), "global-1", constants.MFAFactorTOTP).Return(factor, nil)
+
+	deviceRepo := mock_repositories.NewMockTrustedDeviceRepository(ctrl)
+	deviceRepo.EXPECT().RevokeAll(ctx, tenantID.String(), "global-1", gomock.Any()).Return(int64(0), assert.AnError)
+
+	u := &userUseCase{
+		rateLimiter:       rateLimiter,
+		userMFARepo:       mfaRepo,
+		trustedDeviceRepo: deviceRepo,
+	}
+
+	resp, derr := u.ActivateTOTP(ctx, tenantID, "global-1", code)
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_REVOKE_TRUSTED_DEVICE_FAILED", derr.Code)
+}
+
+func TestRevokeTrustedDevice_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	deviceRepo := mock_repositories.NewMockTrustedDeviceRepository(ctrl)
+	u := NewTrustedDeviceUseCase(deviceRepo)
+
+	derr := u.RevokeTrustedDevice(ctx, tenantID, "global-1", "not-a-device")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_TRUSTED_DEVICE_NOT_FOUND", derr.Code)
+
+	deviceID := uuid.NewString()
+	deviceRepo.EXPECT().Revoke(ctx, tenantID.String(), "global-1", deviceID, gomock.Any()).Return(false, nil)
+	derr = u.RevokeTrustedDevice(ctx, tenantID, "global-1", deviceID)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_TRUSTED_DEVICE_NOT_FOUND", derr.Code)
+}
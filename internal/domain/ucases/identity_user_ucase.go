@@ -2,6 +2,7 @@ package ucases
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -39,19 +40,57 @@ type userUseCase struct {
 	userIdentityRepo          domainrepo.UserIdentityRepository
 	userIdentifierMappingRepo domainrepo.UserIdentifierMappingRepository
 	challengeSessionRepo      domainrepo.ChallengeSessionRepository
+	tenantSettingRepo         domainrepo.TenantSettingRepository
+	sessionRefreshTokenRepo   domainrepo.SessionRefreshTokenRepository
+	userMFARepo               domainrepo.UserMFARepository
+	userPasskeyRepo           domainrepo.UserPasskeyRepository
+	userSessionRepo           domainrepo.UserSessionRepository
+	changeLogRepo             domainrepo.UserIdentityChangeLogRepository
+	userAccountStatusRepo     domainrepo.UserAccountStatusRepository
+	userInvitationRepo        domainrepo.UserInvitationRepository
+	identifierLockoutRepo     domainrepo.IdentifierLockoutRepository
+	trustedDeviceRepo         domainrepo.TrustedDeviceRepository
+	loginEventRepo            domainrepo.LoginEventRepository
+	identifierQuarantineRepo  domainrepo.IdentifierQuarantineRepository
 	kratosService             domainservice.KratosService
+	breachedPasswordChecker   domainservice.BreachedPasswordChecker
+	oidcVerifier              domainservice.OIDCTokenVerifier
+	passkeyService            domainservice.PasskeyService
+	geoIPLocator              domainservice.GeoIPLocator
+	courierUseCase            interfaces.CourierUseCase
+	securityNotifier          interfaces.SecurityNotificationUseCase
+	sessionCache              *SessionCache
 }
 
 func NewIdentityUserUseCase(
 	db *gorm.DB,
 	cacheRepo cachetypes.CacheRepository,
+	sessionCache *SessionCache,
 	rateLimiter ratelimiters.RateLimiter,
 	challengeSessionRepo domainrepo.ChallengeSessionRepository,
 	tenantRepo domainrepo.TenantRepository,
 	globalUserRepo domainrepo.GlobalUserRepository,
 	userIdentityRepo domainrepo.UserIdentityRepository,
 	userIdentifierMappingRepo domainrepo.UserIdentifierMappingRepository,
+	tenantSettingRepo domainrepo.TenantSettingRepository,
+	sessionRefreshTokenRepo domainrepo.SessionRefreshTokenRepository,
+	userMFARepo domainrepo.UserMFARepository,
+	userPasskeyRepo domainrepo.UserPasskeyRepository,
+	userSessionRepo domainrepo.UserSessionRepository,
+	changeLogRepo domainrepo.UserIdentityChangeLogRepository,
+	userAccountStatusRepo domainrepo.UserAccountStatusRepository,
+	userInvitationRepo domainrepo.UserInvitationRepository,
+	identifierLockoutRepo domainrepo.IdentifierLockoutRepository,
+	trustedDeviceRepo domainrepo.TrustedDeviceRepository,
+	loginEventRepo domainrepo.LoginEventRepository,
+	identifierQuarantineRepo domainrepo.IdentifierQuarantineRepository,
 	kratosService domainservice.KratosService,
+	breachedPasswordChecker domainservice.BreachedPasswordChecker,
+	oidcVerifier domainservice.OIDCTokenVerifier,
+	passkeyService domainservice.PasskeyService,
+	geoIPLocator domainservice.GeoIPLocator,
+	courierUseCase interfaces.CourierUseCase,
+	securityNotifier interfaces.SecurityNotificationUseCase,
 ) interfaces.IdentityUserUseCase {
 	return &userUseCase{
 		db:                        db,
@@ -62,7 +101,26 @@ func NewIdentityUserUseCase(
 		globalUserRepo:            globalUserRepo,
 		userIdentityRepo:          userIdentityRepo,
 		userIdentifierMappingRepo: userIdentifierMappingRepo,
+		tenantSettingRepo:         tenantSettingRepo,
+		sessionRefreshTokenRepo:   sessionRefreshTokenRepo,
+		userMFARepo:               userMFARepo,
+		userPasskeyRepo:           userPasskeyRepo,
+		userSessionRepo:           userSessionRepo,
+		changeLogRepo:             changeLogRepo,
+		userAccountStatusRepo:     userAccountStatusRepo,
+		userInvitationRepo:        userInvitationRepo,
+		identifierLockoutRepo:     identifierLockoutRepo,
+		trustedDeviceRepo:         trustedDeviceRepo,
+		loginEventRepo:            loginEventRepo,
+		identifierQuarantineRepo:  identifierQuarantineRepo,
 		kratosService:             kratosService,
+		breachedPasswordChecker:   breachedPasswordChecker,
+		oidcVerifier:              oidcVerifier,
+		passkeyService:            passkeyService,
+		geoIPLocator:              geoIPLocator,
+		courierUseCase:            courierUseCase,
+		securityNotifier:          securityNotifier,
+		sessionCache:              sessionCache,
 	}
 }
 
@@ -92,7 +150,8 @@ func (u *userUseCase) ChallengeWithPhone(
 	}
 
 	// Check if the identifier exists in the database
-	if _, err = u.userIdentityRepo.GetByTypeAndValue(ctx, nil, tenantID.String(), constants.IdentifierPhone.String(), phone); err != nil {
+	identity, err := u.userIdentityRepo.GetByTypeAndValue(ctx, nil, tenantID.String(), constants.IdentifierPhone.String(), phone)
+	if err != nil {
 		// Dev bypass logic
 		if conf.IsDevReviewerBypassEnabled() && phone == conf.DevReviewerIdentifier() {
 			// Create identity with traits
@@ -111,6 +170,11 @@ func (u *userUseCase) ChallengeWithPhone(
 				},
 			})
 		}
+	} else if derr := u.checkAccountStatus(ctx, tenantID, identity.GlobalUserID); derr != nil {
+		return nil, derr
+	}
+	if derr := u.checkIdentifierLockout(ctx, tenantID, phone); derr != nil {
+		return nil, derr
 	}
 
 	// Initialize login flow with Kratos
@@ -132,6 +196,7 @@ func (u *userUseCase) ChallengeWithPhone(
 	if err != nil {
 		return nil, domainerrors.WrapInternal(err, "MSG_SAVING_SESSION_FAILED", "Saving challenge session failed")
 	}
+	u.startResendCooldown(flow.Id, time.Now())
 
 	return &types.IdentityUserChallengeResponse{
 		FlowID:      flow.Id,
@@ -174,7 +239,8 @@ func (u *userUseCase) ChallengeWithEmail(
 	}
 
 	// Check if the identifier exists in the database
-	if _, err = u.userIdentityRepo.GetByTypeAndValue(ctx, nil, tenantID.String(), constants.IdentifierEmail.String(), email); err != nil {
+	identity, err := u.userIdentityRepo.GetByTypeAndValue(ctx, nil, tenantID.String(), constants.IdentifierEmail.String(), email)
+	if err != nil {
 		return nil, domainerrors.NewNotFoundError("MSG_IDENTITY_NOT_FOUND", "Email not registered in the system").WithDetails([]interface{}{
 			map[string]string{
 				"field": "email",
@@ -182,6 +248,12 @@ func (u *userUseCase) ChallengeWithEmail(
 			},
 		})
 	}
+	if derr := u.checkAccountStatus(ctx, tenantID, identity.GlobalUserID); derr != nil {
+		return nil, derr
+	}
+	if derr := u.checkIdentifierLockout(ctx, tenantID, email); derr != nil {
+		return nil, derr
+	}
 
 	// Initialize login flow with Kratos
 	flow, err := u.kratosService.InitializeLoginFlow(ctx, tenantID)
@@ -202,6 +274,7 @@ func (u *userUseCase) ChallengeWithEmail(
 	if err != nil {
 		return nil, domainerrors.WrapInternal(err, "MSG_SAVING_SESSION_FAILED", "Saving challenge session failed")
 	}
+	u.startResendCooldown(flow.Id, time.Now())
 
 	// Return challenge session
 	return &types.IdentityUserChallengeResponse{
@@ -228,6 +301,12 @@ func (u *userUseCase) VerifyRegister(
 	if err != nil {
 		return nil, domainerrors.WrapInternal(err, "MSG_CHALLENGE_SESSION_NOT_FOUND", "Challenge session not found")
 	}
+	if sessionValue == nil {
+		return nil, domainerrors.NewNotFoundError("MSG_CHALLENGE_SESSION_NOT_FOUND", "Challenge session")
+	}
+	if derr := u.checkIdentifierLockout(ctx, tenantID, sessionValue.Identifier); derr != nil {
+		return nil, derr
+	}
 
 	flow, err := u.kratosService.GetRegistrationFlow(ctx, tenantID, flowID)
 	if err != nil {
@@ -239,8 +318,14 @@ func (u *userUseCase) VerifyRegister(
 	registrationResult, err := u.kratosService.SubmitRegistrationFlowWithCode(ctx, tenantID, flow, code)
 	if err != nil {
 		logger.GetLogger().Errorf("Failed to submit registration flow with code: %v", err)
+		if errors.Is(err, domainservice.ErrFlowRejected) {
+			if derr := u.recordWrongCode(ctx, tenantID, flowID, sessionValue.Identifier); derr != nil {
+				return nil, derr
+			}
+		}
 		return nil, domainerrors.NewValidationError("MSG_REGISTRATION_FAILED", "Registration failed", []interface{}{err.Error()})
 	}
+	u.clearWrongCodes(ctx, tenantID, sessionValue.Identifier)
 
 	// Extract traits
 	traits, ok := registrationResult.Session.Identity.Traits.(map[string]interface{})
@@ -272,19 +357,41 @@ func (u *userUseCase) VerifyRegister(
 
 	switch sessionValue.ChallengeType {
 	case constants.ChallengeTypeAddIdentifier:
-		inserted, err := u.userIdentityRepo.InsertOnceByKratosUserAndType(
-			ctx, nil, tenantID.String(), newKratosUserID, sessionValue.GlobalUserID, identifierType, identifier,
-		)
+		var inserted bool
+		err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var err error
+			inserted, err = u.userIdentityRepo.InsertOnceByKratosUserAndType(
+				ctx, tx, tenantID.String(), newKratosUserID, sessionValue.GlobalUserID, identifierType, identifier,
+			)
+			if err != nil || !inserted {
+				return err
+			}
+			return u.changeLogRepo.Create(ctx, tx, &domain.UserIdentityChangeLog{
+				GlobalUserID: sessionValue.GlobalUserID,
+				TenantID:     tenantID.String(),
+				Action:       constants.IdentityChangeActionAdd,
+				IdentityType: identifierType,
+				NewValue:     identifier,
+				Actor:        constants.IdentityChangeActorUser,
+			})
+		})
 		if err != nil {
 			return nil, domainerrors.WrapInternal(err, "MSG_ADD_IDENTIFIER_FAILED", "Failed to add identifier")
 		}
 		if !inserted {
 			return nil, domainerrors.NewConflictError("MSG_IDENTIFIER_TYPE_EXISTS", "Identifier of this type already added", nil)
 		}
+		u.sessionCache.invalidateUser(sessionValue.GlobalUserID)
+		u.notifySecurityEvent(ctx, tenantID, types.SecurityNotice{
+			Event:          constants.SecurityEventIdentifierAdded,
+			GlobalUserID:   sessionValue.GlobalUserID,
+			IdentifierType: identifierType,
+			Identifier:     identifier,
+		})
 
 	case constants.ChallengeTypeChangeIdentifier:
 		// Handle change identifier challenge
-		if err := u.bindIAMToUpdateIdentifier(
+		oldIdentity, err := u.bindIAMToUpdateIdentifier(
 			ctx,
 			tenant,
 			sessionValue.GlobalUserID,
@@ -292,9 +399,20 @@ func (u *userUseCase) VerifyRegister(
 			newKratosUserID,
 			identifier,
 			identifierType,
-		); err != nil {
+		)
+		if err != nil {
 			return nil, domainerrors.WrapInternal(err, "MSG_UPDATE_IDENTIFIER_FAILED", "Failed to update identifier")
 		}
+		u.sessionCache.invalidateUser(sessionValue.GlobalUserID)
+		quarantineIdentity(ctx, u.tenantSettingRepo, u.identifierQuarantineRepo, oldIdentity, constants.IdentifierQuarantineReasonChanged)
+		u.notifySecurityEvent(ctx, tenantID, types.SecurityNotice{
+			Event:                  constants.SecurityEventIdentifierChanged,
+			GlobalUserID:           sessionValue.GlobalUserID,
+			IdentifierType:         identifierType,
+			Identifier:             identifier,
+			PreviousIdentifierType: oldIdentity.Type,
+			PreviousIdentifier:     oldIdentity.Value,
+		})
 
 	default:
 		// Bind IAM to registration
@@ -316,7 +434,7 @@ func (u *userUseCase) VerifyRegister(
 		globalUserID = userGlobalID.GlobalUserID
 	}
 
-	return &types.IdentityUserAuthResponse{
+	resp := &types.IdentityUserAuthResponse{
 		SessionID:       registrationResult.Session.Id,
 		SessionToken:    *registrationResult.SessionToken,
 		Active:          *registrationResult.Session.Active,
@@ -335,11 +453,20 @@ func (u *userUseCase) VerifyRegister(
 		AuthenticationMethods: utils.Map(registrationResult.Session.AuthenticationMethods, func(method client.SessionAuthenticationMethod) string {
 			return *method.Method
 		}),
-	}, nil
+	}
+
+	// A registration starts the history new logins are compared with, so it is not assessed itself
+	flowType := sessionValue.ChallengeType
+	if flowType == "" {
+		flowType = constants.ChallengeTypeRegister
+	}
+	return u.completeSignIn(ctx, tenantID, resp, signInOptions{
+		login: newLoginEvent(ctx, flowType, u.codeChannel(ctx, tenantID, identifierType, identifier)),
+	})
 }
 
 // bindIAMToUpdateIdentifier handles updating to a different identifier
-// keeping the same GlobalUserID but mapping to the new KratosUserID
+// keeping the same GlobalUserID but mapping to the new KratosUserID, and returns the identity as it was
 // All write operations are wrapped in a transaction to ensure atomicity
 func (u *userUseCase) bindIAMToUpdateIdentifier(
 	ctx context.Context,
@@ -349,20 +476,20 @@ func (u *userUseCase) bindIAMToUpdateIdentifier(
 	newKratosUserID string,
 	newIdentifier string,
 	newIdentifierType string,
-) error {
+) (*domain.UserIdentity, error) {
 	// Pre-fetch the current mapping before starting the transaction
 	globalIdentifierMapping, err := u.userIdentifierMappingRepo.GetByGlobalUserID(
 		ctx,
 		globalUserID,
 	)
 	if err != nil || globalIdentifierMapping == nil {
-		return fmt.Errorf("get existing mapping: %w", err)
+		return nil, fmt.Errorf("get existing mapping: %w", err)
 	}
 
 	// Get the old identifier
 	oldIdentity, err := u.userIdentityRepo.GetByTenantAndKratosUserID(ctx, nil, tenant.ID.String(), oldKratosUserID)
 	if err != nil {
-		return fmt.Errorf("old identity with id: %s in tenant %s not found: %w", oldKratosUserID, tenant.ID.String(), err)
+		return nil, fmt.Errorf("old identity with id: %s in tenant %s not found: %w", oldKratosUserID, tenant.ID.String(), err)
 	}
 
 	// Begin transaction
@@ -377,6 +504,17 @@ func (u *userUseCase) bindIAMToUpdateIdentifier(
 		}); err != nil {
 			return fmt.Errorf("create new identity: %w", err)
 		}
+		if err := u.changeLogRepo.Create(ctx, tx, &domain.UserIdentityChangeLog{
+			GlobalUserID: globalUserID,
+			TenantID:     tenant.ID.String(),
+			Action:       constants.IdentityChangeActionChange,
+			IdentityType: newIdentifierType,
+			OldValue:     oldIdentity.Value,
+			NewValue:     newIdentifier,
+			Actor:        constants.IdentityChangeActorUser,
+		}); err != nil {
+			return fmt.Errorf("record identity change: %w", err)
+		}
 		// If we reach here, all operations succeeded and the transaction will be committed
 		return nil
 	})
@@ -385,14 +523,14 @@ func (u *userUseCase) bindIAMToUpdateIdentifier(
 		if cleanUpErr := u.rollbackKratosUpdateIdentifier(ctx, tenant, newKratosUserID); cleanUpErr != nil {
 			logger.GetLogger().Errorf("Failed to clean up: %v", cleanUpErr)
 		}
-		return fmt.Errorf("failed to bind IAM to update identifier: %v", txErr)
+		return nil, fmt.Errorf("failed to bind IAM to update identifier: %v", txErr)
 	}
 	// Delete the old identifier from Kratos
 	if err := u.kratosService.DeleteIdentifierAdmin(ctx, tenant.ID, uuid.MustParse(oldIdentity.KratosUserID)); err != nil {
-		return fmt.Errorf("failed to delete old identifier: %w", err)
+		return nil, fmt.Errorf("failed to delete old identifier: %w", err)
 	}
 
-	return nil
+	return oldIdentity, nil
 }
 
 // rollbackKratosUpdateIdentifier rolls back the changes to Kratos when the update identifier flow fails
@@ -408,6 +546,31 @@ func (u *userUseCase) rollbackKratosUpdateIdentifier(
 	return nil
 }
 
+// deleteIdentity removes an identity from IAM and records the deletion in the user's history
+func (u *userUseCase) deleteIdentity(ctx context.Context, identity *domain.UserIdentity, actor string) error {
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := u.userIdentityRepo.Delete(tx, identity.ID); err != nil {
+			return err
+		}
+		return u.changeLogRepo.Create(ctx, tx, &domain.UserIdentityChangeLog{
+			GlobalUserID: identity.GlobalUserID,
+			TenantID:     identity.TenantID,
+			Action:       constants.IdentityChangeActionDelete,
+			IdentityType: identity.Type,
+			OldValue:     identity.Value,
+			Actor:        actor,
+		})
+	})
+}
+
+// notifySecurityEvent tells the user's identifiers about a sensitive change, when notices are set up
+func (u *userUseCase) notifySecurityEvent(ctx context.Context, tenantID uuid.UUID, notice types.SecurityNotice) {
+	if u.securityNotifier == nil {
+		return
+	}
+	u.securityNotifier.Notify(ctx, tenantID, notice)
+}
+
 // bindIAMToRegistration binds the IAM records to the registration flow
 func (u *userUseCase) bindIAMToRegistration(
 	ctx context.Context,
@@ -446,7 +609,7 @@ func (u *userUseCase) bindIAMToRegistration(
 	}
 
 	// Create identities
-	_, err := u.userIdentityRepo.InsertOnceByKratosUserAndType(
+	inserted, err := u.userIdentityRepo.InsertOnceByKratosUserAndType(
 		ctx, tx,
 		tenant.ID.String(),
 		newKratosUserID,
@@ -457,6 +620,18 @@ func (u *userUseCase) bindIAMToRegistration(
 	if err != nil {
 		return fmt.Errorf("create identity: %w", err)
 	}
+	if inserted {
+		if err := u.changeLogRepo.Create(ctx, tx, &domain.UserIdentityChangeLog{
+			GlobalUserID: globalUserID,
+			TenantID:     tenant.ID.String(),
+			Action:       constants.IdentityChangeActionAdd,
+			IdentityType: identifierType,
+			NewValue:     identifier,
+			Actor:        constants.IdentityChangeActorUser,
+		}); err != nil {
+			return fmt.Errorf("record identity change: %w", err)
+		}
+	}
 
 	// Create mapping
 	if err := u.userIdentifierMappingRepo.Create(ctx, tx, &domain.UserIdentifierMapping{
@@ -469,12 +644,13 @@ func (u *userUseCase) bindIAMToRegistration(
 	return nil
 }
 
-// VerifyLogin verifies the login flow
+// VerifyLogin verifies the login flow and, with rememberDevice, trusts the client once signed in
 func (u *userUseCase) VerifyLogin(
 	ctx context.Context,
 	tenantID uuid.UUID,
 	flowID string,
 	code string,
+	rememberDevice bool,
 ) (*types.IdentityUserAuthResponse, *domainerrors.DomainError) {
 	// Check rate limit for verification attempts
 	key := "verify:login:" + flowID
@@ -501,6 +677,9 @@ func (u *userUseCase) VerifyLogin(
 		})
 	}
 	identifier := sessionValue.Identifier
+	if derr := u.checkIdentifierLockout(ctx, tenantID, identifier); derr != nil {
+		return nil, derr
+	}
 
 	var loginResult *client.SuccessfulNativeLogin
 
@@ -540,14 +719,20 @@ func (u *userUseCase) VerifyLogin(
 		ctx, tenantID, flow, constants.MethodTypeCode.String(), &identifier, nil, &code,
 	)
 	if err != nil {
+		if errors.Is(err, domainservice.ErrFlowRejected) {
+			if derr := u.recordWrongCode(ctx, tenantID, flowID, identifier); derr != nil {
+				return nil, derr
+			}
+		}
 		return nil, domainerrors.NewValidationError("MSG_LOGIN_FAILED", "Login failed", []interface{}{err.Error()})
 	}
+	u.clearWrongCodes(ctx, tenantID, identifier)
 
 	// Delete challenge session
 	_ = u.challengeSessionRepo.DeleteChallenge(ctx, flowID)
 
 	// Return authentication response
-	return &types.IdentityUserAuthResponse{
+	resp := &types.IdentityUserAuthResponse{
 		SessionID:       loginResult.Session.Id,
 		SessionToken:    *loginResult.SessionToken,
 		Active:          *loginResult.Session.Active,
@@ -565,7 +750,12 @@ func (u *userUseCase) VerifyLogin(
 		AuthenticationMethods: utils.Map(loginResult.Session.AuthenticationMethods, func(method client.SessionAuthenticationMethod) string {
 			return *method.Method
 		}),
-	}, nil
+	}
+	return u.completeSignIn(ctx, tenantID, resp, signInOptions{
+		rememberDevice: rememberDevice,
+		login:          newLoginEvent(ctx, constants.ChallengeTypeLogin, u.codeChannel(ctx, tenantID, sessionValue.IdentifierType, identifier)),
+		assessRisk:     true,
+	})
 }
 
 // Register registers a new user
@@ -624,9 +814,13 @@ func (u *userUseCase) Register(
 	if exists {
 		// Orphan resolution: if IAM row exists but Kratos identity is gone, hard-delete IAM record and proceed
 		if existingIdentity, getErr := u.userIdentityRepo.GetByTypeAndValue(ctx, nil, tenantID.String(), identifierType, identifierValue); getErr == nil && existingIdentity != nil {
+			// A blocked user must not get a fresh account by having the identifier cleaned up
+			if derr := u.checkAccountStatus(ctx, tenantID, existingIdentity.GlobalUserID); derr != nil {
+				return nil, derr
+			}
 			if _, kerr := u.kratosService.GetIdentity(ctx, tenantID, uuid.MustParse(existingIdentity.KratosUserID)); kerr != nil {
 				// Kratos missing → treat as orphan; hard delete IAM record before continuing
-				if err := u.userIdentityRepo.Delete(nil, existingIdentity.ID); err != nil {
+				if err := u.deleteIdentity(ctx, existingIdentity, constants.IdentityChangeActorSystem); err != nil {
 					logger.GetLogger().Errorf("Failed to delete orphan IAM identity: %v (identity_id=%s)", err, existingIdentity.ID)
 					return nil, domainerrors.WrapInternal(err, "MSG_DELETE_IDENTIFIER_FAILED", "Failed to delete orphan IAM identity")
 				}
@@ -637,6 +831,15 @@ func (u *userUseCase) Register(
 	if exists {
 		return nil, domainerrors.NewConflictError("MSG_IDENTIFIER_ALREADY_EXISTS", "Identifier has already been registered", nil)
 	}
+	if derr := u.checkRegistrationAllowed(ctx, tenantID, identifierType, identifierValue); derr != nil {
+		return nil, derr
+	}
+	if derr := u.checkIdentifierLockout(ctx, tenantID, identifierValue); derr != nil {
+		return nil, derr
+	}
+	if derr := u.checkIdentifierQuarantine(ctx, tenantID, identifierType, identifierValue, ""); derr != nil {
+		return nil, derr
+	}
 
 	// Initialize registration flow with Kratos
 	flow, err := u.kratosService.InitializeRegistrationFlow(ctx, tenantID)
@@ -680,6 +883,7 @@ func (u *userUseCase) Register(
 	if err := u.challengeSessionRepo.SaveChallenge(ctx, flow.Id, session, constants.DefaultChallengeDuration); err != nil {
 		return nil, domainerrors.WrapInternal(err, "MSG_SAVE_CHALLENGE_FAILED", "Failed to save challenge session")
 	}
+	u.startResendCooldown(flow.Id, time.Now())
 
 	// Return success with verification flow info
 	return &types.IdentityUserAuthResponse{
@@ -692,47 +896,53 @@ func (u *userUseCase) Register(
 	}, nil
 }
 
-// Login logs in a user with username and password
+// Login logs in a user with an identifier (email or phone) and password
 func (u *userUseCase) Login(
 	ctx context.Context,
 	tenantID uuid.UUID,
 	username string,
 	password string,
 ) (*types.IdentityUserAuthResponse, *domainerrors.DomainError) {
-	// Initialize login flow
+	// 1. Normalize identifier so it matches the traits stored in Kratos
+	idType, identifier, derr := inferAndNormalizeIdentifier(username)
+	if derr != nil {
+		return nil, derr
+	}
+	if password == "" {
+		return nil, domainerrors.NewValidationError("MSG_PASSWORD_REQUIRED", "Password is required", []interface{}{
+			map[string]string{"field": "password", "error": "Password is required"},
+		})
+	}
+
+	// 2. Rate limit password attempts per identifier
+	key := fmt.Sprintf("login:password:%s:tenant:%s:%s", idType, identifier, tenantID.String())
+	if err := utils.CheckRateLimitDomain(u.rateLimiter, key, constants.MaxAttemptsPerWindow, constants.RateLimitWindow); err != nil {
+		return nil, domainerrors.NewRateLimitError("MSG_RATE_LIMIT_EXCEEDED", "Rate limit exceeded", err)
+	}
+
+	// 3. Initialize login flow
 	flow, err := u.kratosService.InitializeLoginFlow(ctx, tenantID)
 	if err != nil {
 		logger.GetLogger().Errorf("Failed to initialize login flow: %v", err)
 		return nil, domainerrors.WrapInternal(err, "MSG_INITIALIZE_LOGIN_FAILED", "Failed to initialize login flow")
 	}
 
-	// Submit login flow to Kratos
-	loginResult, err := u.kratosService.SubmitLoginFlow(ctx, tenantID, flow, constants.MethodTypePassword.String(), &username, &password, nil)
+	// 4. Submit login flow to Kratos
+	loginResult, err := u.kratosService.SubmitLoginFlow(ctx, tenantID, flow, constants.MethodTypePassword.String(), &identifier, &password, nil)
 	if err != nil {
 		logger.GetLogger().Errorf("Failed to submit login flow: %v", err)
 		return nil, domainerrors.NewUnauthorizedError("MSG_LOGIN_FAILED", "Login failed").WithCause(err)
 	}
+	if loginResult.SessionToken == nil {
+		return nil, domainerrors.NewUnauthorizedError("MSG_LOGIN_FAILED", "Login failed")
+	}
 
-	// Return authentication response
-	return &types.IdentityUserAuthResponse{
-		SessionID:       loginResult.Session.Id,
-		SessionToken:    *loginResult.SessionToken,
-		Active:          *loginResult.Session.Active,
-		ExpiresAt:       loginResult.Session.ExpiresAt,
-		IssuedAt:        loginResult.Session.IssuedAt,
-		AuthenticatedAt: loginResult.Session.AuthenticatedAt,
-		User: &types.IdentityUserResponse{
-			ID:       loginResult.Session.Identity.Id,
-			UserName: extractStringFromTraits(loginResult.Session.Identity.Traits.(map[string]interface{}), constants.IdentifierUsername.String(), ""),
-			Email:    extractStringFromTraits(loginResult.Session.Identity.Traits.(map[string]interface{}), constants.IdentifierEmail.String(), ""),
-			Phone:    extractStringFromTraits(loginResult.Session.Identity.Traits.(map[string]interface{}), constants.IdentifierPhone.String(), ""),
-			Tenant:   extractStringFromTraits(loginResult.Session.Identity.Traits.(map[string]interface{}), constants.IdentifierTenant.String(), ""),
-			Lang:     extractStringFromTraits(loginResult.Session.Identity.Traits.(map[string]interface{}), constants.IdentifierLang.String(), ""),
-		},
-		AuthenticationMethods: utils.Map(loginResult.Session.AuthenticationMethods, func(method client.SessionAuthenticationMethod) string {
-			return *method.Method
-		}),
-	}, nil
+	// 5. Return authentication response
+	resp := newAuthResponse(&loginResult.Session, *loginResult.SessionToken)
+	if identity, err := u.userIdentityRepo.GetByTenantAndKratosUserID(ctx, nil, tenantID.String(), resp.User.ID); err == nil && identity != nil {
+		resp.User.GlobalUserID = identity.GlobalUserID
+	}
+	return u.completeSignIn(ctx, tenantID, resp, signInOptions{})
 }
 
 // Logout logs out a user
@@ -762,44 +972,12 @@ func (u *userUseCase) Logout(
 		logger.GetLogger().Errorf("Failed to logout: %v", err)
 		return domainerrors.WrapInternal(err, "MSG_LOGOUT_FAILED", "Failed to logout")
 	}
-
-	return nil
-}
-
-// RefreshToken refreshes a user's session token
-func (u *userUseCase) RefreshToken(
-	ctx context.Context,
-	tenantID uuid.UUID,
-	accessToken string,
-	refreshToken string,
-) (*types.IdentityUserAuthResponse, *domainerrors.DomainError) {
-	// Get session
-	session, err := u.kratosService.GetSession(ctx, tenantID, accessToken)
-	if err != nil {
-		logger.GetLogger().Errorf("Failed to get session: %v", err)
-		return nil, domainerrors.NewUnauthorizedError("MSG_INVALID_SESSION", "Invalid session").WithCause(err)
+	u.sessionCache.remove(tenantID, sessionToken)
+	if err := u.userSessionRepo.Revoke(ctx, tenantID.String(), session.Id, time.Now()); err != nil {
+		logger.GetLogger().Errorf("Failed to mark session %s revoked: %v", session.Id, err)
 	}
 
-	// Return authentication response
-	return &types.IdentityUserAuthResponse{
-		SessionID:       session.Id,
-		SessionToken:    accessToken,
-		Active:          *session.Active,
-		ExpiresAt:       session.ExpiresAt,
-		IssuedAt:        session.IssuedAt,
-		AuthenticatedAt: session.AuthenticatedAt,
-		User: &types.IdentityUserResponse{
-			ID:       session.Identity.Id,
-			UserName: extractStringFromTraits(session.Identity.Traits.(map[string]interface{}), constants.IdentifierUsername.String(), ""),
-			Email:    extractStringFromTraits(session.Identity.Traits.(map[string]interface{}), constants.IdentifierEmail.String(), ""),
-			Phone:    extractStringFromTraits(session.Identity.Traits.(map[string]interface{}), constants.IdentifierPhone.String(), ""),
-			Tenant:   extractStringFromTraits(session.Identity.Traits.(map[string]interface{}), constants.IdentifierTenant.String(), ""),
-			Lang:     extractStringFromTraits(session.Identity.Traits.(map[string]interface{}), constants.IdentifierLang.String(), ""),
-		},
-		AuthenticationMethods: utils.Map(session.AuthenticationMethods, func(method client.SessionAuthenticationMethod) string {
-			return *method.Method
-		}),
-	}, nil
+	return nil
 }
 
 // Profile gets a user's profile
@@ -813,6 +991,13 @@ func (u *userUseCase) Profile(
 		return nil, sessionTokenErr
 	}
 
+	// Serve recently validated sessions from cache; activity is recorded on the next miss,
+	// which keeps last-seen accurate as long as the cache TTL is short
+	if user := u.sessionCache.get(tenantID, sessionToken); user != nil {
+		return user, nil
+	}
+	validatedAt := time.Now()
+
 	// Get session
 	session, err := u.kratosService.WhoAmI(ctx, tenantID, sessionToken)
 	if err != nil {
@@ -820,6 +1005,11 @@ func (u *userUseCase) Profile(
 		return nil, domainerrors.NewUnauthorizedError("MSG_INVALID_SESSION", "Invalid session").WithCause(err)
 	}
 
+	// Record activity for the session list
+	if err := u.userSessionRepo.Touch(ctx, tenantID.String(), session.Id, time.Now(), constants.SessionActivityInterval); err != nil {
+		logger.GetLogger().Errorf("Failed to record session activity: %v", err)
+	}
+
 	// Extract user traits
 	user, err := extractUserFromTraits(session.Identity.Traits, "", "")
 	if err != nil {
@@ -843,19 +1033,7 @@ func (u *userUseCase) Profile(
 	}
 
 	// Map Email/Phone from DB
-	var emailFromDB, phoneFromDB string
-	for _, id := range identities {
-		switch id.Type {
-		case constants.IdentifierEmail.String():
-			if emailFromDB == "" {
-				emailFromDB = id.Value
-			}
-		case constants.IdentifierPhone.String():
-			if phoneFromDB == "" {
-				phoneFromDB = id.Value
-			}
-		}
-	}
+	emailFromDB, phoneFromDB := identifiersFromIdentities(identities)
 	globalUserID := identities[0].GlobalUserID
 
 	// Set user id
@@ -864,6 +1042,17 @@ func (u *userUseCase) Profile(
 	user.Email = emailFromDB
 	user.Phone = phoneFromDB
 
+	// Only active users are cached; a status change invalidates the user's cached sessions
+	if derr := u.checkAccountStatus(ctx, tenantID, globalUserID); derr != nil {
+		return nil, derr
+	}
+	pending, derr := u.mfaEnrollmentPending(ctx, tenantID, globalUserID)
+	if derr != nil {
+		return nil, derr
+	}
+	user.MFAEnrollmentRequired = pending
+	u.sessionCache.put(tenantID, sessionToken, &user, session.Id, session.ExpiresAt, validatedAt)
+
 	return &user, nil
 }
 
@@ -889,6 +1078,9 @@ func (u *userUseCase) AddNewIdentifier(
 	if exists {
 		return nil, domainerrors.NewConflictError("MSG_IDENTIFIER_ALREADY_EXISTS", "Identifier has already been registered", nil)
 	}
+	if derr := u.checkIdentifierQuarantine(ctx, tenantID, idType, identifier, globalUserID); derr != nil {
+		return nil, derr
+	}
 
 	// 3. Check if user already has this identifier type
 	hasType, err := u.userIdentityRepo.ExistsByTenantGlobalUserIDAndType(ctx, tenantID.String(), globalUserID, identifierType)
@@ -946,6 +1138,7 @@ func (u *userUseCase) AddNewIdentifier(
 	if err := u.challengeSessionRepo.SaveChallenge(ctx, flow.Id, session, constants.DefaultChallengeDuration); err != nil {
 		return nil, domainerrors.WrapInternal(err, "MSG_SAVE_CHALLENGE_FAILED", "Failed to save challenge session")
 	}
+	u.startResendCooldown(flow.Id, time.Now())
 
 	// 8. Return response
 	return &types.IdentityUserChallengeResponse{
@@ -1008,14 +1201,22 @@ func (u *userUseCase) DeleteIdentifier(
 	if err := u.kratosService.DeleteIdentifierAdmin(ctx, tenantID, uuid.MustParse(kratosUserID)); err != nil {
 		return domainerrors.WrapInternal(err, "MSG_DELETE_IDENTIFIER_FAILED", "Failed to delete identifier from Kratos")
 	}
+	u.sessionCache.invalidateUser(globalUserID)
 
 	// 6. Delete the identifier from the database only after Kratos succeeds
-	if err := u.userIdentityRepo.Delete(nil, identifierToDelete.ID); err != nil {
+	if err := u.deleteIdentity(ctx, identifierToDelete, constants.IdentityChangeActorUser); err != nil {
 		logger.GetLogger().Errorf("IAM delete after Kratos success failed: %v (identity_id=%s)", err, identifierToDelete.ID)
 		// Consider operation successful since Kratos is the source of truth for auth surface
-		return nil
 	}
+	quarantineIdentity(ctx, u.tenantSettingRepo, u.identifierQuarantineRepo, identifierToDelete, constants.IdentifierQuarantineReasonDeleted)
 
+	// 7. Tell the user, on the deleted identifier too
+	u.notifySecurityEvent(ctx, tenantID, types.SecurityNotice{
+		Event:                  constants.SecurityEventIdentifierDeleted,
+		GlobalUserID:           globalUserID,
+		PreviousIdentifierType: identifierToDelete.Type,
+		PreviousIdentifier:     identifierToDelete.Value,
+	})
 	return nil
 }
 
@@ -1044,6 +1245,9 @@ func (u *userUseCase) ChangeIdentifier(
 	if exists {
 		return nil, domainerrors.NewConflictError("MSG_IDENTIFIER_ALREADY_EXISTS", "Identifier has already been registered", nil)
 	}
+	if derr := u.checkIdentifierQuarantine(ctx, tenantID, newIdentifierType, newIdentifier, globalUserID); derr != nil {
+		return nil, derr
+	}
 
 	// 4. Check user's current identifiers
 	identities, err := u.userIdentityRepo.ListByTenantAndKratosUserID(ctx, nil, tenantID.String(), kratosUserID)
@@ -1113,6 +1317,7 @@ func (u *userUseCase) ChangeIdentifier(
 	if err := u.challengeSessionRepo.SaveChallenge(ctx, flow.Id, session, constants.DefaultChallengeDuration); err != nil {
 		return nil, domainerrors.WrapInternal(err, "MSG_SAVE_CHALLENGE_FAILED", "Failed to save challenge session")
 	}
+	u.startResendCooldown(flow.Id, time.Now())
 
 	// 8. Return response
 	return &types.IdentityUserChallengeResponse{
@@ -1150,6 +1355,9 @@ func (u *userUseCase) ChallengeVerification(
 	if err := utils.CheckRateLimitDomain(u.rateLimiter, key, constants.MaxAttemptsPerWindow, constants.RateLimitWindow); err != nil {
 		return nil, domainerrors.NewRateLimitError("MSG_RATE_LIMIT_EXCEEDED", "Rate limit exceeded", err)
 	}
+	if derr := u.checkIdentifierLockout(ctx, tenantID, identifier); derr != nil {
+		return nil, derr
+	}
 
 	// 3. Init verification flow
 	flowID, err := u.kratosService.InitializeVerificationFlow(ctx, tenantID)
@@ -1176,6 +1384,7 @@ func (u *userUseCase) ChallengeVerification(
 	if err := u.challengeSessionRepo.SaveChallenge(ctx, flowID, session, constants.DefaultChallengeDuration); err != nil {
 		return nil, domainerrors.WrapInternal(err, "MSG_SAVING_SESSION_FAILED", "Saving challenge session failed")
 	}
+	u.startResendCooldown(flowID, time.Now())
 
 	// 6. Response
 	return &types.IdentityUserChallengeResponse{
@@ -1202,6 +1411,9 @@ func (u *userUseCase) VerifyIdentifier(
 	if err != nil || sessionValue == nil {
 		return nil, domainerrors.NewNotFoundError("MSG_CHALLENGE_SESSION_NOT_FOUND", "Challenge session")
 	}
+	if derr := u.checkIdentifierLockout(ctx, tenantID, sessionValue.Identifier); derr != nil {
+		return nil, derr
+	}
 
 	// 3. Submit verification with code
 	id := sessionValue.Identifier
@@ -1209,6 +1421,11 @@ func (u *userUseCase) VerifyIdentifier(
 		ctx, tenantID, flowID, &id, constants.IdentifierType(sessionValue.IdentifierType), &code,
 	)
 	if err != nil {
+		if errors.Is(err, domainservice.ErrFlowRejected) {
+			if derr := u.recordWrongCode(ctx, tenantID, flowID, id); derr != nil {
+				return nil, derr
+			}
+		}
 		return nil, domainerrors.NewValidationError("MSG_VERIFICATION_FAILED", "Verification failed", []interface{}{err.Error()})
 	}
 
@@ -1221,9 +1438,13 @@ func (u *userUseCase) VerifyIdentifier(
 	}
 
 	if !verified {
-		// Do NOT delete the session here; allow user to retry
+		// Do NOT delete the session here; allow user to retry until the identifier is locked out
+		if derr := u.recordWrongCode(ctx, tenantID, flowID, id); derr != nil {
+			return nil, derr
+		}
 		return nil, domainerrors.NewValidationError("MSG_VERIFICATION_FAILED", "Invalid or expired verification code", nil)
 	}
+	u.clearWrongCodes(ctx, tenantID, id)
 
 	// 5. Cleanup session
 	_ = u.challengeSessionRepo.DeleteChallenge(ctx, flowID)
@@ -1288,13 +1509,35 @@ func (u *userUseCase) UpdateLang(
 		}
 	}
 
-	// 5. Upsert global mapping lang (one row per global_user_id)
-	if err := u.userIdentifierMappingRepo.Upsert(ctx, u.db, &domain.UserIdentifierMapping{
-		GlobalUserID: identities[0].GlobalUserID,
-		Lang:         lang,
+	// 5. Upsert global mapping lang (one row per global_user_id), recording the change in the history
+	globalUserID := identities[0].GlobalUserID
+	var oldLang string
+	if mapping, err := u.userIdentifierMappingRepo.GetByGlobalUserID(ctx, globalUserID); err == nil && mapping != nil {
+		oldLang = mapping.Lang
+	}
+	if err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := u.userIdentifierMappingRepo.Upsert(ctx, tx, &domain.UserIdentifierMapping{
+			GlobalUserID: globalUserID,
+			Lang:         lang,
+		}); err != nil {
+			return err
+		}
+		if oldLang == lang {
+			return nil
+		}
+		return u.changeLogRepo.Create(ctx, tx, &domain.UserIdentityChangeLog{
+			GlobalUserID: globalUserID,
+			TenantID:     tenantID.String(),
+			Action:       constants.IdentityChangeActionLang,
+			IdentityType: constants.IdentifierLang.String(),
+			OldValue:     oldLang,
+			NewValue:     lang,
+			Actor:        constants.IdentityChangeActorUser,
+		})
 	}); err != nil {
 		return domainerrors.WrapInternal(err, "MSG_UPDATE_MAPPING_FAILED", "Failed to upsert mapping lang")
 	}
+	u.sessionCache.invalidateUser(globalUserID)
 
 	return nil
 }
@@ -7,7 +7,10 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
 
 	"github.com/lifenetwork-ai/iam-service/constants"
 	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
@@ -34,16 +37,21 @@ func TestRegister_OrphanDeleteFails(t *testing.T) {
 	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
 	identityRepo.EXPECT().ExistsWithinTenant(ctx, tenantID.String(), constants.IdentifierEmail.String(), "test@example.com").Return(true, nil)
 	identityRepo.EXPECT().GetByTypeAndValue(ctx, nil, tenantID.String(), constants.IdentifierEmail.String(), "test@example.com").Return(&domain.UserIdentity{ID: identityID, TenantID: tenantID.String(), KratosUserID: uuid.NewString()}, nil)
-	identityRepo.EXPECT().Delete(nil, identityID).Return(assert.AnError)
+	identityRepo.EXPECT().Delete(gomock.Any(), identityID).Return(assert.AnError)
 
 	kratos := mock_services.NewMockKratosService(ctrl)
 	kratos.EXPECT().GetIdentity(ctx, tenantID, gomock.Any()).Return(nil, errors.New("identity missing"))
 
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
 	u := &userUseCase{
-		rateLimiter:      rateLimiter,
-		tenantRepo:       tenantRepo,
-		userIdentityRepo: identityRepo,
-		kratosService:    kratos,
+		db:                    db,
+		rateLimiter:           rateLimiter,
+		tenantRepo:            tenantRepo,
+		userIdentityRepo:      identityRepo,
+		kratosService:         kratos,
+		userAccountStatusRepo: activeAccountStatusRepo(ctrl),
 	}
 
 	resp, derr := u.Register(ctx, tenantID, "en", "test@example.com", "")
@@ -0,0 +1,252 @@
+package ucases
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+	"github.com/lifenetwork-ai/iam-service/packages/siwe"
+	"github.com/lifenetwork-ai/iam-service/packages/utils"
+)
+
+// ChallengeWithWallet issues a single-use nonce for a Sign-In With Ethereum message
+func (u *userUseCase) ChallengeWithWallet(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	address string,
+) (*types.WalletChallengeResponse, *domainerrors.DomainError) {
+	// 1. Validate address
+	address, err := siwe.ChecksumAddress(address)
+	if err != nil {
+		return nil, domainerrors.NewValidationError("MSG_INVALID_WALLET_ADDRESS", "Invalid wallet address", []interface{}{
+			map[string]string{"field": "address", "error": "Address must be a 0x-prefixed, 20-byte hex string"},
+		})
+	}
+
+	// 2. Wallet sign-in is refused until the domains messages may name are configured, as any
+	// site could otherwise relay a message a wallet signed for it
+	if len(conf.GetSIWEAllowedDomains()) == 0 {
+		return nil, domainerrors.NewInternalError("MSG_WALLET_NOT_CONFIGURED", "Wallet sign-in is not configured")
+	}
+
+	// 3. Rate limit
+	key := fmt.Sprintf("challenge:wallet:tenant:%s:%s", address, tenantID.String())
+	if err := utils.CheckRateLimitDomain(u.rateLimiter, key, constants.MaxAttemptsPerWindow, constants.RateLimitWindow); err != nil {
+		return nil, domainerrors.NewRateLimitError("MSG_RATE_LIMIT_EXCEEDED", "Rate limit exceeded", err)
+	}
+
+	// 4. Save challenge session; EIP-4361 nonces must be alphanumeric
+	flowID := uuid.NewString()
+	nonce := strings.ReplaceAll(uuid.NewString(), "-", "")
+	session := &domain.ChallengeSession{
+		ChallengeType:  constants.ChallengeTypeWallet,
+		IdentifierType: constants.IdentifierWallet.String(),
+		Identifier:     address,
+		OTP:            nonce,
+	}
+	if err := u.challengeSessionRepo.SaveChallenge(ctx, flowID, session, constants.DefaultChallengeDuration); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_SAVE_CHALLENGE_FAILED", "Failed to save challenge session")
+	}
+
+	// 5. Return response
+	return &types.WalletChallengeResponse{
+		FlowID:      flowID,
+		Address:     address,
+		Nonce:       nonce,
+		ChallengeAt: time.Now().Unix(),
+	}, nil
+}
+
+// VerifyWallet checks the signed EIP-4361 message of a wallet challenge and signs the wallet in,
+// registering it as a new user on first use.
+func (u *userUseCase) VerifyWallet(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	flowID string,
+	message string,
+	signature string,
+	lang string,
+) (*types.IdentityUserAuthResponse, *domainerrors.DomainError) {
+	// 1. Rate limit verification attempts
+	key := "verify:wallet:" + flowID
+	if err := utils.CheckRateLimitDomain(u.rateLimiter, key, constants.MaxAttemptsPerWindow, constants.RateLimitWindow); err != nil {
+		return nil, domainerrors.NewRateLimitError("MSG_RATE_LIMIT_EXCEEDED", "Rate limit exceeded", err)
+	}
+
+	// 2. Load challenge
+	challenge, err := u.challengeSessionRepo.GetChallenge(ctx, flowID)
+	if err != nil || challenge == nil {
+		return nil, domainerrors.NewNotFoundError("MSG_CHALLENGE_SESSION_NOT_FOUND", "Challenge session")
+	}
+	if challenge.ChallengeType != constants.ChallengeTypeWallet {
+		return nil, domainerrors.NewValidationError("MSG_INVALID_CHALLENGE_TYPE", "Invalid challenge type", nil)
+	}
+
+	// 3. Check the message is the one issued for this challenge, then its signature
+	if derr := validateWalletMessage(message, challenge); derr != nil {
+		return nil, derr
+	}
+	if err := siwe.VerifySignature(message, signature, challenge.Identifier); err != nil {
+		return nil, domainerrors.NewUnauthorizedError("MSG_INVALID_SIGNATURE", "Invalid signature").WithCause(err)
+	}
+
+	// 4. The nonce is single use
+	_ = u.challengeSessionRepo.DeleteChallenge(ctx, flowID)
+
+	// 5. Sign in, registering the wallet on first use
+	secret := conf.GetSIWECredentialSecret()
+	if secret == "" {
+		return nil, domainerrors.NewInternalError("MSG_WALLET_NOT_CONFIGURED", "Wallet sign-in is not configured")
+	}
+	address := challenge.Identifier
+	credential := derivedCredential(secret, tenantID, address)
+
+	identity, err := u.userIdentityRepo.GetByTypeAndValue(ctx, nil, tenantID.String(), constants.IdentifierWallet.String(), address)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainerrors.WrapInternal(err, "MSG_IAM_LOOKUP_FAILED", "Failed to look up identity")
+	}
+
+	var resp *types.IdentityUserAuthResponse
+	var derr *domainerrors.DomainError
+	if identity != nil {
+		resp, derr = u.loginWithDerivedCredential(ctx, tenantID, address, credential)
+		if derr != nil {
+			return nil, derr
+		}
+		resp.User.GlobalUserID = identity.GlobalUserID
+	} else {
+		resp, derr = u.registerWallet(ctx, tenantID, address, credential, lang)
+		if derr != nil {
+			return nil, derr
+		}
+	}
+
+	// 6. Return authentication response
+	return u.completeSignIn(ctx, tenantID, resp, signInOptions{})
+}
+
+// validateWalletMessage checks the address, nonce, domain and validity window of a sign-in message
+func validateWalletMessage(message string, challenge *domain.ChallengeSession) *domainerrors.DomainError {
+	msg, err := siwe.ParseMessage(message)
+	if err != nil {
+		return domainerrors.NewValidationError("MSG_INVALID_SIWE_MESSAGE", "Invalid sign-in message", []interface{}{err.Error()})
+	}
+	if msg.Address != challenge.Identifier || msg.Nonce != challenge.OTP {
+		return domainerrors.NewUnauthorizedError("MSG_SIWE_MESSAGE_MISMATCH", "Sign-in message does not match the challenge")
+	}
+	domains := conf.GetSIWEAllowedDomains()
+	if len(domains) == 0 {
+		return domainerrors.NewInternalError("MSG_WALLET_NOT_CONFIGURED", "Wallet sign-in is not configured")
+	}
+	if !slices.Contains(domains, msg.Domain) {
+		return domainerrors.NewUnauthorizedError("MSG_SIWE_DOMAIN_NOT_ALLOWED", "Sign-in message was issued for another domain")
+	}
+	now := time.Now()
+	if msg.IssuedAt.After(now.Add(constants.SIWEClockSkew)) || !msg.ValidAt(now) {
+		return domainerrors.NewUnauthorizedError("MSG_SIWE_MESSAGE_EXPIRED", "Sign-in message is expired or not yet valid")
+	}
+	return nil
+}
+
+// derivedCredential derives the Kratos password of an identity whose ownership is proven outside Kratos,
+// such as a wallet signature or a passkey assertion; it only lets Kratos issue the session.
+func derivedCredential(secret string, tenantID uuid.UUID, identifier string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(tenantID.String() + ":" + identifier))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (u *userUseCase) loginWithDerivedCredential(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	identifier string,
+	credential string,
+) (*types.IdentityUserAuthResponse, *domainerrors.DomainError) {
+	flow, err := u.kratosService.InitializeLoginFlow(ctx, tenantID)
+	if err != nil {
+		logger.GetLogger().Errorf("Failed to initialize login flow: %v", err)
+		return nil, domainerrors.WrapInternal(err, "MSG_INITIALIZE_LOGIN_FAILED", "Failed to initialize login flow")
+	}
+	loginResult, err := u.kratosService.SubmitLoginFlow(ctx, tenantID, flow, constants.MethodTypePassword.String(), &identifier, &credential, nil)
+	if err != nil || loginResult.SessionToken == nil {
+		logger.GetLogger().Errorf("Failed to submit login flow with derived credential: %v", err)
+		return nil, domainerrors.NewUnauthorizedError("MSG_LOGIN_FAILED", "Login failed").WithCause(err)
+	}
+	return newAuthResponse(&loginResult.Session, *loginResult.SessionToken), nil
+}
+
+func (u *userUseCase) registerWallet(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	address string,
+	credential string,
+	lang string,
+) (*types.IdentityUserAuthResponse, *domainerrors.DomainError) {
+	tenant, err := u.tenantRepo.GetByID(tenantID)
+	if err != nil || tenant == nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_TENANT_FAILED", "Failed to get tenant")
+	}
+	if lang == "" {
+		lang = constants.LangEN
+	}
+	if derr := u.checkRegistrationAllowed(ctx, tenantID, constants.IdentifierWallet.String(), address); derr != nil {
+		return nil, derr
+	}
+
+	flow, err := u.kratosService.InitializeRegistrationFlow(ctx, tenantID)
+	if err != nil {
+		logger.GetLogger().Errorf("Failed to initialize registration flow: %v", err)
+		return nil, domainerrors.WrapInternal(err, "MSG_INITIALIZE_REGISTRATION_FAILED", "Failed to initialize registration flow")
+	}
+	traits := map[string]interface{}{
+		constants.IdentifierTenant.String(): tenant.Name,
+		constants.IdentifierLang.String():   lang,
+		constants.IdentifierWallet.String(): address,
+		"password":                          credential,
+	}
+	result, err := u.kratosService.SubmitRegistrationFlow(ctx, tenantID, flow, constants.MethodTypePassword.String(), traits)
+	if err != nil || result.Identity.Id == "" {
+		logger.GetLogger().Errorf("Failed to submit wallet registration flow: %v", err)
+		return nil, domainerrors.NewValidationError("MSG_REGISTRATION_FAILED", "Registration failed", nil).WithCause(err)
+	}
+	newKratosUserID := result.Identity.Id
+
+	if err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return u.bindIAMToRegistration(ctx, tx, tenant, newKratosUserID, address, constants.IdentifierWallet.String(), lang)
+	}); err != nil {
+		if cleanUpErr := u.kratosService.DeleteIdentifierAdmin(ctx, tenantID, uuid.MustParse(newKratosUserID)); cleanUpErr != nil {
+			logger.GetLogger().Errorf("Failed to clean up Kratos identity %s: %v", newKratosUserID, cleanUpErr)
+		}
+		return nil, domainerrors.WrapInternal(err, "MSG_IAM_REGISTRATION_FAILED", "Failed to bind IAM to registration")
+	}
+
+	// Kratos only returns a session when its registration hooks sign the identity in
+	var resp *types.IdentityUserAuthResponse
+	if result.Session != nil && result.SessionToken != nil {
+		resp = newAuthResponse(result.Session, *result.SessionToken)
+	} else {
+		var derr *domainerrors.DomainError
+		if resp, derr = u.loginWithDerivedCredential(ctx, tenantID, address, credential); derr != nil {
+			return nil, derr
+		}
+	}
+	if identity, err := u.userIdentityRepo.GetByTenantAndKratosUserID(ctx, nil, tenantID.String(), newKratosUserID); err == nil && identity != nil {
+		resp.User.GlobalUserID = identity.GlobalUserID
+	}
+	return resp, nil
+}
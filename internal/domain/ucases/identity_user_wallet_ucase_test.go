@@ -0,0 +1,281 @@
+package ucases
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/sha3"
+	"gorm.io/gorm"
+
+	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	mock_repositories "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/repositories"
+	mock_services "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/services"
+	mock_rate_limiter "github.com/lifenetwork-ai/iam-service/mocks/infrastructures/rate_limiter/types"
+	"github.com/lifenetwork-ai/iam-service/packages/siwe"
+)
+
+type testWallet struct {
+	key     *secp256k1.PrivateKey
+	address string
+}
+
+func newTestWallet(t *testing.T) testWallet {
+	t.Helper()
+	key, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	h := sha3.NewLegacyKeccak256()
+	h.Write(key.PubKey().SerializeUncompressed()[1:])
+	address, err := siwe.ChecksumAddress("0x" + hex.EncodeToString(h.Sum(nil)[12:]))
+	require.NoError(t, err)
+	return testWallet{key: key, address: address}
+}
+
+func (w testWallet) message(nonce string) string {
+	return w.messageFor("app.example.com", nonce)
+}
+
+func (w testWallet) messageFor(domain, nonce string) string {
+	return fmt.Sprintf(`%s wants you to sign in with your Ethereum account:
+%s
+
+URI: https://%s
+Version: 1
+Chain ID: 1
+Nonce: %s
+Issued At: %s`, domain, w.address, domain, nonce, time.Now().UTC().Format(time.RFC3339))
+}
+
+// sign returns a personal_sign signature in the r || s || v layout
+func (w testWallet) sign(message string) string {
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte("\x19Ethereum Signed Message:\n" + strconv.Itoa(len(message)) + message))
+	compact := ecdsa.SignCompact(w.key, h.Sum(nil), false)
+	return "0x" + hex.EncodeToString(append(compact[1:], compact[0]))
+}
+
+// useSIWEDomain lets wallets sign in from app.example.com for the duration of the test
+func useSIWEDomain(t *testing.T) {
+	previousDomains := conf.GetConfiguration().SIWE.AllowedDomains
+	conf.GetConfiguration().SIWE.AllowedDomains = "app.example.com"
+	t.Cleanup(func() { conf.GetConfiguration().SIWE.AllowedDomains = previousDomains })
+}
+
+func TestChallengeWithWallet_NormalizesAddress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useSIWEDomain(t)
+	ctx := context.Background()
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().SaveChallenge(ctx, gomock.Any(), gomock.Any(), constants.DefaultChallengeDuration).
+		DoAndReturn(func(_ context.Context, _ string, c *domain.ChallengeSession, _ time.Duration) error {
+			assert.Equal(t, constants.ChallengeTypeWallet, c.ChallengeType)
+			assert.Equal(t, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", c.Identifier)
+			assert.Len(t, c.OTP, 32)
+			return nil
+		})
+
+	u := &userUseCase{rateLimiter: rateLimiter, challengeSessionRepo: challengeRepo}
+
+	resp, derr := u.ChallengeWithWallet(ctx, uuid.New(), "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+	require.Nil(t, derr)
+	assert.Equal(t, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", resp.Address)
+	assert.NotEmpty(t, resp.FlowID)
+}
+
+func TestChallengeWithWallet_InvalidAddress(t *testing.T) {
+	// The address is refused before anything is rate limited or saved
+	u := &userUseCase{}
+
+	_, derr := u.ChallengeWithWallet(context.Background(), uuid.New(), "not-an-address")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_INVALID_WALLET_ADDRESS", derr.Code)
+}
+
+func TestChallengeWithWallet_RefusedWithoutAllowedDomains(t *testing.T) {
+	previousDomains := conf.GetConfiguration().SIWE.AllowedDomains
+	conf.GetConfiguration().SIWE.AllowedDomains = ""
+	t.Cleanup(func() { conf.GetConfiguration().SIWE.AllowedDomains = previousDomains })
+
+	u := &userUseCase{}
+
+	_, derr := u.ChallengeWithWallet(context.Background(), uuid.New(), "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_WALLET_NOT_CONFIGURED", derr.Code)
+}
+
+func TestVerifyWallet_ExpiredChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useSIWEDomain(t)
+	ctx := context.Background()
+	wallet := newTestWallet(t)
+	message := wallet.message("0123456789abcdef")
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").Return(nil, nil)
+
+	u := &userUseCase{rateLimiter: rateLimiter, challengeSessionRepo: challengeRepo}
+
+	_, derr := u.VerifyWallet(ctx, uuid.New(), "flow-1", message, wallet.sign(message), "")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_CHALLENGE_SESSION_NOT_FOUND", derr.Code)
+	assert.Equal(t, domainerrors.ErrorTypeNotFound, derr.Type)
+}
+
+func TestVerifyWallet_Rejections(t *testing.T) {
+	wallet := newTestWallet(t)
+	other := newTestWallet(t)
+	nonce := "0123456789abcdef"
+	message := wallet.message(nonce)
+
+	tests := []struct {
+		name      string
+		message   string
+		signature string
+		wantCode  string
+	}{
+		{"nonce mismatch", wallet.message("fedcba9876543210"), wallet.sign(wallet.message("fedcba9876543210")), "MSG_SIWE_MESSAGE_MISMATCH"},
+		{"other wallet's message", other.message(nonce), other.sign(other.message(nonce)), "MSG_SIWE_MESSAGE_MISMATCH"},
+		{"signed by another key", message, other.sign(message), "MSG_INVALID_SIGNATURE"},
+		{"malformed message", "hello", wallet.sign("hello"), "MSG_INVALID_SIWE_MESSAGE"},
+		{"relayed from another site", wallet.messageFor("phish.example.net", nonce), wallet.sign(wallet.messageFor("phish.example.net", nonce)), "MSG_SIWE_DOMAIN_NOT_ALLOWED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			useSIWEDomain(t)
+			ctx := context.Background()
+
+			rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+			rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+			rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+			challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").Return(&domain.ChallengeSession{
+				ChallengeType:  constants.ChallengeTypeWallet,
+				IdentifierType: constants.IdentifierWallet.String(),
+				Identifier:     wallet.address,
+				OTP:            nonce,
+			}, nil)
+
+			u := &userUseCase{rateLimiter: rateLimiter, challengeSessionRepo: challengeRepo}
+
+			_, derr := u.VerifyWallet(ctx, uuid.New(), "flow-1", tt.message, tt.signature, "")
+			require.NotNil(t, derr)
+			assert.Equal(t, tt.wantCode, derr.Code)
+		})
+	}
+}
+
+func TestVerifyWallet_ConsumesNonce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useSIWEDomain(t)
+	ctx := context.Background()
+	wallet := newTestWallet(t)
+	nonce := "0123456789abcdef"
+	message := wallet.message(nonce)
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").Return(&domain.ChallengeSession{
+		ChallengeType: constants.ChallengeTypeWallet,
+		Identifier:    wallet.address,
+		OTP:           nonce,
+	}, nil)
+	challengeRepo.EXPECT().DeleteChallenge(ctx, "flow-1").Return(nil)
+
+	// No credential secret is configured in tests, so the flow stops right after the nonce is consumed
+	u := &userUseCase{rateLimiter: rateLimiter, challengeSessionRepo: challengeRepo}
+
+	_, derr := u.VerifyWallet(ctx, uuid.New(), "flow-1", message, wallet.sign(message), "")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_WALLET_NOT_CONFIGURED", derr.Code)
+}
+
+func TestVerifyWallet_InviteOnlyTenantRefusesNewWallet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := conf.GetConfiguration()
+	previousDomains, previousSecret := cfg.SIWE.AllowedDomains, cfg.SIWE.CredentialSecret
+	cfg.SIWE.AllowedDomains, cfg.SIWE.CredentialSecret = "app.example.com", "siwe-secret"
+	t.Cleanup(func() { cfg.SIWE.AllowedDomains, cfg.SIWE.CredentialSecret = previousDomains, previousSecret })
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	wallet := newTestWallet(t)
+	nonce := "0123456789abcdef"
+	message := wallet.message(nonce)
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	challengeRepo := mock_repositories.NewMockChallengeSessionRepository(ctrl)
+	challengeRepo.EXPECT().GetChallenge(ctx, "flow-1").Return(&domain.ChallengeSession{
+		ChallengeType: constants.ChallengeTypeWallet,
+		Identifier:    wallet.address,
+		OTP:           nonce,
+	}, nil)
+	challengeRepo.EXPECT().DeleteChallenge(ctx, "flow-1").Return(nil)
+
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	identityRepo.EXPECT().GetByTypeAndValue(ctx, nil, tenantID.String(), constants.IdentifierWallet.String(), wallet.address).
+		Return(nil, gorm.ErrRecordNotFound)
+
+	tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+	tenantRepo.EXPECT().GetByID(tenantID).Return(&domain.Tenant{ID: tenantID, Name: "acme"}, nil)
+
+	invitationRepo := mock_repositories.NewMockUserInvitationRepository(ctrl)
+	invitationRepo.EXPECT().GetOpen(ctx, tenantID.String(), constants.IdentifierWallet.String(), wallet.address).Return(nil, nil)
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(&domain.TenantSetting{InviteOnlyRegistration: true}, nil)
+
+	// No Kratos identity is created
+	u := &userUseCase{
+		rateLimiter:          rateLimiter,
+		challengeSessionRepo: challengeRepo,
+		tenantRepo:           tenantRepo,
+		tenantSettingRepo:    settingRepo,
+		userIdentityRepo:     identityRepo,
+		userInvitationRepo:   invitationRepo,
+		kratosService:        mock_services.NewMockKratosService(ctrl),
+	}
+
+	resp, derr := u.VerifyWallet(ctx, tenantID, "flow-1", message, wallet.sign(message), "")
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_REGISTRATION_INVITE_ONLY", derr.Code)
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/lifenetwork-ai/iam-service/constants"
 	"github.com/lifenetwork-ai/iam-service/internal/delivery/dto"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -192,7 +193,7 @@ func TestIntegration_AdminUseCase_UserIdentityManagement(t *testing.T) {
 			NewIdentifier:      "+84312345678", // Valid phone format (VN)
 		}
 
-		resp, derr := adminUcase.AddIdentifierAdmin(ctx, tenantID, req)
+		resp, derr := adminUcase.AddIdentifierAdmin(ctx, tenantID, req, "support-admin")
 		require.Nil(t, derr)
 		require.NotNil(t, resp)
 		require.Equal(t, verifyResp.User.GlobalUserID, resp.GlobalUserID)
@@ -202,5 +203,14 @@ func TestIntegration_AdminUseCase_UserIdentityManagement(t *testing.T) {
 		identities, err := deps.userIdentityRepo.GetByGlobalUserIDAndTenantID(ctx, nil, verifyResp.User.GlobalUserID, tenantID.String())
 		require.Nil(t, err)
 		require.Len(t, identities, 2) // existing + new
+
+		// The history names the administrator who added it
+		logs, err := deps.changeLogRepo.ListByGlobalUserID(ctx, uuid.MustParse(verifyResp.User.GlobalUserID))
+		require.NoError(t, err)
+		require.Len(t, logs, 2) // registration + admin addition
+		require.Equal(t, constants.IdentityChangeActionAdd, logs[0].Action)
+		require.Equal(t, constants.IdentityChangeActorAdmin, logs[0].Actor)
+		require.Equal(t, "support-admin", logs[0].ActorID)
+		require.Equal(t, "+84312345678", logs[0].NewValue)
 	})
 }
@@ -49,7 +49,7 @@ func TestIntegration_RateLimit_Paths(t *testing.T) {
 	require.Equal(t, "MSG_RATE_LIMIT_EXCEEDED", derr.Code)
 
 	// VerifyLogin should fail with rate-limit
-	auth2, derr := ucase.VerifyLogin(ctx, tenantID, "flow2", "000000")
+	auth2, derr := ucase.VerifyLogin(ctx, tenantID, "flow2", "000000", false)
 	require.NotNil(t, derr)
 	require.Nil(t, auth2)
 	require.Equal(t, "MSG_RATE_LIMIT_EXCEEDED", derr.Code)
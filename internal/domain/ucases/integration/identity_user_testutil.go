@@ -21,6 +21,7 @@ import (
 
 	"github.com/docker/go-connections/nat"
 	"github.com/google/uuid"
+	"github.com/lifenetwork-ai/iam-service/constants"
 	"github.com/lifenetwork-ai/iam-service/infrastructures/caching"
 	adaptersrepo "github.com/lifenetwork-ai/iam-service/internal/adapters/repositories"
 	kratos_service "github.com/lifenetwork-ai/iam-service/internal/adapters/services/kratos"
@@ -45,6 +46,18 @@ type testDeps struct {
 	userIdentityRepo          domainrepo.UserIdentityRepository
 	userIdentifierMappingRepo domainrepo.UserIdentifierMappingRepository
 	challengeSessionRepo      domainrepo.ChallengeSessionRepository
+	tenantSettingRepo         domainrepo.TenantSettingRepository
+	sessionRefreshTokenRepo   domainrepo.SessionRefreshTokenRepository
+	userMFARepo               domainrepo.UserMFARepository
+	userPasskeyRepo           domainrepo.UserPasskeyRepository
+	userSessionRepo           domainrepo.UserSessionRepository
+	changeLogRepo             domainrepo.UserIdentityChangeLogRepository
+	userAccountStatusRepo     domainrepo.UserAccountStatusRepository
+	userInvitationRepo        domainrepo.UserInvitationRepository
+	identifierLockoutRepo     domainrepo.IdentifierLockoutRepository
+	trustedDeviceRepo         domainrepo.TrustedDeviceRepository
+	loginEventRepo            domainrepo.LoginEventRepository
+	identifierQuarantineRepo  domainrepo.IdentifierQuarantineRepository
 	kratosService             domainservice.KratosService
 	rateLimiter               *mock_rl_types.MockRateLimiter
 }
@@ -133,33 +146,74 @@ func startPostgresAndBuildUCase(t *testing.T, ctx context.Context, ctrl *gomock.
 	inMemCache := caching.NewCachingRepository(context.Background(), caching.NewGoCacheClient(cache.New(5*time.Minute, 10*time.Minute)))
 	deps := testDeps{}
 	deps.cacheRepo = mock_cache_types.NewMockCacheRepository(ctrl)
+	// Starting a challenge records when its code may be resent
+	deps.cacheRepo.EXPECT().SaveItem(gomock.Any(), gomock.Any(), constants.ChallengeResendCooldown).Return(nil).AnyTimes()
 	deps.tenantRepo = adaptersrepo.NewTenantRepository(db)
 	deps.globalUserRepo = adaptersrepo.NewGlobalUserRepository(db)
 	deps.userIdentityRepo = adaptersrepo.NewUserIdentityRepository(db)
 	deps.userIdentifierMappingRepo = adaptersrepo.NewUserIdentifierMappingRepository(db)
 	deps.challengeSessionRepo = adaptersrepo.NewChallengeSessionRepository(inMemCache)
+	deps.tenantSettingRepo = adaptersrepo.NewTenantSettingRepository(db)
+	deps.sessionRefreshTokenRepo = adaptersrepo.NewSessionRefreshTokenRepository(db)
+	deps.userMFARepo = adaptersrepo.NewUserMFARepository(db)
+	deps.userPasskeyRepo = adaptersrepo.NewUserPasskeyRepository(db)
+	deps.userSessionRepo = adaptersrepo.NewUserSessionRepository(db)
+	deps.changeLogRepo = adaptersrepo.NewUserIdentityChangeLogRepository(db)
+	deps.userAccountStatusRepo = adaptersrepo.NewUserAccountStatusRepository(db)
+	deps.userInvitationRepo = adaptersrepo.NewUserInvitationRepository(db)
+	deps.identifierLockoutRepo = adaptersrepo.NewIdentifierLockoutRepository(db)
+	deps.trustedDeviceRepo = adaptersrepo.NewTrustedDeviceRepository(db)
+	deps.loginEventRepo = adaptersrepo.NewLoginEventRepository(db)
+	deps.identifierQuarantineRepo = adaptersrepo.NewIdentifierQuarantineRepository(db)
 	deps.kratosService = kratos_service.NewFakeKratosService()
 	deps.rateLimiter = mock_rl_types.NewMockRateLimiter(ctrl)
 
 	ucase := ucases.NewIdentityUserUseCase(
 		db,
 		deps.cacheRepo,
+		nil,
 		deps.rateLimiter,
 		deps.challengeSessionRepo,
 		deps.tenantRepo,
 		deps.globalUserRepo,
 		deps.userIdentityRepo,
 		deps.userIdentifierMappingRepo,
+		deps.tenantSettingRepo,
+		deps.sessionRefreshTokenRepo,
+		deps.userMFARepo,
+		deps.userPasskeyRepo,
+		deps.userSessionRepo,
+		deps.changeLogRepo,
+		deps.userAccountStatusRepo,
+		deps.userInvitationRepo,
+		deps.identifierLockoutRepo,
+		deps.trustedDeviceRepo,
+		deps.loginEventRepo,
+		deps.identifierQuarantineRepo,
 		deps.kratosService,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	// Create admin use case
 	adminUcase := ucases.NewAdminUseCase(
+		db,
 		deps.tenantRepo,
 		adaptersrepo.NewAdminAccountRepository(db),
 		deps.userIdentityRepo,
 		deps.userIdentifierMappingRepo,
+		deps.tenantSettingRepo,
+		adaptersrepo.NewOAuthClientRepository(db),
+		deps.changeLogRepo,
+		deps.loginEventRepo,
+		deps.identifierQuarantineRepo,
 		deps.kratosService,
+		nil,
+		nil,
 	)
 	tenantID := uuid.New()
 	require.NoError(t, db.Create(&domain.Tenant{ID: tenantID, Name: seedTenantName}).Error)
@@ -218,16 +272,36 @@ func startPostgresAndBuildAdminUCase(t *testing.T, ctx context.Context, ctrl *go
 	deps.userIdentityRepo = adaptersrepo.NewUserIdentityRepository(db)
 	deps.userIdentifierMappingRepo = adaptersrepo.NewUserIdentifierMappingRepository(db)
 	deps.challengeSessionRepo = adaptersrepo.NewChallengeSessionRepository(inMemCache)
+	deps.tenantSettingRepo = adaptersrepo.NewTenantSettingRepository(db)
+	deps.sessionRefreshTokenRepo = adaptersrepo.NewSessionRefreshTokenRepository(db)
+	deps.userMFARepo = adaptersrepo.NewUserMFARepository(db)
+	deps.userPasskeyRepo = adaptersrepo.NewUserPasskeyRepository(db)
+	deps.userSessionRepo = adaptersrepo.NewUserSessionRepository(db)
+	deps.changeLogRepo = adaptersrepo.NewUserIdentityChangeLogRepository(db)
+	deps.userAccountStatusRepo = adaptersrepo.NewUserAccountStatusRepository(db)
+	deps.userInvitationRepo = adaptersrepo.NewUserInvitationRepository(db)
+	deps.identifierLockoutRepo = adaptersrepo.NewIdentifierLockoutRepository(db)
+	deps.trustedDeviceRepo = adaptersrepo.NewTrustedDeviceRepository(db)
+	deps.loginEventRepo = adaptersrepo.NewLoginEventRepository(db)
+	deps.identifierQuarantineRepo = adaptersrepo.NewIdentifierQuarantineRepository(db)
 	deps.kratosService = kratos_service.NewFakeKratosService()
 	deps.rateLimiter = mock_rl_types.NewMockRateLimiter(ctrl)
 
 	// Create admin use case
 	adminUcase := ucases.NewAdminUseCase(
+		db,
 		deps.tenantRepo,
 		adaptersrepo.NewAdminAccountRepository(db),
 		deps.userIdentityRepo,
 		deps.userIdentifierMappingRepo,
+		deps.tenantSettingRepo,
+		adaptersrepo.NewOAuthClientRepository(db),
+		deps.changeLogRepo,
+		deps.loginEventRepo,
+		deps.identifierQuarantineRepo,
 		deps.kratosService,
+		nil,
+		nil,
 	)
 	tenantID := uuid.New()
 	require.NoError(t, db.Create(&domain.Tenant{ID: tenantID, Name: seedTenantName}).Error)
@@ -283,32 +357,73 @@ func startPostgresAndBuildUCaseWithKratos(t *testing.T, ctx context.Context, ctr
 
 	deps := testDeps{}
 	deps.cacheRepo = mock_cache_types.NewMockCacheRepository(ctrl)
+	// Starting a challenge records when its code may be resent
+	deps.cacheRepo.EXPECT().SaveItem(gomock.Any(), gomock.Any(), constants.ChallengeResendCooldown).Return(nil).AnyTimes()
 	deps.tenantRepo = adaptersrepo.NewTenantRepository(db)
 	deps.globalUserRepo = adaptersrepo.NewGlobalUserRepository(db)
 	deps.userIdentityRepo = adaptersrepo.NewUserIdentityRepository(db)
 	deps.userIdentifierMappingRepo = adaptersrepo.NewUserIdentifierMappingRepository(db)
 	deps.challengeSessionRepo = adaptersrepo.NewChallengeSessionRepository(inMemCache)
+	deps.tenantSettingRepo = adaptersrepo.NewTenantSettingRepository(db)
+	deps.sessionRefreshTokenRepo = adaptersrepo.NewSessionRefreshTokenRepository(db)
+	deps.userMFARepo = adaptersrepo.NewUserMFARepository(db)
+	deps.userPasskeyRepo = adaptersrepo.NewUserPasskeyRepository(db)
+	deps.userSessionRepo = adaptersrepo.NewUserSessionRepository(db)
+	deps.changeLogRepo = adaptersrepo.NewUserIdentityChangeLogRepository(db)
+	deps.userAccountStatusRepo = adaptersrepo.NewUserAccountStatusRepository(db)
+	deps.userInvitationRepo = adaptersrepo.NewUserInvitationRepository(db)
+	deps.identifierLockoutRepo = adaptersrepo.NewIdentifierLockoutRepository(db)
+	deps.trustedDeviceRepo = adaptersrepo.NewTrustedDeviceRepository(db)
+	deps.loginEventRepo = adaptersrepo.NewLoginEventRepository(db)
+	deps.identifierQuarantineRepo = adaptersrepo.NewIdentifierQuarantineRepository(db)
 	deps.kratosService = kratosSvc
 	deps.rateLimiter = mock_rl_types.NewMockRateLimiter(ctrl)
 
 	ucase := ucases.NewIdentityUserUseCase(
 		db,
 		deps.cacheRepo,
+		nil,
 		deps.rateLimiter,
 		deps.challengeSessionRepo,
 		deps.tenantRepo,
 		deps.globalUserRepo,
 		deps.userIdentityRepo,
 		deps.userIdentifierMappingRepo,
+		deps.tenantSettingRepo,
+		deps.sessionRefreshTokenRepo,
+		deps.userMFARepo,
+		deps.userPasskeyRepo,
+		deps.userSessionRepo,
+		deps.changeLogRepo,
+		deps.userAccountStatusRepo,
+		deps.userInvitationRepo,
+		deps.identifierLockoutRepo,
+		deps.trustedDeviceRepo,
+		deps.loginEventRepo,
+		deps.identifierQuarantineRepo,
 		deps.kratosService,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	adminUcase := ucases.NewAdminUseCase(
+		db,
 		deps.tenantRepo,
 		adaptersrepo.NewAdminAccountRepository(db),
 		deps.userIdentityRepo,
 		deps.userIdentifierMappingRepo,
+		deps.tenantSettingRepo,
+		adaptersrepo.NewOAuthClientRepository(db),
+		deps.changeLogRepo,
+		deps.loginEventRepo,
+		deps.identifierQuarantineRepo,
 		deps.kratosService,
+		nil,
+		nil,
 	)
 
 	tenantID := uuid.New()
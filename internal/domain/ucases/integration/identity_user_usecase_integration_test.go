@@ -448,7 +448,7 @@ func TestIntegration_VerifyLogin_GetFlowFails(t *testing.T) {
 	fake := deps.kratosService.(*kratos_service.FakeKratosService)
 	fake.SetFaults(kratos_service.Faults{NetworkError: true})
 
-	auth, derr := ucase.VerifyLogin(ctx, tenantID, "any-flow", "000000")
+	auth, derr := ucase.VerifyLogin(ctx, tenantID, "any-flow", "000000", false)
 	require.NotNil(t, derr)
 	require.Nil(t, auth)
 	require.Equal(t, "MSG_GET_FLOW_FAILED", derr.Code)
@@ -654,7 +654,7 @@ func TestIntegration_ChallengeEmail_Then_VerifyLogin_Succeeds(t *testing.T) {
 	require.NotNil(t, chall)
 
 	// VerifyLogin with the flow id
-	auth, derr := ucase.VerifyLogin(ctx, tenantID, chall.FlowID, "000000")
+	auth, derr := ucase.VerifyLogin(ctx, tenantID, chall.FlowID, "000000", false)
 	require.Nil(t, derr)
 	require.NotNil(t, auth)
 	require.True(t, auth.Active)
@@ -811,7 +811,7 @@ func TestIntegration_VerifyLogin_WithEmail(t *testing.T) {
 	require.NotEmpty(t, chall.FlowID)
 
 	// Verify login with code
-	auth, derr := ucase.VerifyLogin(ctx, tenantID, chall.FlowID, "000000")
+	auth, derr := ucase.VerifyLogin(ctx, tenantID, chall.FlowID, "000000", false)
 	require.Nil(t, derr)
 	require.NotNil(t, auth)
 	require.True(t, auth.Active)
@@ -1000,7 +1000,7 @@ func TestIntegration_DeletePhone_ReRegisterSamePhone_Then_EmailLogin_Succeeds(t
 	chall, derr := ucase.ChallengeWithEmail(ctx, tenantID, email)
 	require.Nil(t, derr)
 	require.NotNil(t, chall)
-	auth, derr := ucase.VerifyLogin(ctx, tenantID, chall.FlowID, "000000")
+	auth, derr := ucase.VerifyLogin(ctx, tenantID, chall.FlowID, "000000", false)
 	require.Nil(t, derr)
 	require.NotNil(t, auth)
 	require.True(t, auth.Active)
@@ -0,0 +1,36 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+)
+
+// AccountDeletionUseCase erases users on request. A deletion signs the user out right away and
+// erases the account once the tenant's grace period has passed, unless it is cancelled first.
+type AccountDeletionUseCase interface {
+	// ChallengeAccountDeletion sends a code confirming the deletion to the user's email or phone
+	ChallengeAccountDeletion(ctx context.Context, tenantID uuid.UUID, user *types.IdentityUserResponse) (*types.IdentityUserChallengeResponse, *domainerrors.DomainError)
+
+	// RequestAccountDeletion checks the code and schedules the user's deletion
+	RequestAccountDeletion(ctx context.Context, tenantID uuid.UUID, user *types.IdentityUserResponse, flowID, code string) (*types.AccountDeletionResponse, *domainerrors.DomainError)
+
+	// GetAccountDeletion returns the user's pending deletion
+	GetAccountDeletion(ctx context.Context, tenantID uuid.UUID, globalUserID string) (*types.AccountDeletionResponse, *domainerrors.DomainError)
+
+	// CancelAccountDeletion cancels the user's pending deletion while the grace period lasts
+	CancelAccountDeletion(ctx context.Context, tenantID uuid.UUID, globalUserID string) *domainerrors.DomainError
+
+	// ScheduleAccountDeletion deletes a user on an administrator's behalf, after the grace period
+	// or, when immediate is set, on the next run of the deletion worker
+	ScheduleAccountDeletion(ctx context.Context, tenantID uuid.UUID, globalUserID, requestedBy string, immediate bool) (*types.AccountDeletionResponse, *domainerrors.DomainError)
+
+	// ListAccountDeletions returns the tenant's deletions, newest first
+	ListAccountDeletions(ctx context.Context, tenantID uuid.UUID) ([]*types.AccountDeletionResponse, *domainerrors.DomainError)
+
+	// ProcessDueAccountDeletions erases the accounts whose grace period has passed, returning how
+	// many were erased. A deletion that fails part way is retried later and picks up where it stopped.
+	ProcessDueAccountDeletions(ctx context.Context) (int, *domainerrors.DomainError)
+}
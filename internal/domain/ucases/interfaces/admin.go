@@ -2,12 +2,14 @@ package interfaces
 
 import (
 	"context"
+	"io"
 
 	"github.com/google/uuid"
 	"github.com/lifenetwork-ai/iam-service/internal/delivery/dto"
 	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
 	"github.com/lifenetwork-ai/iam-service/internal/domain/types"
 	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	ucasetypes "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
 )
 
 // AdminUseCase defines the interface for administrative operations
@@ -21,7 +23,19 @@ type AdminUseCase interface {
 	CreateTenant(ctx context.Context, name, publicURL, adminURL string) (*domain.Tenant, *domainerrors.DomainError)
 	UpdateTenant(ctx context.Context, id, name, publicURL, adminURL string) (*domain.Tenant, *domainerrors.DomainError)
 	DeleteTenant(ctx context.Context, id string) (*domain.Tenant, *domainerrors.DomainError)
+	GetTenantSetting(ctx context.Context, id string) (*domain.TenantSetting, *domainerrors.DomainError)
+	UpdateTenantSetting(ctx context.Context, id string, req dto.UpdateTenantSettingPayloadDTO) (*domain.TenantSetting, *domainerrors.DomainError)
+	// OAuth clients
+	CreateOAuthClient(ctx context.Context, id string, req dto.CreateOAuthClientPayloadDTO) (*dto.OAuthClientCredentialsDTO, *domainerrors.DomainError)
+	ListOAuthClients(ctx context.Context, id string) ([]*domain.OAuthClient, *domainerrors.DomainError)
+	RevokeOAuthClient(ctx context.Context, id, clientID string) *domainerrors.DomainError
 	// User Identity Management
 	CheckIdentifierAdmin(ctx context.Context, tenantID uuid.UUID, identifier string) (bool, string, *domainerrors.DomainError)
-	AddIdentifierAdmin(ctx context.Context, tenantID uuid.UUID, req dto.AdminAddIdentifierPayloadDTO) (*dto.AdminAddIdentifierResponse, *domainerrors.DomainError)
+	AddIdentifierAdmin(ctx context.Context, tenantID uuid.UUID, req dto.AdminAddIdentifierPayloadDTO, adminUsername string) (*dto.AdminAddIdentifierResponse, *domainerrors.DomainError)
+	// User directory
+	ListTenantUsers(ctx context.Context, tenantID uuid.UUID, query ucasetypes.TenantUserQuery) (*ucasetypes.TenantUserPage, *domainerrors.DomainError)
+	GetTenantUser(ctx context.Context, tenantID uuid.UUID, globalUserID string) (*ucasetypes.TenantUserDetail, *domainerrors.DomainError)
+	// ExportTenantUsers writes every user of the tenant to w as CSV or NDJSON. Nothing is written
+	// when the export is refused.
+	ExportTenantUsers(ctx context.Context, tenantID uuid.UUID, query ucasetypes.TenantUserExportQuery, w io.Writer) *domainerrors.DomainError
 }
@@ -12,6 +12,7 @@ type CourierUseCase interface {
 	ReceiveOTP(ctx context.Context, receiver, body string) *domainerrors.DomainError
 	GetAvailableChannels(ctx context.Context, tenantName, receiver string) []string
 	DeliverOTP(ctx context.Context, tenantName, receiver string) *domainerrors.DomainError
+	DeliverMessage(ctx context.Context, tenantName, receiver, message string) *domainerrors.DomainError
 	RetryFailedOTPs(ctx context.Context, now time.Time) (int, *domainerrors.DomainError)
 	ChooseChannel(ctx context.Context, tenantName, receiver, channel string) *domainerrors.DomainError
 	GetChannel(ctx context.Context, tenantName, receiver string) (types.ChooseChannelResponse, *domainerrors.DomainError)
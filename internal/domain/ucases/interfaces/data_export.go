@@ -0,0 +1,26 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+)
+
+// DataExportUseCase gives users a machine-readable copy of their data. Exports are generated in
+// the background and downloaded through short-lived signed links.
+type DataExportUseCase interface {
+	// RequestDataExport queues an export of the user's data, or returns the one already queued
+	RequestDataExport(ctx context.Context, tenantID uuid.UUID, globalUserID string) (*types.DataExportResponse, *domainerrors.DomainError)
+
+	// GetDataExport returns one of the user's exports, with a download link once it is ready
+	GetDataExport(ctx context.Context, tenantID uuid.UUID, globalUserID, exportID string) (*types.DataExportResponse, *domainerrors.DomainError)
+
+	// DownloadDataExport checks a download link and returns the export's JSON document
+	DownloadDataExport(ctx context.Context, exportID string, expires int64, signature string) ([]byte, *domainerrors.DomainError)
+
+	// ProcessPendingDataExports generates the queued exports and deletes the expired ones,
+	// returning how many were generated
+	ProcessPendingDataExports(ctx context.Context) (int, *domainerrors.DomainError)
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+)
+
+// IdentifierLockoutUseCase lets administrators see and lift the lockouts of emails and phone
+// numbers that had too many wrong OTP codes entered for them.
+type IdentifierLockoutUseCase interface {
+	// ListIdentifierLockouts returns the tenant's identifiers that are locked out now
+	ListIdentifierLockouts(ctx context.Context, tenantID uuid.UUID) ([]*types.IdentifierLockoutResponse, *domainerrors.DomainError)
+
+	// UnlockIdentifier lifts the identifier's lockout and forgets its wrong codes
+	UnlockIdentifier(ctx context.Context, tenantID uuid.UUID, identifier, unlockedBy string) *domainerrors.DomainError
+}
@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	domaintypes "github.com/lifenetwork-ai/iam-service/internal/domain/types"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+)
+
+// IdentifierQuarantineUseCase lets administrators see the emails and phone numbers quarantined
+// after they were taken off an account, and release them before the quarantine ends.
+type IdentifierQuarantineUseCase interface {
+	// ListQuarantinedIdentifiers returns a page of the tenant's quarantined identifiers, or the
+	// quarantine of a single identifier when one is given
+	ListQuarantinedIdentifiers(ctx context.Context, tenantID uuid.UUID, identifier string, page, size int) (*domaintypes.PaginatedResponse[*types.IdentifierQuarantineResponse], *domainerrors.DomainError)
+
+	// ReleaseIdentifier ends a quarantine so that any account may register the identifier
+	ReleaseIdentifier(ctx context.Context, tenantID uuid.UUID, quarantineID, releasedBy string) *domainerrors.DomainError
+}
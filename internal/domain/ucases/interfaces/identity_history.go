@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	domaintypes "github.com/lifenetwork-ai/iam-service/internal/domain/types"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+)
+
+// IdentityHistoryUseCase reads the history of changes to users' identifiers and language
+type IdentityHistoryUseCase interface {
+	// ListUserIdentityHistory returns the user's history in the tenant, newest first
+	ListUserIdentityHistory(ctx context.Context, tenantID uuid.UUID, globalUserID string, query types.IdentityHistoryQuery) (*domaintypes.PaginatedResponse[*types.IdentityHistoryEntry], *domainerrors.DomainError)
+
+	// ListTenantIdentityHistory returns the history of every user of the tenant, newest first
+	ListTenantIdentityHistory(ctx context.Context, tenantID uuid.UUID, query types.IdentityHistoryQuery) (*domaintypes.PaginatedResponse[*types.IdentityHistoryEntry], *domainerrors.DomainError)
+}
@@ -29,6 +29,15 @@ type IdentityUserUseCase interface {
 		phone string,
 	) (*types.IdentityUserAuthResponse, *errors.DomainError)
 
+	// ResendChallenge sends a new code for an ongoing challenge, optionally through another
+	// channel for a phone number, instead of starting a new challenge
+	ResendChallenge(
+		ctx context.Context,
+		tenantID uuid.UUID,
+		flowID string,
+		channel string,
+	) (*types.IdentityUserResendResponse, *errors.DomainError)
+
 	VerifyRegister(
 		ctx context.Context,
 		tenantID uuid.UUID,
@@ -36,11 +45,14 @@ type IdentityUserUseCase interface {
 		code string,
 	) (*types.IdentityUserAuthResponse, *errors.DomainError)
 
+	// VerifyLogin completes a code sign-in. With rememberDevice the client becomes a trusted
+	// device once the sign-in is complete, if the tenant allows it.
 	VerifyLogin(
 		ctx context.Context,
 		tenantID uuid.UUID,
 		flowID string,
 		code string,
+		rememberDevice bool,
 	) (*types.IdentityUserAuthResponse, *errors.DomainError)
 
 	Login(
@@ -50,11 +62,151 @@ type IdentityUserUseCase interface {
 		password string,
 	) (*types.IdentityUserAuthResponse, *errors.DomainError)
 
+	SetPassword(
+		ctx context.Context,
+		tenantID uuid.UUID,
+		password string,
+	) *errors.DomainError
+
+	ChallengePasswordRecovery(
+		ctx context.Context,
+		tenantID uuid.UUID,
+		identifier string,
+	) (*types.IdentityUserChallengeResponse, *errors.DomainError)
+
+	VerifyPasswordRecovery(
+		ctx context.Context,
+		tenantID uuid.UUID,
+		flowID string,
+		code string,
+		newPassword string,
+	) (*types.IdentityUserAuthResponse, *errors.DomainError)
+
+	LoginWithOIDC(
+		ctx context.Context,
+		tenantID uuid.UUID,
+		provider string,
+		idToken string,
+		nonce string,
+		lang string,
+	) (*types.IdentityUserAuthResponse, *errors.DomainError)
+
+	ChallengeWithWallet(
+		ctx context.Context,
+		tenantID uuid.UUID,
+		address string,
+	) (*types.WalletChallengeResponse, *errors.DomainError)
+
+	VerifyWallet(
+		ctx context.Context,
+		tenantID uuid.UUID,
+		flowID string,
+		message string,
+		signature string,
+		lang string,
+	) (*types.IdentityUserAuthResponse, *errors.DomainError)
+
+	ChallengeWithPasskey(
+		ctx context.Context,
+		tenantID uuid.UUID,
+	) (*types.PasskeyChallengeResponse, *errors.DomainError)
+
+	VerifyPasskey(
+		ctx context.Context,
+		tenantID uuid.UUID,
+		flowID string,
+		credential []byte,
+	) (*types.IdentityUserAuthResponse, *errors.DomainError)
+
+	VerifyMFA(
+		ctx context.Context,
+		tenantID uuid.UUID,
+		flowID string,
+		code string,
+	) (*types.IdentityUserAuthResponse, *errors.DomainError)
+
+	EnrollTOTP(
+		ctx context.Context,
+		tenantID uuid.UUID,
+		globalUserID string,
+		accountName string,
+	) (*types.TOTPEnrollmentResponse, *errors.DomainError)
+
+	ActivateTOTP(
+		ctx context.Context,
+		tenantID uuid.UUID,
+		globalUserID string,
+		code string,
+	) (*types.MFARecoveryCodesResponse, *errors.DomainError)
+
+	DisableTOTP(
+		ctx context.Context,
+		tenantID uuid.UUID,
+		globalUserID string,
+		code string,
+	) *errors.DomainError
+
+	BeginPasskeyRegistration(
+		ctx context.Context,
+		tenantID uuid.UUID,
+		globalUserID string,
+		accountName string,
+	) (*types.PasskeyChallengeResponse, *errors.DomainError)
+
+	FinishPasskeyRegistration(
+		ctx context.Context,
+		tenantID uuid.UUID,
+		globalUserID string,
+		flowID string,
+		name string,
+		credential []byte,
+	) (*types.PasskeyResponse, *errors.DomainError)
+
+	ListPasskeys(
+		ctx context.Context,
+		tenantID uuid.UUID,
+		globalUserID string,
+	) ([]*types.PasskeyResponse, *errors.DomainError)
+
+	DeletePasskey(
+		ctx context.Context,
+		tenantID uuid.UUID,
+		globalUserID string,
+		passkeyID string,
+	) *errors.DomainError
+
 	Logout(
 		ctx context.Context,
 		tenantID uuid.UUID,
 	) *errors.DomainError
 
+	ListSessions(
+		ctx context.Context,
+		tenantID uuid.UUID,
+		globalUserID string,
+	) ([]*types.UserSessionResponse, *errors.DomainError)
+
+	RevokeSession(
+		ctx context.Context,
+		tenantID uuid.UUID,
+		globalUserID string,
+		sessionID string,
+	) *errors.DomainError
+
+	RevokeOtherSessions(
+		ctx context.Context,
+		tenantID uuid.UUID,
+		globalUserID string,
+	) (*types.SessionsRevokedResponse, *errors.DomainError)
+
+	SessionCacheStats() *types.SessionCacheStats
+
+	IntrospectToken(
+		ctx context.Context,
+		tenantID uuid.UUID,
+		token string,
+	) (*types.IntrospectionResponse, *errors.DomainError)
+
 	RefreshToken(
 		ctx context.Context,
 		tenantID uuid.UUID,
@@ -75,6 +227,15 @@ type IdentityUserUseCase interface {
 		identifierType string,
 	) (*types.IdentityUserChallengeResponse, *errors.DomainError)
 
+	LinkOIDCIdentifier(
+		ctx context.Context,
+		tenantID uuid.UUID,
+		globalUserID string,
+		provider string,
+		idToken string,
+		nonce string,
+	) (*types.IdentityLinkedResponse, *errors.DomainError)
+
 	DeleteIdentifier(
 		ctx context.Context,
 		globalUserID string,
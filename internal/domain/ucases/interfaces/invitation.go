@@ -0,0 +1,30 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lifenetwork-ai/iam-service/internal/delivery/dto"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+)
+
+// InvitationUseCase onboards users by invitation. Each invitation creates the Kratos identity up
+// front and sends a code to the identifier; accepting it with the code creates the user.
+type InvitationUseCase interface {
+	// CreateInvitation invites an email or phone number that is not registered in the tenant yet
+	CreateInvitation(ctx context.Context, tenantID uuid.UUID, req dto.CreateInvitationPayloadDTO, invitedBy string) (*types.InvitationResponse, *domainerrors.DomainError)
+
+	// ListInvitations returns the tenant's invitations, newest first
+	ListInvitations(ctx context.Context, tenantID uuid.UUID) ([]*types.InvitationResponse, *domainerrors.DomainError)
+
+	// ResendInvitation sends a new code and restarts the invitation's expiry
+	ResendInvitation(ctx context.Context, tenantID uuid.UUID, invitationID string) (*types.InvitationResponse, *domainerrors.DomainError)
+
+	// RevokeInvitation withdraws an invitation that was not accepted and removes its pending identity
+	RevokeInvitation(ctx context.Context, tenantID uuid.UUID, invitationID string) *domainerrors.DomainError
+
+	// AcceptInvitation verifies the code sent to the identifier, creates the user and grants the
+	// invitation's roles
+	AcceptInvitation(ctx context.Context, tenantID uuid.UUID, identifier, code string) (*types.InvitationResponse, *domainerrors.DomainError)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	domaintypes "github.com/lifenetwork-ai/iam-service/internal/domain/types"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+)
+
+// LoginHistoryUseCase reads the history of users' logins
+type LoginHistoryUseCase interface {
+	// ListUserLogins returns a page of the user's logins in the tenant, newest first
+	ListUserLogins(ctx context.Context, tenantID uuid.UUID, globalUserID string, page, size int) (*domaintypes.PaginatedResponse[*types.LoginEventResponse], *domainerrors.DomainError)
+}
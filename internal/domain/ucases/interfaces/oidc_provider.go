@@ -0,0 +1,44 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+)
+
+// OIDCProviderUseCase lets clients registered with a tenant sign its users in through OpenID
+// Connect. Users sign in on the tenant's login page with the regular challenge flows, which then
+// accepts or rejects the authorization request.
+type OIDCProviderUseCase interface {
+	// Discovery returns the tenant's provider metadata
+	Discovery(ctx context.Context, tenantID uuid.UUID) (*types.OIDCDiscoveryDocument, *domainerrors.DomainError)
+
+	// Authorize validates an authorization request and returns where to send the browser: the
+	// tenant's login page, or the client's redirect URI with an error. An error is returned when
+	// the client or redirect URI cannot be trusted with a redirect.
+	Authorize(ctx context.Context, tenantID uuid.UUID, req *types.OIDCAuthorizeRequest) (string, *domainerrors.DomainError)
+
+	// GetAuthorizationRequest describes a pending request to the signed in user
+	GetAuthorizationRequest(ctx context.Context, tenantID uuid.UUID, user *types.IdentityUserResponse, requestID string) (*types.OIDCAuthorizationRequestResponse, *domainerrors.DomainError)
+
+	// AcceptAuthorizationRequest records the user's consent and issues an authorization code for
+	// the current session
+	AcceptAuthorizationRequest(ctx context.Context, tenantID uuid.UUID, user *types.IdentityUserResponse, requestID string) (*types.OIDCRedirectResponse, *domainerrors.DomainError)
+
+	// RejectAuthorizationRequest sends the user back to the client with access_denied
+	RejectAuthorizationRequest(ctx context.Context, tenantID uuid.UUID, user *types.IdentityUserResponse, requestID string) (*types.OIDCRedirectResponse, *domainerrors.DomainError)
+
+	// ExchangeCode redeems an authorization code for an access token and an ID token
+	ExchangeCode(ctx context.Context, tenantID uuid.UUID, req *types.OIDCTokenRequest) (*types.OIDCTokenResponse, *domainerrors.DomainError)
+
+	// UserInfo returns the claims of the user an access token issued by the provider belongs to
+	UserInfo(ctx context.Context, tenantID uuid.UUID, accessToken string) (*types.OIDCUserInfoResponse, *domainerrors.DomainError)
+
+	// ListConsents returns the clients the user allowed to sign them in
+	ListConsents(ctx context.Context, tenantID uuid.UUID, user *types.IdentityUserResponse) ([]*types.OAuthConsentResponse, *domainerrors.DomainError)
+
+	// RevokeConsent makes the client ask for consent again next time
+	RevokeConsent(ctx context.Context, tenantID uuid.UUID, user *types.IdentityUserResponse, clientID string) *domainerrors.DomainError
+}
@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+)
+
+// SecurityNotificationUseCase tells users about sensitive changes to their account and lets them
+// freeze it when they did not make the change
+type SecurityNotificationUseCase interface {
+	// Notify sends the notice to every identifier of the user, and to the one the change took off
+	// the account. It returns straight away: the notice is sent in the background, outliving the
+	// request, and one that cannot be sent is only logged.
+	Notify(ctx context.Context, tenantID uuid.UUID, notice types.SecurityNotice)
+
+	// ReportUnrecognized redeems the report token of a notice and suspends the account until an
+	// administrator reviews it, signing the user out everywhere
+	ReportUnrecognized(ctx context.Context, token string) (*types.SecurityReportResponse, *domainerrors.DomainError)
+}
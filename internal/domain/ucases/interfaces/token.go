@@ -0,0 +1,27 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+)
+
+type TokenUseCase interface {
+	// IssueAccessToken exchanges the current session for a signed access token
+	IssueAccessToken(ctx context.Context, tenantID uuid.UUID, user *types.IdentityUserResponse) (*types.AccessTokenResponse, *domainerrors.DomainError)
+
+	// JWKS returns the public keys access tokens can be verified with
+	JWKS(ctx context.Context) (*types.JSONWebKeySet, *domainerrors.DomainError)
+
+	// ListSigningKeys returns the published signing keys
+	ListSigningKeys(ctx context.Context) ([]*types.SigningKeyResponse, *domainerrors.DomainError)
+
+	// RotateSigningKey schedules a new signing key to take over
+	RotateSigningKey(ctx context.Context) (*types.SigningKeyResponse, *domainerrors.DomainError)
+
+	// RotateSigningKeyIfDue rotates the signing key when it is older than maxAge and reports whether it did
+	RotateSigningKeyIfDue(ctx context.Context, maxAge time.Duration) (bool, *domainerrors.DomainError)
+}
@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+)
+
+// TrustedDeviceUseCase lets users and administrators see and forget the devices a user chose to
+// remember at sign-in.
+type TrustedDeviceUseCase interface {
+	// ListTrustedDevices returns the user's trusted devices, newest first
+	ListTrustedDevices(ctx context.Context, tenantID uuid.UUID, globalUserID string) ([]*types.TrustedDeviceResponse, *domainerrors.DomainError)
+
+	// RevokeTrustedDevice stops trusting one of the user's devices
+	RevokeTrustedDevice(ctx context.Context, tenantID uuid.UUID, globalUserID, deviceID string) *domainerrors.DomainError
+
+	// RevokeTrustedDevices stops trusting every device of the user
+	RevokeTrustedDevices(ctx context.Context, tenantID uuid.UUID, globalUserID string) (*types.TrustedDevicesRevokedResponse, *domainerrors.DomainError)
+}
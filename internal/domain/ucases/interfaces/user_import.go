@@ -0,0 +1,31 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+)
+
+// UserImportUseCase imports users into a tenant in bulk, for migrations from other systems.
+// The file is checked when it is uploaded and imported in the background.
+type UserImportUseCase interface {
+	// CreateUserImport reads a CSV or NDJSON file of email, phone and lang records and queues
+	// it. A dry run reports what would be imported without changing anything.
+	CreateUserImport(ctx context.Context, tenantID uuid.UUID, format string, file io.Reader, dryRun bool, requestedBy string) (*types.UserImportResponse, *domainerrors.DomainError)
+
+	// ListUserImports returns the tenant's imports, newest first
+	ListUserImports(ctx context.Context, tenantID uuid.UUID) ([]*types.UserImportResponse, *domainerrors.DomainError)
+
+	// GetUserImport returns an import and its progress
+	GetUserImport(ctx context.Context, tenantID uuid.UUID, importID string) (*types.UserImportResponse, *domainerrors.DomainError)
+
+	// ListUserImportErrors returns the import's failed rows after the given line
+	ListUserImportErrors(ctx context.Context, tenantID uuid.UUID, importID string, afterLine, limit int) (*types.UserImportErrorPage, *domainerrors.DomainError)
+
+	// ProcessPendingUserImports imports the next batch of rows of the pending imports, returning
+	// how many rows were processed
+	ProcessPendingUserImports(ctx context.Context) (int, *domainerrors.DomainError)
+}
@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lifenetwork-ai/iam-service/internal/delivery/dto"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+)
+
+// UserStatusUseCase lets administrators suspend, ban and reactivate a tenant's users.
+// Suspended and banned users cannot sign in, register again or use their sessions.
+type UserStatusUseCase interface {
+	// GetUserStatus returns the status in force for the user
+	GetUserStatus(ctx context.Context, tenantID uuid.UUID, globalUserID string) (*types.UserStatusResponse, *domainerrors.DomainError)
+
+	// UpdateUserStatus changes the user's status on an administrator's behalf. Suspending or
+	// banning the user signs them out of every session.
+	UpdateUserStatus(ctx context.Context, tenantID uuid.UUID, globalUserID string, req dto.UpdateUserStatusPayloadDTO, updatedBy string) (*types.UserStatusResponse, *domainerrors.DomainError)
+}
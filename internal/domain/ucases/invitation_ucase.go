@@ -0,0 +1,510 @@
+package ucases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	ratelimiters "github.com/lifenetwork-ai/iam-service/infrastructures/rate_limiter/types"
+	"github.com/lifenetwork-ai/iam-service/internal/delivery/dto"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+	"github.com/lifenetwork-ai/iam-service/packages/utils"
+)
+
+// errInvitationClosed aborts an acceptance that lost the race with another acceptance or a revocation
+var errInvitationClosed = errors.New("invitation already accepted or revoked")
+
+type invitationUseCase struct {
+	db                        *gorm.DB
+	rateLimiter               ratelimiters.RateLimiter
+	tenantRepo                domainrepo.TenantRepository
+	userInvitationRepo        domainrepo.UserInvitationRepository
+	globalUserRepo            domainrepo.GlobalUserRepository
+	userIdentityRepo          domainrepo.UserIdentityRepository
+	userIdentifierMappingRepo domainrepo.UserIdentifierMappingRepository
+	changeLogRepo             domainrepo.UserIdentityChangeLogRepository
+	identifierQuarantineRepo  domainrepo.IdentifierQuarantineRepository
+	identifierLockoutRepo     domainrepo.IdentifierLockoutRepository
+	kratosService             domainservice.KratosService
+	ketoService               domainservice.KetoService
+}
+
+func NewInvitationUseCase(
+	db *gorm.DB,
+	rateLimiter ratelimiters.RateLimiter,
+	tenantRepo domainrepo.TenantRepository,
+	userInvitationRepo domainrepo.UserInvitationRepository,
+	globalUserRepo domainrepo.GlobalUserRepository,
+	userIdentityRepo domainrepo.UserIdentityRepository,
+	userIdentifierMappingRepo domainrepo.UserIdentifierMappingRepository,
+	changeLogRepo domainrepo.UserIdentityChangeLogRepository,
+	identifierQuarantineRepo domainrepo.IdentifierQuarantineRepository,
+	identifierLockoutRepo domainrepo.IdentifierLockoutRepository,
+	kratosService domainservice.KratosService,
+	ketoService domainservice.KetoService,
+) interfaces.InvitationUseCase {
+	return &invitationUseCase{
+		db:                        db,
+		rateLimiter:               rateLimiter,
+		tenantRepo:                tenantRepo,
+		userInvitationRepo:        userInvitationRepo,
+		globalUserRepo:            globalUserRepo,
+		userIdentityRepo:          userIdentityRepo,
+		userIdentifierMappingRepo: userIdentifierMappingRepo,
+		changeLogRepo:             changeLogRepo,
+		identifierQuarantineRepo:  identifierQuarantineRepo,
+		identifierLockoutRepo:     identifierLockoutRepo,
+		kratosService:             kratosService,
+		ketoService:               ketoService,
+	}
+}
+
+// CreateInvitation creates the invitee's Kratos identity the way AddIdentifierAdmin does, then
+// sends the code that accepts the invitation
+func (u *invitationUseCase) CreateInvitation(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	req dto.CreateInvitationPayloadDTO,
+	invitedBy string,
+) (*types.InvitationResponse, *domainerrors.DomainError) {
+	idType, identifier, derr := inferAndNormalizeIdentifier(req.Identifier)
+	if derr != nil {
+		return nil, derr
+	}
+	roles, derr := normalizeInvitationRoles(req.Roles)
+	if derr != nil {
+		return nil, derr
+	}
+	lang := req.Lang
+	if lang == "" {
+		lang = constants.LangEN
+	}
+
+	tenant, err := u.tenantRepo.GetByID(tenantID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_TENANT_FAILED", "Failed to get tenant")
+	}
+	if tenant == nil {
+		return nil, domainerrors.NewNotFoundError("MSG_TENANT_NOT_FOUND", "Tenant")
+	}
+
+	exists, err := u.userIdentityRepo.ExistsWithinTenant(ctx, tenantID.String(), idType, identifier)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_IAM_LOOKUP_FAILED", "Failed to check existing identifier")
+	}
+	if exists {
+		return nil, domainerrors.NewConflictError("MSG_IDENTIFIER_ALREADY_EXISTS", "Identifier has already been registered", nil)
+	}
+	quarantine, derr := activeIdentifierQuarantine(ctx, u.identifierQuarantineRepo, tenantID, idType, identifier, "")
+	if derr != nil {
+		return nil, derr
+	}
+	if quarantine != nil {
+		return nil, identifierQuarantinedError(quarantine)
+	}
+	if derr := identifierLockout(ctx, u.identifierLockoutRepo, tenantID, identifier); derr != nil {
+		return nil, derr
+	}
+
+	open, err := u.userInvitationRepo.GetOpen(ctx, tenantID.String(), idType, identifier)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_INVITATION_FAILED", "Failed to get invitation")
+	}
+	if open != nil {
+		if open.Status(time.Now()) == constants.InvitationStatusPending {
+			return nil, domainerrors.NewConflictError(
+				"MSG_INVITATION_PENDING",
+				"The identifier already has a pending invitation; resend or revoke it",
+				map[string]string{"invitation_id": open.ID},
+			)
+		}
+		// The expired invitation still holds the identifier in Kratos
+		if err := withdrawInvitation(ctx, tenantID, open, u.userInvitationRepo, u.kratosService); err != nil {
+			return nil, domainerrors.WrapInternal(err, "MSG_REVOKE_INVITATION_FAILED", "Failed to withdraw the expired invitation")
+		}
+	}
+
+	identity, statusCode, err := u.kratosService.CreateIdentityAdmin(ctx, tenantID, map[string]interface{}{
+		"tenant": tenant.Name,
+		"lang":   lang,
+		idType:   identifier,
+	})
+	if err != nil {
+		if statusCode == http.StatusConflict {
+			return nil, domainerrors.NewConflictError("MSG_IDENTIFIER_ALREADY_EXISTS", "Identifier has already been registered", nil)
+		}
+		return nil, domainerrors.WrapInternal(err, "MSG_CREATE_IDENTITY_FAILED", "Failed to create identity")
+	}
+
+	invitation := &domain.UserInvitation{
+		TenantID:       tenantID.String(),
+		IdentifierType: idType,
+		Identifier:     identifier,
+		Lang:           lang,
+		Roles:          strings.Join(roles, " "),
+		KratosUserID:   identity.Id,
+		InvitedBy:      invitedBy,
+		ExpiresAt:      time.Now().Add(constants.InvitationTTL),
+	}
+	invitation.FlowID, derr = u.sendInvitationCode(ctx, tenantID, idType, identifier)
+	if derr == nil {
+		if err := u.userInvitationRepo.Create(ctx, invitation); err != nil {
+			derr = domainerrors.WrapInternal(err, "MSG_CREATE_INVITATION_FAILED", "Failed to create invitation")
+		}
+	}
+	if derr != nil {
+		// Without an invitation the pending identity would only block the identifier
+		if err := u.kratosService.DeleteIdentifierAdmin(ctx, tenantID, uuid.MustParse(identity.Id)); err != nil {
+			logger.GetLogger().Errorf("Failed to delete pending identity %s of tenant %s: %v", identity.Id, tenantID, err)
+		}
+		return nil, derr
+	}
+	logger.GetLogger().Infof("Invitation %s of tenant %s created by %s", invitation.ID, tenantID, invitedBy)
+
+	return toInvitationResponse(invitation, time.Now()), nil
+}
+
+// ListInvitations returns the tenant's invitations, newest first
+func (u *invitationUseCase) ListInvitations(
+	ctx context.Context,
+	tenantID uuid.UUID,
+) ([]*types.InvitationResponse, *domainerrors.DomainError) {
+	invitations, err := u.userInvitationRepo.ListByTenant(ctx, tenantID.String())
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_LIST_INVITATIONS_FAILED", "Failed to list invitations")
+	}
+
+	now := time.Now()
+	responses := make([]*types.InvitationResponse, 0, len(invitations))
+	for _, invitation := range invitations {
+		responses = append(responses, toInvitationResponse(invitation, now))
+	}
+	return responses, nil
+}
+
+// ResendInvitation sends a new code; an expired invitation becomes pending again
+func (u *invitationUseCase) ResendInvitation(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	invitationID string,
+) (*types.InvitationResponse, *domainerrors.DomainError) {
+	invitation, derr := u.getOpenInvitation(ctx, tenantID, invitationID)
+	if derr != nil {
+		return nil, derr
+	}
+
+	key := "invitation:resend:" + invitation.ID
+	if err := utils.CheckRateLimitDomain(u.rateLimiter, key, constants.MaxAttemptsPerWindow, constants.RateLimitWindow); err != nil {
+		return nil, domainerrors.NewRateLimitError("MSG_RATE_LIMIT_EXCEEDED", "Rate limit exceeded", err)
+	}
+	if derr := identifierLockout(ctx, u.identifierLockoutRepo, tenantID, invitation.Identifier); derr != nil {
+		return nil, derr
+	}
+
+	flowID, derr := u.sendInvitationCode(ctx, tenantID, invitation.IdentifierType, invitation.Identifier)
+	if derr != nil {
+		return nil, derr
+	}
+	expiresAt := time.Now().Add(constants.InvitationTTL)
+	if err := u.userInvitationRepo.Renew(ctx, invitation.ID, flowID, expiresAt); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_UPDATE_INVITATION_FAILED", "Failed to update invitation")
+	}
+	invitation.FlowID = flowID
+	invitation.ExpiresAt = expiresAt
+
+	return toInvitationResponse(invitation, time.Now()), nil
+}
+
+// RevokeInvitation withdraws the invitation so its code can no longer be used
+func (u *invitationUseCase) RevokeInvitation(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	invitationID string,
+) *domainerrors.DomainError {
+	invitation, derr := u.getOpenInvitation(ctx, tenantID, invitationID)
+	if derr != nil {
+		return derr
+	}
+
+	if err := withdrawInvitation(ctx, tenantID, invitation, u.userInvitationRepo, u.kratosService); err != nil {
+		return domainerrors.WrapInternal(err, "MSG_REVOKE_INVITATION_FAILED", "Failed to revoke invitation")
+	}
+	logger.GetLogger().Infof("Invitation %s of tenant %s revoked", invitation.ID, tenantID)
+	return nil
+}
+
+// AcceptInvitation binds the pending identity to a new user once the invitee proves they own the
+// identifier. Roles are granted afterwards; a role that fails is logged and can be granted again
+// through the permission API.
+func (u *invitationUseCase) AcceptInvitation(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	identifier string,
+	code string,
+) (*types.InvitationResponse, *domainerrors.DomainError) {
+	idType, identifier, derr := inferAndNormalizeIdentifier(identifier)
+	if derr != nil {
+		return nil, derr
+	}
+
+	key := fmt.Sprintf("invitation:accept:tenant:%s:%s", identifier, tenantID.String())
+	if err := utils.CheckRateLimitDomain(u.rateLimiter, key, constants.MaxAttemptsPerWindow, constants.RateLimitWindow); err != nil {
+		return nil, domainerrors.NewRateLimitError("MSG_RATE_LIMIT_EXCEEDED", "Rate limit exceeded", err)
+	}
+	if derr := identifierLockout(ctx, u.identifierLockoutRepo, tenantID, identifier); derr != nil {
+		return nil, derr
+	}
+
+	invitation, err := u.userInvitationRepo.GetOpen(ctx, tenantID.String(), idType, identifier)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_INVITATION_FAILED", "Failed to get invitation")
+	}
+	if invitation == nil {
+		return nil, domainerrors.NewNotFoundError("MSG_INVITATION_NOT_FOUND", "Invitation")
+	}
+	if invitation.Status(time.Now()) == constants.InvitationStatusExpired {
+		return nil, domainerrors.NewValidationError("MSG_INVITATION_EXPIRED", "The invitation has expired; ask for a new one", nil)
+	}
+
+	result, err := u.kratosService.SubmitVerificationFlow(
+		ctx, tenantID, invitation.FlowID, &identifier, constants.IdentifierType(idType), &code,
+	)
+	if err != nil {
+		if errors.Is(err, domainservice.ErrFlowRejected) {
+			if derr := countWrongCode(ctx, u.identifierLockoutRepo, tenantID, invitation.FlowID, identifier); derr != nil {
+				return nil, derr
+			}
+		}
+		return nil, domainerrors.NewValidationError("MSG_VERIFICATION_FAILED", "Verification failed", []interface{}{err.Error()})
+	}
+	verified := false
+	if result != nil {
+		if state, ok := result.State.(string); ok && strings.EqualFold(state, constants.StatePassedChallenge) {
+			verified = true
+		}
+	}
+	if !verified {
+		if derr := countWrongCode(ctx, u.identifierLockoutRepo, tenantID, invitation.FlowID, identifier); derr != nil {
+			return nil, derr
+		}
+		return nil, domainerrors.NewValidationError("MSG_VERIFICATION_FAILED", "Invalid or expired verification code", nil)
+	}
+	forgetWrongCodes(ctx, u.identifierLockoutRepo, tenantID, identifier)
+
+	now := time.Now()
+	globalUser := &domain.GlobalUser{}
+	err = u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := u.globalUserRepo.Create(tx, globalUser); err != nil {
+			return fmt.Errorf("create global user: %w", err)
+		}
+		inserted, err := u.userIdentityRepo.InsertOnceByKratosUserAndType(
+			ctx, tx, tenantID.String(), invitation.KratosUserID, globalUser.ID, idType, identifier,
+		)
+		if err != nil {
+			return fmt.Errorf("create identity: %w", err)
+		}
+		if !inserted {
+			return errInvitationClosed
+		}
+		if err := u.changeLogRepo.Create(ctx, tx, &domain.UserIdentityChangeLog{
+			GlobalUserID: globalUser.ID,
+			TenantID:     tenantID.String(),
+			Action:       constants.IdentityChangeActionAdd,
+			IdentityType: idType,
+			NewValue:     identifier,
+			Actor:        constants.IdentityChangeActorAdmin,
+			ActorID:      invitation.InvitedBy,
+		}); err != nil {
+			return fmt.Errorf("record identity change: %w", err)
+		}
+		if err := u.userIdentifierMappingRepo.Create(ctx, tx, &domain.UserIdentifierMapping{
+			GlobalUserID: globalUser.ID,
+			Lang:         invitation.Lang,
+		}); err != nil {
+			return fmt.Errorf("create mapping: %w", err)
+		}
+		accepted, err := u.userInvitationRepo.MarkAccepted(ctx, tx, invitation.ID, globalUser.ID, now)
+		if err != nil {
+			return fmt.Errorf("accept invitation: %w", err)
+		}
+		if !accepted {
+			return errInvitationClosed
+		}
+		return nil
+	})
+	if errors.Is(err, errInvitationClosed) {
+		return nil, domainerrors.NewConflictError("MSG_INVITATION_CLOSED", "The invitation was already accepted or revoked", nil)
+	}
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_ACCEPT_INVITATION_FAILED", "Failed to accept invitation")
+	}
+	invitation.GlobalUserID = &globalUser.ID
+	invitation.AcceptedAt = &now
+
+	for _, role := range invitation.RoleList() {
+		if derr := u.ketoService.CreateRelationTuple(ctx, types.CreateRelationTupleRequest{
+			Namespace: "roles",
+			Relation:  "member",
+			Object:    fmt.Sprintf("%s:%s", tenantID, role),
+			TenantRelation: types.TenantRelation{
+				TenantID:   tenantID.String(),
+				Identifier: identifier,
+			},
+			GlobalUserID: globalUser.ID,
+		}); derr != nil {
+			logger.GetLogger().Errorf("Failed to grant role %s to user %s from invitation %s: %v", role, globalUser.ID, invitation.ID, derr)
+		}
+	}
+
+	return toInvitationResponse(invitation, now), nil
+}
+
+// getOpenInvitation returns the tenant's invitation as long as it was neither accepted nor revoked
+func (u *invitationUseCase) getOpenInvitation(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	invitationID string,
+) (*domain.UserInvitation, *domainerrors.DomainError) {
+	if _, err := uuid.Parse(invitationID); err != nil {
+		return nil, domainerrors.NewNotFoundError("MSG_INVITATION_NOT_FOUND", "Invitation")
+	}
+	invitation, err := u.userInvitationRepo.GetByID(ctx, tenantID.String(), invitationID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_INVITATION_FAILED", "Failed to get invitation")
+	}
+	if invitation == nil {
+		return nil, domainerrors.NewNotFoundError("MSG_INVITATION_NOT_FOUND", "Invitation")
+	}
+	switch invitation.Status(time.Now()) {
+	case constants.InvitationStatusAccepted, constants.InvitationStatusRevoked:
+		return nil, domainerrors.NewConflictError("MSG_INVITATION_CLOSED", "The invitation was already accepted or revoked", nil)
+	}
+	return invitation, nil
+}
+
+// sendInvitationCode starts a verification flow for the pending identity, which has Kratos send
+// the code through the courier
+func (u *invitationUseCase) sendInvitationCode(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	idType string,
+	identifier string,
+) (string, *domainerrors.DomainError) {
+	flowID, err := u.kratosService.InitializeVerificationFlow(ctx, tenantID)
+	if err != nil {
+		return "", domainerrors.WrapInternal(err, "MSG_VERIFICATION_FLOW_FAILED", "Failed to initialize verification flow")
+	}
+	if _, err := u.kratosService.SubmitVerificationFlow(
+		ctx, tenantID, flowID, &identifier, constants.IdentifierType(idType), nil,
+	); err != nil {
+		return "", domainerrors.WrapInternal(err, "MSG_SEND_VERIFICATION_FAILED", "Failed to send verification code")
+	}
+	return flowID, nil
+}
+
+// withdrawInvitation revokes an open invitation and deletes its pending identity, freeing the
+// identifier in Kratos. The identity goes first so a failure leaves the invitation to retry.
+func withdrawInvitation(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	invitation *domain.UserInvitation,
+	userInvitationRepo domainrepo.UserInvitationRepository,
+	kratosService domainservice.KratosService,
+) error {
+	kratosUserID, err := uuid.Parse(invitation.KratosUserID)
+	if err != nil {
+		return fmt.Errorf("parse kratos user id: %w", err)
+	}
+	if err := kratosService.DeleteIdentifierAdmin(ctx, tenantID, kratosUserID); err != nil {
+		return fmt.Errorf("delete pending identity: %w", err)
+	}
+	if _, err := userInvitationRepo.Revoke(ctx, invitation.ID, time.Now()); err != nil {
+		return fmt.Errorf("revoke invitation: %w", err)
+	}
+	return nil
+}
+
+// checkRegistrationAllowed refuses self-registration of an identifier that has a pending
+// invitation, which has to be accepted instead, and of any identifier on an invite-only tenant
+func (u *userUseCase) checkRegistrationAllowed(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	identifierType string,
+	identifier string,
+) *domainerrors.DomainError {
+	invitation, err := u.userInvitationRepo.GetOpen(ctx, tenantID.String(), identifierType, identifier)
+	if err != nil {
+		return domainerrors.WrapInternal(err, "MSG_GET_INVITATION_FAILED", "Failed to get invitation")
+	}
+	if invitation != nil && invitation.Status(time.Now()) == constants.InvitationStatusPending {
+		return domainerrors.NewConflictError("MSG_INVITATION_PENDING", "The identifier has a pending invitation; accept it instead", nil)
+	}
+
+	setting, derr := getTenantSetting(ctx, u.tenantSettingRepo, tenantID)
+	if derr != nil {
+		return derr
+	}
+	if setting.InviteOnlyRegistration {
+		return domainerrors.NewForbiddenError("MSG_REGISTRATION_INVITE_ONLY", "Registration is by invitation only", nil)
+	}
+
+	if invitation != nil {
+		// The expired invitation's pending identity would make Kratos refuse the registration
+		if err := withdrawInvitation(ctx, tenantID, invitation, u.userInvitationRepo, u.kratosService); err != nil {
+			return domainerrors.WrapInternal(err, "MSG_REVOKE_INVITATION_FAILED", "Failed to withdraw the expired invitation")
+		}
+	}
+	return nil
+}
+
+// normalizeInvitationRoles trims and deduplicates the roles; a role names a Keto object and so
+// cannot contain spaces or colons
+func normalizeInvitationRoles(roles []string) ([]string, *domainerrors.DomainError) {
+	normalized := make([]string, 0, len(roles))
+	for _, role := range roles {
+		role = strings.TrimSpace(role)
+		if role == "" || strings.ContainsAny(role, " \t\r\n:") {
+			return nil, domainerrors.NewValidationError("MSG_INVALID_ROLE", "Invalid role", []interface{}{
+				map[string]string{"field": "roles", "error": "A role cannot be empty or contain spaces or colons"},
+			})
+		}
+		if !slices.Contains(normalized, role) {
+			normalized = append(normalized, role)
+		}
+	}
+	return normalized, nil
+}
+
+func toInvitationResponse(invitation *domain.UserInvitation, now time.Time) *types.InvitationResponse {
+	resp := &types.InvitationResponse{
+		ID:             invitation.ID,
+		IdentifierType: invitation.IdentifierType,
+		Identifier:     invitation.Identifier,
+		Lang:           invitation.Lang,
+		Roles:          invitation.RoleList(),
+		Status:         invitation.Status(now),
+		InvitedBy:      invitation.InvitedBy,
+		ExpiresAt:      invitation.ExpiresAt,
+		AcceptedAt:     invitation.AcceptedAt,
+		RevokedAt:      invitation.RevokedAt,
+		CreatedAt:      invitation.CreatedAt,
+	}
+	if invitation.GlobalUserID != nil {
+		resp.GlobalUserID = *invitation.GlobalUserID
+	}
+	return resp
+}
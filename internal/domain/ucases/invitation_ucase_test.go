@@ -0,0 +1,302 @@
+package ucases
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	client "github.com/ory/kratos-client-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	"github.com/lifenetwork-ai/iam-service/internal/delivery/dto"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	mock_repositories "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/repositories"
+	mock_services "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/services"
+	mock_rate_limiter "github.com/lifenetwork-ai/iam-service/mocks/infrastructures/rate_limiter/types"
+)
+
+func TestCreateInvitation_CreatesPendingIdentityAndSendsTheCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	kratosUserID := uuid.NewString()
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+	tenantRepo.EXPECT().GetByID(tenantID).Return(&domain.Tenant{ID: tenantID, Name: "acme"}, nil)
+
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	identityRepo.EXPECT().ExistsWithinTenant(ctx, tenantID.String(), "email", "staff@acme.io").Return(false, nil)
+
+	invitationRepo := mock_repositories.NewMockUserInvitationRepository(ctrl)
+	invitationRepo.EXPECT().GetOpen(ctx, tenantID.String(), "email", "staff@acme.io").Return(nil, nil)
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().CreateIdentityAdmin(ctx, tenantID, map[string]interface{}{
+		"tenant": "acme",
+		"lang":   "vi",
+		"email":  "staff@acme.io",
+	}).Return(&client.Identity{Id: kratosUserID}, http.StatusCreated, nil)
+	kratos.EXPECT().InitializeVerificationFlow(ctx, tenantID).Return("flow-1", nil)
+	kratos.EXPECT().SubmitVerificationFlow(ctx, tenantID, "flow-1", gomock.Any(), constants.IdentifierEmail, nil).
+		Return(&client.VerificationFlow{}, nil)
+	invitationRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, invitation *domain.UserInvitation) error {
+		assert.Equal(t, kratosUserID, invitation.KratosUserID)
+		assert.Equal(t, "flow-1", invitation.FlowID)
+		assert.Equal(t, "editor viewer", invitation.Roles)
+		assert.Equal(t, "hr-admin", invitation.InvitedBy)
+		assert.WithinDuration(t, time.Now().Add(constants.InvitationTTL), invitation.ExpiresAt, time.Minute)
+		return nil
+	})
+
+	lockoutRepo := mock_repositories.NewMockIdentifierLockoutRepository(ctrl)
+	lockoutRepo.EXPECT().Get(ctx, tenantID.String(), "staff@acme.io").Return(nil, nil)
+
+	u := &invitationUseCase{
+		rateLimiter:           rateLimiter,
+		tenantRepo:            tenantRepo,
+		userInvitationRepo:    invitationRepo,
+		userIdentityRepo:      identityRepo,
+		identifierLockoutRepo: lockoutRepo,
+		kratosService:         kratos,
+	}
+
+	resp, derr := u.CreateInvitation(ctx, tenantID, dto.CreateInvitationPayloadDTO{
+		Identifier: " Staff@Acme.io ",
+		Lang:       "vi",
+		Roles:      []string{"editor", " viewer", "editor"},
+	}, "hr-admin")
+	require.Nil(t, derr)
+	assert.Equal(t, constants.InvitationStatusPending, resp.Status)
+	assert.Equal(t, []string{"editor", "viewer"}, resp.Roles)
+}
+
+func TestCreateInvitation_RefusesSecondPendingInvitation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+	tenantRepo.EXPECT().GetByID(tenantID).Return(&domain.Tenant{ID: tenantID, Name: "acme"}, nil)
+
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	identityRepo.EXPECT().ExistsWithinTenant(ctx, tenantID.String(), "email", "staff@acme.io").Return(false, nil)
+
+	invitationRepo := mock_repositories.NewMockUserInvitationRepository(ctrl)
+	invitationRepo.EXPECT().GetOpen(ctx, tenantID.String(), "email", "staff@acme.io").
+		Return(&domain.UserInvitation{ID: "invitation-1", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+
+	lockoutRepo := mock_repositories.NewMockIdentifierLockoutRepository(ctrl)
+	lockoutRepo.EXPECT().Get(ctx, tenantID.String(), "staff@acme.io").Return(nil, nil)
+
+	// No second Kratos identity is created
+	u := &invitationUseCase{
+		rateLimiter:           rateLimiter,
+		tenantRepo:            tenantRepo,
+		userInvitationRepo:    invitationRepo,
+		userIdentityRepo:      identityRepo,
+		identifierLockoutRepo: lockoutRepo,
+		kratosService:         mock_services.NewMockKratosService(ctrl),
+	}
+
+	resp, derr := u.CreateInvitation(ctx, tenantID, dto.CreateInvitationPayloadDTO{Identifier: "staff@acme.io"}, "hr-admin")
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_INVITATION_PENDING", derr.Code)
+
+	_, derr = u.CreateInvitation(ctx, tenantID, dto.CreateInvitationPayloadDTO{
+		Identifier: "staff@acme.io", Roles: []string{"tenant:admin"},
+	}, "hr-admin")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_INVALID_ROLE", derr.Code)
+}
+
+func TestAcceptInvitation_CreatesTheUserAndGrantsRoles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	globalUserID := uuid.NewString()
+	invitation := &domain.UserInvitation{
+		ID:             "invitation-1",
+		TenantID:       tenantID.String(),
+		IdentifierType: "phone_number",
+		Identifier:     "+84987654321",
+		Lang:           "vi",
+		Roles:          "editor viewer",
+		KratosUserID:   uuid.NewString(),
+		FlowID:         "flow-1",
+		InvitedBy:      "hr-admin",
+		ExpiresAt:      time.Now().Add(time.Hour),
+	}
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	invitationRepo := mock_repositories.NewMockUserInvitationRepository(ctrl)
+	globalRepo := mock_repositories.NewMockGlobalUserRepository(ctrl)
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	mappingRepo := mock_repositories.NewMockUserIdentifierMappingRepository(ctrl)
+	changeLogRepo := mock_repositories.NewMockUserIdentityChangeLogRepository(ctrl)
+	lockoutRepo := mock_repositories.NewMockIdentifierLockoutRepository(ctrl)
+	kratos := mock_services.NewMockKratosService(ctrl)
+	keto := mock_services.NewMockKetoService(ctrl)
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	u := &invitationUseCase{
+		db:                        db,
+		rateLimiter:               rateLimiter,
+		userInvitationRepo:        invitationRepo,
+		globalUserRepo:            globalRepo,
+		userIdentityRepo:          identityRepo,
+		userIdentifierMappingRepo: mappingRepo,
+		changeLogRepo:             changeLogRepo,
+		identifierLockoutRepo:     lockoutRepo,
+		kratosService:             kratos,
+		ketoService:               keto,
+	}
+
+	lockoutRepo.EXPECT().Get(ctx, tenantID.String(), "+84987654321").Return(nil, nil)
+	invitationRepo.EXPECT().GetOpen(ctx, tenantID.String(), "phone_number", "+84987654321").Return(invitation, nil)
+	kratos.EXPECT().SubmitVerificationFlow(ctx, tenantID, "flow-1", gomock.Any(), constants.IdentifierPhone, gomock.Any()).
+		Return(&client.VerificationFlow{State: constants.StatePassedChallenge}, nil)
+	lockoutRepo.EXPECT().Delete(ctx, tenantID.String(), "+84987654321").Return(true, nil)
+	globalRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ *gorm.DB, user *domain.GlobalUser) error {
+		user.ID = globalUserID
+		return nil
+	})
+	identityRepo.EXPECT().InsertOnceByKratosUserAndType(
+		ctx, gomock.Any(), tenantID.String(), invitation.KratosUserID, globalUserID, "phone_number", "+84987654321",
+	).Return(true, nil)
+	changeLogRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *gorm.DB, log *domain.UserIdentityChangeLog) error {
+			assert.Equal(t, constants.IdentityChangeActorAdmin, log.Actor)
+			assert.Equal(t, "hr-admin", log.ActorID)
+			return nil
+		})
+	mappingRepo.EXPECT().Create(ctx, gomock.Any(), &domain.UserIdentifierMapping{GlobalUserID: globalUserID, Lang: "vi"}).Return(nil)
+	invitationRepo.EXPECT().MarkAccepted(ctx, gomock.Any(), "invitation-1", globalUserID, gomock.Any()).Return(true, nil)
+	var granted []string
+	keto.EXPECT().CreateRelationTuple(ctx, gomock.Any()).Times(2).
+		DoAndReturn(func(_ context.Context, req types.CreateRelationTupleRequest) *domainerrors.DomainError {
+			assert.Equal(t, globalUserID, req.GlobalUserID)
+			granted = append(granted, req.Object)
+			return nil
+		})
+
+	resp, derr := u.AcceptInvitation(ctx, tenantID, "+84987654321", "123456")
+	require.Nil(t, derr)
+	assert.Equal(t, constants.InvitationStatusAccepted, resp.Status)
+	assert.Equal(t, globalUserID, resp.GlobalUserID)
+	assert.Equal(t, []string{tenantID.String() + ":editor", tenantID.String() + ":viewer"}, granted)
+}
+
+func TestAcceptInvitation_WrongCodeLocksOutIdentifierAtLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	invitation := &domain.UserInvitation{
+		ID:             "invitation-1",
+		TenantID:       tenantID.String(),
+		IdentifierType: "phone_number",
+		Identifier:     "+84987654321",
+		FlowID:         "flow-1",
+		ExpiresAt:      time.Now().Add(time.Hour),
+	}
+
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	invitationRepo := mock_repositories.NewMockUserInvitationRepository(ctrl)
+	invitationRepo.EXPECT().GetOpen(ctx, tenantID.String(), "phone_number", "+84987654321").Return(invitation, nil)
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().SubmitVerificationFlow(ctx, tenantID, "flow-1", gomock.Any(), constants.IdentifierPhone, gomock.Any()).
+		Return(nil, fmt.Errorf("verification failed: %w", domainservice.ErrFlowRejected))
+
+	lockoutRepo := mock_repositories.NewMockIdentifierLockoutRepository(ctrl)
+	lockoutRepo.EXPECT().Get(ctx, tenantID.String(), "+84987654321").Return(nil, nil)
+	lockoutRepo.EXPECT().RecordFailure(ctx, tenantID.String(), "+84987654321", gomock.Any(), gomock.Any()).
+		Return(&domain.IdentifierLockout{ID: "lockout-1", FailedAttempts: constants.OTPMaxFailedAttempts}, nil)
+	lockoutRepo.EXPECT().Lock(ctx, "lockout-1", gomock.Any()).Return(nil)
+
+	u := &invitationUseCase{
+		rateLimiter:           rateLimiter,
+		userInvitationRepo:    invitationRepo,
+		identifierLockoutRepo: lockoutRepo,
+		kratosService:         kratos,
+	}
+
+	resp, derr := u.AcceptInvitation(ctx, tenantID, "+84987654321", "000000")
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_IDENTIFIER_LOCKED", derr.Code)
+}
+
+func TestCheckRegistrationAllowed_RespectsInvitations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	invitationRepo := mock_repositories.NewMockUserInvitationRepository(ctrl)
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	kratos := mock_services.NewMockKratosService(ctrl)
+	u := &userUseCase{
+		userInvitationRepo: invitationRepo,
+		tenantSettingRepo:  settingRepo,
+		kratosService:      kratos,
+	}
+
+	// A pending invitation has to be accepted instead
+	invitationRepo.EXPECT().GetOpen(ctx, tenantID.String(), "email", "a@acme.io").
+		Return(&domain.UserInvitation{ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	derr := u.checkRegistrationAllowed(ctx, tenantID, "email", "a@acme.io")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_INVITATION_PENDING", derr.Code)
+
+	// An invite-only tenant refuses everyone else
+	invitationRepo.EXPECT().GetOpen(ctx, tenantID.String(), "email", "b@acme.io").Return(nil, nil)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(&domain.TenantSetting{TenantID: tenantID, InviteOnlyRegistration: true}, nil)
+	derr = u.checkRegistrationAllowed(ctx, tenantID, "email", "b@acme.io")
+	require.NotNil(t, derr)
+	assert.Equal(t, domainerrors.ErrorTypeForbidden, derr.Type)
+	assert.Equal(t, "MSG_REGISTRATION_INVITE_ONLY", derr.Code)
+
+	// An expired invitation is withdrawn so Kratos accepts the registration
+	expired := &domain.UserInvitation{ID: "invitation-1", KratosUserID: uuid.NewString(), ExpiresAt: time.Now().Add(-time.Hour)}
+	invitationRepo.EXPECT().GetOpen(ctx, tenantID.String(), "email", "c@acme.io").Return(expired, nil)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(nil, nil)
+	kratos.EXPECT().DeleteIdentifierAdmin(ctx, tenantID, uuid.MustParse(expired.KratosUserID)).Return(nil)
+	invitationRepo.EXPECT().Revoke(ctx, "invitation-1", gomock.Any()).Return(true, nil)
+	assert.Nil(t, u.checkRegistrationAllowed(ctx, tenantID, "email", "c@acme.io"))
+}
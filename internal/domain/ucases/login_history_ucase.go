@@ -0,0 +1,80 @@
+package ucases
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domaintypes "github.com/lifenetwork-ai/iam-service/internal/domain/types"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+)
+
+type loginHistoryUseCase struct {
+	loginEventRepo domainrepo.LoginEventRepository
+}
+
+func NewLoginHistoryUseCase(loginEventRepo domainrepo.LoginEventRepository) interfaces.LoginHistoryUseCase {
+	return &loginHistoryUseCase{
+		loginEventRepo: loginEventRepo,
+	}
+}
+
+// ListUserLogins returns a page of the user's logins, newest first
+func (u *loginHistoryUseCase) ListUserLogins(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+	page, size int,
+) (*domaintypes.PaginatedResponse[*types.LoginEventResponse], *domainerrors.DomainError) {
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = constants.LoginHistoryDefaultPageSize
+	}
+	if size > constants.LoginHistoryMaxPageSize {
+		size = constants.LoginHistoryMaxPageSize
+	}
+
+	events, total, err := u.loginEventRepo.List(ctx, tenantID.String(), globalUserID, (page-1)*size, size)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_LIST_LOGIN_HISTORY_FAILED", "Failed to list login history")
+	}
+
+	nextPage := page
+	if int64(page*size) < total {
+		nextPage++
+	}
+	return &domaintypes.PaginatedResponse[*types.LoginEventResponse]{
+		Items:      toLoginEventResponses(events),
+		TotalCount: total,
+		Page:       page,
+		PageSize:   size,
+		NextPage:   nextPage,
+	}, nil
+}
+
+func toLoginEventResponses(events []*domain.LoginEvent) []*types.LoginEventResponse {
+	items := make([]*types.LoginEventResponse, len(events))
+	for i, event := range events {
+		items[i] = &types.LoginEventResponse{
+			ID:         event.ID,
+			Flow:       event.Flow,
+			Method:     event.Method,
+			Channel:    event.Channel,
+			IPAddress:  event.IPAddress,
+			Device:     event.UserAgent,
+			Country:    event.Country,
+			NewDevice:  event.NewDevice,
+			NewCountry: event.NewCountry,
+			RiskAction: event.RiskAction,
+			CreatedAt:  event.CreatedAt,
+		}
+	}
+	return items
+}
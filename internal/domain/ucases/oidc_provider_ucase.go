@@ -0,0 +1,709 @@
+package ucases
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	cachetypes "github.com/lifenetwork-ai/iam-service/infrastructures/caching/types"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+	"github.com/lifenetwork-ai/iam-service/packages/utils"
+)
+
+// supportedOIDCScopes are the scopes the provider grants, in the order they are reported
+var supportedOIDCScopes = []string{
+	constants.OIDCScopeOpenID,
+	constants.OIDCScopeProfile,
+	constants.OIDCScopeEmail,
+	constants.OIDCScopePhone,
+}
+
+type oidcProviderUseCase struct {
+	tenantRepo                domainrepo.TenantRepository
+	tenantSettingRepo         domainrepo.TenantSettingRepository
+	oauthClientRepo           domainrepo.OAuthClientRepository
+	consentRepo               domainrepo.OAuthConsentRepository
+	authCodeRepo              domainrepo.OAuthAuthorizationCodeRepository
+	userIdentityRepo          domainrepo.UserIdentityRepository
+	userIdentifierMappingRepo domainrepo.UserIdentifierMappingRepository
+	userAccountStatusRepo     domainrepo.UserAccountStatusRepository
+	cacheRepo                 cachetypes.CacheRepository
+	kratosService             domainservice.KratosService
+	signer                    domainservice.TokenSigner
+	keys                      *signingKeyring
+}
+
+func NewOIDCProviderUseCase(
+	tenantRepo domainrepo.TenantRepository,
+	tenantSettingRepo domainrepo.TenantSettingRepository,
+	oauthClientRepo domainrepo.OAuthClientRepository,
+	consentRepo domainrepo.OAuthConsentRepository,
+	authCodeRepo domainrepo.OAuthAuthorizationCodeRepository,
+	userIdentityRepo domainrepo.UserIdentityRepository,
+	userIdentifierMappingRepo domainrepo.UserIdentifierMappingRepository,
+	userAccountStatusRepo domainrepo.UserAccountStatusRepository,
+	cacheRepo cachetypes.CacheRepository,
+	kratosService domainservice.KratosService,
+	signingKeyRepo domainrepo.SigningKeyRepository,
+	signer domainservice.TokenSigner,
+) interfaces.OIDCProviderUseCase {
+	return &oidcProviderUseCase{
+		tenantRepo:                tenantRepo,
+		tenantSettingRepo:         tenantSettingRepo,
+		oauthClientRepo:           oauthClientRepo,
+		consentRepo:               consentRepo,
+		authCodeRepo:              authCodeRepo,
+		userIdentityRepo:          userIdentityRepo,
+		userIdentifierMappingRepo: userIdentifierMappingRepo,
+		userAccountStatusRepo:     userAccountStatusRepo,
+		cacheRepo:                 cacheRepo,
+		kratosService:             kratosService,
+		signer:                    signer,
+		keys:                      newSigningKeyring(signingKeyRepo, signer),
+	}
+}
+
+func oidcAuthorizationRequestKey(requestID string) *cachetypes.Keyer {
+	return &cachetypes.Keyer{Raw: "oidc_provider:authorization_request:" + requestID}
+}
+
+// oidcIssuer is the issuer of a tenant's ID tokens
+func oidcIssuer(tenantID uuid.UUID) string {
+	return conf.GetOIDCProviderBaseURL() + "/oidc/" + tenantID.String()
+}
+
+// Discovery returns the tenant's provider metadata
+func (u *oidcProviderUseCase) Discovery(ctx context.Context, tenantID uuid.UUID) (*types.OIDCDiscoveryDocument, *domainerrors.DomainError) {
+	if _, derr := u.loginURL(ctx, tenantID); derr != nil {
+		return nil, derr
+	}
+
+	issuer := oidcIssuer(tenantID)
+	return &types.OIDCDiscoveryDocument{
+		Issuer:                            issuer,
+		AuthorizationEndpoint:             issuer + "/authorize",
+		TokenEndpoint:                     issuer + "/token",
+		UserinfoEndpoint:                  issuer + "/userinfo",
+		JWKSURI:                           conf.GetOIDCProviderBaseURL() + "/.well-known/jwks.json",
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{"authorization_code"},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{"RS256"},
+		ScopesSupported:                   supportedOIDCScopes,
+		ClaimsSupported:                   []string{"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce", "acr", "sid", "global_user_id", "tenant", "email", "phone_number", "locale"},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{constants.PKCEMethodS256},
+	}, nil
+}
+
+// Authorize validates an authorization request and parks it until the user has signed in on the
+// tenant's login page. Once the redirect URI is known to belong to the client, problems with the
+// request are reported to the client rather than the user.
+func (u *oidcProviderUseCase) Authorize(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	req *types.OIDCAuthorizeRequest,
+) (string, *domainerrors.DomainError) {
+	loginURL, derr := u.loginURL(ctx, tenantID)
+	if derr != nil {
+		return "", derr
+	}
+	client, derr := u.activeClient(ctx, tenantID, req.ClientID)
+	if derr != nil {
+		return "", derr
+	}
+	if !client.AllowsRedirectURI(req.RedirectURI) {
+		return "", domainerrors.NewValidationError(
+			"MSG_INVALID_REDIRECT_URI",
+			"The redirect URI is not registered for this client",
+			map[string]string{"field": "redirect_uri", "error": req.RedirectURI},
+		)
+	}
+
+	fail := func(code, description string) (string, *domainerrors.DomainError) {
+		return withQuery(req.RedirectURI, url.Values{
+			"error":             {code},
+			"error_description": {description},
+			"state":             {req.State},
+		}), nil
+	}
+	if req.ResponseType != "code" {
+		return fail("unsupported_response_type", "Only the authorization code flow is supported")
+	}
+	scopes := parseOIDCScopes(req.Scope)
+	if !slices.Contains(scopes, constants.OIDCScopeOpenID) {
+		return fail("invalid_scope", "The openid scope is required")
+	}
+	// PKCE is required of every client, confidential ones included (RFC 9700 section 2.1.1)
+	if req.CodeChallenge == "" {
+		return fail("invalid_request", "code_challenge is required")
+	}
+	if req.CodeChallengeMethod != constants.PKCEMethodS256 {
+		return fail("invalid_request", "code_challenge_method must be S256")
+	}
+	if _, err := base64.RawURLEncoding.DecodeString(req.CodeChallenge); err != nil || len(req.CodeChallenge) != 43 {
+		return fail("invalid_request", "code_challenge is malformed")
+	}
+	// Signing in always happens on the tenant's login page
+	if req.Prompt == "none" {
+		return fail("login_required", "The user has to sign in")
+	}
+
+	pending := &types.OIDCAuthorizationRequest{
+		ID:            uuid.NewString(),
+		TenantID:      tenantID.String(),
+		ClientID:      client.ClientID,
+		RedirectURI:   req.RedirectURI,
+		Scope:         strings.Join(scopes, " "),
+		State:         req.State,
+		Nonce:         req.Nonce,
+		CodeChallenge: req.CodeChallenge,
+		CreatedAt:     time.Now(),
+	}
+	if err := u.cacheRepo.SaveItem(oidcAuthorizationRequestKey(pending.ID), pending, constants.OIDCAuthorizationRequestTTL); err != nil {
+		logger.GetLogger().Errorf("Failed to store authorization request: %v", err)
+		return fail("server_error", "Failed to start signing in")
+	}
+
+	return withQuery(loginURL, url.Values{"authorization_request": {pending.ID}}), nil
+}
+
+// GetAuthorizationRequest describes a pending request, and whether it still needs the user's
+// consent, to the login page
+func (u *oidcProviderUseCase) GetAuthorizationRequest(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	user *types.IdentityUserResponse,
+	requestID string,
+) (*types.OIDCAuthorizationRequestResponse, *domainerrors.DomainError) {
+	pending, derr := u.pendingRequest(tenantID, requestID)
+	if derr != nil {
+		return nil, derr
+	}
+	client, derr := u.activeClient(ctx, tenantID, pending.ClientID)
+	if derr != nil {
+		return nil, derr
+	}
+	consent, err := u.consentRepo.Get(ctx, tenantID.String(), user.GlobalUserID, client.ClientID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_CONSENT_FAILED", "Failed to load consent")
+	}
+
+	scopes := strings.Fields(pending.Scope)
+	consentRequired := consent == nil || consent.RevokedAt != nil
+	if !consentRequired {
+		granted := strings.Fields(consent.Scope)
+		for _, s := range scopes {
+			if !slices.Contains(granted, s) {
+				consentRequired = true
+				break
+			}
+		}
+	}
+
+	return &types.OIDCAuthorizationRequestResponse{
+		RequestID:       pending.ID,
+		ClientID:        client.ClientID,
+		ClientName:      client.Name,
+		Scopes:          scopes,
+		ConsentRequired: consentRequired,
+	}, nil
+}
+
+// AcceptAuthorizationRequest records the user's consent and issues a code bound to the session
+// the user signed in with
+func (u *oidcProviderUseCase) AcceptAuthorizationRequest(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	user *types.IdentityUserResponse,
+	requestID string,
+) (*types.OIDCRedirectResponse, *domainerrors.DomainError) {
+	pending, derr := u.pendingRequest(tenantID, requestID)
+	if derr != nil {
+		return nil, derr
+	}
+	client, derr := u.activeClient(ctx, tenantID, pending.ClientID)
+	if derr != nil {
+		return nil, derr
+	}
+
+	sessionToken, derr := extractSessionToken(ctx)
+	if derr != nil {
+		return nil, derr
+	}
+	session, err := u.kratosService.GetSession(ctx, tenantID, sessionToken)
+	if err != nil || session == nil || session.Identity == nil || session.Identity.Id != user.ID {
+		return nil, domainerrors.NewUnauthorizedError("MSG_INVALID_SESSION", "Invalid session").WithCause(err)
+	}
+
+	now := time.Now()
+	scope, derr := u.grantConsent(ctx, tenantID, user.GlobalUserID, client.ClientID, pending.Scope, now)
+	if derr != nil {
+		return nil, derr
+	}
+
+	code, err := utils.RandomToken(constants.OIDCAuthorizationCodeBytes)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_CREATE_AUTHORIZATION_CODE_FAILED", "Failed to generate authorization code")
+	}
+	authTime := now
+	if session.AuthenticatedAt != nil {
+		authTime = *session.AuthenticatedAt
+	}
+	var aal string
+	if session.AuthenticatorAssuranceLevel != nil {
+		aal = string(*session.AuthenticatorAssuranceLevel)
+	}
+	if err := u.authCodeRepo.Create(ctx, &domain.OAuthAuthorizationCode{
+		TenantID:         tenantID.String(),
+		ClientID:         client.ClientID,
+		CodeHash:         utils.HashToken(code),
+		RedirectURI:      pending.RedirectURI,
+		Scope:            scope,
+		Nonce:            pending.Nonce,
+		CodeChallenge:    pending.CodeChallenge,
+		GlobalUserID:     user.GlobalUserID,
+		KratosUserID:     user.ID,
+		KratosSessionID:  session.Id,
+		AAL:              aal,
+		AuthTime:         authTime,
+		SessionExpiresAt: session.ExpiresAt,
+		ExpiresAt:        now.Add(constants.OIDCAuthorizationCodeTTL),
+	}); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_CREATE_AUTHORIZATION_CODE_FAILED", "Failed to store authorization code")
+	}
+	u.dropPendingRequest(requestID)
+
+	return &types.OIDCRedirectResponse{
+		RedirectTo: withQuery(pending.RedirectURI, url.Values{"code": {code}, "state": {pending.State}}),
+	}, nil
+}
+
+// RejectAuthorizationRequest sends the user back to the client without a code
+func (u *oidcProviderUseCase) RejectAuthorizationRequest(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	user *types.IdentityUserResponse,
+	requestID string,
+) (*types.OIDCRedirectResponse, *domainerrors.DomainError) {
+	pending, derr := u.pendingRequest(tenantID, requestID)
+	if derr != nil {
+		return nil, derr
+	}
+	u.dropPendingRequest(requestID)
+
+	return &types.OIDCRedirectResponse{
+		RedirectTo: withQuery(pending.RedirectURI, url.Values{
+			"error":             {"access_denied"},
+			"error_description": {"The user denied the request"},
+			"state":             {pending.State},
+		}),
+	}, nil
+}
+
+// ExchangeCode redeems an authorization code. The code is checked against the client, the
+// redirect URI and the PKCE verifier, and can only be redeemed once.
+func (u *oidcProviderUseCase) ExchangeCode(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	req *types.OIDCTokenRequest,
+) (*types.OIDCTokenResponse, *domainerrors.DomainError) {
+	if req.GrantType != "authorization_code" {
+		return nil, domainerrors.NewValidationError("MSG_UNSUPPORTED_GRANT_TYPE", "Only the authorization_code grant is supported", nil)
+	}
+	client, derr := u.authenticateClient(ctx, tenantID, req.ClientID, req.ClientSecret)
+	if derr != nil {
+		return nil, derr
+	}
+	if req.Code == "" || req.CodeVerifier == "" {
+		return nil, domainerrors.NewValidationError("MSG_INVALID_REQUEST", "code and code_verifier are required", nil)
+	}
+
+	code, err := u.authCodeRepo.GetByCodeHash(ctx, utils.HashToken(req.Code))
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_AUTHORIZATION_CODE_FAILED", "Failed to load authorization code")
+	}
+	now := time.Now()
+	invalidGrant := domainerrors.NewValidationError("MSG_INVALID_GRANT", "The authorization code is invalid or has expired", nil)
+	if code == nil || code.TenantID != tenantID.String() || code.ClientID != client.ClientID ||
+		code.UsedAt != nil || !now.Before(code.ExpiresAt) ||
+		(code.SessionExpiresAt != nil && !now.Before(*code.SessionExpiresAt)) {
+		return nil, invalidGrant
+	}
+	if code.RedirectURI != req.RedirectURI || !verifyPKCE(req.CodeVerifier, code.CodeChallenge) {
+		return nil, invalidGrant
+	}
+	// A user suspended or banned since approving the request gets no tokens
+	if derr := ensureAccountActive(ctx, u.userAccountStatusRepo, tenantID, code.GlobalUserID); derr != nil {
+		if derr.Type == domainerrors.ErrorTypeForbidden {
+			return nil, invalidGrant
+		}
+		return nil, derr
+	}
+	redeemed, err := u.authCodeRepo.MarkUsed(ctx, code.ID, now)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_REDEEM_AUTHORIZATION_CODE_FAILED", "Failed to redeem authorization code")
+	}
+	if !redeemed {
+		return nil, invalidGrant
+	}
+
+	tenant, err := u.tenantRepo.GetByID(tenantID)
+	if err != nil || tenant == nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_TENANT_FAILED", "Failed to load tenant")
+	}
+	email, phone, locale, derr := u.scopedIdentifiers(ctx, tenantID, code.GlobalUserID, code.Scope)
+	if derr != nil {
+		return nil, derr
+	}
+
+	key, derr := u.keys.activeKey(ctx, now)
+	if derr != nil {
+		return nil, derr
+	}
+	privateKey, derr := decryptSigningKey(key.PrivateKey)
+	if derr != nil {
+		return nil, derr
+	}
+
+	expiresAt := now.Add(conf.GetAccessTokenTTL())
+	if code.SessionExpiresAt != nil && code.SessionExpiresAt.Before(expiresAt) {
+		expiresAt = *code.SessionExpiresAt
+	}
+	audience := conf.GetJWTAudience()
+	if len(audience) == 0 {
+		audience = []string{client.ClientID}
+	}
+
+	accessToken, err := u.signer.Sign(key.KeyID, privateKey, &types.AccessTokenClaims{
+		ID:           uuid.NewString(),
+		Issuer:       conf.GetJWTIssuer(),
+		Audience:     audience,
+		Subject:      code.GlobalUserID,
+		IssuedAt:     now,
+		ExpiresAt:    expiresAt,
+		GlobalUserID: code.GlobalUserID,
+		TenantID:     tenantID.String(),
+		Tenant:       tenant.Name,
+		KratosUserID: code.KratosUserID,
+		SessionID:    code.KratosSessionID,
+		AAL:          code.AAL,
+		Email:        email,
+		Phone:        phone,
+		Lang:         locale,
+		ClientID:     client.ClientID,
+		Scope:        code.Scope,
+	})
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_SIGN_ACCESS_TOKEN_FAILED", "Failed to sign access token")
+	}
+	idToken, err := u.signer.SignIDToken(key.KeyID, privateKey, &types.IDTokenClaims{
+		Issuer:       oidcIssuer(tenantID),
+		Audience:     client.ClientID,
+		Subject:      code.GlobalUserID,
+		IssuedAt:     now,
+		ExpiresAt:    expiresAt,
+		AuthTime:     code.AuthTime,
+		Nonce:        code.Nonce,
+		GlobalUserID: code.GlobalUserID,
+		Tenant:       tenant.Name,
+		SessionID:    code.KratosSessionID,
+		AAL:          code.AAL,
+		Email:        email,
+		Phone:        phone,
+		Locale:       locale,
+	})
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_SIGN_ID_TOKEN_FAILED", "Failed to sign ID token")
+	}
+
+	return &types.OIDCTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   constants.AccessTokenType,
+		ExpiresIn:   int64(expiresAt.Sub(now).Seconds()),
+		IDToken:     idToken,
+		Scope:       code.Scope,
+	}, nil
+}
+
+// UserInfo returns the current claims of the user an access token from the provider belongs to
+func (u *oidcProviderUseCase) UserInfo(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	accessToken string,
+) (*types.OIDCUserInfoResponse, *domainerrors.DomainError) {
+	invalidToken := domainerrors.NewUnauthorizedError("MSG_INVALID_ACCESS_TOKEN", "Invalid access token")
+	if accessToken == "" {
+		return nil, invalidToken
+	}
+
+	keys, derr := u.keys.publishedKeys(ctx, time.Now())
+	if derr != nil {
+		return nil, derr
+	}
+	publicKeys := make([]json.RawMessage, 0, len(keys))
+	for _, k := range keys {
+		publicKeys = append(publicKeys, json.RawMessage(k.PublicKey))
+	}
+	claims, err := u.signer.Verify(accessToken, publicKeys)
+	if err != nil {
+		return nil, invalidToken.WithCause(err)
+	}
+	if claims.Issuer != conf.GetJWTIssuer() || claims.TenantID != tenantID.String() || claims.ClientID == "" ||
+		!slices.Contains(strings.Fields(claims.Scope), constants.OIDCScopeOpenID) {
+		return nil, invalidToken
+	}
+	if _, derr := u.activeClient(ctx, tenantID, claims.ClientID); derr != nil {
+		return nil, invalidToken
+	}
+	if derr := ensureAccountActive(ctx, u.userAccountStatusRepo, tenantID, claims.GlobalUserID); derr != nil {
+		if derr.Type == domainerrors.ErrorTypeForbidden {
+			return nil, invalidToken
+		}
+		return nil, derr
+	}
+
+	email, phone, locale, derr := u.scopedIdentifiers(ctx, tenantID, claims.GlobalUserID, claims.Scope)
+	if derr != nil {
+		return nil, derr
+	}
+	return &types.OIDCUserInfoResponse{
+		Subject:      claims.GlobalUserID,
+		GlobalUserID: claims.GlobalUserID,
+		Tenant:       claims.Tenant,
+		Email:        email,
+		Phone:        phone,
+		Locale:       locale,
+	}, nil
+}
+
+// ListConsents returns the clients the user allowed to sign them in, leaving out revoked clients
+func (u *oidcProviderUseCase) ListConsents(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	user *types.IdentityUserResponse,
+) ([]*types.OAuthConsentResponse, *domainerrors.DomainError) {
+	consents, err := u.consentRepo.ListByUser(ctx, tenantID.String(), user.GlobalUserID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_LIST_CONSENTS_FAILED", "Failed to list consents")
+	}
+
+	resp := make([]*types.OAuthConsentResponse, 0, len(consents))
+	for _, consent := range consents {
+		client, err := u.oauthClientRepo.GetByClientID(ctx, consent.ClientID)
+		if err != nil {
+			return nil, domainerrors.WrapInternal(err, "MSG_LIST_CONSENTS_FAILED", "Failed to load client")
+		}
+		if client == nil || client.RevokedAt != nil {
+			continue
+		}
+		resp = append(resp, &types.OAuthConsentResponse{
+			ClientID:   client.ClientID,
+			ClientName: client.Name,
+			Scopes:     strings.Fields(consent.Scope),
+			GrantedAt:  consent.GrantedAt,
+		})
+	}
+	return resp, nil
+}
+
+// RevokeConsent revokes the user's consent for a client. Tokens already issued stay valid until
+// they expire.
+func (u *oidcProviderUseCase) RevokeConsent(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	user *types.IdentityUserResponse,
+	clientID string,
+) *domainerrors.DomainError {
+	revoked, err := u.consentRepo.Revoke(ctx, tenantID.String(), user.GlobalUserID, clientID, time.Now())
+	if err != nil {
+		return domainerrors.WrapInternal(err, "MSG_REVOKE_CONSENT_FAILED", "Failed to revoke consent")
+	}
+	if !revoked {
+		return domainerrors.NewNotFoundError("MSG_CONSENT_NOT_FOUND", "Consent")
+	}
+	return nil
+}
+
+// loginURL returns the tenant's login page, failing when the tenant has not enabled the provider
+func (u *oidcProviderUseCase) loginURL(ctx context.Context, tenantID uuid.UUID) (string, *domainerrors.DomainError) {
+	if conf.GetOIDCProviderBaseURL() == "" {
+		return "", domainerrors.NewNotFoundError("MSG_OIDC_PROVIDER_DISABLED", "OpenID Connect provider")
+	}
+	setting, err := u.tenantSettingRepo.GetByTenantID(ctx, tenantID)
+	if err != nil {
+		return "", domainerrors.WrapInternal(err, "MSG_GET_TENANT_SETTING_FAILED", "Failed to load tenant settings")
+	}
+	if setting == nil || setting.OIDCLoginURL == "" {
+		return "", domainerrors.NewNotFoundError("MSG_OIDC_PROVIDER_DISABLED", "OpenID Connect provider")
+	}
+	return setting.OIDCLoginURL, nil
+}
+
+// activeClient returns a client of the tenant that has not been revoked
+func (u *oidcProviderUseCase) activeClient(ctx context.Context, tenantID uuid.UUID, clientID string) (*domain.OAuthClient, *domainerrors.DomainError) {
+	if clientID == "" {
+		return nil, domainerrors.NewValidationError("MSG_INVALID_CLIENT", "Unknown client", nil)
+	}
+	client, err := u.oauthClientRepo.GetByClientID(ctx, clientID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_OAUTH_CLIENT_FAILED", "Failed to load client")
+	}
+	if client == nil || client.RevokedAt != nil || client.TenantID != tenantID.String() {
+		return nil, domainerrors.NewValidationError("MSG_INVALID_CLIENT", "Unknown client", nil)
+	}
+	return client, nil
+}
+
+// authenticateClient authenticates the client at the token endpoint. Public clients must not
+// send a secret; confidential ones must send theirs.
+func (u *oidcProviderUseCase) authenticateClient(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	clientID, secret string,
+) (*domain.OAuthClient, *domainerrors.DomainError) {
+	invalidClient := domainerrors.NewUnauthorizedError("MSG_INVALID_CLIENT", "Invalid client credentials")
+	client, derr := u.activeClient(ctx, tenantID, clientID)
+	if derr != nil {
+		if derr.Type == domainerrors.ErrorTypeInternal {
+			return nil, derr
+		}
+		return nil, invalidClient
+	}
+	if client.Public {
+		if secret != "" {
+			return nil, invalidClient
+		}
+		return client, nil
+	}
+	if !client.MatchesSecretHash(utils.HashToken(secret)) {
+		return nil, invalidClient
+	}
+	return client, nil
+}
+
+func (u *oidcProviderUseCase) pendingRequest(tenantID uuid.UUID, requestID string) (*types.OIDCAuthorizationRequest, *domainerrors.DomainError) {
+	var pending types.OIDCAuthorizationRequest
+	if err := u.cacheRepo.RetrieveItem(oidcAuthorizationRequestKey(requestID), &pending); err != nil ||
+		pending.TenantID != tenantID.String() {
+		return nil, domainerrors.NewNotFoundError("MSG_AUTHORIZATION_REQUEST_NOT_FOUND", "Authorization request")
+	}
+	return &pending, nil
+}
+
+func (u *oidcProviderUseCase) dropPendingRequest(requestID string) {
+	if err := u.cacheRepo.RemoveItem(oidcAuthorizationRequestKey(requestID)); err != nil {
+		logger.GetLogger().Errorf("Failed to remove authorization request %s: %v", requestID, err)
+	}
+}
+
+// grantConsent adds the requested scopes to the user's consent and returns them
+func (u *oidcProviderUseCase) grantConsent(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID, clientID, scope string,
+	now time.Time,
+) (string, *domainerrors.DomainError) {
+	existing, err := u.consentRepo.Get(ctx, tenantID.String(), globalUserID, clientID)
+	if err != nil {
+		return "", domainerrors.WrapInternal(err, "MSG_GET_CONSENT_FAILED", "Failed to load consent")
+	}
+	granted := strings.Fields(scope)
+	if existing != nil && existing.RevokedAt == nil {
+		granted = parseOIDCScopes(existing.Scope + " " + scope)
+	}
+
+	if err := u.consentRepo.Upsert(ctx, &domain.OAuthConsent{
+		TenantID:     tenantID.String(),
+		ClientID:     clientID,
+		GlobalUserID: globalUserID,
+		Scope:        strings.Join(granted, " "),
+		GrantedAt:    now,
+	}); err != nil {
+		return "", domainerrors.WrapInternal(err, "MSG_SAVE_CONSENT_FAILED", "Failed to save consent")
+	}
+	return scope, nil
+}
+
+// scopedIdentifiers returns the user's identifiers the scope allows the client to see
+func (u *oidcProviderUseCase) scopedIdentifiers(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID, scope string,
+) (email, phone, locale string, derr *domainerrors.DomainError) {
+	scopes := strings.Fields(scope)
+	identities, err := u.userIdentityRepo.GetByGlobalUserIDAndTenantID(ctx, nil, globalUserID, tenantID.String())
+	if err != nil {
+		return "", "", "", domainerrors.WrapInternal(err, "MSG_GET_IDENTIFIERS_FAILED", "Failed to fetch user identifiers")
+	}
+	allEmail, allPhone := identifiersFromIdentities(identities)
+	if slices.Contains(scopes, constants.OIDCScopeEmail) {
+		email = allEmail
+	}
+	if slices.Contains(scopes, constants.OIDCScopePhone) {
+		phone = allPhone
+	}
+	if slices.Contains(scopes, constants.OIDCScopeProfile) {
+		// A user without a mapping has no language preference
+		if mapping, err := u.userIdentifierMappingRepo.GetByGlobalUserID(ctx, globalUserID); err == nil && mapping != nil {
+			locale = mapping.Lang
+		}
+	}
+	return email, phone, locale, nil
+}
+
+// parseOIDCScopes returns the supported scopes in scope, without duplicates. Unknown scopes are
+// dropped rather than rejected (RFC 6749 section 3.3).
+func parseOIDCScopes(scope string) []string {
+	requested := strings.Fields(scope)
+	scopes := make([]string, 0, len(supportedOIDCScopes))
+	for _, s := range supportedOIDCScopes {
+		if slices.Contains(requested, s) {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
+// verifyPKCE checks an S256 code verifier against the challenge (RFC 7636 section 4.6)
+func verifyPKCE(verifier, challenge string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	computed := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(challenge)) == 1
+}
+
+// withQuery adds params to rawURL, keeping its existing query. Empty values are left out.
+func withQuery(rawURL string, params url.Values) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	query := u.Query()
+	for k, values := range params {
+		for _, v := range values {
+			if v != "" {
+				query.Add(k, v)
+			}
+		}
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
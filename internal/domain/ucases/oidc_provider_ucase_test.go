@@ -0,0 +1,378 @@
+package ucases
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	client "github.com/ory/kratos-client-go"
+	gocache "github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	"github.com/lifenetwork-ai/iam-service/infrastructures/caching"
+	cachetypes "github.com/lifenetwork-ai/iam-service/infrastructures/caching/types"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	mock_repositories "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/repositories"
+	mock_services "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/services"
+)
+
+const (
+	testRedirectURI  = "https://partner.example.com/callback"
+	testLoginURL     = "https://login.example.com/oidc"
+	testCodeVerifier = "dBjftJeZ4CVP-mJ92K27uhbUJU1p1r_wW1gFWFOEjXk"
+)
+
+// useOIDCProviderConfig sets the issuer base URL and the key signing keys are sealed with
+func useOIDCProviderConfig(t *testing.T) {
+	useTestEncryptionKey(t)
+	cfg := conf.GetConfiguration()
+	previousBaseURL := cfg.OIDCProvider.BaseURL
+	cfg.OIDCProvider.BaseURL = "https://iam.example.com/"
+	t.Cleanup(func() { cfg.OIDCProvider.BaseURL = previousBaseURL })
+}
+
+func newPartnerClient(tenantID uuid.UUID) *domain.OAuthClient {
+	return &domain.OAuthClient{
+		TenantID:     tenantID.String(),
+		ClientID:     "partner-app",
+		Name:         "Partner",
+		RedirectURIs: testRedirectURI,
+		Public:       true,
+	}
+}
+
+func oidcLoginSetting(tenantID uuid.UUID) *domain.TenantSetting {
+	return &domain.TenantSetting{TenantID: tenantID, OIDCLoginURL: testLoginURL}
+}
+
+func newAuthorizationRequestCache() cachetypes.CacheRepository {
+	return caching.NewCachingRepository(context.Background(), caching.NewGoCacheClient(gocache.New(time.Minute, time.Minute)))
+}
+
+func testCodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func partnerAuthorizeRequest() *types.OIDCAuthorizeRequest {
+	return &types.OIDCAuthorizeRequest{
+		ClientID:            "partner-app",
+		RedirectURI:         testRedirectURI,
+		ResponseType:        "code",
+		Scope:               "openid email offline_access",
+		State:               "state-1",
+		Nonce:               "nonce-1",
+		CodeChallenge:       testCodeChallenge(testCodeVerifier),
+		CodeChallengeMethod: constants.PKCEMethodS256,
+	}
+}
+
+func TestAuthorize_OnlyRedirectsToRegisteredURIs(t *testing.T) {
+	tests := []struct {
+		name      string
+		modify    func(req *types.OIDCAuthorizeRequest)
+		wantCode  string // domain error
+		wantError string // OAuth error sent to the client
+	}{
+		{"unknown client", func(req *types.OIDCAuthorizeRequest) { req.ClientID = "other" }, "MSG_INVALID_CLIENT", ""},
+		{"unregistered redirect", func(req *types.OIDCAuthorizeRequest) { req.RedirectURI += "/evil" }, "MSG_INVALID_REDIRECT_URI", ""},
+		{"missing pkce", func(req *types.OIDCAuthorizeRequest) { req.CodeChallenge = "" }, "", "invalid_request"},
+		{"plain pkce", func(req *types.OIDCAuthorizeRequest) { req.CodeChallengeMethod = "plain" }, "", "invalid_request"},
+		{"missing openid scope", func(req *types.OIDCAuthorizeRequest) { req.Scope = "email" }, "", "invalid_scope"},
+		{"silent sign in", func(req *types.OIDCAuthorizeRequest) { req.Prompt = "none" }, "", "login_required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			useOIDCProviderConfig(t)
+			tenantID := uuid.New()
+
+			settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+			settingRepo.EXPECT().GetByTenantID(gomock.Any(), tenantID).Return(oidcLoginSetting(tenantID), nil).AnyTimes()
+
+			clientRepo := mock_repositories.NewMockOAuthClientRepository(ctrl)
+			clientRepo.EXPECT().GetByClientID(gomock.Any(), "partner-app").Return(newPartnerClient(tenantID), nil).AnyTimes()
+			clientRepo.EXPECT().GetByClientID(gomock.Any(), "other").Return(nil, nil).AnyTimes()
+
+			u := &oidcProviderUseCase{
+				tenantSettingRepo: settingRepo,
+				oauthClientRepo:   clientRepo,
+				cacheRepo:         newAuthorizationRequestCache(),
+			}
+
+			req := partnerAuthorizeRequest()
+			tt.modify(req)
+
+			redirectTo, derr := u.Authorize(context.Background(), tenantID, req)
+			if tt.wantCode != "" {
+				require.NotNil(t, derr)
+				assert.Equal(t, tt.wantCode, derr.Code)
+				return
+			}
+			require.Nil(t, derr)
+			redirect, err := url.Parse(redirectTo)
+			require.NoError(t, err)
+			assert.Equal(t, "partner.example.com", redirect.Host)
+			assert.Equal(t, tt.wantError, redirect.Query().Get("error"))
+			assert.Equal(t, "state-1", redirect.Query().Get("state"))
+		})
+	}
+}
+
+func TestOIDCCodeFlow_RedeemsCodeOnceWithVerifier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useOIDCProviderConfig(t)
+	tenantID := uuid.New()
+	user := &types.IdentityUserResponse{ID: "kratos-1", GlobalUserID: "global-1"}
+	ctx := context.WithValue(context.Background(), constants.SessionTokenKey, "token-1")
+
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(gomock.Any(), tenantID).Return(oidcLoginSetting(tenantID), nil).AnyTimes()
+
+	clientRepo := mock_repositories.NewMockOAuthClientRepository(ctrl)
+	clientRepo.EXPECT().GetByClientID(gomock.Any(), "partner-app").Return(newPartnerClient(tenantID), nil).AnyTimes()
+
+	tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+	consentRepo := mock_repositories.NewMockOAuthConsentRepository(ctrl)
+	codeRepo := mock_repositories.NewMockOAuthAuthorizationCodeRepository(ctrl)
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	keyRepo := mock_repositories.NewMockSigningKeyRepository(ctrl)
+	kratos := mock_services.NewMockKratosService(ctrl)
+	signer := mock_services.NewMockTokenSigner(ctrl)
+
+	u := NewOIDCProviderUseCase(
+		tenantRepo, settingRepo, clientRepo, consentRepo, codeRepo,
+		identityRepo, mock_repositories.NewMockUserIdentifierMappingRepository(ctrl), activeAccountStatusRepo(ctrl),
+		newAuthorizationRequestCache(), kratos, keyRepo, signer,
+	).(*oidcProviderUseCase)
+
+	// The login page receives the parked request
+	redirectTo, derr := u.Authorize(ctx, tenantID, partnerAuthorizeRequest())
+	require.Nil(t, derr)
+	login, err := url.Parse(redirectTo)
+	require.NoError(t, err)
+	assert.Equal(t, "login.example.com", login.Host)
+	requestID := login.Query().Get("authorization_request")
+	require.NotEmpty(t, requestID)
+
+	consentRepo.EXPECT().Get(ctx, tenantID.String(), "global-1", "partner-app").Return(nil, nil).Times(2)
+	pending, derr := u.GetAuthorizationRequest(ctx, tenantID, user, requestID)
+	require.Nil(t, derr)
+	assert.Equal(t, []string{"openid", "email"}, pending.Scopes)
+	assert.True(t, pending.ConsentRequired)
+
+	// Accepting records consent and hands the client a code
+	sessionExpiresAt := time.Now().Add(time.Hour)
+	kratos.EXPECT().GetSession(ctx, tenantID, "token-1").Return(&client.Session{
+		Id:        "session-1",
+		Identity:  &client.Identity{Id: "kratos-1"},
+		ExpiresAt: &sessionExpiresAt,
+	}, nil)
+	consentRepo.EXPECT().Upsert(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, consent *domain.OAuthConsent) error {
+		assert.Equal(t, "openid email", consent.Scope)
+		return nil
+	})
+	var stored *domain.OAuthAuthorizationCode
+	codeRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, code *domain.OAuthAuthorizationCode) error {
+		stored = code
+		stored.ID = "code-id"
+		return nil
+	})
+	accepted, derr := u.AcceptAuthorizationRequest(ctx, tenantID, user, requestID)
+	require.Nil(t, derr)
+	callback, err := url.Parse(accepted.RedirectTo)
+	require.NoError(t, err)
+	code := callback.Query().Get("code")
+	require.NotEmpty(t, code)
+	assert.Equal(t, "state-1", callback.Query().Get("state"))
+	assert.NotEqual(t, code, stored.CodeHash)
+
+	// The request cannot be accepted twice
+	_, derr = u.AcceptAuthorizationRequest(ctx, tenantID, user, requestID)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_AUTHORIZATION_REQUEST_NOT_FOUND", derr.Code)
+
+	codeRepo.EXPECT().GetByCodeHash(ctx, stored.CodeHash).Return(stored, nil).AnyTimes()
+	tokenRequest := &types.OIDCTokenRequest{
+		GrantType:    "authorization_code",
+		Code:         code,
+		RedirectURI:  testRedirectURI,
+		CodeVerifier: testCodeVerifier[:len(testCodeVerifier)-1] + "Y",
+		ClientID:     "partner-app",
+	}
+	_, derr = u.ExchangeCode(ctx, tenantID, tokenRequest)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_INVALID_GRANT", derr.Code)
+
+	tokenRequest.CodeVerifier = testCodeVerifier
+	codeRepo.EXPECT().MarkUsed(ctx, "code-id", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, at time.Time) (bool, error) {
+		stored.UsedAt = &at
+		return true, nil
+	})
+	tenantRepo.EXPECT().GetByID(tenantID).Return(&domain.Tenant{ID: tenantID, Name: "life"}, nil)
+	identityRepo.EXPECT().GetByGlobalUserIDAndTenantID(ctx, nil, "global-1", tenantID.String()).Return([]*domain.UserIdentity{
+		{Type: constants.IdentifierEmail.String(), Value: "alice@example.com"},
+		{Type: constants.IdentifierPhone.String(), Value: "+84900000000"},
+	}, nil)
+	keyRepo.EXPECT().ListPublished(ctx, gomock.Any()).Return([]*domain.SigningKey{
+		newStoredSigningKey(t, "kid-1", time.Now().Add(-time.Hour), nil),
+	}, nil)
+	signer.EXPECT().Sign("kid-1", []byte("private-kid-1"), gomock.Any()).
+		DoAndReturn(func(_ string, _ []byte, claims *types.AccessTokenClaims) (string, error) {
+			assert.Equal(t, "partner-app", claims.ClientID)
+			assert.Equal(t, "openid email", claims.Scope)
+			assert.Equal(t, "alice@example.com", claims.Email)
+			assert.Empty(t, claims.Phone)
+			return "access-token", nil
+		})
+	signer.EXPECT().SignIDToken("kid-1", []byte("private-kid-1"), gomock.Any()).
+		DoAndReturn(func(_ string, _ []byte, claims *types.IDTokenClaims) (string, error) {
+			assert.Equal(t, "https://iam.example.com/oidc/"+tenantID.String(), claims.Issuer)
+			assert.Equal(t, "partner-app", claims.Audience)
+			assert.Equal(t, "global-1", claims.Subject)
+			assert.Equal(t, "global-1", claims.GlobalUserID)
+			assert.Equal(t, "nonce-1", claims.Nonce)
+			assert.Equal(t, "session-1", claims.SessionID)
+			return "id-token", nil
+		})
+
+	tokens, derr := u.ExchangeCode(ctx, tenantID, tokenRequest)
+	require.Nil(t, derr)
+	assert.Equal(t, "access-token", tokens.AccessToken)
+	assert.Equal(t, "id-token", tokens.IDToken)
+	assert.Equal(t, "openid email", tokens.Scope)
+
+	// A redeemed code is worthless
+	_, derr = u.ExchangeCode(ctx, tenantID, tokenRequest)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_INVALID_GRANT", derr.Code)
+}
+
+func TestExchangeCode_ConfidentialClientNeedsItsSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := uuid.New()
+	partner := newPartnerClient(tenantID)
+	partner.Public = false
+	partner.SecretHash = testCodeChallenge("unused")
+
+	clientRepo := mock_repositories.NewMockOAuthClientRepository(ctrl)
+	clientRepo.EXPECT().GetByClientID(gomock.Any(), "partner-app").Return(partner, nil)
+
+	// The code is never looked up for a client that failed to authenticate
+	u := &oidcProviderUseCase{
+		oauthClientRepo: clientRepo,
+		authCodeRepo:    mock_repositories.NewMockOAuthAuthorizationCodeRepository(ctrl),
+	}
+
+	_, derr := u.ExchangeCode(context.Background(), tenantID, &types.OIDCTokenRequest{
+		GrantType:    "authorization_code",
+		Code:         "code",
+		RedirectURI:  testRedirectURI,
+		CodeVerifier: testCodeVerifier,
+		ClientID:     "partner-app",
+	})
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_INVALID_CLIENT", derr.Code)
+}
+
+func TestExchangeCode_RefusesBannedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	code := &domain.OAuthAuthorizationCode{
+		ID:            "code-id",
+		TenantID:      tenantID.String(),
+		ClientID:      "partner-app",
+		GlobalUserID:  "global-1",
+		RedirectURI:   testRedirectURI,
+		CodeChallenge: testCodeChallenge(testCodeVerifier),
+		ExpiresAt:     time.Now().Add(time.Minute),
+	}
+
+	clientRepo := mock_repositories.NewMockOAuthClientRepository(ctrl)
+	clientRepo.EXPECT().GetByClientID(gomock.Any(), "partner-app").Return(newPartnerClient(tenantID), nil)
+
+	// MarkUsed is not expected: no token is signed for a banned user
+	codeRepo := mock_repositories.NewMockOAuthAuthorizationCodeRepository(ctrl)
+	codeRepo.EXPECT().GetByCodeHash(ctx, gomock.Any()).Return(code, nil)
+
+	statusRepo := mock_repositories.NewMockUserAccountStatusRepository(ctrl)
+	statusRepo.EXPECT().Get(ctx, tenantID.String(), "global-1").
+		Return(&domain.UserAccountStatus{Status: constants.UserStatusBanned}, nil)
+
+	u := &oidcProviderUseCase{
+		oauthClientRepo:       clientRepo,
+		authCodeRepo:          codeRepo,
+		userAccountStatusRepo: statusRepo,
+	}
+
+	_, derr := u.ExchangeCode(ctx, tenantID, &types.OIDCTokenRequest{
+		GrantType:    "authorization_code",
+		Code:         "code",
+		RedirectURI:  testRedirectURI,
+		CodeVerifier: testCodeVerifier,
+		ClientID:     "partner-app",
+	})
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_INVALID_GRANT", derr.Code)
+}
+
+func TestUserInfo_RefusesSuspendedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useOIDCProviderConfig(t)
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	keyRepo := mock_repositories.NewMockSigningKeyRepository(ctrl)
+	keyRepo.EXPECT().ListPublished(ctx, gomock.Any()).Return([]*domain.SigningKey{
+		newStoredSigningKey(t, "kid-1", time.Now().Add(-time.Hour), nil),
+	}, nil)
+
+	signer := mock_services.NewMockTokenSigner(ctrl)
+	signer.EXPECT().Verify("access-token", gomock.Any()).Return(&types.AccessTokenClaims{
+		Issuer:       conf.GetJWTIssuer(),
+		TenantID:     tenantID.String(),
+		GlobalUserID: "global-1",
+		ClientID:     "partner-app",
+		Scope:        "openid email",
+	}, nil)
+
+	clientRepo := mock_repositories.NewMockOAuthClientRepository(ctrl)
+	clientRepo.EXPECT().GetByClientID(gomock.Any(), "partner-app").Return(newPartnerClient(tenantID), nil)
+
+	statusRepo := mock_repositories.NewMockUserAccountStatusRepository(ctrl)
+	statusRepo.EXPECT().Get(ctx, tenantID.String(), "global-1").
+		Return(&domain.UserAccountStatus{Status: constants.UserStatusSuspended}, nil)
+
+	// The user's identifiers are not looked up
+	u := NewOIDCProviderUseCase(
+		mock_repositories.NewMockTenantRepository(ctrl), mock_repositories.NewMockTenantSettingRepository(ctrl),
+		clientRepo, mock_repositories.NewMockOAuthConsentRepository(ctrl), mock_repositories.NewMockOAuthAuthorizationCodeRepository(ctrl),
+		mock_repositories.NewMockUserIdentityRepository(ctrl), mock_repositories.NewMockUserIdentifierMappingRepository(ctrl), statusRepo,
+		newAuthorizationRequestCache(), mock_services.NewMockKratosService(ctrl), keyRepo, signer,
+	)
+
+	resp, derr := u.UserInfo(ctx, tenantID, "access-token")
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_INVALID_ACCESS_TOKEN", derr.Code)
+}
@@ -0,0 +1,68 @@
+package ucases
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+)
+
+// getTenantSetting loads the tenant's settings, falling back to the defaults when none are stored
+func getTenantSetting(
+	ctx context.Context,
+	repo domainrepo.TenantSettingRepository,
+	tenantID uuid.UUID,
+) (*domain.TenantSetting, *domainerrors.DomainError) {
+	setting, err := repo.GetByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_TENANT_SETTING_FAILED", "Failed to get tenant settings")
+	}
+	if setting == nil {
+		return domain.DefaultTenantSetting(tenantID), nil
+	}
+	return setting, nil
+}
+
+// validatePassword checks a candidate password against the tenant's password policy
+func validatePassword(
+	password string,
+	setting *domain.TenantSetting,
+	checker domainservice.BreachedPasswordChecker,
+) *domainerrors.DomainError {
+	minLength := setting.PasswordMinLength
+	if minLength < constants.MinPasswordMinLength {
+		minLength = constants.MinPasswordMinLength
+	}
+
+	if utf8.RuneCountInString(password) < minLength {
+		return domainerrors.NewValidationError(
+			"MSG_PASSWORD_TOO_SHORT",
+			fmt.Sprintf("Password must be at least %d characters", minLength),
+			[]interface{}{map[string]string{"field": "password", "error": "Password is too short"}},
+		)
+	}
+
+	if len(password) > constants.MaxPasswordLength {
+		return domainerrors.NewValidationError(
+			"MSG_PASSWORD_TOO_LONG",
+			fmt.Sprintf("Password must be at most %d bytes", constants.MaxPasswordLength),
+			[]interface{}{map[string]string{"field": "password", "error": "Password is too long"}},
+		)
+	}
+
+	if setting.PasswordRejectBreached && checker != nil && checker.IsBreached(password) {
+		return domainerrors.NewValidationError(
+			"MSG_PASSWORD_BREACHED",
+			"Password has appeared in a data breach, please choose another one",
+			[]interface{}{map[string]string{"field": "password", "error": "Password is known to be breached"}},
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package ucases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	mock_repositories "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/repositories"
+	mock_services "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/services"
+)
+
+func TestValidatePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	checker := mock_services.NewMockBreachedPasswordChecker(ctrl)
+	checker.EXPECT().IsBreached("password123").Return(true).AnyTimes()
+	checker.EXPECT().IsBreached(gomock.Any()).Return(false).AnyTimes()
+
+	setting := &domain.TenantSetting{PasswordMinLength: 10, PasswordRejectBreached: true}
+
+	tests := []struct {
+		name     string
+		password string
+		setting  *domain.TenantSetting
+		wantCode string
+	}{
+		{name: "too short", password: "short", setting: setting, wantCode: "MSG_PASSWORD_TOO_SHORT"},
+		{name: "too long", password: string(make([]byte, constants.MaxPasswordLength+1)), setting: setting, wantCode: "MSG_PASSWORD_TOO_LONG"},
+		{name: "breached", password: "password123", setting: setting, wantCode: "MSG_PASSWORD_BREACHED"},
+		{name: "breach check disabled", password: "password123", setting: &domain.TenantSetting{PasswordMinLength: 8}},
+		{name: "min length clamped", password: "abcde", setting: &domain.TenantSetting{PasswordMinLength: 1}, wantCode: "MSG_PASSWORD_TOO_SHORT"},
+		{name: "valid", password: "correct horse battery", setting: setting},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			derr := validatePassword(tt.password, tt.setting, checker)
+			if tt.wantCode == "" {
+				assert.Nil(t, derr)
+				return
+			}
+			require.NotNil(t, derr)
+			assert.Equal(t, tt.wantCode, derr.Code)
+		})
+	}
+}
+
+func TestGetTenantSetting_FallsBackToDefault(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	repo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	repo.EXPECT().GetByTenantID(ctx, tenantID).Return(nil, nil)
+
+	setting, derr := getTenantSetting(ctx, repo, tenantID)
+	require.Nil(t, derr)
+	assert.Equal(t, tenantID, setting.TenantID)
+	assert.Equal(t, constants.DefaultPasswordMinLength, setting.PasswordMinLength)
+	assert.True(t, setting.PasswordRejectBreached)
+}
@@ -27,6 +27,22 @@ type ChallengeSessionRepository interface {
 type GlobalUserRepository interface {
 	GetByID(ctx context.Context, id string) (*domain.GlobalUser, error)
 	Create(tx *gorm.DB, user *domain.GlobalUser) error
+	// Delete removes the user; rows referencing it are removed by cascade
+	Delete(tx *gorm.DB, id string) error
+}
+
+type SessionRefreshTokenRepository interface {
+	Create(ctx context.Context, token *domain.SessionRefreshToken) error
+	// GetByTokenHash returns nil when no token matches
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.SessionRefreshToken, error)
+	// Rotate consumes the token and stores the next one of its chain in one transaction,
+	// reporting false if the token was already rotated or revoked
+	Rotate(ctx context.Context, id string, next *domain.SessionRefreshToken, at time.Time) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string, at time.Time) error
+	// RevokeBySession revokes every refresh token issued for a Kratos session
+	RevokeBySession(ctx context.Context, tenantID, kratosSessionID string, at time.Time) error
+	// ListByKratosUserID returns every refresh token issued to the Kratos identity, oldest first
+	ListByKratosUserID(ctx context.Context, tenantID, kratosUserID string) ([]*domain.SessionRefreshToken, error)
 }
 
 type TenantRepository interface {
@@ -38,16 +54,38 @@ type TenantRepository interface {
 	GetByName(name string) (*domain.Tenant, error)
 }
 
+type TenantSettingRepository interface {
+	// GetByTenantID returns nil when the tenant has no settings row yet
+	GetByTenantID(ctx context.Context, tenantID uuid.UUID) (*domain.TenantSetting, error)
+	Upsert(ctx context.Context, setting *domain.TenantSetting) error
+}
+
 type UserIdentityChangeLogRepository interface {
 	Create(ctx context.Context, tx *gorm.DB, log *domain.UserIdentityChangeLog) error
 	ListByGlobalUserID(ctx context.Context, globalUserID uuid.UUID) ([]*domain.UserIdentityChangeLog, error)
 	ListByTenantID(ctx context.Context, tenantID uuid.UUID) ([]*domain.UserIdentityChangeLog, error)
+	// Search returns a page of the entries matching filter, newest first, with the number of
+	// matching entries
+	Search(ctx context.Context, filter IdentityChangeLogFilter, offset, limit int) ([]*domain.UserIdentityChangeLog, int64, error)
+}
+
+// IdentityChangeLogFilter selects a tenant's identity history entries. Empty fields match any entry.
+type IdentityChangeLogFilter struct {
+	TenantID     string
+	GlobalUserID string
+	Action       string
+	IdentityType string
+	Actor        string
+	From         *time.Time
+	To           *time.Time
 }
 
 type UserIdentifierMappingRepository interface {
 	GetByGlobalUserID(ctx context.Context, globalUserID string) (*domain.UserIdentifierMapping, error)
 	Create(ctx context.Context, tx *gorm.DB, mapping *domain.UserIdentifierMapping) error
 	Upsert(ctx context.Context, tx *gorm.DB, mapping *domain.UserIdentifierMapping) error
+	ListByGlobalUserIDs(ctx context.Context, globalUserIDs []string) ([]*domain.UserIdentifierMapping, error)
+	DeleteByGlobalUserID(ctx context.Context, tx *gorm.DB, globalUserID string) error
 }
 
 type UserIdentityRepository interface {
@@ -61,6 +99,242 @@ type UserIdentityRepository interface {
 	ListByTenantAndKratosUserID(ctx context.Context, tx *gorm.DB, tenantID, kratosUserID string) ([]*domain.UserIdentity, error)
 	GetByGlobalUserIDAndTenantID(ctx context.Context, tx *gorm.DB, globalUserID, tenantID string) ([]*domain.UserIdentity, error)
 	Delete(tx *gorm.DB, identityID string) error
+	// ListByGlobalUserID returns the user's identities in every tenant
+	ListByGlobalUserID(ctx context.Context, tx *gorm.DB, globalUserID string) ([]*domain.UserIdentity, error)
+	DeleteByGlobalUserID(ctx context.Context, tx *gorm.DB, globalUserID string) error
+	// SearchTenantUsers returns up to limit of the tenant's users matching filter, newest first
+	SearchTenantUsers(ctx context.Context, filter TenantUserFilter, limit int) ([]*TenantUser, error)
+	// ListByTenantAndGlobalUserIDs returns the tenant identities of the given users
+	ListByTenantAndGlobalUserIDs(ctx context.Context, tenantID string, globalUserIDs []string) ([]*domain.UserIdentity, error)
+	// ListTenantUserIDs returns up to limit of the tenant's users in ID order, starting after the
+	// given user, or from the first one when after is empty
+	ListTenantUserIDs(ctx context.Context, tenantID, after string, limit int) ([]string, error)
+}
+
+// TenantUser is a global user as seen from one tenant. CreatedAt is when the user's first
+// identity in the tenant was created.
+type TenantUser struct {
+	GlobalUserID string
+	CreatedAt    time.Time
+}
+
+// TenantUserFilter selects a tenant's users. Empty fields match any user.
+type TenantUserFilter struct {
+	TenantID string
+	// Identifier matches part of an email or phone number; it is expected in normalized form
+	Identifier  string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	// After continues a listing after this user
+	After *TenantUser
+}
+
+type UserMFARepository interface {
+	// GetFactor returns nil when the user has not started enrolling the factor
+	GetFactor(ctx context.Context, tenantID, globalUserID, factorType string) (*domain.UserMFAFactor, error)
+	// SavePendingFactor creates the factor or replaces a pending one; an enabled factor is left untouched
+	SavePendingFactor(ctx context.Context, factor *domain.UserMFAFactor) error
+	// EnableFactor enables the factor at the given step and replaces the user's recovery codes
+	EnableFactor(ctx context.Context, factor *domain.UserMFAFactor, step int64, recoveryCodes []*domain.UserMFARecoveryCode) error
+	// ConsumeStep records a used TOTP step, reporting false if that step or a later one was already used
+	ConsumeStep(ctx context.Context, factorID string, step int64) (bool, error)
+	// UseRecoveryCode consumes an unused code, reporting false when none matches
+	UseRecoveryCode(ctx context.Context, tenantID, globalUserID, codeHash string, at time.Time) (bool, error)
+	// DeleteFactor removes the factor together with the user's recovery codes
+	DeleteFactor(ctx context.Context, tenantID, globalUserID, factorType string) error
+}
+
+type UserPasskeyRepository interface {
+	Create(ctx context.Context, passkey *domain.UserPasskey) error
+	ListByGlobalUserID(ctx context.Context, tenantID, globalUserID string) ([]*domain.UserPasskey, error)
+	// GetByCredentialID returns nil when no passkey matches
+	GetByCredentialID(ctx context.Context, tenantID, credentialID string) (*domain.UserPasskey, error)
+	// RecordUse stores the authenticator state reported by a successful assertion
+	RecordUse(ctx context.Context, id string, signCount int64, backupState bool, at time.Time) error
+	// Delete removes one of the user's passkeys, reporting false when it does not exist
+	Delete(ctx context.Context, tenantID, globalUserID, id string) (bool, error)
+}
+
+type UserSessionRepository interface {
+	Create(ctx context.Context, session *domain.UserSession) error
+	// ListActive returns the user's unrevoked, unexpired sessions, oldest first
+	ListActive(ctx context.Context, tenantID, globalUserID string, now time.Time) ([]*domain.UserSession, error)
+	// ListByGlobalUserID returns all of the user's sessions, revoked and expired ones included, oldest first
+	ListByGlobalUserID(ctx context.Context, tenantID, globalUserID string) ([]*domain.UserSession, error)
+	// GetByID returns nil when no session matches
+	GetByID(ctx context.Context, tenantID, id string) (*domain.UserSession, error)
+	// GetByKratosSessionID returns nil when the session is not tracked
+	GetByKratosSessionID(ctx context.Context, tenantID, kratosSessionID string) (*domain.UserSession, error)
+	// Touch records activity on a session at most once per interval
+	Touch(ctx context.Context, tenantID, kratosSessionID string, at time.Time, interval time.Duration) error
+	Extend(ctx context.Context, tenantID, kratosSessionID string, expiresAt time.Time) error
+	Revoke(ctx context.Context, tenantID, kratosSessionID string, at time.Time) error
+}
+
+type SigningKeyRepository interface {
+	Create(ctx context.Context, key *domain.SigningKey) error
+	// ListPublished returns the keys that have not expired, oldest activation first
+	ListPublished(ctx context.Context, now time.Time) ([]*domain.SigningKey, error)
+	// ExpireCurrent schedules every key without an expiry to leave the key set at expiresAt.
+	// It reports false when the key activating at newestActivatesAt was already retired by another caller.
+	ExpireCurrent(ctx context.Context, newestActivatesAt, expiresAt time.Time) (bool, error)
+}
+
+type OAuthClientRepository interface {
+	Create(ctx context.Context, client *domain.OAuthClient) error
+	// GetByClientID returns the client whether or not it has been revoked
+	GetByClientID(ctx context.Context, clientID string) (*domain.OAuthClient, error)
+	ListByTenant(ctx context.Context, tenantID string) ([]*domain.OAuthClient, error)
+	Revoke(ctx context.Context, tenantID, clientID string, at time.Time) error
+}
+
+type OAuthConsentRepository interface {
+	// Get returns the user's consent for the client, including a revoked one
+	Get(ctx context.Context, tenantID, globalUserID, clientID string) (*domain.OAuthConsent, error)
+	// Upsert grants the consent's scopes, reinstating a revoked consent
+	Upsert(ctx context.Context, consent *domain.OAuthConsent) error
+	// ListByUser returns the user's active consents
+	ListByUser(ctx context.Context, tenantID, globalUserID string) ([]*domain.OAuthConsent, error)
+	// Revoke reports whether an active consent was revoked
+	Revoke(ctx context.Context, tenantID, globalUserID, clientID string, at time.Time) (bool, error)
+}
+
+type OAuthAuthorizationCodeRepository interface {
+	Create(ctx context.Context, code *domain.OAuthAuthorizationCode) error
+	GetByCodeHash(ctx context.Context, codeHash string) (*domain.OAuthAuthorizationCode, error)
+	// MarkUsed reports whether this call redeemed the code. It is false when the code was
+	// already used, so concurrent exchanges cannot both succeed.
+	MarkUsed(ctx context.Context, id string, at time.Time) (bool, error)
+}
+
+type AccountDeletionRepository interface {
+	Create(ctx context.Context, deletion *domain.AccountDeletion) error
+	// GetPending returns nil when the user has no pending deletion
+	GetPending(ctx context.Context, tenantID, globalUserID string) (*domain.AccountDeletion, error)
+	// ListByTenant returns the tenant's deletions, newest first
+	ListByTenant(ctx context.Context, tenantID string) ([]*domain.AccountDeletion, error)
+	// ListDue returns pending deletions scheduled at or before now, oldest first
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.AccountDeletion, error)
+	// Claim counts an attempt and pushes the deletion back to retryAt, reporting false when it
+	// is no longer due because another worker claimed it first
+	Claim(ctx context.Context, id string, now, retryAt time.Time) (bool, error)
+	RecordFailure(ctx context.Context, id, lastError string) error
+	MarkCompleted(ctx context.Context, id string, at time.Time) error
+	// Cancel reports whether a pending deletion that has not been attempted yet was cancelled
+	Cancel(ctx context.Context, tenantID, globalUserID string, at time.Time) (bool, error)
+}
+
+type UserAccountStatusRepository interface {
+	// Get returns nil when the user has no status in the tenant, which means they are active
+	Get(ctx context.Context, tenantID, globalUserID string) (*domain.UserAccountStatus, error)
+	// Upsert creates or replaces the user's status in the tenant
+	Upsert(ctx context.Context, status *domain.UserAccountStatus) error
+}
+
+type IdentifierLockoutRepository interface {
+	// Get returns nil when no wrong code was entered for the identifier
+	Get(ctx context.Context, tenantID, identifier string) (*domain.IdentifierLockout, error)
+	// RecordFailure counts a wrong code entered at now and returns the updated record. The failures
+	// and lockouts on record are forgotten first when the last failure came before resetBefore and
+	// the identifier is not locked.
+	RecordFailure(ctx context.Context, tenantID, identifier string, now, resetBefore time.Time) (*domain.IdentifierLockout, error)
+	// Lock locks the identifier out until the given time and starts counting failures afresh
+	Lock(ctx context.Context, id string, until time.Time) error
+	// Delete forgets the identifier's failures and lifts its lockout, reporting whether there was anything on record
+	Delete(ctx context.Context, tenantID, identifier string) (bool, error)
+	// ListLocked returns the tenant's identifiers locked out at now, soonest unlocked first
+	ListLocked(ctx context.Context, tenantID string, now time.Time) ([]*domain.IdentifierLockout, error)
+}
+
+type TrustedDeviceRepository interface {
+	Create(ctx context.Context, device *domain.TrustedDevice) error
+	// GetByID returns nil when the tenant has no such device
+	GetByID(ctx context.Context, tenantID, id string) (*domain.TrustedDevice, error)
+	// ListTrusted returns the user's unrevoked, unexpired devices, newest first
+	ListTrusted(ctx context.Context, tenantID, globalUserID string, now time.Time) ([]*domain.TrustedDevice, error)
+	// ListByGlobalUserID returns all of the user's devices, revoked and expired ones included, newest first
+	ListByGlobalUserID(ctx context.Context, tenantID, globalUserID string) ([]*domain.TrustedDevice, error)
+	// Touch records the device signing in at most once per interval
+	Touch(ctx context.Context, id string, at time.Time, interval time.Duration) error
+	// Revoke stops trusting one of the user's devices, reporting false when it was not trusted
+	Revoke(ctx context.Context, tenantID, globalUserID, id string, at time.Time) (bool, error)
+	// RevokeAll stops trusting every device of the user and returns how many were trusted
+	RevokeAll(ctx context.Context, tenantID, globalUserID string, at time.Time) (int64, error)
+}
+
+type LoginEventRepository interface {
+	Create(ctx context.Context, event *domain.LoginEvent) error
+	// List returns a page of the user's login events, newest first, and how many there are in all
+	List(ctx context.Context, tenantID, globalUserID string, offset, limit int) ([]*domain.LoginEvent, int64, error)
+	// ListByGlobalUserID returns all of the user's login events, newest first
+	ListByGlobalUserID(ctx context.Context, tenantID, globalUserID string) ([]*domain.LoginEvent, error)
+	// Count returns how many login events the user has
+	Count(ctx context.Context, tenantID, globalUserID string) (int64, error)
+	// HasUserAgent reports whether the user ever signed in from a client with this user agent
+	HasUserAgent(ctx context.Context, tenantID, globalUserID, userAgent string) (bool, error)
+	// ListCountries returns the countries the user signed in from, without duplicates
+	ListCountries(ctx context.Context, tenantID, globalUserID string) ([]string, error)
+}
+
+type UserImportRepository interface {
+	// Create stores the import together with its rows
+	Create(ctx context.Context, userImport *domain.UserImport, rows []*domain.UserImportRow) error
+	// GetByID returns nil when the tenant has no such import
+	GetByID(ctx context.Context, tenantID, id string) (*domain.UserImport, error)
+	// ListByTenant returns the tenant's imports, newest first
+	ListByTenant(ctx context.Context, tenantID string) ([]*domain.UserImport, error)
+	// ListDue returns pending imports scheduled at or before now, oldest first
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.UserImport, error)
+	// Claim counts an attempt and pushes the import back to retryAt, reporting false when
+	// another worker claimed it first
+	Claim(ctx context.Context, id string, now, retryAt time.Time) (bool, error)
+	// ListPendingRows returns the first rows that were not processed yet, in file order
+	ListPendingRows(ctx context.Context, importID string, limit int) ([]*domain.UserImportRow, error)
+	// SaveRows stores the outcome of processed rows and refreshes the import's counters
+	SaveRows(ctx context.Context, importID string, rows []*domain.UserImportRow) error
+	// ListFailedRows returns failed rows after the given line, in file order
+	ListFailedRows(ctx context.Context, importID string, afterLine, limit int) ([]*domain.UserImportRow, error)
+	// Reschedule makes a claimed import due again at the given time, resetting its attempts
+	Reschedule(ctx context.Context, id string, at time.Time) error
+	Complete(ctx context.Context, id string, at time.Time) error
+	RecordFailure(ctx context.Context, id, lastError string) error
+	Fail(ctx context.Context, id, lastError string, at time.Time) error
+}
+
+type UserInvitationRepository interface {
+	Create(ctx context.Context, invitation *domain.UserInvitation) error
+	// GetByID returns nil when the tenant has no such invitation
+	GetByID(ctx context.Context, tenantID, id string) (*domain.UserInvitation, error)
+	// GetOpen returns the invitation for the identifier that was neither accepted nor revoked,
+	// including an expired one, or nil
+	GetOpen(ctx context.Context, tenantID, identifierType, identifier string) (*domain.UserInvitation, error)
+	// ListByTenant returns the tenant's invitations, newest first
+	ListByTenant(ctx context.Context, tenantID string) ([]*domain.UserInvitation, error)
+	// Renew points an open invitation at a new verification flow and extends it to expiresAt
+	Renew(ctx context.Context, id, flowID string, expiresAt time.Time) error
+	// MarkAccepted reports false when the invitation was already accepted or revoked
+	MarkAccepted(ctx context.Context, tx *gorm.DB, id, globalUserID string, at time.Time) (bool, error)
+	// Revoke reports false when the invitation was already accepted or revoked
+	Revoke(ctx context.Context, id string, at time.Time) (bool, error)
+}
+
+type UserDataExportRepository interface {
+	Create(ctx context.Context, export *domain.UserDataExport) error
+	// GetByID returns nil when no export matches
+	GetByID(ctx context.Context, id string) (*domain.UserDataExport, error)
+	// GetPending returns the user's export that is still being generated, or nil
+	GetPending(ctx context.Context, tenantID, globalUserID string) (*domain.UserDataExport, error)
+	// ListDue returns pending exports scheduled at or before now, oldest first
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.UserDataExport, error)
+	// Claim counts an attempt and pushes the export back to retryAt, reporting false when
+	// another worker claimed it first
+	Claim(ctx context.Context, id string, now, retryAt time.Time) (bool, error)
+	Complete(ctx context.Context, id, payload string, at, expiresAt time.Time) error
+	RecordFailure(ctx context.Context, id, lastError string) error
+	// Fail gives up on the export; it is kept until expiresAt so the user can see it failed
+	Fail(ctx context.Context, id, lastError string, at, expiresAt time.Time) error
+	// DeleteExpired removes exports that expired before now, returning how many
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
 }
 
 type ZaloTokenRepository interface {
@@ -76,3 +350,27 @@ type ZaloTokenRepository interface {
 	// Delete removes a tenant's Zalo token configuration
 	Delete(ctx context.Context, tenantID uuid.UUID) error
 }
+
+type SecurityNotificationRepository interface {
+	Create(ctx context.Context, notification *domain.SecurityNotification) error
+	// GetByReportTokenHash returns nil when no notice carries the report token
+	GetByReportTokenHash(ctx context.Context, tokenHash string) (*domain.SecurityNotification, error)
+	// MarkReported records the report unless the notice was already reported, and tells which
+	MarkReported(ctx context.Context, id string, at time.Time) (bool, error)
+}
+
+type IdentifierQuarantineRepository interface {
+	Create(ctx context.Context, quarantine *domain.IdentifierQuarantine) error
+	// GetActive returns the newest quarantine of the identifier in effect at now that another
+	// account than exceptGlobalUserID placed, or nil. An empty exceptGlobalUserID excludes no one.
+	GetActive(ctx context.Context, tenantID, identifierHash, exceptGlobalUserID string, now time.Time) (*domain.IdentifierQuarantine, error)
+	// RequestApproval records another account asking for the quarantined identifier
+	RequestApproval(ctx context.Context, id string, at time.Time) error
+	// List returns a page of the tenant's quarantines in effect at now, soonest ending first, and
+	// how many there are in all; a non-empty identifierHash narrows it to one identifier
+	List(ctx context.Context, tenantID, identifierHash string, now time.Time, offset, limit int) ([]*domain.IdentifierQuarantine, int64, error)
+	// Release ends a quarantine early, reporting false when it is not in effect at the given time
+	Release(ctx context.Context, tenantID, id, releasedBy string, at time.Time) (bool, error)
+	// ForgetGlobalUser clears the account from the quarantines it placed, which stay in effect
+	ForgetGlobalUser(ctx context.Context, tx *gorm.DB, globalUserID string) error
+}
@@ -0,0 +1,289 @@
+package ucases
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+	"github.com/lifenetwork-ai/iam-service/packages/utils"
+)
+
+// securityNoticeTemplate is short enough for an SMS; emails carry the same text
+var securityNoticeTemplate = template.Must(template.New("security_notice").Parse(
+	`{{ .Tenant }}: {{ .Summary }} on {{ .At }}.` +
+		`{{ if .ReportURL }} If this wasn't you, freeze your account: {{ .ReportURL }}{{ end }}`,
+))
+
+type securityNotificationUseCase struct {
+	tenantRepo              domainrepo.TenantRepository
+	userIdentityRepo        domainrepo.UserIdentityRepository
+	notificationRepo        domainrepo.SecurityNotificationRepository
+	userAccountStatusRepo   domainrepo.UserAccountStatusRepository
+	userSessionRepo         domainrepo.UserSessionRepository
+	sessionRefreshTokenRepo domainrepo.SessionRefreshTokenRepository
+	kratosService           domainservice.KratosService
+	emailSender             domainservice.EmailSender
+	courierUseCase          interfaces.CourierUseCase
+	sessionCache            *SessionCache
+}
+
+func NewSecurityNotificationUseCase(
+	sessionCache *SessionCache,
+	tenantRepo domainrepo.TenantRepository,
+	userIdentityRepo domainrepo.UserIdentityRepository,
+	notificationRepo domainrepo.SecurityNotificationRepository,
+	userAccountStatusRepo domainrepo.UserAccountStatusRepository,
+	userSessionRepo domainrepo.UserSessionRepository,
+	sessionRefreshTokenRepo domainrepo.SessionRefreshTokenRepository,
+	kratosService domainservice.KratosService,
+	emailSender domainservice.EmailSender,
+	courierUseCase interfaces.CourierUseCase,
+) interfaces.SecurityNotificationUseCase {
+	return &securityNotificationUseCase{
+		tenantRepo:              tenantRepo,
+		userIdentityRepo:        userIdentityRepo,
+		notificationRepo:        notificationRepo,
+		userAccountStatusRepo:   userAccountStatusRepo,
+		userSessionRepo:         userSessionRepo,
+		sessionRefreshTokenRepo: sessionRefreshTokenRepo,
+		kratosService:           kratosService,
+		emailSender:             emailSender,
+		courierUseCase:          courierUseCase,
+		sessionCache:            sessionCache,
+	}
+}
+
+// Notify sends the notice off the request path. The request context is detached, so the notice is
+// still sent once the response is written and the request is cancelled.
+func (u *securityNotificationUseCase) Notify(ctx context.Context, tenantID uuid.UUID, notice types.SecurityNotice) {
+	go u.send(context.WithoutCancel(ctx), tenantID, notice)
+}
+
+// send records the notice with a fresh report token, then sends it to each recipient in turn
+func (u *securityNotificationUseCase) send(ctx context.Context, tenantID uuid.UUID, notice types.SecurityNotice) {
+	recipients, err := u.recipients(ctx, tenantID, notice)
+	if err != nil {
+		logger.GetLogger().Errorf("Failed to get recipients of security notice %s: %v", notice.Event, err)
+		return
+	}
+	if len(recipients) == 0 {
+		return
+	}
+	tenant, err := u.tenantRepo.GetByID(tenantID)
+	if err != nil || tenant == nil {
+		logger.GetLogger().Errorf("Failed to get tenant for security notice: %v", err)
+		return
+	}
+
+	token, err := utils.RandomToken(constants.SecurityReportTokenBytes)
+	if err != nil {
+		logger.GetLogger().Errorf("Failed to generate security report token: %v", err)
+		return
+	}
+	now := time.Now()
+	notification := &domain.SecurityNotification{
+		TenantID:        tenantID.String(),
+		GlobalUserID:    notice.GlobalUserID,
+		Event:           notice.Event,
+		IdentifierType:  notice.IdentifierType,
+		Recipients:      len(recipients),
+		ReportTokenHash: utils.HashToken(token),
+		ExpiresAt:       now.Add(constants.SecurityReportLinkTTL),
+	}
+	if notification.IdentifierType == "" {
+		notification.IdentifierType = notice.PreviousIdentifierType
+	}
+	if err := u.notificationRepo.Create(ctx, notification); err != nil {
+		// Without the record the link would not work, but the user should still hear of the change
+		logger.GetLogger().Errorf("Failed to record security notice: %v", err)
+		token = ""
+	}
+
+	message, err := securityNoticeMessage(tenant.Name, notice, now, securityReportURL(token))
+	if err != nil {
+		logger.GetLogger().Errorf("Failed to render security notice %s: %v", notice.Event, err)
+		return
+	}
+	subject := fmt.Sprintf("Security notice from %s", tenant.Name)
+	for _, recipient := range recipients {
+		switch recipient.Type {
+		case constants.IdentifierPhone.String():
+			if u.courierUseCase == nil {
+				continue
+			}
+			if derr := u.courierUseCase.DeliverMessage(ctx, tenant.Name, recipient.Value, message); derr != nil {
+				logger.GetLogger().Errorf("Failed to send security notice to %s: %v", maskIdentifier(recipient.Type, recipient.Value), derr)
+			}
+		case constants.IdentifierEmail.String():
+			if u.emailSender == nil {
+				continue
+			}
+			if err := u.emailSender.SendEmail(ctx, tenant.Name, recipient.Value, subject, message); err != nil {
+				logger.GetLogger().Errorf("Failed to send security notice to %s: %v", maskIdentifier(recipient.Type, recipient.Value), err)
+			}
+		}
+	}
+}
+
+// recipients returns the email and phone identifiers of the user, along with the one the change
+// took off the account
+func (u *securityNotificationUseCase) recipients(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	notice types.SecurityNotice,
+) ([]*domain.UserIdentity, error) {
+	identities, err := u.userIdentityRepo.GetByGlobalUserIDAndTenantID(ctx, nil, notice.GlobalUserID, tenantID.String())
+	if err != nil {
+		return nil, err
+	}
+	if notice.PreviousIdentifier != "" {
+		identities = append(identities, &domain.UserIdentity{
+			Type:  notice.PreviousIdentifierType,
+			Value: notice.PreviousIdentifier,
+		})
+	}
+
+	recipients := make([]*domain.UserIdentity, 0, len(identities))
+	seen := make(map[string]bool, len(identities))
+	for _, identity := range identities {
+		if identity.Type != constants.IdentifierEmail.String() && identity.Type != constants.IdentifierPhone.String() {
+			continue
+		}
+		if seen[identity.Value] {
+			continue
+		}
+		seen[identity.Value] = true
+		recipients = append(recipients, identity)
+	}
+	return recipients, nil
+}
+
+// securityNoticeMessage renders the notice. Identifiers are masked: the message also goes to an
+// identifier that may now belong to someone else.
+func securityNoticeMessage(tenantName string, notice types.SecurityNotice, at time.Time, reportURL string) (string, error) {
+	identifier := maskIdentifier(notice.IdentifierType, notice.Identifier)
+	previous := maskIdentifier(notice.PreviousIdentifierType, notice.PreviousIdentifier)
+	var summary string
+	switch notice.Event {
+	case constants.SecurityEventIdentifierAdded:
+		if notice.IdentifierType == constants.IdentifierEmail.String() || notice.IdentifierType == constants.IdentifierPhone.String() {
+			summary = fmt.Sprintf("%s was added to your account", identifier)
+		} else {
+			// A provider's subject means nothing to the user, the provider does
+			summary = fmt.Sprintf("A %s sign-in was linked to your account", notice.IdentifierType)
+		}
+	case constants.SecurityEventIdentifierChanged:
+		summary = fmt.Sprintf("%s on your account was replaced with %s", previous, identifier)
+	case constants.SecurityEventIdentifierDeleted:
+		summary = fmt.Sprintf("%s was removed from your account", previous)
+	case constants.SecurityEventNewDeviceLogin:
+		summary = "Your account was signed in to from a new device"
+		if notice.Country != "" {
+			summary += " in " + notice.Country
+		}
+	default:
+		return "", fmt.Errorf("unknown security event %q", notice.Event)
+	}
+	if notice.ByAdmin {
+		summary += " by an administrator"
+	}
+
+	var message strings.Builder
+	err := securityNoticeTemplate.Execute(&message, map[string]string{
+		"Tenant":    tenantName,
+		"Summary":   summary,
+		"At":        at.UTC().Format("2006-01-02 15:04 UTC"),
+		"ReportURL": reportURL,
+	})
+	return message.String(), err
+}
+
+// securityReportURL links the report page to the token, or returns nothing when either is missing
+func securityReportURL(token string) string {
+	base := conf.GetSecurityReportURL()
+	if base == "" || token == "" {
+		return ""
+	}
+	separator := "?"
+	if strings.Contains(base, "?") {
+		separator = "&"
+	}
+	return base + separator + url.Values{"token": {token}}.Encode()
+}
+
+// ReportUnrecognized freezes the account once per notice. A link used again after an
+// administrator reviewed the account and let the user back in does not freeze it a second time.
+func (u *securityNotificationUseCase) ReportUnrecognized(
+	ctx context.Context,
+	token string,
+) (*types.SecurityReportResponse, *domainerrors.DomainError) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, domainerrors.NewUnauthorizedError("MSG_INVALID_REPORT_LINK", "Invalid report link")
+	}
+	notification, err := u.notificationRepo.GetByReportTokenHash(ctx, utils.HashToken(token))
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_SECURITY_NOTICE_FAILED", "Failed to get security notice")
+	}
+	if notification == nil {
+		return nil, domainerrors.NewUnauthorizedError("MSG_INVALID_REPORT_LINK", "Invalid report link")
+	}
+
+	now := time.Now()
+	status, err := u.userAccountStatusRepo.Get(ctx, notification.TenantID, notification.GlobalUserID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_USER_STATUS_FAILED", "Failed to get user status")
+	}
+	if notification.ReportedAt != nil {
+		return &types.SecurityReportResponse{Status: status.EffectiveStatus(now), ReportedAt: *notification.ReportedAt}, nil
+	}
+	if !now.Before(notification.ExpiresAt) {
+		return nil, domainerrors.NewUnauthorizedError("MSG_REPORT_LINK_EXPIRED", "Report link has expired")
+	}
+
+	tenantID, err := uuid.Parse(notification.TenantID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_INVALID_TENANT_ID", "Invalid tenant ID")
+	}
+	// A ban is already stricter than the freeze
+	if status.EffectiveStatus(now) != constants.UserStatusBanned {
+		status = &domain.UserAccountStatus{
+			TenantID:     notification.TenantID,
+			GlobalUserID: notification.GlobalUserID,
+			Status:       constants.UserStatusSuspended,
+			Reason:       fmt.Sprintf("The user reported %s as not theirs (security notice %s)", notification.Event, notification.ID),
+			UpdatedBy:    constants.SecurityReportActor,
+		}
+		if err := u.userAccountStatusRepo.Upsert(ctx, status); err != nil {
+			return nil, domainerrors.WrapInternal(err, "MSG_UPDATE_USER_STATUS_FAILED", "Failed to freeze the account")
+		}
+		logger.GetLogger().Warnf("User %s of tenant %s frozen after reporting security notice %s",
+			notification.GlobalUserID, notification.TenantID, notification.ID)
+		u.sessionCache.invalidateUser(notification.GlobalUserID)
+	}
+	if err := revokeUserSessions(
+		ctx, tenantID, notification.GlobalUserID, u.kratosService, u.userSessionRepo, u.sessionRefreshTokenRepo, u.sessionCache,
+	); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_REVOKE_SESSION_FAILED", "Failed to sign the user out")
+	}
+
+	// The account is frozen before the report is recorded, so a failure here leaves the link usable
+	if _, err := u.notificationRepo.MarkReported(ctx, notification.ID, now); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_RECORD_REPORT_FAILED", "Failed to record the report")
+	}
+	return &types.SecurityReportResponse{Status: status.Status, ReportedAt: now}, nil
+}
@@ -0,0 +1,235 @@
+package ucases
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	mock_interfaces "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/interfaces"
+	mock_repositories "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/repositories"
+	mock_services "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/services"
+	"github.com/lifenetwork-ai/iam-service/packages/utils"
+)
+
+func TestNotify_TellsEveryIdentifierIncludingTheReplacedOne(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	previousURL := conf.GetConfiguration().Notification.ReportURL
+	conf.GetConfiguration().Notification.ReportURL = "https://app.example.com/security/report"
+	t.Cleanup(func() { conf.GetConfiguration().Notification.ReportURL = previousURL })
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	globalUserID := uuid.NewString()
+
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	identityRepo.EXPECT().GetByGlobalUserIDAndTenantID(ctx, nil, globalUserID, tenantID.String()).Return([]*domain.UserIdentity{
+		{Type: constants.IdentifierPhone.String(), Value: "+84907654321"},
+		{Type: constants.IdentifierEmail.String(), Value: "user@example.com"},
+	}, nil)
+
+	tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+	tenantRepo.EXPECT().GetByID(tenantID).Return(&domain.Tenant{ID: tenantID, Name: "life_ai"}, nil)
+
+	var notification *domain.SecurityNotification
+	notificationRepo := mock_repositories.NewMockSecurityNotificationRepository(ctrl)
+	notificationRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, n *domain.SecurityNotification) error {
+		notification = n
+		return nil
+	})
+
+	var messages []string
+	courier := mock_interfaces.NewMockCourierUseCase(ctrl)
+	for _, phone := range []string{"+84907654321", "+84901234567"} {
+		courier.EXPECT().DeliverMessage(ctx, "life_ai", phone, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _, message string) *domainerrors.DomainError {
+				messages = append(messages, message)
+				return nil
+			})
+	}
+
+	emailSender := mock_services.NewMockEmailSender(ctrl)
+	emailSender.EXPECT().SendEmail(ctx, "life_ai", "user@example.com", "Security notice from life_ai", gomock.Any()).Return(nil)
+
+	u := &securityNotificationUseCase{
+		tenantRepo:       tenantRepo,
+		userIdentityRepo: identityRepo,
+		notificationRepo: notificationRepo,
+		emailSender:      emailSender,
+		courierUseCase:   courier,
+	}
+
+	u.send(ctx, tenantID, types.SecurityNotice{
+		Event:                  constants.SecurityEventIdentifierChanged,
+		GlobalUserID:           globalUserID,
+		IdentifierType:         constants.IdentifierPhone.String(),
+		Identifier:             "+84907654321",
+		PreviousIdentifierType: constants.IdentifierPhone.String(),
+		PreviousIdentifier:     "+84901234567",
+	})
+
+	require.NotNil(t, notification)
+	assert.Equal(t, constants.SecurityEventIdentifierChanged, notification.Event)
+	assert.Equal(t, 3, notification.Recipients)
+	assert.WithinDuration(t, time.Now().Add(constants.SecurityReportLinkTTL), notification.ExpiresAt, time.Minute)
+
+	require.Len(t, messages, 2)
+	assert.Contains(t, messages[0], "+********567 on your account was replaced with +********321")
+	assert.NotContains(t, messages[0], "+84907654321", "identifiers are masked")
+	_, link, ok := strings.Cut(messages[0], "freeze your account: ")
+	require.True(t, ok)
+	reportURL, err := url.Parse(link)
+	require.NoError(t, err)
+	assert.Equal(t, utils.HashToken(reportURL.Query().Get("token")), notification.ReportTokenHash)
+}
+
+func TestNotify_OutlivesTheRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	tenantID := uuid.New()
+	globalUserID := uuid.NewString()
+
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	identityRepo.EXPECT().GetByGlobalUserIDAndTenantID(gomock.Any(), nil, globalUserID, tenantID.String()).Return([]*domain.UserIdentity{
+		{Type: constants.IdentifierEmail.String(), Value: "user@example.com"},
+	}, nil)
+
+	tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+	tenantRepo.EXPECT().GetByID(tenantID).Return(&domain.Tenant{ID: tenantID, Name: "life_ai"}, nil)
+
+	notificationRepo := mock_repositories.NewMockSecurityNotificationRepository(ctrl)
+	notificationRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	// Sending waits for the request to finish, then must still have a usable context
+	release := make(chan struct{})
+	sent := make(chan error, 1)
+	emailSender := mock_services.NewMockEmailSender(ctrl)
+	emailSender.EXPECT().SendEmail(gomock.Any(), "life_ai", "user@example.com", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(sendCtx context.Context, _, _, _, _ string) error {
+			<-release
+			sent <- sendCtx.Err()
+			return nil
+		})
+
+	u := &securityNotificationUseCase{
+		tenantRepo:       tenantRepo,
+		userIdentityRepo: identityRepo,
+		notificationRepo: notificationRepo,
+		emailSender:      emailSender,
+	}
+
+	u.Notify(ctx, tenantID, types.SecurityNotice{Event: constants.SecurityEventNewDeviceLogin, GlobalUserID: globalUserID})
+	cancel()
+	close(release)
+
+	select {
+	case err := <-sent:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the notice was not sent")
+	}
+}
+
+func TestReportUnrecognized_FreezesTheAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	globalUserID := uuid.NewString()
+	notification := &domain.SecurityNotification{
+		ID:           "notice-1",
+		TenantID:     tenantID.String(),
+		GlobalUserID: globalUserID,
+		Event:        constants.SecurityEventNewDeviceLogin,
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}
+
+	notificationRepo := mock_repositories.NewMockSecurityNotificationRepository(ctrl)
+	notificationRepo.EXPECT().GetByReportTokenHash(ctx, utils.HashToken("report-token")).Return(notification, nil)
+	notificationRepo.EXPECT().MarkReported(ctx, "notice-1", gomock.Any()).Return(true, nil)
+
+	statusRepo := mock_repositories.NewMockUserAccountStatusRepository(ctrl)
+	statusRepo.EXPECT().Get(ctx, tenantID.String(), globalUserID).Return(nil, nil)
+	statusRepo.EXPECT().Upsert(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, status *domain.UserAccountStatus) error {
+		assert.Equal(t, constants.UserStatusSuspended, status.Status)
+		assert.Nil(t, status.ExpiresAt, "the account stays frozen until it is reviewed")
+		assert.Equal(t, constants.SecurityReportActor, status.UpdatedBy)
+		assert.Contains(t, status.Reason, "notice-1")
+		return nil
+	})
+
+	sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	sessionRepo.EXPECT().ListActive(ctx, tenantID.String(), globalUserID, gomock.Any()).
+		Return([]*domain.UserSession{{KratosSessionID: "session-1"}}, nil)
+	sessionRepo.EXPECT().Revoke(ctx, tenantID.String(), "session-1", gomock.Any()).Return(nil)
+
+	kratos := mock_services.NewMockKratosService(ctrl)
+	kratos.EXPECT().DisableSessionAdmin(ctx, tenantID, "session-1").Return(nil)
+
+	tokenRepo := mock_repositories.NewMockSessionRefreshTokenRepository(ctrl)
+	tokenRepo.EXPECT().RevokeBySession(ctx, tenantID.String(), "session-1", gomock.Any()).Return(nil)
+
+	u := &securityNotificationUseCase{
+		notificationRepo:        notificationRepo,
+		userAccountStatusRepo:   statusRepo,
+		userSessionRepo:         sessionRepo,
+		sessionRefreshTokenRepo: tokenRepo,
+		kratosService:           kratos,
+	}
+
+	resp, derr := u.ReportUnrecognized(ctx, " report-token ")
+	require.Nil(t, derr)
+	assert.Equal(t, constants.UserStatusSuspended, resp.Status)
+}
+
+func TestReportUnrecognized_ReusedOrExpiredLink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	reportedAt := time.Now().Add(-time.Hour)
+	notificationRepo := mock_repositories.NewMockSecurityNotificationRepository(ctrl)
+	statusRepo := mock_repositories.NewMockUserAccountStatusRepository(ctrl)
+	u := &securityNotificationUseCase{notificationRepo: notificationRepo, userAccountStatusRepo: statusRepo}
+
+	// Reviewed and reactivated since: the link does not freeze the account again
+	notificationRepo.EXPECT().GetByReportTokenHash(ctx, utils.HashToken("reported")).Return(&domain.SecurityNotification{
+		ID: "notice-1", TenantID: uuid.NewString(), GlobalUserID: uuid.NewString(),
+		ExpiresAt: time.Now().Add(time.Hour), ReportedAt: &reportedAt,
+	}, nil)
+	statusRepo.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(&domain.UserAccountStatus{Status: constants.UserStatusActive}, nil)
+	resp, derr := u.ReportUnrecognized(ctx, "reported")
+	require.Nil(t, derr)
+	assert.Equal(t, constants.UserStatusActive, resp.Status)
+	assert.Equal(t, reportedAt, resp.ReportedAt)
+
+	notificationRepo.EXPECT().GetByReportTokenHash(ctx, utils.HashToken("expired")).Return(&domain.SecurityNotification{
+		ID: "notice-2", TenantID: uuid.NewString(), GlobalUserID: uuid.NewString(),
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}, nil)
+	statusRepo.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).Return(nil, nil)
+	_, derr = u.ReportUnrecognized(ctx, "expired")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_REPORT_LINK_EXPIRED", derr.Code)
+
+	notificationRepo.EXPECT().GetByReportTokenHash(ctx, utils.HashToken("unknown")).Return(nil, nil)
+	_, derr = u.ReportUnrecognized(ctx, "unknown")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_INVALID_REPORT_LINK", derr.Code)
+}
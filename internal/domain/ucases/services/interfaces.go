@@ -2,6 +2,8 @@ package domain
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,19 +13,29 @@ import (
 	kratos "github.com/ory/kratos-client-go"
 )
 
+// ErrFlowRejected is matched by the error of a flow submission Kratos turned down, such as one
+// carrying a wrong or expired code, as opposed to a failure to reach Kratos
+var ErrFlowRejected = errors.New("flow rejected")
+
 // KratosService defines the interface for interacting with Ory Kratos
 type KratosService interface {
-	// Registration flow
+	// Registration flow. For the password method the password is read from traits["password"].
 	InitializeRegistrationFlow(ctx context.Context, tenantID uuid.UUID) (*kratos.RegistrationFlow, error)
 	SubmitRegistrationFlow(ctx context.Context, tenantID uuid.UUID, flow *kratos.RegistrationFlow, method string, traits map[string]interface{}) (*kratos.SuccessfulNativeRegistration, error)
 	GetRegistrationFlow(ctx context.Context, tenantID uuid.UUID, flowID string) (*kratos.RegistrationFlow, error)
 	SubmitRegistrationFlowWithCode(ctx context.Context, tenantID uuid.UUID, flow *kratos.RegistrationFlow, code string) (*kratos.SuccessfulNativeRegistration, error)
+	SubmitRegistrationFlowWithIDToken(ctx context.Context, tenantID uuid.UUID, flow *kratos.RegistrationFlow, provider, idToken, nonce string, traits map[string]interface{}) (*kratos.SuccessfulNativeRegistration, error)
 
 	// Login flow
 	InitializeLoginFlow(ctx context.Context, tenantID uuid.UUID) (*kratos.LoginFlow, error)
 	SubmitLoginFlow(ctx context.Context, tenantID uuid.UUID, flow *kratos.LoginFlow, method string, identifier, password, code *string) (*kratos.SuccessfulNativeLogin, error)
+	SubmitLoginFlowWithIDToken(ctx context.Context, tenantID uuid.UUID, flow *kratos.LoginFlow, provider, idToken, nonce string) (*kratos.SuccessfulNativeLogin, error)
 	GetLoginFlow(ctx context.Context, tenantID uuid.UUID, flowID string) (*kratos.LoginFlow, error)
 
+	// Sending a new code for an ongoing code flow. A verification flow resends when submitted without a code.
+	ResendLoginCode(ctx context.Context, tenantID uuid.UUID, flowID, identifier string) error
+	ResendRegistrationCode(ctx context.Context, tenantID uuid.UUID, flowID string) error
+
 	// Verification flow
 	InitializeVerificationFlow(ctx context.Context, tenantID uuid.UUID) (string, error)
 	GetVerificationFlow(ctx context.Context, tenantID uuid.UUID, flowID string) (*kratos.VerificationFlow, error)
@@ -37,7 +49,7 @@ type KratosService interface {
 	RevokeSession(ctx context.Context, tenantID uuid.UUID, sessionToken string) error
 	WhoAmI(ctx context.Context, tenantID uuid.UUID, sessionToken string) (*kratos.Session, error)
 
-	// Settings flow
+	// Settings flow. For the password method the new password is read from traits["password"].
 	InitializeSettingsFlow(ctx context.Context, tenantID uuid.UUID, sessionToken string) (*kratos.SettingsFlow, error)
 	SubmitSettingsFlow(ctx context.Context, tenantID uuid.UUID, flow *kratos.SettingsFlow, sessionToken, method string, traits map[string]interface{}) (*kratos.SettingsFlow, error)
 	GetSettingsFlow(ctx context.Context, tenantID uuid.UUID, flowID, sessionToken string) (*kratos.SettingsFlow, error)
@@ -45,17 +57,72 @@ type KratosService interface {
 	// Admin API
 	CreateIdentityAdmin(ctx context.Context, tenantID uuid.UUID, traits map[string]interface{}) (*kratos.Identity, int, error)
 	GetIdentity(ctx context.Context, tenantID, identityID uuid.UUID) (*kratos.Identity, error)
+	// GetIdentityByIdentifierAdmin returns nil when no identity has the identifier
+	GetIdentityByIdentifierAdmin(ctx context.Context, tenantID uuid.UUID, identifier string) (*kratos.Identity, error)
 	UpdateIdentifierTraitAdmin(ctx context.Context, tenantID, identityID uuid.UUID, traits map[string]interface{}) error
 	DeleteIdentifierAdmin(ctx context.Context, tenantID, identityID uuid.UUID) error
 	UpdateLangAdmin(ctx context.Context, tenantID, identityID uuid.UUID, newLang string) error
+	ExtendSession(ctx context.Context, tenantID uuid.UUID, sessionID string) (*kratos.Session, error)
+	DisableSessionAdmin(ctx context.Context, tenantID uuid.UUID, sessionID string) error
 }
 
 type KetoService interface {
 	CheckPermission(ctx context.Context, request types.CheckPermissionRequest) (bool, *domainerrors.DomainError)
 	// BatchCheckPermission(ctx context.Context, dto dto.BatchCheckPermissionRequestDTO) (bool, *domainerrors.DomainError)
 	CreateRelationTuple(ctx context.Context, request types.CreateRelationTupleRequest) *domainerrors.DomainError
+	// ListRelationTuplesBySubject returns every tuple whose subject is the user
+	ListRelationTuplesBySubject(ctx context.Context, globalUserID string) ([]types.RelationTuple, *domainerrors.DomainError)
+	// DeleteRelationTuplesBySubject removes every tuple whose subject is the user
+	DeleteRelationTuplesBySubject(ctx context.Context, globalUserID string) *domainerrors.DomainError
 }
 
 type SMSProvider interface {
 	SendOTP(ctx context.Context, tenantName, receiver, channel, message string, ttl time.Duration) error
+	SendMessage(ctx context.Context, tenantName, receiver, channel, message string) error
+}
+
+// EmailSender delivers the messages IAM writes itself by email. Emails that belong to an
+// authentication flow are sent by Kratos.
+type EmailSender interface {
+	SendEmail(ctx context.Context, tenantName, to, subject, body string) error
+}
+
+// BreachedPasswordChecker reports whether a password appears in a known breach corpus
+type BreachedPasswordChecker interface {
+	IsBreached(password string) bool
+}
+
+// GeoIPLocator tells which country an IP address is located in
+type GeoIPLocator interface {
+	Country(ip string) string
+}
+
+// OIDCTokenVerifier validates ID tokens issued by social identity providers (google, apple)
+type OIDCTokenVerifier interface {
+	Verify(ctx context.Context, provider, rawIDToken, nonce string) (*types.OIDCClaims, error)
+}
+
+// PasskeyService runs the WebAuthn registration and assertion ceremonies for passkeys
+type PasskeyService interface {
+	BeginRegistration(rp types.PasskeyRelyingParty, user types.PasskeyUser, existing []*types.PasskeyCredential) (*types.PasskeyCeremony, error)
+	FinishRegistration(rp types.PasskeyRelyingParty, user types.PasskeyUser, state string, response []byte) (*types.PasskeyCredential, error)
+	BeginLogin(rp types.PasskeyRelyingParty) (*types.PasskeyCeremony, error)
+	// FinishLogin verifies an assertion for a discoverable credential found through lookup and returns its new state
+	FinishLogin(
+		rp types.PasskeyRelyingParty,
+		state string,
+		response []byte,
+		lookup func(credentialID, userHandle []byte) (*types.PasskeyCredential, error),
+	) (*types.PasskeyCredential, error)
+}
+
+// TokenSigner generates the key pairs access and ID tokens are signed with, signs them and
+// verifies access tokens against the published keys
+type TokenSigner interface {
+	GenerateKey() (*types.SigningKeyMaterial, error)
+	Sign(keyID string, privateKey []byte, claims *types.AccessTokenClaims) (string, error)
+	SignIDToken(keyID string, privateKey []byte, claims *types.IDTokenClaims) (string, error)
+	// Verify checks the signature, type and lifetime of an access token. Issuer and audience
+	// are left to the caller.
+	Verify(token string, publicKeys []json.RawMessage) (*types.AccessTokenClaims, error)
 }
@@ -0,0 +1,257 @@
+package ucases
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	cachetypes "github.com/lifenetwork-ai/iam-service/infrastructures/caching/types"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+	"github.com/lifenetwork-ai/iam-service/packages/utils"
+)
+
+// sessionCacheEntry is a session Kratos has validated, keyed by its tenant and the hash of its token
+type sessionCacheEntry struct {
+	User            types.IdentityUserResponse `json:"user"`
+	KratosSessionID string                     `json:"kratos_session_id"`
+	ValidatedAt     time.Time                  `json:"validated_at"`
+}
+
+// introspectionCacheEntry is an active introspection result, kept until the session expires
+type introspectionCacheEntry struct {
+	Response        types.IntrospectionResponse `json:"response"`
+	KratosSessionID string                      `json:"kratos_session_id"`
+	ValidatedAt     time.Time                   `json:"validated_at"`
+}
+
+// SessionCache short-circuits session validation for tokens Kratos has recently accepted.
+//
+// Entries cannot be looked up by session or user, so invalidation leaves a marker instead:
+// a revoked session's entries are dropped outright, and a user's or tenant's entries are dropped
+// when they were validated before its last change. Markers only need to outlive the entries they
+// guard, so they last as long as the longest lived entry. A nil cache is disabled.
+//
+// A single instance is shared by every use case, so invalidations made anywhere reach all
+// cached sessions and the counters describe the whole service.
+type SessionCache struct {
+	cacheRepo cachetypes.CacheRepository
+	ttl       time.Duration
+
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	invalidations atomic.Uint64
+}
+
+// NewSessionCache returns a cache of the given TTL, or nil when caching is disabled
+func NewSessionCache(cacheRepo cachetypes.CacheRepository, ttl time.Duration) *SessionCache {
+	if cacheRepo == nil || ttl <= 0 {
+		return nil
+	}
+	return &SessionCache{cacheRepo: cacheRepo, ttl: ttl}
+}
+
+// Tokens are only valid against their own tenant's Kratos, so the same token presented to
+// another tenant must miss
+func sessionCacheTokenKey(tenantID uuid.UUID, token string) *cachetypes.Keyer {
+	return &cachetypes.Keyer{Raw: "session_cache:token:" + tenantID.String() + ":" + utils.HashToken(token)}
+}
+
+func sessionCacheIntrospectionKey(tenantID uuid.UUID, token string) *cachetypes.Keyer {
+	return &cachetypes.Keyer{Raw: "session_cache:introspection:" + tenantID.String() + ":" + utils.HashToken(token)}
+}
+
+func sessionCacheRevokedKey(kratosSessionID string) *cachetypes.Keyer {
+	return &cachetypes.Keyer{Raw: "session_cache:revoked:" + kratosSessionID}
+}
+
+func sessionCacheUserKey(globalUserID string) *cachetypes.Keyer {
+	return &cachetypes.Keyer{Raw: "session_cache:user:" + globalUserID}
+}
+
+func sessionCacheTenantKey(tenantID uuid.UUID) *cachetypes.Keyer {
+	return &cachetypes.Keyer{Raw: "session_cache:tenant:" + tenantID.String()}
+}
+
+// get returns the cached user of a session token, or nil when it has to be validated again
+func (c *SessionCache) get(tenantID uuid.UUID, token string) *types.IdentityUserResponse {
+	if c == nil {
+		return nil
+	}
+
+	var entry sessionCacheEntry
+	if err := c.cacheRepo.RetrieveItem(sessionCacheTokenKey(tenantID, token), &entry); err != nil ||
+		entry.KratosSessionID == "" || c.invalidated(tenantID, entry.KratosSessionID, entry.User.GlobalUserID, entry.ValidatedAt) {
+		c.misses.Add(1)
+		return nil
+	}
+
+	c.hits.Add(1)
+	user := entry.User
+	return &user
+}
+
+// getIntrospection returns the cached introspection result of an active token, or nil when it
+// has to be introspected again
+func (c *SessionCache) getIntrospection(tenantID uuid.UUID, token string) *types.IntrospectionResponse {
+	if c == nil {
+		return nil
+	}
+
+	var entry introspectionCacheEntry
+	if err := c.cacheRepo.RetrieveItem(sessionCacheIntrospectionKey(tenantID, token), &entry); err != nil ||
+		entry.KratosSessionID == "" || c.invalidated(tenantID, entry.KratosSessionID, entry.Response.Subject, entry.ValidatedAt) {
+		c.misses.Add(1)
+		return nil
+	}
+
+	c.hits.Add(1)
+	resp := entry.Response
+	return &resp
+}
+
+// invalidated reports whether the session was revoked, or the user or tenant changed, since validatedAt
+func (c *SessionCache) invalidated(tenantID uuid.UUID, kratosSessionID, globalUserID string, validatedAt time.Time) bool {
+	var revokedAt time.Time
+	if err := c.cacheRepo.RetrieveItem(sessionCacheRevokedKey(kratosSessionID), &revokedAt); err == nil {
+		return true
+	}
+	for _, key := range []*cachetypes.Keyer{sessionCacheUserKey(globalUserID), sessionCacheTenantKey(tenantID)} {
+		var changedAt time.Time
+		if err := c.cacheRepo.RetrieveItem(key, &changedAt); err == nil && !validatedAt.After(changedAt) {
+			return true
+		}
+	}
+	return false
+}
+
+// put caches a validated session until the cache TTL or the session's expiry, whichever is
+// sooner. validatedAt must be taken before asking Kratos, so an invalidation racing the
+// lookup still wins.
+func (c *SessionCache) put(
+	tenantID uuid.UUID,
+	token string,
+	user *types.IdentityUserResponse,
+	kratosSessionID string,
+	expiresAt *time.Time,
+	validatedAt time.Time,
+) {
+	if c == nil || user == nil || user.GlobalUserID == "" || kratosSessionID == "" {
+		return
+	}
+
+	ttl := c.ttl
+	if expiresAt != nil {
+		if remaining := time.Until(*expiresAt); remaining < ttl {
+			ttl = remaining
+		}
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	entry := sessionCacheEntry{User: *user, KratosSessionID: kratosSessionID, ValidatedAt: validatedAt}
+	if err := c.cacheRepo.SaveItem(sessionCacheTokenKey(tenantID, token), entry, ttl); err != nil {
+		logger.GetLogger().Errorf("Failed to cache session: %v", err)
+	}
+}
+
+// putIntrospection caches an active introspection result until the session expires, capped at
+// IntrospectionCacheMaxTTL. Like put, validatedAt must be taken before asking Kratos.
+func (c *SessionCache) putIntrospection(
+	tenantID uuid.UUID,
+	token string,
+	resp *types.IntrospectionResponse,
+	kratosSessionID string,
+	validatedAt time.Time,
+) {
+	if c == nil || resp == nil || !resp.Active || resp.Subject == "" || kratosSessionID == "" {
+		return
+	}
+
+	ttl := constants.IntrospectionCacheMaxTTL
+	if resp.ExpiresAt > 0 {
+		if remaining := time.Until(time.Unix(resp.ExpiresAt, 0)); remaining < ttl {
+			ttl = remaining
+		}
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	entry := introspectionCacheEntry{Response: *resp, KratosSessionID: kratosSessionID, ValidatedAt: validatedAt}
+	if err := c.cacheRepo.SaveItem(sessionCacheIntrospectionKey(tenantID, token), entry, ttl); err != nil {
+		logger.GetLogger().Errorf("Failed to cache introspection result: %v", err)
+	}
+}
+
+// remove drops the cached validation of a session token
+func (c *SessionCache) remove(tenantID uuid.UUID, token string) {
+	if c == nil {
+		return
+	}
+	c.invalidations.Add(1)
+	if err := c.cacheRepo.RemoveItem(sessionCacheTokenKey(tenantID, token)); err != nil {
+		logger.GetLogger().Errorf("Failed to remove cached session: %v", err)
+	}
+	if err := c.cacheRepo.RemoveItem(sessionCacheIntrospectionKey(tenantID, token)); err != nil {
+		logger.GetLogger().Errorf("Failed to remove cached introspection result: %v", err)
+	}
+}
+
+// invalidateSession stops serving a revoked session from cache
+func (c *SessionCache) invalidateSession(kratosSessionID string) {
+	if c == nil || kratosSessionID == "" {
+		return
+	}
+	c.invalidations.Add(1)
+	if err := c.cacheRepo.SaveItem(sessionCacheRevokedKey(kratosSessionID), time.Now(), c.markerTTL()); err != nil {
+		logger.GetLogger().Errorf("Failed to invalidate cached session %s: %v", kratosSessionID, err)
+	}
+}
+
+// invalidateUser stops serving every session of a user validated before now, so profile
+// changes show up on the next request
+func (c *SessionCache) invalidateUser(globalUserID string) {
+	if c == nil || globalUserID == "" {
+		return
+	}
+	c.invalidations.Add(1)
+	if err := c.cacheRepo.SaveItem(sessionCacheUserKey(globalUserID), time.Now(), c.markerTTL()); err != nil {
+		logger.GetLogger().Errorf("Failed to invalidate cached sessions of user %s: %v", globalUserID, err)
+	}
+}
+
+// invalidateTenant stops serving every session of a tenant validated before now, so changes to
+// its settings apply to sessions that are already signed in
+func (c *SessionCache) invalidateTenant(tenantID uuid.UUID) {
+	if c == nil {
+		return
+	}
+	c.invalidations.Add(1)
+	if err := c.cacheRepo.SaveItem(sessionCacheTenantKey(tenantID), time.Now(), c.markerTTL()); err != nil {
+		logger.GetLogger().Errorf("Failed to invalidate cached sessions of tenant %s: %v", tenantID, err)
+	}
+}
+
+func (c *SessionCache) markerTTL() time.Duration {
+	return max(c.ttl, constants.IntrospectionCacheMaxTTL)
+}
+
+func (c *SessionCache) stats() *types.SessionCacheStats {
+	if c == nil {
+		return &types.SessionCacheStats{}
+	}
+	stats := &types.SessionCacheStats{
+		Enabled:       true,
+		TTLSeconds:    c.ttl.Seconds(),
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Invalidations: c.invalidations.Load(),
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(total)
+	}
+	return stats
+}
@@ -0,0 +1,188 @@
+package ucases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	client "github.com/ory/kratos-client-go"
+	gocache "github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	"github.com/lifenetwork-ai/iam-service/infrastructures/caching"
+	"github.com/lifenetwork-ai/iam-service/internal/delivery/dto"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	mock_repositories "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/repositories"
+	mock_services "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/services"
+)
+
+var testCacheTenantID = uuid.New()
+
+func newTestSessionCache(ttl time.Duration) *SessionCache {
+	cacheRepo := caching.NewCachingRepository(context.Background(), caching.NewGoCacheClient(gocache.New(time.Minute, time.Minute)))
+	return NewSessionCache(cacheRepo, ttl)
+}
+
+func TestSessionCache_Invalidation(t *testing.T) {
+	user := &types.IdentityUserResponse{ID: "kratos-1", GlobalUserID: "global-1", Email: "alice@example.com"}
+
+	tests := []struct {
+		name       string
+		invalidate func(c *SessionCache)
+		wantHit    bool
+	}{
+		{"serves a cached session", func(c *SessionCache) {}, true},
+		{"logout removes the token", func(c *SessionCache) { c.remove(testCacheTenantID, "token-1") }, false},
+		{"revocation drops the session", func(c *SessionCache) { c.invalidateSession("session-1") }, false},
+		{"revoking another session keeps it", func(c *SessionCache) { c.invalidateSession("session-2") }, true},
+		{"user change drops the session", func(c *SessionCache) { c.invalidateUser("global-1") }, false},
+		{"another user's change keeps it", func(c *SessionCache) { c.invalidateUser("global-2") }, true},
+		{"tenant change drops the session", func(c *SessionCache) { c.invalidateTenant(testCacheTenantID) }, false},
+		{"another tenant's change keeps it", func(c *SessionCache) { c.invalidateTenant(uuid.New()) }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestSessionCache(time.Minute)
+			c.put(testCacheTenantID, "token-1", user, "session-1", nil, time.Now())
+			tt.invalidate(c)
+
+			got := c.get(testCacheTenantID, "token-1")
+			if !tt.wantHit {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, *user, *got)
+		})
+	}
+}
+
+func TestSessionCache_ScopedToTenant(t *testing.T) {
+	c := newTestSessionCache(time.Minute)
+	c.put(testCacheTenantID, "token-1", &types.IdentityUserResponse{GlobalUserID: "global-1"}, "session-1", nil, time.Now())
+
+	assert.NotNil(t, c.get(testCacheTenantID, "token-1"))
+	assert.Nil(t, c.get(uuid.New(), "token-1"))
+}
+
+func TestUpdateTenantSetting_InvalidatesSharedSessionCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenant := &domain.Tenant{ID: testCacheTenantID, Name: "genetica"}
+	cache := newTestSessionCache(time.Minute)
+
+	tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+	tenantRepo.EXPECT().GetByID(tenant.ID).Return(tenant, nil)
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenant.ID).Return(nil, nil)
+	settingRepo.EXPECT().Upsert(ctx, gomock.Any()).Return(nil)
+
+	// The admin use case shares the cache the identity use case validates sessions with
+	admin := &adminUseCase{tenantRepo: tenantRepo, tenantSettingRepo: settingRepo, sessionCache: cache}
+	cache.put(tenant.ID, "token-1", &types.IdentityUserResponse{GlobalUserID: "global-1"}, "session-1", nil, time.Now())
+
+	mfaRequired := true
+	_, derr := admin.UpdateTenantSetting(ctx, tenant.ID.String(), dto.UpdateTenantSettingPayloadDTO{MFARequired: &mfaRequired})
+	require.Nil(t, derr)
+	assert.Nil(t, cache.get(tenant.ID, "token-1"))
+}
+
+func TestSessionCache_ValidatedBeforeUserChange(t *testing.T) {
+	c := newTestSessionCache(time.Minute)
+	user := &types.IdentityUserResponse{ID: "kratos-1", GlobalUserID: "global-1"}
+
+	// A lookup that started before the change must not repopulate the cache with stale data
+	validatedAt := time.Now()
+	c.invalidateUser("global-1")
+	c.put(testCacheTenantID, "token-1", user, "session-1", nil, validatedAt)
+	assert.Nil(t, c.get(testCacheTenantID, "token-1"))
+
+	c.put(testCacheTenantID, "token-1", user, "session-1", nil, time.Now())
+	assert.NotNil(t, c.get(testCacheTenantID, "token-1"))
+}
+
+func TestSessionCache_CappedAtSessionExpiry(t *testing.T) {
+	c := newTestSessionCache(time.Minute)
+	user := &types.IdentityUserResponse{ID: "kratos-1", GlobalUserID: "global-1"}
+
+	expired := time.Now().Add(-time.Second)
+	c.put(testCacheTenantID, "token-1", user, "session-1", &expired, time.Now())
+	assert.Nil(t, c.get(testCacheTenantID, "token-1"))
+
+	expiring := time.Now().Add(50 * time.Millisecond)
+	c.put(testCacheTenantID, "token-2", user, "session-2", &expiring, time.Now())
+	assert.NotNil(t, c.get(testCacheTenantID, "token-2"))
+	time.Sleep(100 * time.Millisecond)
+	assert.Nil(t, c.get(testCacheTenantID, "token-2"))
+}
+
+func TestSessionCache_Stats(t *testing.T) {
+	var disabled *SessionCache
+	assert.Nil(t, disabled.get(uuid.New(), "token-1"))
+	assert.Equal(t, &types.SessionCacheStats{}, disabled.stats())
+	assert.Nil(t, NewSessionCache(nil, time.Minute))
+
+	c := newTestSessionCache(30 * time.Second)
+	c.put(testCacheTenantID, "token-1", &types.IdentityUserResponse{GlobalUserID: "global-1"}, "session-1", nil, time.Now())
+	c.get(testCacheTenantID, "token-1")
+	c.get(testCacheTenantID, "token-1")
+	c.get(testCacheTenantID, "token-2")
+	c.invalidateSession("session-1")
+
+	assert.Equal(t, &types.SessionCacheStats{
+		Enabled:       true,
+		TTLSeconds:    30,
+		Hits:          2,
+		Misses:        1,
+		Invalidations: 1,
+		HitRatio:      2.0 / 3.0,
+	}, c.stats())
+}
+
+func TestProfile_ServedFromSessionCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantID := uuid.New()
+	ctx := context.WithValue(context.Background(), constants.SessionTokenKey, "token-1")
+	kratos := mock_services.NewMockKratosService(ctrl)
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	settingRepo := mock_repositories.NewMockTenantSettingRepository(ctrl)
+	ucase := &userUseCase{
+		kratosService:         kratos,
+		userAccountStatusRepo: activeAccountStatusRepo(ctrl),
+		userIdentityRepo:      identityRepo,
+		userSessionRepo:       sessionRepo,
+		tenantSettingRepo:     settingRepo,
+		sessionCache:          newTestSessionCache(time.Minute),
+	}
+
+	// Kratos and the database are only asked once
+	kratos.EXPECT().WhoAmI(ctx, tenantID, "token-1").Return(&client.Session{
+		Id:       "session-1",
+		Identity: &client.Identity{Id: "kratos-1", Traits: map[string]interface{}{"tenant": "genetica"}},
+	}, nil)
+	sessionRepo.EXPECT().Touch(ctx, tenantID.String(), "session-1", gomock.Any(), constants.SessionActivityInterval).Return(nil)
+	identityRepo.EXPECT().ListByTenantAndKratosUserID(ctx, nil, tenantID.String(), "kratos-1").Return([]*domain.UserIdentity{
+		{GlobalUserID: "global-1", Type: constants.IdentifierEmail.String(), Value: "alice@example.com"},
+	}, nil)
+	settingRepo.EXPECT().GetByTenantID(ctx, tenantID).Return(nil, nil)
+
+	first, derr := ucase.Profile(ctx, tenantID)
+	require.Nil(t, derr)
+	second, derr := ucase.Profile(ctx, tenantID)
+	require.Nil(t, derr)
+
+	assert.Equal(t, first, second)
+	assert.Equal(t, "global-1", second.GlobalUserID)
+	assert.Equal(t, "alice@example.com", second.Email)
+	assert.Equal(t, uint64(1), ucase.SessionCacheStats().Hits)
+}
@@ -0,0 +1,134 @@
+package ucases
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+	"github.com/lifenetwork-ai/iam-service/packages/utils"
+)
+
+// signingKeyring picks the keys access and ID tokens are signed with
+type signingKeyring struct {
+	repo   domainrepo.SigningKeyRepository
+	signer domainservice.TokenSigner
+
+	// Published keys are reloaded at most every SigningKeyCacheTTL
+	mu       sync.Mutex
+	keys     []*domain.SigningKey
+	loadedAt time.Time
+}
+
+func newSigningKeyring(repo domainrepo.SigningKeyRepository, signer domainservice.TokenSigner) *signingKeyring {
+	return &signingKeyring{repo: repo, signer: signer}
+}
+
+// activeKey returns the newest activated key, creating the very first key on demand.
+// Nobody can hold a key set yet at that point, so it signs right away.
+func (k *signingKeyring) activeKey(ctx context.Context, now time.Time) (*domain.SigningKey, *domainerrors.DomainError) {
+	keys, derr := k.publishedKeys(ctx, now)
+	if derr != nil {
+		return nil, derr
+	}
+	for i := len(keys) - 1; i >= 0; i-- {
+		if !keys[i].ActivatesAt.After(now) {
+			return keys[i], nil
+		}
+	}
+	if len(keys) > 0 {
+		return nil, domainerrors.NewInternalError("MSG_NO_ACTIVE_SIGNING_KEY", "No signing key is active yet")
+	}
+	return k.createKey(ctx, now)
+}
+
+// publishedKeys returns the unexpired keys, reloading them when the cached copy is stale
+func (k *signingKeyring) publishedKeys(ctx context.Context, now time.Time) ([]*domain.SigningKey, *domainerrors.DomainError) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.keys == nil || now.Sub(k.loadedAt) >= constants.SigningKeyCacheTTL {
+		keys, err := k.repo.ListPublished(ctx, now)
+		if err != nil {
+			return nil, domainerrors.WrapInternal(err, "MSG_LIST_SIGNING_KEYS_FAILED", "Failed to list signing keys")
+		}
+		k.keys = keys
+		k.loadedAt = now
+	}
+
+	published := make([]*domain.SigningKey, 0, len(k.keys))
+	for _, key := range k.keys {
+		if key.ExpiresAt == nil || key.ExpiresAt.After(now) {
+			published = append(published, key)
+		}
+	}
+	return published, nil
+}
+
+func (k *signingKeyring) createKey(ctx context.Context, activatesAt time.Time) (*domain.SigningKey, *domainerrors.DomainError) {
+	material, err := k.signer.GenerateKey()
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GENERATE_SIGNING_KEY_FAILED", "Failed to generate signing key")
+	}
+	encrypted, derr := encryptSigningKey(material.PrivateKey)
+	if derr != nil {
+		return nil, derr
+	}
+
+	key := &domain.SigningKey{
+		KeyID:       material.KeyID,
+		Algorithm:   material.Algorithm,
+		PrivateKey:  encrypted,
+		PublicKey:   string(material.PublicJWK),
+		ActivatesAt: activatesAt,
+	}
+	if err := k.repo.Create(ctx, key); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_CREATE_SIGNING_KEY_FAILED", "Failed to store signing key")
+	}
+	logger.GetLogger().Infof("Created signing key %s, signing from %s", key.KeyID, activatesAt.Format(time.RFC3339))
+
+	k.mu.Lock()
+	k.keys = nil
+	k.mu.Unlock()
+	return key, nil
+}
+
+// signingKeyEncryptionKey derives the AES key for private signing keys from the database encryption key
+func signingKeyEncryptionKey() ([32]byte, *domainerrors.DomainError) {
+	dbKey := conf.GetConfiguration().DbEncryptionKey
+	if dbKey == "" {
+		return [32]byte{}, domainerrors.WrapInternal(errors.New("db encryption key is empty"), "MSG_ACCESS_TOKENS_NOT_CONFIGURED", "Access tokens are not configured")
+	}
+	return utils.DeriveKey(dbKey, constants.KeyPurposeSigningKey), nil
+}
+
+func encryptSigningKey(privateKey []byte) (string, *domainerrors.DomainError) {
+	key, derr := signingKeyEncryptionKey()
+	if derr != nil {
+		return "", derr
+	}
+	encrypted, err := utils.Encrypt(key, string(privateKey))
+	if err != nil {
+		return "", domainerrors.WrapInternal(err, "MSG_ENCRYPT_SIGNING_KEY_FAILED", "Failed to encrypt signing key")
+	}
+	return encrypted, nil
+}
+
+func decryptSigningKey(encrypted string) ([]byte, *domainerrors.DomainError) {
+	key, derr := signingKeyEncryptionKey()
+	if derr != nil {
+		return nil, derr
+	}
+	privateKey, err := utils.Decrypt(key, encrypted)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_DECRYPT_SIGNING_KEY_FAILED", "Failed to decrypt signing key")
+	}
+	return []byte(privateKey), nil
+}
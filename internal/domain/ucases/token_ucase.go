@@ -0,0 +1,197 @@
+package ucases
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+)
+
+type tokenUseCase struct {
+	signingKeyRepo domainrepo.SigningKeyRepository
+	kratosService  domainservice.KratosService
+	signer         domainservice.TokenSigner
+	keys           *signingKeyring
+}
+
+func NewTokenUseCase(
+	signingKeyRepo domainrepo.SigningKeyRepository,
+	kratosService domainservice.KratosService,
+	signer domainservice.TokenSigner,
+) interfaces.TokenUseCase {
+	return &tokenUseCase{
+		signingKeyRepo: signingKeyRepo,
+		kratosService:  kratosService,
+		signer:         signer,
+		keys:           newSigningKeyring(signingKeyRepo, signer),
+	}
+}
+
+// IssueAccessToken exchanges the session the request was authenticated with for a signed access
+// token. The token never outlives the session.
+func (u *tokenUseCase) IssueAccessToken(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	user *types.IdentityUserResponse,
+) (*types.AccessTokenResponse, *domainerrors.DomainError) {
+	sessionToken, derr := extractSessionToken(ctx)
+	if derr != nil {
+		return nil, derr
+	}
+	session, err := u.kratosService.GetSession(ctx, tenantID, sessionToken)
+	if err != nil || session == nil || session.Identity == nil || session.Identity.Id != user.ID {
+		return nil, domainerrors.NewUnauthorizedError("MSG_INVALID_SESSION", "Invalid session").WithCause(err)
+	}
+
+	now := time.Now()
+	key, derr := u.keys.activeKey(ctx, now)
+	if derr != nil {
+		return nil, derr
+	}
+	privateKey, derr := decryptSigningKey(key.PrivateKey)
+	if derr != nil {
+		return nil, derr
+	}
+
+	expiresAt := now.Add(conf.GetAccessTokenTTL())
+	if session.ExpiresAt != nil && session.ExpiresAt.Before(expiresAt) {
+		expiresAt = *session.ExpiresAt
+	}
+	var aal string
+	if session.AuthenticatorAssuranceLevel != nil {
+		aal = string(*session.AuthenticatorAssuranceLevel)
+	}
+
+	token, err := u.signer.Sign(key.KeyID, privateKey, &types.AccessTokenClaims{
+		ID:           uuid.NewString(),
+		Issuer:       conf.GetJWTIssuer(),
+		Audience:     conf.GetJWTAudience(),
+		Subject:      user.GlobalUserID,
+		IssuedAt:     now,
+		ExpiresAt:    expiresAt,
+		GlobalUserID: user.GlobalUserID,
+		TenantID:     tenantID.String(),
+		Tenant:       user.Tenant,
+		KratosUserID: user.ID,
+		SessionID:    session.Id,
+		AAL:          aal,
+		Email:        user.Email,
+		Phone:        user.Phone,
+		Lang:         user.Lang,
+	})
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_SIGN_ACCESS_TOKEN_FAILED", "Failed to sign access token")
+	}
+
+	return &types.AccessTokenResponse{
+		AccessToken: token,
+		TokenType:   constants.AccessTokenType,
+		ExpiresIn:   int64(expiresAt.Sub(now).Seconds()),
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// JWKS returns the public keys of every key whose tokens may still be in circulation,
+// including a rotated-in key that has not started signing yet
+func (u *tokenUseCase) JWKS(ctx context.Context) (*types.JSONWebKeySet, *domainerrors.DomainError) {
+	now := time.Now()
+	keys, derr := u.keys.publishedKeys(ctx, now)
+	if derr != nil {
+		return nil, derr
+	}
+	set := &types.JSONWebKeySet{Keys: make([]json.RawMessage, 0, len(keys))}
+	for _, k := range keys {
+		set.Keys = append(set.Keys, json.RawMessage(k.PublicKey))
+	}
+	return set, nil
+}
+
+// ListSigningKeys returns the published signing keys, oldest first
+func (u *tokenUseCase) ListSigningKeys(ctx context.Context) ([]*types.SigningKeyResponse, *domainerrors.DomainError) {
+	keys, err := u.signingKeyRepo.ListPublished(ctx, time.Now())
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_LIST_SIGNING_KEYS_FAILED", "Failed to list signing keys")
+	}
+	resp := make([]*types.SigningKeyResponse, 0, len(keys))
+	for _, k := range keys {
+		resp = append(resp, newSigningKeyResponse(k))
+	}
+	return resp, nil
+}
+
+// RotateSigningKey publishes a new key that takes over signing after SigningKeyActivationDelay.
+// The current keys stay published until the last token they sign has expired.
+func (u *tokenUseCase) RotateSigningKey(ctx context.Context) (*types.SigningKeyResponse, *domainerrors.DomainError) {
+	now := time.Now()
+	current, err := u.signingKeyRepo.ListPublished(ctx, now)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_LIST_SIGNING_KEYS_FAILED", "Failed to list signing keys")
+	}
+	if len(current) == 0 {
+		key, derr := u.keys.createKey(ctx, now)
+		if derr != nil {
+			return nil, derr
+		}
+		return newSigningKeyResponse(key), nil
+	}
+
+	key, derr := u.rotateAfter(ctx, now, current[len(current)-1])
+	if derr != nil {
+		return nil, derr
+	}
+	if key == nil {
+		return nil, domainerrors.NewConflictError("MSG_SIGNING_KEY_ROTATION_IN_PROGRESS", "The signing key is already being rotated", nil)
+	}
+	return newSigningKeyResponse(key), nil
+}
+
+// RotateSigningKeyIfDue rotates the signing key once the newest one is older than maxAge
+func (u *tokenUseCase) RotateSigningKeyIfDue(ctx context.Context, maxAge time.Duration) (bool, *domainerrors.DomainError) {
+	now := time.Now()
+	keys, err := u.signingKeyRepo.ListPublished(ctx, now)
+	if err != nil {
+		return false, domainerrors.WrapInternal(err, "MSG_LIST_SIGNING_KEYS_FAILED", "Failed to list signing keys")
+	}
+	// The first key is created on demand when a token is issued
+	if len(keys) == 0 || now.Sub(keys[len(keys)-1].ActivatesAt) < maxAge {
+		return false, nil
+	}
+	key, derr := u.rotateAfter(ctx, now, keys[len(keys)-1])
+	if derr != nil {
+		return false, derr
+	}
+	return key != nil, nil
+}
+
+// rotateAfter retires the keys up to newest and publishes their successor.
+// It returns nil when another replica retired newest first, so each cycle rotates once.
+func (u *tokenUseCase) rotateAfter(ctx context.Context, now time.Time, newest *domain.SigningKey) (*domain.SigningKey, *domainerrors.DomainError) {
+	activatesAt := now.Add(constants.SigningKeyActivationDelay)
+	retired, err := u.signingKeyRepo.ExpireCurrent(ctx, newest.ActivatesAt, activatesAt.Add(conf.GetAccessTokenTTL()))
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_ROTATE_SIGNING_KEY_FAILED", "Failed to retire the current signing key")
+	}
+	if !retired {
+		return nil, nil
+	}
+	return u.keys.createKey(ctx, activatesAt)
+}
+
+func newSigningKeyResponse(k *domain.SigningKey) *types.SigningKeyResponse {
+	return &types.SigningKeyResponse{
+		KeyID:       k.KeyID,
+		Algorithm:   k.Algorithm,
+		ActivatesAt: k.ActivatesAt,
+		ExpiresAt:   k.ExpiresAt,
+		CreatedAt:   k.CreatedAt,
+	}
+}
@@ -0,0 +1,240 @@
+package ucases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	client "github.com/ory/kratos-client-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	mock_repositories "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/repositories"
+	mock_services "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/services"
+)
+
+// useTestEncryptionKey sets the key private signing keys are sealed with for the test
+func useTestEncryptionKey(t *testing.T) {
+	previousKey := conf.GetConfiguration().DbEncryptionKey
+	conf.GetConfiguration().DbEncryptionKey = "test-db-encryption-key"
+	t.Cleanup(func() { conf.GetConfiguration().DbEncryptionKey = previousKey })
+}
+
+func newStoredSigningKey(t *testing.T, kid string, activatesAt time.Time, expiresAt *time.Time) *domain.SigningKey {
+	t.Helper()
+	encrypted, derr := encryptSigningKey([]byte("private-" + kid))
+	require.Nil(t, derr)
+	return &domain.SigningKey{
+		KeyID:       kid,
+		Algorithm:   "RS256",
+		PrivateKey:  encrypted,
+		PublicKey:   `{"kid":"` + kid + `"}`,
+		ActivatesAt: activatesAt,
+		ExpiresAt:   expiresAt,
+	}
+}
+
+func TestIssueAccessToken_CreatesFirstKeyAndCapsAtSessionExpiry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useTestEncryptionKey(t)
+	keyRepo := mock_repositories.NewMockSigningKeyRepository(ctrl)
+	kratos := mock_services.NewMockKratosService(ctrl)
+	signer := mock_services.NewMockTokenSigner(ctrl)
+	u := NewTokenUseCase(keyRepo, kratos, signer).(*tokenUseCase)
+	tenantID := uuid.New()
+	ctx := context.WithValue(context.Background(), constants.SessionTokenKey, "token-1")
+	user := &types.IdentityUserResponse{ID: "kratos-1", GlobalUserID: "global-1", Email: "alice@example.com", Lang: "en"}
+	sessionExpiresAt := time.Now().Add(5 * time.Minute)
+	aal := client.AUTHENTICATORASSURANCELEVEL_AAL2
+
+	kratos.EXPECT().GetSession(ctx, tenantID, "token-1").Return(&client.Session{
+		Id:                          "session-1",
+		Identity:                    &client.Identity{Id: "kratos-1"},
+		ExpiresAt:                   &sessionExpiresAt,
+		AuthenticatorAssuranceLevel: &aal,
+	}, nil)
+	keyRepo.EXPECT().ListPublished(ctx, gomock.Any()).Return(nil, nil)
+	signer.EXPECT().GenerateKey().Return(&types.SigningKeyMaterial{
+		KeyID:      "kid-1",
+		Algorithm:  "RS256",
+		PrivateKey: []byte("private-kid-1"),
+		PublicJWK:  json.RawMessage(`{"kid":"kid-1"}`),
+	}, nil)
+	keyRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, key *domain.SigningKey) error {
+		assert.NotContains(t, key.PrivateKey, "private-kid-1")
+		assert.WithinDuration(t, time.Now(), key.ActivatesAt, time.Second)
+		return nil
+	})
+	signer.EXPECT().Sign("kid-1", []byte("private-kid-1"), gomock.Any()).
+		DoAndReturn(func(_ string, _ []byte, claims *types.AccessTokenClaims) (string, error) {
+			assert.Equal(t, "global-1", claims.Subject)
+			assert.Equal(t, tenantID.String(), claims.TenantID)
+			assert.Equal(t, "kratos-1", claims.KratosUserID)
+			assert.Equal(t, "session-1", claims.SessionID)
+			assert.Equal(t, constants.AAL2, claims.AAL)
+			assert.Equal(t, "alice@example.com", claims.Email)
+			assert.Equal(t, "en", claims.Lang)
+			assert.Equal(t, sessionExpiresAt, claims.ExpiresAt)
+			return "signed-token", nil
+		})
+
+	resp, derr := u.IssueAccessToken(ctx, tenantID, user)
+	require.Nil(t, derr)
+	assert.Equal(t, "signed-token", resp.AccessToken)
+	assert.Equal(t, constants.AccessTokenType, resp.TokenType)
+	assert.Equal(t, sessionExpiresAt, resp.ExpiresAt)
+	assert.LessOrEqual(t, resp.ExpiresIn, int64(5*60))
+}
+
+func TestIssueAccessToken_RejectsSessionOfAnotherUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useTestEncryptionKey(t)
+	kratos := mock_services.NewMockKratosService(ctrl)
+	u := NewTokenUseCase(mock_repositories.NewMockSigningKeyRepository(ctrl), kratos, mock_services.NewMockTokenSigner(ctrl)).(*tokenUseCase)
+	tenantID := uuid.New()
+	ctx := context.WithValue(context.Background(), constants.SessionTokenKey, "token-1")
+	kratos.EXPECT().GetSession(ctx, tenantID, "token-1").Return(&client.Session{
+		Id:       "session-1",
+		Identity: &client.Identity{Id: "kratos-2"},
+	}, nil)
+
+	_, derr := u.IssueAccessToken(ctx, tenantID, &types.IdentityUserResponse{ID: "kratos-1"})
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_INVALID_SESSION", derr.Code)
+}
+
+func TestSigningKeys_OverlapDuringRotation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useTestEncryptionKey(t)
+	keyRepo := mock_repositories.NewMockSigningKeyRepository(ctrl)
+	u := NewTokenUseCase(keyRepo, mock_services.NewMockKratosService(ctrl), mock_services.NewMockTokenSigner(ctrl)).(*tokenUseCase)
+	ctx := context.Background()
+	now := time.Now()
+	retiring := now.Add(time.Hour)
+	expired := now.Add(-time.Minute)
+	keys := []*domain.SigningKey{
+		newStoredSigningKey(t, "kid-0", now.Add(-48*time.Hour), &expired),
+		newStoredSigningKey(t, "kid-1", now.Add(-24*time.Hour), &retiring),
+		newStoredSigningKey(t, "kid-2", now.Add(constants.SigningKeyActivationDelay), nil),
+	}
+	// Served from the instance cache until it goes stale
+	keyRepo.EXPECT().ListPublished(ctx, gomock.Any()).Return(keys, nil).Times(2)
+
+	// The pending key is published, but the current one keeps signing until it activates
+	set, derr := u.JWKS(ctx)
+	require.Nil(t, derr)
+	assert.Equal(t, []json.RawMessage{json.RawMessage(`{"kid":"kid-1"}`), json.RawMessage(`{"kid":"kid-2"}`)}, set.Keys)
+
+	key, derr := u.keys.activeKey(ctx, now)
+	require.Nil(t, derr)
+	assert.Equal(t, "kid-1", key.KeyID)
+
+	key, derr = u.keys.activeKey(ctx, now.Add(constants.SigningKeyActivationDelay))
+	require.Nil(t, derr)
+	assert.Equal(t, "kid-2", key.KeyID)
+}
+
+func TestRotateSigningKey_RetiresCurrentAfterItsTokensExpire(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useTestEncryptionKey(t)
+	keyRepo := mock_repositories.NewMockSigningKeyRepository(ctrl)
+	signer := mock_services.NewMockTokenSigner(ctrl)
+	u := NewTokenUseCase(keyRepo, mock_services.NewMockKratosService(ctrl), signer).(*tokenUseCase)
+	ctx := context.Background()
+	now := time.Now()
+	keyRepo.EXPECT().ListPublished(ctx, gomock.Any()).Return([]*domain.SigningKey{
+		newStoredSigningKey(t, "kid-1", now.Add(-24*time.Hour), nil),
+	}, nil)
+	keyRepo.EXPECT().ExpireCurrent(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _, expiresAt time.Time) (bool, error) {
+		want := now.Add(constants.SigningKeyActivationDelay + conf.GetAccessTokenTTL())
+		assert.WithinDuration(t, want, expiresAt, time.Second)
+		return true, nil
+	})
+	signer.EXPECT().GenerateKey().Return(&types.SigningKeyMaterial{
+		KeyID:      "kid-2",
+		Algorithm:  "RS256",
+		PrivateKey: []byte("private-kid-2"),
+		PublicJWK:  json.RawMessage(`{"kid":"kid-2"}`),
+	}, nil)
+	keyRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	resp, derr := u.RotateSigningKey(ctx)
+	require.Nil(t, derr)
+	assert.Equal(t, "kid-2", resp.KeyID)
+	assert.WithinDuration(t, now.Add(constants.SigningKeyActivationDelay), resp.ActivatesAt, time.Second)
+}
+
+func TestRotateSigningKeyIfDue(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name        string
+		activatesAt []time.Duration
+		retire      bool
+		wantRotated bool
+	}{
+		{"no key yet", nil, false, false},
+		{"current key is young", []time.Duration{-time.Hour}, false, false},
+		{"current key is old", []time.Duration{-48 * time.Hour}, true, true},
+		{"rotation already pending", []time.Duration{-48 * time.Hour, 5 * time.Minute}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			useTestEncryptionKey(t)
+			keyRepo := mock_repositories.NewMockSigningKeyRepository(ctrl)
+			signer := mock_services.NewMockTokenSigner(ctrl)
+			u := NewTokenUseCase(keyRepo, mock_services.NewMockKratosService(ctrl), signer).(*tokenUseCase)
+			ctx := context.Background()
+			var keys []*domain.SigningKey
+			for i, offset := range tt.activatesAt {
+				keys = append(keys, newStoredSigningKey(t, fmt.Sprintf("kid-%d", i), now.Add(offset), nil))
+			}
+			keyRepo.EXPECT().ListPublished(ctx, gomock.Any()).Return(keys, nil)
+			if tt.retire {
+				keyRepo.EXPECT().ExpireCurrent(ctx, keys[len(keys)-1].ActivatesAt, gomock.Any()).Return(true, nil)
+				signer.EXPECT().GenerateKey().Return(&types.SigningKeyMaterial{KeyID: "kid-new", PrivateKey: []byte("private")}, nil)
+				keyRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+			}
+
+			rotated, derr := u.RotateSigningKeyIfDue(ctx, 24*time.Hour)
+			require.Nil(t, derr)
+			assert.Equal(t, tt.wantRotated, rotated)
+		})
+	}
+}
+
+func TestRotateSigningKeyIfDue_AnotherReplicaRotatedFirst(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useTestEncryptionKey(t)
+	keyRepo := mock_repositories.NewMockSigningKeyRepository(ctrl)
+	u := NewTokenUseCase(keyRepo, mock_services.NewMockKratosService(ctrl), mock_services.NewMockTokenSigner(ctrl)).(*tokenUseCase)
+	ctx := context.Background()
+	current := newStoredSigningKey(t, "kid-1", time.Now().Add(-48*time.Hour), nil)
+	keyRepo.EXPECT().ListPublished(ctx, gomock.Any()).Return([]*domain.SigningKey{current}, nil)
+	// The key was retired between listing and expiring, so no second successor is published
+	keyRepo.EXPECT().ExpireCurrent(ctx, current.ActivatesAt, gomock.Any()).Return(false, nil)
+
+	rotated, derr := u.RotateSigningKeyIfDue(ctx, 24*time.Hour)
+	require.Nil(t, derr)
+	assert.False(t, rotated)
+}
@@ -0,0 +1,113 @@
+package ucases
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+)
+
+type trustedDeviceUseCase struct {
+	trustedDeviceRepo domainrepo.TrustedDeviceRepository
+}
+
+func NewTrustedDeviceUseCase(trustedDeviceRepo domainrepo.TrustedDeviceRepository) interfaces.TrustedDeviceUseCase {
+	return &trustedDeviceUseCase{
+		trustedDeviceRepo: trustedDeviceRepo,
+	}
+}
+
+// ListTrustedDevices returns the user's trusted devices, newest first, flagging the one making the request
+func (u *trustedDeviceUseCase) ListTrustedDevices(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+) ([]*types.TrustedDeviceResponse, *domainerrors.DomainError) {
+	devices, err := u.trustedDeviceRepo.ListTrusted(ctx, tenantID.String(), globalUserID, time.Now())
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_LIST_TRUSTED_DEVICES_FAILED", "Failed to list trusted devices")
+	}
+
+	currentID, _, _, _ := splitDeviceToken(deviceToken(ctx))
+	resp := make([]*types.TrustedDeviceResponse, 0, len(devices))
+	for _, d := range devices {
+		resp = append(resp, &types.TrustedDeviceResponse{
+			ID:         d.ID,
+			Device:     d.UserAgent,
+			IPAddress:  d.IPAddress,
+			Current:    d.ID == currentID,
+			CreatedAt:  d.CreatedAt,
+			LastUsedAt: d.LastUsedAt,
+			ExpiresAt:  d.ExpiresAt,
+		})
+	}
+	return resp, nil
+}
+
+// RevokeTrustedDevice stops trusting one of the user's devices. Its sessions are left alone; the
+// device signs in like any other from now on.
+func (u *trustedDeviceUseCase) RevokeTrustedDevice(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+	deviceID string,
+) *domainerrors.DomainError {
+	if _, err := uuid.Parse(deviceID); err != nil {
+		return domainerrors.NewNotFoundError("MSG_TRUSTED_DEVICE_NOT_FOUND", "Trusted device")
+	}
+	revoked, err := u.trustedDeviceRepo.Revoke(ctx, tenantID.String(), globalUserID, deviceID, time.Now())
+	if err != nil {
+		return domainerrors.WrapInternal(err, "MSG_REVOKE_TRUSTED_DEVICE_FAILED", "Failed to revoke trusted device")
+	}
+	if !revoked {
+		return domainerrors.NewNotFoundError("MSG_TRUSTED_DEVICE_NOT_FOUND", "Trusted device")
+	}
+	return nil
+}
+
+// RevokeTrustedDevices stops trusting every device of the user, e.g. after a phone is lost
+func (u *trustedDeviceUseCase) RevokeTrustedDevices(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+) (*types.TrustedDevicesRevokedResponse, *domainerrors.DomainError) {
+	revoked, err := u.trustedDeviceRepo.RevokeAll(ctx, tenantID.String(), globalUserID, time.Now())
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_REVOKE_TRUSTED_DEVICE_FAILED", "Failed to revoke trusted devices")
+	}
+	return &types.TrustedDevicesRevokedResponse{Revoked: revoked}, nil
+}
+
+// deviceTokenSignature binds a device token to the tenant and user it was issued to, so a token
+// copied into another account's sign-in vouches for nothing
+func deviceTokenSignature(secret string, tenantID uuid.UUID, globalUserID, deviceID, nonce string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(tenantID.String() + ":" + globalUserID + ":" + deviceID + ":" + nonce))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// newDeviceToken returns the token a trusted device presents: its ID, a random nonce and the signature
+func newDeviceToken(secret string, tenantID uuid.UUID, globalUserID, deviceID, nonce string) string {
+	return deviceID + "." + nonce + "." + deviceTokenSignature(secret, tenantID, globalUserID, deviceID, nonce)
+}
+
+// splitDeviceToken returns the parts of a device token, reporting false when it is malformed
+func splitDeviceToken(token string) (deviceID, nonce, signature string, ok bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+		return "", "", "", false
+	}
+	if _, err := uuid.Parse(parts[0]); err != nil {
+		return "", "", "", false
+	}
+	return parts[0], parts[1], parts[2], true
+}
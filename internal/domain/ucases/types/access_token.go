@@ -0,0 +1,79 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AccessTokenClaims are the claims of a signed access token
+type AccessTokenClaims struct {
+	ID        string
+	Issuer    string
+	Audience  []string
+	Subject   string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+
+	GlobalUserID string
+	TenantID     string
+	Tenant       string
+	KratosUserID string
+	SessionID    string
+	AAL          string
+	Email        string
+	Phone        string
+	Lang         string
+
+	// Set on tokens issued to an OAuth client
+	ClientID string
+	Scope    string
+}
+
+// IDTokenClaims are the claims of an OpenID Connect ID token
+type IDTokenClaims struct {
+	Issuer    string
+	Audience  string
+	Subject   string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+	AuthTime  time.Time
+	Nonce     string
+
+	GlobalUserID string
+	Tenant       string
+	SessionID    string
+	AAL          string
+	Email        string
+	Phone        string
+	Locale       string
+}
+
+// SigningKeyMaterial is a freshly generated signing key pair
+type SigningKeyMaterial struct {
+	KeyID      string
+	Algorithm  string
+	PrivateKey []byte          // PKCS #8, DER encoded
+	PublicJWK  json.RawMessage // public half as a JSON Web Key
+}
+
+// AccessTokenResponse is a signed access token exchanged for a session
+type AccessTokenResponse struct {
+	AccessToken string    `json:"access_token"`
+	TokenType   string    `json:"token_type"`
+	ExpiresIn   int64     `json:"expires_in" description:"Seconds until the token expires"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// JSONWebKeySet is the set of public keys access tokens can be verified with
+type JSONWebKeySet struct {
+	Keys []json.RawMessage `json:"keys" swaggertype:"array,object"`
+}
+
+// SigningKeyResponse describes a signing key without its private half
+type SigningKeyResponse struct {
+	KeyID       string     `json:"kid"`
+	Algorithm   string     `json:"algorithm"`
+	ActivatesAt time.Time  `json:"activates_at"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
@@ -0,0 +1,17 @@
+package types
+
+import "time"
+
+// AccountDeletionResponse is a requested erasure of a user's account
+type AccountDeletionResponse struct {
+	ID           string     `json:"id"`
+	GlobalUserID string     `json:"global_user_id"`
+	RequestedBy  string     `json:"requested_by"`
+	Status       string     `json:"status" enums:"pending,completed,cancelled"`
+	ScheduledFor time.Time  `json:"scheduled_for" description:"When the account is erased, unless the deletion is cancelled first"`
+	Attempts     int        `json:"attempts"`
+	LastError    string     `json:"last_error,omitempty"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	CancelledAt  *time.Time `json:"cancelled_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
@@ -0,0 +1,68 @@
+package types
+
+import "time"
+
+// TenantUserQuery filters and pages the tenant's user directory
+type TenantUserQuery struct {
+	// Identifier matches part of an email address or phone number
+	Identifier  string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Cursor      string
+	Limit       int
+}
+
+// TenantUserPage is a page of the tenant's user directory
+type TenantUserPage struct {
+	Items []*TenantUserSummary `json:"items"`
+	// NextCursor fetches the next page; it is empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// TenantUserSummary is a user in the tenant's user directory
+type TenantUserSummary struct {
+	GlobalUserID string               `json:"global_user_id"`
+	Identities   []TenantUserIdentity `json:"identities"`
+	CreatedAt    time.Time            `json:"created_at" description:"When the user's first identity in the tenant was created"`
+}
+
+// TenantUserIdentity is one of a user's identities in the tenant
+type TenantUserIdentity struct {
+	ID           string    `json:"id"`
+	Type         string    `json:"type"`
+	Value        string    `json:"value"`
+	KratosUserID string    `json:"kratos_user_id"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	// KratosState is the identity's state in Kratos. It is only set on the user detail, and left
+	// empty when Kratos could not return the identity.
+	KratosState string `json:"kratos_state,omitempty" enums:"active,inactive"`
+}
+
+// TenantUserDetail is everything the service knows about one of the tenant's users
+type TenantUserDetail struct {
+	GlobalUserID  string                  `json:"global_user_id"`
+	Lang          string                  `json:"lang,omitempty"`
+	CreatedAt     time.Time               `json:"created_at"`
+	Identities    []TenantUserIdentity    `json:"identities"`
+	RecentChanges []*IdentityHistoryEntry `json:"recent_changes"`
+	RecentLogins  []*LoginEventResponse   `json:"recent_logins"`
+}
+
+// TenantUserExportQuery selects how the tenant's users are exported
+type TenantUserExportQuery struct {
+	Format string
+	// Mask redacts email addresses and phone numbers, for exports shared outside the admin team
+	Mask bool
+}
+
+// TenantUserExportRecord is one user of a tenant user export
+type TenantUserExportRecord struct {
+	GlobalUserID string `json:"global_user_id"`
+	// Identifiers holds the user's identifier of each type, e.g. email and phone_number
+	Identifiers map[string]string `json:"identifiers"`
+	// KratosUserIDs holds the Kratos identity behind each identifier, by the same types
+	KratosUserIDs map[string]string `json:"kratos_user_ids"`
+	Lang          string            `json:"lang,omitempty"`
+	CreatedAt     time.Time         `json:"created_at" description:"When the user's first identity in the tenant was created"`
+}
@@ -0,0 +1,141 @@
+package types
+
+import "time"
+
+// DataExportResponse is a personal data export requested by the user
+type DataExportResponse struct {
+	ID          string     `json:"id"`
+	Status      string     `json:"status" enums:"pending,ready,failed"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty" description:"When the export is deleted"`
+	// DownloadURL is set once the export is ready. It is relative to this service, needs no
+	// session and stops working at DownloadURLExpiresAt; fetch the export again for a new one.
+	DownloadURL          string     `json:"download_url,omitempty"`
+	DownloadURLExpiresAt *time.Time `json:"download_url_expires_at,omitempty"`
+}
+
+// UserDataExportDocument is the machine-readable copy of everything stored about a global user
+type UserDataExportDocument struct {
+	GlobalUserID     string                   `json:"global_user_id"`
+	GeneratedAt      time.Time                `json:"generated_at"`
+	Lang             string                   `json:"lang,omitempty"`
+	Identities       []ExportedIdentity       `json:"identities"`
+	IdentityChanges  []ExportedIdentityChange `json:"identity_changes"`
+	KratosIdentities []ExportedKratosIdentity `json:"kratos_identities"`
+	RelationTuples   []RelationTuple          `json:"relation_tuples"`
+	LoginHistory     []ExportedLoginEvent     `json:"login_history"`
+	Sessions         []ExportedSession        `json:"sessions"`
+	RefreshTokens    []ExportedRefreshToken   `json:"refresh_tokens"`
+	TrustedDevices   []ExportedTrustedDevice  `json:"trusted_devices"`
+	Passkeys         []ExportedPasskey        `json:"passkeys"`
+	MFAFactors       []ExportedMFAFactor      `json:"mfa_factors"`
+}
+
+// ExportedIdentity is one of the user's identifiers in a tenant
+type ExportedIdentity struct {
+	TenantID     string    `json:"tenant_id"`
+	Type         string    `json:"type"`
+	Value        string    `json:"value"`
+	KratosUserID string    `json:"kratos_user_id"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// ExportedIdentityChange is an entry of the user's identifier history
+type ExportedIdentityChange struct {
+	TenantID     string    `json:"tenant_id"`
+	Action       string    `json:"action"`
+	IdentityType string    `json:"identity_type"`
+	OldValue     string    `json:"old_value,omitempty"`
+	NewValue     string    `json:"new_value,omitempty"`
+	Actor        string    `json:"actor"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ExportedKratosIdentity is the profile Kratos holds for one of the user's identities
+type ExportedKratosIdentity struct {
+	TenantID  string      `json:"tenant_id"`
+	ID        string      `json:"id"`
+	State     string      `json:"state,omitempty"`
+	Traits    interface{} `json:"traits"`
+	CreatedAt *time.Time  `json:"created_at,omitempty"`
+}
+
+// ExportedLoginEvent is a sign-in of the user, as shown in their login history
+type ExportedLoginEvent struct {
+	TenantID   string    `json:"tenant_id"`
+	Flow       string    `json:"flow"`
+	Method     string    `json:"method"`
+	Channel    string    `json:"channel"`
+	IPAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+	Country    string    `json:"country,omitempty"`
+	NewDevice  bool      `json:"new_device"`
+	NewCountry bool      `json:"new_country"`
+	RiskAction string    `json:"risk_action,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ExportedSession is a session the user signed in with, revoked and expired ones included
+type ExportedSession struct {
+	TenantID        string     `json:"tenant_id"`
+	ID              string     `json:"id"`
+	KratosSessionID string     `json:"kratos_session_id"`
+	IPAddress       string     `json:"ip_address"`
+	UserAgent       string     `json:"user_agent"`
+	AAL             string     `json:"aal"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	LastSeenAt      time.Time  `json:"last_seen_at"`
+	RevokedAt       *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// ExportedRefreshToken is a link of a session's refresh-token chain. Tokens sharing a family
+// ID belong to the same chain; the token itself is never exported.
+type ExportedRefreshToken struct {
+	TenantID        string     `json:"tenant_id"`
+	ID              string     `json:"id"`
+	FamilyID        string     `json:"family_id"`
+	ParentID        *string    `json:"parent_id,omitempty"`
+	KratosSessionID string     `json:"kratos_session_id"`
+	ChainStartedAt  time.Time  `json:"chain_started_at"`
+	ExpiresAt       time.Time  `json:"expires_at"`
+	RotatedAt       *time.Time `json:"rotated_at,omitempty"`
+	RevokedAt       *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// ExportedTrustedDevice is a device the user chose to trust, revoked and expired ones included
+type ExportedTrustedDevice struct {
+	TenantID   string     `json:"tenant_id"`
+	ID         string     `json:"id"`
+	IPAddress  string     `json:"ip_address"`
+	UserAgent  string     `json:"user_agent"`
+	LastUsedAt time.Time  `json:"last_used_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ExportedPasskey is one of the user's registered passkeys, without its public key
+type ExportedPasskey struct {
+	TenantID        string     `json:"tenant_id"`
+	ID              string     `json:"id"`
+	Name            string     `json:"name"`
+	CredentialID    string     `json:"credential_id"`
+	AttestationType string     `json:"attestation_type"`
+	Transports      string     `json:"transports,omitempty"`
+	BackupEligible  bool       `json:"backup_eligible"`
+	BackupState     bool       `json:"backup_state"`
+	LastUsedAt      *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// ExportedMFAFactor is a second factor the user enrolled, or started enrolling, without its secret
+type ExportedMFAFactor struct {
+	TenantID  string     `json:"tenant_id"`
+	Type      string     `json:"type"`
+	EnabledAt *time.Time `json:"enabled_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,11 @@
+package types
+
+import "time"
+
+// IdentifierLockoutResponse is an identifier locked out after too many wrong OTP codes
+type IdentifierLockoutResponse struct {
+	Identifier   string    `json:"identifier"`
+	Lockouts     int       `json:"lockouts" description:"Lockouts in a row, each lasting twice as long as the one before"`
+	LockedUntil  time.Time `json:"locked_until"`
+	LastFailedAt time.Time `json:"last_failed_at"`
+}
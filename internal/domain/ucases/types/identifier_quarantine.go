@@ -0,0 +1,15 @@
+package types
+
+import "time"
+
+// IdentifierQuarantineResponse is an identifier kept from other accounts after it was taken off one
+type IdentifierQuarantineResponse struct {
+	ID                  string     `json:"id"`
+	IdentifierType      string     `json:"identifier_type" enums:"email,phone_number"`
+	MaskedIdentifier    string     `json:"masked_identifier" description:"The identifier itself is only stored hashed"`
+	GlobalUserID        string     `json:"global_user_id,omitempty" description:"The account that released the identifier; only it may claim the identifier back. Left out once the account is erased"`
+	Reason              string     `json:"reason" enums:"deleted,changed,erased"`
+	ExpiresAt           time.Time  `json:"expires_at"`
+	ApprovalRequestedAt *time.Time `json:"approval_requested_at,omitempty" description:"Last time another account asked to register the identifier"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
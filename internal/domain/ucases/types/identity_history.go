@@ -0,0 +1,29 @@
+package types
+
+import "time"
+
+// IdentityHistoryQuery filters and pages a tenant's identity history. Empty fields match any entry.
+type IdentityHistoryQuery struct {
+	GlobalUserID string
+	Action       string
+	IdentityType string
+	Actor        string
+	From         *time.Time
+	To           *time.Time
+	Page         int
+	Size         int
+}
+
+// IdentityHistoryEntry is a change to a user's identifiers or language
+type IdentityHistoryEntry struct {
+	ID           string    `json:"id"`
+	GlobalUserID string    `json:"global_user_id"`
+	TenantID     string    `json:"tenant_id"`
+	Action       string    `json:"action" enums:"add,change,delete,lang_change"`
+	IdentityType string    `json:"identity_type"`
+	OldValue     string    `json:"old_value,omitempty"`
+	NewValue     string    `json:"new_value,omitempty"`
+	Actor        string    `json:"actor" enums:"user,admin,system"`
+	ActorID      string    `json:"actor_id,omitempty" description:"Username of the administrator who made the change"`
+	CreatedAt    time.Time `json:"created_at"`
+}
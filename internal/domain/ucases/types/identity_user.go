@@ -19,6 +19,9 @@ type IdentityUserResponse struct {
 	Lang         string `json:"lang"`
 	CreatedAt    int64  `json:"created_at,omitempty"`
 	UpdatedAt    int64  `json:"updated_at,omitempty"`
+	// MFAEnrollmentRequired is set while the tenant requires MFA and the user has not enrolled;
+	// the session only reaches the enrollment endpoints until they do
+	MFAEnrollmentRequired bool `json:"mfa_enrollment_required,omitempty" description:"Tenant requires MFA and the user has not enrolled; the session only reaches the enrollment endpoints"`
 }
 
 // IdentityUserChallengeDTO represents a challenge for identity verification.
@@ -28,15 +31,26 @@ type IdentityUserChallengeResponse struct {
 	ChallengeAt int64  `json:"challenge_at" description:"Time challenge was sent"`
 }
 
+// IdentityUserResendResponse represents the response for a code sent again for an ongoing challenge
+type IdentityUserResendResponse struct {
+	FlowID       string `json:"flow_id" description:"The flow ID of the challenge"`
+	Receiver     string `json:"receiver" description:"The receiver of the challenge"`
+	Channel      string `json:"channel" description:"The channel the code is sent through: email, or the channel chosen for the phone number such as sms, whatsapp or zalo"`
+	ChallengeAt  int64  `json:"challenge_at" description:"Time the code was sent"`
+	NextResendAt int64  `json:"next_resend_at" description:"Time from which another code may be asked for"`
+}
+
 // IdentityUserAuthDTO represents the response for a successful authentication with Kratos session
 type IdentityUserAuthResponse struct {
 	// Core session fields from Kratos
 	SessionID       string     `json:"session_id,omitempty"`
 	SessionToken    string     `json:"session_token,omitempty"` // Token used for authenticating subsequent requests
+	RefreshToken    string     `json:"refresh_token,omitempty"` // Single-use token for extending the session
 	Active          bool       `json:"active,omitempty"`
 	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
 	IssuedAt        *time.Time `json:"issued_at,omitempty"`
 	AuthenticatedAt *time.Time `json:"authenticated_at,omitempty"`
+	DeviceToken     string     `json:"device_token,omitempty"` // Token of the device remembered at sign-in, sent in X-Device-Token on later sign-ins
 
 	// User information
 	User *IdentityUserResponse `json:"user,omitempty"`
@@ -47,6 +61,12 @@ type IdentityUserAuthResponse struct {
 	// Verification flow (for incomplete registrations)
 	VerificationNeeded bool                           `json:"verification_needed,omitempty"`
 	VerificationFlow   *IdentityUserChallengeResponse `json:"verification_flow,omitempty"`
+
+	// Second factor: when MFARequired is set no session is returned until MFAFlow is completed
+	AAL                   string                `json:"aal,omitempty"`
+	MFARequired           bool                  `json:"mfa_required,omitempty"`
+	MFAFlow               *MFAChallengeResponse `json:"mfa_flow,omitempty"`
+	MFAEnrollmentRequired bool                  `json:"mfa_enrollment_required,omitempty"` // tenant requires MFA but the user has not enrolled yet
 }
 
 // IdentityVerificationResponse represents the response for identity verification status
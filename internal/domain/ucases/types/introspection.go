@@ -0,0 +1,17 @@
+package types
+
+// IntrospectionResponse is an RFC 7662 token introspection response. Only Active is set when the
+// token is not active.
+type IntrospectionResponse struct {
+	Active       bool   `json:"active"`
+	Subject      string `json:"sub,omitempty" description:"Global user ID"`
+	Tenant       string `json:"tenant,omitempty"`
+	TenantID     string `json:"tenant_id,omitempty"`
+	ExpiresAt    int64  `json:"exp,omitempty"`
+	IssuedAt     int64  `json:"iat,omitempty"`
+	KratosUserID string `json:"kratos_user_id,omitempty"`
+	SessionID    string `json:"sid,omitempty"`
+	AAL          string `json:"aal,omitempty"`
+	Email        string `json:"email,omitempty"`
+	Phone        string `json:"phone_number,omitempty"`
+}
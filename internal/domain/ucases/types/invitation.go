@@ -0,0 +1,19 @@
+package types
+
+import "time"
+
+// InvitationResponse is an invitation to join a tenant
+type InvitationResponse struct {
+	ID             string     `json:"id"`
+	IdentifierType string     `json:"identifier_type" enums:"email,phone_number"`
+	Identifier     string     `json:"identifier"`
+	Lang           string     `json:"lang"`
+	Roles          []string   `json:"roles"`
+	Status         string     `json:"status" enums:"pending,accepted,revoked,expired"`
+	InvitedBy      string     `json:"invited_by"`
+	GlobalUserID   string     `json:"global_user_id,omitempty" description:"The user the invitation created, once accepted"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	AcceptedAt     *time.Time `json:"accepted_at,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
@@ -0,0 +1,18 @@
+package types
+
+import "time"
+
+// LoginEventResponse is one of the user's successful logins or registrations
+type LoginEventResponse struct {
+	ID         string    `json:"id"`
+	Flow       string    `json:"flow" enums:"login,register,add_identifier,change_identifier"`
+	Method     string    `json:"method"`
+	Channel    string    `json:"channel" description:"How the code was delivered, e.g. email, sms or zalo"`
+	IPAddress  string    `json:"ip_address"`
+	Device     string    `json:"device" description:"User agent of the client"`
+	Country    string    `json:"country,omitempty" description:"ISO 3166 country code the IP address is located in"`
+	NewDevice  bool      `json:"new_device" description:"The user never signed in from this device before"`
+	NewCountry bool      `json:"new_country" description:"The user never signed in from this country before"`
+	RiskAction string    `json:"risk_action,omitempty" enums:"event,verify" description:"What the tenant's policy made of a login flagged as new"`
+	CreatedAt  time.Time `json:"created_at"`
+}
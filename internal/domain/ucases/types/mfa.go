@@ -0,0 +1,20 @@
+package types
+
+// MFAChallengeResponse identifies a sign-in waiting for its second factor
+type MFAChallengeResponse struct {
+	FlowID      string   `json:"flow_id" description:"The flow ID to complete with a second factor"`
+	Methods     []string `json:"methods" description:"Accepted second factors"`
+	ChallengeAt int64    `json:"challenge_at" description:"Time the challenge was issued"`
+	Receiver    string   `json:"receiver,omitempty" description:"Where the verification code was sent, for the verification_code method"`
+}
+
+// TOTPEnrollmentResponse carries the secret of a pending TOTP factor
+type TOTPEnrollmentResponse struct {
+	Secret          string `json:"secret" description:"Base32 secret for manual entry"`
+	ProvisioningURI string `json:"provisioning_uri" description:"otpauth:// URI to render as a QR code"`
+}
+
+// MFARecoveryCodesResponse returns recovery codes in clear text; they are not retrievable again
+type MFARecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
@@ -0,0 +1,17 @@
+package types
+
+// OIDCClaims holds the verified claims of a social provider ID token
+type OIDCClaims struct {
+	Provider      string `json:"provider"`
+	Subject       string `json:"subject"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified bool   `json:"email_verified"`
+}
+
+// IdentityLinkedResponse describes a social account linked to the current user
+type IdentityLinkedResponse struct {
+	IdentifierType string `json:"identifier_type"`
+	Subject        string `json:"subject"`
+	Email          string `json:"email,omitempty"`
+	LinkedAt       int64  `json:"linked_at"`
+}
@@ -0,0 +1,101 @@
+package types
+
+import (
+	"time"
+)
+
+// OIDCDiscoveryDocument is a tenant's OpenID Connect provider metadata
+type OIDCDiscoveryDocument struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserinfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+}
+
+// OIDCAuthorizeRequest holds the parameters of an authorization request
+type OIDCAuthorizeRequest struct {
+	ClientID            string
+	RedirectURI         string
+	ResponseType        string
+	Scope               string
+	State               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Prompt              string
+}
+
+// OIDCAuthorizationRequest is a validated authorization request waiting for the user to sign in
+// and consent
+type OIDCAuthorizationRequest struct {
+	ID            string    `json:"id"`
+	TenantID      string    `json:"tenant_id"`
+	ClientID      string    `json:"client_id"`
+	RedirectURI   string    `json:"redirect_uri"`
+	Scope         string    `json:"scope"`
+	State         string    `json:"state"`
+	Nonce         string    `json:"nonce"`
+	CodeChallenge string    `json:"code_challenge"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// OIDCAuthorizationRequestResponse describes a pending authorization request to the login UI
+type OIDCAuthorizationRequestResponse struct {
+	RequestID       string   `json:"request_id"`
+	ClientID        string   `json:"client_id"`
+	ClientName      string   `json:"client_name"`
+	Scopes          []string `json:"scopes"`
+	ConsentRequired bool     `json:"consent_required" description:"False when the user already allowed every requested scope"`
+}
+
+// OIDCRedirectResponse tells the login UI where to send the browser next
+type OIDCRedirectResponse struct {
+	RedirectTo string `json:"redirect_to"`
+}
+
+// OIDCTokenRequest holds the parameters of a token request
+type OIDCTokenRequest struct {
+	GrantType    string
+	Code         string
+	RedirectURI  string
+	CodeVerifier string
+	ClientID     string
+	ClientSecret string
+}
+
+// OIDCTokenResponse is the token endpoint's response to an authorization code exchange
+type OIDCTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	IDToken     string `json:"id_token"`
+	Scope       string `json:"scope"`
+}
+
+// OIDCUserInfoResponse is the userinfo endpoint's response. Identifiers are only included for
+// the scopes the access token was granted.
+type OIDCUserInfoResponse struct {
+	Subject      string `json:"sub" description:"Global user ID"`
+	GlobalUserID string `json:"global_user_id"`
+	Tenant       string `json:"tenant,omitempty"`
+	Email        string `json:"email,omitempty"`
+	Phone        string `json:"phone_number,omitempty"`
+	Locale       string `json:"locale,omitempty"`
+}
+
+// OAuthConsentResponse is a client the user allowed to sign them in
+type OAuthConsentResponse struct {
+	ClientID   string    `json:"client_id"`
+	ClientName string    `json:"client_name"`
+	Scopes     []string  `json:"scopes"`
+	GrantedAt  time.Time `json:"granted_at"`
+}
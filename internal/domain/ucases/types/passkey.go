@@ -0,0 +1,56 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// PasskeyRelyingParty is the WebAuthn relying party a tenant's passkeys are scoped to
+type PasskeyRelyingParty struct {
+	ID     string // host name of the tenant's public URL
+	Name   string
+	Origin string
+}
+
+// PasskeyUser is the account a passkey is created for
+type PasskeyUser struct {
+	Handle      []byte // opaque WebAuthn user handle
+	Name        string
+	DisplayName string
+}
+
+// PasskeyCredential is the stored state of a WebAuthn credential needed to verify assertions
+type PasskeyCredential struct {
+	ID              []byte
+	PublicKey       []byte
+	AttestationType string
+	AAGUID          []byte
+	Transports      []string
+	SignCount       uint32
+	BackupEligible  bool
+	BackupState     bool
+	UserHandle      []byte
+}
+
+// PasskeyCeremony holds the options for the browser and the state to keep until the ceremony is finished
+type PasskeyCeremony struct {
+	Options json.RawMessage
+	State   string
+}
+
+// PasskeyChallengeResponse starts a passkey registration or sign-in in the browser
+type PasskeyChallengeResponse struct {
+	FlowID      string          `json:"flow_id" description:"The flow ID to send back with the credential"`
+	Options     json.RawMessage `json:"options" swaggertype:"object" description:"Options for navigator.credentials.create() or .get()"`
+	ChallengeAt int64           `json:"challenge_at" description:"Time the challenge was issued"`
+}
+
+// PasskeyResponse describes one of the user's passkeys
+type PasskeyResponse struct {
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	Transports     []string   `json:"transports,omitempty"`
+	BackupEligible bool       `json:"backup_eligible"`
+	CreatedAt      time.Time  `json:"created_at"`
+	LastUsedAt     *time.Time `json:"last_used_at,omitempty"`
+}
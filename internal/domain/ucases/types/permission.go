@@ -131,3 +131,10 @@ func (r *DelegateAccessRequest) Validate() error {
 func (r *DelegateAccessRequest) GetIdentifier() string {
 	return r.Identifier
 }
+
+// RelationTuple is a relation granted to a user, as stored in Keto
+type RelationTuple struct {
+	Namespace string `json:"namespace"`
+	Object    string `json:"object"`
+	Relation  string `json:"relation"`
+}
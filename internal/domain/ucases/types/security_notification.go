@@ -0,0 +1,29 @@
+package types
+
+import "time"
+
+// SecurityNotice is a sensitive change to an account, or a login from a new device, that the
+// user's identifiers are told about
+type SecurityNotice struct {
+	Event        string
+	GlobalUserID string
+	// IdentifierType and Identifier are the identifier that was added, or the new one of a change
+	IdentifierType string
+	Identifier     string
+	// PreviousIdentifierType and PreviousIdentifier are the identifier a change or deletion took
+	// off the account. It is told as well: it is the one its owner still reads if the change was
+	// made by someone else.
+	PreviousIdentifierType string
+	PreviousIdentifier     string
+	// ByAdmin tells the change was made by a tenant administrator
+	ByAdmin bool
+	// Device and Country describe the client of a login
+	Device  string
+	Country string
+}
+
+// SecurityReportResponse is the state a reported account was left in
+type SecurityReportResponse struct {
+	Status     string    `json:"status" enums:"suspended,banned" description:"The account stays frozen until an administrator reviews it"`
+	ReportedAt time.Time `json:"reported_at"`
+}
@@ -0,0 +1,30 @@
+package types
+
+import "time"
+
+// UserSessionResponse describes one of the user's signed-in sessions
+type UserSessionResponse struct {
+	ID         string     `json:"id"`
+	Device     string     `json:"device" description:"User agent of the client the session was issued to"`
+	IPAddress  string     `json:"ip_address"`
+	AAL        string     `json:"aal,omitempty"`
+	Current    bool       `json:"current" description:"Whether this is the session making the request"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+// SessionsRevokedResponse reports how many sessions were signed out
+type SessionsRevokedResponse struct {
+	Revoked int `json:"revoked"`
+}
+
+// SessionCacheStats reports how the session validation cache of this instance has performed since it started
+type SessionCacheStats struct {
+	Enabled       bool    `json:"enabled"`
+	TTLSeconds    float64 `json:"ttl_seconds"`
+	Hits          uint64  `json:"hits"`
+	Misses        uint64  `json:"misses"`
+	Invalidations uint64  `json:"invalidations"`
+	HitRatio      float64 `json:"hit_ratio"`
+}
@@ -0,0 +1,19 @@
+package types
+
+import "time"
+
+// TrustedDeviceResponse describes a device the user chose to remember
+type TrustedDeviceResponse struct {
+	ID         string    `json:"id"`
+	Device     string    `json:"device" description:"User agent of the client that was remembered"`
+	IPAddress  string    `json:"ip_address"`
+	Current    bool      `json:"current" description:"Whether this is the device making the request"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// TrustedDevicesRevokedResponse reports how many devices are no longer trusted
+type TrustedDevicesRevokedResponse struct {
+	Revoked int64 `json:"revoked"`
+}
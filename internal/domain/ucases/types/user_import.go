@@ -0,0 +1,39 @@
+package types
+
+import "time"
+
+// UserImportResponse is a bulk user import and its progress
+type UserImportResponse struct {
+	ID            string `json:"id"`
+	Format        string `json:"format" enums:"csv,ndjson"`
+	DryRun        bool   `json:"dry_run"`
+	Status        string `json:"status" enums:"pending,completed,failed"`
+	RequestedBy   string `json:"requested_by"`
+	TotalRows     int    `json:"total_rows"`
+	ProcessedRows int    `json:"processed_rows"`
+	CreatedRows   int    `json:"created_rows"`
+	ExistingRows  int    `json:"existing_rows" description:"Rows whose identifiers were already registered to the same user"`
+	ValidRows     int    `json:"valid_rows" description:"Rows a dry run would import"`
+	FailedRows    int    `json:"failed_rows"`
+	// LastError is why the import is being retried or was given up on
+	LastError   string     `json:"last_error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	FailedAt    *time.Time `json:"failed_at,omitempty"`
+}
+
+// UserImportRowError is why a row of an import was not imported
+type UserImportRowError struct {
+	Line    int    `json:"line" description:"Line of the file the record starts on"`
+	Email   string `json:"email,omitempty"`
+	Phone   string `json:"phone,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// UserImportErrorPage is a page of an import's failed rows
+type UserImportErrorPage struct {
+	Errors []UserImportRowError `json:"errors"`
+	// NextAfter fetches the next page; it is left out on the last page
+	NextAfter *int `json:"next_after,omitempty"`
+}
@@ -0,0 +1,13 @@
+package types
+
+import "time"
+
+// UserStatusResponse is whether a user may use the tenant
+type UserStatusResponse struct {
+	GlobalUserID string     `json:"global_user_id"`
+	Status       string     `json:"status" enums:"active,suspended,banned"`
+	Reason       string     `json:"reason,omitempty"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty" description:"When the suspension ends on its own"`
+	UpdatedBy    string     `json:"updated_by,omitempty"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,9 @@
+package types
+
+// WalletChallengeResponse carries the nonce the wallet has to sign in its EIP-4361 message
+type WalletChallengeResponse struct {
+	FlowID      string `json:"flow_id" description:"The flow ID to submit with the signed message"`
+	Address     string `json:"address" description:"The EIP-55 checksummed wallet address"`
+	Nonce       string `json:"nonce" description:"The nonce to put in the sign-in message"`
+	ChallengeAt int64  `json:"challenge_at" description:"Time challenge was issued"`
+}
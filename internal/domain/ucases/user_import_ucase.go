@@ -0,0 +1,788 @@
+package ucases
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+)
+
+// errImportRowTaken aborts a row whose identifier was registered while the row was being imported
+var errImportRowTaken = errors.New("identifier registered concurrently")
+
+type userImportUseCase struct {
+	db                        *gorm.DB
+	tenantRepo                domainrepo.TenantRepository
+	userImportRepo            domainrepo.UserImportRepository
+	userInvitationRepo        domainrepo.UserInvitationRepository
+	globalUserRepo            domainrepo.GlobalUserRepository
+	userIdentityRepo          domainrepo.UserIdentityRepository
+	userIdentifierMappingRepo domainrepo.UserIdentifierMappingRepository
+	changeLogRepo             domainrepo.UserIdentityChangeLogRepository
+	identifierQuarantineRepo  domainrepo.IdentifierQuarantineRepository
+	kratosService             domainservice.KratosService
+}
+
+func NewUserImportUseCase(
+	db *gorm.DB,
+	tenantRepo domainrepo.TenantRepository,
+	userImportRepo domainrepo.UserImportRepository,
+	userInvitationRepo domainrepo.UserInvitationRepository,
+	globalUserRepo domainrepo.GlobalUserRepository,
+	userIdentityRepo domainrepo.UserIdentityRepository,
+	userIdentifierMappingRepo domainrepo.UserIdentifierMappingRepository,
+	changeLogRepo domainrepo.UserIdentityChangeLogRepository,
+	identifierQuarantineRepo domainrepo.IdentifierQuarantineRepository,
+	kratosService domainservice.KratosService,
+) interfaces.UserImportUseCase {
+	return &userImportUseCase{
+		db:                        db,
+		tenantRepo:                tenantRepo,
+		userImportRepo:            userImportRepo,
+		userInvitationRepo:        userInvitationRepo,
+		globalUserRepo:            globalUserRepo,
+		userIdentityRepo:          userIdentityRepo,
+		userIdentifierMappingRepo: userIdentifierMappingRepo,
+		changeLogRepo:             changeLogRepo,
+		identifierQuarantineRepo:  identifierQuarantineRepo,
+		kratosService:             kratosService,
+	}
+}
+
+// userImportRecord is a record of an uploaded file before it is checked. A record that could not
+// be decoded keeps why in parseErr.
+type userImportRecord struct {
+	line     int
+	email    string
+	phone    string
+	lang     string
+	parseErr *domainerrors.DomainError
+}
+
+// CreateUserImport checks every record up front so that the rows left for the worker only need
+// to be looked up and created; invalid records are stored as failed rows for the error report
+func (u *userImportUseCase) CreateUserImport(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	format string,
+	file io.Reader,
+	dryRun bool,
+	requestedBy string,
+) (*types.UserImportResponse, *domainerrors.DomainError) {
+	tenant, err := u.tenantRepo.GetByID(tenantID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_TENANT_FAILED", "Failed to get tenant")
+	}
+	if tenant == nil {
+		return nil, domainerrors.NewNotFoundError("MSG_TENANT_NOT_FOUND", "Tenant")
+	}
+
+	records, derr := parseUserImport(format, file)
+	if derr != nil {
+		return nil, derr
+	}
+
+	userImport := &domain.UserImport{
+		TenantID:     tenantID.String(),
+		Format:       format,
+		DryRun:       dryRun,
+		RequestedBy:  requestedBy,
+		TotalRows:    len(records),
+		ScheduledFor: time.Now(),
+	}
+	rows := checkUserImportRecords(records)
+	for _, row := range rows {
+		if row.Status == constants.UserImportRowFailed {
+			userImport.ProcessedRows++
+			userImport.FailedRows++
+		}
+	}
+	if userImport.ProcessedRows == userImport.TotalRows {
+		now := time.Now()
+		userImport.CompletedAt = &now
+	}
+
+	if err := u.userImportRepo.Create(ctx, userImport, rows); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_CREATE_USER_IMPORT_FAILED", "Failed to create user import")
+	}
+	logger.GetLogger().Infof(
+		"User import %s of tenant %s created by %s with %d rows (dry run: %t)",
+		userImport.ID, tenantID, requestedBy, userImport.TotalRows, dryRun,
+	)
+
+	return toUserImportResponse(userImport), nil
+}
+
+// ListUserImports returns the tenant's imports, newest first
+func (u *userImportUseCase) ListUserImports(
+	ctx context.Context,
+	tenantID uuid.UUID,
+) ([]*types.UserImportResponse, *domainerrors.DomainError) {
+	imports, err := u.userImportRepo.ListByTenant(ctx, tenantID.String())
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_LIST_USER_IMPORTS_FAILED", "Failed to list user imports")
+	}
+
+	responses := make([]*types.UserImportResponse, 0, len(imports))
+	for _, userImport := range imports {
+		responses = append(responses, toUserImportResponse(userImport))
+	}
+	return responses, nil
+}
+
+// GetUserImport returns the import and its progress
+func (u *userImportUseCase) GetUserImport(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	importID string,
+) (*types.UserImportResponse, *domainerrors.DomainError) {
+	userImport, derr := u.getUserImport(ctx, tenantID, importID)
+	if derr != nil {
+		return nil, derr
+	}
+	return toUserImportResponse(userImport), nil
+}
+
+// ListUserImportErrors pages through the import's failed rows in file order
+func (u *userImportUseCase) ListUserImportErrors(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	importID string,
+	afterLine, limit int,
+) (*types.UserImportErrorPage, *domainerrors.DomainError) {
+	if limit <= 0 {
+		limit = constants.UserImportDefaultLimit
+	}
+	if limit > constants.UserImportMaxLimit {
+		limit = constants.UserImportMaxLimit
+	}
+
+	userImport, derr := u.getUserImport(ctx, tenantID, importID)
+	if derr != nil {
+		return nil, derr
+	}
+	rows, err := u.userImportRepo.ListFailedRows(ctx, userImport.ID, afterLine, limit)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_LIST_USER_IMPORT_ERRORS_FAILED", "Failed to list user import errors")
+	}
+
+	page := &types.UserImportErrorPage{Errors: make([]types.UserImportRowError, 0, len(rows))}
+	for _, row := range rows {
+		page.Errors = append(page.Errors, types.UserImportRowError{
+			Line:    row.Line,
+			Email:   row.Email,
+			Phone:   row.Phone,
+			Code:    row.ErrorCode,
+			Message: row.ErrorMessage,
+		})
+	}
+	if len(rows) == limit {
+		next := rows[len(rows)-1].Line
+		page.NextAfter = &next
+	}
+	return page, nil
+}
+
+// ProcessPendingUserImports takes one batch of each due import, so a large import does not hold
+// up the ones queued behind it
+func (u *userImportUseCase) ProcessPendingUserImports(ctx context.Context) (int, *domainerrors.DomainError) {
+	now := time.Now()
+	due, err := u.userImportRepo.ListDue(ctx, now, constants.UserImportJobsPerRun)
+	if err != nil {
+		return 0, domainerrors.WrapInternal(err, "MSG_LIST_USER_IMPORTS_FAILED", "Failed to list pending user imports")
+	}
+
+	processed := 0
+	for _, userImport := range due {
+		if !claimJob("user import", userImport.ID, func() (bool, error) {
+			return u.userImportRepo.Claim(ctx, userImport.ID, now, now.Add(constants.UserImportRetryDelay))
+		}) {
+			continue
+		}
+
+		count, err := u.processBatch(ctx, userImport)
+		processed += count
+		if err != nil {
+			u.recordFailure(ctx, userImport, err)
+		}
+	}
+	return processed, nil
+}
+
+// processBatch imports the next rows of the import and stores their outcome. Rows processed
+// before an error are kept; the row that hit it stays pending for the next attempt.
+func (u *userImportUseCase) processBatch(ctx context.Context, userImport *domain.UserImport) (int, error) {
+	tenantID, err := uuid.Parse(userImport.TenantID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid tenant id: %w", err)
+	}
+	tenant, err := u.tenantRepo.GetByID(tenantID)
+	if err != nil {
+		return 0, fmt.Errorf("get tenant: %w", err)
+	}
+	if tenant == nil {
+		return 0, fmt.Errorf("tenant %s not found", tenantID)
+	}
+
+	rows, err := u.userImportRepo.ListPendingRows(ctx, userImport.ID, constants.UserImportBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("list pending rows: %w", err)
+	}
+
+	done := make([]*domain.UserImportRow, 0, len(rows))
+	var rowErr error
+	for _, row := range rows {
+		if rowErr = u.importRow(ctx, tenant, userImport, row); rowErr != nil {
+			rowErr = fmt.Errorf("line %d: %w", row.Line, rowErr)
+			break
+		}
+		done = append(done, row)
+	}
+	if len(done) > 0 {
+		if err := u.userImportRepo.SaveRows(ctx, userImport.ID, done); err != nil {
+			return 0, fmt.Errorf("save rows: %w", err)
+		}
+	}
+	if rowErr != nil {
+		return len(done), rowErr
+	}
+
+	now := time.Now()
+	if len(rows) < constants.UserImportBatchSize {
+		if err := u.userImportRepo.Complete(ctx, userImport.ID, now); err != nil {
+			return len(done), fmt.Errorf("complete import: %w", err)
+		}
+		logger.GetLogger().Infof("User import %s of tenant %s completed", userImport.ID, userImport.TenantID)
+		return len(done), nil
+	}
+	if err := u.userImportRepo.Reschedule(ctx, userImport.ID, now); err != nil {
+		return len(done), fmt.Errorf("reschedule import: %w", err)
+	}
+	return len(done), nil
+}
+
+// importRow creates the row's user, or adds the row's missing identifiers to the user that already
+// has the others. Each identifier gets its own Kratos identity, as with AddIdentifierAdmin. A
+// Kratos identity left behind by an earlier attempt is adopted, which makes re-running an import
+// safe. Problems with the row itself are recorded on it; the returned error is for failures that
+// are worth retrying.
+func (u *userImportUseCase) importRow(
+	ctx context.Context,
+	tenant *domain.Tenant,
+	userImport *domain.UserImport,
+	row *domain.UserImportRow,
+) error {
+	tenantID := tenant.ID.String()
+	identifiers := userImportRowIdentifiers(row)
+
+	globalUserID := ""
+	missing := make([][2]string, 0, len(identifiers))
+	for _, id := range identifiers {
+		identity, err := u.userIdentityRepo.GetByTypeAndValue(ctx, nil, tenantID, id[0], id[1])
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("look up identifier: %w", err)
+		}
+		if identity == nil {
+			missing = append(missing, id)
+			continue
+		}
+		if globalUserID != "" && globalUserID != identity.GlobalUserID {
+			failUserImportRow(row, domainerrors.NewConflictError(
+				"MSG_IDENTIFIERS_BELONG_TO_DIFFERENT_USERS", "The email and phone number are registered to different users", nil,
+			))
+			return nil
+		}
+		globalUserID = identity.GlobalUserID
+	}
+	if len(missing) == 0 {
+		row.Status = constants.UserImportRowExisting
+		row.GlobalUserID = &globalUserID
+		return nil
+	}
+
+	for _, id := range missing {
+		if globalUserID != "" {
+			hasType, err := u.userIdentityRepo.ExistsByTenantGlobalUserIDAndType(ctx, tenantID, globalUserID, id[0])
+			if err != nil {
+				return fmt.Errorf("check identity type: %w", err)
+			}
+			if hasType {
+				failUserImportRow(row, domainerrors.NewConflictError(
+					"MSG_IDENTIFIER_TYPE_EXISTS", fmt.Sprintf("User already has an identifier of type %s", id[0]), nil,
+				))
+				return nil
+			}
+		}
+		invitation, err := u.userInvitationRepo.GetOpen(ctx, tenantID, id[0], id[1])
+		if err != nil {
+			return fmt.Errorf("get invitation: %w", err)
+		}
+		if invitation != nil {
+			failUserImportRow(row, domainerrors.NewConflictError(
+				"MSG_INVITATION_PENDING", "The identifier has an open invitation; accept or revoke it first", nil,
+			))
+			return nil
+		}
+		quarantine, derr := activeIdentifierQuarantine(ctx, u.identifierQuarantineRepo, tenant.ID, id[0], id[1], globalUserID)
+		if derr != nil {
+			return fmt.Errorf("check identifier quarantine: %w", derr)
+		}
+		if quarantine != nil {
+			failUserImportRow(row, identifierQuarantinedError(quarantine))
+			return nil
+		}
+	}
+
+	if userImport.DryRun {
+		row.Status = constants.UserImportRowValid
+		if globalUserID != "" {
+			row.GlobalUserID = &globalUserID
+		}
+		return nil
+	}
+
+	lang := row.Lang
+	if lang == "" && globalUserID != "" {
+		if mapping, err := u.userIdentifierMappingRepo.GetByGlobalUserID(ctx, globalUserID); err == nil && mapping != nil {
+			lang = strings.TrimSpace(mapping.Lang)
+		}
+	}
+	if lang == "" {
+		lang = constants.LangEN
+	}
+
+	kratosUserIDs := make([]string, 0, len(missing))
+	for _, id := range missing {
+		kratosUserID, derr, err := u.createImportedIdentity(ctx, tenant, lang, id[0], id[1])
+		if err != nil {
+			return err
+		}
+		if derr != nil {
+			failUserImportRow(row, derr)
+			return nil
+		}
+		kratosUserIDs = append(kratosUserIDs, kratosUserID)
+	}
+
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		newUser := globalUserID == ""
+		if newUser {
+			globalUser := &domain.GlobalUser{}
+			if err := u.globalUserRepo.Create(tx, globalUser); err != nil {
+				return fmt.Errorf("create global user: %w", err)
+			}
+			globalUserID = globalUser.ID
+		}
+		for i, id := range missing {
+			inserted, err := u.userIdentityRepo.InsertOnceByKratosUserAndType(
+				ctx, tx, tenantID, kratosUserIDs[i], globalUserID, id[0], id[1],
+			)
+			if err != nil {
+				return fmt.Errorf("create identity: %w", err)
+			}
+			if !inserted {
+				return errImportRowTaken
+			}
+			if err := u.changeLogRepo.Create(ctx, tx, &domain.UserIdentityChangeLog{
+				GlobalUserID: globalUserID,
+				TenantID:     tenantID,
+				Action:       constants.IdentityChangeActionAdd,
+				IdentityType: id[0],
+				NewValue:     id[1],
+				Actor:        constants.IdentityChangeActorAdmin,
+				ActorID:      userImport.RequestedBy,
+			}); err != nil {
+				return fmt.Errorf("record identity change: %w", err)
+			}
+		}
+		if newUser {
+			if err := u.userIdentifierMappingRepo.Create(ctx, tx, &domain.UserIdentifierMapping{
+				GlobalUserID: globalUserID,
+				Lang:         lang,
+			}); err != nil {
+				return fmt.Errorf("create mapping: %w", err)
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errImportRowTaken) {
+		failUserImportRow(row, domainerrors.NewConflictError(
+			"MSG_IDENTIFIER_ALREADY_EXISTS", "Identifier was registered while the row was being imported", nil,
+		))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	row.Status = constants.UserImportRowCreated
+	row.GlobalUserID = &globalUserID
+	return nil
+}
+
+// createImportedIdentity creates the Kratos identity for one identifier, or finds the one Kratos
+// already has for it. Kratos refusing the traits is a problem with the row; any other failure is
+// returned as an error so the batch is retried.
+func (u *userImportUseCase) createImportedIdentity(
+	ctx context.Context,
+	tenant *domain.Tenant,
+	lang, idType, identifier string,
+) (string, *domainerrors.DomainError, error) {
+	identity, statusCode, err := u.kratosService.CreateIdentityAdmin(ctx, tenant.ID, map[string]interface{}{
+		"tenant": tenant.Name,
+		"lang":   lang,
+		idType:   identifier,
+	})
+	if err == nil {
+		return identity.Id, nil, nil
+	}
+	switch {
+	case statusCode == http.StatusConflict:
+		existing, lookupErr := u.kratosService.GetIdentityByIdentifierAdmin(ctx, tenant.ID, identifier)
+		if lookupErr != nil {
+			return "", nil, fmt.Errorf("look up identity: %w", lookupErr)
+		}
+		if existing == nil {
+			return "", nil, fmt.Errorf("kratos reported a conflict but has no identity for %s", idType)
+		}
+		return existing.Id, nil, nil
+	case statusCode >= http.StatusBadRequest && statusCode < http.StatusInternalServerError:
+		return "", domainerrors.NewValidationError(
+			"MSG_CREATE_IDENTITY_FAILED", fmt.Sprintf("Failed to create identity: %v", err), nil,
+		), nil
+	}
+	return "", nil, fmt.Errorf("create identity: %w", err)
+}
+
+// recordFailure schedules the import for another attempt, giving up after UserImportMaxAttempts
+func (u *userImportUseCase) recordFailure(ctx context.Context, userImport *domain.UserImport, cause error) {
+	recordJobFailure("user import", userImport.ID, userImport.Attempts, constants.UserImportMaxAttempts, cause,
+		func(lastError string) error {
+			return u.userImportRepo.RecordFailure(ctx, userImport.ID, lastError)
+		},
+		func(lastError string) error {
+			return u.userImportRepo.Fail(ctx, userImport.ID, lastError, time.Now())
+		},
+	)
+}
+
+func (u *userImportUseCase) getUserImport(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	importID string,
+) (*domain.UserImport, *domainerrors.DomainError) {
+	if _, err := uuid.Parse(importID); err != nil {
+		return nil, domainerrors.NewNotFoundError("MSG_USER_IMPORT_NOT_FOUND", "User import")
+	}
+	userImport, err := u.userImportRepo.GetByID(ctx, tenantID.String(), importID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_USER_IMPORT_FAILED", "Failed to get user import")
+	}
+	if userImport == nil {
+		return nil, domainerrors.NewNotFoundError("MSG_USER_IMPORT_NOT_FOUND", "User import")
+	}
+	return userImport, nil
+}
+
+// parseUserImport decodes the uploaded file. A file that cannot be read as a whole is refused,
+// while a single record that cannot be decoded only fails its own row.
+func parseUserImport(format string, file io.Reader) ([]userImportRecord, *domainerrors.DomainError) {
+	data, err := io.ReadAll(io.LimitReader(file, constants.UserImportMaxBytes+1))
+	if err != nil {
+		return nil, domainerrors.NewValidationError("MSG_INVALID_IMPORT_FILE", "Failed to read the import file", nil)
+	}
+	if len(data) > constants.UserImportMaxBytes {
+		return nil, domainerrors.NewValidationError(
+			"MSG_IMPORT_FILE_TOO_LARGE", fmt.Sprintf("The import file is larger than %d bytes", constants.UserImportMaxBytes), nil,
+		)
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	var records []userImportRecord
+	var derr *domainerrors.DomainError
+	switch format {
+	case constants.BulkFormatCSV:
+		records, derr = parseUserImportCSV(data)
+	case constants.BulkFormatNDJSON:
+		records, derr = parseUserImportNDJSON(data)
+	default:
+		return nil, domainerrors.NewValidationError("MSG_INVALID_IMPORT_FORMAT", "The import file must be CSV or NDJSON", nil)
+	}
+	if derr != nil {
+		return nil, derr
+	}
+
+	if len(records) == 0 {
+		return nil, domainerrors.NewValidationError("MSG_EMPTY_IMPORT_FILE", "The import file has no records", nil)
+	}
+	if len(records) > constants.UserImportMaxRows {
+		return nil, domainerrors.NewValidationError(
+			"MSG_TOO_MANY_IMPORT_ROWS", fmt.Sprintf("The import file has more than %d records", constants.UserImportMaxRows), nil,
+		)
+	}
+	return records, nil
+}
+
+// parseUserImportCSV reads a CSV file whose header names the email, phone and lang columns, in
+// any order; other columns are ignored
+func parseUserImportCSV(data []byte) ([]userImportRecord, *domainerrors.DomainError) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, invalidImportFile(err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "phone_number" {
+			name = "phone"
+		}
+		if _, seen := columns[name]; !seen {
+			columns[name] = i
+		}
+	}
+	_, hasEmail := columns["email"]
+	_, hasPhone := columns["phone"]
+	if !hasEmail && !hasPhone {
+		return nil, domainerrors.NewValidationError(
+			"MSG_INVALID_IMPORT_FILE", "The CSV header must name an email or phone column", nil,
+		)
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	var records []userImportRecord
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, invalidImportFile(err)
+		}
+		if len(records) == constants.UserImportMaxRows {
+			// One over the limit is enough for parseUserImport to refuse the file
+			records = append(records, userImportRecord{})
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		records = append(records, userImportRecord{
+			line:  line,
+			email: field(record, "email"),
+			phone: field(record, "phone"),
+			lang:  field(record, "lang"),
+		})
+	}
+	return records, nil
+}
+
+// parseUserImportNDJSON reads one JSON object per line; blank lines are skipped
+func parseUserImportNDJSON(data []byte) ([]userImportRecord, *domainerrors.DomainError) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), constants.UserImportMaxBytes)
+
+	var records []userImportRecord
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		if len(records) == constants.UserImportMaxRows {
+			records = append(records, userImportRecord{})
+			break
+		}
+
+		var object struct {
+			Email       string `json:"email"`
+			Phone       string `json:"phone"`
+			PhoneNumber string `json:"phone_number"`
+			Lang        string `json:"lang"`
+		}
+		record := userImportRecord{line: line}
+		if err := json.Unmarshal(text, &object); err != nil {
+			record.email = string(text)
+			record.parseErr = domainerrors.NewValidationError("MSG_INVALID_RECORD", "The line is not a JSON object of strings", nil)
+		} else {
+			record.email = object.Email
+			record.phone = object.Phone
+			if record.phone == "" {
+				record.phone = object.PhoneNumber
+			}
+			record.lang = object.Lang
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, invalidImportFile(err)
+	}
+	return records, nil
+}
+
+// checkUserImportRecords validates and normalizes the records into rows. A record failing any
+// check becomes a failed row keeping the values as uploaded; an identifier already used by an
+// earlier record fails the later one.
+func checkUserImportRecords(records []userImportRecord) []*domain.UserImportRow {
+	seen := map[string]int{}
+	rows := make([]*domain.UserImportRow, 0, len(records))
+	for _, record := range records {
+		row := &domain.UserImportRow{
+			Line:   record.line,
+			Email:  truncateImportValue(strings.TrimSpace(record.email), 320),
+			Phone:  truncateImportValue(strings.TrimSpace(record.phone), 32),
+			Lang:   truncateImportValue(strings.TrimSpace(record.lang), 10),
+			Status: constants.UserImportRowPending,
+		}
+		rows = append(rows, row)
+
+		derr := record.parseErr
+		var email, phone, lang string
+		if derr == nil {
+			email, phone, lang, derr = normalizeUserImportRecord(record)
+		}
+		if derr == nil {
+			for _, identifier := range []string{email, phone} {
+				if identifier == "" {
+					continue
+				}
+				if line, ok := seen[identifier]; ok {
+					derr = domainerrors.NewConflictError(
+						"MSG_DUPLICATE_IDENTIFIER", fmt.Sprintf("The identifier is also on line %d", line), nil,
+					)
+					break
+				}
+			}
+		}
+		if derr != nil {
+			failUserImportRow(row, derr)
+			continue
+		}
+
+		for _, identifier := range []string{email, phone} {
+			if identifier != "" {
+				seen[identifier] = record.line
+			}
+		}
+		row.Email, row.Phone, row.Lang = email, phone, lang
+	}
+	return rows
+}
+
+// normalizeUserImportRecord requires an email or phone number, each of the type of its column
+func normalizeUserImportRecord(record userImportRecord) (string, string, string, *domainerrors.DomainError) {
+	rawEmail := strings.TrimSpace(record.email)
+	rawPhone := strings.TrimSpace(record.phone)
+	if rawEmail == "" && rawPhone == "" {
+		return "", "", "", domainerrors.NewValidationError("MSG_IDENTIFIER_REQUIRED", "An email or phone number is required", nil)
+	}
+
+	var email, phone string
+	if rawEmail != "" {
+		idType, value, derr := inferAndNormalizeIdentifier(rawEmail)
+		if derr == nil && idType != constants.IdentifierEmail.String() {
+			derr = domainerrors.NewValidationError("MSG_INVALID_EMAIL", "Invalid email", nil)
+		}
+		if derr != nil {
+			return "", "", "", derr
+		}
+		email = value
+	}
+	if rawPhone != "" {
+		idType, value, derr := inferAndNormalizeIdentifier(rawPhone)
+		if derr == nil && idType != constants.IdentifierPhone.String() {
+			derr = domainerrors.NewValidationError("MSG_INVALID_PHONE", "Invalid phone", nil)
+		}
+		if derr != nil {
+			return "", "", "", derr
+		}
+		phone = value
+	}
+
+	lang := strings.ToLower(strings.TrimSpace(record.lang))
+	if lang != "" {
+		if _, ok := constants.LangSupported[lang]; !ok {
+			return "", "", "", domainerrors.NewValidationError("MSG_INVALID_LANG", "Unsupported language", nil)
+		}
+	}
+	return email, phone, lang, nil
+}
+
+// userImportRowIdentifiers returns the row's (type, value) pairs
+func userImportRowIdentifiers(row *domain.UserImportRow) [][2]string {
+	identifiers := make([][2]string, 0, 2)
+	if row.Email != "" {
+		identifiers = append(identifiers, [2]string{constants.IdentifierEmail.String(), row.Email})
+	}
+	if row.Phone != "" {
+		identifiers = append(identifiers, [2]string{constants.IdentifierPhone.String(), row.Phone})
+	}
+	return identifiers
+}
+
+func failUserImportRow(row *domain.UserImportRow, derr *domainerrors.DomainError) {
+	row.Status = constants.UserImportRowFailed
+	row.ErrorCode = derr.Code
+	row.ErrorMessage = derr.Message
+}
+
+func invalidImportFile(err error) *domainerrors.DomainError {
+	return domainerrors.NewValidationError("MSG_INVALID_IMPORT_FILE", "The import file could not be read", []interface{}{err.Error()})
+}
+
+// truncateImportValue fits an uploaded value into its column, dropping bytes that are not UTF-8
+func truncateImportValue(s string, n int) string {
+	s = strings.ToValidUTF8(s, "")
+	for len(s) > n {
+		_, size := utf8.DecodeLastRuneInString(s)
+		s = s[:len(s)-size]
+	}
+	return s
+}
+
+func toUserImportResponse(userImport *domain.UserImport) *types.UserImportResponse {
+	return &types.UserImportResponse{
+		ID:            userImport.ID,
+		Format:        userImport.Format,
+		DryRun:        userImport.DryRun,
+		Status:        userImport.Status(),
+		RequestedBy:   userImport.RequestedBy,
+		TotalRows:     userImport.TotalRows,
+		ProcessedRows: userImport.ProcessedRows,
+		CreatedRows:   userImport.CreatedRows,
+		ExistingRows:  userImport.ExistingRows,
+		ValidRows:     userImport.ValidRows,
+		FailedRows:    userImport.FailedRows,
+		LastError:     userImport.LastError,
+		CreatedAt:     userImport.CreatedAt,
+		CompletedAt:   userImport.CompletedAt,
+		FailedAt:      userImport.FailedAt,
+	}
+}
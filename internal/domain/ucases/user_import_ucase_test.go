@@ -0,0 +1,281 @@
+package ucases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	client "github.com/ory/kratos-client-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	mock_repositories "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/repositories"
+	mock_services "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/services"
+)
+
+// expectImportBatch hands the rows to the worker as one due import and returns the rows it saved
+func expectImportBatch(
+	ctx context.Context,
+	importRepo *mock_repositories.MockUserImportRepository,
+	userImport *domain.UserImport,
+	rows []*domain.UserImportRow,
+) *[]*domain.UserImportRow {
+	saved := &[]*domain.UserImportRow{}
+	importRepo.EXPECT().ListDue(ctx, gomock.Any(), constants.UserImportJobsPerRun).Return([]*domain.UserImport{userImport}, nil)
+	importRepo.EXPECT().Claim(ctx, userImport.ID, gomock.Any(), gomock.Any()).Return(true, nil)
+	importRepo.EXPECT().ListPendingRows(ctx, userImport.ID, constants.UserImportBatchSize).Return(rows, nil)
+	importRepo.EXPECT().SaveRows(ctx, userImport.ID, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, rows []*domain.UserImportRow) error {
+			*saved = rows
+			return nil
+		})
+	importRepo.EXPECT().Complete(ctx, userImport.ID, gomock.Any()).Return(nil)
+	return saved
+}
+
+func TestCreateUserImport_ChecksEveryRecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenant := &domain.Tenant{ID: uuid.New(), Name: "acme"}
+	file := strings.Join([]string{
+		"Email,Phone,Lang",
+		" Ann@Acme.io ,,VI",
+		"ann@acme.io,,",
+		"not-an-email,,",
+		",+84901234567,en",
+		"bob@acme.io,,fr",
+		",,",
+		"+84911111111,,",
+	}, "\n")
+
+	tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+	tenantRepo.EXPECT().GetByID(tenant.ID).Return(tenant, nil)
+
+	var stored []*domain.UserImportRow
+	importRepo := mock_repositories.NewMockUserImportRepository(ctrl)
+	importRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, userImport *domain.UserImport, rows []*domain.UserImportRow) error {
+			assert.Equal(t, "admin", userImport.RequestedBy)
+			assert.True(t, userImport.DryRun)
+			stored = rows
+			return nil
+		})
+
+	u := &userImportUseCase{tenantRepo: tenantRepo, userImportRepo: importRepo}
+
+	resp, derr := u.CreateUserImport(ctx, tenant.ID, constants.BulkFormatCSV, strings.NewReader(file), true, "admin")
+	require.Nil(t, derr)
+	assert.Equal(t, 7, resp.TotalRows)
+	assert.Equal(t, 5, resp.FailedRows)
+	assert.Equal(t, constants.UserImportStatusPending, resp.Status)
+
+	require.Len(t, stored, 7)
+	outcomes := make(map[int]string, len(stored))
+	for _, row := range stored {
+		outcomes[row.Line] = row.Status + " " + row.ErrorCode
+	}
+	assert.Equal(t, map[int]string{
+		2: "pending ",
+		3: "failed MSG_DUPLICATE_IDENTIFIER",
+		4: "failed MSG_INVALID_IDENTIFIER_TYPE",
+		5: "pending ",
+		6: "failed MSG_INVALID_LANG",
+		7: "failed MSG_IDENTIFIER_REQUIRED",
+		8: "failed MSG_INVALID_EMAIL",
+	}, outcomes)
+	assert.Equal(t, "ann@acme.io", stored[0].Email)
+	assert.Equal(t, "vi", stored[0].Lang)
+	assert.Equal(t, "+84901234567", stored[3].Phone)
+}
+
+func TestCreateUserImport_RefusesUnreadableFiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenant := &domain.Tenant{ID: uuid.New(), Name: "acme"}
+
+	tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+	tenantRepo.EXPECT().GetByID(tenant.ID).Return(tenant, nil).Times(3)
+
+	// None of the files is stored
+	u := &userImportUseCase{
+		tenantRepo:     tenantRepo,
+		userImportRepo: mock_repositories.NewMockUserImportRepository(ctrl),
+	}
+
+	_, derr := u.CreateUserImport(ctx, tenant.ID, constants.BulkFormatCSV, strings.NewReader("name,lang\nann,en\n"), false, "admin")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_INVALID_IMPORT_FILE", derr.Code)
+
+	_, derr = u.CreateUserImport(ctx, tenant.ID, "xlsx", strings.NewReader("email\nann@acme.io\n"), false, "admin")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_INVALID_IMPORT_FORMAT", derr.Code)
+
+	_, derr = u.CreateUserImport(ctx, tenant.ID, constants.BulkFormatNDJSON, strings.NewReader("\n\n"), false, "admin")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_EMPTY_IMPORT_FILE", derr.Code)
+}
+
+func TestProcessPendingUserImports_CreatesNewUsersAndSkipsExistingOnes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenant := &domain.Tenant{ID: uuid.New(), Name: "acme"}
+	tenantID := tenant.ID.String()
+	userImport := &domain.UserImport{ID: uuid.NewString(), TenantID: tenantID, RequestedBy: "admin"}
+	rows := []*domain.UserImportRow{
+		{ID: "r1", Line: 2, Email: "ann@acme.io", Phone: "+84901234567", Status: constants.UserImportRowPending},
+		{ID: "r2", Line: 3, Email: "bob@acme.io", Status: constants.UserImportRowPending},
+	}
+	tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+	tenantRepo.EXPECT().GetByID(tenant.ID).Return(tenant, nil).AnyTimes()
+
+	importRepo := mock_repositories.NewMockUserImportRepository(ctrl)
+	saved := expectImportBatch(ctx, importRepo, userImport, rows)
+
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	invitationRepo := mock_repositories.NewMockUserInvitationRepository(ctrl)
+	globalRepo := mock_repositories.NewMockGlobalUserRepository(ctrl)
+	mappingRepo := mock_repositories.NewMockUserIdentifierMappingRepository(ctrl)
+	changeLogRepo := mock_repositories.NewMockUserIdentityChangeLogRepository(ctrl)
+	kratos := mock_services.NewMockKratosService(ctrl)
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	u := &userImportUseCase{
+		db:                        db,
+		tenantRepo:                tenantRepo,
+		userImportRepo:            importRepo,
+		userInvitationRepo:        invitationRepo,
+		globalUserRepo:            globalRepo,
+		userIdentityRepo:          identityRepo,
+		userIdentifierMappingRepo: mappingRepo,
+		changeLogRepo:             changeLogRepo,
+		kratosService:             kratos,
+	}
+
+	// ann is new; the phone identity was left behind in Kratos by an attempt that did not finish
+	identityRepo.EXPECT().GetByTypeAndValue(ctx, nil, tenantID, "email", "ann@acme.io").Return(nil, gorm.ErrRecordNotFound)
+	identityRepo.EXPECT().GetByTypeAndValue(ctx, nil, tenantID, "phone_number", "+84901234567").Return(nil, gorm.ErrRecordNotFound)
+	invitationRepo.EXPECT().GetOpen(ctx, tenantID, gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	kratos.EXPECT().CreateIdentityAdmin(ctx, tenant.ID, map[string]interface{}{
+		"tenant": "acme", "lang": constants.LangEN, "email": "ann@acme.io",
+	}).Return(&client.Identity{Id: "kratos-email"}, http.StatusCreated, nil)
+	kratos.EXPECT().CreateIdentityAdmin(ctx, tenant.ID, map[string]interface{}{
+		"tenant": "acme", "lang": constants.LangEN, "phone_number": "+84901234567",
+	}).Return(nil, http.StatusConflict, errors.New("conflict"))
+	kratos.EXPECT().GetIdentityByIdentifierAdmin(ctx, tenant.ID, "+84901234567").Return(&client.Identity{Id: "kratos-phone"}, nil)
+	globalRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ *gorm.DB, user *domain.GlobalUser) error {
+		user.ID = "ann-id"
+		return nil
+	})
+	identityRepo.EXPECT().InsertOnceByKratosUserAndType(ctx, gomock.Any(), tenantID, "kratos-email", "ann-id", "email", "ann@acme.io").Return(true, nil)
+	identityRepo.EXPECT().InsertOnceByKratosUserAndType(ctx, gomock.Any(), tenantID, "kratos-phone", "ann-id", "phone_number", "+84901234567").Return(true, nil)
+	changeLogRepo.EXPECT().Create(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *gorm.DB, log *domain.UserIdentityChangeLog) error {
+			assert.Equal(t, constants.IdentityChangeActorAdmin, log.Actor)
+			assert.Equal(t, "admin", log.ActorID)
+			return nil
+		}).Times(2)
+	mappingRepo.EXPECT().Create(ctx, gomock.Any(), &domain.UserIdentifierMapping{GlobalUserID: "ann-id", Lang: constants.LangEN}).Return(nil)
+
+	// bob was imported before
+	identityRepo.EXPECT().GetByTypeAndValue(ctx, nil, tenantID, "email", "bob@acme.io").
+		Return(&domain.UserIdentity{GlobalUserID: "bob-id"}, nil)
+
+	processed, derr := u.ProcessPendingUserImports(ctx)
+	require.Nil(t, derr)
+	assert.Equal(t, 2, processed)
+	require.Len(t, *saved, 2)
+	assert.Equal(t, constants.UserImportRowCreated, (*saved)[0].Status)
+	assert.Equal(t, "ann-id", *(*saved)[0].GlobalUserID)
+	assert.Equal(t, constants.UserImportRowExisting, (*saved)[1].Status)
+	assert.Equal(t, "bob-id", *(*saved)[1].GlobalUserID)
+}
+
+func TestProcessPendingUserImports_DryRunChangesNothing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenant := &domain.Tenant{ID: uuid.New(), Name: "acme"}
+	tenantID := tenant.ID.String()
+	userImport := &domain.UserImport{ID: uuid.NewString(), TenantID: tenantID, DryRun: true}
+	rows := []*domain.UserImportRow{
+		{ID: "r1", Line: 2, Email: "ann@acme.io", Status: constants.UserImportRowPending},
+		{ID: "r2", Line: 3, Email: "bob@acme.io", Phone: "+84901234567", Status: constants.UserImportRowPending},
+	}
+	tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+	tenantRepo.EXPECT().GetByID(tenant.ID).Return(tenant, nil).AnyTimes()
+
+	importRepo := mock_repositories.NewMockUserImportRepository(ctrl)
+	saved := expectImportBatch(ctx, importRepo, userImport, rows)
+
+	// Nothing is written to Kratos or the database
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	invitationRepo := mock_repositories.NewMockUserInvitationRepository(ctrl)
+
+	u := &userImportUseCase{
+		tenantRepo:         tenantRepo,
+		userImportRepo:     importRepo,
+		userInvitationRepo: invitationRepo,
+		userIdentityRepo:   identityRepo,
+		kratosService:      mock_services.NewMockKratosService(ctrl),
+	}
+
+	identityRepo.EXPECT().GetByTypeAndValue(ctx, nil, tenantID, "email", "ann@acme.io").Return(nil, gorm.ErrRecordNotFound)
+	invitationRepo.EXPECT().GetOpen(ctx, tenantID, "email", "ann@acme.io").Return(nil, nil)
+	identityRepo.EXPECT().GetByTypeAndValue(ctx, nil, tenantID, "email", "bob@acme.io").
+		Return(&domain.UserIdentity{GlobalUserID: "bob-id"}, nil)
+	identityRepo.EXPECT().GetByTypeAndValue(ctx, nil, tenantID, "phone_number", "+84901234567").
+		Return(&domain.UserIdentity{GlobalUserID: "carol-id"}, nil)
+
+	_, derr := u.ProcessPendingUserImports(ctx)
+	require.Nil(t, derr)
+	require.Len(t, *saved, 2)
+	assert.Equal(t, constants.UserImportRowValid, (*saved)[0].Status)
+	assert.Equal(t, constants.UserImportRowFailed, (*saved)[1].Status)
+	assert.Equal(t, "MSG_IDENTIFIERS_BELONG_TO_DIFFERENT_USERS", (*saved)[1].ErrorCode)
+}
+
+func TestProcessPendingUserImports_GivesUpAfterTheLastAttempt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	userImport := &domain.UserImport{ID: uuid.NewString(), TenantID: tenantID.String(), Attempts: constants.UserImportMaxAttempts - 1}
+
+	tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+	tenantRepo.EXPECT().GetByID(tenantID).Return(nil, errors.New("database unavailable"))
+
+	// RecordFailure is not expected: no attempt is left to retry with
+	importRepo := mock_repositories.NewMockUserImportRepository(ctrl)
+	importRepo.EXPECT().ListDue(ctx, gomock.Any(), constants.UserImportJobsPerRun).Return([]*domain.UserImport{userImport}, nil)
+	importRepo.EXPECT().Claim(ctx, userImport.ID, gomock.Any(), gomock.Any()).Return(true, nil)
+	importRepo.EXPECT().Fail(ctx, userImport.ID, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, lastError string, _ time.Time) error {
+			assert.Contains(t, lastError, "database unavailable")
+			return nil
+		})
+
+	u := &userImportUseCase{tenantRepo: tenantRepo, userImportRepo: importRepo}
+
+	processed, derr := u.ProcessPendingUserImports(ctx)
+	require.Nil(t, derr)
+	assert.Equal(t, 0, processed)
+}
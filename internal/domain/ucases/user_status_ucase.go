@@ -0,0 +1,193 @@
+package ucases
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	"github.com/lifenetwork-ai/iam-service/internal/delivery/dto"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+)
+
+type userStatusUseCase struct {
+	userAccountStatusRepo   domainrepo.UserAccountStatusRepository
+	userIdentityRepo        domainrepo.UserIdentityRepository
+	userSessionRepo         domainrepo.UserSessionRepository
+	sessionRefreshTokenRepo domainrepo.SessionRefreshTokenRepository
+	kratosService           domainservice.KratosService
+	sessionCache            *SessionCache
+}
+
+func NewUserStatusUseCase(
+	sessionCache *SessionCache,
+	userAccountStatusRepo domainrepo.UserAccountStatusRepository,
+	userIdentityRepo domainrepo.UserIdentityRepository,
+	userSessionRepo domainrepo.UserSessionRepository,
+	sessionRefreshTokenRepo domainrepo.SessionRefreshTokenRepository,
+	kratosService domainservice.KratosService,
+) interfaces.UserStatusUseCase {
+	return &userStatusUseCase{
+		userAccountStatusRepo:   userAccountStatusRepo,
+		userIdentityRepo:        userIdentityRepo,
+		userSessionRepo:         userSessionRepo,
+		sessionRefreshTokenRepo: sessionRefreshTokenRepo,
+		kratosService:           kratosService,
+		sessionCache:            sessionCache,
+	}
+}
+
+// GetUserStatus returns the user's status, reporting an expired suspension as active
+func (u *userStatusUseCase) GetUserStatus(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+) (*types.UserStatusResponse, *domainerrors.DomainError) {
+	if derr := u.ensureTenantUser(ctx, tenantID, globalUserID); derr != nil {
+		return nil, derr
+	}
+	status, err := u.userAccountStatusRepo.Get(ctx, tenantID.String(), globalUserID)
+	if err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_GET_USER_STATUS_FAILED", "Failed to get user status")
+	}
+	return toUserStatusResponse(globalUserID, status, time.Now()), nil
+}
+
+// UpdateUserStatus records the new status, then signs a blocked user out everywhere. The status is
+// enforced on every use of a session, so a sign-out that fails part way can simply be retried.
+func (u *userStatusUseCase) UpdateUserStatus(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	globalUserID string,
+	req dto.UpdateUserStatusPayloadDTO,
+	updatedBy string,
+) (*types.UserStatusResponse, *domainerrors.DomainError) {
+	now := time.Now()
+	reason := strings.TrimSpace(req.Reason)
+	switch req.Status {
+	case constants.UserStatusActive:
+		// Reactivating clears the previous block; a reason is kept as a note
+		req.ExpiresAt = nil
+	case constants.UserStatusSuspended, constants.UserStatusBanned:
+		if reason == "" {
+			return nil, domainerrors.NewValidationError("MSG_REASON_REQUIRED", "A reason is required", []interface{}{
+				map[string]string{"field": "reason", "error": "A reason is required to suspend or ban a user"},
+			})
+		}
+		if req.Status == constants.UserStatusBanned && req.ExpiresAt != nil {
+			return nil, domainerrors.NewValidationError("MSG_INVALID_EXPIRY", "A ban does not expire", []interface{}{
+				map[string]string{"field": "expires_at", "error": "Only a suspension can expire"},
+			})
+		}
+		if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+			return nil, domainerrors.NewValidationError("MSG_INVALID_EXPIRY", "The suspension must end in the future", []interface{}{
+				map[string]string{"field": "expires_at", "error": "Must be in the future"},
+			})
+		}
+	default:
+		return nil, domainerrors.NewValidationError("MSG_INVALID_USER_STATUS", "Invalid user status", nil)
+	}
+
+	if derr := u.ensureTenantUser(ctx, tenantID, globalUserID); derr != nil {
+		return nil, derr
+	}
+
+	status := &domain.UserAccountStatus{
+		TenantID:     tenantID.String(),
+		GlobalUserID: globalUserID,
+		Status:       req.Status,
+		Reason:       reason,
+		ExpiresAt:    req.ExpiresAt,
+		UpdatedBy:    updatedBy,
+	}
+	if err := u.userAccountStatusRepo.Upsert(ctx, status); err != nil {
+		return nil, domainerrors.WrapInternal(err, "MSG_UPDATE_USER_STATUS_FAILED", "Failed to update user status")
+	}
+	logger.GetLogger().Infof("User %s of tenant %s set to %s by %s", globalUserID, tenantID, req.Status, updatedBy)
+
+	// Cached sessions and introspection results stop being served even if the sign-out fails
+	u.sessionCache.invalidateUser(globalUserID)
+	if req.Status != constants.UserStatusActive {
+		if err := revokeUserSessions(
+			ctx, tenantID, globalUserID, u.kratosService, u.userSessionRepo, u.sessionRefreshTokenRepo, u.sessionCache,
+		); err != nil {
+			return nil, domainerrors.WrapInternal(err, "MSG_REVOKE_SESSION_FAILED", "Failed to sign the user out")
+		}
+	}
+
+	return toUserStatusResponse(globalUserID, status, now), nil
+}
+
+func (u *userStatusUseCase) ensureTenantUser(ctx context.Context, tenantID uuid.UUID, globalUserID string) *domainerrors.DomainError {
+	if _, err := uuid.Parse(globalUserID); err != nil {
+		return domainerrors.NewNotFoundError("MSG_USER_NOT_FOUND", "User")
+	}
+	identities, err := u.userIdentityRepo.GetByGlobalUserIDAndTenantID(ctx, nil, globalUserID, tenantID.String())
+	if err != nil {
+		return domainerrors.WrapInternal(err, "MSG_GET_IDENTITIES_FAILED", "Failed to get user identities")
+	}
+	if len(identities) == 0 {
+		return domainerrors.NewNotFoundError("MSG_USER_NOT_FOUND", "User")
+	}
+	return nil
+}
+
+// accountStatusError refuses a suspended or banned user. The reason is meant for administrators
+// and is not shown to the user.
+func accountStatusError(status *domain.UserAccountStatus, now time.Time) *domainerrors.DomainError {
+	switch status.EffectiveStatus(now) {
+	case constants.UserStatusSuspended:
+		var details interface{}
+		if status.ExpiresAt != nil {
+			details = map[string]string{"expires_at": status.ExpiresAt.UTC().Format(time.RFC3339)}
+		}
+		return domainerrors.NewForbiddenError("MSG_ACCOUNT_SUSPENDED", "The account is suspended", details)
+	case constants.UserStatusBanned:
+		return domainerrors.NewForbiddenError("MSG_ACCOUNT_BANNED", "The account is banned", nil)
+	}
+	return nil
+}
+
+// checkAccountStatus refuses users who are suspended or banned in the tenant
+func (u *userUseCase) checkAccountStatus(ctx context.Context, tenantID uuid.UUID, globalUserID string) *domainerrors.DomainError {
+	return ensureAccountActive(ctx, u.userAccountStatusRepo, tenantID, globalUserID)
+}
+
+// ensureAccountActive refuses a user who is suspended or banned in the tenant
+func ensureAccountActive(
+	ctx context.Context,
+	userAccountStatusRepo domainrepo.UserAccountStatusRepository,
+	tenantID uuid.UUID,
+	globalUserID string,
+) *domainerrors.DomainError {
+	status, err := userAccountStatusRepo.Get(ctx, tenantID.String(), globalUserID)
+	if err != nil {
+		return domainerrors.WrapInternal(err, "MSG_GET_USER_STATUS_FAILED", "Failed to get user status")
+	}
+	return accountStatusError(status, time.Now())
+}
+
+func toUserStatusResponse(globalUserID string, status *domain.UserAccountStatus, now time.Time) *types.UserStatusResponse {
+	resp := &types.UserStatusResponse{
+		GlobalUserID: globalUserID,
+		Status:       status.EffectiveStatus(now),
+	}
+	if status == nil {
+		return resp
+	}
+	resp.UpdatedBy = status.UpdatedBy
+	resp.UpdatedAt = &status.UpdatedAt
+	if resp.Status == status.Status {
+		resp.Reason = status.Reason
+		resp.ExpiresAt = status.ExpiresAt
+	}
+	return resp
+}
@@ -0,0 +1,152 @@
+package ucases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/lifenetwork-ai/iam-service/constants"
+	"github.com/lifenetwork-ai/iam-service/internal/delivery/dto"
+	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domainerrors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	mock_repositories "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/repositories"
+	mock_services "github.com/lifenetwork-ai/iam-service/mocks/domain/ucases/services"
+	mock_rate_limiter "github.com/lifenetwork-ai/iam-service/mocks/infrastructures/rate_limiter/types"
+)
+
+// activeAccountStatusRepo reports every user as active
+func activeAccountStatusRepo(ctrl *gomock.Controller) *mock_repositories.MockUserAccountStatusRepository {
+	repo := mock_repositories.NewMockUserAccountStatusRepository(ctrl)
+	repo.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	return repo
+}
+
+func TestUpdateUserStatus_SuspendSignsTheUserOut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	globalUserID := uuid.NewString()
+	statusRepo := mock_repositories.NewMockUserAccountStatusRepository(ctrl)
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	sessionRepo := mock_repositories.NewMockUserSessionRepository(ctrl)
+	tokenRepo := mock_repositories.NewMockSessionRefreshTokenRepository(ctrl)
+	kratos := mock_services.NewMockKratosService(ctrl)
+	u := &userStatusUseCase{
+		userAccountStatusRepo:   statusRepo,
+		userIdentityRepo:        identityRepo,
+		userSessionRepo:         sessionRepo,
+		sessionRefreshTokenRepo: tokenRepo,
+		kratosService:           kratos,
+		sessionCache:            NewSessionCache(nil, 0),
+	}
+	expiresAt := time.Now().Add(24 * time.Hour)
+
+	identityRepo.EXPECT().GetByGlobalUserIDAndTenantID(ctx, nil, globalUserID, tenantID.String()).
+		Return([]*domain.UserIdentity{{GlobalUserID: globalUserID}}, nil)
+	statusRepo.EXPECT().Upsert(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, status *domain.UserAccountStatus) error {
+		assert.Equal(t, constants.UserStatusSuspended, status.Status)
+		assert.Equal(t, "chargeback fraud", status.Reason)
+		assert.Equal(t, "support-admin", status.UpdatedBy)
+		return nil
+	})
+	sessionRepo.EXPECT().ListActive(ctx, tenantID.String(), globalUserID, gomock.Any()).
+		Return([]*domain.UserSession{{KratosSessionID: "session-1"}, {KratosSessionID: "session-2"}}, nil)
+	for _, sessionID := range []string{"session-1", "session-2"} {
+		kratos.EXPECT().DisableSessionAdmin(ctx, tenantID, sessionID).Return(nil)
+		tokenRepo.EXPECT().RevokeBySession(ctx, tenantID.String(), sessionID, gomock.Any()).Return(nil)
+		sessionRepo.EXPECT().Revoke(ctx, tenantID.String(), sessionID, gomock.Any()).Return(nil)
+	}
+
+	resp, derr := u.UpdateUserStatus(ctx, tenantID, globalUserID, dto.UpdateUserStatusPayloadDTO{
+		Status:    constants.UserStatusSuspended,
+		Reason:    " chargeback fraud ",
+		ExpiresAt: &expiresAt,
+	}, "support-admin")
+	require.Nil(t, derr)
+	assert.Equal(t, constants.UserStatusSuspended, resp.Status)
+	assert.Equal(t, &expiresAt, resp.ExpiresAt)
+}
+
+func TestUpdateUserStatus_RejectsInvalidChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	u := &userStatusUseCase{}
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	for code, req := range map[string]dto.UpdateUserStatusPayloadDTO{
+		"MSG_REASON_REQUIRED":     {Status: constants.UserStatusBanned, Reason: "  "},
+		"MSG_INVALID_EXPIRY":      {Status: constants.UserStatusSuspended, Reason: "spam", ExpiresAt: &past},
+		"MSG_INVALID_USER_STATUS": {Status: "deleted"},
+	} {
+		resp, derr := u.UpdateUserStatus(context.Background(), uuid.New(), uuid.NewString(), req, "support-admin")
+		assert.Nil(t, resp)
+		require.NotNil(t, derr, code)
+		assert.Equal(t, code, derr.Code)
+	}
+
+	_, derr := u.UpdateUserStatus(context.Background(), uuid.New(), uuid.NewString(), dto.UpdateUserStatusPayloadDTO{
+		Status: constants.UserStatusBanned, Reason: "spam", ExpiresAt: &future,
+	}, "support-admin")
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_INVALID_EXPIRY", derr.Code, "a ban does not expire")
+}
+
+func TestAccountStatusError_SuspensionEndsOnItsOwn(t *testing.T) {
+	now := time.Now()
+	ended := now.Add(-time.Minute)
+	ongoing := now.Add(time.Minute)
+
+	assert.Nil(t, accountStatusError(nil, now))
+	assert.Nil(t, accountStatusError(&domain.UserAccountStatus{Status: constants.UserStatusSuspended, ExpiresAt: &ended}, now))
+
+	derr := accountStatusError(&domain.UserAccountStatus{Status: constants.UserStatusSuspended, ExpiresAt: &ongoing}, now)
+	require.NotNil(t, derr)
+	assert.Equal(t, domainerrors.ErrorTypeForbidden, derr.Type)
+	assert.Equal(t, "MSG_ACCOUNT_SUSPENDED", derr.Code)
+
+	derr = accountStatusError(&domain.UserAccountStatus{Status: constants.UserStatusBanned, Reason: "fraud"}, now)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_ACCOUNT_BANNED", derr.Code)
+	assert.Nil(t, derr.Details, "the reason is not shown to the user")
+}
+
+func TestChallengeWithEmail_RefusesBannedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	tenantID := uuid.New()
+	rateLimiter := mock_rate_limiter.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().IsLimited(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+	rateLimiter.EXPECT().RegisterAttempt(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	tenantRepo := mock_repositories.NewMockTenantRepository(ctrl)
+	tenantRepo.EXPECT().GetByID(tenantID).Return(&domain.Tenant{ID: tenantID}, nil)
+	identityRepo := mock_repositories.NewMockUserIdentityRepository(ctrl)
+	identityRepo.EXPECT().GetByTypeAndValue(ctx, nil, tenantID.String(), constants.IdentifierEmail.String(), "banned@example.com").
+		Return(&domain.UserIdentity{GlobalUserID: "global-1"}, nil)
+	statusRepo := mock_repositories.NewMockUserAccountStatusRepository(ctrl)
+	statusRepo.EXPECT().Get(ctx, tenantID.String(), "global-1").
+		Return(&domain.UserAccountStatus{Status: constants.UserStatusBanned}, nil)
+
+	// No code is sent: Kratos is never asked for a login flow
+	u := &userUseCase{
+		rateLimiter:           rateLimiter,
+		tenantRepo:            tenantRepo,
+		userIdentityRepo:      identityRepo,
+		userAccountStatusRepo: statusRepo,
+		kratosService:         mock_services.NewMockKratosService(ctrl),
+	}
+	resp, derr := u.ChallengeWithEmail(ctx, tenantID, "Banned@example.com")
+	assert.Nil(t, resp)
+	require.NotNil(t, derr)
+	assert.Equal(t, "MSG_ACCOUNT_BANNED", derr.Code)
+}
@@ -0,0 +1,29 @@
+package instances
+
+import (
+	"sync"
+
+	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/internal/adapters/services/password"
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+)
+
+var (
+	breachedPasswordOnce     sync.Once
+	breachedPasswordInstance domainservice.BreachedPasswordChecker
+)
+
+// BreachedPasswordCheckerInstance returns a singleton breached-password checker.
+// If the configured list cannot be read, only the bundled list is used.
+func BreachedPasswordCheckerInstance() domainservice.BreachedPasswordChecker {
+	breachedPasswordOnce.Do(func() {
+		checker, err := password.NewLocalBreachedList(conf.GetBreachedPasswordListPath())
+		if err != nil {
+			logger.GetLogger().Errorf("Failed to load breached password list, falling back to bundled list: %v", err)
+			checker, _ = password.NewLocalBreachedList("")
+		}
+		breachedPasswordInstance = checker
+	})
+	return breachedPasswordInstance
+}
@@ -0,0 +1,29 @@
+package instances
+
+import (
+	"sync"
+
+	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/internal/adapters/services/email"
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+)
+
+var (
+	emailSenderOnce     sync.Once
+	emailSenderInstance domainservice.EmailSender
+)
+
+// EmailSenderInstance returns a singleton email sender posting to the configured webhook.
+// Without a webhook there is no sender, and security notices skip email identifiers.
+func EmailSenderInstance() domainservice.EmailSender {
+	emailSenderOnce.Do(func() {
+		url := conf.GetNotificationEmailWebhookURL()
+		if url == "" {
+			logger.GetLogger().Warn("NOTIFICATION_EMAIL_WEBHOOK_URL is not set, security notices will not be emailed")
+			return
+		}
+		emailSenderInstance = email.NewWebhookSender(url)
+	})
+	return emailSenderInstance
+}
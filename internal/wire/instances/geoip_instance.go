@@ -0,0 +1,29 @@
+package instances
+
+import (
+	"sync"
+
+	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/internal/adapters/services/geoip"
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+)
+
+var (
+	geoIPOnce     sync.Once
+	geoIPInstance domainservice.GeoIPLocator
+)
+
+// GeoIPLocatorInstance returns a singleton GeoIP locator.
+// If the configured database cannot be read, no login is located.
+func GeoIPLocatorInstance() domainservice.GeoIPLocator {
+	geoIPOnce.Do(func() {
+		locator, err := geoip.NewLocalDatabase(conf.GetGeoIPDatabasePath())
+		if err != nil {
+			logger.GetLogger().Errorf("Failed to load GeoIP database, logins will not be located: %v", err)
+			locator, _ = geoip.NewLocalDatabase("")
+		}
+		geoIPInstance = locator
+	})
+	return geoIPInstance
+}
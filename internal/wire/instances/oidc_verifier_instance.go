@@ -0,0 +1,46 @@
+package instances
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/lifenetwork-ai/iam-service/conf"
+	"github.com/lifenetwork-ai/iam-service/constants"
+	"github.com/lifenetwork-ai/iam-service/internal/adapters/services/oidc"
+	domainservice "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/services"
+)
+
+var (
+	oidcVerifierOnce     sync.Once
+	oidcVerifierInstance domainservice.OIDCTokenVerifier
+)
+
+// OIDCVerifierInstance returns a singleton ID token verifier for the social providers.
+func OIDCVerifierInstance() domainservice.OIDCTokenVerifier {
+	oidcVerifierOnce.Do(func() {
+		config := conf.GetOIDCConfiguration()
+		oidcVerifierInstance = oidc.NewJWKSVerifier(map[string]oidc.Provider{
+			constants.IdentifierGoogle.String(): {
+				Issuers:   oidc.GoogleIssuers,
+				JWKSURL:   config.GoogleJWKSURL,
+				ClientIDs: splitList(config.GoogleClientIDs),
+			},
+			constants.IdentifierApple.String(): {
+				Issuers:   oidc.AppleIssuers,
+				JWKSURL:   config.AppleJWKSURL,
+				ClientIDs: splitList(config.AppleClientIDs),
+			},
+		}, nil)
+	})
+	return oidcVerifierInstance
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/lifenetwork-ai/iam-service/conf"
 	"github.com/lifenetwork-ai/iam-service/infrastructures/caching/types"
 	"github.com/lifenetwork-ai/iam-service/internal/adapters/repositories"
+	"github.com/lifenetwork-ai/iam-service/internal/adapters/services/accesstoken"
 	keto "github.com/lifenetwork-ai/iam-service/internal/adapters/services/keto"
+	"github.com/lifenetwork-ai/iam-service/internal/adapters/services/passkey"
 	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases"
 	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
 	domainrepo "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
@@ -17,14 +19,34 @@ import (
 
 // Struct to hold all repositories
 type Repos struct {
-	ChallengeSessionRepo      domainrepo.ChallengeSessionRepository
-	GlobalUserRepo            domainrepo.GlobalUserRepository
-	UserIdentityRepo          domainrepo.UserIdentityRepository
-	UserIdentifierMappingRepo domainrepo.UserIdentifierMappingRepository
-	TenantRepo                domainrepo.TenantRepository
-	AdminAccountRepo          domainrepo.AdminAccountRepository
-	ZaloTokenRepo             domainrepo.ZaloTokenRepository
-	CacheRepo                 types.CacheRepository
+	ChallengeSessionRepo       domainrepo.ChallengeSessionRepository
+	GlobalUserRepo             domainrepo.GlobalUserRepository
+	UserIdentityRepo           domainrepo.UserIdentityRepository
+	UserIdentifierMappingRepo  domainrepo.UserIdentifierMappingRepository
+	TenantRepo                 domainrepo.TenantRepository
+	AdminAccountRepo           domainrepo.AdminAccountRepository
+	ZaloTokenRepo              domainrepo.ZaloTokenRepository
+	TenantSettingRepo          domainrepo.TenantSettingRepository
+	SessionRefreshTokenRepo    domainrepo.SessionRefreshTokenRepository
+	UserMFARepo                domainrepo.UserMFARepository
+	UserPasskeyRepo            domainrepo.UserPasskeyRepository
+	UserSessionRepo            domainrepo.UserSessionRepository
+	SigningKeyRepo             domainrepo.SigningKeyRepository
+	OAuthClientRepo            domainrepo.OAuthClientRepository
+	OAuthConsentRepo           domainrepo.OAuthConsentRepository
+	OAuthAuthorizationCodeRepo domainrepo.OAuthAuthorizationCodeRepository
+	AccountDeletionRepo        domainrepo.AccountDeletionRepository
+	UserIdentityChangeLogRepo  domainrepo.UserIdentityChangeLogRepository
+	UserDataExportRepo         domainrepo.UserDataExportRepository
+	UserAccountStatusRepo      domainrepo.UserAccountStatusRepository
+	UserInvitationRepo         domainrepo.UserInvitationRepository
+	UserImportRepo             domainrepo.UserImportRepository
+	IdentifierLockoutRepo      domainrepo.IdentifierLockoutRepository
+	TrustedDeviceRepo          domainrepo.TrustedDeviceRepository
+	LoginEventRepo             domainrepo.LoginEventRepository
+	SecurityNotificationRepo   domainrepo.SecurityNotificationRepository
+	IdentifierQuarantineRepo   domainrepo.IdentifierQuarantineRepository
+	CacheRepo                  types.CacheRepository
 }
 
 // Initialize repositories (only using cache where needed)
@@ -39,46 +61,215 @@ func InitializeRepos(db *gorm.DB, cacheRepo types.CacheRepository) *Repos {
 		TenantRepo: repositories.NewTenantRepositoryCache(
 			repositories.NewTenantRepository(db), cacheRepo,
 		),
-		AdminAccountRepo: repositories.NewAdminAccountRepository(db),
-		ZaloTokenRepo:    repositories.NewZaloTokenRepositoryCache(repositories.NewZaloTokenRepository(db), cacheRepo),
+		AdminAccountRepo:           repositories.NewAdminAccountRepository(db),
+		ZaloTokenRepo:              repositories.NewZaloTokenRepositoryCache(repositories.NewZaloTokenRepository(db), cacheRepo),
+		TenantSettingRepo:          repositories.NewTenantSettingRepository(db),
+		SessionRefreshTokenRepo:    repositories.NewSessionRefreshTokenRepository(db),
+		UserMFARepo:                repositories.NewUserMFARepository(db),
+		UserPasskeyRepo:            repositories.NewUserPasskeyRepository(db),
+		UserSessionRepo:            repositories.NewUserSessionRepository(db),
+		SigningKeyRepo:             repositories.NewSigningKeyRepository(db),
+		OAuthClientRepo:            repositories.NewOAuthClientRepository(db),
+		OAuthConsentRepo:           repositories.NewOAuthConsentRepository(db),
+		OAuthAuthorizationCodeRepo: repositories.NewOAuthAuthorizationCodeRepository(db),
+		AccountDeletionRepo:        repositories.NewAccountDeletionRepository(db),
+		UserIdentityChangeLogRepo:  repositories.NewUserIdentityChangeLogRepository(db),
+		UserDataExportRepo:         repositories.NewUserDataExportRepository(db),
+		UserAccountStatusRepo:      repositories.NewUserAccountStatusRepository(db),
+		UserInvitationRepo:         repositories.NewUserInvitationRepository(db),
+		UserImportRepo:             repositories.NewUserImportRepository(db),
+		IdentifierLockoutRepo:      repositories.NewIdentifierLockoutRepository(db),
+		TrustedDeviceRepo:          repositories.NewTrustedDeviceRepository(db),
+		LoginEventRepo:             repositories.NewLoginEventRepository(db),
+		SecurityNotificationRepo:   repositories.NewSecurityNotificationRepository(db),
+		IdentifierQuarantineRepo:   repositories.NewIdentifierQuarantineRepository(db),
 	}
 }
 
 // Struct to hold all use cases
 type UseCases struct {
-	IdentityUserUCase interfaces.IdentityUserUseCase
-	AdminUCase        interfaces.AdminUseCase
-	TenantUCase       interfaces.TenantUseCase
-	PermissionUCase   interfaces.PermissionUseCase
-	CourierUCase      interfaces.CourierUseCase
-	SmsTokenUCase     interfaces.SmsTokenUseCase
+	IdentityUserUCase         interfaces.IdentityUserUseCase
+	AdminUCase                interfaces.AdminUseCase
+	TenantUCase               interfaces.TenantUseCase
+	PermissionUCase           interfaces.PermissionUseCase
+	CourierUCase              interfaces.CourierUseCase
+	SmsTokenUCase             interfaces.SmsTokenUseCase
+	TokenUCase                interfaces.TokenUseCase
+	OIDCProviderUCase         interfaces.OIDCProviderUseCase
+	AccountDeletionUCase      interfaces.AccountDeletionUseCase
+	DataExportUCase           interfaces.DataExportUseCase
+	IdentityHistoryUCase      interfaces.IdentityHistoryUseCase
+	UserStatusUCase           interfaces.UserStatusUseCase
+	InvitationUCase           interfaces.InvitationUseCase
+	UserImportUCase           interfaces.UserImportUseCase
+	IdentifierLockoutUCase    interfaces.IdentifierLockoutUseCase
+	TrustedDeviceUCase        interfaces.TrustedDeviceUseCase
+	LoginHistoryUCase         interfaces.LoginHistoryUseCase
+	SecurityNoticeUCase       interfaces.SecurityNotificationUseCase
+	IdentifierQuarantineUCase interfaces.IdentifierQuarantineUseCase
 }
 
 // Initialize use cases
 func InitializeUseCases(db *gorm.DB, repos *Repos, cacheRepo types.CacheRepository) *UseCases {
+	// The identity use case switches OTP channels through the courier when a code is resent
+	courierUCase := ucases.NewCourierUseCase(instances.OTPQueueRepositoryInstance(context.Background()), instances.SMSServiceInstance(repos.ZaloTokenRepo, repos.TenantRepo), repos.CacheRepo)
+	// Every use case that validates or invalidates sessions shares one session cache
+	sessionCache := ucases.NewSessionCache(cacheRepo, conf.GetSessionCacheTTL())
+	// Identifier changes made by users and administrators alike are notified
+	securityNoticeUCase := ucases.NewSecurityNotificationUseCase(
+		sessionCache,
+		repos.TenantRepo,
+		repos.UserIdentityRepo,
+		repos.SecurityNotificationRepo,
+		repos.UserAccountStatusRepo,
+		repos.UserSessionRepo,
+		repos.SessionRefreshTokenRepo,
+		instances.KratosServiceInstance(repos.TenantRepo),
+		instances.EmailSenderInstance(),
+		courierUCase,
+	)
+
 	// Return all use cases
 	return &UseCases{
 		IdentityUserUCase: ucases.NewIdentityUserUseCase(
 			db,
 			cacheRepo,
+			sessionCache,
 			instances.RateLimiterInstance(),
 			repos.ChallengeSessionRepo,
 			repos.TenantRepo,
 			repos.GlobalUserRepo,
 			repos.UserIdentityRepo,
 			repos.UserIdentifierMappingRepo,
+			repos.TenantSettingRepo,
+			repos.SessionRefreshTokenRepo,
+			repos.UserMFARepo,
+			repos.UserPasskeyRepo,
+			repos.UserSessionRepo,
+			repos.UserIdentityChangeLogRepo,
+			repos.UserAccountStatusRepo,
+			repos.UserInvitationRepo,
+			repos.IdentifierLockoutRepo,
+			repos.TrustedDeviceRepo,
+			repos.LoginEventRepo,
+			repos.IdentifierQuarantineRepo,
 			instances.KratosServiceInstance(repos.TenantRepo),
+			instances.BreachedPasswordCheckerInstance(),
+			instances.OIDCVerifierInstance(),
+			passkey.NewWebAuthnService(),
+			instances.GeoIPLocatorInstance(),
+			courierUCase,
+			securityNoticeUCase,
 		),
 		AdminUCase: ucases.NewAdminUseCase(
+			db,
 			repos.TenantRepo,
 			repos.AdminAccountRepo,
 			repos.UserIdentityRepo,
 			repos.UserIdentifierMappingRepo,
+			repos.TenantSettingRepo,
+			repos.OAuthClientRepo,
+			repos.UserIdentityChangeLogRepo,
+			repos.LoginEventRepo,
+			repos.IdentifierQuarantineRepo,
 			instances.KratosServiceInstance(repos.TenantRepo),
+			securityNoticeUCase,
+			sessionCache,
 		),
 		TenantUCase:     ucases.NewTenantUseCase(repos.TenantRepo),
 		PermissionUCase: ucases.NewPermissionUseCase(keto.NewKetoService(repos.TenantRepo), repos.UserIdentityRepo),
-		CourierUCase:    ucases.NewCourierUseCase(instances.OTPQueueRepositoryInstance(context.Background()), instances.SMSServiceInstance(repos.ZaloTokenRepo, repos.TenantRepo), repos.CacheRepo),
+		CourierUCase:    courierUCase,
 		SmsTokenUCase:   ucases.NewSmsTokenUseCase(repos.ZaloTokenRepo, conf.GetConfiguration().DbEncryptionKey),
+		TokenUCase: ucases.NewTokenUseCase(
+			repos.SigningKeyRepo,
+			instances.KratosServiceInstance(repos.TenantRepo),
+			accesstoken.NewRSASigner(),
+		),
+		OIDCProviderUCase: ucases.NewOIDCProviderUseCase(
+			repos.TenantRepo,
+			repos.TenantSettingRepo,
+			repos.OAuthClientRepo,
+			repos.OAuthConsentRepo,
+			repos.OAuthAuthorizationCodeRepo,
+			repos.UserIdentityRepo,
+			repos.UserIdentifierMappingRepo,
+			repos.UserAccountStatusRepo,
+			cacheRepo,
+			instances.KratosServiceInstance(repos.TenantRepo),
+			repos.SigningKeyRepo,
+			accesstoken.NewRSASigner(),
+		),
+		AccountDeletionUCase: ucases.NewAccountDeletionUseCase(
+			db,
+			sessionCache,
+			instances.RateLimiterInstance(),
+			repos.ChallengeSessionRepo,
+			repos.IdentifierLockoutRepo,
+			repos.IdentifierQuarantineRepo,
+			repos.TenantSettingRepo,
+			repos.AccountDeletionRepo,
+			repos.GlobalUserRepo,
+			repos.UserIdentityRepo,
+			repos.UserIdentifierMappingRepo,
+			repos.UserSessionRepo,
+			repos.SessionRefreshTokenRepo,
+			instances.KratosServiceInstance(repos.TenantRepo),
+			keto.NewKetoService(repos.TenantRepo),
+		),
+		DataExportUCase: ucases.NewDataExportUseCase(
+			instances.RateLimiterInstance(),
+			repos.UserDataExportRepo,
+			repos.UserIdentityRepo,
+			repos.UserIdentifierMappingRepo,
+			repos.UserIdentityChangeLogRepo,
+			repos.LoginEventRepo,
+			repos.UserSessionRepo,
+			repos.SessionRefreshTokenRepo,
+			repos.TrustedDeviceRepo,
+			repos.UserPasskeyRepo,
+			repos.UserMFARepo,
+			instances.KratosServiceInstance(repos.TenantRepo),
+			keto.NewKetoService(repos.TenantRepo),
+		),
+		IdentityHistoryUCase: ucases.NewIdentityHistoryUseCase(repos.UserIdentityChangeLogRepo),
+		UserStatusUCase: ucases.NewUserStatusUseCase(
+			sessionCache,
+			repos.UserAccountStatusRepo,
+			repos.UserIdentityRepo,
+			repos.UserSessionRepo,
+			repos.SessionRefreshTokenRepo,
+			instances.KratosServiceInstance(repos.TenantRepo),
+		),
+		InvitationUCase: ucases.NewInvitationUseCase(
+			db,
+			instances.RateLimiterInstance(),
+			repos.TenantRepo,
+			repos.UserInvitationRepo,
+			repos.GlobalUserRepo,
+			repos.UserIdentityRepo,
+			repos.UserIdentifierMappingRepo,
+			repos.UserIdentityChangeLogRepo,
+			repos.IdentifierQuarantineRepo,
+			repos.IdentifierLockoutRepo,
+			instances.KratosServiceInstance(repos.TenantRepo),
+			keto.NewKetoService(repos.TenantRepo),
+		),
+		UserImportUCase: ucases.NewUserImportUseCase(
+			db,
+			repos.TenantRepo,
+			repos.UserImportRepo,
+			repos.UserInvitationRepo,
+			repos.GlobalUserRepo,
+			repos.UserIdentityRepo,
+			repos.UserIdentifierMappingRepo,
+			repos.UserIdentityChangeLogRepo,
+			repos.IdentifierQuarantineRepo,
+			instances.KratosServiceInstance(repos.TenantRepo),
+		),
+		IdentifierLockoutUCase:    ucases.NewIdentifierLockoutUseCase(repos.IdentifierLockoutRepo),
+		TrustedDeviceUCase:        ucases.NewTrustedDeviceUseCase(repos.TrustedDeviceRepo),
+		LoginHistoryUCase:         ucases.NewLoginHistoryUseCase(repos.LoginEventRepo),
+		SecurityNoticeUCase:       securityNoticeUCase,
+		IdentifierQuarantineUCase: ucases.NewIdentifierQuarantineUseCase(repos.IdentifierQuarantineRepo),
 	}
 }
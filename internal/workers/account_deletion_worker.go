@@ -0,0 +1,57 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	"github.com/lifenetwork-ai/iam-service/internal/workers/types"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+)
+
+type accountDeletionWorker struct {
+	accountDeletionUCase interfaces.AccountDeletionUseCase
+}
+
+// NewAccountDeletionWorker creates a worker that erases accounts whose deletion grace period has passed
+func NewAccountDeletionWorker(accountDeletionUCase interfaces.AccountDeletionUseCase) types.Worker {
+	return &accountDeletionWorker{
+		accountDeletionUCase: accountDeletionUCase,
+	}
+}
+
+// Name returns the worker name
+func (w *accountDeletionWorker) Name() string {
+	return "account-deletion-worker"
+}
+
+// Start periodically erases the accounts that are due
+func (w *accountDeletionWorker) Start(ctx context.Context, interval time.Duration) {
+	logger.GetLogger().Infof("[%s] started with interval %s", w.Name(), interval.String())
+
+	w.process(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.process(ctx)
+		case <-ctx.Done():
+			logger.GetLogger().Infof("[%s] stopped", w.Name())
+			return
+		}
+	}
+}
+
+func (w *accountDeletionWorker) process(ctx context.Context) {
+	erased, err := w.accountDeletionUCase.ProcessDueAccountDeletions(ctx)
+	if err != nil {
+		logger.GetLogger().Errorf("[%s] failed to process account deletions: %v", w.Name(), err)
+		return
+	}
+	if erased > 0 {
+		logger.GetLogger().Infof("[%s] erased %d accounts", w.Name(), erased)
+	}
+}
@@ -0,0 +1,57 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	"github.com/lifenetwork-ai/iam-service/internal/workers/types"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+)
+
+type dataExportWorker struct {
+	dataExportUCase interfaces.DataExportUseCase
+}
+
+// NewDataExportWorker creates a worker that generates queued personal data exports and deletes expired ones
+func NewDataExportWorker(dataExportUCase interfaces.DataExportUseCase) types.Worker {
+	return &dataExportWorker{
+		dataExportUCase: dataExportUCase,
+	}
+}
+
+// Name returns the worker name
+func (w *dataExportWorker) Name() string {
+	return "data-export-worker"
+}
+
+// Start periodically generates the queued exports
+func (w *dataExportWorker) Start(ctx context.Context, interval time.Duration) {
+	logger.GetLogger().Infof("[%s] started with interval %s", w.Name(), interval.String())
+
+	w.process(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.process(ctx)
+		case <-ctx.Done():
+			logger.GetLogger().Infof("[%s] stopped", w.Name())
+			return
+		}
+	}
+}
+
+func (w *dataExportWorker) process(ctx context.Context) {
+	generated, err := w.dataExportUCase.ProcessPendingDataExports(ctx)
+	if err != nil {
+		logger.GetLogger().Errorf("[%s] failed to process data exports: %v", w.Name(), err)
+		return
+	}
+	if generated > 0 {
+		logger.GetLogger().Infof("[%s] generated %d data exports", w.Name(), generated)
+	}
+}
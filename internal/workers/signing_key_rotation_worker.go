@@ -0,0 +1,59 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	"github.com/lifenetwork-ai/iam-service/internal/workers/types"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+)
+
+type signingKeyRotationWorker struct {
+	tokenUCase interfaces.TokenUseCase
+	maxAge     time.Duration
+}
+
+// NewSigningKeyRotationWorker creates a worker that rotates the access token signing key once it is older than maxAge
+func NewSigningKeyRotationWorker(tokenUCase interfaces.TokenUseCase, maxAge time.Duration) types.Worker {
+	return &signingKeyRotationWorker{
+		tokenUCase: tokenUCase,
+		maxAge:     maxAge,
+	}
+}
+
+// Name returns the worker name
+func (w *signingKeyRotationWorker) Name() string {
+	return "signing-key-rotation-worker"
+}
+
+// Start periodically checks the age of the signing key
+func (w *signingKeyRotationWorker) Start(ctx context.Context, interval time.Duration) {
+	logger.GetLogger().Infof("[%s] started with interval %s", w.Name(), interval.String())
+
+	w.process(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.process(ctx)
+		case <-ctx.Done():
+			logger.GetLogger().Infof("[%s] stopped", w.Name())
+			return
+		}
+	}
+}
+
+func (w *signingKeyRotationWorker) process(ctx context.Context) {
+	rotated, err := w.tokenUCase.RotateSigningKeyIfDue(ctx, w.maxAge)
+	if err != nil {
+		logger.GetLogger().Errorf("[%s] failed to rotate signing key: %v", w.Name(), err)
+		return
+	}
+	if rotated {
+		logger.GetLogger().Infof("[%s] rotated signing key older than %s", w.Name(), w.maxAge.String())
+	}
+}
@@ -0,0 +1,57 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/lifenetwork-ai/iam-service/internal/domain/ucases/interfaces"
+	"github.com/lifenetwork-ai/iam-service/internal/workers/types"
+	"github.com/lifenetwork-ai/iam-service/packages/logger"
+)
+
+type userImportWorker struct {
+	userImportUCase interfaces.UserImportUseCase
+}
+
+// NewUserImportWorker creates a worker that imports the rows of queued bulk user imports
+func NewUserImportWorker(userImportUCase interfaces.UserImportUseCase) types.Worker {
+	return &userImportWorker{
+		userImportUCase: userImportUCase,
+	}
+}
+
+// Name returns the worker name
+func (w *userImportWorker) Name() string {
+	return "user-import-worker"
+}
+
+// Start periodically imports the next batch of each queued import
+func (w *userImportWorker) Start(ctx context.Context, interval time.Duration) {
+	logger.GetLogger().Infof("[%s] started with interval %s", w.Name(), interval.String())
+
+	w.process(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.process(ctx)
+		case <-ctx.Done():
+			logger.GetLogger().Infof("[%s] stopped", w.Name())
+			return
+		}
+	}
+}
+
+func (w *userImportWorker) process(ctx context.Context) {
+	processed, err := w.userImportUCase.ProcessPendingUserImports(ctx)
+	if err != nil {
+		logger.GetLogger().Errorf("[%s] failed to process user imports: %v", w.Name(), err)
+		return
+	}
+	if processed > 0 {
+		logger.GetLogger().Infof("[%s] processed %d user import rows", w.Name(), processed)
+	}
+}
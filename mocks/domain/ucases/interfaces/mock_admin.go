@@ -11,6 +11,7 @@ package mock_interfaces
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	uuid "github.com/google/uuid"
@@ -18,6 +19,7 @@ import (
 	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
 	types "github.com/lifenetwork-ai/iam-service/internal/domain/types"
 	errors "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/errors"
+	types0 "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/types"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -46,18 +48,18 @@ func (m *MockAdminUseCase) EXPECT() *MockAdminUseCaseMockRecorder {
 }
 
 // AddIdentifierAdmin mocks base method.
-func (m *MockAdminUseCase) AddIdentifierAdmin(ctx context.Context, tenantID uuid.UUID, req dto.AdminAddIdentifierPayloadDTO) (*dto.AdminAddIdentifierResponse, *errors.DomainError) {
+func (m *MockAdminUseCase) AddIdentifierAdmin(ctx context.Context, tenantID uuid.UUID, req dto.AdminAddIdentifierPayloadDTO, adminUsername string) (*dto.AdminAddIdentifierResponse, *errors.DomainError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddIdentifierAdmin", ctx, tenantID, req)
+	ret := m.ctrl.Call(m, "AddIdentifierAdmin", ctx, tenantID, req, adminUsername)
 	ret0, _ := ret[0].(*dto.AdminAddIdentifierResponse)
 	ret1, _ := ret[1].(*errors.DomainError)
 	return ret0, ret1
 }
 
 // AddIdentifierAdmin indicates an expected call of AddIdentifierAdmin.
-func (mr *MockAdminUseCaseMockRecorder) AddIdentifierAdmin(ctx, tenantID, req any) *gomock.Call {
+func (mr *MockAdminUseCaseMockRecorder) AddIdentifierAdmin(ctx, tenantID, req, adminUsername any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddIdentifierAdmin", reflect.TypeOf((*MockAdminUseCase)(nil).AddIdentifierAdmin), ctx, tenantID, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddIdentifierAdmin", reflect.TypeOf((*MockAdminUseCase)(nil).AddIdentifierAdmin), ctx, tenantID, req, adminUsername)
 }
 
 // CheckIdentifierAdmin mocks base method.
@@ -91,6 +93,21 @@ func (mr *MockAdminUseCaseMockRecorder) CreateAdminAccount(ctx, username, passwo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAdminAccount", reflect.TypeOf((*MockAdminUseCase)(nil).CreateAdminAccount), ctx, username, password, role)
 }
 
+// CreateOAuthClient mocks base method.
+func (m *MockAdminUseCase) CreateOAuthClient(ctx context.Context, id string, req dto.CreateOAuthClientPayloadDTO) (*dto.OAuthClientCredentialsDTO, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOAuthClient", ctx, id, req)
+	ret0, _ := ret[0].(*dto.OAuthClientCredentialsDTO)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// CreateOAuthClient indicates an expected call of CreateOAuthClient.
+func (mr *MockAdminUseCaseMockRecorder) CreateOAuthClient(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOAuthClient", reflect.TypeOf((*MockAdminUseCase)(nil).CreateOAuthClient), ctx, id, req)
+}
+
 // CreateTenant mocks base method.
 func (m *MockAdminUseCase) CreateTenant(ctx context.Context, name, publicURL, adminURL string) (*domain.Tenant, *errors.DomainError) {
 	m.ctrl.T.Helper()
@@ -121,6 +138,20 @@ func (mr *MockAdminUseCaseMockRecorder) DeleteTenant(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTenant", reflect.TypeOf((*MockAdminUseCase)(nil).DeleteTenant), ctx, id)
 }
 
+// ExportTenantUsers mocks base method.
+func (m *MockAdminUseCase) ExportTenantUsers(ctx context.Context, tenantID uuid.UUID, query types0.TenantUserExportQuery, w io.Writer) *errors.DomainError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTenantUsers", ctx, tenantID, query, w)
+	ret0, _ := ret[0].(*errors.DomainError)
+	return ret0
+}
+
+// ExportTenantUsers indicates an expected call of ExportTenantUsers.
+func (mr *MockAdminUseCaseMockRecorder) ExportTenantUsers(ctx, tenantID, query, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTenantUsers", reflect.TypeOf((*MockAdminUseCase)(nil).ExportTenantUsers), ctx, tenantID, query, w)
+}
+
 // GetAdminAccountByUsername mocks base method.
 func (m *MockAdminUseCase) GetAdminAccountByUsername(ctx context.Context, username string) (*domain.AdminAccount, *errors.DomainError) {
 	m.ctrl.T.Helper()
@@ -151,6 +182,66 @@ func (mr *MockAdminUseCaseMockRecorder) GetTenantByID(ctx, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenantByID", reflect.TypeOf((*MockAdminUseCase)(nil).GetTenantByID), ctx, id)
 }
 
+// GetTenantSetting mocks base method.
+func (m *MockAdminUseCase) GetTenantSetting(ctx context.Context, id string) (*domain.TenantSetting, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenantSetting", ctx, id)
+	ret0, _ := ret[0].(*domain.TenantSetting)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// GetTenantSetting indicates an expected call of GetTenantSetting.
+func (mr *MockAdminUseCaseMockRecorder) GetTenantSetting(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenantSetting", reflect.TypeOf((*MockAdminUseCase)(nil).GetTenantSetting), ctx, id)
+}
+
+// GetTenantUser mocks base method.
+func (m *MockAdminUseCase) GetTenantUser(ctx context.Context, tenantID uuid.UUID, globalUserID string) (*types0.TenantUserDetail, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenantUser", ctx, tenantID, globalUserID)
+	ret0, _ := ret[0].(*types0.TenantUserDetail)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// GetTenantUser indicates an expected call of GetTenantUser.
+func (mr *MockAdminUseCaseMockRecorder) GetTenantUser(ctx, tenantID, globalUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenantUser", reflect.TypeOf((*MockAdminUseCase)(nil).GetTenantUser), ctx, tenantID, globalUserID)
+}
+
+// ListOAuthClients mocks base method.
+func (m *MockAdminUseCase) ListOAuthClients(ctx context.Context, id string) ([]*domain.OAuthClient, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOAuthClients", ctx, id)
+	ret0, _ := ret[0].([]*domain.OAuthClient)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// ListOAuthClients indicates an expected call of ListOAuthClients.
+func (mr *MockAdminUseCaseMockRecorder) ListOAuthClients(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOAuthClients", reflect.TypeOf((*MockAdminUseCase)(nil).ListOAuthClients), ctx, id)
+}
+
+// ListTenantUsers mocks base method.
+func (m *MockAdminUseCase) ListTenantUsers(ctx context.Context, tenantID uuid.UUID, query types0.TenantUserQuery) (*types0.TenantUserPage, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTenantUsers", ctx, tenantID, query)
+	ret0, _ := ret[0].(*types0.TenantUserPage)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// ListTenantUsers indicates an expected call of ListTenantUsers.
+func (mr *MockAdminUseCaseMockRecorder) ListTenantUsers(ctx, tenantID, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTenantUsers", reflect.TypeOf((*MockAdminUseCase)(nil).ListTenantUsers), ctx, tenantID, query)
+}
+
 // ListTenants mocks base method.
 func (m *MockAdminUseCase) ListTenants(ctx context.Context, page, size int, keyword string) (*types.PaginatedResponse[*domain.Tenant], *errors.DomainError) {
 	m.ctrl.T.Helper()
@@ -166,6 +257,20 @@ func (mr *MockAdminUseCaseMockRecorder) ListTenants(ctx, page, size, keyword any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTenants", reflect.TypeOf((*MockAdminUseCase)(nil).ListTenants), ctx, page, size, keyword)
 }
 
+// RevokeOAuthClient mocks base method.
+func (m *MockAdminUseCase) RevokeOAuthClient(ctx context.Context, id, clientID string) *errors.DomainError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOAuthClient", ctx, id, clientID)
+	ret0, _ := ret[0].(*errors.DomainError)
+	return ret0
+}
+
+// RevokeOAuthClient indicates an expected call of RevokeOAuthClient.
+func (mr *MockAdminUseCaseMockRecorder) RevokeOAuthClient(ctx, id, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOAuthClient", reflect.TypeOf((*MockAdminUseCase)(nil).RevokeOAuthClient), ctx, id, clientID)
+}
+
 // UpdateTenant mocks base method.
 func (m *MockAdminUseCase) UpdateTenant(ctx context.Context, id, name, publicURL, adminURL string) (*domain.Tenant, *errors.DomainError) {
 	m.ctrl.T.Helper()
@@ -180,3 +285,18 @@ func (mr *MockAdminUseCaseMockRecorder) UpdateTenant(ctx, id, name, publicURL, a
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTenant", reflect.TypeOf((*MockAdminUseCase)(nil).UpdateTenant), ctx, id, name, publicURL, adminURL)
 }
+
+// UpdateTenantSetting mocks base method.
+func (m *MockAdminUseCase) UpdateTenantSetting(ctx context.Context, id string, req dto.UpdateTenantSettingPayloadDTO) (*domain.TenantSetting, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTenantSetting", ctx, id, req)
+	ret0, _ := ret[0].(*domain.TenantSetting)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// UpdateTenantSetting indicates an expected call of UpdateTenantSetting.
+func (mr *MockAdminUseCaseMockRecorder) UpdateTenantSetting(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTenantSetting", reflect.TypeOf((*MockAdminUseCase)(nil).UpdateTenantSetting), ctx, id, req)
+}
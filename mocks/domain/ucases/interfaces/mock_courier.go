@@ -57,6 +57,20 @@ func (mr *MockCourierUseCaseMockRecorder) ChooseChannel(ctx, tenantName, receive
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChooseChannel", reflect.TypeOf((*MockCourierUseCase)(nil).ChooseChannel), ctx, tenantName, receiver, channel)
 }
 
+// DeliverMessage mocks base method.
+func (m *MockCourierUseCase) DeliverMessage(ctx context.Context, tenantName, receiver, message string) *errors.DomainError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverMessage", ctx, tenantName, receiver, message)
+	ret0, _ := ret[0].(*errors.DomainError)
+	return ret0
+}
+
+// DeliverMessage indicates an expected call of DeliverMessage.
+func (mr *MockCourierUseCaseMockRecorder) DeliverMessage(ctx, tenantName, receiver, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverMessage", reflect.TypeOf((*MockCourierUseCase)(nil).DeliverMessage), ctx, tenantName, receiver, message)
+}
+
 // DeliverOTP mocks base method.
 func (m *MockCourierUseCase) DeliverOTP(ctx context.Context, tenantName, receiver string) *errors.DomainError {
 	m.ctrl.T.Helper()
@@ -43,6 +43,21 @@ func (m *MockIdentityUserUseCase) EXPECT() *MockIdentityUserUseCaseMockRecorder
 	return m.recorder
 }
 
+// ActivateTOTP mocks base method.
+func (m *MockIdentityUserUseCase) ActivateTOTP(ctx context.Context, tenantID uuid.UUID, globalUserID, code string) (*types.MFARecoveryCodesResponse, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateTOTP", ctx, tenantID, globalUserID, code)
+	ret0, _ := ret[0].(*types.MFARecoveryCodesResponse)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// ActivateTOTP indicates an expected call of ActivateTOTP.
+func (mr *MockIdentityUserUseCaseMockRecorder) ActivateTOTP(ctx, tenantID, globalUserID, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateTOTP", reflect.TypeOf((*MockIdentityUserUseCase)(nil).ActivateTOTP), ctx, tenantID, globalUserID, code)
+}
+
 // AddNewIdentifier mocks base method.
 func (m *MockIdentityUserUseCase) AddNewIdentifier(ctx context.Context, tenantID uuid.UUID, globalUserID, identifier, identifierType string) (*types.IdentityUserChallengeResponse, *errors.DomainError) {
 	m.ctrl.T.Helper()
@@ -58,6 +73,36 @@ func (mr *MockIdentityUserUseCaseMockRecorder) AddNewIdentifier(ctx, tenantID, g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNewIdentifier", reflect.TypeOf((*MockIdentityUserUseCase)(nil).AddNewIdentifier), ctx, tenantID, globalUserID, identifier, identifierType)
 }
 
+// BeginPasskeyRegistration mocks base method.
+func (m *MockIdentityUserUseCase) BeginPasskeyRegistration(ctx context.Context, tenantID uuid.UUID, globalUserID, accountName string) (*types.PasskeyChallengeResponse, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginPasskeyRegistration", ctx, tenantID, globalUserID, accountName)
+	ret0, _ := ret[0].(*types.PasskeyChallengeResponse)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// BeginPasskeyRegistration indicates an expected call of BeginPasskeyRegistration.
+func (mr *MockIdentityUserUseCaseMockRecorder) BeginPasskeyRegistration(ctx, tenantID, globalUserID, accountName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginPasskeyRegistration", reflect.TypeOf((*MockIdentityUserUseCase)(nil).BeginPasskeyRegistration), ctx, tenantID, globalUserID, accountName)
+}
+
+// ChallengePasswordRecovery mocks base method.
+func (m *MockIdentityUserUseCase) ChallengePasswordRecovery(ctx context.Context, tenantID uuid.UUID, identifier string) (*types.IdentityUserChallengeResponse, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChallengePasswordRecovery", ctx, tenantID, identifier)
+	ret0, _ := ret[0].(*types.IdentityUserChallengeResponse)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// ChallengePasswordRecovery indicates an expected call of ChallengePasswordRecovery.
+func (mr *MockIdentityUserUseCaseMockRecorder) ChallengePasswordRecovery(ctx, tenantID, identifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChallengePasswordRecovery", reflect.TypeOf((*MockIdentityUserUseCase)(nil).ChallengePasswordRecovery), ctx, tenantID, identifier)
+}
+
 // ChallengeVerification mocks base method.
 func (m *MockIdentityUserUseCase) ChallengeVerification(ctx context.Context, tenantID uuid.UUID, identifier string) (*types.IdentityUserChallengeResponse, *errors.DomainError) {
 	m.ctrl.T.Helper()
@@ -88,6 +133,21 @@ func (mr *MockIdentityUserUseCaseMockRecorder) ChallengeWithEmail(ctx, tenantID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChallengeWithEmail", reflect.TypeOf((*MockIdentityUserUseCase)(nil).ChallengeWithEmail), ctx, tenantID, email)
 }
 
+// ChallengeWithPasskey mocks base method.
+func (m *MockIdentityUserUseCase) ChallengeWithPasskey(ctx context.Context, tenantID uuid.UUID) (*types.PasskeyChallengeResponse, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChallengeWithPasskey", ctx, tenantID)
+	ret0, _ := ret[0].(*types.PasskeyChallengeResponse)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// ChallengeWithPasskey indicates an expected call of ChallengeWithPasskey.
+func (mr *MockIdentityUserUseCaseMockRecorder) ChallengeWithPasskey(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChallengeWithPasskey", reflect.TypeOf((*MockIdentityUserUseCase)(nil).ChallengeWithPasskey), ctx, tenantID)
+}
+
 // ChallengeWithPhone mocks base method.
 func (m *MockIdentityUserUseCase) ChallengeWithPhone(ctx context.Context, tenantID uuid.UUID, phone string) (*types.IdentityUserChallengeResponse, *errors.DomainError) {
 	m.ctrl.T.Helper()
@@ -103,6 +163,21 @@ func (mr *MockIdentityUserUseCaseMockRecorder) ChallengeWithPhone(ctx, tenantID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChallengeWithPhone", reflect.TypeOf((*MockIdentityUserUseCase)(nil).ChallengeWithPhone), ctx, tenantID, phone)
 }
 
+// ChallengeWithWallet mocks base method.
+func (m *MockIdentityUserUseCase) ChallengeWithWallet(ctx context.Context, tenantID uuid.UUID, address string) (*types.WalletChallengeResponse, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChallengeWithWallet", ctx, tenantID, address)
+	ret0, _ := ret[0].(*types.WalletChallengeResponse)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// ChallengeWithWallet indicates an expected call of ChallengeWithWallet.
+func (mr *MockIdentityUserUseCaseMockRecorder) ChallengeWithWallet(ctx, tenantID, address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChallengeWithWallet", reflect.TypeOf((*MockIdentityUserUseCase)(nil).ChallengeWithWallet), ctx, tenantID, address)
+}
+
 // ChangeIdentifier mocks base method.
 func (m *MockIdentityUserUseCase) ChangeIdentifier(ctx context.Context, globalUserID string, tenantID uuid.UUID, kratosUserID, newIdentifier string) (*types.IdentityUserChallengeResponse, *errors.DomainError) {
 	m.ctrl.T.Helper()
@@ -132,6 +207,124 @@ func (mr *MockIdentityUserUseCaseMockRecorder) DeleteIdentifier(ctx, globalUserI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIdentifier", reflect.TypeOf((*MockIdentityUserUseCase)(nil).DeleteIdentifier), ctx, globalUserID, tenantID, kratosUserID, identifierType)
 }
 
+// DeletePasskey mocks base method.
+func (m *MockIdentityUserUseCase) DeletePasskey(ctx context.Context, tenantID uuid.UUID, globalUserID, passkeyID string) *errors.DomainError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePasskey", ctx, tenantID, globalUserID, passkeyID)
+	ret0, _ := ret[0].(*errors.DomainError)
+	return ret0
+}
+
+// DeletePasskey indicates an expected call of DeletePasskey.
+func (mr *MockIdentityUserUseCaseMockRecorder) DeletePasskey(ctx, tenantID, globalUserID, passkeyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePasskey", reflect.TypeOf((*MockIdentityUserUseCase)(nil).DeletePasskey), ctx, tenantID, globalUserID, passkeyID)
+}
+
+// DisableTOTP mocks base method.
+func (m *MockIdentityUserUseCase) DisableTOTP(ctx context.Context, tenantID uuid.UUID, globalUserID, code string) *errors.DomainError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTOTP", ctx, tenantID, globalUserID, code)
+	ret0, _ := ret[0].(*errors.DomainError)
+	return ret0
+}
+
+// DisableTOTP indicates an expected call of DisableTOTP.
+func (mr *MockIdentityUserUseCaseMockRecorder) DisableTOTP(ctx, tenantID, globalUserID, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTOTP", reflect.TypeOf((*MockIdentityUserUseCase)(nil).DisableTOTP), ctx, tenantID, globalUserID, code)
+}
+
+// EnrollTOTP mocks base method.
+func (m *MockIdentityUserUseCase) EnrollTOTP(ctx context.Context, tenantID uuid.UUID, globalUserID, accountName string) (*types.TOTPEnrollmentResponse, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTOTP", ctx, tenantID, globalUserID, accountName)
+	ret0, _ := ret[0].(*types.TOTPEnrollmentResponse)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// EnrollTOTP indicates an expected call of EnrollTOTP.
+func (mr *MockIdentityUserUseCaseMockRecorder) EnrollTOTP(ctx, tenantID, globalUserID, accountName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTOTP", reflect.TypeOf((*MockIdentityUserUseCase)(nil).EnrollTOTP), ctx, tenantID, globalUserID, accountName)
+}
+
+// FinishPasskeyRegistration mocks base method.
+func (m *MockIdentityUserUseCase) FinishPasskeyRegistration(ctx context.Context, tenantID uuid.UUID, globalUserID, flowID, name string, credential []byte) (*types.PasskeyResponse, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishPasskeyRegistration", ctx, tenantID, globalUserID, flowID, name, credential)
+	ret0, _ := ret[0].(*types.PasskeyResponse)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// FinishPasskeyRegistration indicates an expected call of FinishPasskeyRegistration.
+func (mr *MockIdentityUserUseCaseMockRecorder) FinishPasskeyRegistration(ctx, tenantID, globalUserID, flowID, name, credential any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishPasskeyRegistration", reflect.TypeOf((*MockIdentityUserUseCase)(nil).FinishPasskeyRegistration), ctx, tenantID, globalUserID, flowID, name, credential)
+}
+
+// IntrospectToken mocks base method.
+func (m *MockIdentityUserUseCase) IntrospectToken(ctx context.Context, tenantID uuid.UUID, token string) (*types.IntrospectionResponse, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IntrospectToken", ctx, tenantID, token)
+	ret0, _ := ret[0].(*types.IntrospectionResponse)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// IntrospectToken indicates an expected call of IntrospectToken.
+func (mr *MockIdentityUserUseCaseMockRecorder) IntrospectToken(ctx, tenantID, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntrospectToken", reflect.TypeOf((*MockIdentityUserUseCase)(nil).IntrospectToken), ctx, tenantID, token)
+}
+
+// LinkOIDCIdentifier mocks base method.
+func (m *MockIdentityUserUseCase) LinkOIDCIdentifier(ctx context.Context, tenantID uuid.UUID, globalUserID, provider, idToken, nonce string) (*types.IdentityLinkedResponse, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkOIDCIdentifier", ctx, tenantID, globalUserID, provider, idToken, nonce)
+	ret0, _ := ret[0].(*types.IdentityLinkedResponse)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// LinkOIDCIdentifier indicates an expected call of LinkOIDCIdentifier.
+func (mr *MockIdentityUserUseCaseMockRecorder) LinkOIDCIdentifier(ctx, tenantID, globalUserID, provider, idToken, nonce any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkOIDCIdentifier", reflect.TypeOf((*MockIdentityUserUseCase)(nil).LinkOIDCIdentifier), ctx, tenantID, globalUserID, provider, idToken, nonce)
+}
+
+// ListPasskeys mocks base method.
+func (m *MockIdentityUserUseCase) ListPasskeys(ctx context.Context, tenantID uuid.UUID, globalUserID string) ([]*types.PasskeyResponse, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPasskeys", ctx, tenantID, globalUserID)
+	ret0, _ := ret[0].([]*types.PasskeyResponse)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// ListPasskeys indicates an expected call of ListPasskeys.
+func (mr *MockIdentityUserUseCaseMockRecorder) ListPasskeys(ctx, tenantID, globalUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPasskeys", reflect.TypeOf((*MockIdentityUserUseCase)(nil).ListPasskeys), ctx, tenantID, globalUserID)
+}
+
+// ListSessions mocks base method.
+func (m *MockIdentityUserUseCase) ListSessions(ctx context.Context, tenantID uuid.UUID, globalUserID string) ([]*types.UserSessionResponse, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx, tenantID, globalUserID)
+	ret0, _ := ret[0].([]*types.UserSessionResponse)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockIdentityUserUseCaseMockRecorder) ListSessions(ctx, tenantID, globalUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockIdentityUserUseCase)(nil).ListSessions), ctx, tenantID, globalUserID)
+}
+
 // Login mocks base method.
 func (m *MockIdentityUserUseCase) Login(ctx context.Context, tenantID uuid.UUID, username, password string) (*types.IdentityUserAuthResponse, *errors.DomainError) {
 	m.ctrl.T.Helper()
@@ -147,6 +340,21 @@ func (mr *MockIdentityUserUseCaseMockRecorder) Login(ctx, tenantID, username, pa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockIdentityUserUseCase)(nil).Login), ctx, tenantID, username, password)
 }
 
+// LoginWithOIDC mocks base method.
+func (m *MockIdentityUserUseCase) LoginWithOIDC(ctx context.Context, tenantID uuid.UUID, provider, idToken, nonce, lang string) (*types.IdentityUserAuthResponse, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginWithOIDC", ctx, tenantID, provider, idToken, nonce, lang)
+	ret0, _ := ret[0].(*types.IdentityUserAuthResponse)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// LoginWithOIDC indicates an expected call of LoginWithOIDC.
+func (mr *MockIdentityUserUseCaseMockRecorder) LoginWithOIDC(ctx, tenantID, provider, idToken, nonce, lang any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginWithOIDC", reflect.TypeOf((*MockIdentityUserUseCase)(nil).LoginWithOIDC), ctx, tenantID, provider, idToken, nonce, lang)
+}
+
 // Logout mocks base method.
 func (m *MockIdentityUserUseCase) Logout(ctx context.Context, tenantID uuid.UUID) *errors.DomainError {
 	m.ctrl.T.Helper()
@@ -206,6 +414,78 @@ func (mr *MockIdentityUserUseCaseMockRecorder) Register(ctx, tenantID, lang, ema
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockIdentityUserUseCase)(nil).Register), ctx, tenantID, lang, email, phone)
 }
 
+// ResendChallenge mocks base method.
+func (m *MockIdentityUserUseCase) ResendChallenge(ctx context.Context, tenantID uuid.UUID, flowID, channel string) (*types.IdentityUserResendResponse, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendChallenge", ctx, tenantID, flowID, channel)
+	ret0, _ := ret[0].(*types.IdentityUserResendResponse)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// ResendChallenge indicates an expected call of ResendChallenge.
+func (mr *MockIdentityUserUseCaseMockRecorder) ResendChallenge(ctx, tenantID, flowID, channel any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendChallenge", reflect.TypeOf((*MockIdentityUserUseCase)(nil).ResendChallenge), ctx, tenantID, flowID, channel)
+}
+
+// RevokeOtherSessions mocks base method.
+func (m *MockIdentityUserUseCase) RevokeOtherSessions(ctx context.Context, tenantID uuid.UUID, globalUserID string) (*types.SessionsRevokedResponse, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOtherSessions", ctx, tenantID, globalUserID)
+	ret0, _ := ret[0].(*types.SessionsRevokedResponse)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// RevokeOtherSessions indicates an expected call of RevokeOtherSessions.
+func (mr *MockIdentityUserUseCaseMockRecorder) RevokeOtherSessions(ctx, tenantID, globalUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOtherSessions", reflect.TypeOf((*MockIdentityUserUseCase)(nil).RevokeOtherSessions), ctx, tenantID, globalUserID)
+}
+
+// RevokeSession mocks base method.
+func (m *MockIdentityUserUseCase) RevokeSession(ctx context.Context, tenantID uuid.UUID, globalUserID, sessionID string) *errors.DomainError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, tenantID, globalUserID, sessionID)
+	ret0, _ := ret[0].(*errors.DomainError)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockIdentityUserUseCaseMockRecorder) RevokeSession(ctx, tenantID, globalUserID, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockIdentityUserUseCase)(nil).RevokeSession), ctx, tenantID, globalUserID, sessionID)
+}
+
+// SessionCacheStats mocks base method.
+func (m *MockIdentityUserUseCase) SessionCacheStats() *types.SessionCacheStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SessionCacheStats")
+	ret0, _ := ret[0].(*types.SessionCacheStats)
+	return ret0
+}
+
+// SessionCacheStats indicates an expected call of SessionCacheStats.
+func (mr *MockIdentityUserUseCaseMockRecorder) SessionCacheStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SessionCacheStats", reflect.TypeOf((*MockIdentityUserUseCase)(nil).SessionCacheStats))
+}
+
+// SetPassword mocks base method.
+func (m *MockIdentityUserUseCase) SetPassword(ctx context.Context, tenantID uuid.UUID, password string) *errors.DomainError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPassword", ctx, tenantID, password)
+	ret0, _ := ret[0].(*errors.DomainError)
+	return ret0
+}
+
+// SetPassword indicates an expected call of SetPassword.
+func (mr *MockIdentityUserUseCaseMockRecorder) SetPassword(ctx, tenantID, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPassword", reflect.TypeOf((*MockIdentityUserUseCase)(nil).SetPassword), ctx, tenantID, password)
+}
+
 // UpdateLang mocks base method.
 func (m *MockIdentityUserUseCase) UpdateLang(ctx context.Context, tenantID uuid.UUID, kratosUserID, lang string) *errors.DomainError {
 	m.ctrl.T.Helper()
@@ -236,18 +516,63 @@ func (mr *MockIdentityUserUseCaseMockRecorder) VerifyIdentifier(ctx, tenantID, f
 }
 
 // VerifyLogin mocks base method.
-func (m *MockIdentityUserUseCase) VerifyLogin(ctx context.Context, tenantID uuid.UUID, flowID, code string) (*types.IdentityUserAuthResponse, *errors.DomainError) {
+func (m *MockIdentityUserUseCase) VerifyLogin(ctx context.Context, tenantID uuid.UUID, flowID, code string, rememberDevice bool) (*types.IdentityUserAuthResponse, *errors.DomainError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "VerifyLogin", ctx, tenantID, flowID, code)
+	ret := m.ctrl.Call(m, "VerifyLogin", ctx, tenantID, flowID, code, rememberDevice)
 	ret0, _ := ret[0].(*types.IdentityUserAuthResponse)
 	ret1, _ := ret[1].(*errors.DomainError)
 	return ret0, ret1
 }
 
 // VerifyLogin indicates an expected call of VerifyLogin.
-func (mr *MockIdentityUserUseCaseMockRecorder) VerifyLogin(ctx, tenantID, flowID, code any) *gomock.Call {
+func (mr *MockIdentityUserUseCaseMockRecorder) VerifyLogin(ctx, tenantID, flowID, code, rememberDevice any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyLogin", reflect.TypeOf((*MockIdentityUserUseCase)(nil).VerifyLogin), ctx, tenantID, flowID, code, rememberDevice)
+}
+
+// VerifyMFA mocks base method.
+func (m *MockIdentityUserUseCase) VerifyMFA(ctx context.Context, tenantID uuid.UUID, flowID, code string) (*types.IdentityUserAuthResponse, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyMFA", ctx, tenantID, flowID, code)
+	ret0, _ := ret[0].(*types.IdentityUserAuthResponse)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// VerifyMFA indicates an expected call of VerifyMFA.
+func (mr *MockIdentityUserUseCaseMockRecorder) VerifyMFA(ctx, tenantID, flowID, code any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyLogin", reflect.TypeOf((*MockIdentityUserUseCase)(nil).VerifyLogin), ctx, tenantID, flowID, code)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMFA", reflect.TypeOf((*MockIdentityUserUseCase)(nil).VerifyMFA), ctx, tenantID, flowID, code)
+}
+
+// VerifyPasskey mocks base method.
+func (m *MockIdentityUserUseCase) VerifyPasskey(ctx context.Context, tenantID uuid.UUID, flowID string, credential []byte) (*types.IdentityUserAuthResponse, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyPasskey", ctx, tenantID, flowID, credential)
+	ret0, _ := ret[0].(*types.IdentityUserAuthResponse)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// VerifyPasskey indicates an expected call of VerifyPasskey.
+func (mr *MockIdentityUserUseCaseMockRecorder) VerifyPasskey(ctx, tenantID, flowID, credential any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyPasskey", reflect.TypeOf((*MockIdentityUserUseCase)(nil).VerifyPasskey), ctx, tenantID, flowID, credential)
+}
+
+// VerifyPasswordRecovery mocks base method.
+func (m *MockIdentityUserUseCase) VerifyPasswordRecovery(ctx context.Context, tenantID uuid.UUID, flowID, code, newPassword string) (*types.IdentityUserAuthResponse, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyPasswordRecovery", ctx, tenantID, flowID, code, newPassword)
+	ret0, _ := ret[0].(*types.IdentityUserAuthResponse)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// VerifyPasswordRecovery indicates an expected call of VerifyPasswordRecovery.
+func (mr *MockIdentityUserUseCaseMockRecorder) VerifyPasswordRecovery(ctx, tenantID, flowID, code, newPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyPasswordRecovery", reflect.TypeOf((*MockIdentityUserUseCase)(nil).VerifyPasswordRecovery), ctx, tenantID, flowID, code, newPassword)
 }
 
 // VerifyRegister mocks base method.
@@ -264,3 +589,18 @@ func (mr *MockIdentityUserUseCaseMockRecorder) VerifyRegister(ctx, tenantID, flo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyRegister", reflect.TypeOf((*MockIdentityUserUseCase)(nil).VerifyRegister), ctx, tenantID, flowID, code)
 }
+
+// VerifyWallet mocks base method.
+func (m *MockIdentityUserUseCase) VerifyWallet(ctx context.Context, tenantID uuid.UUID, flowID, message, signature, lang string) (*types.IdentityUserAuthResponse, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyWallet", ctx, tenantID, flowID, message, signature, lang)
+	ret0, _ := ret[0].(*types.IdentityUserAuthResponse)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// VerifyWallet indicates an expected call of VerifyWallet.
+func (mr *MockIdentityUserUseCaseMockRecorder) VerifyWallet(ctx, tenantID, flowID, message, signature, lang any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyWallet", reflect.TypeOf((*MockIdentityUserUseCase)(nil).VerifyWallet), ctx, tenantID, flowID, message, signature, lang)
+}
@@ -16,6 +16,7 @@ import (
 
 	uuid "github.com/google/uuid"
 	domain "github.com/lifenetwork-ai/iam-service/internal/domain/entities"
+	domain0 "github.com/lifenetwork-ai/iam-service/internal/domain/ucases/repositories"
 	gomock "go.uber.org/mock/gomock"
 	gorm "gorm.io/gorm"
 )
@@ -221,6 +222,20 @@ func (mr *MockGlobalUserRepositoryMockRecorder) Create(tx, user any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGlobalUserRepository)(nil).Create), tx, user)
 }
 
+// Delete mocks base method.
+func (m *MockGlobalUserRepository) Delete(tx *gorm.DB, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", tx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockGlobalUserRepositoryMockRecorder) Delete(tx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGlobalUserRepository)(nil).Delete), tx, id)
+}
+
 // GetByID mocks base method.
 func (m *MockGlobalUserRepository) GetByID(ctx context.Context, id string) (*domain.GlobalUser, error) {
 	m.ctrl.T.Helper()
@@ -236,6 +251,117 @@ func (mr *MockGlobalUserRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockGlobalUserRepository)(nil).GetByID), ctx, id)
 }
 
+// MockSessionRefreshTokenRepository is a mock of SessionRefreshTokenRepository interface.
+type MockSessionRefreshTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionRefreshTokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSessionRefreshTokenRepositoryMockRecorder is the mock recorder for MockSessionRefreshTokenRepository.
+type MockSessionRefreshTokenRepositoryMockRecorder struct {
+	mock *MockSessionRefreshTokenRepository
+}
+
+// NewMockSessionRefreshTokenRepository creates a new mock instance.
+func NewMockSessionRefreshTokenRepository(ctrl *gomock.Controller) *MockSessionRefreshTokenRepository {
+	mock := &MockSessionRefreshTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockSessionRefreshTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionRefreshTokenRepository) EXPECT() *MockSessionRefreshTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSessionRefreshTokenRepository) Create(ctx context.Context, token *domain.SessionRefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSessionRefreshTokenRepositoryMockRecorder) Create(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSessionRefreshTokenRepository)(nil).Create), ctx, token)
+}
+
+// GetByTokenHash mocks base method.
+func (m *MockSessionRefreshTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.SessionRefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*domain.SessionRefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTokenHash indicates an expected call of GetByTokenHash.
+func (mr *MockSessionRefreshTokenRepositoryMockRecorder) GetByTokenHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTokenHash", reflect.TypeOf((*MockSessionRefreshTokenRepository)(nil).GetByTokenHash), ctx, tokenHash)
+}
+
+// ListByKratosUserID mocks base method.
+func (m *MockSessionRefreshTokenRepository) ListByKratosUserID(ctx context.Context, tenantID, kratosUserID string) ([]*domain.SessionRefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByKratosUserID", ctx, tenantID, kratosUserID)
+	ret0, _ := ret[0].([]*domain.SessionRefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByKratosUserID indicates an expected call of ListByKratosUserID.
+func (mr *MockSessionRefreshTokenRepositoryMockRecorder) ListByKratosUserID(ctx, tenantID, kratosUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByKratosUserID", reflect.TypeOf((*MockSessionRefreshTokenRepository)(nil).ListByKratosUserID), ctx, tenantID, kratosUserID)
+}
+
+// RevokeBySession mocks base method.
+func (m *MockSessionRefreshTokenRepository) RevokeBySession(ctx context.Context, tenantID, kratosSessionID string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeBySession", ctx, tenantID, kratosSessionID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeBySession indicates an expected call of RevokeBySession.
+func (mr *MockSessionRefreshTokenRepositoryMockRecorder) RevokeBySession(ctx, tenantID, kratosSessionID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeBySession", reflect.TypeOf((*MockSessionRefreshTokenRepository)(nil).RevokeBySession), ctx, tenantID, kratosSessionID, at)
+}
+
+// RevokeFamily mocks base method.
+func (m *MockSessionRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeFamily", ctx, familyID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeFamily indicates an expected call of RevokeFamily.
+func (mr *MockSessionRefreshTokenRepositoryMockRecorder) RevokeFamily(ctx, familyID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeFamily", reflect.TypeOf((*MockSessionRefreshTokenRepository)(nil).RevokeFamily), ctx, familyID, at)
+}
+
+// Rotate mocks base method.
+func (m *MockSessionRefreshTokenRepository) Rotate(ctx context.Context, id string, next *domain.SessionRefreshToken, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", ctx, id, next, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockSessionRefreshTokenRepositoryMockRecorder) Rotate(ctx, id, next, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockSessionRefreshTokenRepository)(nil).Rotate), ctx, id, next, at)
+}
+
 // MockTenantRepository is a mock of TenantRepository interface.
 type MockTenantRepository struct {
 	ctrl     *gomock.Controller
@@ -347,6 +473,59 @@ func (mr *MockTenantRepositoryMockRecorder) Update(tenant any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTenantRepository)(nil).Update), tenant)
 }
 
+// MockTenantSettingRepository is a mock of TenantSettingRepository interface.
+type MockTenantSettingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenantSettingRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTenantSettingRepositoryMockRecorder is the mock recorder for MockTenantSettingRepository.
+type MockTenantSettingRepositoryMockRecorder struct {
+	mock *MockTenantSettingRepository
+}
+
+// NewMockTenantSettingRepository creates a new mock instance.
+func NewMockTenantSettingRepository(ctrl *gomock.Controller) *MockTenantSettingRepository {
+	mock := &MockTenantSettingRepository{ctrl: ctrl}
+	mock.recorder = &MockTenantSettingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenantSettingRepository) EXPECT() *MockTenantSettingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByTenantID mocks base method.
+func (m *MockTenantSettingRepository) GetByTenantID(ctx context.Context, tenantID uuid.UUID) (*domain.TenantSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTenantID", ctx, tenantID)
+	ret0, _ := ret[0].(*domain.TenantSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTenantID indicates an expected call of GetByTenantID.
+func (mr *MockTenantSettingRepositoryMockRecorder) GetByTenantID(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTenantID", reflect.TypeOf((*MockTenantSettingRepository)(nil).GetByTenantID), ctx, tenantID)
+}
+
+// Upsert mocks base method.
+func (m *MockTenantSettingRepository) Upsert(ctx context.Context, setting *domain.TenantSetting) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, setting)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockTenantSettingRepositoryMockRecorder) Upsert(ctx, setting any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockTenantSettingRepository)(nil).Upsert), ctx, setting)
+}
+
 // MockUserIdentityChangeLogRepository is a mock of UserIdentityChangeLogRepository interface.
 type MockUserIdentityChangeLogRepository struct {
 	ctrl     *gomock.Controller
@@ -415,6 +594,22 @@ func (mr *MockUserIdentityChangeLogRepositoryMockRecorder) ListByTenantID(ctx, t
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTenantID", reflect.TypeOf((*MockUserIdentityChangeLogRepository)(nil).ListByTenantID), ctx, tenantID)
 }
 
+// Search mocks base method.
+func (m *MockUserIdentityChangeLogRepository) Search(ctx context.Context, filter domain0.IdentityChangeLogFilter, offset, limit int) ([]*domain.UserIdentityChangeLog, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, filter, offset, limit)
+	ret0, _ := ret[0].([]*domain.UserIdentityChangeLog)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockUserIdentityChangeLogRepositoryMockRecorder) Search(ctx, filter, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockUserIdentityChangeLogRepository)(nil).Search), ctx, filter, offset, limit)
+}
+
 // MockUserIdentifierMappingRepository is a mock of UserIdentifierMappingRepository interface.
 type MockUserIdentifierMappingRepository struct {
 	ctrl     *gomock.Controller
@@ -453,6 +648,20 @@ func (mr *MockUserIdentifierMappingRepositoryMockRecorder) Create(ctx, tx, mappi
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserIdentifierMappingRepository)(nil).Create), ctx, tx, mapping)
 }
 
+// DeleteByGlobalUserID mocks base method.
+func (m *MockUserIdentifierMappingRepository) DeleteByGlobalUserID(ctx context.Context, tx *gorm.DB, globalUserID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByGlobalUserID", ctx, tx, globalUserID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByGlobalUserID indicates an expected call of DeleteByGlobalUserID.
+func (mr *MockUserIdentifierMappingRepositoryMockRecorder) DeleteByGlobalUserID(ctx, tx, globalUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByGlobalUserID", reflect.TypeOf((*MockUserIdentifierMappingRepository)(nil).DeleteByGlobalUserID), ctx, tx, globalUserID)
+}
+
 // GetByGlobalUserID mocks base method.
 func (m *MockUserIdentifierMappingRepository) GetByGlobalUserID(ctx context.Context, globalUserID string) (*domain.UserIdentifierMapping, error) {
 	m.ctrl.T.Helper()
@@ -468,6 +677,21 @@ func (mr *MockUserIdentifierMappingRepositoryMockRecorder) GetByGlobalUserID(ctx
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByGlobalUserID", reflect.TypeOf((*MockUserIdentifierMappingRepository)(nil).GetByGlobalUserID), ctx, globalUserID)
 }
 
+// ListByGlobalUserIDs mocks base method.
+func (m *MockUserIdentifierMappingRepository) ListByGlobalUserIDs(ctx context.Context, globalUserIDs []string) ([]*domain.UserIdentifierMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByGlobalUserIDs", ctx, globalUserIDs)
+	ret0, _ := ret[0].([]*domain.UserIdentifierMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByGlobalUserIDs indicates an expected call of ListByGlobalUserIDs.
+func (mr *MockUserIdentifierMappingRepositoryMockRecorder) ListByGlobalUserIDs(ctx, globalUserIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByGlobalUserIDs", reflect.TypeOf((*MockUserIdentifierMappingRepository)(nil).ListByGlobalUserIDs), ctx, globalUserIDs)
+}
+
 // Upsert mocks base method.
 func (m *MockUserIdentifierMappingRepository) Upsert(ctx context.Context, tx *gorm.DB, mapping *domain.UserIdentifierMapping) error {
 	m.ctrl.T.Helper()
@@ -520,6 +744,20 @@ func (mr *MockUserIdentityRepositoryMockRecorder) Delete(tx, identityID any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserIdentityRepository)(nil).Delete), tx, identityID)
 }
 
+// DeleteByGlobalUserID mocks base method.
+func (m *MockUserIdentityRepository) DeleteByGlobalUserID(ctx context.Context, tx *gorm.DB, globalUserID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByGlobalUserID", ctx, tx, globalUserID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByGlobalUserID indicates an expected call of DeleteByGlobalUserID.
+func (mr *MockUserIdentityRepositoryMockRecorder) DeleteByGlobalUserID(ctx, tx, globalUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByGlobalUserID", reflect.TypeOf((*MockUserIdentityRepository)(nil).DeleteByGlobalUserID), ctx, tx, globalUserID)
+}
+
 // ExistsByTenantGlobalUserIDAndType mocks base method.
 func (m *MockUserIdentityRepository) ExistsByTenantGlobalUserIDAndType(ctx context.Context, tenantID, globalUserID, identityType string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -625,6 +863,36 @@ func (mr *MockUserIdentityRepositoryMockRecorder) InsertOnceByKratosUserAndType(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOnceByKratosUserAndType", reflect.TypeOf((*MockUserIdentityRepository)(nil).InsertOnceByKratosUserAndType), ctx, tx, tenantID, kratosUserID, globalUserID, idType, value)
 }
 
+// ListByGlobalUserID mocks base method.
+func (m *MockUserIdentityRepository) ListByGlobalUserID(ctx context.Context, tx *gorm.DB, globalUserID string) ([]*domain.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByGlobalUserID", ctx, tx, globalUserID)
+	ret0, _ := ret[0].([]*domain.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByGlobalUserID indicates an expected call of ListByGlobalUserID.
+func (mr *MockUserIdentityRepositoryMockRecorder) ListByGlobalUserID(ctx, tx, globalUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByGlobalUserID", reflect.TypeOf((*MockUserIdentityRepository)(nil).ListByGlobalUserID), ctx, tx, globalUserID)
+}
+
+// ListByTenantAndGlobalUserIDs mocks base method.
+func (m *MockUserIdentityRepository) ListByTenantAndGlobalUserIDs(ctx context.Context, tenantID string, globalUserIDs []string) ([]*domain.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTenantAndGlobalUserIDs", ctx, tenantID, globalUserIDs)
+	ret0, _ := ret[0].([]*domain.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTenantAndGlobalUserIDs indicates an expected call of ListByTenantAndGlobalUserIDs.
+func (mr *MockUserIdentityRepositoryMockRecorder) ListByTenantAndGlobalUserIDs(ctx, tenantID, globalUserIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTenantAndGlobalUserIDs", reflect.TypeOf((*MockUserIdentityRepository)(nil).ListByTenantAndGlobalUserIDs), ctx, tenantID, globalUserIDs)
+}
+
 // ListByTenantAndKratosUserID mocks base method.
 func (m *MockUserIdentityRepository) ListByTenantAndKratosUserID(ctx context.Context, tx *gorm.DB, tenantID, kratosUserID string) ([]*domain.UserIdentity, error) {
 	m.ctrl.T.Helper()
@@ -640,6 +908,36 @@ func (mr *MockUserIdentityRepositoryMockRecorder) ListByTenantAndKratosUserID(ct
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTenantAndKratosUserID", reflect.TypeOf((*MockUserIdentityRepository)(nil).ListByTenantAndKratosUserID), ctx, tx, tenantID, kratosUserID)
 }
 
+// ListTenantUserIDs mocks base method.
+func (m *MockUserIdentityRepository) ListTenantUserIDs(ctx context.Context, tenantID, after string, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTenantUserIDs", ctx, tenantID, after, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTenantUserIDs indicates an expected call of ListTenantUserIDs.
+func (mr *MockUserIdentityRepositoryMockRecorder) ListTenantUserIDs(ctx, tenantID, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTenantUserIDs", reflect.TypeOf((*MockUserIdentityRepository)(nil).ListTenantUserIDs), ctx, tenantID, after, limit)
+}
+
+// SearchTenantUsers mocks base method.
+func (m *MockUserIdentityRepository) SearchTenantUsers(ctx context.Context, filter domain0.TenantUserFilter, limit int) ([]*domain0.TenantUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTenantUsers", ctx, filter, limit)
+	ret0, _ := ret[0].([]*domain0.TenantUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTenantUsers indicates an expected call of SearchTenantUsers.
+func (mr *MockUserIdentityRepositoryMockRecorder) SearchTenantUsers(ctx, filter, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTenantUsers", reflect.TypeOf((*MockUserIdentityRepository)(nil).SearchTenantUsers), ctx, filter, limit)
+}
+
 // Update mocks base method.
 func (m *MockUserIdentityRepository) Update(tx *gorm.DB, identity *domain.UserIdentity) error {
 	m.ctrl.T.Helper()
@@ -653,3 +951,1927 @@ func (mr *MockUserIdentityRepositoryMockRecorder) Update(tx, identity any) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserIdentityRepository)(nil).Update), tx, identity)
 }
+
+// MockUserMFARepository is a mock of UserMFARepository interface.
+type MockUserMFARepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserMFARepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockUserMFARepositoryMockRecorder is the mock recorder for MockUserMFARepository.
+type MockUserMFARepositoryMockRecorder struct {
+	mock *MockUserMFARepository
+}
+
+// NewMockUserMFARepository creates a new mock instance.
+func NewMockUserMFARepository(ctrl *gomock.Controller) *MockUserMFARepository {
+	mock := &MockUserMFARepository{ctrl: ctrl}
+	mock.recorder = &MockUserMFARepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserMFARepository) EXPECT() *MockUserMFARepositoryMockRecorder {
+	return m.recorder
+}
+
+// ConsumeStep mocks base method.
+func (m *MockUserMFARepository) ConsumeStep(ctx context.Context, factorID string, step int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeStep", ctx, factorID, step)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeStep indicates an expected call of ConsumeStep.
+func (mr *MockUserMFARepositoryMockRecorder) ConsumeStep(ctx, factorID, step any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeStep", reflect.TypeOf((*MockUserMFARepository)(nil).ConsumeStep), ctx, factorID, step)
+}
+
+// DeleteFactor mocks base method.
+func (m *MockUserMFARepository) DeleteFactor(ctx context.Context, tenantID, globalUserID, factorType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFactor", ctx, tenantID, globalUserID, factorType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFactor indicates an expected call of DeleteFactor.
+func (mr *MockUserMFARepositoryMockRecorder) DeleteFactor(ctx, tenantID, globalUserID, factorType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFactor", reflect.TypeOf((*MockUserMFARepository)(nil).DeleteFactor), ctx, tenantID, globalUserID, factorType)
+}
+
+// EnableFactor mocks base method.
+func (m *MockUserMFARepository) EnableFactor(ctx context.Context, factor *domain.UserMFAFactor, step int64, recoveryCodes []*domain.UserMFARecoveryCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableFactor", ctx, factor, step, recoveryCodes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableFactor indicates an expected call of EnableFactor.
+func (mr *MockUserMFARepositoryMockRecorder) EnableFactor(ctx, factor, step, recoveryCodes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableFactor", reflect.TypeOf((*MockUserMFARepository)(nil).EnableFactor), ctx, factor, step, recoveryCodes)
+}
+
+// GetFactor mocks base method.
+func (m *MockUserMFARepository) GetFactor(ctx context.Context, tenantID, globalUserID, factorType string) (*domain.UserMFAFactor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFactor", ctx, tenantID, globalUserID, factorType)
+	ret0, _ := ret[0].(*domain.UserMFAFactor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFactor indicates an expected call of GetFactor.
+func (mr *MockUserMFARepositoryMockRecorder) GetFactor(ctx, tenantID, globalUserID, factorType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFactor", reflect.TypeOf((*MockUserMFARepository)(nil).GetFactor), ctx, tenantID, globalUserID, factorType)
+}
+
+// SavePendingFactor mocks base method.
+func (m *MockUserMFARepository) SavePendingFactor(ctx context.Context, factor *domain.UserMFAFactor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePendingFactor", ctx, factor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePendingFactor indicates an expected call of SavePendingFactor.
+func (mr *MockUserMFARepositoryMockRecorder) SavePendingFactor(ctx, factor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePendingFactor", reflect.TypeOf((*MockUserMFARepository)(nil).SavePendingFactor), ctx, factor)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockUserMFARepository) UseRecoveryCode(ctx context.Context, tenantID, globalUserID, codeHash string, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, tenantID, globalUserID, codeHash, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockUserMFARepositoryMockRecorder) UseRecoveryCode(ctx, tenantID, globalUserID, codeHash, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockUserMFARepository)(nil).UseRecoveryCode), ctx, tenantID, globalUserID, codeHash, at)
+}
+
+// MockUserPasskeyRepository is a mock of UserPasskeyRepository interface.
+type MockUserPasskeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserPasskeyRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockUserPasskeyRepositoryMockRecorder is the mock recorder for MockUserPasskeyRepository.
+type MockUserPasskeyRepositoryMockRecorder struct {
+	mock *MockUserPasskeyRepository
+}
+
+// NewMockUserPasskeyRepository creates a new mock instance.
+func NewMockUserPasskeyRepository(ctrl *gomock.Controller) *MockUserPasskeyRepository {
+	mock := &MockUserPasskeyRepository{ctrl: ctrl}
+	mock.recorder = &MockUserPasskeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserPasskeyRepository) EXPECT() *MockUserPasskeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockUserPasskeyRepository) Create(ctx context.Context, passkey *domain.UserPasskey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, passkey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserPasskeyRepositoryMockRecorder) Create(ctx, passkey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserPasskeyRepository)(nil).Create), ctx, passkey)
+}
+
+// Delete mocks base method.
+func (m *MockUserPasskeyRepository) Delete(ctx context.Context, tenantID, globalUserID, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, tenantID, globalUserID, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUserPasskeyRepositoryMockRecorder) Delete(ctx, tenantID, globalUserID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserPasskeyRepository)(nil).Delete), ctx, tenantID, globalUserID, id)
+}
+
+// GetByCredentialID mocks base method.
+func (m *MockUserPasskeyRepository) GetByCredentialID(ctx context.Context, tenantID, credentialID string) (*domain.UserPasskey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCredentialID", ctx, tenantID, credentialID)
+	ret0, _ := ret[0].(*domain.UserPasskey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCredentialID indicates an expected call of GetByCredentialID.
+func (mr *MockUserPasskeyRepositoryMockRecorder) GetByCredentialID(ctx, tenantID, credentialID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCredentialID", reflect.TypeOf((*MockUserPasskeyRepository)(nil).GetByCredentialID), ctx, tenantID, credentialID)
+}
+
+// ListByGlobalUserID mocks base method.
+func (m *MockUserPasskeyRepository) ListByGlobalUserID(ctx context.Context, tenantID, globalUserID string) ([]*domain.UserPasskey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByGlobalUserID", ctx, tenantID, globalUserID)
+	ret0, _ := ret[0].([]*domain.UserPasskey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByGlobalUserID indicates an expected call of ListByGlobalUserID.
+func (mr *MockUserPasskeyRepositoryMockRecorder) ListByGlobalUserID(ctx, tenantID, globalUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByGlobalUserID", reflect.TypeOf((*MockUserPasskeyRepository)(nil).ListByGlobalUserID), ctx, tenantID, globalUserID)
+}
+
+// RecordUse mocks base method.
+func (m *MockUserPasskeyRepository) RecordUse(ctx context.Context, id string, signCount int64, backupState bool, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordUse", ctx, id, signCount, backupState, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordUse indicates an expected call of RecordUse.
+func (mr *MockUserPasskeyRepositoryMockRecorder) RecordUse(ctx, id, signCount, backupState, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordUse", reflect.TypeOf((*MockUserPasskeyRepository)(nil).RecordUse), ctx, id, signCount, backupState, at)
+}
+
+// MockUserSessionRepository is a mock of UserSessionRepository interface.
+type MockUserSessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserSessionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockUserSessionRepositoryMockRecorder is the mock recorder for MockUserSessionRepository.
+type MockUserSessionRepositoryMockRecorder struct {
+	mock *MockUserSessionRepository
+}
+
+// NewMockUserSessionRepository creates a new mock instance.
+func NewMockUserSessionRepository(ctrl *gomock.Controller) *MockUserSessionRepository {
+	mock := &MockUserSessionRepository{ctrl: ctrl}
+	mock.recorder = &MockUserSessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserSessionRepository) EXPECT() *MockUserSessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockUserSessionRepository) Create(ctx context.Context, session *domain.UserSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserSessionRepositoryMockRecorder) Create(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserSessionRepository)(nil).Create), ctx, session)
+}
+
+// Extend mocks base method.
+func (m *MockUserSessionRepository) Extend(ctx context.Context, tenantID, kratosSessionID string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Extend", ctx, tenantID, kratosSessionID, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Extend indicates an expected call of Extend.
+func (mr *MockUserSessionRepositoryMockRecorder) Extend(ctx, tenantID, kratosSessionID, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Extend", reflect.TypeOf((*MockUserSessionRepository)(nil).Extend), ctx, tenantID, kratosSessionID, expiresAt)
+}
+
+// GetByID mocks base method.
+func (m *MockUserSessionRepository) GetByID(ctx context.Context, tenantID, id string) (*domain.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, tenantID, id)
+	ret0, _ := ret[0].(*domain.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockUserSessionRepositoryMockRecorder) GetByID(ctx, tenantID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserSessionRepository)(nil).GetByID), ctx, tenantID, id)
+}
+
+// GetByKratosSessionID mocks base method.
+func (m *MockUserSessionRepository) GetByKratosSessionID(ctx context.Context, tenantID, kratosSessionID string) (*domain.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByKratosSessionID", ctx, tenantID, kratosSessionID)
+	ret0, _ := ret[0].(*domain.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByKratosSessionID indicates an expected call of GetByKratosSessionID.
+func (mr *MockUserSessionRepositoryMockRecorder) GetByKratosSessionID(ctx, tenantID, kratosSessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByKratosSessionID", reflect.TypeOf((*MockUserSessionRepository)(nil).GetByKratosSessionID), ctx, tenantID, kratosSessionID)
+}
+
+// ListActive mocks base method.
+func (m *MockUserSessionRepository) ListActive(ctx context.Context, tenantID, globalUserID string, now time.Time) ([]*domain.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActive", ctx, tenantID, globalUserID, now)
+	ret0, _ := ret[0].([]*domain.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActive indicates an expected call of ListActive.
+func (mr *MockUserSessionRepositoryMockRecorder) ListActive(ctx, tenantID, globalUserID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActive", reflect.TypeOf((*MockUserSessionRepository)(nil).ListActive), ctx, tenantID, globalUserID, now)
+}
+
+// ListByGlobalUserID mocks base method.
+func (m *MockUserSessionRepository) ListByGlobalUserID(ctx context.Context, tenantID, globalUserID string) ([]*domain.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByGlobalUserID", ctx, tenantID, globalUserID)
+	ret0, _ := ret[0].([]*domain.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByGlobalUserID indicates an expected call of ListByGlobalUserID.
+func (mr *MockUserSessionRepositoryMockRecorder) ListByGlobalUserID(ctx, tenantID, globalUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByGlobalUserID", reflect.TypeOf((*MockUserSessionRepository)(nil).ListByGlobalUserID), ctx, tenantID, globalUserID)
+}
+
+// Revoke mocks base method.
+func (m *MockUserSessionRepository) Revoke(ctx context.Context, tenantID, kratosSessionID string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, tenantID, kratosSessionID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockUserSessionRepositoryMockRecorder) Revoke(ctx, tenantID, kratosSessionID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockUserSessionRepository)(nil).Revoke), ctx, tenantID, kratosSessionID, at)
+}
+
+// Touch mocks base method.
+func (m *MockUserSessionRepository) Touch(ctx context.Context, tenantID, kratosSessionID string, at time.Time, interval time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", ctx, tenantID, kratosSessionID, at, interval)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockUserSessionRepositoryMockRecorder) Touch(ctx, tenantID, kratosSessionID, at, interval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockUserSessionRepository)(nil).Touch), ctx, tenantID, kratosSessionID, at, interval)
+}
+
+// MockSigningKeyRepository is a mock of SigningKeyRepository interface.
+type MockSigningKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSigningKeyRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSigningKeyRepositoryMockRecorder is the mock recorder for MockSigningKeyRepository.
+type MockSigningKeyRepositoryMockRecorder struct {
+	mock *MockSigningKeyRepository
+}
+
+// NewMockSigningKeyRepository creates a new mock instance.
+func NewMockSigningKeyRepository(ctrl *gomock.Controller) *MockSigningKeyRepository {
+	mock := &MockSigningKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockSigningKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSigningKeyRepository) EXPECT() *MockSigningKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSigningKeyRepository) Create(ctx context.Context, key *domain.SigningKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSigningKeyRepositoryMockRecorder) Create(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSigningKeyRepository)(nil).Create), ctx, key)
+}
+
+// ExpireCurrent mocks base method.
+func (m *MockSigningKeyRepository) ExpireCurrent(ctx context.Context, newestActivatesAt, expiresAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireCurrent", ctx, newestActivatesAt, expiresAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireCurrent indicates an expected call of ExpireCurrent.
+func (mr *MockSigningKeyRepositoryMockRecorder) ExpireCurrent(ctx, newestActivatesAt, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireCurrent", reflect.TypeOf((*MockSigningKeyRepository)(nil).ExpireCurrent), ctx, newestActivatesAt, expiresAt)
+}
+
+// ListPublished mocks base method.
+func (m *MockSigningKeyRepository) ListPublished(ctx context.Context, now time.Time) ([]*domain.SigningKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPublished", ctx, now)
+	ret0, _ := ret[0].([]*domain.SigningKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPublished indicates an expected call of ListPublished.
+func (mr *MockSigningKeyRepositoryMockRecorder) ListPublished(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPublished", reflect.TypeOf((*MockSigningKeyRepository)(nil).ListPublished), ctx, now)
+}
+
+// MockOAuthClientRepository is a mock of OAuthClientRepository interface.
+type MockOAuthClientRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOAuthClientRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOAuthClientRepositoryMockRecorder is the mock recorder for MockOAuthClientRepository.
+type MockOAuthClientRepositoryMockRecorder struct {
+	mock *MockOAuthClientRepository
+}
+
+// NewMockOAuthClientRepository creates a new mock instance.
+func NewMockOAuthClientRepository(ctrl *gomock.Controller) *MockOAuthClientRepository {
+	mock := &MockOAuthClientRepository{ctrl: ctrl}
+	mock.recorder = &MockOAuthClientRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOAuthClientRepository) EXPECT() *MockOAuthClientRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOAuthClientRepository) Create(ctx context.Context, client *domain.OAuthClient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, client)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOAuthClientRepositoryMockRecorder) Create(ctx, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOAuthClientRepository)(nil).Create), ctx, client)
+}
+
+// GetByClientID mocks base method.
+func (m *MockOAuthClientRepository) GetByClientID(ctx context.Context, clientID string) (*domain.OAuthClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByClientID", ctx, clientID)
+	ret0, _ := ret[0].(*domain.OAuthClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByClientID indicates an expected call of GetByClientID.
+func (mr *MockOAuthClientRepositoryMockRecorder) GetByClientID(ctx, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByClientID", reflect.TypeOf((*MockOAuthClientRepository)(nil).GetByClientID), ctx, clientID)
+}
+
+// ListByTenant mocks base method.
+func (m *MockOAuthClientRepository) ListByTenant(ctx context.Context, tenantID string) ([]*domain.OAuthClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTenant", ctx, tenantID)
+	ret0, _ := ret[0].([]*domain.OAuthClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTenant indicates an expected call of ListByTenant.
+func (mr *MockOAuthClientRepositoryMockRecorder) ListByTenant(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTenant", reflect.TypeOf((*MockOAuthClientRepository)(nil).ListByTenant), ctx, tenantID)
+}
+
+// Revoke mocks base method.
+func (m *MockOAuthClientRepository) Revoke(ctx context.Context, tenantID, clientID string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, tenantID, clientID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockOAuthClientRepositoryMockRecorder) Revoke(ctx, tenantID, clientID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockOAuthClientRepository)(nil).Revoke), ctx, tenantID, clientID, at)
+}
+
+// MockOAuthConsentRepository is a mock of OAuthConsentRepository interface.
+type MockOAuthConsentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOAuthConsentRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOAuthConsentRepositoryMockRecorder is the mock recorder for MockOAuthConsentRepository.
+type MockOAuthConsentRepositoryMockRecorder struct {
+	mock *MockOAuthConsentRepository
+}
+
+// NewMockOAuthConsentRepository creates a new mock instance.
+func NewMockOAuthConsentRepository(ctrl *gomock.Controller) *MockOAuthConsentRepository {
+	mock := &MockOAuthConsentRepository{ctrl: ctrl}
+	mock.recorder = &MockOAuthConsentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOAuthConsentRepository) EXPECT() *MockOAuthConsentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockOAuthConsentRepository) Get(ctx context.Context, tenantID, globalUserID, clientID string) (*domain.OAuthConsent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, tenantID, globalUserID, clientID)
+	ret0, _ := ret[0].(*domain.OAuthConsent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockOAuthConsentRepositoryMockRecorder) Get(ctx, tenantID, globalUserID, clientID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockOAuthConsentRepository)(nil).Get), ctx, tenantID, globalUserID, clientID)
+}
+
+// ListByUser mocks base method.
+func (m *MockOAuthConsentRepository) ListByUser(ctx context.Context, tenantID, globalUserID string) ([]*domain.OAuthConsent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", ctx, tenantID, globalUserID)
+	ret0, _ := ret[0].([]*domain.OAuthConsent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockOAuthConsentRepositoryMockRecorder) ListByUser(ctx, tenantID, globalUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockOAuthConsentRepository)(nil).ListByUser), ctx, tenantID, globalUserID)
+}
+
+// Revoke mocks base method.
+func (m *MockOAuthConsentRepository) Revoke(ctx context.Context, tenantID, globalUserID, clientID string, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, tenantID, globalUserID, clientID, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockOAuthConsentRepositoryMockRecorder) Revoke(ctx, tenantID, globalUserID, clientID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockOAuthConsentRepository)(nil).Revoke), ctx, tenantID, globalUserID, clientID, at)
+}
+
+// Upsert mocks base method.
+func (m *MockOAuthConsentRepository) Upsert(ctx context.Context, consent *domain.OAuthConsent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, consent)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockOAuthConsentRepositoryMockRecorder) Upsert(ctx, consent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockOAuthConsentRepository)(nil).Upsert), ctx, consent)
+}
+
+// MockOAuthAuthorizationCodeRepository is a mock of OAuthAuthorizationCodeRepository interface.
+type MockOAuthAuthorizationCodeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOAuthAuthorizationCodeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOAuthAuthorizationCodeRepositoryMockRecorder is the mock recorder for MockOAuthAuthorizationCodeRepository.
+type MockOAuthAuthorizationCodeRepositoryMockRecorder struct {
+	mock *MockOAuthAuthorizationCodeRepository
+}
+
+// NewMockOAuthAuthorizationCodeRepository creates a new mock instance.
+func NewMockOAuthAuthorizationCodeRepository(ctrl *gomock.Controller) *MockOAuthAuthorizationCodeRepository {
+	mock := &MockOAuthAuthorizationCodeRepository{ctrl: ctrl}
+	mock.recorder = &MockOAuthAuthorizationCodeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOAuthAuthorizationCodeRepository) EXPECT() *MockOAuthAuthorizationCodeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOAuthAuthorizationCodeRepository) Create(ctx context.Context, code *domain.OAuthAuthorizationCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOAuthAuthorizationCodeRepositoryMockRecorder) Create(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOAuthAuthorizationCodeRepository)(nil).Create), ctx, code)
+}
+
+// GetByCodeHash mocks base method.
+func (m *MockOAuthAuthorizationCodeRepository) GetByCodeHash(ctx context.Context, codeHash string) (*domain.OAuthAuthorizationCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCodeHash", ctx, codeHash)
+	ret0, _ := ret[0].(*domain.OAuthAuthorizationCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCodeHash indicates an expected call of GetByCodeHash.
+func (mr *MockOAuthAuthorizationCodeRepositoryMockRecorder) GetByCodeHash(ctx, codeHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCodeHash", reflect.TypeOf((*MockOAuthAuthorizationCodeRepository)(nil).GetByCodeHash), ctx, codeHash)
+}
+
+// MarkUsed mocks base method.
+func (m *MockOAuthAuthorizationCodeRepository) MarkUsed(ctx context.Context, id string, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, id, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockOAuthAuthorizationCodeRepositoryMockRecorder) MarkUsed(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockOAuthAuthorizationCodeRepository)(nil).MarkUsed), ctx, id, at)
+}
+
+// MockAccountDeletionRepository is a mock of AccountDeletionRepository interface.
+type MockAccountDeletionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountDeletionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAccountDeletionRepositoryMockRecorder is the mock recorder for MockAccountDeletionRepository.
+type MockAccountDeletionRepositoryMockRecorder struct {
+	mock *MockAccountDeletionRepository
+}
+
+// NewMockAccountDeletionRepository creates a new mock instance.
+func NewMockAccountDeletionRepository(ctrl *gomock.Controller) *MockAccountDeletionRepository {
+	mock := &MockAccountDeletionRepository{ctrl: ctrl}
+	mock.recorder = &MockAccountDeletionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountDeletionRepository) EXPECT() *MockAccountDeletionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockAccountDeletionRepository) Cancel(ctx context.Context, tenantID, globalUserID string, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, tenantID, globalUserID, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockAccountDeletionRepositoryMockRecorder) Cancel(ctx, tenantID, globalUserID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockAccountDeletionRepository)(nil).Cancel), ctx, tenantID, globalUserID, at)
+}
+
+// Claim mocks base method.
+func (m *MockAccountDeletionRepository) Claim(ctx context.Context, id string, now, retryAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, id, now, retryAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockAccountDeletionRepositoryMockRecorder) Claim(ctx, id, now, retryAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockAccountDeletionRepository)(nil).Claim), ctx, id, now, retryAt)
+}
+
+// Create mocks base method.
+func (m *MockAccountDeletionRepository) Create(ctx context.Context, deletion *domain.AccountDeletion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, deletion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAccountDeletionRepositoryMockRecorder) Create(ctx, deletion any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAccountDeletionRepository)(nil).Create), ctx, deletion)
+}
+
+// GetPending mocks base method.
+func (m *MockAccountDeletionRepository) GetPending(ctx context.Context, tenantID, globalUserID string) (*domain.AccountDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPending", ctx, tenantID, globalUserID)
+	ret0, _ := ret[0].(*domain.AccountDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPending indicates an expected call of GetPending.
+func (mr *MockAccountDeletionRepositoryMockRecorder) GetPending(ctx, tenantID, globalUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPending", reflect.TypeOf((*MockAccountDeletionRepository)(nil).GetPending), ctx, tenantID, globalUserID)
+}
+
+// ListByTenant mocks base method.
+func (m *MockAccountDeletionRepository) ListByTenant(ctx context.Context, tenantID string) ([]*domain.AccountDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTenant", ctx, tenantID)
+	ret0, _ := ret[0].([]*domain.AccountDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTenant indicates an expected call of ListByTenant.
+func (mr *MockAccountDeletionRepositoryMockRecorder) ListByTenant(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTenant", reflect.TypeOf((*MockAccountDeletionRepository)(nil).ListByTenant), ctx, tenantID)
+}
+
+// ListDue mocks base method.
+func (m *MockAccountDeletionRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.AccountDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDue", ctx, now, limit)
+	ret0, _ := ret[0].([]*domain.AccountDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDue indicates an expected call of ListDue.
+func (mr *MockAccountDeletionRepositoryMockRecorder) ListDue(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDue", reflect.TypeOf((*MockAccountDeletionRepository)(nil).ListDue), ctx, now, limit)
+}
+
+// MarkCompleted mocks base method.
+func (m *MockAccountDeletionRepository) MarkCompleted(ctx context.Context, id string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkCompleted", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkCompleted indicates an expected call of MarkCompleted.
+func (mr *MockAccountDeletionRepositoryMockRecorder) MarkCompleted(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkCompleted", reflect.TypeOf((*MockAccountDeletionRepository)(nil).MarkCompleted), ctx, id, at)
+}
+
+// RecordFailure mocks base method.
+func (m *MockAccountDeletionRepository) RecordFailure(ctx context.Context, id, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailure", ctx, id, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockAccountDeletionRepositoryMockRecorder) RecordFailure(ctx, id, lastError any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockAccountDeletionRepository)(nil).RecordFailure), ctx, id, lastError)
+}
+
+// MockUserAccountStatusRepository is a mock of UserAccountStatusRepository interface.
+type MockUserAccountStatusRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserAccountStatusRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockUserAccountStatusRepositoryMockRecorder is the mock recorder for MockUserAccountStatusRepository.
+type MockUserAccountStatusRepositoryMockRecorder struct {
+	mock *MockUserAccountStatusRepository
+}
+
+// NewMockUserAccountStatusRepository creates a new mock instance.
+func NewMockUserAccountStatusRepository(ctrl *gomock.Controller) *MockUserAccountStatusRepository {
+	mock := &MockUserAccountStatusRepository{ctrl: ctrl}
+	mock.recorder = &MockUserAccountStatusRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserAccountStatusRepository) EXPECT() *MockUserAccountStatusRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockUserAccountStatusRepository) Get(ctx context.Context, tenantID, globalUserID string) (*domain.UserAccountStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, tenantID, globalUserID)
+	ret0, _ := ret[0].(*domain.UserAccountStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockUserAccountStatusRepositoryMockRecorder) Get(ctx, tenantID, globalUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUserAccountStatusRepository)(nil).Get), ctx, tenantID, globalUserID)
+}
+
+// Upsert mocks base method.
+func (m *MockUserAccountStatusRepository) Upsert(ctx context.Context, status *domain.UserAccountStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockUserAccountStatusRepositoryMockRecorder) Upsert(ctx, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockUserAccountStatusRepository)(nil).Upsert), ctx, status)
+}
+
+// MockIdentifierLockoutRepository is a mock of IdentifierLockoutRepository interface.
+type MockIdentifierLockoutRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentifierLockoutRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIdentifierLockoutRepositoryMockRecorder is the mock recorder for MockIdentifierLockoutRepository.
+type MockIdentifierLockoutRepositoryMockRecorder struct {
+	mock *MockIdentifierLockoutRepository
+}
+
+// NewMockIdentifierLockoutRepository creates a new mock instance.
+func NewMockIdentifierLockoutRepository(ctrl *gomock.Controller) *MockIdentifierLockoutRepository {
+	mock := &MockIdentifierLockoutRepository{ctrl: ctrl}
+	mock.recorder = &MockIdentifierLockoutRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentifierLockoutRepository) EXPECT() *MockIdentifierLockoutRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockIdentifierLockoutRepository) Delete(ctx context.Context, tenantID, identifier string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, tenantID, identifier)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIdentifierLockoutRepositoryMockRecorder) Delete(ctx, tenantID, identifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIdentifierLockoutRepository)(nil).Delete), ctx, tenantID, identifier)
+}
+
+// Get mocks base method.
+func (m *MockIdentifierLockoutRepository) Get(ctx context.Context, tenantID, identifier string) (*domain.IdentifierLockout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, tenantID, identifier)
+	ret0, _ := ret[0].(*domain.IdentifierLockout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIdentifierLockoutRepositoryMockRecorder) Get(ctx, tenantID, identifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIdentifierLockoutRepository)(nil).Get), ctx, tenantID, identifier)
+}
+
+// ListLocked mocks base method.
+func (m *MockIdentifierLockoutRepository) ListLocked(ctx context.Context, tenantID string, now time.Time) ([]*domain.IdentifierLockout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLocked", ctx, tenantID, now)
+	ret0, _ := ret[0].([]*domain.IdentifierLockout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLocked indicates an expected call of ListLocked.
+func (mr *MockIdentifierLockoutRepositoryMockRecorder) ListLocked(ctx, tenantID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLocked", reflect.TypeOf((*MockIdentifierLockoutRepository)(nil).ListLocked), ctx, tenantID, now)
+}
+
+// Lock mocks base method.
+func (m *MockIdentifierLockoutRepository) Lock(ctx context.Context, id string, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", ctx, id, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockIdentifierLockoutRepositoryMockRecorder) Lock(ctx, id, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockIdentifierLockoutRepository)(nil).Lock), ctx, id, until)
+}
+
+// RecordFailure mocks base method.
+func (m *MockIdentifierLockoutRepository) RecordFailure(ctx context.Context, tenantID, identifier string, now, resetBefore time.Time) (*domain.IdentifierLockout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailure", ctx, tenantID, identifier, now, resetBefore)
+	ret0, _ := ret[0].(*domain.IdentifierLockout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockIdentifierLockoutRepositoryMockRecorder) RecordFailure(ctx, tenantID, identifier, now, resetBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockIdentifierLockoutRepository)(nil).RecordFailure), ctx, tenantID, identifier, now, resetBefore)
+}
+
+// MockTrustedDeviceRepository is a mock of TrustedDeviceRepository interface.
+type MockTrustedDeviceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrustedDeviceRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTrustedDeviceRepositoryMockRecorder is the mock recorder for MockTrustedDeviceRepository.
+type MockTrustedDeviceRepositoryMockRecorder struct {
+	mock *MockTrustedDeviceRepository
+}
+
+// NewMockTrustedDeviceRepository creates a new mock instance.
+func NewMockTrustedDeviceRepository(ctrl *gomock.Controller) *MockTrustedDeviceRepository {
+	mock := &MockTrustedDeviceRepository{ctrl: ctrl}
+	mock.recorder = &MockTrustedDeviceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrustedDeviceRepository) EXPECT() *MockTrustedDeviceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTrustedDeviceRepository) Create(ctx context.Context, device *domain.TrustedDevice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, device)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTrustedDeviceRepositoryMockRecorder) Create(ctx, device any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTrustedDeviceRepository)(nil).Create), ctx, device)
+}
+
+// GetByID mocks base method.
+func (m *MockTrustedDeviceRepository) GetByID(ctx context.Context, tenantID, id string) (*domain.TrustedDevice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, tenantID, id)
+	ret0, _ := ret[0].(*domain.TrustedDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockTrustedDeviceRepositoryMockRecorder) GetByID(ctx, tenantID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTrustedDeviceRepository)(nil).GetByID), ctx, tenantID, id)
+}
+
+// ListByGlobalUserID mocks base method.
+func (m *MockTrustedDeviceRepository) ListByGlobalUserID(ctx context.Context, tenantID, globalUserID string) ([]*domain.TrustedDevice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByGlobalUserID", ctx, tenantID, globalUserID)
+	ret0, _ := ret[0].([]*domain.TrustedDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByGlobalUserID indicates an expected call of ListByGlobalUserID.
+func (mr *MockTrustedDeviceRepositoryMockRecorder) ListByGlobalUserID(ctx, tenantID, globalUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByGlobalUserID", reflect.TypeOf((*MockTrustedDeviceRepository)(nil).ListByGlobalUserID), ctx, tenantID, globalUserID)
+}
+
+// ListTrusted mocks base method.
+func (m *MockTrustedDeviceRepository) ListTrusted(ctx context.Context, tenantID, globalUserID string, now time.Time) ([]*domain.TrustedDevice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrusted", ctx, tenantID, globalUserID, now)
+	ret0, _ := ret[0].([]*domain.TrustedDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrusted indicates an expected call of ListTrusted.
+func (mr *MockTrustedDeviceRepositoryMockRecorder) ListTrusted(ctx, tenantID, globalUserID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrusted", reflect.TypeOf((*MockTrustedDeviceRepository)(nil).ListTrusted), ctx, tenantID, globalUserID, now)
+}
+
+// Revoke mocks base method.
+func (m *MockTrustedDeviceRepository) Revoke(ctx context.Context, tenantID, globalUserID, id string, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, tenantID, globalUserID, id, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockTrustedDeviceRepositoryMockRecorder) Revoke(ctx, tenantID, globalUserID, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockTrustedDeviceRepository)(nil).Revoke), ctx, tenantID, globalUserID, id, at)
+}
+
+// RevokeAll mocks base method.
+func (m *MockTrustedDeviceRepository) RevokeAll(ctx context.Context, tenantID, globalUserID string, at time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, tenantID, globalUserID, at)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockTrustedDeviceRepositoryMockRecorder) RevokeAll(ctx, tenantID, globalUserID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockTrustedDeviceRepository)(nil).RevokeAll), ctx, tenantID, globalUserID, at)
+}
+
+// Touch mocks base method.
+func (m *MockTrustedDeviceRepository) Touch(ctx context.Context, id string, at time.Time, interval time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", ctx, id, at, interval)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockTrustedDeviceRepositoryMockRecorder) Touch(ctx, id, at, interval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockTrustedDeviceRepository)(nil).Touch), ctx, id, at, interval)
+}
+
+// MockLoginEventRepository is a mock of LoginEventRepository interface.
+type MockLoginEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginEventRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockLoginEventRepositoryMockRecorder is the mock recorder for MockLoginEventRepository.
+type MockLoginEventRepositoryMockRecorder struct {
+	mock *MockLoginEventRepository
+}
+
+// NewMockLoginEventRepository creates a new mock instance.
+func NewMockLoginEventRepository(ctrl *gomock.Controller) *MockLoginEventRepository {
+	mock := &MockLoginEventRepository{ctrl: ctrl}
+	mock.recorder = &MockLoginEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginEventRepository) EXPECT() *MockLoginEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockLoginEventRepository) Count(ctx context.Context, tenantID, globalUserID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, tenantID, globalUserID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockLoginEventRepositoryMockRecorder) Count(ctx, tenantID, globalUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockLoginEventRepository)(nil).Count), ctx, tenantID, globalUserID)
+}
+
+// Create mocks base method.
+func (m *MockLoginEventRepository) Create(ctx context.Context, event *domain.LoginEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLoginEventRepositoryMockRecorder) Create(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLoginEventRepository)(nil).Create), ctx, event)
+}
+
+// HasUserAgent mocks base method.
+func (m *MockLoginEventRepository) HasUserAgent(ctx context.Context, tenantID, globalUserID, userAgent string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasUserAgent", ctx, tenantID, globalUserID, userAgent)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasUserAgent indicates an expected call of HasUserAgent.
+func (mr *MockLoginEventRepositoryMockRecorder) HasUserAgent(ctx, tenantID, globalUserID, userAgent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasUserAgent", reflect.TypeOf((*MockLoginEventRepository)(nil).HasUserAgent), ctx, tenantID, globalUserID, userAgent)
+}
+
+// List mocks base method.
+func (m *MockLoginEventRepository) List(ctx context.Context, tenantID, globalUserID string, offset, limit int) ([]*domain.LoginEvent, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, tenantID, globalUserID, offset, limit)
+	ret0, _ := ret[0].([]*domain.LoginEvent)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockLoginEventRepositoryMockRecorder) List(ctx, tenantID, globalUserID, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockLoginEventRepository)(nil).List), ctx, tenantID, globalUserID, offset, limit)
+}
+
+// ListByGlobalUserID mocks base method.
+func (m *MockLoginEventRepository) ListByGlobalUserID(ctx context.Context, tenantID, globalUserID string) ([]*domain.LoginEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByGlobalUserID", ctx, tenantID, globalUserID)
+	ret0, _ := ret[0].([]*domain.LoginEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByGlobalUserID indicates an expected call of ListByGlobalUserID.
+func (mr *MockLoginEventRepositoryMockRecorder) ListByGlobalUserID(ctx, tenantID, globalUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByGlobalUserID", reflect.TypeOf((*MockLoginEventRepository)(nil).ListByGlobalUserID), ctx, tenantID, globalUserID)
+}
+
+// ListCountries mocks base method.
+func (m *MockLoginEventRepository) ListCountries(ctx context.Context, tenantID, globalUserID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCountries", ctx, tenantID, globalUserID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCountries indicates an expected call of ListCountries.
+func (mr *MockLoginEventRepositoryMockRecorder) ListCountries(ctx, tenantID, globalUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCountries", reflect.TypeOf((*MockLoginEventRepository)(nil).ListCountries), ctx, tenantID, globalUserID)
+}
+
+// MockUserImportRepository is a mock of UserImportRepository interface.
+type MockUserImportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserImportRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockUserImportRepositoryMockRecorder is the mock recorder for MockUserImportRepository.
+type MockUserImportRepositoryMockRecorder struct {
+	mock *MockUserImportRepository
+}
+
+// NewMockUserImportRepository creates a new mock instance.
+func NewMockUserImportRepository(ctrl *gomock.Controller) *MockUserImportRepository {
+	mock := &MockUserImportRepository{ctrl: ctrl}
+	mock.recorder = &MockUserImportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserImportRepository) EXPECT() *MockUserImportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockUserImportRepository) Claim(ctx context.Context, id string, now, retryAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, id, now, retryAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockUserImportRepositoryMockRecorder) Claim(ctx, id, now, retryAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockUserImportRepository)(nil).Claim), ctx, id, now, retryAt)
+}
+
+// Complete mocks base method.
+func (m *MockUserImportRepository) Complete(ctx context.Context, id string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockUserImportRepositoryMockRecorder) Complete(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockUserImportRepository)(nil).Complete), ctx, id, at)
+}
+
+// Create mocks base method.
+func (m *MockUserImportRepository) Create(ctx context.Context, userImport *domain.UserImport, rows []*domain.UserImportRow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userImport, rows)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserImportRepositoryMockRecorder) Create(ctx, userImport, rows any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserImportRepository)(nil).Create), ctx, userImport, rows)
+}
+
+// Fail mocks base method.
+func (m *MockUserImportRepository) Fail(ctx context.Context, id, lastError string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", ctx, id, lastError, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockUserImportRepositoryMockRecorder) Fail(ctx, id, lastError, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockUserImportRepository)(nil).Fail), ctx, id, lastError, at)
+}
+
+// GetByID mocks base method.
+func (m *MockUserImportRepository) GetByID(ctx context.Context, tenantID, id string) (*domain.UserImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, tenantID, id)
+	ret0, _ := ret[0].(*domain.UserImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockUserImportRepositoryMockRecorder) GetByID(ctx, tenantID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserImportRepository)(nil).GetByID), ctx, tenantID, id)
+}
+
+// ListByTenant mocks base method.
+func (m *MockUserImportRepository) ListByTenant(ctx context.Context, tenantID string) ([]*domain.UserImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTenant", ctx, tenantID)
+	ret0, _ := ret[0].([]*domain.UserImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTenant indicates an expected call of ListByTenant.
+func (mr *MockUserImportRepositoryMockRecorder) ListByTenant(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTenant", reflect.TypeOf((*MockUserImportRepository)(nil).ListByTenant), ctx, tenantID)
+}
+
+// ListDue mocks base method.
+func (m *MockUserImportRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.UserImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDue", ctx, now, limit)
+	ret0, _ := ret[0].([]*domain.UserImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDue indicates an expected call of ListDue.
+func (mr *MockUserImportRepositoryMockRecorder) ListDue(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDue", reflect.TypeOf((*MockUserImportRepository)(nil).ListDue), ctx, now, limit)
+}
+
+// ListFailedRows mocks base method.
+func (m *MockUserImportRepository) ListFailedRows(ctx context.Context, importID string, afterLine, limit int) ([]*domain.UserImportRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFailedRows", ctx, importID, afterLine, limit)
+	ret0, _ := ret[0].([]*domain.UserImportRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFailedRows indicates an expected call of ListFailedRows.
+func (mr *MockUserImportRepositoryMockRecorder) ListFailedRows(ctx, importID, afterLine, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFailedRows", reflect.TypeOf((*MockUserImportRepository)(nil).ListFailedRows), ctx, importID, afterLine, limit)
+}
+
+// ListPendingRows mocks base method.
+func (m *MockUserImportRepository) ListPendingRows(ctx context.Context, importID string, limit int) ([]*domain.UserImportRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingRows", ctx, importID, limit)
+	ret0, _ := ret[0].([]*domain.UserImportRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingRows indicates an expected call of ListPendingRows.
+func (mr *MockUserImportRepositoryMockRecorder) ListPendingRows(ctx, importID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingRows", reflect.TypeOf((*MockUserImportRepository)(nil).ListPendingRows), ctx, importID, limit)
+}
+
+// RecordFailure mocks base method.
+func (m *MockUserImportRepository) RecordFailure(ctx context.Context, id, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailure", ctx, id, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockUserImportRepositoryMockRecorder) RecordFailure(ctx, id, lastError any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockUserImportRepository)(nil).RecordFailure), ctx, id, lastError)
+}
+
+// Reschedule mocks base method.
+func (m *MockUserImportRepository) Reschedule(ctx context.Context, id string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reschedule", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reschedule indicates an expected call of Reschedule.
+func (mr *MockUserImportRepositoryMockRecorder) Reschedule(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reschedule", reflect.TypeOf((*MockUserImportRepository)(nil).Reschedule), ctx, id, at)
+}
+
+// SaveRows mocks base method.
+func (m *MockUserImportRepository) SaveRows(ctx context.Context, importID string, rows []*domain.UserImportRow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRows", ctx, importID, rows)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRows indicates an expected call of SaveRows.
+func (mr *MockUserImportRepositoryMockRecorder) SaveRows(ctx, importID, rows any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRows", reflect.TypeOf((*MockUserImportRepository)(nil).SaveRows), ctx, importID, rows)
+}
+
+// MockUserInvitationRepository is a mock of UserInvitationRepository interface.
+type MockUserInvitationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserInvitationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockUserInvitationRepositoryMockRecorder is the mock recorder for MockUserInvitationRepository.
+type MockUserInvitationRepositoryMockRecorder struct {
+	mock *MockUserInvitationRepository
+}
+
+// NewMockUserInvitationRepository creates a new mock instance.
+func NewMockUserInvitationRepository(ctrl *gomock.Controller) *MockUserInvitationRepository {
+	mock := &MockUserInvitationRepository{ctrl: ctrl}
+	mock.recorder = &MockUserInvitationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserInvitationRepository) EXPECT() *MockUserInvitationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockUserInvitationRepository) Create(ctx context.Context, invitation *domain.UserInvitation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, invitation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserInvitationRepositoryMockRecorder) Create(ctx, invitation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserInvitationRepository)(nil).Create), ctx, invitation)
+}
+
+// GetByID mocks base method.
+func (m *MockUserInvitationRepository) GetByID(ctx context.Context, tenantID, id string) (*domain.UserInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, tenantID, id)
+	ret0, _ := ret[0].(*domain.UserInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockUserInvitationRepositoryMockRecorder) GetByID(ctx, tenantID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserInvitationRepository)(nil).GetByID), ctx, tenantID, id)
+}
+
+// GetOpen mocks base method.
+func (m *MockUserInvitationRepository) GetOpen(ctx context.Context, tenantID, identifierType, identifier string) (*domain.UserInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpen", ctx, tenantID, identifierType, identifier)
+	ret0, _ := ret[0].(*domain.UserInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpen indicates an expected call of GetOpen.
+func (mr *MockUserInvitationRepositoryMockRecorder) GetOpen(ctx, tenantID, identifierType, identifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpen", reflect.TypeOf((*MockUserInvitationRepository)(nil).GetOpen), ctx, tenantID, identifierType, identifier)
+}
+
+// ListByTenant mocks base method.
+func (m *MockUserInvitationRepository) ListByTenant(ctx context.Context, tenantID string) ([]*domain.UserInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTenant", ctx, tenantID)
+	ret0, _ := ret[0].([]*domain.UserInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTenant indicates an expected call of ListByTenant.
+func (mr *MockUserInvitationRepositoryMockRecorder) ListByTenant(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTenant", reflect.TypeOf((*MockUserInvitationRepository)(nil).ListByTenant), ctx, tenantID)
+}
+
+// MarkAccepted mocks base method.
+func (m *MockUserInvitationRepository) MarkAccepted(ctx context.Context, tx *gorm.DB, id, globalUserID string, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAccepted", ctx, tx, id, globalUserID, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAccepted indicates an expected call of MarkAccepted.
+func (mr *MockUserInvitationRepositoryMockRecorder) MarkAccepted(ctx, tx, id, globalUserID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAccepted", reflect.TypeOf((*MockUserInvitationRepository)(nil).MarkAccepted), ctx, tx, id, globalUserID, at)
+}
+
+// Renew mocks base method.
+func (m *MockUserInvitationRepository) Renew(ctx context.Context, id, flowID string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Renew", ctx, id, flowID, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Renew indicates an expected call of Renew.
+func (mr *MockUserInvitationRepositoryMockRecorder) Renew(ctx, id, flowID, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Renew", reflect.TypeOf((*MockUserInvitationRepository)(nil).Renew), ctx, id, flowID, expiresAt)
+}
+
+// Revoke mocks base method.
+func (m *MockUserInvitationRepository) Revoke(ctx context.Context, id string, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockUserInvitationRepositoryMockRecorder) Revoke(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockUserInvitationRepository)(nil).Revoke), ctx, id, at)
+}
+
+// MockUserDataExportRepository is a mock of UserDataExportRepository interface.
+type MockUserDataExportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserDataExportRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockUserDataExportRepositoryMockRecorder is the mock recorder for MockUserDataExportRepository.
+type MockUserDataExportRepositoryMockRecorder struct {
+	mock *MockUserDataExportRepository
+}
+
+// NewMockUserDataExportRepository creates a new mock instance.
+func NewMockUserDataExportRepository(ctrl *gomock.Controller) *MockUserDataExportRepository {
+	mock := &MockUserDataExportRepository{ctrl: ctrl}
+	mock.recorder = &MockUserDataExportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserDataExportRepository) EXPECT() *MockUserDataExportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockUserDataExportRepository) Claim(ctx context.Context, id string, now, retryAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, id, now, retryAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockUserDataExportRepositoryMockRecorder) Claim(ctx, id, now, retryAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockUserDataExportRepository)(nil).Claim), ctx, id, now, retryAt)
+}
+
+// Complete mocks base method.
+func (m *MockUserDataExportRepository) Complete(ctx context.Context, id, payload string, at, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, id, payload, at, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockUserDataExportRepositoryMockRecorder) Complete(ctx, id, payload, at, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockUserDataExportRepository)(nil).Complete), ctx, id, payload, at, expiresAt)
+}
+
+// Create mocks base method.
+func (m *MockUserDataExportRepository) Create(ctx context.Context, export *domain.UserDataExport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, export)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserDataExportRepositoryMockRecorder) Create(ctx, export any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserDataExportRepository)(nil).Create), ctx, export)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockUserDataExportRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockUserDataExportRepositoryMockRecorder) DeleteExpired(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockUserDataExportRepository)(nil).DeleteExpired), ctx, now)
+}
+
+// Fail mocks base method.
+func (m *MockUserDataExportRepository) Fail(ctx context.Context, id, lastError string, at, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", ctx, id, lastError, at, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockUserDataExportRepositoryMockRecorder) Fail(ctx, id, lastError, at, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockUserDataExportRepository)(nil).Fail), ctx, id, lastError, at, expiresAt)
+}
+
+// GetByID mocks base method.
+func (m *MockUserDataExportRepository) GetByID(ctx context.Context, id string) (*domain.UserDataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.UserDataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockUserDataExportRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserDataExportRepository)(nil).GetByID), ctx, id)
+}
+
+// GetPending mocks base method.
+func (m *MockUserDataExportRepository) GetPending(ctx context.Context, tenantID, globalUserID string) (*domain.UserDataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPending", ctx, tenantID, globalUserID)
+	ret0, _ := ret[0].(*domain.UserDataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPending indicates an expected call of GetPending.
+func (mr *MockUserDataExportRepositoryMockRecorder) GetPending(ctx, tenantID, globalUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPending", reflect.TypeOf((*MockUserDataExportRepository)(nil).GetPending), ctx, tenantID, globalUserID)
+}
+
+// ListDue mocks base method.
+func (m *MockUserDataExportRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.UserDataExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDue", ctx, now, limit)
+	ret0, _ := ret[0].([]*domain.UserDataExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDue indicates an expected call of ListDue.
+func (mr *MockUserDataExportRepositoryMockRecorder) ListDue(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDue", reflect.TypeOf((*MockUserDataExportRepository)(nil).ListDue), ctx, now, limit)
+}
+
+// RecordFailure mocks base method.
+func (m *MockUserDataExportRepository) RecordFailure(ctx context.Context, id, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailure", ctx, id, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockUserDataExportRepositoryMockRecorder) RecordFailure(ctx, id, lastError any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockUserDataExportRepository)(nil).RecordFailure), ctx, id, lastError)
+}
+
+// MockZaloTokenRepository is a mock of ZaloTokenRepository interface.
+type MockZaloTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockZaloTokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockZaloTokenRepositoryMockRecorder is the mock recorder for MockZaloTokenRepository.
+type MockZaloTokenRepositoryMockRecorder struct {
+	mock *MockZaloTokenRepository
+}
+
+// NewMockZaloTokenRepository creates a new mock instance.
+func NewMockZaloTokenRepository(ctrl *gomock.Controller) *MockZaloTokenRepository {
+	mock := &MockZaloTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockZaloTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockZaloTokenRepository) EXPECT() *MockZaloTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockZaloTokenRepository) Delete(ctx context.Context, tenantID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockZaloTokenRepositoryMockRecorder) Delete(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockZaloTokenRepository)(nil).Delete), ctx, tenantID)
+}
+
+// Get mocks base method.
+func (m *MockZaloTokenRepository) Get(ctx context.Context, tenantID uuid.UUID) (*domain.ZaloToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, tenantID)
+	ret0, _ := ret[0].(*domain.ZaloToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockZaloTokenRepositoryMockRecorder) Get(ctx, tenantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockZaloTokenRepository)(nil).Get), ctx, tenantID)
+}
+
+// GetAll mocks base method.
+func (m *MockZaloTokenRepository) GetAll(ctx context.Context) ([]*domain.ZaloToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]*domain.ZaloToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockZaloTokenRepositoryMockRecorder) GetAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockZaloTokenRepository)(nil).GetAll), ctx)
+}
+
+// Save mocks base method.
+func (m *MockZaloTokenRepository) Save(ctx context.Context, token *domain.ZaloToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockZaloTokenRepositoryMockRecorder) Save(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockZaloTokenRepository)(nil).Save), ctx, token)
+}
+
+// MockSecurityNotificationRepository is a mock of SecurityNotificationRepository interface.
+type MockSecurityNotificationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecurityNotificationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSecurityNotificationRepositoryMockRecorder is the mock recorder for MockSecurityNotificationRepository.
+type MockSecurityNotificationRepositoryMockRecorder struct {
+	mock *MockSecurityNotificationRepository
+}
+
+// NewMockSecurityNotificationRepository creates a new mock instance.
+func NewMockSecurityNotificationRepository(ctrl *gomock.Controller) *MockSecurityNotificationRepository {
+	mock := &MockSecurityNotificationRepository{ctrl: ctrl}
+	mock.recorder = &MockSecurityNotificationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecurityNotificationRepository) EXPECT() *MockSecurityNotificationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSecurityNotificationRepository) Create(ctx context.Context, notification *domain.SecurityNotification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSecurityNotificationRepositoryMockRecorder) Create(ctx, notification any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSecurityNotificationRepository)(nil).Create), ctx, notification)
+}
+
+// GetByReportTokenHash mocks base method.
+func (m *MockSecurityNotificationRepository) GetByReportTokenHash(ctx context.Context, tokenHash string) (*domain.SecurityNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByReportTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*domain.SecurityNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByReportTokenHash indicates an expected call of GetByReportTokenHash.
+func (mr *MockSecurityNotificationRepositoryMockRecorder) GetByReportTokenHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByReportTokenHash", reflect.TypeOf((*MockSecurityNotificationRepository)(nil).GetByReportTokenHash), ctx, tokenHash)
+}
+
+// MarkReported mocks base method.
+func (m *MockSecurityNotificationRepository) MarkReported(ctx context.Context, id string, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkReported", ctx, id, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkReported indicates an expected call of MarkReported.
+func (mr *MockSecurityNotificationRepositoryMockRecorder) MarkReported(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkReported", reflect.TypeOf((*MockSecurityNotificationRepository)(nil).MarkReported), ctx, id, at)
+}
+
+// MockIdentifierQuarantineRepository is a mock of IdentifierQuarantineRepository interface.
+type MockIdentifierQuarantineRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentifierQuarantineRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIdentifierQuarantineRepositoryMockRecorder is the mock recorder for MockIdentifierQuarantineRepository.
+type MockIdentifierQuarantineRepositoryMockRecorder struct {
+	mock *MockIdentifierQuarantineRepository
+}
+
+// NewMockIdentifierQuarantineRepository creates a new mock instance.
+func NewMockIdentifierQuarantineRepository(ctrl *gomock.Controller) *MockIdentifierQuarantineRepository {
+	mock := &MockIdentifierQuarantineRepository{ctrl: ctrl}
+	mock.recorder = &MockIdentifierQuarantineRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentifierQuarantineRepository) EXPECT() *MockIdentifierQuarantineRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIdentifierQuarantineRepository) Create(ctx context.Context, quarantine *domain.IdentifierQuarantine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, quarantine)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIdentifierQuarantineRepositoryMockRecorder) Create(ctx, quarantine any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIdentifierQuarantineRepository)(nil).Create), ctx, quarantine)
+}
+
+// ForgetGlobalUser mocks base method.
+func (m *MockIdentifierQuarantineRepository) ForgetGlobalUser(ctx context.Context, tx *gorm.DB, globalUserID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgetGlobalUser", ctx, tx, globalUserID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgetGlobalUser indicates an expected call of ForgetGlobalUser.
+func (mr *MockIdentifierQuarantineRepositoryMockRecorder) ForgetGlobalUser(ctx, tx, globalUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgetGlobalUser", reflect.TypeOf((*MockIdentifierQuarantineRepository)(nil).ForgetGlobalUser), ctx, tx, globalUserID)
+}
+
+// GetActive mocks base method.
+func (m *MockIdentifierQuarantineRepository) GetActive(ctx context.Context, tenantID, identifierHash, exceptGlobalUserID string, now time.Time) (*domain.IdentifierQuarantine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActive", ctx, tenantID, identifierHash, exceptGlobalUserID, now)
+	ret0, _ := ret[0].(*domain.IdentifierQuarantine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActive indicates an expected call of GetActive.
+func (mr *MockIdentifierQuarantineRepositoryMockRecorder) GetActive(ctx, tenantID, identifierHash, exceptGlobalUserID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActive", reflect.TypeOf((*MockIdentifierQuarantineRepository)(nil).GetActive), ctx, tenantID, identifierHash, exceptGlobalUserID, now)
+}
+
+// List mocks base method.
+func (m *MockIdentifierQuarantineRepository) List(ctx context.Context, tenantID, identifierHash string, now time.Time, offset, limit int) ([]*domain.IdentifierQuarantine, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, tenantID, identifierHash, now, offset, limit)
+	ret0, _ := ret[0].([]*domain.IdentifierQuarantine)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockIdentifierQuarantineRepositoryMockRecorder) List(ctx, tenantID, identifierHash, now, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockIdentifierQuarantineRepository)(nil).List), ctx, tenantID, identifierHash, now, offset, limit)
+}
+
+// Release mocks base method.
+func (m *MockIdentifierQuarantineRepository) Release(ctx context.Context, tenantID, id, releasedBy string, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, tenantID, id, releasedBy, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockIdentifierQuarantineRepositoryMockRecorder) Release(ctx, tenantID, id, releasedBy, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockIdentifierQuarantineRepository)(nil).Release), ctx, tenantID, id, releasedBy, at)
+}
+
+// RequestApproval mocks base method.
+func (m *MockIdentifierQuarantineRepository) RequestApproval(ctx context.Context, id string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestApproval", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestApproval indicates an expected call of RequestApproval.
+func (mr *MockIdentifierQuarantineRepositoryMockRecorder) RequestApproval(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestApproval", reflect.TypeOf((*MockIdentifierQuarantineRepository)(nil).RequestApproval), ctx, id, at)
+}
@@ -11,6 +11,7 @@ package mock_services
 
 import (
 	context "context"
+	json "encoding/json"
 	reflect "reflect"
 	time "time"
 
@@ -76,6 +77,35 @@ func (mr *MockKratosServiceMockRecorder) DeleteIdentifierAdmin(ctx, tenantID, id
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIdentifierAdmin", reflect.TypeOf((*MockKratosService)(nil).DeleteIdentifierAdmin), ctx, tenantID, identityID)
 }
 
+// DisableSessionAdmin mocks base method.
+func (m *MockKratosService) DisableSessionAdmin(ctx context.Context, tenantID uuid.UUID, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableSessionAdmin", ctx, tenantID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableSessionAdmin indicates an expected call of DisableSessionAdmin.
+func (mr *MockKratosServiceMockRecorder) DisableSessionAdmin(ctx, tenantID, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableSessionAdmin", reflect.TypeOf((*MockKratosService)(nil).DisableSessionAdmin), ctx, tenantID, sessionID)
+}
+
+// ExtendSession mocks base method.
+func (m *MockKratosService) ExtendSession(ctx context.Context, tenantID uuid.UUID, sessionID string) (*client.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtendSession", ctx, tenantID, sessionID)
+	ret0, _ := ret[0].(*client.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExtendSession indicates an expected call of ExtendSession.
+func (mr *MockKratosServiceMockRecorder) ExtendSession(ctx, tenantID, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtendSession", reflect.TypeOf((*MockKratosService)(nil).ExtendSession), ctx, tenantID, sessionID)
+}
+
 // GetIdentity mocks base method.
 func (m *MockKratosService) GetIdentity(ctx context.Context, tenantID, identityID uuid.UUID) (*client.Identity, error) {
 	m.ctrl.T.Helper()
@@ -91,6 +121,21 @@ func (mr *MockKratosServiceMockRecorder) GetIdentity(ctx, tenantID, identityID a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentity", reflect.TypeOf((*MockKratosService)(nil).GetIdentity), ctx, tenantID, identityID)
 }
 
+// GetIdentityByIdentifierAdmin mocks base method.
+func (m *MockKratosService) GetIdentityByIdentifierAdmin(ctx context.Context, tenantID uuid.UUID, identifier string) (*client.Identity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentityByIdentifierAdmin", ctx, tenantID, identifier)
+	ret0, _ := ret[0].(*client.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdentityByIdentifierAdmin indicates an expected call of GetIdentityByIdentifierAdmin.
+func (mr *MockKratosServiceMockRecorder) GetIdentityByIdentifierAdmin(ctx, tenantID, identifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentityByIdentifierAdmin", reflect.TypeOf((*MockKratosService)(nil).GetIdentityByIdentifierAdmin), ctx, tenantID, identifier)
+}
+
 // GetLoginFlow mocks base method.
 func (m *MockKratosService) GetLoginFlow(ctx context.Context, tenantID uuid.UUID, flowID string) (*client.LoginFlow, error) {
 	m.ctrl.T.Helper()
@@ -240,6 +285,34 @@ func (mr *MockKratosServiceMockRecorder) Logout(ctx, tenantID, sessionToken any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockKratosService)(nil).Logout), ctx, tenantID, sessionToken)
 }
 
+// ResendLoginCode mocks base method.
+func (m *MockKratosService) ResendLoginCode(ctx context.Context, tenantID uuid.UUID, flowID, identifier string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendLoginCode", ctx, tenantID, flowID, identifier)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendLoginCode indicates an expected call of ResendLoginCode.
+func (mr *MockKratosServiceMockRecorder) ResendLoginCode(ctx, tenantID, flowID, identifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendLoginCode", reflect.TypeOf((*MockKratosService)(nil).ResendLoginCode), ctx, tenantID, flowID, identifier)
+}
+
+// ResendRegistrationCode mocks base method.
+func (m *MockKratosService) ResendRegistrationCode(ctx context.Context, tenantID uuid.UUID, flowID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendRegistrationCode", ctx, tenantID, flowID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendRegistrationCode indicates an expected call of ResendRegistrationCode.
+func (mr *MockKratosServiceMockRecorder) ResendRegistrationCode(ctx, tenantID, flowID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendRegistrationCode", reflect.TypeOf((*MockKratosService)(nil).ResendRegistrationCode), ctx, tenantID, flowID)
+}
+
 // RevokeSession mocks base method.
 func (m *MockKratosService) RevokeSession(ctx context.Context, tenantID uuid.UUID, sessionToken string) error {
 	m.ctrl.T.Helper()
@@ -269,6 +342,21 @@ func (mr *MockKratosServiceMockRecorder) SubmitLoginFlow(ctx, tenantID, flow, me
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitLoginFlow", reflect.TypeOf((*MockKratosService)(nil).SubmitLoginFlow), ctx, tenantID, flow, method, identifier, password, code)
 }
 
+// SubmitLoginFlowWithIDToken mocks base method.
+func (m *MockKratosService) SubmitLoginFlowWithIDToken(ctx context.Context, tenantID uuid.UUID, flow *client.LoginFlow, provider, idToken, nonce string) (*client.SuccessfulNativeLogin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitLoginFlowWithIDToken", ctx, tenantID, flow, provider, idToken, nonce)
+	ret0, _ := ret[0].(*client.SuccessfulNativeLogin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitLoginFlowWithIDToken indicates an expected call of SubmitLoginFlowWithIDToken.
+func (mr *MockKratosServiceMockRecorder) SubmitLoginFlowWithIDToken(ctx, tenantID, flow, provider, idToken, nonce any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitLoginFlowWithIDToken", reflect.TypeOf((*MockKratosService)(nil).SubmitLoginFlowWithIDToken), ctx, tenantID, flow, provider, idToken, nonce)
+}
+
 // SubmitRegistrationFlow mocks base method.
 func (m *MockKratosService) SubmitRegistrationFlow(ctx context.Context, tenantID uuid.UUID, flow *client.RegistrationFlow, method string, traits map[string]any) (*client.SuccessfulNativeRegistration, error) {
 	m.ctrl.T.Helper()
@@ -299,6 +387,21 @@ func (mr *MockKratosServiceMockRecorder) SubmitRegistrationFlowWithCode(ctx, ten
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitRegistrationFlowWithCode", reflect.TypeOf((*MockKratosService)(nil).SubmitRegistrationFlowWithCode), ctx, tenantID, flow, code)
 }
 
+// SubmitRegistrationFlowWithIDToken mocks base method.
+func (m *MockKratosService) SubmitRegistrationFlowWithIDToken(ctx context.Context, tenantID uuid.UUID, flow *client.RegistrationFlow, provider, idToken, nonce string, traits map[string]any) (*client.SuccessfulNativeRegistration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitRegistrationFlowWithIDToken", ctx, tenantID, flow, provider, idToken, nonce, traits)
+	ret0, _ := ret[0].(*client.SuccessfulNativeRegistration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitRegistrationFlowWithIDToken indicates an expected call of SubmitRegistrationFlowWithIDToken.
+func (mr *MockKratosServiceMockRecorder) SubmitRegistrationFlowWithIDToken(ctx, tenantID, flow, provider, idToken, nonce, traits any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitRegistrationFlowWithIDToken", reflect.TypeOf((*MockKratosService)(nil).SubmitRegistrationFlowWithIDToken), ctx, tenantID, flow, provider, idToken, nonce, traits)
+}
+
 // SubmitSettingsFlow mocks base method.
 func (m *MockKratosService) SubmitSettingsFlow(ctx context.Context, tenantID uuid.UUID, flow *client.SettingsFlow, sessionToken, method string, traits map[string]any) (*client.SettingsFlow, error) {
 	m.ctrl.T.Helper()
@@ -425,6 +528,35 @@ func (mr *MockKetoServiceMockRecorder) CreateRelationTuple(ctx, request any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRelationTuple", reflect.TypeOf((*MockKetoService)(nil).CreateRelationTuple), ctx, request)
 }
 
+// DeleteRelationTuplesBySubject mocks base method.
+func (m *MockKetoService) DeleteRelationTuplesBySubject(ctx context.Context, globalUserID string) *errors.DomainError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRelationTuplesBySubject", ctx, globalUserID)
+	ret0, _ := ret[0].(*errors.DomainError)
+	return ret0
+}
+
+// DeleteRelationTuplesBySubject indicates an expected call of DeleteRelationTuplesBySubject.
+func (mr *MockKetoServiceMockRecorder) DeleteRelationTuplesBySubject(ctx, globalUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRelationTuplesBySubject", reflect.TypeOf((*MockKetoService)(nil).DeleteRelationTuplesBySubject), ctx, globalUserID)
+}
+
+// ListRelationTuplesBySubject mocks base method.
+func (m *MockKetoService) ListRelationTuplesBySubject(ctx context.Context, globalUserID string) ([]types.RelationTuple, *errors.DomainError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRelationTuplesBySubject", ctx, globalUserID)
+	ret0, _ := ret[0].([]types.RelationTuple)
+	ret1, _ := ret[1].(*errors.DomainError)
+	return ret0, ret1
+}
+
+// ListRelationTuplesBySubject indicates an expected call of ListRelationTuplesBySubject.
+func (mr *MockKetoServiceMockRecorder) ListRelationTuplesBySubject(ctx, globalUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRelationTuplesBySubject", reflect.TypeOf((*MockKetoService)(nil).ListRelationTuplesBySubject), ctx, globalUserID)
+}
+
 // MockSMSProvider is a mock of SMSProvider interface.
 type MockSMSProvider struct {
 	ctrl     *gomock.Controller
@@ -449,6 +581,20 @@ func (m *MockSMSProvider) EXPECT() *MockSMSProviderMockRecorder {
 	return m.recorder
 }
 
+// SendMessage mocks base method.
+func (m *MockSMSProvider) SendMessage(ctx context.Context, tenantName, receiver, channel, message string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMessage", ctx, tenantName, receiver, channel, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMessage indicates an expected call of SendMessage.
+func (mr *MockSMSProviderMockRecorder) SendMessage(ctx, tenantName, receiver, channel, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockSMSProvider)(nil).SendMessage), ctx, tenantName, receiver, channel, message)
+}
+
 // SendOTP mocks base method.
 func (m *MockSMSProvider) SendOTP(ctx context.Context, tenantName, receiver, channel, message string, ttl time.Duration) error {
 	m.ctrl.T.Helper()
@@ -462,3 +608,324 @@ func (mr *MockSMSProviderMockRecorder) SendOTP(ctx, tenantName, receiver, channe
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendOTP", reflect.TypeOf((*MockSMSProvider)(nil).SendOTP), ctx, tenantName, receiver, channel, message, ttl)
 }
+
+// MockEmailSender is a mock of EmailSender interface.
+type MockEmailSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockEmailSenderMockRecorder is the mock recorder for MockEmailSender.
+type MockEmailSenderMockRecorder struct {
+	mock *MockEmailSender
+}
+
+// NewMockEmailSender creates a new mock instance.
+func NewMockEmailSender(ctrl *gomock.Controller) *MockEmailSender {
+	mock := &MockEmailSender{ctrl: ctrl}
+	mock.recorder = &MockEmailSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailSender) EXPECT() *MockEmailSenderMockRecorder {
+	return m.recorder
+}
+
+// SendEmail mocks base method.
+func (m *MockEmailSender) SendEmail(ctx context.Context, tenantName, to, subject, body string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEmail", ctx, tenantName, to, subject, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEmail indicates an expected call of SendEmail.
+func (mr *MockEmailSenderMockRecorder) SendEmail(ctx, tenantName, to, subject, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmail", reflect.TypeOf((*MockEmailSender)(nil).SendEmail), ctx, tenantName, to, subject, body)
+}
+
+// MockBreachedPasswordChecker is a mock of BreachedPasswordChecker interface.
+type MockBreachedPasswordChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockBreachedPasswordCheckerMockRecorder
+	isgomock struct{}
+}
+
+// MockBreachedPasswordCheckerMockRecorder is the mock recorder for MockBreachedPasswordChecker.
+type MockBreachedPasswordCheckerMockRecorder struct {
+	mock *MockBreachedPasswordChecker
+}
+
+// NewMockBreachedPasswordChecker creates a new mock instance.
+func NewMockBreachedPasswordChecker(ctrl *gomock.Controller) *MockBreachedPasswordChecker {
+	mock := &MockBreachedPasswordChecker{ctrl: ctrl}
+	mock.recorder = &MockBreachedPasswordCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBreachedPasswordChecker) EXPECT() *MockBreachedPasswordCheckerMockRecorder {
+	return m.recorder
+}
+
+// IsBreached mocks base method.
+func (m *MockBreachedPasswordChecker) IsBreached(password string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBreached", password)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsBreached indicates an expected call of IsBreached.
+func (mr *MockBreachedPasswordCheckerMockRecorder) IsBreached(password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBreached", reflect.TypeOf((*MockBreachedPasswordChecker)(nil).IsBreached), password)
+}
+
+// MockGeoIPLocator is a mock of GeoIPLocator interface.
+type MockGeoIPLocator struct {
+	ctrl     *gomock.Controller
+	recorder *MockGeoIPLocatorMockRecorder
+	isgomock struct{}
+}
+
+// MockGeoIPLocatorMockRecorder is the mock recorder for MockGeoIPLocator.
+type MockGeoIPLocatorMockRecorder struct {
+	mock *MockGeoIPLocator
+}
+
+// NewMockGeoIPLocator creates a new mock instance.
+func NewMockGeoIPLocator(ctrl *gomock.Controller) *MockGeoIPLocator {
+	mock := &MockGeoIPLocator{ctrl: ctrl}
+	mock.recorder = &MockGeoIPLocatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGeoIPLocator) EXPECT() *MockGeoIPLocatorMockRecorder {
+	return m.recorder
+}
+
+// Country mocks base method.
+func (m *MockGeoIPLocator) Country(ip string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Country", ip)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Country indicates an expected call of Country.
+func (mr *MockGeoIPLocatorMockRecorder) Country(ip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Country", reflect.TypeOf((*MockGeoIPLocator)(nil).Country), ip)
+}
+
+// MockOIDCTokenVerifier is a mock of OIDCTokenVerifier interface.
+type MockOIDCTokenVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockOIDCTokenVerifierMockRecorder
+	isgomock struct{}
+}
+
+// MockOIDCTokenVerifierMockRecorder is the mock recorder for MockOIDCTokenVerifier.
+type MockOIDCTokenVerifierMockRecorder struct {
+	mock *MockOIDCTokenVerifier
+}
+
+// NewMockOIDCTokenVerifier creates a new mock instance.
+func NewMockOIDCTokenVerifier(ctrl *gomock.Controller) *MockOIDCTokenVerifier {
+	mock := &MockOIDCTokenVerifier{ctrl: ctrl}
+	mock.recorder = &MockOIDCTokenVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOIDCTokenVerifier) EXPECT() *MockOIDCTokenVerifierMockRecorder {
+	return m.recorder
+}
+
+// Verify mocks base method.
+func (m *MockOIDCTokenVerifier) Verify(ctx context.Context, provider, rawIDToken, nonce string) (*types.OIDCClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, provider, rawIDToken, nonce)
+	ret0, _ := ret[0].(*types.OIDCClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockOIDCTokenVerifierMockRecorder) Verify(ctx, provider, rawIDToken, nonce any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockOIDCTokenVerifier)(nil).Verify), ctx, provider, rawIDToken, nonce)
+}
+
+// MockPasskeyService is a mock of PasskeyService interface.
+type MockPasskeyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasskeyServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPasskeyServiceMockRecorder is the mock recorder for MockPasskeyService.
+type MockPasskeyServiceMockRecorder struct {
+	mock *MockPasskeyService
+}
+
+// NewMockPasskeyService creates a new mock instance.
+func NewMockPasskeyService(ctrl *gomock.Controller) *MockPasskeyService {
+	mock := &MockPasskeyService{ctrl: ctrl}
+	mock.recorder = &MockPasskeyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasskeyService) EXPECT() *MockPasskeyServiceMockRecorder {
+	return m.recorder
+}
+
+// BeginLogin mocks base method.
+func (m *MockPasskeyService) BeginLogin(rp types.PasskeyRelyingParty) (*types.PasskeyCeremony, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginLogin", rp)
+	ret0, _ := ret[0].(*types.PasskeyCeremony)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginLogin indicates an expected call of BeginLogin.
+func (mr *MockPasskeyServiceMockRecorder) BeginLogin(rp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginLogin", reflect.TypeOf((*MockPasskeyService)(nil).BeginLogin), rp)
+}
+
+// BeginRegistration mocks base method.
+func (m *MockPasskeyService) BeginRegistration(rp types.PasskeyRelyingParty, user types.PasskeyUser, existing []*types.PasskeyCredential) (*types.PasskeyCeremony, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginRegistration", rp, user, existing)
+	ret0, _ := ret[0].(*types.PasskeyCeremony)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginRegistration indicates an expected call of BeginRegistration.
+func (mr *MockPasskeyServiceMockRecorder) BeginRegistration(rp, user, existing any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginRegistration", reflect.TypeOf((*MockPasskeyService)(nil).BeginRegistration), rp, user, existing)
+}
+
+// FinishLogin mocks base method.
+func (m *MockPasskeyService) FinishLogin(rp types.PasskeyRelyingParty, state string, response []byte, lookup func([]byte, []byte) (*types.PasskeyCredential, error)) (*types.PasskeyCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishLogin", rp, state, response, lookup)
+	ret0, _ := ret[0].(*types.PasskeyCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishLogin indicates an expected call of FinishLogin.
+func (mr *MockPasskeyServiceMockRecorder) FinishLogin(rp, state, response, lookup any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishLogin", reflect.TypeOf((*MockPasskeyService)(nil).FinishLogin), rp, state, response, lookup)
+}
+
+// FinishRegistration mocks base method.
+func (m *MockPasskeyService) FinishRegistration(rp types.PasskeyRelyingParty, user types.PasskeyUser, state string, response []byte) (*types.PasskeyCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishRegistration", rp, user, state, response)
+	ret0, _ := ret[0].(*types.PasskeyCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishRegistration indicates an expected call of FinishRegistration.
+func (mr *MockPasskeyServiceMockRecorder) FinishRegistration(rp, user, state, response any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishRegistration", reflect.TypeOf((*MockPasskeyService)(nil).FinishRegistration), rp, user, state, response)
+}
+
+// MockTokenSigner is a mock of TokenSigner interface.
+type MockTokenSigner struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenSignerMockRecorder
+	isgomock struct{}
+}
+
+// MockTokenSignerMockRecorder is the mock recorder for MockTokenSigner.
+type MockTokenSignerMockRecorder struct {
+	mock *MockTokenSigner
+}
+
+// NewMockTokenSigner creates a new mock instance.
+func NewMockTokenSigner(ctrl *gomock.Controller) *MockTokenSigner {
+	mock := &MockTokenSigner{ctrl: ctrl}
+	mock.recorder = &MockTokenSignerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenSigner) EXPECT() *MockTokenSignerMockRecorder {
+	return m.recorder
+}
+
+// GenerateKey mocks base method.
+func (m *MockTokenSigner) GenerateKey() (*types.SigningKeyMaterial, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateKey")
+	ret0, _ := ret[0].(*types.SigningKeyMaterial)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateKey indicates an expected call of GenerateKey.
+func (mr *MockTokenSignerMockRecorder) GenerateKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateKey", reflect.TypeOf((*MockTokenSigner)(nil).GenerateKey))
+}
+
+// Sign mocks base method.
+func (m *MockTokenSigner) Sign(keyID string, privateKey []byte, claims *types.AccessTokenClaims) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sign", keyID, privateKey, claims)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sign indicates an expected call of Sign.
+func (mr *MockTokenSignerMockRecorder) Sign(keyID, privateKey, claims any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sign", reflect.TypeOf((*MockTokenSigner)(nil).Sign), keyID, privateKey, claims)
+}
+
+// SignIDToken mocks base method.
+func (m *MockTokenSigner) SignIDToken(keyID string, privateKey []byte, claims *types.IDTokenClaims) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignIDToken", keyID, privateKey, claims)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignIDToken indicates an expected call of SignIDToken.
+func (mr *MockTokenSignerMockRecorder) SignIDToken(keyID, privateKey, claims any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIDToken", reflect.TypeOf((*MockTokenSigner)(nil).SignIDToken), keyID, privateKey, claims)
+}
+
+// Verify mocks base method.
+func (m *MockTokenSigner) Verify(token string, publicKeys []json.RawMessage) (*types.AccessTokenClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", token, publicKeys)
+	ret0, _ := ret[0].(*types.AccessTokenClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockTokenSignerMockRecorder) Verify(token, publicKeys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockTokenSigner)(nil).Verify), token, publicKeys)
+}
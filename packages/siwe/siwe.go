@@ -0,0 +1,234 @@
+// Package siwe parses Sign-In With Ethereum (EIP-4361) messages and verifies
+// their personal_sign signatures.
+package siwe
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/sha3"
+)
+
+const headerSuffix = " wants you to sign in with your Ethereum account:"
+
+var (
+	ErrInvalidAddress   = errors.New("invalid ethereum address")
+	ErrInvalidMessage   = errors.New("invalid sign-in message")
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+// Message is a parsed EIP-4361 message
+type Message struct {
+	Domain         string
+	Address        string
+	Statement      string
+	URI            string
+	Version        string
+	ChainID        int64
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	RequestID      string
+	Resources      []string
+}
+
+// ParseMessage parses the text a wallet was asked to sign
+func ParseMessage(raw string) (*Message, error) {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+	if len(lines) < 2 || !strings.HasSuffix(lines[0], headerSuffix) {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidMessage)
+	}
+
+	msg := &Message{Domain: strings.TrimSuffix(lines[0], headerSuffix)}
+	if msg.Domain == "" {
+		return nil, fmt.Errorf("%w: missing domain", ErrInvalidMessage)
+	}
+	address, err := ChecksumAddress(lines[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+	}
+	msg.Address = address
+
+	// The optional statement sits between the address and the first field, padded by blank lines
+	i := 2
+	var statement []string
+	for ; i < len(lines) && !strings.HasPrefix(lines[i], "URI: "); i++ {
+		if lines[i] != "" {
+			statement = append(statement, lines[i])
+		}
+	}
+	msg.Statement = strings.Join(statement, "\n")
+
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if line == "" {
+			continue
+		}
+		if line == "Resources:" {
+			for i++; i < len(lines) && strings.HasPrefix(lines[i], "- "); i++ {
+				msg.Resources = append(msg.Resources, strings.TrimPrefix(lines[i], "- "))
+			}
+			i--
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			return nil, fmt.Errorf("%w: unexpected line %q", ErrInvalidMessage, line)
+		}
+		if err := msg.setField(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case msg.URI == "":
+		return nil, fmt.Errorf("%w: missing URI", ErrInvalidMessage)
+	case msg.Version != "1":
+		return nil, fmt.Errorf("%w: unsupported version %q", ErrInvalidMessage, msg.Version)
+	case msg.ChainID == 0:
+		return nil, fmt.Errorf("%w: missing chain ID", ErrInvalidMessage)
+	case len(msg.Nonce) < 8:
+		return nil, fmt.Errorf("%w: nonce must be at least 8 characters", ErrInvalidMessage)
+	case msg.IssuedAt.IsZero():
+		return nil, fmt.Errorf("%w: missing issued at", ErrInvalidMessage)
+	}
+	return msg, nil
+}
+
+func (m *Message) setField(key, value string) error {
+	var err error
+	switch key {
+	case "URI":
+		m.URI = value
+	case "Version":
+		m.Version = value
+	case "Chain ID":
+		m.ChainID, err = strconv.ParseInt(value, 10, 64)
+	case "Nonce":
+		m.Nonce = value
+	case "Issued At":
+		m.IssuedAt, err = time.Parse(time.RFC3339, value)
+	case "Expiration Time":
+		var t time.Time
+		t, err = time.Parse(time.RFC3339, value)
+		m.ExpirationTime = &t
+	case "Not Before":
+		var t time.Time
+		t, err = time.Parse(time.RFC3339, value)
+		m.NotBefore = &t
+	case "Request ID":
+		m.RequestID = value
+	default:
+		return fmt.Errorf("%w: unknown field %q", ErrInvalidMessage, key)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: invalid %s: %v", ErrInvalidMessage, key, err)
+	}
+	return nil
+}
+
+// ValidAt reports whether the message is inside its validity window at the given time
+func (m *Message) ValidAt(now time.Time) bool {
+	if m.ExpirationTime != nil && !now.Before(*m.ExpirationTime) {
+		return false
+	}
+	if m.NotBefore != nil && now.Before(*m.NotBefore) {
+		return false
+	}
+	return true
+}
+
+// ChecksumAddress validates a hex address and returns its EIP-55 checksummed form.
+// Mixed-case input must already carry a correct checksum.
+func ChecksumAddress(address string) (string, error) {
+	address = strings.TrimSpace(address)
+	if len(address) != 42 || !strings.HasPrefix(address, "0x") {
+		return "", ErrInvalidAddress
+	}
+	hexPart := address[2:]
+	lower := strings.ToLower(hexPart)
+	if _, err := hex.DecodeString(lower); err != nil {
+		return "", ErrInvalidAddress
+	}
+
+	hash := keccak256([]byte(lower))
+	checksummed := []byte(lower)
+	for i, c := range checksummed {
+		if c < 'a' {
+			continue
+		}
+		nibble := hash[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if nibble&0x0f >= 8 {
+			checksummed[i] = c - 'a' + 'A'
+		}
+	}
+
+	result := "0x" + string(checksummed)
+	if hexPart != lower && hexPart != strings.ToUpper(hexPart) && address != result {
+		return "", ErrInvalidAddress
+	}
+	return result, nil
+}
+
+// VerifySignature checks that the personal_sign signature over message was made by address
+func VerifySignature(message, signature, address string) error {
+	sig, err := hex.DecodeString(strings.TrimPrefix(signature, "0x"))
+	if err != nil || len(sig) != 65 {
+		return ErrInvalidSignature
+	}
+
+	// Wallets encode the recovery ID as 0/1 or, more commonly, 27/28
+	recoveryID := sig[64]
+	if recoveryID >= 27 {
+		recoveryID -= 27
+	}
+	if recoveryID > 1 {
+		return ErrInvalidSignature
+	}
+
+	compact := make([]byte, 65)
+	compact[0] = 27 + recoveryID
+	copy(compact[1:], sig[:64])
+
+	pubKey, _, err := ecdsa.RecoverCompact(compact, personalMessageHash(message))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	recovered, err := ChecksumAddress("0x" + hex.EncodeToString(keccak256(pubKey.SerializeUncompressed()[1:])[12:]))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	expected, err := ChecksumAddress(address)
+	if err != nil {
+		return err
+	}
+	if recovered != expected {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// personalMessageHash is the EIP-191 digest wallets sign for personal_sign
+func personalMessageHash(message string) []byte {
+	prefix := "\x19Ethereum Signed Message:\n" + strconv.Itoa(len(message))
+	return keccak256([]byte(prefix), []byte(message))
+}
+
+func keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
@@ -0,0 +1,126 @@
+package siwe
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecksumAddress(t *testing.T) {
+	// Vectors from EIP-55
+	vectors := []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	}
+	for _, want := range vectors {
+		got, err := ChecksumAddress(want)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+
+		got, err = ChecksumAddress("0x" + lower(want[2:]))
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	_, err := ChecksumAddress("0x5AAeb6053F3E94C9b9A09f33669435E7Ef1BeAed") // wrong checksum
+	assert.ErrorIs(t, err, ErrInvalidAddress)
+	_, err = ChecksumAddress("0x1234")
+	assert.ErrorIs(t, err, ErrInvalidAddress)
+	_, err = ChecksumAddress("0xZZaeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	assert.ErrorIs(t, err, ErrInvalidAddress)
+}
+
+func lower(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if c >= 'A' && c <= 'F' {
+			b[i] = c + 'a' - 'A'
+		}
+	}
+	return string(b)
+}
+
+func testMessage(address string) string {
+	return fmt.Sprintf(`app.example.com wants you to sign in with your Ethereum account:
+%s
+
+Sign in to Example
+
+URI: https://app.example.com/login
+Version: 1
+Chain ID: 1
+Nonce: 3b2c1f0a9d8e7f6a
+Issued At: 2025-01-02T03:04:05Z
+Expiration Time: 2025-01-02T03:14:05Z
+Resources:
+- https://app.example.com/terms`, address)
+}
+
+func TestParseMessage(t *testing.T) {
+	msg, err := ParseMessage(testMessage("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"))
+	require.NoError(t, err)
+	assert.Equal(t, "app.example.com", msg.Domain)
+	assert.Equal(t, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", msg.Address)
+	assert.Equal(t, "Sign in to Example", msg.Statement)
+	assert.Equal(t, "https://app.example.com/login", msg.URI)
+	assert.Equal(t, int64(1), msg.ChainID)
+	assert.Equal(t, "3b2c1f0a9d8e7f6a", msg.Nonce)
+	assert.Equal(t, []string{"https://app.example.com/terms"}, msg.Resources)
+	require.NotNil(t, msg.ExpirationTime)
+
+	assert.True(t, msg.ValidAt(msg.IssuedAt.Add(time.Minute)))
+	assert.False(t, msg.ValidAt(msg.ExpirationTime.Add(time.Second)))
+}
+
+func TestParseMessage_Invalid(t *testing.T) {
+	_, err := ParseMessage("hello world")
+	assert.ErrorIs(t, err, ErrInvalidMessage)
+
+	withoutNonce := `app.example.com wants you to sign in with your Ethereum account:
+0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed
+
+
+URI: https://app.example.com
+Version: 1
+Chain ID: 1
+Issued At: 2025-01-02T03:04:05Z`
+	_, err = ParseMessage(withoutNonce)
+	assert.ErrorIs(t, err, ErrInvalidMessage)
+}
+
+// sign produces a personal_sign signature in the r || s || v layout wallets return
+func sign(t *testing.T, key *secp256k1.PrivateKey, message string) string {
+	t.Helper()
+	compact := ecdsa.SignCompact(key, personalMessageHash(message), false)
+	sig := append(compact[1:], compact[0]) //nolint:gocritic
+	return "0x" + hex.EncodeToString(sig)
+}
+
+func addressOf(key *secp256k1.PrivateKey) string {
+	addr, _ := ChecksumAddress("0x" + hex.EncodeToString(keccak256(key.PubKey().SerializeUncompressed()[1:])[12:]))
+	return addr
+}
+
+func TestVerifySignature(t *testing.T) {
+	key, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	address := addressOf(key)
+	message := testMessage(address)
+	signature := sign(t, key, message)
+
+	assert.NoError(t, VerifySignature(message, signature, address))
+
+	other, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	assert.ErrorIs(t, VerifySignature(message, signature, addressOf(other)), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifySignature(message+" ", signature, address), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifySignature(message, "0x1234", address), ErrInvalidSignature)
+}
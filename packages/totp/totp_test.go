@@ -0,0 +1,79 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA-1 seed of the RFC 6238 appendix B test vectors
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestCode_RFC6238Vectors(t *testing.T) {
+	// The RFC lists 8-digit codes; 6-digit codes are their last six digits
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1111111111: "050471",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for unix, want := range vectors {
+		got, err := Code(rfcSecret, Step(time.Unix(unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, want, got, "time %d", unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	at := time.Unix(1111111111, 0)
+	step := Step(at)
+
+	previous, err := Code(rfcSecret, step-1)
+	require.NoError(t, err)
+	got, ok := Validate(rfcSecret, previous, at, 1)
+	assert.True(t, ok)
+	assert.Equal(t, step-1, got)
+
+	_, ok = Validate(rfcSecret, previous, at, 0)
+	assert.False(t, ok)
+
+	stale, err := Code(rfcSecret, step-2)
+	require.NoError(t, err)
+	_, ok = Validate(rfcSecret, stale, at, 1)
+	assert.False(t, ok)
+
+	_, ok = Validate(rfcSecret, "12345", at, 1)
+	assert.False(t, ok)
+	_, ok = Validate("not base32!", "123456", at, 1)
+	assert.False(t, ok)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret(20)
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	other, err := GenerateSecret(20)
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, other)
+
+	_, err = Code(secret, 1)
+	assert.NoError(t, err)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri, err := url.Parse(ProvisioningURI("JBSWY3DPEHPK3PXP", "Genetica", "alice@example.com"))
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/Genetica:alice@example.com", uri.Path)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", uri.Query().Get("secret"))
+	assert.Equal(t, "Genetica", uri.Query().Get("issuer"))
+	assert.Equal(t, "6", uri.Query().Get("digits"))
+	assert.Equal(t, "30", uri.Query().Get("period"))
+}
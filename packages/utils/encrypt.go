@@ -1,13 +1,25 @@
 package utils
 
 import (
+	"crypto/sha256"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
 
 	"github.com/gtank/cryptopasta"
+	"golang.org/x/crypto/hkdf"
 )
 
+// DeriveKey expands a secret into a key for one purpose with HKDF-SHA256. Keys derived for
+// different purposes are unrelated, so data protected for one cannot be opened with another.
+func DeriveKey(secret, purpose string) [32]byte {
+	var key [32]byte
+	// HKDF-SHA256 yields up to 8160 bytes, so reading one key cannot fail
+	_, _ = io.ReadFull(hkdf.New(sha256.New, []byte(secret), nil, []byte(purpose)), key[:])
+	return key
+}
+
 // Encrypt encrypts a plaintext string using the key and returns a base64 string.
 func Encrypt(key [32]byte, plaintext string) (string, error) {
 	if plaintext == "" {
@@ -73,6 +73,27 @@ func TestDecrypt_WrongKey(t *testing.T) {
 	}
 }
 
+func TestDeriveKey_SeparatesPurposes(t *testing.T) {
+	exportKey := DeriveKey("db-key", "data-export")
+	if exportKey != DeriveKey("db-key", "data-export") {
+		t.Fatalf("DeriveKey should be deterministic")
+	}
+	if exportKey == DeriveKey("db-key", "mfa-secret") {
+		t.Fatalf("keys for different purposes should differ")
+	}
+	if exportKey == DeriveKey("other-key", "data-export") {
+		t.Fatalf("keys from different secrets should differ")
+	}
+
+	ct, err := Encrypt(exportKey, "secret")
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	if _, err := Decrypt(DeriveKey("db-key", "mfa-secret"), ct); err == nil {
+		t.Fatalf("Decrypt with the key of another purpose should fail")
+	}
+}
+
 func TestDecrypt_InvalidBase64(t *testing.T) {
 	key := mustKey()
 	_, err := Decrypt(key, "not-base64!!!")